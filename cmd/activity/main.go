@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"strings"
 	"time"
@@ -10,13 +11,21 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	activityapiserver "go.miloapis.com/activity/internal/apiserver"
+	"go.miloapis.com/activity/internal/cel"
+	"go.miloapis.com/activity/internal/changesource"
+	"go.miloapis.com/activity/internal/metrics"
+	"go.miloapis.com/activity/internal/registry/activity/auditlog"
+	"go.miloapis.com/activity/internal/registry/idempotency"
+	"go.miloapis.com/activity/internal/registry/scope"
 	"go.miloapis.com/activity/internal/storage"
 	"go.miloapis.com/activity/internal/version"
 	"go.miloapis.com/activity/internal/watch"
 	"go.miloapis.com/activity/pkg/generated/openapi"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	apiopenapi "k8s.io/apiserver/pkg/endpoints/openapi"
 	genericapiserver "k8s.io/apiserver/pkg/server"
+	genericfilters "k8s.io/apiserver/pkg/server/filters"
 	"k8s.io/apiserver/pkg/server/options"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	"k8s.io/component-base/cli"
@@ -60,6 +69,7 @@ AuditLogQuery resources accessible through kubectl or any Kubernetes client.`,
 	cmd.AddCommand(NewProcessorCommand())
 	cmd.AddCommand(NewEventExporterCommand())
 	cmd.AddCommand(NewReindexWorkerCommand())
+	cmd.AddCommand(NewTailActivitiesCommand())
 	cmd.AddCommand(NewVersionCommand())
 	cmd.AddCommand(NewMCPCommand())
 
@@ -127,6 +137,7 @@ type ActivityServerOptions struct {
 
 	ClickHouseAddress  string
 	ClickHouseDatabase string
+	ClickHouseCluster  string
 	ClickHouseUsername string
 	ClickHousePassword string
 
@@ -139,6 +150,34 @@ type ActivityServerOptions struct {
 	MaxQueryWindow time.Duration // Maximum time range allowed for queries
 	MaxPageSize    int32         // Maximum number of results per page
 
+	MaxQueryTimeout time.Duration // Maximum spec.timeoutSeconds a query may request
+
+	// ClickHouse query concurrency limits
+	ClickHouseMaxConcurrentQueries int
+	ClickHouseQueryQueueTimeout    time.Duration
+
+	EnableCELJSONExtract bool // Allow jsonExtract() in audit log filters
+	MaxCELFilterNodes    int  // Cap on the size of a filter expression
+
+	MaxFacetDistinctValues int // Cap on distinct values a facet query may aggregate
+
+	// Per-query ClickHouse limits keyed by tenant type
+	TenantMaxMemoryUsage   map[string]string
+	TenantMaxExecutionTime map[string]string
+
+	ClickHouseInjectTraceComment bool // Prefix queries with a traceparent SQL comment
+
+	// How far back each table retains data, matching the ClickHouse TTLs
+	AuditLogRetentionWindow time.Duration
+	ActivityRetentionWindow time.Duration
+	EventRetentionWindow    time.Duration
+
+	// OTLP export of the ClickHouse query metrics
+	OTelMetricsEnabled        bool
+	OTelMetricsEndpoint       string
+	OTelMetricsInsecure       bool
+	OTelMetricsExportInterval time.Duration
+
 	// NATS configuration for activities watch
 	ActivitiesNATSURL           string
 	ActivitiesNATSStream        string
@@ -156,6 +195,24 @@ type ActivityServerOptions struct {
 	EventsNATSTLSCertFile   string
 	EventsNATSTLSKeyFile    string
 	EventsNATSTLSCAFile     string
+
+	// Scope of requests whose token carries no tenant extras
+	DefaultScope string
+
+	// Redaction of sensitive audit event bodies in query results
+	RedactedResources     []string
+	RedactionExemptScopes []string
+	RedactionRulesConfig  string
+
+	// Caching of query results for retries that send an Idempotency-Key
+	QueryIdempotencyWindow     time.Duration
+	QueryIdempotencyMaxEntries int
+
+	// Startup check that queries select their ClickHouse projections
+	VerifyProjections bool
+
+	// Human/system classification of actors
+	SystemUsersConfig string
 }
 
 // NewActivityServerOptions creates options with default values.
@@ -172,8 +229,29 @@ func NewActivityServerOptions() *ActivityServerOptions {
 		ClickHousePassword: "",
 		MaxQueryWindow:     30 * 24 * time.Hour,
 		MaxPageSize:        1000,
+		MaxQueryTimeout:    storage.DefaultMaxQueryTimeout,
+
+		MaxFacetDistinctValues: storage.DefaultMaxFacetGroupByRows,
+		MaxCELFilterNodes:      cel.DefaultMaxFilterNodes,
+
+		DefaultScope: scope.DefaultScopePlatform,
+
+		ClickHouseQueryQueueTimeout:  5 * time.Second,
+		ClickHouseInjectTraceComment: true,
+
+		ActivityRetentionWindow: storage.DefaultActivityRetentionWindow,
+		EventRetentionWindow:    storage.DefaultEventRetentionWindow,
+
+		OTelMetricsExportInterval: time.Minute,
+
+		QueryIdempotencyWindow:     2 * time.Minute,
+		QueryIdempotencyMaxEntries: 256,
 	}
 
+	redaction := auditlog.DefaultRedactionConfig()
+	o.RedactedResources = redaction.Resources
+	o.RedactionExemptScopes = redaction.ExemptScopes
+
 	// Disable admission plugins since this server doesn't mutate or validate resources.
 	o.RecommendedOptions.Admission = nil
 
@@ -187,6 +265,8 @@ func (o *ActivityServerOptions) AddFlags(fs *pflag.FlagSet) {
 		"ClickHouse server address (host:port)")
 	fs.StringVar(&o.ClickHouseDatabase, "clickhouse-database", o.ClickHouseDatabase,
 		"Database containing audit log data")
+	fs.StringVar(&o.ClickHouseCluster, "clickhouse-cluster", o.ClickHouseCluster,
+		"Name of a sharded ClickHouse cluster; when set, queries go through the <table>_distributed tables so counts and facets cover every shard")
 	fs.StringVar(&o.ClickHouseUsername, "clickhouse-username", o.ClickHouseUsername,
 		"Username for ClickHouse authentication")
 	fs.StringVar(&o.ClickHousePassword, "clickhouse-password", o.ClickHousePassword,
@@ -205,6 +285,40 @@ func (o *ActivityServerOptions) AddFlags(fs *pflag.FlagSet) {
 		"Maximum time range for a single query (e.g., 720h for 30 days)")
 	fs.Int32Var(&o.MaxPageSize, "max-page-size", o.MaxPageSize,
 		"Maximum results returned per page")
+	fs.DurationVar(&o.MaxQueryTimeout, "max-query-timeout", o.MaxQueryTimeout,
+		"Maximum spec.timeoutSeconds a query may request. Raising it above 60s also extends the apiserver request timeout to match.")
+
+	fs.IntVar(&o.ClickHouseMaxConcurrentQueries, "clickhouse-max-concurrent-queries", o.ClickHouseMaxConcurrentQueries,
+		"Maximum ClickHouse queries this server runs at once, regardless of apiserver in-flight limits. Zero means unlimited.")
+	fs.DurationVar(&o.ClickHouseQueryQueueTimeout, "clickhouse-query-queue-timeout", o.ClickHouseQueryQueueTimeout,
+		"How long a query waits for a free slot when --clickhouse-max-concurrent-queries is reached before failing with 503")
+	fs.BoolVar(&o.EnableCELJSONExtract, "enable-cel-json-extract", o.EnableCELJSONExtract,
+		"Allow jsonExtract() in audit log filters to match fields that aren't materialized as columns. These queries read the raw event JSON and can't use indexes or projections.")
+	fs.IntVar(&o.MaxCELFilterNodes, "max-cel-filter-nodes", o.MaxCELFilterNodes,
+		"Maximum number of expression nodes in a query filter (each comparison such as verb == 'get' is three). Larger filters are rejected before they are compiled into SQL. Zero means unlimited.")
+	fs.IntVar(&o.MaxFacetDistinctValues, "max-facet-distinct-values", o.MaxFacetDistinctValues,
+		"Maximum distinct values a single facet query may aggregate. Facets on fields with more values in the queried range fail and ask the caller to add a filter. Zero means unlimited.")
+	fs.StringToStringVar(&o.TenantMaxMemoryUsage, "tenant-max-memory-usage", o.TenantMaxMemoryUsage,
+		"ClickHouse max_memory_usage for each query, by the tenant type it runs for (platform, Organization, Project or User), e.g. Project=1Gi,Organization=4Gi. Tenant types not listed use the server default.")
+	fs.StringToStringVar(&o.TenantMaxExecutionTime, "tenant-max-execution-time", o.TenantMaxExecutionTime,
+		"ClickHouse max_execution_time for each query, by tenant type, e.g. Project=20s. Only shortens a query's own timeout. Tenant types not listed are limited by the query timeout alone.")
+	fs.DurationVar(&o.AuditLogRetentionWindow, "audit-log-retention-window", o.AuditLogRetentionWindow,
+		"How far back audit logs are retained. Queries starting earlier are clamped and warned that older data has aged out. Zero means audit logs are kept indefinitely.")
+	fs.DurationVar(&o.ActivityRetentionWindow, "activity-retention-window", o.ActivityRetentionWindow,
+		"How far back activities are retained, matching the activities table TTL. Zero means no retention limit.")
+	fs.DurationVar(&o.EventRetentionWindow, "event-retention-window", o.EventRetentionWindow,
+		"How far back Kubernetes events are retained, matching the k8s_events table TTL. Zero means no retention limit.")
+	fs.BoolVar(&o.ClickHouseInjectTraceComment, "clickhouse-inject-trace-comment", o.ClickHouseInjectTraceComment,
+		"Prefix each ClickHouse query with a /* traceparent: ... */ comment for correlating system.query_log with traces. Spans and metrics still carry the trace when disabled.")
+
+	fs.BoolVar(&o.OTelMetricsEnabled, "otel-metrics-enabled", o.OTelMetricsEnabled,
+		"Also export ClickHouse query metrics (duration, count, errors) over OTLP. Prometheus /metrics is unaffected.")
+	fs.StringVar(&o.OTelMetricsEndpoint, "otel-metrics-endpoint", o.OTelMetricsEndpoint,
+		"OTLP gRPC collector address (host:port). Defaults to OTEL_EXPORTER_OTLP_ENDPOINT or localhost:4317.")
+	fs.BoolVar(&o.OTelMetricsInsecure, "otel-metrics-insecure", o.OTelMetricsInsecure,
+		"Disable TLS for the OTLP collector connection")
+	fs.DurationVar(&o.OTelMetricsExportInterval, "otel-metrics-export-interval", o.OTelMetricsExportInterval,
+		"How often metrics are pushed to the OTLP collector")
 
 	// Activities NATS watch configuration
 	fs.StringVar(&o.ActivitiesNATSURL, "activities-nats-url", o.ActivitiesNATSURL,
@@ -237,6 +351,26 @@ func (o *ActivityServerOptions) AddFlags(fs *pflag.FlagSet) {
 		"Path to client private key file for Events NATS TLS")
 	fs.StringVar(&o.EventsNATSTLSCAFile, "events-nats-tls-ca-file", o.EventsNATSTLSCAFile,
 		"Path to CA certificate file for Events NATS TLS")
+
+	fs.StringVar(&o.DefaultScope, "default-scope", o.DefaultScope,
+		"Scope of requests whose token has no parent-type/parent-name extras: platform (see every tenant's data) or deny (see nothing). Defaults to platform for compatibility; deny is recommended so a token that lost its scope can't read platform-wide.")
+	fs.StringSliceVar(&o.RedactedResources, "redacted-resources", o.RedactedResources,
+		"Resources (resource.group) whose request and response bodies are redacted from audit log query results. Set to an empty value to disable redaction.")
+	fs.StringSliceVar(&o.RedactionExemptScopes, "redaction-exempt-scopes", o.RedactionExemptScopes,
+		"Scope types (platform, organization, project, user) that receive unredacted audit event bodies")
+	fs.StringVar(&o.RedactionRulesConfig, "redaction-rules-config", o.RedactionRulesConfig,
+		"Path to a YAML file of rules that null out fields (e.g. spec.data.password) of matching audit event bodies in query results")
+
+	fs.DurationVar(&o.QueryIdempotencyWindow, "query-idempotency-window", o.QueryIdempotencyWindow,
+		"How long a query result is kept for retries that repeat its Idempotency-Key header. Set to 0 to disable.")
+	fs.IntVar(&o.QueryIdempotencyMaxEntries, "query-idempotency-max-entries", o.QueryIdempotencyMaxEntries,
+		"Maximum number of query results kept for Idempotency-Key retries; the oldest are evicted first")
+
+	fs.BoolVar(&o.VerifyProjections, "verify-projections", o.VerifyProjections,
+		"At startup, EXPLAIN a representative query for each query pattern and log which ClickHouse projection it selects, warning when one is missed. Not supported with --clickhouse-cluster.")
+
+	fs.StringVar(&o.SystemUsersConfig, "system-users-config", o.SystemUsersConfig,
+		"YAML or JSON file listing the usernames treated as system actors (prefixes and regular expression patterns), typically mounted from a ConfigMap. Empty uses the built-in rules: the system: prefix and usernames containing serviceaccount or controller.")
 }
 
 func (o *ActivityServerOptions) Complete() error {
@@ -253,6 +387,21 @@ func (o *ActivityServerOptions) Validate() error {
 	if o.ClickHouseDatabase == "" {
 		errors = append(errors, fmt.Errorf("--clickhouse-database is required"))
 	}
+	if o.MaxQueryTimeout < time.Second {
+		errors = append(errors, fmt.Errorf("--max-query-timeout must be at least 1s"))
+	}
+	if o.MaxFacetDistinctValues < 0 {
+		errors = append(errors, fmt.Errorf("--max-facet-distinct-values must not be negative"))
+	}
+	if o.MaxCELFilterNodes < 0 {
+		errors = append(errors, fmt.Errorf("--max-cel-filter-nodes must not be negative"))
+	}
+	if o.DefaultScope != scope.DefaultScopePlatform && o.DefaultScope != scope.DefaultScopeDeny {
+		errors = append(errors, fmt.Errorf("--default-scope must be %s or %s", scope.DefaultScopePlatform, scope.DefaultScopeDeny))
+	}
+	if _, err := storage.ParseTenantQueryLimits(o.TenantMaxMemoryUsage, o.TenantMaxExecutionTime); err != nil {
+		errors = append(errors, fmt.Errorf("--tenant-max-memory-usage/--tenant-max-execution-time: %w", err))
+	}
 
 	if len(errors) > 0 {
 		return fmt.Errorf("validation errors: %v", errors)
@@ -326,16 +475,48 @@ func (o *ActivityServerOptions) Config() (*activityapiserver.Config, error) {
 		return spec.MustCreateRef("#/definitions/" + openapicommon.EscapeJsonPointer(defName))
 	})
 
+	// Apply scope override headers after authentication and authorization so
+	// RBAC always sees the caller's real identity.
+	genericConfig.BuildHandlerChainFunc = func(apiHandler http.Handler, c *genericapiserver.Config) http.Handler {
+		handler := scope.WithScopeOverride(apiHandler, activityapiserver.Codecs)
+		handler = idempotency.WithIdempotencyKey(handler, activityapiserver.Codecs)
+		return genericapiserver.DefaultBuildHandlerChain(handler, c)
+	}
+
 	if err := o.RecommendedOptions.ApplyTo(genericConfig); err != nil {
 		return nil, fmt.Errorf("failed to apply recommended options: %w", err)
 	}
 
+	// Give the longest allowed query time to finish before the apiserver
+	// times out the request around it.
+	if o.MaxQueryTimeout > genericConfig.RequestTimeout {
+		genericConfig.RequestTimeout = o.MaxQueryTimeout
+	}
+
+	// Exports stream for as long as their query runs, which the export bounds
+	// itself, so they are exempt from the request timeout like watches.
+	genericConfig.LongRunningFunc = genericfilters.BasicLongRunningRequestCheck(
+		sets.NewString("watch"),
+		sets.NewString(auditlog.ExportSubresource),
+	)
+
+	redactionRules, err := auditlog.LoadFieldRedactionRules(o.RedactionRulesConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	tenantQueryLimits, err := storage.ParseTenantQueryLimits(o.TenantMaxMemoryUsage, o.TenantMaxExecutionTime)
+	if err != nil {
+		return nil, err
+	}
+
 	serverConfig := &activityapiserver.Config{
 		GenericConfig: genericConfig,
 		ExtraConfig: activityapiserver.ExtraConfig{
 			ClickHouseConfig: storage.ClickHouseConfig{
 				Address:        o.ClickHouseAddress,
 				Database:       o.ClickHouseDatabase,
+				Cluster:        o.ClickHouseCluster,
 				Username:       o.ClickHouseUsername,
 				Password:       o.ClickHousePassword,
 				TLSEnabled:     o.ClickHouseTLSEnabled,
@@ -344,6 +525,20 @@ func (o *ActivityServerOptions) Config() (*activityapiserver.Config, error) {
 				TLSCAFile:      o.ClickHouseTLSCAFile,
 				MaxQueryWindow: o.MaxQueryWindow,
 				MaxPageSize:    o.MaxPageSize,
+
+				MaxQueryTimeout: o.MaxQueryTimeout,
+
+				MaxConcurrentQueries: o.ClickHouseMaxConcurrentQueries,
+				QueryQueueTimeout:    o.ClickHouseQueryQueueTimeout,
+
+				EnableJSONExtract:   o.EnableCELJSONExtract,
+				InjectTraceComment:  o.ClickHouseInjectTraceComment,
+				MaxFacetGroupByRows: o.MaxFacetDistinctValues,
+				TenantQueryLimits:   tenantQueryLimits,
+
+				AuditLogRetentionWindow: o.AuditLogRetentionWindow,
+				ActivityRetentionWindow: o.ActivityRetentionWindow,
+				EventRetentionWindow:    o.EventRetentionWindow,
 			},
 			NATSConfig: watch.NATSConfig{
 				URL:           o.ActivitiesNATSURL,
@@ -363,6 +558,14 @@ func (o *ActivityServerOptions) Config() (*activityapiserver.Config, error) {
 				TLSKeyFile:    o.EventsNATSTLSKeyFile,
 				TLSCAFile:     o.EventsNATSTLSCAFile,
 			},
+			AuditRedaction: auditlog.RedactionConfig{
+				Resources:    o.RedactedResources,
+				ExemptScopes: o.RedactionExemptScopes,
+				FieldRules:   redactionRules,
+			},
+			QueryIdempotencyWindow:     o.QueryIdempotencyWindow,
+			QueryIdempotencyMaxEntries: o.QueryIdempotencyMaxEntries,
+			VerifyProjections:          o.VerifyProjections,
 		},
 	}
 
@@ -375,11 +578,39 @@ func Run(options *ActivityServerOptions, ctx context.Context) error {
 		return fmt.Errorf("failed to apply logging configuration: %w", err)
 	}
 
+	if err := changesource.LoadDefault(options.SystemUsersConfig); err != nil {
+		return err
+	}
+
+	cel.SetMaxFilterNodes(options.MaxCELFilterNodes)
+	if err := scope.SetDefaultScope(options.DefaultScope); err != nil {
+		return err
+	}
+
 	config, err := options.Config()
 	if err != nil {
 		return err
 	}
 
+	if options.OTelMetricsEnabled {
+		shutdown, err := metrics.EnableOTelExport(ctx, metrics.OTelOptions{
+			Endpoint:       options.OTelMetricsEndpoint,
+			Insecure:       options.OTelMetricsInsecure,
+			ExportInterval: options.OTelMetricsExportInterval,
+		})
+		if err != nil {
+			return err
+		}
+		defer func() {
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := shutdown(shutdownCtx); err != nil {
+				klog.ErrorS(err, "Failed to flush OTLP metrics")
+			}
+		}()
+		klog.Info("Exporting ClickHouse query metrics over OTLP")
+	}
+
 	server, err := config.Complete().New()
 	if err != nil {
 		return err
@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
+	"go.miloapis.com/activity/internal/changesource"
 	"go.miloapis.com/activity/internal/version"
 	"go.miloapis.com/activity/pkg/mcp/tools"
 )
@@ -19,12 +20,22 @@ type MCPServerOptions struct {
 	Kubeconfig string
 	Context    string
 	Namespace  string
+
+	// Compact emits unindented JSON tool results to save context tokens
+	Compact bool
+
+	// MaxTimelineBuckets caps get_activity_timeline buckets
+	MaxTimelineBuckets int
+
+	// Human/system classification of actors; should match the processor
+	SystemUsersConfig string
 }
 
 // NewMCPServerOptions creates options with default values.
 func NewMCPServerOptions() *MCPServerOptions {
 	return &MCPServerOptions{
-		Namespace: "default",
+		Namespace:          "default",
+		MaxTimelineBuckets: 500,
 	}
 }
 
@@ -36,6 +47,12 @@ func (o *MCPServerOptions) AddFlags(fs *pflag.FlagSet) {
 		"Kubeconfig context to use. If not set, uses the current context")
 	fs.StringVar(&o.Namespace, "namespace", o.Namespace,
 		"Namespace for namespaced resources like Activities (default: 'default')")
+	fs.BoolVar(&o.Compact, "compact", o.Compact,
+		"Return tool results as unindented JSON, which uses fewer tokens of the assistant's context window for large results")
+	fs.IntVar(&o.MaxTimelineBuckets, "max-timeline-buckets", o.MaxTimelineBuckets,
+		"Maximum number of buckets get_activity_timeline returns. Auto bucket sizing picks the finest of hour, day and week that fits, and explicit bucket sizes that exceed it are rejected")
+	fs.StringVar(&o.SystemUsersConfig, "system-users-config", o.SystemUsersConfig,
+		"YAML or JSON file listing the usernames treated as system actors (prefixes and regular expression patterns), typically mounted from a ConfigMap. Empty uses the built-in rules: the system: prefix and usernames containing serviceaccount or controller. Use the same file as the processor so system actors are filtered consistently.")
 }
 
 // NewMCPCommand creates the mcp subcommand that starts the MCP server.
@@ -68,11 +85,13 @@ Available tools:
     - find_failed_operations: Find operations that failed (4xx/5xx)
     - get_resource_history: Get change history for a specific resource
     - get_user_activity_summary: Get a user's recent actions
+    - get_actor_blast_radius: Every resource a user touched, grouped by namespace
 
   Analytics Tools:
     - get_activity_timeline: Activity counts grouped by time buckets
     - summarize_recent_activity: Summary with top actors and resources
     - compare_activity_periods: Compare activity between time periods
+    - get_change_velocity: Is the current rate of changes unusual?
 
   Policy Tools:
     - list_activity_policies: List configured ActivityPolicies
@@ -100,11 +119,18 @@ Example configuration for Claude Desktop (claude_desktop_config.json):
 
 // RunMCPServer starts the MCP server with the given options.
 func RunMCPServer(options *MCPServerOptions) error {
+	if err := changesource.LoadDefault(options.SystemUsersConfig); err != nil {
+		return err
+	}
+
 	// Create tool provider
 	cfg := tools.Config{
 		Kubeconfig: options.Kubeconfig,
 		Context:    options.Context,
 		Namespace:  options.Namespace,
+		Compact:    options.Compact,
+
+		MaxTimelineBuckets: options.MaxTimelineBuckets,
 	}
 
 	provider, err := tools.NewToolProvider(cfg)
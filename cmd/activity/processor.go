@@ -2,10 +2,12 @@ package main
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/labels"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -14,6 +16,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"go.miloapis.com/activity/internal/activityprocessor"
+	"go.miloapis.com/activity/internal/changesource"
 )
 
 // ProcessorOptions contains configuration for the activity processor.
@@ -26,6 +29,7 @@ type ProcessorOptions struct {
 	NATSURL        string
 	NATSStreamName string
 	ConsumerName   string
+	ConsumerGroup  string
 
 	// NATS event stream configuration
 	NATSEventStream   string
@@ -34,6 +38,7 @@ type ProcessorOptions struct {
 	// Output NATS stream
 	OutputStreamName    string
 	OutputSubjectPrefix string
+	AlertSubjectPrefix  string
 
 	// NATS TLS/mTLS configuration
 	NATSTLSEnabled  bool
@@ -58,13 +63,37 @@ type ProcessorOptions struct {
 	DLQRetryEventSubject      string
 
 	// Processing configuration
-	Workers   int
-	BatchSize int
-	AckWait   time.Duration
+	Workers      int
+	BatchSize    int
+	FetchMaxWait time.Duration
+	AckWait      time.Duration
+
+	// Batched publishing
+	AsyncPublish        bool
+	PublishMaxPending   int
+	PublishFlushTimeout time.Duration
 
 	// Health probe configuration
 	HealthProbeAddr string
 
+	// Kubernetes cache configuration
+	PolicySelector string
+	ResyncPeriod   time.Duration
+
+	// Actor enrichment
+	ActorDirectoryURL string
+	ActorCacheTTL     time.Duration
+
+	// Activity deduplication
+	DedupWindow     time.Duration
+	DedupMaxEntries int
+
+	// Summary truncation
+	MaxSummaryLength int
+
+	// Human/system classification of actors
+	SystemUsersConfig string
+
 	Logs *logsapi.LoggingConfiguration
 }
 
@@ -93,8 +122,14 @@ func NewProcessorOptions() *ProcessorOptions {
 		DLQRetryEventSubject:      "events.retry",
 		Workers:                   4,
 		BatchSize:            100,
+		FetchMaxWait:         5 * time.Second,
 		AckWait:              30 * time.Second,
+		PublishMaxPending:    256,
+		PublishFlushTimeout:  5 * time.Second,
 		HealthProbeAddr:      ":8081",
+		ActorCacheTTL:        15 * time.Minute,
+		DedupMaxEntries:      10000,
+		MaxSummaryLength:     2048,
 	}
 }
 
@@ -112,15 +147,19 @@ func (o *ProcessorOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.NATSStreamName, "nats-stream", o.NATSStreamName,
 		"NATS JetStream stream name for audit events.")
 	fs.StringVar(&o.ConsumerName, "consumer-name", o.ConsumerName,
-		"Durable consumer name for the audit log processor.")
+		"Durable consumer name for the audit log processor. {group} is replaced by --consumer-group.")
+	fs.StringVar(&o.ConsumerGroup, "consumer-group", o.ConsumerGroup,
+		"Identifier substituted for {group} in --consumer-name and --nats-event-consumer, e.g. canary. Deployments with different groups read through separate durables and each see every message; replicas sharing a durable split the messages between them.")
 	fs.StringVar(&o.NATSEventStream, "nats-event-stream", o.NATSEventStream,
 		"NATS JetStream stream name for Kubernetes events.")
 	fs.StringVar(&o.NATSEventConsumer, "nats-event-consumer", o.NATSEventConsumer,
-		"Durable consumer name for the event processor.")
+		"Durable consumer name for the event processor. {group} is replaced by --consumer-group.")
 	fs.StringVar(&o.OutputStreamName, "output-stream", o.OutputStreamName,
 		"NATS JetStream stream name for generated activities.")
 	fs.StringVar(&o.OutputSubjectPrefix, "output-subject-prefix", o.OutputSubjectPrefix,
 		"Subject prefix for published activities.")
+	fs.StringVar(&o.AlertSubjectPrefix, "alert-subject-prefix", o.AlertSubjectPrefix,
+		"Subject prefix for the alert copy of activities from High severity policy rules. Empty disables alert routing.")
 
 	// NATS TLS/mTLS flags
 	fs.BoolVar(&o.NATSTLSEnabled, "nats-tls-enabled", o.NATSTLSEnabled,
@@ -165,13 +204,47 @@ func (o *ProcessorOptions) AddFlags(fs *pflag.FlagSet) {
 		"Number of worker goroutines for processing.")
 	fs.IntVar(&o.BatchSize, "batch-size", o.BatchSize,
 		"Number of messages to fetch per batch.")
+	fs.DurationVar(&o.FetchMaxWait, "fetch-max-wait", o.FetchMaxWait,
+		"How long each fetch waits for a full batch before processing the messages it has. Lower values reduce latency under light load; higher values give fuller batches under bursty load.")
 	fs.DurationVar(&o.AckWait, "ack-wait", o.AckWait,
 		"Time to wait before message redelivery.")
+	fs.BoolVar(&o.AsyncPublish, "async-publish", o.AsyncPublish,
+		"Publish activities asynchronously and wait for JetStream acknowledgements once per fetched batch instead of once per activity. Messages whose activities fail to publish are NAKed for redelivery.")
+	fs.IntVar(&o.PublishMaxPending, "publish-max-pending", o.PublishMaxPending,
+		"Maximum asynchronous publishes awaiting a JetStream acknowledgement before publishing blocks. Only used with --async-publish.")
+	fs.DurationVar(&o.PublishFlushTimeout, "publish-flush-timeout", o.PublishFlushTimeout,
+		"How long to wait for a batch's publish acknowledgements before NAKing its messages. Only used with --async-publish.")
 
 	// Health probe flags
 	fs.StringVar(&o.HealthProbeAddr, "health-probe-addr", o.HealthProbeAddr,
 		"Address for health probe server (e.g., :8081). Set to empty to disable.")
 
+	// Kubernetes cache flags
+	fs.StringVar(&o.PolicySelector, "policy-selector", o.PolicySelector,
+		"Label selector limiting which ActivityPolicies this processor watches and evaluates (e.g. tenant=a). Policies that don't match are ignored. Empty evaluates all policies.")
+	fs.DurationVar(&o.ResyncPeriod, "policy-resync-period", o.ResyncPeriod,
+		"Periodic resync interval for cached ActivityPolicies. Zero uses the controller-runtime default (10h).")
+
+	// Actor enrichment flags
+	fs.StringVar(&o.ActorDirectoryURL, "actor-directory-url", o.ActorDirectoryURL,
+		"Directory service URL used to resolve actor display names and team labels (GET <url>?username=&uid=). Empty disables enrichment.")
+	fs.DurationVar(&o.ActorCacheTTL, "actor-cache-ttl", o.ActorCacheTTL,
+		"How long actor directory lookups are cached.")
+
+	// Activity deduplication flags
+	fs.DurationVar(&o.DedupWindow, "dedup-window", o.DedupWindow,
+		"Suppress identical activities (same resource, verb, summary, actor, changes and resourceVersion) published within this window, e.g. 10s. Zero disables deduplication.")
+	fs.IntVar(&o.DedupMaxEntries, "dedup-max-entries", o.DedupMaxEntries,
+		"Maximum number of recent activities remembered for deduplication. The oldest are forgotten first.")
+
+	// Summary flags
+	fs.IntVar(&o.MaxSummaryLength, "max-summary-length", o.MaxSummaryLength,
+		"Maximum size in bytes of a generated activity summary. Longer summaries are truncated with an ellipsis. Zero disables truncation.")
+
+	// Change source flags
+	fs.StringVar(&o.SystemUsersConfig, "system-users-config", o.SystemUsersConfig,
+		"YAML or JSON file listing the usernames treated as system actors (prefixes and regular expression patterns), typically mounted from a ConfigMap. Empty uses the built-in rules: the system: prefix and usernames containing serviceaccount or controller.")
+
 	logsapi.AddFlags(o.Logs, fs)
 }
 
@@ -209,9 +282,31 @@ The processor:
 func RunProcessor(options *ProcessorOptions) error {
 	klog.Info("Starting Activity Processor")
 
+	// Alert copies under the output prefix would be stored as activities twice
+	if alert := options.AlertSubjectPrefix; alert != "" &&
+		(alert == options.OutputSubjectPrefix || strings.HasPrefix(alert, options.OutputSubjectPrefix+".")) {
+		return fmt.Errorf("--alert-subject-prefix %q must not be within --output-subject-prefix %q", alert, options.OutputSubjectPrefix)
+	}
+
+	// Expand {group} so each processor deployment binds to its own durables
+	consumers, err := activityprocessor.ResolveConsumerNames(options.ConsumerGroup, options.ConsumerName, options.NATSEventConsumer)
+	if err != nil {
+		return fmt.Errorf("invalid --consumer-name, --nats-event-consumer or --consumer-group: %w", err)
+	}
+	consumerName, eventConsumerName := consumers[0], consumers[1]
+
+	policySelector, err := labels.Parse(options.PolicySelector)
+	if err != nil {
+		return fmt.Errorf("invalid --policy-selector: %w", err)
+	}
+
+	// Load the human/system classification used for changeSource
+	if err := changesource.LoadDefault(options.SystemUsersConfig); err != nil {
+		return err
+	}
+
 	// Build Kubernetes client configuration
 	var restConfig *rest.Config
-	var err error
 
 	if options.Kubeconfig != "" {
 		restConfig, err = clientcmd.BuildConfigFromFlags(options.MasterURL, options.Kubeconfig)
@@ -226,11 +321,12 @@ func RunProcessor(options *ProcessorOptions) error {
 	processorConfig := activityprocessor.Config{
 		NATSURL:              options.NATSURL,
 		NATSStreamName:       options.NATSStreamName,
-		ConsumerName:         options.ConsumerName,
+		ConsumerName:         consumerName,
 		NATSEventStream:      options.NATSEventStream,
-		NATSEventConsumer:    options.NATSEventConsumer,
+		NATSEventConsumer:    eventConsumerName,
 		OutputStreamName:     options.OutputStreamName,
 		OutputSubjectPrefix:  options.OutputSubjectPrefix,
+		AlertSubjectPrefix:   options.AlertSubjectPrefix,
 		NATSTLSEnabled:       options.NATSTLSEnabled,
 		NATSTLSCertFile:      options.NATSTLSCertFile,
 		NATSTLSKeyFile:       options.NATSTLSKeyFile,
@@ -249,9 +345,20 @@ func RunProcessor(options *ProcessorOptions) error {
 		DLQRetryEventSubject:      options.DLQRetryEventSubject,
 		Workers:                   options.Workers,
 		BatchSize:            options.BatchSize,
+		FetchMaxWait:         options.FetchMaxWait,
 		AckWait:              options.AckWait,
 		MaxDeliver:           5,
+		AsyncPublish:         options.AsyncPublish,
+		PublishMaxPending:    options.PublishMaxPending,
+		PublishFlushTimeout:  options.PublishFlushTimeout,
 		HealthProbeAddr:      options.HealthProbeAddr,
+		PolicySelector:       policySelector,
+		ResyncPeriod:         options.ResyncPeriod,
+		ActorDirectoryURL:    options.ActorDirectoryURL,
+		ActorCacheTTL:        options.ActorCacheTTL,
+		DedupWindow:          options.DedupWindow,
+		DedupMaxEntries:      options.DedupMaxEntries,
+		MaxSummaryLength:     options.MaxSummaryLength,
 	}
 
 	proc, err := activityprocessor.New(processorConfig, restConfig)
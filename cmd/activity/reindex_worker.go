@@ -22,6 +22,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"go.miloapis.com/activity/internal/controller"
+	"go.miloapis.com/activity/internal/changesource"
 	"go.miloapis.com/activity/internal/processor"
 	"go.miloapis.com/activity/internal/reindex"
 	"go.miloapis.com/activity/internal/timeutil"
@@ -48,6 +49,9 @@ type ReindexWorkerOptions struct {
 	NATSTLSKeyFile  string
 	NATSTLSCAFile   string
 
+	// Human/system classification of actors; should match the processor
+	SystemUsersConfig string
+
 	Logs *logsapi.LoggingConfiguration
 }
 
@@ -76,6 +80,9 @@ func (o *ReindexWorkerOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.NATSTLSCAFile, "nats-tls-ca-file", o.NATSTLSCAFile,
 		"Path to CA certificate file for NATS TLS.")
 
+	fs.StringVar(&o.SystemUsersConfig, "system-users-config", o.SystemUsersConfig,
+		"YAML or JSON file listing the usernames treated as system actors (prefixes and regular expression patterns), typically mounted from a ConfigMap. Empty uses the built-in rules: the system: prefix and usernames containing serviceaccount or controller. Use the same file as the processor so reindexed activities are labeled the same way.")
+
 	logsapi.AddFlags(o.Logs, fs)
 }
 
@@ -116,6 +123,10 @@ func RunReindexWorker(ctx context.Context, options *ReindexWorkerOptions) error
 		return fmt.Errorf("reindexjob name is required")
 	}
 
+	if err := changesource.LoadDefault(options.SystemUsersConfig); err != nil {
+		return err
+	}
+
 	klog.InfoS("Starting reindex worker", "job", options.JobName)
 
 	// Build Kubernetes client config
@@ -152,7 +163,7 @@ func RunReindexWorker(ctx context.Context, options *ReindexWorkerOptions) error
 
 	// Initialize NATS JetStream connection
 	klog.InfoS("Connecting to NATS", "url", options.NATSURL)
-	natsOpts, err := buildNATSOptions(options)
+	natsOpts, err := buildNATSOptions(options.NATSTLSEnabled, options.NATSTLSCertFile, options.NATSTLSKeyFile, options.NATSTLSCAFile)
 	if err != nil {
 		return fmt.Errorf("failed to build NATS options: %w", err)
 	}
@@ -382,19 +393,19 @@ func updateJobProgress(ctx context.Context, cl client.Client, job *v1alpha1.Rein
 	return cl.Status().Update(ctx, &latest)
 }
 
-// buildNATSOptions constructs NATS connection options from configuration.
-func buildNATSOptions(options *ReindexWorkerOptions) ([]nats.Option, error) {
+// buildNATSOptions constructs NATS connection options from the TLS flags.
+func buildNATSOptions(tlsEnabled bool, certFile, keyFile, caFile string) ([]nats.Option, error) {
 	var natsOpts []nats.Option
 
 	// Configure TLS if enabled
-	if options.NATSTLSEnabled {
+	if tlsEnabled {
 		tlsConfig := &tls.Config{
 			MinVersion: tls.VersionTLS12,
 		}
 
 		// Load client cert/key if provided
-		if options.NATSTLSCertFile != "" && options.NATSTLSKeyFile != "" {
-			cert, err := tls.LoadX509KeyPair(options.NATSTLSCertFile, options.NATSTLSKeyFile)
+		if certFile != "" && keyFile != "" {
+			cert, err := tls.LoadX509KeyPair(certFile, keyFile)
 			if err != nil {
 				return nil, fmt.Errorf("failed to load NATS TLS client cert: %w", err)
 			}
@@ -402,8 +413,8 @@ func buildNATSOptions(options *ReindexWorkerOptions) ([]nats.Option, error) {
 		}
 
 		// Load CA cert if provided
-		if options.NATSTLSCAFile != "" {
-			caCert, err := os.ReadFile(options.NATSTLSCAFile)
+		if caFile != "" {
+			caCert, err := os.ReadFile(caFile)
 			if err != nil {
 				return nil, fmt.Errorf("failed to read NATS TLS CA file: %w", err)
 			}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+)
+
+// TailActivitiesOptions contains configuration for tail-activities.
+type TailActivitiesOptions struct {
+	// NATS configuration
+	NATSURL         string
+	NATSTLSEnabled  bool
+	NATSTLSCertFile string
+	NATSTLSKeyFile  string
+	NATSTLSCAFile   string
+
+	// SubjectPrefix is the processor's --output-subject-prefix
+	SubjectPrefix string
+
+	// Duration bounds how long to listen; MaxMessages stops earlier when set
+	Duration    time.Duration
+	MaxMessages int
+
+	// Output is "text" for one line per activity or "json" for the raw messages
+	Output string
+}
+
+// NewTailActivitiesOptions creates options with default values.
+func NewTailActivitiesOptions() *TailActivitiesOptions {
+	return &TailActivitiesOptions{
+		SubjectPrefix: "activities",
+		Duration:      30 * time.Second,
+		Output:        "text",
+	}
+}
+
+// AddFlags adds tail-activities flags to the flag set.
+func (o *TailActivitiesOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.NATSURL, "nats-url", o.NATSURL,
+		"NATS server URL (e.g., nats://localhost:4222). Required.")
+	fs.BoolVar(&o.NATSTLSEnabled, "nats-tls-enabled", o.NATSTLSEnabled,
+		"Enable TLS for NATS connection.")
+	fs.StringVar(&o.NATSTLSCertFile, "nats-tls-cert-file", o.NATSTLSCertFile,
+		"Path to client certificate file for NATS TLS.")
+	fs.StringVar(&o.NATSTLSKeyFile, "nats-tls-key-file", o.NATSTLSKeyFile,
+		"Path to client private key file for NATS TLS.")
+	fs.StringVar(&o.NATSTLSCAFile, "nats-tls-ca-file", o.NATSTLSCAFile,
+		"Path to CA certificate file for NATS TLS.")
+	fs.StringVar(&o.SubjectPrefix, "output-subject-prefix", o.SubjectPrefix,
+		"Subject prefix the processor publishes activities under; must match the processor's --output-subject-prefix")
+	fs.DurationVar(&o.Duration, "duration", o.Duration,
+		"How long to listen for activities before exiting")
+	fs.IntVar(&o.MaxMessages, "max-messages", o.MaxMessages,
+		"Exit after this many activities (0 for no limit)")
+	fs.StringVarP(&o.Output, "output", "o", o.Output,
+		"Output format: text (one line per activity) or json (one published message per line)")
+}
+
+// Validate checks the options.
+func (o *TailActivitiesOptions) Validate() error {
+	if o.NATSURL == "" {
+		return fmt.Errorf("--nats-url is required")
+	}
+	if o.SubjectPrefix == "" {
+		return fmt.Errorf("--output-subject-prefix must not be empty")
+	}
+	if o.Duration <= 0 {
+		return fmt.Errorf("--duration must be positive")
+	}
+	if o.MaxMessages < 0 {
+		return fmt.Errorf("--max-messages must not be negative")
+	}
+	if o.Output != "text" && o.Output != "json" {
+		return fmt.Errorf("--output must be text or json, got %q", o.Output)
+	}
+	return nil
+}
+
+// NewTailActivitiesCommand creates the tail-activities subcommand.
+func NewTailActivitiesCommand() *cobra.Command {
+	options := NewTailActivitiesOptions()
+
+	cmd := &cobra.Command{
+		Use:   "tail-activities",
+		Short: "Print the activities the processor publishes, live",
+		Long: `Subscribe to the processor's output subjects for a short window and print
+each activity as it is published.
+
+Use this when activities are missing from queries to tell whether the
+processor is producing them (they show up here) or ingestion into ClickHouse
+is broken (they show up here but not in queries). The subscription is a plain
+NATS subscription, so it does not create a consumer or affect delivery to
+the real ones.`,
+		Example: `  # Watch for 30 seconds
+  activity tail-activities --nats-url nats://nats.nats-system:4222
+
+  # Capture the next 10 activities as JSON
+  activity tail-activities --nats-url nats://nats.nats-system:4222 --max-messages 10 -o json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.Validate(); err != nil {
+				return err
+			}
+			return RunTailActivities(cmd.Context(), options, cmd.OutOrStdout(), cmd.ErrOrStderr())
+		},
+	}
+
+	options.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// RunTailActivities subscribes to the activity subjects and prints what
+// arrives until the duration passes, enough messages arrive or ctx ends.
+func RunTailActivities(ctx context.Context, options *TailActivitiesOptions, out, errOut io.Writer) error {
+	natsOpts, err := buildNATSOptions(options.NATSTLSEnabled, options.NATSTLSCertFile, options.NATSTLSKeyFile, options.NATSTLSCAFile)
+	if err != nil {
+		return fmt.Errorf("failed to build NATS options: %w", err)
+	}
+
+	natsConn, err := nats.Connect(options.NATSURL, natsOpts...)
+	if err != nil {
+		return fmt.Errorf("failed to connect to NATS: %w", err)
+	}
+	defer natsConn.Close()
+
+	subject := options.SubjectPrefix + ".>"
+	msgs := make(chan *nats.Msg, 256)
+	sub, err := natsConn.ChanSubscribe(subject, msgs)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to %s: %w", subject, err)
+	}
+	defer func() { _ = sub.Unsubscribe() }()
+
+	fmt.Fprintf(errOut, "Listening on %s for %s...\n", subject, options.Duration)
+
+	ctx, cancel := context.WithTimeout(ctx, options.Duration)
+	defer cancel()
+
+	count, err := tailActivities(ctx, msgs, out, options.Output, options.MaxMessages)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(errOut, "Received %d activities.\n", count)
+	if count == 0 {
+		fmt.Fprintf(errOut, "The processor published nothing under %s. Check that audit events are arriving, that an ActivityPolicy matches them, and the processor's logs and activity_processor_events_skipped_total metric.\n", subject)
+	}
+	return nil
+}
+
+// tailActivities prints messages from msgs until ctx ends or maxMessages (when
+// positive) have been printed, and returns how many were printed. Messages
+// that don't decode as activities are still reported, since a malformed
+// payload is itself a likely cause of failed ingestion.
+func tailActivities(ctx context.Context, msgs <-chan *nats.Msg, out io.Writer, format string, maxMessages int) (int, error) {
+	count := 0
+	for maxMessages <= 0 || count < maxMessages {
+		var msg *nats.Msg
+		select {
+		case <-ctx.Done():
+			return count, nil
+		case msg = <-msgs:
+		}
+		count++
+
+		if format == "json" {
+			if _, err := fmt.Fprintf(out, "%s\n", msg.Data); err != nil {
+				return count, err
+			}
+			continue
+		}
+
+		var activity v1alpha1.Activity
+		if err := json.Unmarshal(msg.Data, &activity); err != nil {
+			fmt.Fprintf(out, "%s  <undecodable payload of %d bytes: %v>\n", msg.Subject, len(msg.Data), err)
+			continue
+		}
+		if _, err := fmt.Fprintf(out, "%s  %s  %s\n",
+			activity.CreationTimestamp.UTC().Format(time.RFC3339), msg.Subject, activity.Spec.Summary); err != nil {
+			return count, err
+		}
+	}
+	return count, nil
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+const tailedActivity = `{"metadata":{"name":"act-1","creationTimestamp":"2026-10-16T12:00:00Z"},` +
+	`"spec":{"summary":"alice created deployment api"}}`
+
+func TestTailActivities(t *testing.T) {
+	msgs := make(chan *nats.Msg, 3)
+	msgs <- &nats.Msg{Subject: "activities.platform._.apps.audit.Deployment.default.act-1", Data: []byte(tailedActivity)}
+	msgs <- &nats.Msg{Subject: "activities.platform._.apps.audit.Deployment.default.act-2", Data: []byte("not json")}
+	msgs <- &nats.Msg{Subject: "activities.platform._.apps.audit.Deployment.default.act-3", Data: []byte(tailedActivity)}
+
+	var out bytes.Buffer
+	count, err := tailActivities(context.Background(), msgs, &out, "text", 2)
+	if err != nil {
+		t.Fatalf("tailActivities() error = %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected to stop after 2 messages, got %d", count)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %q", out.String())
+	}
+	want := "2026-10-16T12:00:00Z  activities.platform._.apps.audit.Deployment.default.act-1  alice created deployment api"
+	if lines[0] != want {
+		t.Errorf("line 1 = %q, want %q", lines[0], want)
+	}
+	if !strings.Contains(lines[1], "act-2  <undecodable payload of 8 bytes") {
+		t.Errorf("expected the malformed message to be reported, got %q", lines[1])
+	}
+}
+
+func TestTailActivities_JSONUntilDone(t *testing.T) {
+	msgs := make(chan *nats.Msg, 1)
+	msgs <- &nats.Msg{Subject: "activities.x", Data: []byte(tailedActivity)}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	var out bytes.Buffer
+	count, err := tailActivities(ctx, msgs, &out, "json", 0)
+	if err != nil {
+		t.Fatalf("tailActivities() error = %v", err)
+	}
+	if count != 1 || out.String() != tailedActivity+"\n" {
+		t.Errorf("expected the raw message once, got %d messages: %q", count, out.String())
+	}
+}
@@ -28,6 +28,7 @@ source "${CODEGEN_PKG}/kube_codegen.sh"
 echo "Generating clientset, listers, informers, and deepcopy..."
 kube::codegen::gen_client \
   --with-watch \
+  --plural-exceptions "Endpoints:Endpoints,ScopeStats:ScopeStats" \
   --output-dir "${SCRIPT_ROOT}/pkg/client" \
   --output-pkg "${MODULE_NAME}/pkg/client" \
   --boilerplate "${SCRIPT_ROOT}/hack/boilerplate.go.txt" \
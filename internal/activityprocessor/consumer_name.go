@@ -0,0 +1,38 @@
+package activityprocessor
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ConsumerGroupPlaceholder is replaced in durable consumer names by the
+// processor's consumer group, so deployments that each need their own copy of
+// the stream (a canary, or one per subject subset) can share a name template.
+const ConsumerGroupPlaceholder = "{group}"
+
+// ResolveConsumerNames expands ConsumerGroupPlaceholder in each durable
+// consumer name with group. A placeholder without a group is an error, and so
+// is a group that none of the names use, since the deployment would then
+// silently share its consumers with every other one.
+func ResolveConsumerNames(group string, names ...string) ([]string, error) {
+	if strings.ContainsAny(group, " \t\r\n.*>/\\") {
+		return nil, fmt.Errorf("consumer group %q must not contain whitespace, '.', '*', '>', '/' or '\\'", group)
+	}
+
+	resolved := make([]string, len(names))
+	used := false
+	for i, name := range names {
+		if strings.Contains(name, ConsumerGroupPlaceholder) {
+			if group == "" {
+				return nil, fmt.Errorf("consumer name %q uses %s but no consumer group is set", name, ConsumerGroupPlaceholder)
+			}
+			used = true
+		}
+		resolved[i] = strings.ReplaceAll(name, ConsumerGroupPlaceholder, group)
+	}
+
+	if group != "" && !used {
+		return nil, fmt.Errorf("consumer group %q is set but no consumer name uses %s", group, ConsumerGroupPlaceholder)
+	}
+	return resolved, nil
+}
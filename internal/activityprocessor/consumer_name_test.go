@@ -0,0 +1,63 @@
+package activityprocessor
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestResolveConsumerNames(t *testing.T) {
+	tests := []struct {
+		name    string
+		group   string
+		names   []string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:  "no group keeps the shared durables",
+			names: []string{"activity-processor", "activity-event-processor"},
+			want:  []string{"activity-processor", "activity-event-processor"},
+		},
+		{
+			name:  "group expands the placeholder",
+			group: "canary",
+			names: []string{"activity-processor-{group}", "activity-event-processor-{group}"},
+			want:  []string{"activity-processor-canary", "activity-event-processor-canary"},
+		},
+		{
+			name:  "group may be used by one consumer only",
+			group: "canary",
+			names: []string{"activity-processor-{group}", "activity-event-processor"},
+			want:  []string{"activity-processor-canary", "activity-event-processor"},
+		},
+		{
+			name:    "placeholder without a group",
+			names:   []string{"activity-processor-{group}"},
+			wantErr: true,
+		},
+		{
+			name:    "group without a placeholder",
+			group:   "canary",
+			names:   []string{"activity-processor", "activity-event-processor"},
+			wantErr: true,
+		},
+		{
+			name:    "group with a subject token separator",
+			group:   "canary.v2",
+			names:   []string{"activity-processor-{group}"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveConsumerNames(tt.group, tt.names...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveConsumerNames() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !slices.Equal(got, tt.want) {
+				t.Errorf("ResolveConsumerNames() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package activityprocessor
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// matchRateWindow is how far back policy match rates look.
+	matchRateWindow = 15 * time.Minute
+
+	// matchRateBuckets is how many buckets the window is split into. Old
+	// evaluations drop out one bucket at a time.
+	matchRateBuckets = 15
+)
+
+var (
+	policyMatchRatioDesc = prometheus.NewDesc(
+		"activity_processor_policy_match_ratio",
+		"Fraction of audit events evaluated against a policy that matched one of its rules over the last 15 minutes",
+		[]string{"policy"}, nil,
+	)
+
+	policyRecentEvaluationsDesc = prometheus.NewDesc(
+		"activity_processor_policy_recent_evaluations",
+		"Audit events evaluated against a policy over the last 15 minutes",
+		[]string{"policy"}, nil,
+	)
+
+	// policyMatchRates tracks recent policy evaluations for the match rate
+	// metrics.
+	policyMatchRates = newMatchRateTracker(matchRateWindow, time.Now)
+)
+
+// matchBucket counts the evaluations of one policy within one bucket of the
+// window.
+type matchBucket struct {
+	start     time.Time
+	evaluated int64
+	matched   int64
+}
+
+// matchRateTracker keeps per-policy evaluation counts over a sliding window
+// and exposes the fraction that matched as Prometheus gauges. Computing the
+// same from events_evaluated_total needs a ratio of rates per policy, which is
+// easy to get wrong in dashboards and alerts.
+//
+// Policies without evaluations in the window have no series, since their
+// match rate is undefined.
+type matchRateTracker struct {
+	mu       sync.Mutex
+	window   time.Duration
+	width    time.Duration
+	now      func() time.Time
+	policies map[string][]matchBucket
+}
+
+func newMatchRateTracker(window time.Duration, now func() time.Time) *matchRateTracker {
+	return &matchRateTracker{
+		window:   window,
+		width:    window / matchRateBuckets,
+		now:      now,
+		policies: make(map[string][]matchBucket),
+	}
+}
+
+// record counts one evaluation of policy.
+func (t *matchRateTracker) record(policy string, matched bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	start := now.Truncate(t.width)
+	buckets := t.prune(t.policies[policy], now)
+	if len(buckets) == 0 || !buckets[len(buckets)-1].start.Equal(start) {
+		buckets = append(buckets, matchBucket{start: start})
+	}
+	last := &buckets[len(buckets)-1]
+	last.evaluated++
+	if matched {
+		last.matched++
+	}
+	t.policies[policy] = buckets
+}
+
+// forget drops the counts for a deleted policy so its series disappears
+// immediately.
+func (t *matchRateTracker) forget(policy string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.policies, policy)
+}
+
+// prune drops the buckets that have left the window.
+func (t *matchRateTracker) prune(buckets []matchBucket, now time.Time) []matchBucket {
+	cutoff := now.Add(-t.window)
+	i := 0
+	for i < len(buckets) && !buckets[i].start.After(cutoff) {
+		i++
+	}
+	return buckets[i:]
+}
+
+// Describe implements prometheus.Collector.
+func (t *matchRateTracker) Describe(ch chan<- *prometheus.Desc) {
+	ch <- policyMatchRatioDesc
+	ch <- policyRecentEvaluationsDesc
+}
+
+// Collect implements prometheus.Collector.
+func (t *matchRateTracker) Collect(ch chan<- prometheus.Metric) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	for policy, buckets := range t.policies {
+		buckets = t.prune(buckets, now)
+		if len(buckets) == 0 {
+			delete(t.policies, policy)
+			continue
+		}
+		t.policies[policy] = buckets
+
+		var evaluated, matched int64
+		for _, b := range buckets {
+			evaluated += b.evaluated
+			matched += b.matched
+		}
+		ch <- prometheus.MustNewConstMetric(policyMatchRatioDesc, prometheus.GaugeValue,
+			float64(matched)/float64(evaluated), policy)
+		ch <- prometheus.MustNewConstMetric(policyRecentEvaluationsDesc, prometheus.GaugeValue,
+			float64(evaluated), policy)
+	}
+}
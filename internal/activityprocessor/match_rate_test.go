@@ -0,0 +1,59 @@
+package activityprocessor
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMatchRateTracker(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	tracker := newMatchRateTracker(15*time.Minute, func() time.Time { return now })
+
+	// Three of four evaluations of the broad policy match; the misconfigured
+	// one never does.
+	tracker.record("broad", true)
+	tracker.record("broad", true)
+	tracker.record("broad", false)
+	tracker.record("broad", true)
+	tracker.record("never-matches", false)
+	tracker.record("never-matches", false)
+
+	expected := `
+# HELP activity_processor_policy_match_ratio Fraction of audit events evaluated against a policy that matched one of its rules over the last 15 minutes
+# TYPE activity_processor_policy_match_ratio gauge
+activity_processor_policy_match_ratio{policy="broad"} 0.75
+activity_processor_policy_match_ratio{policy="never-matches"} 0
+# HELP activity_processor_policy_recent_evaluations Audit events evaluated against a policy over the last 15 minutes
+# TYPE activity_processor_policy_recent_evaluations gauge
+activity_processor_policy_recent_evaluations{policy="broad"} 4
+activity_processor_policy_recent_evaluations{policy="never-matches"} 2
+`
+	if err := testutil.CollectAndCompare(tracker, strings.NewReader(expected)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Evaluations age out of the window one bucket at a time.
+	now = now.Add(10 * time.Minute)
+	tracker.record("broad", false)
+	now = now.Add(6 * time.Minute)
+
+	expected = `
+# HELP activity_processor_policy_match_ratio Fraction of audit events evaluated against a policy that matched one of its rules over the last 15 minutes
+# TYPE activity_processor_policy_match_ratio gauge
+activity_processor_policy_match_ratio{policy="broad"} 0
+# HELP activity_processor_policy_recent_evaluations Audit events evaluated against a policy over the last 15 minutes
+# TYPE activity_processor_policy_recent_evaluations gauge
+activity_processor_policy_recent_evaluations{policy="broad"} 1
+`
+	if err := testutil.CollectAndCompare(tracker, strings.NewReader(expected)); err != nil {
+		t.Fatal(err)
+	}
+
+	tracker.forget("broad")
+	if n := testutil.CollectAndCount(tracker); n != 0 {
+		t.Errorf("CollectAndCount() = %d after forget, want 0", n)
+	}
+}
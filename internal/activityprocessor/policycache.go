@@ -25,6 +25,8 @@ type CompiledRule struct {
 	Match string
 	// Summary is the original summary template.
 	Summary string
+	// Severity is the rule's severity, which decides alert routing.
+	Severity v1alpha1.ActivityPolicyRuleSeverity
 	// MatchProgram is the pre-compiled CEL program for match evaluation.
 	MatchProgram cel.Program
 	// SummaryTemplates contains pre-compiled CEL programs for each template expression.
@@ -257,9 +259,10 @@ func (c *PolicyCache) compile(policy *v1alpha1.ActivityPolicy, resource string)
 // compileAuditRule compiles a single audit rule.
 func (c *PolicyCache) compileAuditRule(rule v1alpha1.ActivityPolicyRule, policyName string, ruleIndex int) CompiledRule {
 	compiled := CompiledRule{
-		Match:   rule.Match,
-		Summary: rule.Summary,
-		Valid:   true,
+		Match:    rule.Match,
+		Summary:  rule.Summary,
+		Severity: rule.Severity,
+		Valid:    true,
 	}
 
 	// Create audit environment for compilation
@@ -305,9 +308,10 @@ func (c *PolicyCache) compileAuditRule(rule v1alpha1.ActivityPolicyRule, policyN
 // compileEventRule compiles a single event rule.
 func (c *PolicyCache) compileEventRule(rule v1alpha1.ActivityPolicyRule, policyName string, ruleIndex int) CompiledRule {
 	compiled := CompiledRule{
-		Match:   rule.Match,
-		Summary: rule.Summary,
-		Valid:   true,
+		Match:    rule.Match,
+		Summary:  rule.Summary,
+		Severity: rule.Severity,
+		Valid:    true,
 	}
 
 	// Create event environment for compilation
@@ -463,12 +467,14 @@ func (r *CompiledRule) EvaluateEventMatch(eventMap map[string]any) (bool, error)
 
 // EvaluateCompiledAuditRules evaluates pre-compiled audit rules against an audit event.
 // Returns the generated Activity, the matching rule index, and any error.
-// Returns (nil, -1, nil) if no rule matched.
+// Returns (nil, -1, nil) if no rule matched. Summaries longer than
+// maxSummaryLength bytes are truncated; zero disables truncation.
 func EvaluateCompiledAuditRules(
 	policy *CompiledPolicy,
 	auditMap map[string]any,
 	audit *auditv1.Event,
 	resolveKind processor.KindResolver,
+	maxSummaryLength int,
 ) (*v1alpha1.Activity, int, error) {
 	for i := range policy.AuditRules {
 		rule := &policy.AuditRules[i]
@@ -489,6 +495,7 @@ func EvaluateCompiledAuditRules(
 			if err != nil {
 				return nil, i, fmt.Errorf("rule %d summary: %w", i, err)
 			}
+			summary = processor.LimitSummary(summary, maxSummaryLength, policy.Name, i)
 
 			builder := &processor.ActivityBuilder{
 				APIGroup: policy.APIGroup,
@@ -498,6 +505,7 @@ func EvaluateCompiledAuditRules(
 			if err != nil {
 				return nil, i, fmt.Errorf("rule %d build: %w", i, err)
 			}
+			processor.SetPolicyOrigin(activity, policy.Name, i)
 
 			return activity, i, nil
 		}
@@ -549,10 +557,12 @@ func (c *PolicyCache) MatchEvent(apiGroup, kind string, eventMap map[string]any)
 				return &processor.MatchedPolicy{
 					PolicyName: policy.Name,
 					Generation: policy.OriginalPolicy.Generation,
+					RuleIndex:  i,
 					APIGroup:   policy.APIGroup,
 					Kind:       policy.Kind,
 					Summary:    summary,
 					Links:      links,
+					Severity:   rule.Severity,
 				}, nil
 			}
 		}
@@ -18,6 +18,8 @@ import (
 	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/discovery/cached/memory"
 	"k8s.io/client-go/kubernetes"
@@ -83,6 +85,15 @@ var (
 		[]string{"policy", "api_group", "kind"},
 	)
 
+	activitiesDeduplicated = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "activity_processor",
+			Name:      "activities_deduplicated_total",
+			Help:      "Total number of activities suppressed as duplicates within the dedup window",
+		},
+		[]string{"policy", "api_group", "kind"},
+	)
+
 	eventProcessingDuration = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Namespace: "activity_processor",
@@ -93,6 +104,17 @@ var (
 		[]string{"source", "policy"},
 	)
 
+	// eventLag tracks pipeline freshness: how long after the API server
+	// finished with a request its audit event reached the processor.
+	eventLag = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: "activity_processor",
+			Name:      "event_lag_seconds",
+			Help:      "Delay between an audit event's stage timestamp and when the processor handled it",
+			Buckets:   []float64{.1, .5, 1, 2.5, 5, 10, 30, 60, 120, 300, 600, 1800, 3600},
+		},
+	)
+
 	policyCount = prometheus.NewGauge(
 		prometheus.GaugeOpts{
 			Namespace: "activity_processor",
@@ -165,6 +187,24 @@ var (
 		},
 	)
 
+	natsPublishInFlight = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "activity_processor",
+			Subsystem: "nats",
+			Name:      "publish_in_flight",
+			Help:      "Asynchronous publishes waiting for a JetStream acknowledgement",
+		},
+	)
+
+	natsPublishFlushDuration = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: "activity_processor",
+			Subsystem: "nats",
+			Name:      "publish_flush_duration_seconds",
+			Help:      "Time spent waiting for JetStream to acknowledge a batch of asynchronous publishes",
+			Buckets:   []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+		},
+	)
 )
 
 func init() {
@@ -175,7 +215,9 @@ func init() {
 		eventsSkipped,
 		eventsErrored,
 		activitiesGenerated,
+		activitiesDeduplicated,
 		eventProcessingDuration,
+		eventLag,
 		policyCount,
 		workerCount,
 		// NATS metrics
@@ -185,6 +227,10 @@ func init() {
 		natsErrorsTotal,
 		natsMessagesPublished,
 		natsPublishLatency,
+		natsPublishInFlight,
+		natsPublishFlushDuration,
+		// Policy match rates
+		policyMatchRates,
 	)
 }
 
@@ -202,6 +248,7 @@ type Config struct {
 	// Output NATS stream for generated activities
 	OutputStreamName    string // Stream for publishing activities (e.g., "ACTIVITIES")
 	OutputSubjectPrefix string // Subject prefix for activities (e.g., "activities")
+	AlertSubjectPrefix  string // Subject prefix for high-severity activities (e.g., "activity.alerts"); empty disables alert routing
 
 	// NATS TLS/mTLS configuration
 	NATSTLSEnabled  bool   // Enable TLS for NATS connection
@@ -227,13 +274,38 @@ type Config struct {
 
 	// Processing configuration
 	Workers    int           // Number of concurrent workers
-	BatchSize  int           // Messages to fetch per batch
-	AckWait    time.Duration // Time before message redelivery
-	MaxDeliver int           // Maximum redelivery attempts
+	BatchSize    int           // Messages to fetch per batch
+	FetchMaxWait time.Duration // How long a fetch waits for a full batch before returning what it has
+	AckWait      time.Duration // Time before message redelivery
+	MaxDeliver   int           // Maximum redelivery attempts
+
+	// Batched publishing configuration
+	AsyncPublish        bool          // Publish activities asynchronously and wait for acks once per fetched batch
+	PublishMaxPending   int           // Maximum asynchronous publishes awaiting an ack before publishing blocks
+	PublishFlushTimeout time.Duration // How long to wait for a batch's acks before NAKing its messages
+
+	// The consumers' MaxAckPending is set on the declaratively-managed NATS
+	// Consumer resources, not here. It must be at least Workers * BatchSize for
+	// both the audit and event consumers, or fetches return short batches while
+	// earlier messages wait to be acked.
 
 	// Health probe configuration
 	HealthProbeAddr string // Address for health probe server (e.g., ":8081")
 
+	// Kubernetes cache configuration
+	PolicySelector labels.Selector // Only policies matching this label selector are cached and evaluated; nil caches all policies
+	ResyncPeriod   time.Duration   // Periodic resync of cached policies; zero uses the controller-runtime default
+
+	// Actor enrichment configuration
+	ActorDirectoryURL string        // Directory service for actor display names and labels; empty disables enrichment
+	ActorCacheTTL     time.Duration // How long directory lookups are cached
+
+	// Activity deduplication configuration
+	DedupWindow     time.Duration // Suppress identical activities within this window; zero disables deduplication
+	DedupMaxEntries int           // Maximum activities remembered for deduplication
+
+	// Summary configuration
+	MaxSummaryLength int // Truncate generated summaries to this many bytes; zero disables truncation
 }
 
 // DefaultConfig returns configuration with default values.
@@ -258,10 +330,39 @@ func DefaultConfig() Config {
 		DLQRetryAlertThreshold:    10,
 		Workers:                   4,
 		BatchSize:           100,
+		FetchMaxWait:        processor.DefaultFetchMaxWait,
 		AckWait:             30 * time.Second,
 		MaxDeliver:          5,
+		PublishMaxPending:   256,
+		PublishFlushTimeout: 5 * time.Second,
 		HealthProbeAddr:     ":8081",
+		MaxSummaryLength:    processor.DefaultMaxSummaryLength,
+	}
+}
+
+// cacheOptions builds the controller-runtime cache options for watching policies.
+//
+// ActivityPolicy is cluster-scoped, so policies can't be partitioned by
+// namespace. PolicySelector partitions them by label instead: the informer
+// lists and watches only matching policies, so a processor never sees, or
+// evaluates, the rest.
+func (c Config) cacheOptions() cache.Options {
+	opts := cache.Options{
+		Scheme: controller.Scheme,
 	}
+
+	if c.PolicySelector != nil && !c.PolicySelector.Empty() {
+		opts.ByObject = map[client.Object]cache.ByObject{
+			&v1alpha1.ActivityPolicy{}: {Label: c.PolicySelector},
+		}
+	}
+
+	if c.ResyncPeriod > 0 {
+		resync := c.ResyncPeriod
+		opts.SyncPeriod = &resync
+	}
+
+	return opts
 }
 
 // Processor consumes audit events from NATS, evaluates ActivityPolicies,
@@ -275,6 +376,10 @@ type Processor struct {
 
 	cache cache.Cache
 
+	// client writes the ResourceResolved condition back to policies and
+	// backs the event emitter.
+	client client.Client
+
 	// mapper converts Kind to Resource using API discovery. Requires explicit
 	// Reset() on cache miss to discover newly registered CRDs.
 	mapper meta.ResettableRESTMapper
@@ -294,6 +399,14 @@ type Processor struct {
 	// dlqRetryController handles automatic retry of DLQ events.
 	dlqRetryController *DLQRetryController
 
+	// actorEnricher replaces raw usernames with directory display names.
+	// Nil when no directory is configured.
+	actorEnricher *processor.ActorEnricher
+
+	// activityDeduplicator suppresses identical activities published in quick
+	// succession. Nil when deduplication is disabled.
+	activityDeduplicator *processor.ActivityDeduplicator
+
 	wg     sync.WaitGroup
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -306,6 +419,10 @@ type Processor struct {
 
 // New creates a new activity processor.
 func New(config Config, restConfig *rest.Config) (*Processor, error) {
+	if config.FetchMaxWait <= 0 {
+		config.FetchMaxWait = processor.DefaultFetchMaxWait
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 
 	p := &Processor{
@@ -333,9 +450,17 @@ func (p *Processor) Start(ctx context.Context) error {
 	cachedDiscoveryClient := memory.NewMemCacheClient(discoveryClient)
 	p.mapper = restmapper.NewDeferredDiscoveryRESTMapper(cachedDiscoveryClient)
 
-	c, err := cache.New(p.restConfig, cache.Options{
+	// Create controller-runtime client before the informer starts so policy
+	// handlers can report resource resolution during the initial sync.
+	k8sClient, err := client.New(p.restConfig, client.Options{
 		Scheme: controller.Scheme,
 	})
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+	p.client = k8sClient
+
+	c, err := cache.New(p.restConfig, p.config.cacheOptions())
 	if err != nil {
 		return fmt.Errorf("failed to create cache: %w", err)
 	}
@@ -367,15 +492,10 @@ func (p *Processor) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to sync cache")
 	}
 
-	klog.InfoS("ActivityPolicy cache synced")
-
-	// Create controller-runtime client for event emission
-	k8sClient, err := client.New(p.restConfig, client.Options{
-		Scheme: controller.Scheme,
-	})
-	if err != nil {
-		return fmt.Errorf("failed to create kubernetes client: %w", err)
-	}
+	klog.InfoS("ActivityPolicy cache synced",
+		"selector", p.config.PolicySelector,
+		"resyncPeriod", p.config.ResyncPeriod,
+	)
 
 	// Create Kubernetes clientset for event broadcaster
 	clientset, err := kubernetes.NewForConfig(p.restConfig)
@@ -448,7 +568,11 @@ func (p *Processor) Start(ctx context.Context) error {
 	p.nc = nc
 	natsConnectionStatus.Set(1)
 
-	js, err := nc.JetStream()
+	var jsOpts []nats.JSOpt
+	if p.config.AsyncPublish {
+		jsOpts = append(jsOpts, nats.PublishAsyncMaxPending(p.config.PublishMaxPending))
+	}
+	js, err := nc.JetStream(jsOpts...)
 	if err != nil {
 		nc.Close()
 		return fmt.Errorf("failed to create JetStream context: %w", err)
@@ -531,6 +655,29 @@ func (p *Processor) Start(ctx context.Context) error {
 		)
 	}
 
+	// Resolve actor display names in the background when a directory is configured
+	if p.config.ActorDirectoryURL != "" {
+		enricherConfig := processor.DefaultActorEnricherConfig()
+		if p.config.ActorCacheTTL > 0 {
+			enricherConfig.TTL = p.config.ActorCacheTTL
+		}
+		resolver := processor.NewHTTPActorResolver(p.config.ActorDirectoryURL, &http.Client{Timeout: enricherConfig.LookupTimeout})
+		p.actorEnricher = processor.NewActorEnricher(resolver, enricherConfig)
+		p.actorEnricher.Start(ctx)
+		klog.InfoS("Actor enrichment enabled",
+			"directory", p.config.ActorDirectoryURL,
+			"cacheTTL", enricherConfig.TTL,
+		)
+	}
+
+	if p.config.DedupWindow > 0 {
+		p.activityDeduplicator = processor.NewActivityDeduplicator(p.config.DedupWindow, p.config.DedupMaxEntries)
+		klog.InfoS("Activity deduplication enabled",
+			"window", p.config.DedupWindow,
+			"maxEntries", p.config.DedupMaxEntries,
+		)
+	}
+
 	klog.InfoS("Activity processor starting",
 		"stream", p.config.NATSStreamName,
 		"consumer", p.config.ConsumerName,
@@ -561,9 +708,12 @@ func (p *Processor) Start(ctx context.Context) error {
 			p.config.NATSEventStream,
 			p.config.NATSEventConsumer,
 			p.config.OutputSubjectPrefix,
+			p.config.AlertSubjectPrefix,
 			p.policyCache, // PolicyCache implements EventPolicyLookup
 			p.config.Workers,
 			p.config.BatchSize,
+			p.config.FetchMaxWait,
+			p.config.MaxSummaryLength,
 			p.dlqPublisher,
 		)
 		p.wg.Add(1)
@@ -670,6 +820,7 @@ func (p *Processor) onPolicyAdd(obj any) {
 
 	// Convert Kind to resource (plural) to match audit event ObjectRef format.
 	resource, err := p.kindToResource(policy.Spec.Resource.APIGroup, policy.Spec.Resource.Kind)
+	p.reportResourceResolution(policy, resource, err)
 	if err != nil {
 		klog.ErrorS(err, "Failed to resolve resource for policy, skipping",
 			"policy", policy.Name,
@@ -707,6 +858,14 @@ func (p *Processor) onPolicyUpdate(oldObj, newObj any) {
 		return
 	}
 
+	// Periodic resyncs deliver the cached object as both old and new. Nothing
+	// changed, so skip recompiling the policy and re-triggering DLQ retries.
+	// Policies still waiting for their resource are retried so they activate
+	// once the CRD is installed.
+	if oldPolicy.ResourceVersion == newPolicy.ResourceVersion && !isResourceUnresolved(newPolicy) {
+		return
+	}
+
 	wasReady := isPolicyReady(oldPolicy)
 	isReady := isPolicyReady(newPolicy)
 
@@ -720,6 +879,9 @@ func (p *Processor) onPolicyUpdate(oldObj, newObj any) {
 			"kind", oldPolicy.Spec.Resource.Kind,
 		)
 	}
+	if isReady {
+		p.reportResourceResolution(newPolicy, newResource, newErr)
+	}
 	if newErr != nil && isReady {
 		klog.ErrorS(newErr, "Failed to resolve new resource for policy update",
 			"policy", newPolicy.Name,
@@ -796,6 +958,7 @@ func (p *Processor) onPolicyDelete(obj any) {
 
 	p.policyCache.Remove(policy, resource)
 	policyCount.Set(float64(p.policyCache.Len()))
+	policyMatchRates.forget(policy.Name)
 
 	klog.InfoS("Deleted ActivityPolicy",
 		"policy", policy.Name,
@@ -831,17 +994,24 @@ func (p *Processor) worker(ctx context.Context, id int, errors chan<- error) {
 		default:
 		}
 
-		msgs, err := sub.Fetch(p.config.BatchSize, nats.MaxWait(5*time.Second))
+		msgs, err := sub.Fetch(p.config.BatchSize, nats.MaxWait(p.config.FetchMaxWait))
 		if err != nil {
 			if err == nats.ErrTimeout {
+				processor.ObserveFetchBatch("audit_log", 0)
 				continue
 			}
 			klog.ErrorS(err, "Failed to fetch messages", "worker", id)
 			continue
 		}
+		processor.ObserveFetchBatch("audit_log", len(msgs))
+
+		if p.config.AsyncPublish {
+			p.processBatchAsync(id, msgs)
+			continue
+		}
 
 		for _, msg := range msgs {
-			if err := p.processMessage(msg); err != nil {
+			if err := p.processMessage(msg, nil); err != nil {
 				klog.ErrorS(err, "Failed to process message", "worker", id)
 				msg.Nak()
 				continue
@@ -851,7 +1021,10 @@ func (p *Processor) worker(ctx context.Context, id int, errors chan<- error) {
 	}
 }
 
-func (p *Processor) processMessage(msg *nats.Msg) error {
+// processMessage evaluates one audit event and publishes the resulting
+// activity. When pending is nil the publish is synchronous; otherwise it is
+// queued on pending and the caller must wait for it before acking msg.
+func (p *Processor) processMessage(msg *nats.Msg, pending *pendingPublishes) error {
 	// Keep raw payload for DLQ in case of failure
 	rawPayload := json.RawMessage(msg.Data)
 
@@ -870,6 +1043,8 @@ func (p *Processor) processMessage(msg *nats.Msg) error {
 		return nil
 	}
 
+	observeEventLag(audit.StageTimestamp.Time, time.Now())
+
 	// Extract tenant info early for DLQ context
 	activityTenant := processor.ExtractTenant(audit.User)
 	dlqTenant := processor.NewDeadLetterTenantFromActivity(activityTenant.Type, activityTenant.Name)
@@ -1006,18 +1181,51 @@ func (p *Processor) processMessage(msg *nats.Msg) error {
 			policy.Kind,
 			fmt.Sprintf("%t", ruleMatched),
 		).Inc()
+		policyMatchRates.record(policy.Name, ruleMatched)
 
 		if !ruleMatched {
 			eventProcessingDuration.WithLabelValues("audit_log", policy.Name).Observe(time.Since(policyStart).Seconds())
 			continue
 		}
 
-		if err := p.publishActivity(activity, policy); err != nil {
+		p.actorEnricher.Enrich(activity)
+
+		var dedupKey string
+		if p.activityDeduplicator != nil {
+			dedupKey = processor.DedupKey(activity, &audit)
+		}
+		if dedupKey != "" && p.activityDeduplicator.IsDuplicate(dedupKey) {
+			activitiesDeduplicated.WithLabelValues(
+				policy.Name,
+				policy.APIGroup,
+				policy.Kind,
+			).Inc()
+			klog.V(4).InfoS("Suppressed duplicate activity",
+				"activity", activity.Name,
+				"policy", policy.Name,
+				"auditID", audit.AuditID,
+			)
+			eventProcessingDuration.WithLabelValues("audit_log", policy.Name).Observe(time.Since(policyStart).Seconds())
+			return nil
+		}
+
+		alert := processor.ShouldAlert(policy.AuditRules[ruleIndex].Severity)
+		if err := p.publishActivity(activity, policy, alert, pending); err != nil {
 			eventsErrored.WithLabelValues("audit_log", "publish").Inc()
 			eventProcessingDuration.WithLabelValues("audit_log", policy.Name).Observe(time.Since(policyStart).Seconds())
 			return fmt.Errorf("failed to publish activity: %w", err)
 		}
 
+		if dedupKey != "" {
+			// An asynchronous publish only counts once JetStream has it, so a
+			// failed publish doesn't suppress the redelivered activity.
+			if pending != nil {
+				pending.afterAck(func() { p.activityDeduplicator.Record(dedupKey) })
+			} else {
+				p.activityDeduplicator.Record(dedupKey)
+			}
+		}
+
 		klog.V(4).InfoS("Generated activity",
 			"activity", activity.Name,
 			"policy", policy.Name,
@@ -1034,7 +1242,21 @@ func (p *Processor) processMessage(msg *nats.Msg) error {
 
 // evaluateCompiledAuditRules evaluates audit rules using pre-compiled CEL programs.
 func (p *Processor) evaluateCompiledAuditRules(policy *CompiledPolicy, auditMap map[string]any, audit *auditv1.Event) (*v1alpha1.Activity, int, error) {
-	return EvaluateCompiledAuditRules(policy, auditMap, audit, p.resourceToKind)
+	return EvaluateCompiledAuditRules(policy, auditMap, audit, p.resourceToKind, p.config.MaxSummaryLength)
+}
+
+// observeEventLag records how far behind the audit event stamped at stage the
+// processor is at now. Events without a stage timestamp are skipped, and clock
+// skew that puts stage in the future counts as no lag.
+func observeEventLag(stage, now time.Time) {
+	if stage.IsZero() {
+		return
+	}
+	lag := now.Sub(stage)
+	if lag < 0 {
+		lag = 0
+	}
+	eventLag.Observe(lag.Seconds())
 }
 
 // auditToMap converts an audit event to a map for CEL evaluation.
@@ -1050,13 +1272,19 @@ func auditToMap(audit *auditv1.Event) (map[string]any, error) {
 	return m, nil
 }
 
-func (p *Processor) publishActivity(activity *v1alpha1.Activity, policy *CompiledPolicy) error {
+// publishActivity publishes an activity to the output stream and, when alert is
+// set and an alert subject prefix is configured, to the alert subject as well.
+// With a non-nil pending the publishes are queued asynchronously instead.
+func (p *Processor) publishActivity(activity *v1alpha1.Activity, policy *CompiledPolicy, alert bool, pending *pendingPublishes) error {
 	data, err := json.Marshal(activity)
 	if err != nil {
 		return fmt.Errorf("failed to marshal activity: %w", err)
 	}
 
-	subject := p.buildActivitySubject(activity)
+	subject := processor.ActivitySubject(p.config.OutputSubjectPrefix, activity)
+	if pending != nil {
+		return p.publishActivityAsync(subject, data, activity, policy, alert, pending)
+	}
 
 	// Activity name is unique per audit event, enabling NATS deduplication.
 	publishStart := time.Now()
@@ -1072,46 +1300,81 @@ func (p *Processor) publishActivity(activity *v1alpha1.Activity, policy *Compile
 		policy.APIGroup,
 		policy.Kind,
 	).Inc()
+
+	if alert && p.config.AlertSubjectPrefix != "" {
+		// On failure the audit event is redelivered; the regular copy is then
+		// deduplicated by NATS while the alert copy is retried.
+		if err := processor.PublishAlert(p.js, p.config.AlertSubjectPrefix, activity, data); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-// buildActivitySubject returns the NATS subject for routing activities.
-// Format: <prefix>.<tenant_type>.<tenant_name>.<api_group>.<origin>.<kind>.<namespace>.<name>
-func (p *Processor) buildActivitySubject(activity *v1alpha1.Activity) string {
-	prefix := p.config.OutputSubjectPrefix
+func policyKey(apiGroup, kindOrResource string) string {
+	return fmt.Sprintf("%s/%s", apiGroup, kindOrResource)
+}
 
-	tenantType := activity.Spec.Tenant.Type
-	if tenantType == "" {
-		tenantType = "platform"
-	}
-	tenantName := activity.Spec.Tenant.Name
-	if tenantName == "" {
-		tenantName = "_"
-	}
+func isPolicyReady(policy *v1alpha1.ActivityPolicy) bool {
+	return meta.IsStatusConditionTrue(policy.Status.Conditions, "Ready")
+}
 
-	apiGroup := activity.Spec.Resource.APIGroup
-	if apiGroup == "" {
-		apiGroup = "core"
+// isResourceUnresolved reports whether the processor last failed to resolve
+// the policy's resource.
+func isResourceUnresolved(policy *v1alpha1.ActivityPolicy) bool {
+	return meta.IsStatusConditionFalse(policy.Status.Conditions, v1alpha1.ActivityPolicyConditionResourceResolved)
+}
+
+// resourceResolvedCondition builds the ResourceResolved condition for the
+// outcome of mapping the policy's Kind to a resource.
+func resourceResolvedCondition(policy *v1alpha1.ActivityPolicy, resource string, resolveErr error) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               v1alpha1.ActivityPolicyConditionResourceResolved,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: policy.Generation,
+		Reason:             v1alpha1.ActivityPolicyReasonResolved,
+		Message:            fmt.Sprintf("Matching audit events for resource %q", policyKey(policy.Spec.Resource.APIGroup, resource)),
 	}
 
-	origin := activity.Spec.Origin.Type
-	kind := activity.Spec.Resource.Kind
-	namespace := activity.Spec.Resource.Namespace
-	if namespace == "" {
-		namespace = "_"
+	switch {
+	case resolveErr == nil:
+	case meta.IsNoMatchError(resolveErr):
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = v1alpha1.ActivityPolicyReasonResourceNotFound
+		condition.Message = fmt.Sprintf("Waiting for %s in API group %q to be registered; the policy is inactive until then: %v",
+			policy.Spec.Resource.Kind, policy.Spec.Resource.APIGroup, resolveErr)
+	default:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = v1alpha1.ActivityPolicyReasonDiscoveryFailed
+		condition.Message = fmt.Sprintf("API discovery failed; the policy is inactive until it succeeds: %v", resolveErr)
 	}
-	name := activity.Name
 
-	return fmt.Sprintf("%s.%s.%s.%s.%s.%s.%s.%s",
-		prefix, tenantType, tenantName, apiGroup, origin, kind, namespace, name)
+	return condition
 }
 
-func policyKey(apiGroup, kindOrResource string) string {
-	return fmt.Sprintf("%s/%s", apiGroup, kindOrResource)
-}
+// reportResourceResolution writes the ResourceResolved condition back to the
+// policy so operators can see policies the processor cannot activate. The
+// status is only patched when the condition changes, and conflicts are left
+// for the next update event to retry.
+func (p *Processor) reportResourceResolution(policy *v1alpha1.ActivityPolicy, resource string, resolveErr error) {
+	if p.client == nil {
+		return
+	}
 
-func isPolicyReady(policy *v1alpha1.ActivityPolicy) bool {
-	return meta.IsStatusConditionTrue(policy.Status.Conditions, "Ready")
+	updated := policy.DeepCopy()
+	if !meta.SetStatusCondition(&updated.Status.Conditions, resourceResolvedCondition(policy, resource, resolveErr)) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(p.ctx, 10*time.Second)
+	defer cancel()
+
+	patch := client.MergeFromWithOptions(policy, client.MergeFromWithOptimisticLock{})
+	if err := p.client.Status().Patch(ctx, updated, patch); err != nil {
+		klog.ErrorS(err, "Failed to update ResourceResolved condition",
+			"policy", policy.Name,
+		)
+	}
 }
 
 // setReady sets the ready status.
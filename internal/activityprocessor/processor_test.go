@@ -0,0 +1,259 @@
+package activityprocessor
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"go.miloapis.com/activity/internal/controller"
+	"go.miloapis.com/activity/internal/processor"
+	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+)
+
+func TestConfig_CacheOptions(t *testing.T) {
+	t.Run("defaults watch all policies", func(t *testing.T) {
+		opts := DefaultConfig().cacheOptions()
+
+		if opts.Scheme == nil {
+			t.Error("expected scheme to be set")
+		}
+		if opts.ByObject != nil {
+			t.Errorf("expected no policy selector, got %v", opts.ByObject)
+		}
+		if opts.SyncPeriod != nil {
+			t.Errorf("expected default sync period, got %v", *opts.SyncPeriod)
+		}
+	})
+
+	t.Run("selector and resync period are applied", func(t *testing.T) {
+		selector, err := labels.Parse("tenant in (a, b)")
+		if err != nil {
+			t.Fatalf("labels.Parse() error = %v", err)
+		}
+		cfg := DefaultConfig()
+		cfg.PolicySelector = selector
+		cfg.ResyncPeriod = 15 * time.Minute
+
+		opts := cfg.cacheOptions()
+
+		if len(opts.ByObject) != 1 {
+			t.Fatalf("expected a selector for one type, got %v", opts.ByObject)
+		}
+		for obj, byObject := range opts.ByObject {
+			if _, ok := obj.(*v1alpha1.ActivityPolicy); !ok {
+				t.Errorf("expected the selector to apply to ActivityPolicy, got %T", obj)
+			}
+			if !byObject.Label.Matches(labels.Set{"tenant": "a"}) {
+				t.Error("expected a policy labelled tenant=a to be watched")
+			}
+			if byObject.Label.Matches(labels.Set{"tenant": "c"}) {
+				t.Error("expected a policy labelled tenant=c to be ignored")
+			}
+		}
+		if opts.SyncPeriod == nil || *opts.SyncPeriod != 15*time.Minute {
+			t.Errorf("expected sync period of 15m, got %v", opts.SyncPeriod)
+		}
+	})
+}
+
+func TestNew_FetchMaxWait(t *testing.T) {
+	t.Run("zero falls back to the default", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.FetchMaxWait = 0
+
+		p, err := New(cfg, nil)
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		if p.config.FetchMaxWait != processor.DefaultFetchMaxWait {
+			t.Errorf("expected fetch wait of %v, got %v", processor.DefaultFetchMaxWait, p.config.FetchMaxWait)
+		}
+	})
+
+	t.Run("configured wait is kept", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.FetchMaxWait = 250 * time.Millisecond
+
+		p, err := New(cfg, nil)
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		if p.config.FetchMaxWait != 250*time.Millisecond {
+			t.Errorf("expected fetch wait of 250ms, got %v", p.config.FetchMaxWait)
+		}
+	})
+}
+
+func TestOnPolicyUpdate_IgnoresResync(t *testing.T) {
+	policy := &v1alpha1.ActivityPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "test-policy",
+			ResourceVersion: "42",
+		},
+		Spec: v1alpha1.ActivityPolicySpec{
+			Resource: v1alpha1.ActivityPolicyResource{
+				APIGroup: "test.example.com",
+				Kind:     "TestResource",
+			},
+		},
+		Status: v1alpha1.ActivityPolicyStatus{
+			Conditions: []metav1.Condition{{Type: "Ready", Status: metav1.ConditionTrue}},
+		},
+	}
+
+	// A resync delivers the same object twice. The processor has no REST mapper,
+	// so reaching the resolve step would panic.
+	p := &Processor{policyCache: NewPolicyCache()}
+	p.onPolicyUpdate(policy, policy.DeepCopy())
+
+	if p.policyCache.Len() != 0 {
+		t.Errorf("expected resync to leave the policy cache untouched, got %d policies", p.policyCache.Len())
+	}
+}
+
+func TestResourceResolvedCondition(t *testing.T) {
+	policy := &v1alpha1.ActivityPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-policy", Generation: 3},
+		Spec: v1alpha1.ActivityPolicySpec{
+			Resource: v1alpha1.ActivityPolicyResource{
+				APIGroup: "test.example.com",
+				Kind:     "TestResource",
+			},
+		},
+	}
+	noMatch := &meta.NoKindMatchError{GroupKind: schema.GroupKind{Group: "test.example.com", Kind: "TestResource"}}
+
+	tests := []struct {
+		name       string
+		resolveErr error
+		wantStatus metav1.ConditionStatus
+		wantReason string
+	}{
+		{
+			name:       "resolved",
+			wantStatus: metav1.ConditionTrue,
+			wantReason: v1alpha1.ActivityPolicyReasonResolved,
+		},
+		{
+			name:       "kind not registered",
+			resolveErr: fmt.Errorf("failed to find resource mapping for test.example.com/TestResource: %w", noMatch),
+			wantStatus: metav1.ConditionFalse,
+			wantReason: v1alpha1.ActivityPolicyReasonResourceNotFound,
+		},
+		{
+			name:       "discovery error",
+			resolveErr: fmt.Errorf("the server is currently unable to handle the request"),
+			wantStatus: metav1.ConditionFalse,
+			wantReason: v1alpha1.ActivityPolicyReasonDiscoveryFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cond := resourceResolvedCondition(policy, "testresources", tt.resolveErr)
+
+			if cond.Type != v1alpha1.ActivityPolicyConditionResourceResolved {
+				t.Errorf("Type = %q, want %q", cond.Type, v1alpha1.ActivityPolicyConditionResourceResolved)
+			}
+			if cond.Status != tt.wantStatus {
+				t.Errorf("Status = %q, want %q", cond.Status, tt.wantStatus)
+			}
+			if cond.Reason != tt.wantReason {
+				t.Errorf("Reason = %q, want %q", cond.Reason, tt.wantReason)
+			}
+			if cond.ObservedGeneration != 3 {
+				t.Errorf("ObservedGeneration = %d, want 3", cond.ObservedGeneration)
+			}
+		})
+	}
+}
+
+func TestReportResourceResolution(t *testing.T) {
+	policy := &v1alpha1.ActivityPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-policy"},
+		Spec: v1alpha1.ActivityPolicySpec{
+			Resource: v1alpha1.ActivityPolicyResource{
+				APIGroup: "test.example.com",
+				Kind:     "TestResource",
+			},
+		},
+		Status: v1alpha1.ActivityPolicyStatus{
+			Conditions: []metav1.Condition{{Type: "Ready", Status: metav1.ConditionTrue, Reason: "Valid"}},
+		},
+	}
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(controller.Scheme).
+		WithObjects(policy).
+		WithStatusSubresource(&v1alpha1.ActivityPolicy{}).
+		Build()
+	p := &Processor{client: k8sClient, ctx: context.Background()}
+
+	get := func() *v1alpha1.ActivityPolicy {
+		var got v1alpha1.ActivityPolicy
+		if err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(policy), &got); err != nil {
+			t.Fatalf("failed to get policy: %v", err)
+		}
+		return &got
+	}
+
+	noMatch := &meta.NoKindMatchError{GroupKind: schema.GroupKind{Group: "test.example.com", Kind: "TestResource"}}
+	p.reportResourceResolution(get(), "", noMatch)
+
+	unresolved := get()
+	cond := meta.FindStatusCondition(unresolved.Status.Conditions, v1alpha1.ActivityPolicyConditionResourceResolved)
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != v1alpha1.ActivityPolicyReasonResourceNotFound {
+		t.Fatalf("expected ResourceResolved=False/ResourceNotFound, got %+v", cond)
+	}
+	if !meta.IsStatusConditionTrue(unresolved.Status.Conditions, "Ready") {
+		t.Error("expected Ready condition to be preserved")
+	}
+	if !isResourceUnresolved(unresolved) {
+		t.Error("expected policy to be reported as unresolved")
+	}
+
+	// Reporting the same outcome again must not write to the API server
+	p.reportResourceResolution(unresolved, "", noMatch)
+	if rv := get().ResourceVersion; rv != unresolved.ResourceVersion {
+		t.Errorf("expected no status write for unchanged condition, resourceVersion %s -> %s", unresolved.ResourceVersion, rv)
+	}
+
+	p.reportResourceResolution(unresolved, "testresources", nil)
+	if isResourceUnresolved(get()) {
+		t.Error("expected policy to be reported as resolved once the resource exists")
+	}
+}
+
+func TestObserveEventLag(t *testing.T) {
+	read := func() (uint64, float64) {
+		var m dto.Metric
+		if err := eventLag.Write(&m); err != nil {
+			t.Fatal(err)
+		}
+		return m.GetHistogram().GetSampleCount(), m.GetHistogram().GetSampleSum()
+	}
+
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	count, sum := read()
+
+	observeEventLag(now.Add(-90*time.Second), now)
+	observeEventLag(now.Add(time.Minute), now)
+	observeEventLag(time.Time{}, now)
+
+	gotCount, gotSum := read()
+	if gotCount-count != 2 {
+		t.Errorf("expected 2 observations, got %d", gotCount-count)
+	}
+	if gotSum-sum != 90 {
+		t.Errorf("expected 90s of lag to be recorded, got %v", gotSum-sum)
+	}
+}
@@ -0,0 +1,125 @@
+package activityprocessor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"k8s.io/klog/v2"
+
+	"go.miloapis.com/activity/internal/processor"
+	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+)
+
+// errPublishFlushTimeout is returned when JetStream doesn't acknowledge a
+// batch's publishes within the flush timeout.
+var errPublishFlushTimeout = errors.New("timed out waiting for JetStream to acknowledge published activities")
+
+// pendingPublishes collects the asynchronous publishes made while processing
+// one source message, so the message is only acked once all of them have been
+// acknowledged by JetStream.
+type pendingPublishes struct {
+	futures []nats.PubAckFuture
+	onAck   []func()
+}
+
+// add tracks an asynchronous publish.
+func (p *pendingPublishes) add(future nats.PubAckFuture) {
+	p.futures = append(p.futures, future)
+}
+
+// afterAck registers fn to run once every publish has been acknowledged.
+func (p *pendingPublishes) afterAck(fn func()) {
+	p.onAck = append(p.onAck, fn)
+}
+
+// wait blocks until every publish is acknowledged, one fails, or ctx is done.
+// The afterAck hooks only run when every publish succeeded.
+func (p *pendingPublishes) wait(ctx context.Context) error {
+	for _, future := range p.futures {
+		select {
+		case <-future.Ok():
+		case err := <-future.Err():
+			return fmt.Errorf("failed to publish to NATS: %w", err)
+		case <-ctx.Done():
+			return errPublishFlushTimeout
+		}
+	}
+
+	for _, fn := range p.onAck {
+		fn()
+	}
+	return nil
+}
+
+// processBatchAsync processes a fetched batch with asynchronous publishing,
+// then waits for JetStream to acknowledge the batch's activities. Each source
+// message is acked only when all of its publishes succeeded and is NAKed
+// otherwise; redelivered activities keep their message IDs, so copies that
+// did reach the stream are deduplicated by NATS.
+func (p *Processor) processBatchAsync(id int, msgs []*nats.Msg) {
+	pending := make([]*pendingPublishes, len(msgs))
+	for i, msg := range msgs {
+		pending[i] = &pendingPublishes{}
+		if err := p.processMessage(msg, pending[i]); err != nil {
+			klog.ErrorS(err, "Failed to process message", "worker", id)
+			pending[i] = nil
+		}
+	}
+	natsPublishInFlight.Set(float64(p.js.PublishAsyncPending()))
+
+	flushStart := time.Now()
+	ctx, cancel := context.WithTimeout(p.ctx, p.config.PublishFlushTimeout)
+	defer cancel()
+
+	for i, msg := range msgs {
+		if pending[i] == nil {
+			msg.Nak()
+			continue
+		}
+		if err := pending[i].wait(ctx); err != nil {
+			eventsErrored.WithLabelValues("audit_log", "publish").Inc()
+			klog.ErrorS(err, "Failed to publish activity, NAKing message", "worker", id)
+			msg.Nak()
+			continue
+		}
+		msg.Ack()
+	}
+
+	natsPublishFlushDuration.Observe(time.Since(flushStart).Seconds())
+	natsPublishInFlight.Set(float64(p.js.PublishAsyncPending()))
+}
+
+// publishActivityAsync queues an activity, and its alert copy when alert is
+// set, on pending without waiting for JetStream to acknowledge them. The
+// activity is counted as generated once its publishes are acknowledged.
+func (p *Processor) publishActivityAsync(subject string, data []byte, activity *v1alpha1.Activity, policy *CompiledPolicy, alert bool, pending *pendingPublishes) error {
+	// Activity name is unique per audit event, enabling NATS deduplication.
+	publishStart := time.Now()
+	future, err := p.js.PublishAsync(subject, data, nats.MsgId(activity.Name))
+	natsPublishLatency.Observe(time.Since(publishStart).Seconds())
+	if err != nil {
+		return fmt.Errorf("failed to publish to NATS: %w", err)
+	}
+	pending.add(future)
+
+	pending.afterAck(func() {
+		natsMessagesPublished.Inc()
+		activitiesGenerated.WithLabelValues(
+			policy.Name,
+			policy.APIGroup,
+			policy.Kind,
+		).Inc()
+	})
+
+	if alert && p.config.AlertSubjectPrefix != "" {
+		future, err := processor.PublishAlertAsync(p.js, p.config.AlertSubjectPrefix, activity, data)
+		if err != nil {
+			return err
+		}
+		pending.add(future)
+	}
+	return nil
+}
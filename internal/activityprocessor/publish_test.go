@@ -0,0 +1,81 @@
+package activityprocessor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// fakePubAckFuture is a PubAckFuture resolved by the test.
+type fakePubAckFuture struct {
+	ok  chan *nats.PubAck
+	err chan error
+}
+
+func newFakePubAckFuture() *fakePubAckFuture {
+	return &fakePubAckFuture{ok: make(chan *nats.PubAck, 1), err: make(chan error, 1)}
+}
+
+func (f *fakePubAckFuture) Ok() <-chan *nats.PubAck { return f.ok }
+func (f *fakePubAckFuture) Err() <-chan error       { return f.err }
+func (f *fakePubAckFuture) Msg() *nats.Msg          { return nil }
+
+func TestPendingPublishes_Wait(t *testing.T) {
+	t.Run("all acknowledged runs hooks", func(t *testing.T) {
+		acked, alerted := newFakePubAckFuture(), newFakePubAckFuture()
+		acked.ok <- &nats.PubAck{}
+		alerted.ok <- &nats.PubAck{}
+
+		var calls int
+		pending := &pendingPublishes{}
+		pending.add(acked)
+		pending.add(alerted)
+		pending.afterAck(func() { calls++ })
+
+		if err := pending.wait(context.Background()); err != nil {
+			t.Fatalf("wait() error = %v", err)
+		}
+		if calls != 1 {
+			t.Errorf("afterAck hook ran %d times, want 1", calls)
+		}
+	})
+
+	t.Run("publish error skips hooks", func(t *testing.T) {
+		acked, failed := newFakePubAckFuture(), newFakePubAckFuture()
+		acked.ok <- &nats.PubAck{}
+		failed.err <- errors.New("stream not found")
+
+		var calls int
+		pending := &pendingPublishes{}
+		pending.add(acked)
+		pending.add(failed)
+		pending.afterAck(func() { calls++ })
+
+		if err := pending.wait(context.Background()); err == nil {
+			t.Fatal("wait() error = nil, want publish error")
+		}
+		if calls != 0 {
+			t.Errorf("afterAck hook ran %d times, want 0", calls)
+		}
+	})
+
+	t.Run("missing ack times out", func(t *testing.T) {
+		pending := &pendingPublishes{}
+		pending.add(newFakePubAckFuture())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if err := pending.wait(ctx); !errors.Is(err, errPublishFlushTimeout) {
+			t.Errorf("wait() error = %v, want %v", err, errPublishFlushTimeout)
+		}
+	})
+
+	t.Run("no publishes", func(t *testing.T) {
+		if err := (&pendingPublishes{}).wait(context.Background()); err != nil {
+			t.Errorf("wait() error = %v", err)
+		}
+	})
+}
@@ -3,6 +3,7 @@ package apiserver
 import (
 	"context"
 	"fmt"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	eventsv1 "k8s.io/api/events/v1"
@@ -15,6 +16,7 @@ import (
 	"k8s.io/klog/v2"
 
 	_ "go.miloapis.com/activity/internal/metrics"
+	"go.miloapis.com/activity/internal/registry/activity/activitymetrics"
 	"go.miloapis.com/activity/internal/registry/activity/activityquery"
 	"go.miloapis.com/activity/internal/registry/activity/auditlog"
 	"go.miloapis.com/activity/internal/registry/activity/auditlogfacet"
@@ -22,10 +24,14 @@ import (
 	"go.miloapis.com/activity/internal/registry/activity/eventquery"
 	"go.miloapis.com/activity/internal/registry/activity/events"
 	"go.miloapis.com/activity/internal/registry/activity/facet"
+	"go.miloapis.com/activity/internal/registry/activity/facettrend"
 	"go.miloapis.com/activity/internal/registry/activity/policy"
 	"go.miloapis.com/activity/internal/registry/activity/preview"
 	"go.miloapis.com/activity/internal/registry/activity/record"
 	"go.miloapis.com/activity/internal/registry/activity/reindexjob"
+	"go.miloapis.com/activity/internal/registry/activity/scopestats"
+	"go.miloapis.com/activity/internal/registry/activity/selfscope"
+	"go.miloapis.com/activity/internal/registry/idempotency"
 	"go.miloapis.com/activity/internal/storage"
 	"go.miloapis.com/activity/internal/watch"
 	"go.miloapis.com/activity/pkg/apis/activity/install"
@@ -91,6 +97,20 @@ type ExtraConfig struct {
 	ClickHouseConfig storage.ClickHouseConfig
 	NATSConfig       watch.NATSConfig
 	EventsNATSConfig watch.NATSConfig
+
+	// AuditRedaction controls which audit event bodies are redacted in
+	// AuditLogQuery results.
+	AuditRedaction auditlog.RedactionConfig
+
+	// QueryIdempotencyWindow and QueryIdempotencyMaxEntries bound the cache of
+	// query results returned to retries that repeat an Idempotency-Key. A
+	// zero window disables the cache.
+	QueryIdempotencyWindow     time.Duration
+	QueryIdempotencyMaxEntries int
+
+	// VerifyProjections checks at startup that each query pattern selects
+	// the ClickHouse projection it is written for, and logs the result.
+	VerifyProjections bool
 }
 
 // Config combines generic and activity-specific configuration.
@@ -140,6 +160,10 @@ func (c completedConfig) New() (*ActivityServer, error) {
 		return nil, fmt.Errorf("failed to create ClickHouse storage: %w", err)
 	}
 
+	if c.ExtraConfig.VerifyProjections {
+		verifyProjections(clickhouseStorage)
+	}
+
 	// Create NATS watcher for Watch API (optional - returns nil if not configured)
 	watcher, err := watch.NewNATSWatcher(c.ExtraConfig.NATSConfig)
 	if err != nil {
@@ -166,9 +190,22 @@ func (c completedConfig) New() (*ActivityServer, error) {
 
 	apiGroupInfo := genericapiserver.NewDefaultAPIGroupInfo(v1alpha1.GroupName, Scheme, metav1.ParameterCodec, Codecs)
 
+	// Retried query creates with the same Idempotency-Key get the earlier
+	// result instead of re-running the query
+	queryCache := idempotency.NewCache(c.ExtraConfig.QueryIdempotencyWindow, c.ExtraConfig.QueryIdempotencyMaxEntries)
+
+	auditLogQueryStorage, err := auditlog.NewQueryStorage(clickhouseStorage, c.ExtraConfig.AuditRedaction)
+	if err != nil {
+		return nil, err
+	}
+
 	v1alpha1Storage := map[string]rest.Storage{}
-	v1alpha1Storage["auditlogqueries"] = auditlog.NewQueryStorage(clickhouseStorage)
-	v1alpha1Storage["auditlogfacetsqueries"] = auditlogfacet.NewAuditLogFacetsQueryStorage(clickhouseStorage)
+	v1alpha1Storage["auditlogqueries"] = idempotency.Wrap("auditlogqueries", auditLogQueryStorage, queryCache)
+	// auditlogqueries/export streams a query's full result set as NDJSON
+	v1alpha1Storage["auditlogqueries/"+auditlog.ExportSubresource] = auditlog.NewExportStorage(auditLogQueryStorage)
+	v1alpha1Storage["auditlogfacetsqueries"] = idempotency.Wrap("auditlogfacetsqueries", auditlogfacet.NewAuditLogFacetsQueryStorage(clickhouseStorage, c.ExtraConfig.AuditRedaction), queryCache)
+	// FacetTrendQuery for per-bucket counts of the top values of an audit log field
+	v1alpha1Storage["facettrendqueries"] = idempotency.Wrap("facettrendqueries", facettrend.NewQueryStorage(clickhouseStorage, c.ExtraConfig.AuditRedaction), queryCache)
 
 	// ActivityPolicy is stored in etcd
 	policyStorage, policyStatusStorage, err := policy.NewStorage(Scheme, c.GenericConfig.RESTOptionsGetter)
@@ -190,19 +227,38 @@ func (c completedConfig) New() (*ActivityServer, error) {
 	v1alpha1Storage["activities"] = record.NewActivityStorageWithWatcher(clickhouseStorage, watcher)
 
 	// ActivityQuery for historical queries (custom time ranges, search, CEL filters)
-	v1alpha1Storage["activityqueries"] = activityquery.NewQueryStorage(clickhouseStorage)
+	v1alpha1Storage["activityqueries"] = idempotency.Wrap("activityqueries", activityquery.NewQueryStorage(clickhouseStorage), queryCache)
 
 	// ActivityFacetQuery for faceted search on activities
-	v1alpha1Storage["activityfacetqueries"] = facet.NewFacetQueryStorage(clickhouseStorage)
+	v1alpha1Storage["activityfacetqueries"] = idempotency.Wrap("activityfacetqueries", facet.NewFacetQueryStorage(clickhouseStorage), queryCache)
+
+	// ActivityMetricsQuery for bucketed time-series counts (dashboards, Grafana)
+	v1alpha1Storage["activitymetricsqueries"] = idempotency.Wrap("activitymetricsqueries", activitymetrics.NewQueryStorage(clickhouseStorage), queryCache)
+
+	// ScopeStats for landing dashboard summaries over the last 24 hours
+	v1alpha1Storage["scopestats"] = scopestats.NewStatsStorage(clickhouseStorage)
+
+	// SelfScope reports the scope the caller's queries are limited to
+	v1alpha1Storage["selfscopes"] = selfscope.NewSelfScopeStorage()
 
 	// Create events backend using the same ClickHouse connection
 	eventsBackend := storage.NewClickHouseEventsBackend(clickhouseStorage.Conn(), storage.ClickHouseEventsConfig{
-		Database: clickhouseStorage.Config().Database,
+		Database:            clickhouseStorage.Config().Database,
+		Cluster:             clickhouseStorage.Config().Cluster,
+		MaxQueryTimeout:     clickhouseStorage.GetMaxQueryTimeout(),
+		MaxFacetGroupByRows: clickhouseStorage.Config().MaxFacetGroupByRows,
+		TenantQueryLimits:   clickhouseStorage.Config().TenantQueryLimits,
+		Now:                 clickhouseStorage.Now,
 	})
 
 	// Create EventQuery backend for PolicyPreview auto-fetch
 	eventQueryBackend := storage.NewClickHouseEventQueryBackend(clickhouseStorage.Conn(), storage.ClickHouseEventsConfig{
-		Database: clickhouseStorage.Config().Database,
+		Database:          clickhouseStorage.Config().Database,
+		Cluster:           clickhouseStorage.Config().Cluster,
+		MaxQueryTimeout:   clickhouseStorage.GetMaxQueryTimeout(),
+		RetentionWindow:   clickhouseStorage.Config().EventRetentionWindow,
+		TenantQueryLimits: clickhouseStorage.Config().TenantQueryLimits,
+		Now:               clickhouseStorage.Now,
 	})
 
 	// PolicyPreview for testing policies without persisting
@@ -239,11 +295,11 @@ func (c completedConfig) New() (*ActivityServer, error) {
 	// returning io.k8s.api.core.v1.Event with GVK [/v1, Kind=Event].
 
 	// EventFacetQuery for faceted search on Kubernetes Events
-	v1alpha1Storage["eventfacetqueries"] = eventfacet.NewEventFacetQueryStorage(eventsBackend)
+	v1alpha1Storage["eventfacetqueries"] = idempotency.Wrap("eventfacetqueries", eventfacet.NewEventFacetQueryStorage(eventsBackend), queryCache)
 
 	// EventQuery for historical event queries up to 60 days (no 24-hour limit)
 	// Note: eventQueryBackend was created earlier for PolicyPreview auto-fetch
-	v1alpha1Storage["eventqueries"] = eventquery.NewEventQueryREST(eventQueryBackend)
+	v1alpha1Storage["eventqueries"] = idempotency.Wrap("eventqueries", eventquery.NewEventQueryREST(eventQueryBackend), queryCache)
 
 	apiGroupInfo.VersionedResourcesStorageMap["v1alpha1"] = v1alpha1Storage
 
@@ -270,6 +326,32 @@ func (c completedConfig) New() (*ActivityServer, error) {
 	return s, nil
 }
 
+// verifyProjections logs which projection each query pattern selects and
+// warns about patterns that miss theirs. Startup continues either way: a
+// missed projection makes queries slower, not wrong.
+func verifyProjections(clickhouseStorage *storage.ClickHouseStorage) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	results, err := clickhouseStorage.VerifyProjections(ctx)
+	if err != nil {
+		klog.Warningf("Skipping projection verification: %v", err)
+		return
+	}
+
+	for _, result := range results {
+		switch {
+		case result.Err != nil:
+			klog.Warningf("Could not verify projection for %s: %v", result.Pattern, result.Err)
+		case result.OK():
+			klog.InfoS("Query pattern uses its projection", "pattern", result.Pattern, "projection", result.Expected)
+		default:
+			klog.Warningf("Query pattern %q does not use projection %s (plan selects %v); these queries read more data than they should. Check that the projection exists and that its sort key matches the query's ORDER BY.",
+				result.Pattern, result.Expected, result.Selected)
+		}
+	}
+}
+
 // installLegacyCoreAPIGroup installs the legacy core/v1 API group for Events.
 // This allows serving Events under /api/v1/namespaces/{ns}/events (in addition to activity.miloapis.com).
 func (s *ActivityServer) installLegacyCoreAPIGroup(eventsBackend *storage.ClickHouseEventsBackend) error {
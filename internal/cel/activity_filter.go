@@ -122,9 +122,19 @@ func (m *ActivityFieldMapper) mapNestedField(baseName, parentField, field string
 	case baseName == "spec" && parentField == "resource" && field == "uid":
 		return "resource_uid", nil
 
+	// spec.tenant.*
+	case baseName == "spec" && parentField == "tenant" && field == "type":
+		return "tenant_type", nil
+	case baseName == "spec" && parentField == "tenant" && field == "name":
+		return "tenant_name", nil
+
 	// spec.origin.*
 	case baseName == "spec" && parentField == "origin" && field == "type":
 		return "origin_type", nil
+	case baseName == "spec" && parentField == "origin" && field == "policyName":
+		return "origin_policy", nil
+	case baseName == "spec" && parentField == "origin" && field == "ruleIndex":
+		return "origin_rule_index", nil
 
 	default:
 		return "", fmt.Errorf("field '%s.%s.%s' is not available for filtering", baseName, parentField, field)
@@ -148,6 +158,11 @@ func (m *ActivityFieldMapper) mapDirectField(baseName, field string) (string, er
 	}
 }
 
+// IsStringField reports whether an activity field is stored as a string column.
+func (m *ActivityFieldMapper) IsStringField(sel *expr.Expr_Select) bool {
+	return !activityNonStringFields[selectPath(sel)]
+}
+
 // ActivityEnvironment creates a CEL environment for activity filtering.
 //
 // Available fields:
@@ -162,10 +177,14 @@ func (m *ActivityFieldMapper) mapDirectField(baseName, field string) (string, er
 //   - spec.resource.uid - resource UID
 //   - spec.summary - activity summary text
 //   - spec.origin.type - origin type (audit/event)
+//   - spec.origin.policyName - name of the policy that generated the activity
+//   - spec.origin.ruleIndex - index of the matching rule within the policy
+//   - spec.tenant.type - tenant type (organization, project, user)
+//   - spec.tenant.name - tenant name
 //   - metadata.namespace - activity namespace
 //
 // Supports standard CEL operators (==, !=, &&, ||, !, in) and string methods
-// (startsWith, endsWith, contains).
+// (startsWith, endsWith, contains) on string fields.
 func ActivityEnvironment() (*cel.Env, error) {
 	specType := cel.MapType(cel.StringType, cel.DynType)
 	metadataType := cel.MapType(cel.StringType, cel.DynType)
@@ -185,6 +204,7 @@ var activityValidFields = map[string]map[string]bool{
 		"actor":    true,
 		"resource": true,
 		"origin":   true,
+		"tenant":   true,
 	},
 	"spec.actor": {
 		"name": true,
@@ -199,7 +219,13 @@ var activityValidFields = map[string]map[string]bool{
 		"uid":       true,
 	},
 	"spec.origin": {
+		"type":       true,
+		"policyName": true,
+		"ruleIndex":  true,
+	},
+	"spec.tenant": {
 		"type": true,
+		"name": true,
 	},
 	"metadata": {
 		"namespace": true,
@@ -207,6 +233,12 @@ var activityValidFields = map[string]map[string]bool{
 	},
 }
 
+// activityNonStringFields lists the activity fields that are not stored as
+// strings. String methods are rejected on them.
+var activityNonStringFields = map[string]bool{
+	"spec.origin.ruleIndex": true,
+}
+
 // CompileActivityFilter compiles and validates a CEL filter expression for activities.
 // Returns user-friendly error messages with helpful context.
 func CompileActivityFilter(filterExpr string) (*cel.Ast, error) {
@@ -223,7 +255,15 @@ func CompileActivityFilter(filterExpr string) (*cel.Ast, error) {
 		return nil, fmt.Errorf("unable to process filter expression. Try again or contact support if the problem persists")
 	}
 
-	ast, issues := env.Compile(filterExpr)
+	ast, issues := env.Parse(filterExpr)
+	if issues == nil || issues.Err() == nil {
+		if err := checkFilterComplexity(ast.Expr()); err != nil {
+			metrics.CELFilterErrors.WithLabelValues("too_complex").Inc()
+			metrics.CELFilterParseDuration.Observe(time.Since(startTime).Seconds())
+			return nil, err
+		}
+		ast, issues = env.Check(ast)
+	}
 	if issues != nil && issues.Err() != nil {
 		metrics.CELFilterErrors.WithLabelValues("compilation").Inc()
 		metrics.CELFilterParseDuration.Observe(time.Since(startTime).Seconds())
@@ -244,10 +284,30 @@ func CompileActivityFilter(filterExpr string) (*cel.Ast, error) {
 		return nil, fmt.Errorf("%s", formatActivityFilterError(err))
 	}
 
+	// Validate that string methods are only applied to string fields
+	if err := ValidateStringOperators(ast.Expr(), &ActivityFieldMapper{}); err != nil {
+		metrics.CELFilterErrors.WithLabelValues("invalid_field").Inc()
+		metrics.CELFilterParseDuration.Observe(time.Since(startTime).Seconds())
+		return nil, fmt.Errorf("%s", formatActivityFilterError(err))
+	}
+
 	metrics.CELFilterParseDuration.Observe(time.Since(startTime).Seconds())
 	return ast, nil
 }
 
+// NormalizeActivityFilter returns an activity filter in canonical form; see
+// NormalizeFilter.
+func NormalizeActivityFilter(filterExpr string) (string, error) {
+	if filterExpr == "" {
+		return "", nil
+	}
+	env, err := ActivityEnvironment()
+	if err != nil {
+		return "", err
+	}
+	return normalizeExpression(env, filterExpr)
+}
+
 // CompiledActivityFilter holds a pre-compiled CEL program for reuse in watch operations.
 type CompiledActivityFilter struct {
 	program cel.Program
@@ -281,6 +341,11 @@ func CompileActivityFilterProgram(filterExpr string) (*CompiledActivityFilter, e
 		return nil, fmt.Errorf("%s", formatActivityFilterError(err))
 	}
 
+	// Validate that string methods are only applied to string fields
+	if err := ValidateStringOperators(ast.Expr(), &ActivityFieldMapper{}); err != nil {
+		return nil, fmt.Errorf("%s", formatActivityFilterError(err))
+	}
+
 	// Create program from compiled AST
 	program, err := env.Program(ast)
 	if err != nil {
@@ -335,7 +400,13 @@ func ActivityToMap(activity *v1alpha1.Activity) map[string]interface{} {
 				"uid":       activity.Spec.Resource.UID,
 			},
 			"origin": map[string]interface{}{
-				"type": activity.Spec.Origin.Type,
+				"type":       activity.Spec.Origin.Type,
+				"policyName": activity.Spec.Origin.PolicyName,
+				"ruleIndex":  originRuleIndex(activity.Spec.Origin.RuleIndex),
+			},
+			"tenant": map[string]interface{}{
+				"type": activity.Spec.Tenant.Type,
+				"name": activity.Spec.Tenant.Name,
 			},
 		},
 		"metadata": map[string]interface{}{
@@ -345,6 +416,15 @@ func ActivityToMap(activity *v1alpha1.Activity) map[string]interface{} {
 	}
 }
 
+// originRuleIndex returns the rule index for filter evaluation, using -1 when
+// it wasn't recorded to match the origin_rule_index column.
+func originRuleIndex(ruleIndex *int32) int64 {
+	if ruleIndex == nil {
+		return -1
+	}
+	return int64(*ruleIndex)
+}
+
 // formatActivityFilterError formats error messages for activity filter expressions
 func formatActivityFilterError(err error) string {
 	errMsg := err.Error()
@@ -359,6 +439,8 @@ Available fields for activity filtering:
   - spec.resource.apiGroup, spec.resource.kind, spec.resource.name
   - spec.resource.namespace, spec.resource.uid
   - spec.summary, spec.origin.type
+  - spec.origin.policyName, spec.origin.ruleIndex
+  - spec.tenant.type, spec.tenant.name
   - metadata.namespace, metadata.name
 
 Example: spec.changeSource == "human" && spec.resource.kind == "Deployment"`, errMsg)
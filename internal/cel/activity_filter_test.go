@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
 )
@@ -76,6 +77,11 @@ func TestCompileActivityFilterProgram(t *testing.T) {
 			filter:  `spec.changeSource`,
 			wantErr: true,
 		},
+		{
+			name:    "string method on non-string field",
+			filter:  `spec.origin.ruleIndex.startsWith("1")`,
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -204,7 +210,9 @@ func TestEvaluateActivity(t *testing.T) {
 				UID:       "deployment-uid-456",
 			},
 			Origin: v1alpha1.ActivityOrigin{
-				Type: "audit",
+				Type:       "audit",
+				PolicyName: "deployments",
+				RuleIndex:  ptr.To(int32(2)),
 			},
 		},
 	}
@@ -405,6 +413,18 @@ func TestEvaluateActivity(t *testing.T) {
 			activity: humanDeploymentActivity,
 			want:     true,
 		},
+		{
+			name:     "origin policy and rule filter",
+			filter:   `spec.origin.policyName == "deployments" && spec.origin.ruleIndex == 2`,
+			activity: humanDeploymentActivity,
+			want:     true,
+		},
+		{
+			name:     "origin rule filter - rule not recorded",
+			filter:   `spec.origin.ruleIndex == 0`,
+			activity: systemPodActivity,
+			want:     false,
+		},
 		{
 			name:     "summary contains filter",
 			filter:   `spec.summary.contains("created")`,
@@ -417,6 +437,18 @@ func TestEvaluateActivity(t *testing.T) {
 			activity: humanDeploymentActivity,
 			want:     true,
 		},
+		{
+			name:     "summary shorter than threshold",
+			filter:   `size(spec.summary) < 10`,
+			activity: humanDeploymentActivity,
+			want:     false,
+		},
+		{
+			name:     "summary not empty",
+			filter:   `spec.summary.size() > 0`,
+			activity: humanDeploymentActivity,
+			want:     true,
+		},
 		{
 			name:     "resource name filter",
 			filter:   `spec.resource.name == "nginx"`,
@@ -524,6 +556,77 @@ func TestConvertActivityToClickHouseSQL_ChangeSource(t *testing.T) {
 	}
 }
 
+func TestConvertActivityToClickHouseSQL_Origin(t *testing.T) {
+	sql, args, err := ConvertActivityToClickHouseSQL(context.Background(),
+		`spec.origin.policyName == "deployments" && spec.origin.ruleIndex == 2`)
+	if err != nil {
+		t.Fatalf("ConvertActivityToClickHouseSQL() error = %v", err)
+	}
+
+	if !contains(sql, "origin_policy = {arg") || !contains(sql, "origin_rule_index = {arg") {
+		t.Errorf("SQL = %q, want origin_policy and origin_rule_index comparisons", sql)
+	}
+	if len(args) != 2 || args[0] != "deployments" || args[1] != int64(2) {
+		t.Errorf("args = %#v, want [deployments 2]", args)
+	}
+}
+
+func TestConvertActivityToClickHouseSQL_Tenant(t *testing.T) {
+	sql, args, err := ConvertActivityToClickHouseSQL(context.Background(),
+		`spec.tenant.type == "project" && spec.tenant.name == "web"`)
+	if err != nil {
+		t.Fatalf("ConvertActivityToClickHouseSQL() error = %v", err)
+	}
+
+	if !contains(sql, "tenant_type = {arg") || !contains(sql, "tenant_name = {arg") {
+		t.Errorf("SQL = %q, want tenant_type and tenant_name comparisons", sql)
+	}
+	if len(args) != 2 || args[0] != "project" || args[1] != "web" {
+		t.Errorf("args = %#v, want [project web]", args)
+	}
+}
+
+func TestConvertActivityToClickHouseSQL_StringMethods(t *testing.T) {
+	sql, args, err := ConvertActivityToClickHouseSQL(context.Background(),
+		`spec.resource.name.startsWith("prod-") && spec.resource.namespace.endsWith("-staging")`)
+	if err != nil {
+		t.Fatalf("ConvertActivityToClickHouseSQL() error = %v", err)
+	}
+
+	if !contains(sql, "startsWith(resource_name, {arg") || !contains(sql, "endsWith(resource_namespace, {arg") {
+		t.Errorf("SQL = %q, want startsWith and endsWith calls", sql)
+	}
+	if len(args) != 2 || args[0] != "prod-" || args[1] != "-staging" {
+		t.Errorf("args = %#v, want [prod- -staging]", args)
+	}
+
+	_, _, err = ConvertActivityToClickHouseSQL(context.Background(), `spec.origin.ruleIndex.endsWith("0")`)
+	if err == nil || !contains(err.Error(), "'spec.origin.ruleIndex' is not a string") {
+		t.Errorf("error = %v, want a not-a-string error for spec.origin.ruleIndex", err)
+	}
+}
+
+func TestConvertActivityToClickHouseSQL_SummaryLength(t *testing.T) {
+	sql, args, err := ConvertActivityToClickHouseSQL(context.Background(),
+		`spec.origin.policyName == "deployments" && (size(spec.summary) < 20 || spec.summary.size() == 0)`)
+	if err != nil {
+		t.Fatalf("ConvertActivityToClickHouseSQL() error = %v", err)
+	}
+
+	if !contains(sql, "lengthUTF8(summary) < {arg2}") || !contains(sql, "lengthUTF8(summary) = {arg3}") {
+		t.Errorf("SQL = %q, want lengthUTF8 comparisons on the summary", sql)
+	}
+	if len(args) != 3 || args[1] != int64(20) || args[2] != int64(0) {
+		t.Errorf("args = %#v, want [deployments 20 0]", args)
+	}
+
+	for _, filter := range []string{`size(spec.origin.ruleIndex) > 1`, `size(["a"]) == 1`} {
+		if _, _, err := ConvertActivityToClickHouseSQL(context.Background(), filter); err == nil || !contains(err.Error(), "size() only works on string fields") {
+			t.Errorf("%s: error = %v, want a string fields error", filter, err)
+		}
+	}
+}
+
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(substr) == 0 ||
 		(len(s) > 0 && len(substr) > 0 && findSubstring(s, substr)))
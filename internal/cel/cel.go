@@ -41,6 +41,175 @@ type FieldMapper interface {
 	MapIdentExpr(ident *expr.Expr_Ident) (string, error)
 }
 
+// PresenceMapper is an optional FieldMapper extension for domains that support
+// CEL's has() macro. Materialized ClickHouse columns store missing values as
+// empty strings or zero, so presence tests compile to non-empty checks rather
+// than IS NOT NULL.
+type PresenceMapper interface {
+	// MapPresenceTest converts a has(x.y) test into a ClickHouse condition.
+	MapPresenceTest(sel *expr.Expr_Select) (string, error)
+}
+
+// ArrayFieldMapper is an optional FieldMapper extension for domains with
+// array-typed columns. Array fields only support membership tests, which
+// compile to ClickHouse's has(column, value).
+type ArrayFieldMapper interface {
+	// IsArrayField reports whether the selected field maps to an array column.
+	IsArrayField(sel *expr.Expr_Select) bool
+}
+
+// StringFieldMapper is an optional FieldMapper extension for domains with
+// non-string columns behind dynamically typed maps. CEL can't type-check those
+// fields, so a string method on one would only fail once it reached ClickHouse.
+type StringFieldMapper interface {
+	// IsStringField reports whether the selected field maps to a string column.
+	IsStringField(sel *expr.Expr_Select) bool
+}
+
+// JSONDocumentMapper is an optional FieldMapper extension for domains that
+// keep the raw event JSON alongside the materialized columns. It enables
+// jsonExtract(), which reads arbitrary paths from that document.
+type JSONDocumentMapper interface {
+	// JSONDocumentColumn returns the column holding the raw event JSON.
+	JSONDocumentColumn() string
+}
+
+// stringMethods are the CEL string methods that compile to ClickHouse string functions.
+var stringMethods = map[string]bool{
+	"startsWith": true,
+	"endsWith":   true,
+	"contains":   true,
+	"size":       true,
+}
+
+// stringOperand returns the string a string function applies to: the target
+// of a method call, or the argument of the global size(x) form. Returns nil
+// when there is none.
+func stringOperand(call *expr.Expr_Call) *expr.Expr {
+	if call.Target != nil {
+		return call.Target
+	}
+	if call.Function == "size" && len(call.Args) == 1 {
+		return call.Args[0]
+	}
+	return nil
+}
+
+// ValidateStringOperators checks that the string functions startsWith(),
+// endsWith(), contains() and size() are only called on string fields.
+func ValidateStringOperators(e *expr.Expr, mapper StringFieldMapper) error {
+	if e == nil {
+		return nil
+	}
+
+	switch exprKind := e.ExprKind.(type) {
+	case *expr.Expr_CallExpr:
+		call := exprKind.CallExpr
+		if stringMethods[call.Function] {
+			if sel := stringOperand(call).GetSelectExpr(); sel != nil && !sel.GetTestOnly() && !mapper.IsStringField(sel) {
+				return fmt.Errorf("%s() only works on string fields, but '%s' is not a string. Use a comparison operator like == or >= instead",
+					call.Function, selectPath(sel))
+			}
+		}
+		if err := ValidateStringOperators(call.Target, mapper); err != nil {
+			return err
+		}
+		for _, arg := range call.Args {
+			if err := ValidateStringOperators(arg, mapper); err != nil {
+				return err
+			}
+		}
+
+	case *expr.Expr_ListExpr:
+		for _, elem := range exprKind.ListExpr.Elements {
+			if err := ValidateStringOperators(elem, mapper); err != nil {
+				return err
+			}
+		}
+
+	case *expr.Expr_ComprehensionExpr:
+		comp := exprKind.ComprehensionExpr
+		for _, sub := range []*expr.Expr{comp.IterRange, comp.AccuInit, comp.LoopCondition, comp.LoopStep, comp.Result} {
+			if err := ValidateStringOperators(sub, mapper); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateArrayOperators checks that array fields are only used as the right
+// side of the 'in' operator, and that 'in' is only applied to list literals or
+// array fields. Scalar operators such as == or startsWith() have no meaning on
+// an array column.
+func ValidateArrayOperators(e *expr.Expr, mapper ArrayFieldMapper) error {
+	if e == nil {
+		return nil
+	}
+
+	switch exprKind := e.ExprKind.(type) {
+	case *expr.Expr_SelectExpr:
+		sel := exprKind.SelectExpr
+		if !sel.GetTestOnly() && mapper.IsArrayField(sel) {
+			name := selectPath(sel)
+			return fmt.Errorf("field '%s' is a list and only supports membership tests, e.g. 'value' in %s", name, name)
+		}
+
+	case *expr.Expr_CallExpr:
+		call := exprKind.CallExpr
+		if call.Function == "@in" && len(call.Args) == 2 {
+			if err := ValidateArrayOperators(call.Args[0], mapper); err != nil {
+				return err
+			}
+			right := call.Args[1]
+			if sel := right.GetSelectExpr(); sel != nil && !sel.GetTestOnly() && mapper.IsArrayField(sel) {
+				return nil
+			}
+			if right.GetListExpr() == nil {
+				return fmt.Errorf("the right side of 'in' must be a list literal like ['a', 'b'] or a list field")
+			}
+			return ValidateArrayOperators(right, mapper)
+		}
+		if err := ValidateArrayOperators(call.Target, mapper); err != nil {
+			return err
+		}
+		for _, arg := range call.Args {
+			if err := ValidateArrayOperators(arg, mapper); err != nil {
+				return err
+			}
+		}
+
+	case *expr.Expr_ListExpr:
+		for _, elem := range exprKind.ListExpr.Elements {
+			if err := ValidateArrayOperators(elem, mapper); err != nil {
+				return err
+			}
+		}
+
+	case *expr.Expr_ComprehensionExpr:
+		comp := exprKind.ComprehensionExpr
+		for _, sub := range []*expr.Expr{comp.IterRange, comp.AccuInit, comp.LoopCondition, comp.LoopStep, comp.Result} {
+			if err := ValidateArrayOperators(sub, mapper); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// selectPath returns the dotted path of a select expression, e.g. "user.groups".
+func selectPath(sel *expr.Expr_Select) string {
+	if ident := sel.GetOperand().GetIdentExpr(); ident != nil {
+		return ident.GetName() + "." + sel.GetField()
+	}
+	if inner := sel.GetOperand().GetSelectExpr(); inner != nil {
+		return selectPath(inner) + "." + sel.GetField()
+	}
+	return sel.GetField()
+}
+
 // ValidateFieldAccess recursively validates that only allowed fields are accessed
 // in a CEL expression. It uses the provided FieldValidator for domain-specific
 // field validation.
@@ -157,7 +326,11 @@ func (c *BaseSQLConverter) ConvertExpr(e *expr.Expr) (string, error) {
 	case *expr.Expr_ConstExpr:
 		return c.convertConstExpr(e.GetConstExpr())
 	case *expr.Expr_SelectExpr:
-		return c.mapper.MapSelectExpr(e.GetSelectExpr())
+		sel := e.GetSelectExpr()
+		if sel.GetTestOnly() {
+			return c.convertPresenceTest(sel)
+		}
+		return c.mapper.MapSelectExpr(sel)
 	case *expr.Expr_ListExpr:
 		return c.convertListExpr(e.GetListExpr())
 	default:
@@ -219,6 +392,15 @@ func (c *BaseSQLConverter) convertCallExpr(call *expr.Expr_Call) (string, error)
 		if err != nil {
 			return "", err
 		}
+		if arrays, ok := c.mapper.(ArrayFieldMapper); ok {
+			if sel := call.Args[1].GetSelectExpr(); sel != nil && arrays.IsArrayField(sel) {
+				column, err := c.mapper.MapSelectExpr(sel)
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("has(%s, %s)", column, left), nil
+			}
+		}
 		right, err := c.ConvertExpr(call.Args[1])
 		if err != nil {
 			return "", err
@@ -264,6 +446,23 @@ func (c *BaseSQLConverter) convertCallExpr(call *expr.Expr_Call) (string, error)
 			return fmt.Sprintf("position(%s, %s) > 0", target, substring), nil
 		}
 
+	case "size":
+		// CEL counts a string's code points rather than its bytes, as
+		// lengthUTF8 does. Only fields and jsonExtract() have a column to
+		// measure; list sizes have no SQL equivalent here.
+		operand := stringOperand(call)
+		if operand.GetSelectExpr() != nil || operand.GetCallExpr() != nil {
+			column, err := c.ConvertExpr(operand)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("lengthUTF8(%s)", column), nil
+		}
+		return "", fmt.Errorf("size() only works on string fields")
+
+	case jsonExtractFunction:
+		return c.convertJSONExtract(call)
+
 	case "timestamp":
 		if len(call.Args) == 1 {
 			if constExpr := call.Args[0].GetConstExpr(); constExpr != nil {
@@ -281,6 +480,38 @@ func (c *BaseSQLConverter) convertCallExpr(call *expr.Expr_Call) (string, error)
 	return "", fmt.Errorf("unsupported CEL function: %s", call.Function)
 }
 
+// convertPresenceTest converts a has() macro into a presence check when the
+// domain mapper supports it.
+func (c *BaseSQLConverter) convertPresenceTest(sel *expr.Expr_Select) (string, error) {
+	presence, ok := c.mapper.(PresenceMapper)
+	if !ok {
+		return "", fmt.Errorf("has() is not supported in this filter")
+	}
+	return presence.MapPresenceTest(sel)
+}
+
+// convertJSONExtract converts jsonExtract('a.b') into a JSONExtractString call
+// on the domain's raw JSON column, binding each path key as a parameter.
+func (c *BaseSQLConverter) convertJSONExtract(call *expr.Expr_Call) (string, error) {
+	docs, ok := c.mapper.(JSONDocumentMapper)
+	if !ok {
+		return "", fmt.Errorf("jsonExtract() is not supported in this filter")
+	}
+	if len(call.Args) != 1 || call.Args[0].GetConstExpr() == nil {
+		return "", fmt.Errorf("jsonExtract() takes a single string literal path")
+	}
+	keys, err := ParseJSONPath(call.Args[0].GetConstExpr().GetStringValue())
+	if err != nil {
+		return "", err
+	}
+
+	params := make([]string, len(keys))
+	for i, key := range keys {
+		params[i] = c.addArg(key)
+	}
+	return fmt.Sprintf("JSONExtractString(%s, %s)", docs.JSONDocumentColumn(), strings.Join(params, ", ")), nil
+}
+
 func (c *BaseSQLConverter) convertBinaryOp(call *expr.Expr_Call, op string) (string, error) {
 	left, err := c.ConvertExpr(call.Args[0])
 	if err != nil {
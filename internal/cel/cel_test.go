@@ -52,6 +52,25 @@ func TestCELFilterWorkflow(t *testing.T) {
 			wantArgCount: 1,
 			wantErr:      false,
 		},
+		{
+			name:         "request duration",
+			filter:       "durationMs > 1000 && verb == 'list'",
+			wantSQL:      "(duration_ms > {arg1} AND verb = {arg2})",
+			wantArgCount: 2,
+			wantErr:      false,
+		},
+		{
+			name:         "audit level",
+			filter:       "level == 'RequestResponse'",
+			wantSQL:      "level = {arg1}",
+			wantArgCount: 1,
+			wantErr:      false,
+		},
+		{
+			name:    "request duration compared to a string",
+			filter:  "durationMs > '1000'",
+			wantErr: true,
+		},
 		{
 			name:         "nested fields",
 			filter:       "objectRef.resource == 'pods' && objectRef.name == 'my-pod'",
@@ -66,6 +85,30 @@ func TestCELFilterWorkflow(t *testing.T) {
 			wantArgCount: 1,
 			wantErr:      false,
 		},
+		{
+			name:         "string method - resource name prefix",
+			filter:       "objectRef.name.startsWith('prod-')",
+			wantSQL:      "startsWith(resource_name, {arg1})",
+			wantArgCount: 1,
+			wantErr:      false,
+		},
+		{
+			name:         "string method - endsWith",
+			filter:       "objectRef.name.endsWith('-canary')",
+			wantSQL:      "endsWith(resource_name, {arg1})",
+			wantArgCount: 1,
+			wantErr:      false,
+		},
+		{
+			name:    "string method rejected on status code",
+			filter:  "responseStatus.code.startsWith('4')",
+			wantErr: true,
+		},
+		{
+			name:    "string method rejected on request duration",
+			filter:  "durationMs.endsWith('00')",
+			wantErr: true,
+		},
 		{
 			name:         "string method - contains",
 			filter:       "objectRef.namespace.contains('prod')",
@@ -114,6 +157,134 @@ func TestCELFilterWorkflow(t *testing.T) {
 			wantArgCount: 2,
 			wantErr:      false,
 		},
+		{
+			name:         "has() on optional objectRef field",
+			filter:       "has(objectRef.resource)",
+			wantSQL:      "resource != ''",
+			wantArgCount: 0,
+			wantErr:      false,
+		},
+		{
+			name:         "negated has() on responseStatus",
+			filter:       "!has(responseStatus.code)",
+			wantSQL:      "NOT (status_code != 0)",
+			wantArgCount: 0,
+			wantErr:      false,
+		},
+		{
+			name:         "response message contains",
+			filter:       "responseStatus.code >= 400 && responseStatus.message.contains('admission webhook')",
+			wantSQL:      "(status_code >= {arg1} AND position(status_message, {arg2}) > 0)",
+			wantArgCount: 2,
+			wantErr:      false,
+		},
+		{
+			name:         "has() on response message",
+			filter:       "has(responseStatus.message)",
+			wantSQL:      "status_message != ''",
+			wantArgCount: 0,
+			wantErr:      false,
+		},
+		{
+			name:         "has() combined with comparison",
+			filter:       "has(objectRef.name) && verb == 'get'",
+			wantSQL:      "(resource_name != '' AND verb = {arg1})",
+			wantArgCount: 1,
+			wantErr:      false,
+		},
+		{
+			name:    "has() rejected on always-present field",
+			filter:  "has(user.username)",
+			wantErr: true,
+		},
+		{
+			name:    "has() rejected on unknown field",
+			filter:  "has(objectRef.uid)",
+			wantErr: true,
+		},
+		{
+			name:         "main resource only",
+			filter:       "objectRef.resource == 'pods' && !has(objectRef.subresource)",
+			wantSQL:      "(resource = {arg1} AND NOT (subresource != ''))",
+			wantArgCount: 1,
+			wantErr:      false,
+		},
+		{
+			name:         "subresource",
+			filter:       "objectRef.subresource == 'status'",
+			wantSQL:      "subresource = {arg1}",
+			wantArgCount: 1,
+			wantErr:      false,
+		},
+		{
+			name:         "non-resource request URI prefix",
+			filter:       "!has(objectRef.resource) && requestURI.startsWith('/metrics')",
+			wantSQL:      "(NOT (resource != '') AND startsWith(request_uri, {arg1}))",
+			wantArgCount: 1,
+			wantErr:      false,
+		},
+		{
+			name:         "request URI contains",
+			filter:       "requestURI.contains('?watch=true')",
+			wantSQL:      "position(request_uri, {arg1}) > 0",
+			wantArgCount: 1,
+			wantErr:      false,
+		},
+		{
+			name:         "authorization denials",
+			filter:       "authzDecision == 'forbid' && responseStatus.code == 403",
+			wantSQL:      "(authz_decision = {arg1} AND status_code = {arg2})",
+			wantArgCount: 2,
+			wantErr:      false,
+		},
+		{
+			name:         "webhook rejections",
+			filter:       "admissionWebhook != '' && responseStatus.code >= 400",
+			wantSQL:      "(admission_webhook != {arg1} AND status_code >= {arg2})",
+			wantArgCount: 2,
+			wantErr:      false,
+		},
+		{
+			name:         "group membership",
+			filter:       "'admins' in user.groups",
+			wantSQL:      "has(user_groups, {arg1})",
+			wantArgCount: 1,
+			wantErr:      false,
+		},
+		{
+			name:         "group membership combined with verb",
+			filter:       "!('system:masters' in user.groups) && verb == 'delete'",
+			wantSQL:      "(NOT (has(user_groups, {arg1})) AND verb = {arg2})",
+			wantArgCount: 2,
+			wantErr:      false,
+		},
+		{
+			name:         "has() on user groups",
+			filter:       "has(user.groups)",
+			wantSQL:      "notEmpty(user_groups)",
+			wantArgCount: 0,
+			wantErr:      false,
+		},
+		{
+			name:    "equality rejected on list field",
+			filter:  "user.groups == 'admins'",
+			wantErr: true,
+		},
+		{
+			name:    "string function rejected on list field",
+			filter:  "user.groups.contains('admins')",
+			wantErr: true,
+		},
+		{
+			name:    "list field rejected on left side of in",
+			filter:  "user.groups in ['admins']",
+			wantErr: true,
+		},
+		{
+			name:    "in rejected on scalar field",
+			filter:  "'alice' in user.username",
+			wantErr: true,
+		},
 		{
 			name:         "NOT operator - simple negation",
 			filter:       "!(verb == 'get')",
@@ -142,6 +313,42 @@ func TestCELFilterWorkflow(t *testing.T) {
 			wantArgCount: 1,
 			wantErr:      false,
 		},
+		{
+			name:         "jsonExtract dotted path",
+			filter:       "jsonExtract('requestObject.spec.replicas') == '3'",
+			wantSQL:      "JSONExtractString(event_json, {arg1}, {arg2}, {arg3}) = {arg4}",
+			wantArgCount: 4,
+			wantErr:      false,
+		},
+		{
+			name:         "jsonExtract bracketed annotation key",
+			filter:       "jsonExtract(\"annotations['authorization.k8s.io/decision']\") == 'forbid'",
+			wantSQL:      "JSONExtractString(event_json, {arg1}, {arg2}) = {arg3}",
+			wantArgCount: 3,
+			wantErr:      false,
+		},
+		{
+			name:         "jsonExtract with string method",
+			filter:       "jsonExtract('userAgent').startsWith('kubectl/')",
+			wantSQL:      "startsWith(JSONExtractString(event_json, {arg1}), {arg2})",
+			wantArgCount: 2,
+			wantErr:      false,
+		},
+		{
+			name:    "jsonExtract rejects SQL in the path",
+			filter:  "jsonExtract(\"userAgent') OR 1=1 --\") == 'x'",
+			wantErr: true,
+		},
+		{
+			name:    "jsonExtract rejects empty path segments",
+			filter:  "jsonExtract('requestObject..spec') == 'x'",
+			wantErr: true,
+		},
+		{
+			name:    "jsonExtract requires a literal path",
+			filter:  "jsonExtract(verb) == 'x'",
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -221,6 +428,11 @@ func TestCELFilterCompilation(t *testing.T) {
 			filter:  "user.startsWith('system:')",
 			wantErr: true,
 		},
+		{
+			name:    "string method on non-string field",
+			filter:  "responseStatus.code.endsWith('04')",
+			wantErr: true,
+		},
 		{
 			name:    "invalid field - objectRef.resources (should be resource singular)",
 			filter:  `objectRef.resources == "domains"`,
@@ -418,3 +630,84 @@ func TestCompileFilterErrorMessages(t *testing.T) {
 		})
 	}
 }
+
+// TestParseJSONPath tests the path syntax accepted by jsonExtract().
+func TestParseJSONPath(t *testing.T) {
+	tests := []struct {
+		path     string
+		wantKeys []string
+		wantErr  bool
+	}{
+		{path: "userAgent", wantKeys: []string{"userAgent"}},
+		{path: "requestObject.spec.replicas", wantKeys: []string{"requestObject", "spec", "replicas"}},
+		{path: "annotations['authorization.k8s.io/decision']", wantKeys: []string{"annotations", "authorization.k8s.io/decision"}},
+		{path: "['a.b'].c", wantKeys: []string{"a.b", "c"}},
+		{path: "", wantErr: true},
+		{path: "spec.", wantErr: true},
+		{path: ".spec", wantErr: true},
+		{path: "spec[0]", wantErr: true},
+		{path: "annotations['unterminated", wantErr: true},
+		{path: "annotations['it''s']", wantErr: true},
+		{path: "a,b", wantErr: true},
+		{path: "a.b.c.d.e.f.g.h.i.j.k", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			keys, err := ParseJSONPath(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseJSONPath(%q) error = %v, wantErr %v", tt.path, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if strings.Join(keys, "|") != strings.Join(tt.wantKeys, "|") {
+				t.Errorf("ParseJSONPath(%q) = %q, want %q", tt.path, keys, tt.wantKeys)
+			}
+		})
+	}
+}
+
+// TestUsesJSONExtract tests detection of jsonExtract() calls used to gate the function.
+func TestUsesJSONExtract(t *testing.T) {
+	tests := []struct {
+		filter string
+		want   bool
+	}{
+		{filter: "", want: false},
+		{filter: "verb == 'delete'", want: false},
+		{filter: "verb == 'delete' && jsonExtract('userAgent') == 'kubectl'", want: true},
+		{filter: "jsonExtract('userAgent').contains('kubectl')", want: true},
+		{filter: "verb == 'jsonExtract'", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := UsesJSONExtract(tt.filter); got != tt.want {
+			t.Errorf("UsesJSONExtract(%q) = %v, want %v", tt.filter, got, tt.want)
+		}
+	}
+}
+
+// TestJSONExtractReadsObjectBody tests detection of jsonExtract() paths into
+// request and response object bodies.
+func TestJSONExtractReadsObjectBody(t *testing.T) {
+	tests := []struct {
+		filter string
+		want   bool
+	}{
+		{filter: "", want: false},
+		{filter: "verb == 'delete'", want: false},
+		{filter: "jsonExtract('userAgent') == 'kubectl'", want: false},
+		{filter: "jsonExtract('requestObject.data.password') == 'hunter2'", want: true},
+		{filter: "verb == 'get' && jsonExtract('responseObject.data.token').startsWith('ey')", want: true},
+		{filter: "jsonExtract('requestObject') != ''", want: true},
+		{filter: "jsonExtract(\"['responseObject'].data\") != ''", want: true},
+		{filter: "jsonExtract('annotations.requestObject') == 'x'", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := JSONExtractReadsObjectBody(tt.filter); got != tt.want {
+			t.Errorf("JSONExtractReadsObjectBody(%q) = %v, want %v", tt.filter, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package cel
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	expr "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+)
+
+// DefaultMaxFilterNodes is the default cap on the number of nodes in a filter
+// expression. Each comparison such as verb == 'get' is three nodes, so the cap
+// allows any hand-written filter and generated ones like an auditID list of a
+// few hundred IDs, but rejects filters with hundreds of OR clauses, which turn
+// into enormous SQL and are slow to type-check.
+const DefaultMaxFilterNodes = 500
+
+var maxFilterNodes atomic.Int64
+
+func init() {
+	maxFilterNodes.Store(DefaultMaxFilterNodes)
+}
+
+// MaxFilterNodes returns the process-wide cap on filter expression nodes.
+// Zero means unlimited.
+func MaxFilterNodes() int {
+	return int(maxFilterNodes.Load())
+}
+
+// SetMaxFilterNodes replaces the process-wide cap on filter expression nodes.
+// Zero disables the check.
+func SetMaxFilterNodes(n int) {
+	maxFilterNodes.Store(int64(n))
+}
+
+// checkFilterComplexity rejects a parsed filter with more nodes than
+// MaxFilterNodes. It runs before type-checking, which is the expensive part of
+// compiling a large filter.
+func checkFilterComplexity(e *expr.Expr) error {
+	limit := MaxFilterNodes()
+	if limit <= 0 {
+		return nil
+	}
+	if n := countNodes(e); n > limit {
+		return fmt.Errorf("filter is too complex: it has %d expression nodes and at most %d are allowed. "+
+			"Simplify it, for example by replacing chains of == comparisons joined with || by a single 'in' list, or split it across several queries", n, limit)
+	}
+	return nil
+}
+
+// countNodes returns the number of nodes in the expression tree rooted at e.
+func countNodes(e *expr.Expr) int {
+	if e == nil {
+		return 0
+	}
+
+	n := 1
+	switch exprKind := e.ExprKind.(type) {
+	case *expr.Expr_CallExpr:
+		n += countNodes(exprKind.CallExpr.Target)
+		for _, arg := range exprKind.CallExpr.Args {
+			n += countNodes(arg)
+		}
+
+	case *expr.Expr_SelectExpr:
+		n += countNodes(exprKind.SelectExpr.Operand)
+
+	case *expr.Expr_ListExpr:
+		for _, element := range exprKind.ListExpr.Elements {
+			n += countNodes(element)
+		}
+
+	case *expr.Expr_StructExpr:
+		for _, entry := range exprKind.StructExpr.Entries {
+			n += countNodes(entry.GetMapKey()) + countNodes(entry.Value)
+		}
+
+	case *expr.Expr_ComprehensionExpr:
+		c := exprKind.ComprehensionExpr
+		n += countNodes(c.IterRange) + countNodes(c.AccuInit) + countNodes(c.LoopCondition) +
+			countNodes(c.LoopStep) + countNodes(c.Result)
+	}
+	return n
+}
@@ -0,0 +1,109 @@
+package cel
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// orChain returns n equality clauses joined with ||.
+func orChain(field string, n int) string {
+	clauses := make([]string, n)
+	for i := range clauses {
+		clauses[i] = fmt.Sprintf("%s == 'value-%d'", field, i)
+	}
+	return strings.Join(clauses, " || ")
+}
+
+// nestedNot wraps expression in depth negations.
+func nestedNot(expression string, depth int) string {
+	return strings.Repeat("!(", depth) + expression + strings.Repeat(")", depth)
+}
+
+func setMaxFilterNodes(t *testing.T, n int) {
+	t.Helper()
+	previous := MaxFilterNodes()
+	SetMaxFilterNodes(n)
+	t.Cleanup(func() { SetMaxFilterNodes(previous) })
+}
+
+func TestFilterComplexityLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   string
+		activity bool
+		wantErr  bool
+	}{
+		{name: "simple audit filter", filter: "verb == 'delete' && objectRef.namespace == 'production'"},
+		{name: "simple activity filter", filter: "spec.changeSource == 'human'", activity: true},
+		{name: "long list is cheap", filter: "verb in [" + strings.Repeat("'get', ", 100) + "'list']"},
+		{name: "dozens of audit OR clauses", filter: orChain("verb", 50)},
+		{name: "hundreds of audit OR clauses", filter: orChain("verb", 300), wantErr: true},
+		{name: "hundreds of activity OR clauses", filter: orChain("spec.actor.name", 300), activity: true, wantErr: true},
+		{name: "deeply nested audit expression", filter: nestedNot(orChain("verb", 100), 150), wantErr: true},
+		{name: "deeply nested activity expression", filter: nestedNot(orChain("spec.actor.name", 100), 150), activity: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			convert := ConvertToClickHouseSQL
+			if tt.activity {
+				convert = ConvertActivityToClickHouseSQL
+			}
+
+			_, _, err := convert(context.Background(), tt.filter)
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "filter is too complex") {
+					t.Errorf("expected the complexity limit to reject the filter, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestSetMaxFilterNodes(t *testing.T) {
+	filter := nestedNot("verb == 'get'", 20)
+
+	setMaxFilterNodes(t, 10)
+	if _, err := CompileFilter(filter); err == nil || !strings.Contains(err.Error(), "at most 10 are allowed") {
+		t.Errorf("expected a lowered limit to reject the filter, got %v", err)
+	}
+
+	SetMaxFilterNodes(0)
+	if _, err := CompileFilter(orChain("verb", 300)); err != nil {
+		t.Errorf("expected a zero limit to allow any filter, got %v", err)
+	}
+}
+
+func TestCountNodes(t *testing.T) {
+	env, err := Environment()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		filter string
+		want   int
+	}{
+		// call, ident, constant
+		{"verb == 'get'", 3},
+		// call, select, ident, list, two constants
+		{"objectRef.resource in ['pods', 'secrets']", 6},
+		// two comparisons of three nodes, joined by a call
+		{"verb == 'get' || verb == 'list'", 7},
+	}
+	for _, tt := range tests {
+		ast, issues := env.Parse(tt.filter)
+		if issues != nil && issues.Err() != nil {
+			t.Fatalf("failed to parse %q: %v", tt.filter, issues.Err())
+		}
+		if got := countNodes(ast.Expr()); got != tt.want {
+			t.Errorf("countNodes(%q) = %d, want %d", tt.filter, got, tt.want)
+		}
+	}
+}
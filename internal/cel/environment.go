@@ -10,7 +10,8 @@ import (
 )
 
 // NewAuditEnvironment creates a CEL environment for audit rule expressions.
-// Available variables: audit (map containing all audit fields), actor, actorRef, kind.
+// Available variables: audit (map containing all audit fields), actor, actorRef,
+// kind, objectNamespace, verb, responseCode, timestamp.
 // Access audit fields via the audit map: audit.verb, audit.objectRef, audit.user, etc.
 // If collector is non-nil, link() calls will capture link information.
 func NewAuditEnvironment(collector *linkCollector) (*cel.Env, error) {
@@ -29,6 +30,12 @@ func NewAuditEnvironment(collector *linkCollector) (*cel.Env, error) {
 		// Also expose "kind" for convenience (extracted from audit.objectRef)
 		cel.Variable("kind", cel.StringType),
 
+		// Baseline shorthands for the audit fields most templates need
+		cel.Variable("objectNamespace", cel.StringType),
+		cel.Variable("verb", cel.StringType),
+		cel.Variable("responseCode", cel.IntType),
+		cel.Variable("timestamp", cel.StringType),
+
 		// link function declaration with implementation: link(displayText string, resourceRef map) -> string
 		// Returns the display text and optionally captures link info in the collector.
 		cel.Function("link",
@@ -91,7 +98,11 @@ func NewEventEnvironment(collector *linkCollector) (*cel.Env, error) {
 // BuildAuditVars creates the CEL variable map for audit evaluation.
 // All audit log fields are nested under the "audit" key for consistency with
 // event rules that use the "event" prefix (e.g., event.reason, event.type).
-// Convenience variables actor, actorRef, and kind remain top-level.
+// Convenience variables actor, actorRef, kind, objectNamespace, verb, responseCode
+// and timestamp remain top-level. They are always set, to the zero value when
+// the audit log lacks the field, so templates can use them without has().
+// timestamp is the stage timestamp, falling back to the time the request was
+// received.
 //
 // Nested fields that may be absent from the raw audit log (objectRef, user,
 // responseStatus, responseObject, requestObject) are populated with empty maps
@@ -130,9 +141,48 @@ func BuildAuditVars(auditMap map[string]interface{}) map[string]interface{} {
 		vars["kind"] = ""
 	}
 
+	vars["objectNamespace"] = ExtractString(auditMap, "objectRef", "namespace")
+	vars["verb"] = ExtractString(auditMap, "verb")
+	vars["responseCode"] = extractInt(auditMap, "responseStatus", "code")
+	timestamp := ExtractString(auditMap, "stageTimestamp")
+	if timestamp == "" {
+		timestamp = ExtractString(auditMap, "requestReceivedTimestamp")
+	}
+	vars["timestamp"] = timestamp
+
 	return vars
 }
 
+// AuditVariables lists the top-level variables available to audit rule
+// expressions.
+var AuditVariables = []string{"audit", "actor", "actorRef", "kind", "objectNamespace", "verb", "responseCode", "timestamp"}
+
+// EventVariables lists the top-level variables available to event rule
+// expressions.
+var EventVariables = []string{"event", "actor", "actorRef"}
+
+// extractInt returns the number at the nested keys as an int64, or 0 when it
+// is missing or not a number. Audit maps decoded from JSON hold numbers as
+// float64, while maps built in code may hold any integer type.
+func extractInt(m map[string]interface{}, keys ...string) int64 {
+	if len(keys) == 0 {
+		return 0
+	}
+	parent := ExtractMap(m, keys[:len(keys)-1]...)
+	switch v := parent[keys[len(keys)-1]].(type) {
+	case float64:
+		return int64(v)
+	case int:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case int64:
+		return v
+	default:
+		return 0
+	}
+}
+
 // BuildEventVars creates the CEL variable map for event evaluation.
 func BuildEventVars(eventMap map[string]interface{}) map[string]interface{} {
 	return map[string]interface{}{
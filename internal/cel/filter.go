@@ -3,6 +3,8 @@ package cel
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/cel-go/cel"
@@ -46,6 +48,11 @@ func (v *AuditLogFieldValidator) ValidateSelectExpr(sel *expr.Expr_Select) error
 		}
 	}
 
+	if sel.GetTestOnly() && !optionalFields[baseObject][field] {
+		return fmt.Errorf("has() cannot be used on '%s.%s' because it is always present. has() is supported on: %s",
+			baseObject, field, strings.Join(optionalFieldNames(), ", "))
+	}
+
 	return nil
 }
 
@@ -61,6 +68,16 @@ func (m *AuditLogFieldMapper) MapIdentExpr(ident *expr.Expr_Ident) (string, erro
 		return "verb", nil
 	case "requestReceivedTimestamp":
 		return "timestamp", nil
+	case "durationMs":
+		return "duration_ms", nil
+	case "level":
+		return "level", nil
+	case "requestURI":
+		return "request_uri", nil
+	case "authzDecision":
+		return "authz_decision", nil
+	case "admissionWebhook":
+		return "admission_webhook", nil
 
 	case "objectRef", "user", "responseStatus":
 		return "", fmt.Errorf("field '%s' must be accessed with dot notation (e.g., objectRef.namespace, user.username, responseStatus.code)", ident.Name)
@@ -94,30 +111,89 @@ func (m *AuditLogFieldMapper) MapSelectExpr(sel *expr.Expr_Select) (string, erro
 		return "resource_name", nil
 	case baseObject == "objectRef" && field == "apiGroup":
 		return "api_group", nil
+	case baseObject == "objectRef" && field == "subresource":
+		return "subresource", nil
 
 	case baseObject == "user" && field == "username":
 		return "user", nil
 	case baseObject == "user" && field == "uid":
 		return "user_uid", nil
+	case baseObject == "user" && field == "groups":
+		return "user_groups", nil
 
 	case baseObject == "responseStatus" && field == "code":
 		return "status_code", nil
+	case baseObject == "responseStatus" && field == "message":
+		return "status_message", nil
 
 	default:
 		return "", fmt.Errorf("field '%s.%s' is not available for filtering", baseObject, field)
 	}
 }
 
+// MapPresenceTest maps has() tests on optional audit fields to non-empty checks.
+// Missing values are materialized as empty strings, 0 for the status code, or
+// an empty array for user groups.
+func (m *AuditLogFieldMapper) MapPresenceTest(sel *expr.Expr_Select) (string, error) {
+	column, err := m.MapSelectExpr(sel)
+	if err != nil {
+		return "", err
+	}
+	switch column {
+	case "status_code":
+		return "status_code != 0", nil
+	case "user_groups":
+		return "notEmpty(user_groups)", nil
+	}
+	return fmt.Sprintf("%s != ''", column), nil
+}
+
+// IsArrayField reports whether an audit field is stored as an array column.
+func (m *AuditLogFieldMapper) IsArrayField(sel *expr.Expr_Select) bool {
+	return arrayFields[selectPath(sel)]
+}
+
+// IsStringField reports whether an audit field is stored as a string column.
+func (m *AuditLogFieldMapper) IsStringField(sel *expr.Expr_Select) bool {
+	return !nonStringFields[selectPath(sel)]
+}
+
+// JSONDocumentColumn returns the column holding the raw audit event, which
+// jsonExtract() reads from.
+func (m *AuditLogFieldMapper) JSONDocumentColumn() string {
+	return "event_json"
+}
+
 // Environment creates a CEL environment for audit event filtering.
 //
-// Available fields: auditID, verb, requestReceivedTimestamp,
-// objectRef.{namespace,resource,name,apiGroup}, user.{username,uid}, responseStatus.code
+// Available fields: auditID, verb, level, requestReceivedTimestamp, durationMs,
+// requestURI, authzDecision, admissionWebhook, objectRef.{namespace,resource,subresource,name,apiGroup}, user.{username,uid,groups},
+// responseStatus.{code,message}
+//
+// user.groups is a list and only supports membership tests ('admins' in user.groups).
 //
 // Note: stageTimestamp is intentionally NOT available for filtering as it should
 // only be used for internal pipeline delay calculations, not for querying events.
+// durationMs exposes the request latency derived from it instead.
+//
+// requestURI is the only way to tell non-resource requests (/healthz, /metrics)
+// apart, since they have no objectRef; !has(objectRef.resource) selects them.
+//
+// authzDecision is the authorizer's verdict from the authorization.k8s.io/decision
+// annotation (allow or forbid, empty when missing). It separates RBAC denials
+// from 403s returned by admission, which have different root causes.
 //
-// Supports standard CEL operators (==, !=, <, >, <=, >=, &&, ||, !, in) and string methods
-// (startsWith, endsWith, contains).
+// admissionWebhook names the admission webhook that rejected the request, or
+// that the API server failed to call, as cited in responseStatus.message. It is
+// empty when no webhook rejected the request.
+//
+// Supports standard CEL operators (==, !=, <, >, <=, >=, &&, ||, !, in), string methods
+// (startsWith, endsWith, contains) on string fields, and has() on optional fields
+// (see optionalFields).
+//
+// jsonExtract('path.to.field') returns any other field of the raw event as a
+// string. It can't use indexes or projections, so the API server only accepts
+// it when enabled with --enable-cel-json-extract.
 func Environment() (*cel.Env, error) {
 	objectRefType := cel.MapType(cel.StringType, cel.DynType)
 	userType := cel.MapType(cel.StringType, cel.DynType)
@@ -127,30 +203,91 @@ func Environment() (*cel.Env, error) {
 		cel.Variable("auditID", cel.StringType),
 		cel.Variable("verb", cel.StringType),
 		cel.Variable("requestReceivedTimestamp", cel.TimestampType),
+		cel.Variable("durationMs", cel.IntType),
+		cel.Variable("level", cel.StringType),
+		cel.Variable("requestURI", cel.StringType),
+		cel.Variable("authzDecision", cel.StringType),
+		cel.Variable("admissionWebhook", cel.StringType),
 
 		cel.Variable("objectRef", objectRefType),
 		cel.Variable("user", userType),
 		cel.Variable("responseStatus", responseStatusType),
+
+		cel.Function(jsonExtractFunction,
+			cel.Overload("jsonExtract_string", []*cel.Type{cel.StringType}, cel.StringType),
+		),
 	)
 }
 
 // validFields defines the allowed fields for each structured type
 var validFields = map[string]map[string]bool{
 	"objectRef": {
-		"apiGroup":  true,
-		"namespace": true,
-		"resource":  true,
-		"name":      true,
+		"apiGroup":    true,
+		"namespace":   true,
+		"resource":    true,
+		"subresource": true,
+		"name":        true,
 	},
 	"user": {
 		"username": true,
 		"uid":      true,
+		"groups":   true,
+	},
+	"responseStatus": {
+		"code":    true,
+		"message": true,
+	},
+}
+
+// arrayFields lists the fields stored as ClickHouse arrays. They are only
+// valid as the right side of 'in' and compile to has(column, value).
+var arrayFields = map[string]bool{
+	"user.groups": true,
+}
+
+// nonStringFields lists the scalar fields reached through dynamically typed
+// maps that are not stored as strings. String methods are rejected on them;
+// list fields are covered by arrayFields instead.
+var nonStringFields = map[string]bool{
+	"responseStatus.code": true,
+}
+
+// optionalFields defines the fields that may be absent from an audit event and
+// can therefore be tested with has(). objectRef is missing for non-resource
+// requests (e.g. /healthz), objectRef.subresource is only set on requests to a
+// subresource such as status or exec, responseStatus is missing for events
+// recorded before the response was written, responseStatus.message is usually
+// only set on failures, and user.groups is empty for identities without group
+// memberships.
+var optionalFields = map[string]map[string]bool{
+	"objectRef": {
+		"apiGroup":    true,
+		"namespace":   true,
+		"resource":    true,
+		"subresource": true,
+		"name":        true,
 	},
 	"responseStatus": {
-		"code": true,
+		"code":    true,
+		"message": true,
+	},
+	"user": {
+		"groups": true,
 	},
 }
 
+// optionalFieldNames returns the sorted list of fields that support has().
+func optionalFieldNames() []string {
+	names := make([]string, 0)
+	for base, fields := range optionalFields {
+		for field := range fields {
+			names = append(names, base+"."+field)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
 // CompileFilter compiles and validates a CEL filter expression, ensuring it returns a boolean.
 // Returns user-friendly error messages with helpful context (available fields, documentation links).
 func CompileFilter(filterExpr string) (*cel.Ast, error) {
@@ -167,7 +304,15 @@ func CompileFilter(filterExpr string) (*cel.Ast, error) {
 		return nil, fmt.Errorf("unable to process filter expression. Try again or contact support if the problem persists")
 	}
 
-	ast, issues := env.Compile(filterExpr)
+	ast, issues := env.Parse(filterExpr)
+	if issues == nil || issues.Err() == nil {
+		if err := checkFilterComplexity(ast.Expr()); err != nil {
+			metrics.CELFilterErrors.WithLabelValues("too_complex").Inc()
+			metrics.CELFilterParseDuration.Observe(time.Since(startTime).Seconds())
+			return nil, err
+		}
+		ast, issues = env.Check(ast)
+	}
 	if issues != nil && issues.Err() != nil {
 		metrics.CELFilterErrors.WithLabelValues("compilation").Inc()
 		metrics.CELFilterParseDuration.Observe(time.Since(startTime).Seconds())
@@ -190,10 +335,57 @@ func CompileFilter(filterExpr string) (*cel.Ast, error) {
 		return nil, fmt.Errorf("%s", formatFilterError(err))
 	}
 
+	// Validate that list operators are only applied to list fields
+	if err := ValidateArrayOperators(ast.Expr(), &AuditLogFieldMapper{}); err != nil {
+		metrics.CELFilterErrors.WithLabelValues("invalid_field").Inc()
+		metrics.CELFilterParseDuration.Observe(time.Since(startTime).Seconds())
+		return nil, fmt.Errorf("%s", formatFilterError(err))
+	}
+
+	// Validate that string methods are only applied to string fields
+	if err := ValidateStringOperators(ast.Expr(), &AuditLogFieldMapper{}); err != nil {
+		metrics.CELFilterErrors.WithLabelValues("invalid_field").Inc()
+		metrics.CELFilterParseDuration.Observe(time.Since(startTime).Seconds())
+		return nil, fmt.Errorf("%s", formatFilterError(err))
+	}
+
+	// Validate that jsonExtract() paths are literals that only name keys
+	if err := ValidateJSONExtract(ast.Expr()); err != nil {
+		metrics.CELFilterErrors.WithLabelValues("invalid_field").Inc()
+		metrics.CELFilterParseDuration.Observe(time.Since(startTime).Seconds())
+		return nil, fmt.Errorf("%s", formatFilterError(err))
+	}
+
 	metrics.CELFilterParseDuration.Observe(time.Since(startTime).Seconds())
 	return ast, nil
 }
 
+// normalizeExpression parses expression with env and prints it back in CEL's
+// canonical form, so filters that differ only in spacing or redundant
+// parentheses normalize to the same string. The expression is only parsed;
+// callers validate it separately.
+func normalizeExpression(env *cel.Env, expression string) (string, error) {
+	ast, issues := env.Parse(expression)
+	if issues != nil && issues.Err() != nil {
+		return "", issues.Err()
+	}
+	return cel.AstToString(ast)
+}
+
+// NormalizeFilter returns an audit log filter in canonical form. Equivalent
+// filters that differ only in formatting normalize to the same string, which
+// is what status.replaySpec records. An empty filter stays empty.
+func NormalizeFilter(filterExpr string) (string, error) {
+	if filterExpr == "" {
+		return "", nil
+	}
+	env, err := Environment()
+	if err != nil {
+		return "", err
+	}
+	return normalizeExpression(env, filterExpr)
+}
+
 // ConvertToClickHouseSQL converts a CEL expression to a ClickHouse WHERE clause with tracing.
 func ConvertToClickHouseSQL(ctx context.Context, filterExpr string) (string, []any, error) {
 	_, span := tracer.Start(ctx, "cel.filter.convert",
@@ -0,0 +1,262 @@
+package cel
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	expr "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+)
+
+// jsonExtractFunction is the CEL function that reads a field straight out of the
+// raw audit event JSON. It is an escape hatch for fields that aren't
+// materialized as columns, so it can't use indexes or projections.
+const jsonExtractFunction = "jsonExtract"
+
+// JSONExtractDisabledMessage explains why a filter using jsonExtract() was
+// rejected on a server that hasn't enabled it.
+const JSONExtractDisabledMessage = "jsonExtract() is not enabled on this server. Filter on the materialized fields instead, or ask an operator to enable --enable-cel-json-extract"
+
+// JSONExtractWarning is returned with the results of queries that use
+// jsonExtract(), since they scan the raw event JSON.
+const JSONExtractWarning = "this filter uses jsonExtract(), which reads the raw event JSON and can't use indexes or projections; the query may be slow. Narrow the time range or add filters on materialized fields to reduce the data scanned"
+
+// JSONExtractRedactedBodyMessage explains why a filter passing an object body
+// path to jsonExtract() was rejected for a caller whose results are redacted.
+const JSONExtractRedactedBodyMessage = "jsonExtract() cannot read requestObject or responseObject paths because object bodies are redacted for your scope. Filter on objectRef fields or other event paths instead"
+
+// objectBodyKeys are the top-level event keys holding request and response
+// object bodies, which query results redact for most callers.
+var objectBodyKeys = map[string]bool{"requestObject": true, "responseObject": true}
+
+// maxJSONPathDepth bounds how many keys a jsonExtract() path may contain.
+const maxJSONPathDepth = 10
+
+var (
+	// jsonPathKeyPattern matches a plain dotted path segment.
+	jsonPathKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+	// jsonPathQuotedKeyPattern matches the key inside a ['...'] segment, which
+	// allows the dots and slashes found in annotation keys.
+	jsonPathQuotedKeyPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._/-]*$`)
+)
+
+// ParseJSONPath splits a jsonExtract() path into its keys. Keys are separated
+// by dots; keys containing dots or slashes use bracket notation, e.g.
+// annotations['authorization.k8s.io/decision']. Anything else is rejected so
+// the path can only ever name keys.
+func ParseJSONPath(path string) ([]string, error) {
+	if path == "" {
+		return nil, fmt.Errorf("jsonExtract() path cannot be empty")
+	}
+
+	var keys []string
+	rest := path
+	for rest != "" {
+		var key string
+		if strings.HasPrefix(rest, "['") {
+			end := strings.Index(rest, "']")
+			if end < 0 {
+				return nil, fmt.Errorf("jsonExtract() path %q has an unterminated ['...'] key", path)
+			}
+			key = rest[2:end]
+			if !jsonPathQuotedKeyPattern.MatchString(key) {
+				return nil, fmt.Errorf("jsonExtract() path %q has an invalid key %q. Bracketed keys may only contain letters, digits, '.', '_', '/' and '-'", path, key)
+			}
+			rest = rest[end+2:]
+		} else {
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			key = rest[:end]
+			if !jsonPathKeyPattern.MatchString(key) {
+				return nil, fmt.Errorf("jsonExtract() path %q has an invalid key %q. Use dotted names like requestObject.spec.replicas, or ['...'] for keys with dots or slashes", path, key)
+			}
+			rest = rest[end:]
+		}
+		keys = append(keys, key)
+
+		// A key is followed by the end of the path, a bracketed key, or a dot
+		// and another key.
+		if strings.HasPrefix(rest, ".") {
+			rest = rest[1:]
+			if rest == "" {
+				return nil, fmt.Errorf("jsonExtract() path %q cannot end with '.'", path)
+			}
+		} else if rest != "" && !strings.HasPrefix(rest, "['") {
+			return nil, fmt.Errorf("jsonExtract() path %q is not a valid path", path)
+		}
+	}
+
+	if len(keys) > maxJSONPathDepth {
+		return nil, fmt.Errorf("jsonExtract() path %q is %d keys deep; at most %d are allowed", path, len(keys), maxJSONPathDepth)
+	}
+	return keys, nil
+}
+
+// ValidateJSONExtract checks that every jsonExtract() call takes a single string
+// literal holding a valid path. Paths are fixed at compile time so they can be
+// validated and bound as query parameters.
+func ValidateJSONExtract(e *expr.Expr) error {
+	if e == nil {
+		return nil
+	}
+
+	switch exprKind := e.ExprKind.(type) {
+	case *expr.Expr_CallExpr:
+		call := exprKind.CallExpr
+		if call.Function == jsonExtractFunction {
+			if len(call.Args) != 1 || call.Args[0].GetConstExpr() == nil {
+				return fmt.Errorf("jsonExtract() takes a single string literal path, e.g. jsonExtract('requestObject.spec.replicas')")
+			}
+			if _, err := ParseJSONPath(call.Args[0].GetConstExpr().GetStringValue()); err != nil {
+				return err
+			}
+		}
+		if err := ValidateJSONExtract(call.Target); err != nil {
+			return err
+		}
+		for _, arg := range call.Args {
+			if err := ValidateJSONExtract(arg); err != nil {
+				return err
+			}
+		}
+
+	case *expr.Expr_ListExpr:
+		for _, elem := range exprKind.ListExpr.Elements {
+			if err := ValidateJSONExtract(elem); err != nil {
+				return err
+			}
+		}
+
+	case *expr.Expr_ComprehensionExpr:
+		comp := exprKind.ComprehensionExpr
+		for _, sub := range []*expr.Expr{comp.IterRange, comp.AccuInit, comp.LoopCondition, comp.LoopStep, comp.Result} {
+			if err := ValidateJSONExtract(sub); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// UsesJSONExtract reports whether a filter expression calls jsonExtract(). The
+// expression is only parsed, not checked, so callers can gate the function
+// before running full validation.
+func UsesJSONExtract(filterExpr string) bool {
+	if filterExpr == "" {
+		return false
+	}
+	env, err := Environment()
+	if err != nil {
+		return false
+	}
+	ast, issues := env.Parse(filterExpr)
+	if issues != nil && issues.Err() != nil {
+		return false
+	}
+	return callsFunction(ast.Expr(), jsonExtractFunction)
+}
+
+// JSONExtractReadsObjectBody reports whether a filter expression passes a
+// requestObject or responseObject path to jsonExtract(). A filter can match on
+// fields that redaction strips from results, so callers whose results are
+// redacted must not be able to use one.
+func JSONExtractReadsObjectBody(filterExpr string) bool {
+	if filterExpr == "" {
+		return false
+	}
+	env, err := Environment()
+	if err != nil {
+		return false
+	}
+	ast, issues := env.Parse(filterExpr)
+	if issues != nil && issues.Err() != nil {
+		return false
+	}
+	for _, path := range jsonExtractPaths(ast.Expr(), nil) {
+		keys, err := ParseJSONPath(path)
+		if err == nil && objectBodyKeys[keys[0]] {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonExtractPaths appends the string literal paths passed to jsonExtract()
+// in e to paths.
+func jsonExtractPaths(e *expr.Expr, paths []string) []string {
+	if e == nil {
+		return paths
+	}
+
+	switch exprKind := e.ExprKind.(type) {
+	case *expr.Expr_CallExpr:
+		call := exprKind.CallExpr
+		if call.Function == jsonExtractFunction && len(call.Args) == 1 && call.Args[0].GetConstExpr() != nil {
+			paths = append(paths, call.Args[0].GetConstExpr().GetStringValue())
+		}
+		paths = jsonExtractPaths(call.Target, paths)
+		for _, arg := range call.Args {
+			paths = jsonExtractPaths(arg, paths)
+		}
+
+	case *expr.Expr_SelectExpr:
+		paths = jsonExtractPaths(exprKind.SelectExpr.Operand, paths)
+
+	case *expr.Expr_ListExpr:
+		for _, elem := range exprKind.ListExpr.Elements {
+			paths = jsonExtractPaths(elem, paths)
+		}
+
+	case *expr.Expr_ComprehensionExpr:
+		comp := exprKind.ComprehensionExpr
+		for _, sub := range []*expr.Expr{comp.IterRange, comp.AccuInit, comp.LoopCondition, comp.LoopStep, comp.Result} {
+			paths = jsonExtractPaths(sub, paths)
+		}
+	}
+
+	return paths
+}
+
+// callsFunction reports whether e contains a call to the named function.
+func callsFunction(e *expr.Expr, function string) bool {
+	if e == nil {
+		return false
+	}
+
+	switch exprKind := e.ExprKind.(type) {
+	case *expr.Expr_CallExpr:
+		call := exprKind.CallExpr
+		if call.Function == function || callsFunction(call.Target, function) {
+			return true
+		}
+		for _, arg := range call.Args {
+			if callsFunction(arg, function) {
+				return true
+			}
+		}
+
+	case *expr.Expr_SelectExpr:
+		return callsFunction(exprKind.SelectExpr.Operand, function)
+
+	case *expr.Expr_ListExpr:
+		for _, elem := range exprKind.ListExpr.Elements {
+			if callsFunction(elem, function) {
+				return true
+			}
+		}
+
+	case *expr.Expr_ComprehensionExpr:
+		comp := exprKind.ComprehensionExpr
+		for _, sub := range []*expr.Expr{comp.IterRange, comp.AccuInit, comp.LoopCondition, comp.LoopStep, comp.Result} {
+			if callsFunction(sub, function) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -72,19 +72,19 @@ func ValidatePolicyExpression(expression string, exprType PolicyExpressionType,
 
 	switch exprType {
 	case MatchExpression:
-		return validateMatchExpression(env, expression)
+		return validateMatchExpression(env, expression, ruleType)
 	case SummaryExpression:
-		return validateSummaryExpression(env, expression)
+		return validateSummaryExpression(env, expression, ruleType)
 	default:
 		return fmt.Errorf("unknown expression type: %s", exprType)
 	}
 }
 
 // validateMatchExpression validates a match expression that should return a boolean.
-func validateMatchExpression(env *cel.Env, expression string) error {
+func validateMatchExpression(env *cel.Env, expression string, ruleType PolicyRuleType) error {
 	ast, issues := env.Compile(expression)
 	if issues != nil && issues.Err() != nil {
-		return formatPolicyError(issues.Err(), "match")
+		return formatPolicyError(issues.Err(), "match", ruleType)
 	}
 
 	// Match expressions must return boolean
@@ -96,7 +96,7 @@ func validateMatchExpression(env *cel.Env, expression string) error {
 }
 
 // validateSummaryExpression validates a summary template expression with {{ }} delimiters.
-func validateSummaryExpression(env *cel.Env, expression string) error {
+func validateSummaryExpression(env *cel.Env, expression string, ruleType PolicyRuleType) error {
 	// Check for balanced delimiters
 	openCount := strings.Count(expression, "{{")
 	closeCount := strings.Count(expression, "}}")
@@ -127,7 +127,7 @@ func validateSummaryExpression(env *cel.Env, expression string) error {
 		// Compile the embedded expression
 		ast, issues := env.Compile(embeddedExpr)
 		if issues != nil && issues.Err() != nil {
-			return formatPolicyError(issues.Err(), "summary")
+			return formatPolicyError(issues.Err(), "summary", ruleType)
 		}
 
 		// Summary template expressions should return a string
@@ -140,16 +140,22 @@ func validateSummaryExpression(env *cel.Env, expression string) error {
 	return nil
 }
 
-// formatPolicyError formats a CEL error into a user-friendly message.
-func formatPolicyError(err error, context string) error {
+// formatPolicyError formats a CEL error into a user-friendly message. A
+// reference to an unknown variable lists the variables available to ruleType.
+func formatPolicyError(err error, context string, ruleType PolicyRuleType) error {
 	errStr := err.Error()
 
 	// Check for common error patterns and provide helpful messages
 	if strings.Contains(errStr, "undeclared reference") {
+		variables := AuditVariables
+		fields := "audit.verb, audit.objectRef, audit.user, audit.responseStatus, audit.responseObject, audit.requestObject"
+		if ruleType == EventRule {
+			variables = EventVariables
+			fields = "event.reason, event.type, event.regarding.name"
+		}
 		return fmt.Errorf("invalid %s expression: %s. "+
-			"For audit rules: audit.verb, audit.objectRef, audit.user, audit.responseStatus, audit.responseObject, audit.requestObject, actor, actorRef, kind. "+
-			"For event rules: event.reason, event.type, event.regarding.name, actor, actorRef. "+
-			"Also available: link(displayText, resourceRef)", context, errStr)
+			"Available %s rule variables: %s (fields such as %s). "+
+			"Also available: link(displayText, resourceRef)", context, errStr, ruleType, strings.Join(variables, ", "), fields)
 	}
 
 	if strings.Contains(errStr, "found no matching overload") {
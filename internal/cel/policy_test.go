@@ -135,6 +135,19 @@ func TestValidatePolicyExpression_SummaryExpressions(t *testing.T) {
 			ruleType:   AuditRule,
 			wantErr:    false,
 		},
+		{
+			name:       "valid baseline variables",
+			expression: "{{ actor }} ran {{ verb }} in {{ objectNamespace }} at {{ timestamp }} ({{ string(responseCode) }})",
+			ruleType:   AuditRule,
+			wantErr:    false,
+		},
+		{
+			name:        "invalid - baseline audit variable in event rule",
+			expression:  "{{ verb }}",
+			ruleType:    EventRule,
+			wantErr:     true,
+			errContains: "Available event rule variables: event, actor, actorRef",
+		},
 		{
 			name:       "invalid - empty template expression",
 			expression: "{{ }}",
@@ -147,7 +160,7 @@ func TestValidatePolicyExpression_SummaryExpressions(t *testing.T) {
 			expression: "{{ foo.bar }}",
 			ruleType:   AuditRule,
 			wantErr:    true,
-			errContains: "undeclared reference",
+			errContains: "Available audit rule variables: audit, actor, actorRef, kind, objectNamespace, verb, responseCode, timestamp",
 		},
 		{
 			name:        "invalid - unclosed delimiter",
@@ -269,3 +282,92 @@ func TestEvaluateAuditSummary_TernaryWithLink(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildAuditVars_BaselineVariables(t *testing.T) {
+	tests := []struct {
+		name     string
+		auditMap map[string]interface{}
+		want     map[string]interface{}
+	}{
+		{
+			name: "fields present",
+			auditMap: map[string]interface{}{
+				"verb":                     "delete",
+				"stageTimestamp":           "2026-01-02T03:04:05.123456Z",
+				"requestReceivedTimestamp": "2026-01-02T03:04:05Z",
+				"objectRef": map[string]interface{}{
+					"resource":  "pods",
+					"namespace": "default",
+				},
+				"responseStatus": map[string]interface{}{
+					"code": float64(200),
+				},
+			},
+			want: map[string]interface{}{
+				"objectNamespace": "default",
+				"verb":            "delete",
+				"responseCode":    int64(200),
+				"timestamp":       "2026-01-02T03:04:05.123456Z",
+			},
+		},
+		{
+			name: "timestamp falls back to request received",
+			auditMap: map[string]interface{}{
+				"verb":                     "create",
+				"requestReceivedTimestamp": "2026-01-02T03:04:05Z",
+				"responseStatus": map[string]interface{}{
+					"code": int32(201),
+				},
+			},
+			want: map[string]interface{}{
+				"objectNamespace": "",
+				"verb":            "create",
+				"responseCode":    int64(201),
+				"timestamp":       "2026-01-02T03:04:05Z",
+			},
+		},
+		{
+			name:     "fields absent",
+			auditMap: map[string]interface{}{},
+			want: map[string]interface{}{
+				"objectNamespace": "",
+				"verb":            "",
+				"responseCode":    int64(0),
+				"timestamp":       "",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vars := BuildAuditVars(tt.auditMap)
+			for key, want := range tt.want {
+				if vars[key] != want {
+					t.Errorf("%s = %#v, want %#v", key, vars[key], want)
+				}
+			}
+		})
+	}
+}
+
+func TestEvaluateAuditSummary_BaselineVariables(t *testing.T) {
+	auditMap := map[string]interface{}{
+		"verb": "delete",
+		"objectRef": map[string]interface{}{
+			"resource":  "pods",
+			"namespace": "default",
+			"name":      "web",
+		},
+		"responseStatus": map[string]interface{}{
+			"code": float64(404),
+		},
+	}
+
+	summary, _, err := EvaluateAuditSummary("{{ verb }} {{ objectNamespace }}/{{ audit.objectRef.name }}{{ responseCode >= 400 ? ' failed' : '' }}", auditMap)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "delete default/web failed"; summary != want {
+		t.Errorf("summary = %q, want %q", summary, want)
+	}
+}
@@ -0,0 +1,124 @@
+// Package changesource decides whether a username belongs to a human or to the
+// system (controllers, service accounts, bots). The processor uses it to label
+// each activity's changeSource and the MCP tools use it to filter system actors,
+// so both read the same configuration.
+package changesource
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync/atomic"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Config lists the usernames treated as system actors. A username is a system
+// actor if it starts with any prefix or matches any pattern.
+type Config struct {
+	// Prefixes match usernames that start with them, e.g. "system:".
+	Prefixes []string `json:"prefixes,omitempty"`
+
+	// Patterns are regular expressions matched anywhere in the username, e.g.
+	// "^ci-bot@" or "serviceaccount".
+	Patterns []string `json:"patterns,omitempty"`
+}
+
+// DefaultConfig returns the built-in heuristic: Kubernetes system identities
+// and usernames that mention a service account or controller.
+func DefaultConfig() Config {
+	return Config{
+		Prefixes: []string{"system:"},
+		Patterns: []string{"serviceaccount", "controller"},
+	}
+}
+
+// Classifier reports whether usernames belong to system actors.
+type Classifier struct {
+	prefixes []string
+	patterns []*regexp.Regexp
+}
+
+// New compiles a Classifier from cfg.
+func New(cfg Config) (*Classifier, error) {
+	c := &Classifier{}
+	for _, prefix := range cfg.Prefixes {
+		if prefix == "" {
+			return nil, fmt.Errorf("system user prefixes must not be empty")
+		}
+		c.prefixes = append(c.prefixes, prefix)
+	}
+	for _, pattern := range cfg.Patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid system user pattern %q: %w", pattern, err)
+		}
+		c.patterns = append(c.patterns, re)
+	}
+	return c, nil
+}
+
+// LoadFile reads a YAML or JSON Config from path, typically a mounted
+// ConfigMap, and compiles it.
+func LoadFile(path string) (*Classifier, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read system users config: %w", err)
+	}
+	var cfg Config
+	if err := yaml.UnmarshalStrict(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse system users config %s: %w", path, err)
+	}
+	return New(cfg)
+}
+
+// IsSystem reports whether username belongs to a system actor.
+func (c *Classifier) IsSystem(username string) bool {
+	for _, prefix := range c.prefixes {
+		if strings.HasPrefix(username, prefix) {
+			return true
+		}
+	}
+	for _, re := range c.patterns {
+		if re.MatchString(username) {
+			return true
+		}
+	}
+	return false
+}
+
+var defaultClassifier atomic.Pointer[Classifier]
+
+func init() {
+	c, err := New(DefaultConfig())
+	if err != nil {
+		panic(err)
+	}
+	defaultClassifier.Store(c)
+}
+
+// Default returns the process-wide Classifier. It uses DefaultConfig until
+// SetDefault or LoadDefault replaces it.
+func Default() *Classifier {
+	return defaultClassifier.Load()
+}
+
+// SetDefault replaces the process-wide Classifier.
+func SetDefault(c *Classifier) {
+	defaultClassifier.Store(c)
+}
+
+// LoadDefault loads the Config at path and makes it the process-wide
+// Classifier. An empty path keeps the built-in heuristic.
+func LoadDefault(path string) error {
+	if path == "" {
+		return nil
+	}
+	c, err := LoadFile(path)
+	if err != nil {
+		return err
+	}
+	SetDefault(c)
+	return nil
+}
@@ -0,0 +1,77 @@
+package changesource
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultClassifier(t *testing.T) {
+	c := Default()
+
+	tests := []struct {
+		username string
+		want     bool
+	}{
+		{"system:serviceaccount:kube-system:deployment-controller", true},
+		{"system:kube-scheduler", true},
+		{"serviceaccount:default:builder", true},
+		{"cert-manager-controller", true},
+		{"alice@example.com", false},
+		{"bob", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.username, func(t *testing.T) {
+			assert.Equal(t, tt.want, c.IsSystem(tt.username))
+		})
+	}
+}
+
+func TestClassifierCustomConfig(t *testing.T) {
+	c, err := New(Config{
+		Prefixes: []string{"system:"},
+		Patterns: []string{`^ci-bot@`, `@automation\.example\.com$`},
+	})
+	require.NoError(t, err)
+
+	assert.True(t, c.IsSystem("system:node:worker-1"))
+	assert.True(t, c.IsSystem("ci-bot@example.com"))
+	assert.True(t, c.IsSystem("deployer@automation.example.com"))
+	assert.False(t, c.IsSystem("alice@example.com"))
+
+	// Only the configured rules apply, not the built-in substrings.
+	assert.False(t, c.IsSystem("cert-manager-controller"))
+}
+
+func TestNewRejectsInvalidConfig(t *testing.T) {
+	_, err := New(Config{Patterns: []string{"("}})
+	assert.ErrorContains(t, err, "invalid system user pattern")
+
+	_, err = New(Config{Prefixes: []string{""}})
+	assert.ErrorContains(t, err, "must not be empty")
+}
+
+func TestLoadDefault(t *testing.T) {
+	original := Default()
+	t.Cleanup(func() { SetDefault(original) })
+
+	// An empty path keeps the built-in heuristic.
+	require.NoError(t, LoadDefault(""))
+	assert.Same(t, original, Default())
+
+	path := filepath.Join(t.TempDir(), "system-users.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("prefixes:\n- \"system:\"\npatterns:\n- \"^ci-bot@\"\n"), 0o600))
+
+	require.NoError(t, LoadDefault(path))
+	assert.True(t, Default().IsSystem("ci-bot@example.com"))
+	assert.False(t, Default().IsSystem("cert-manager-controller"))
+
+	// Unknown keys are rejected so typos don't silently fall back to nothing.
+	require.NoError(t, os.WriteFile(path, []byte("prefix:\n- \"system:\"\n"), 0o600))
+	assert.Error(t, LoadDefault(path))
+}
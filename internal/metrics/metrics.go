@@ -9,6 +9,12 @@ const (
 	namespace = "activity"
 )
 
+// queryDurationBuckets are the ClickHouse query duration buckets, 10ms to 60s.
+// They are dense between 1s and 5s, where the p99 < 3s latency SLO sits, so
+// burn-rate alerts can tell a 2.5s query from a 3.5s one. The top bucket
+// matches the default query timeout.
+var queryDurationBuckets = []float64{.01, .025, .05, .1, .25, .5, 1, 2, 3, 5, 10, 30, 60}
+
 var (
 	// ClickHouseQueryDuration tracks the duration of ClickHouse queries
 	ClickHouseQueryDuration = metrics.NewHistogramVec(
@@ -17,8 +23,7 @@ var (
 			Name:           "clickhouse_query_duration_seconds",
 			Help:           "Duration of ClickHouse queries in seconds",
 			StabilityLevel: metrics.ALPHA,
-			// Buckets from 1ms to ~10s for query latency
-			Buckets: metrics.ExponentialBuckets(0.001, 2, 14),
+			Buckets:        queryDurationBuckets,
 		},
 		[]string{"operation"},
 	)
@@ -45,6 +50,41 @@ var (
 		[]string{"error_type"},
 	)
 
+	// ClickHouseQueryCancelled tracks ClickHouse queries abandoned because the
+	// client cancelled the request. These are not counted as errors.
+	ClickHouseQueryCancelled = metrics.NewCounter(
+		&metrics.CounterOpts{
+			Namespace:      namespace,
+			Name:           "clickhouse_query_cancelled_total",
+			Help:           "Total number of ClickHouse queries cancelled by the client",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+
+	// ClickHouseInflightQueries tracks ClickHouse queries currently running,
+	// including those still streaming results
+	ClickHouseInflightQueries = metrics.NewGauge(
+		&metrics.GaugeOpts{
+			Namespace:      namespace,
+			Name:           "clickhouse_inflight_queries",
+			Help:           "Number of ClickHouse queries currently in flight",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+
+	// ClickHouseQueryQueueWait tracks how long queries wait for a free slot
+	// when --clickhouse-max-concurrent-queries is set
+	ClickHouseQueryQueueWait = metrics.NewHistogram(
+		&metrics.HistogramOpts{
+			Namespace:      namespace,
+			Name:           "clickhouse_query_queue_wait_seconds",
+			Help:           "Time queries spent waiting for a free ClickHouse query slot",
+			StabilityLevel: metrics.ALPHA,
+			// Buckets from 1ms to ~16s
+			Buckets: metrics.ExponentialBuckets(0.001, 2, 15),
+		},
+	)
+
 	// AuditLogQueryResults tracks the distribution of result counts per query
 	AuditLogQueryResults = metrics.NewHistogram(
 		&metrics.HistogramOpts{
@@ -138,6 +178,18 @@ var (
 		},
 	)
 
+	// IdempotentQueryCreates counts query creates that carried an
+	// Idempotency-Key, by whether the result was served from the cache.
+	IdempotentQueryCreates = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Namespace:      namespace,
+			Name:           "idempotent_query_creates_total",
+			Help:           "Total number of query creates with an Idempotency-Key, by resource and whether the cached result was returned",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"resource", "result"},
+	)
+
 	// EventsNATSConnectionStatus tracks NATS connection status for the events publisher
 	EventsNATSConnectionStatus = metrics.NewGauge(
 		&metrics.GaugeOpts{
@@ -167,6 +219,9 @@ func init() {
 		ClickHouseQueryDuration,
 		ClickHouseQueryTotal,
 		ClickHouseQueryErrors,
+		ClickHouseQueryCancelled,
+		ClickHouseInflightQueries,
+		ClickHouseQueryQueueWait,
 		AuditLogQueryResults,
 		CELFilterParseDuration,
 		CELFilterErrors,
@@ -177,5 +232,6 @@ func init() {
 		EventsPublishErrorsTotal,
 		EventsNATSConnectionStatus,
 		EventsPublishLatencySeconds,
+		IdempotentQueryCreates,
 	)
 }
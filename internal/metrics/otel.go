@@ -0,0 +1,143 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// otelScope is the instrumentation scope the OTel query metrics are reported under.
+const otelScope = "go.miloapis.com/activity/internal/storage"
+
+// OTelOptions configures OTLP export of the ClickHouse query metrics.
+type OTelOptions struct {
+	// Endpoint is the OTLP gRPC collector address (host:port). When empty the
+	// standard OTEL_EXPORTER_OTLP_* environment variables apply.
+	Endpoint string
+
+	// Insecure disables TLS on the collector connection.
+	Insecure bool
+
+	// ExportInterval is how often metrics are pushed to the collector.
+	ExportInterval time.Duration
+}
+
+// otelQueryInstruments mirrors the Prometheus ClickHouse query metrics.
+type otelQueryInstruments struct {
+	duration metric.Float64Histogram
+	total    metric.Int64Counter
+	errors   metric.Int64Counter
+}
+
+// otelQuery holds the OTel instruments while export is enabled, nil otherwise.
+var otelQuery atomic.Pointer[otelQueryInstruments]
+
+// EnableOTelExport starts pushing the ClickHouse query duration, count and
+// error metrics to an OTLP collector, in addition to the Prometheus /metrics
+// endpoint. The returned function flushes pending metrics and stops export.
+//
+// The meter provider is deliberately not installed as the global provider, so
+// only the metrics recorded through this package are exported over OTLP.
+// Libraries that report to the global provider, such as the apiserver's HTTP
+// instrumentation, stay Prometheus-only and are not counted twice.
+func EnableOTelExport(ctx context.Context, opts OTelOptions) (func(context.Context) error, error) {
+	var exporterOpts []otlpmetricgrpc.Option
+	if opts.Endpoint != "" {
+		exporterOpts = append(exporterOpts, otlpmetricgrpc.WithEndpoint(opts.Endpoint))
+	}
+	if opts.Insecure {
+		exporterOpts = append(exporterOpts, otlpmetricgrpc.WithInsecure())
+	}
+
+	exporter, err := otlpmetricgrpc.New(ctx, exporterOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP metric exporter: %w", err)
+	}
+
+	var readerOpts []sdkmetric.PeriodicReaderOption
+	if opts.ExportInterval > 0 {
+		readerOpts = append(readerOpts, sdkmetric.WithInterval(opts.ExportInterval))
+	}
+	provider := sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exporter, readerOpts...)),
+	)
+
+	if err := enableOTel(provider); err != nil {
+		_ = provider.Shutdown(ctx)
+		return nil, err
+	}
+
+	return func(ctx context.Context) error {
+		otelQuery.Store(nil)
+		return provider.Shutdown(ctx)
+	}, nil
+}
+
+// enableOTel creates the query instruments from provider and starts recording to them.
+func enableOTel(provider metric.MeterProvider) error {
+	meter := provider.Meter(otelScope)
+
+	duration, err := meter.Float64Histogram("activity.clickhouse.query.duration",
+		metric.WithDescription("Duration of ClickHouse queries"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(queryDurationBuckets...),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create query duration instrument: %w", err)
+	}
+
+	total, err := meter.Int64Counter("activity.clickhouse.queries",
+		metric.WithDescription("Number of ClickHouse queries"),
+		metric.WithUnit("{query}"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create query count instrument: %w", err)
+	}
+
+	errs, err := meter.Int64Counter("activity.clickhouse.query.errors",
+		metric.WithDescription("Number of failed ClickHouse queries"),
+		metric.WithUnit("{error}"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create query error instrument: %w", err)
+	}
+
+	otelQuery.Store(&otelQueryInstruments{duration: duration, total: total, errors: errs})
+	return nil
+}
+
+// ObserveClickHouseQueryDuration records how long a ClickHouse query took.
+// The operation is "query" for execution alone, "total" for the whole
+// request including result processing, or "facet" for a facet request.
+func ObserveClickHouseQueryDuration(operation string, seconds float64) {
+	ClickHouseQueryDuration.WithLabelValues(operation).Observe(seconds)
+	if inst := otelQuery.Load(); inst != nil {
+		inst.duration.Record(context.Background(), seconds,
+			metric.WithAttributes(attribute.String("operation", operation)))
+	}
+}
+
+// IncClickHouseQueryTotal counts a finished ClickHouse query by status
+// ("success", "partial" or "error").
+func IncClickHouseQueryTotal(status string) {
+	ClickHouseQueryTotal.WithLabelValues(status).Inc()
+	if inst := otelQuery.Load(); inst != nil {
+		inst.total.Add(context.Background(), 1,
+			metric.WithAttributes(attribute.String("status", status)))
+	}
+}
+
+// IncClickHouseQueryErrors counts a failed ClickHouse query by error type.
+func IncClickHouseQueryErrors(errorType string) {
+	ClickHouseQueryErrors.WithLabelValues(errorType).Inc()
+	if inst := otelQuery.Load(); inst != nil {
+		inst.errors.Add(context.Background(), 1,
+			metric.WithAttributes(attribute.String("error_type", errorType)))
+	}
+}
@@ -0,0 +1,103 @@
+package metrics
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"k8s.io/component-base/metrics/testutil"
+)
+
+func TestOTelQueryMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	if err := enableOTel(provider); err != nil {
+		t.Fatalf("enableOTel() error = %v", err)
+	}
+	t.Cleanup(func() { otelQuery.Store(nil) })
+
+	promBefore, _ := testutil.GetCounterMetricValue(ClickHouseQueryTotal.WithLabelValues("success"))
+
+	ObserveClickHouseQueryDuration("query", 0.25)
+	IncClickHouseQueryTotal("success")
+	IncClickHouseQueryErrors("timeout")
+
+	// Each call is recorded exactly once in the Prometheus registry...
+	if promAfter, _ := testutil.GetCounterMetricValue(ClickHouseQueryTotal.WithLabelValues("success")); promAfter-promBefore != 1 {
+		t.Errorf("Prometheus query total increased by %v, want 1", promAfter-promBefore)
+	}
+
+	// ...and exactly once in the OTel pipeline.
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if len(rm.ScopeMetrics) != 1 || rm.ScopeMetrics[0].Scope.Name != otelScope {
+		t.Fatalf("scope metrics = %+v, want a single %q scope", rm.ScopeMetrics, otelScope)
+	}
+
+	got := make(map[string]metricdata.Aggregation)
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		got[m.Name] = m.Data
+	}
+
+	hist, ok := got["activity.clickhouse.query.duration"].(metricdata.Histogram[float64])
+	if !ok || len(hist.DataPoints) != 1 || hist.DataPoints[0].Count != 1 || hist.DataPoints[0].Sum != 0.25 {
+		t.Errorf("duration = %+v, want one 0.25s observation", got["activity.clickhouse.query.duration"])
+	}
+	for _, name := range []string{"activity.clickhouse.queries", "activity.clickhouse.query.errors"} {
+		sum, ok := got[name].(metricdata.Sum[int64])
+		if !ok || len(sum.DataPoints) != 1 || sum.DataPoints[0].Value != 1 {
+			t.Errorf("%s = %+v, want 1", name, got[name])
+		}
+	}
+}
+
+func TestOTelQueryMetricsDisabled(t *testing.T) {
+	otelQuery.Store(nil)
+
+	// Recording with OTel export off only touches Prometheus and must not panic.
+	ObserveClickHouseQueryDuration("total", 1)
+	IncClickHouseQueryTotal("error")
+	IncClickHouseQueryErrors("scan")
+}
+
+func TestQueryDurationBuckets(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	if err := enableOTel(provider); err != nil {
+		t.Fatalf("enableOTel() error = %v", err)
+	}
+	t.Cleanup(func() { otelQuery.Store(nil) })
+
+	// Queries either side of the 3s SLO threshold must land in different buckets.
+	ObserveClickHouseQueryDuration("total", 2.5)
+	ObserveClickHouseQueryDuration("total", 3.5)
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		if m.Name != "activity.clickhouse.query.duration" {
+			continue
+		}
+		point := m.Data.(metricdata.Histogram[float64]).DataPoints[0]
+		if !slices.Equal(point.Bounds, queryDurationBuckets) {
+			t.Errorf("OTel bounds = %v, want the Prometheus buckets %v", point.Bounds, queryDurationBuckets)
+		}
+		filled := 0
+		for _, count := range point.BucketCounts {
+			if count > 0 {
+				filled++
+			}
+		}
+		if filled != 2 {
+			t.Errorf("bucket counts = %v, want 2.5s and 3.5s in separate buckets", point.BucketCounts)
+		}
+		return
+	}
+	t.Fatal("query duration histogram not exported")
+}
@@ -115,6 +115,14 @@ func (b *ActivityBuilder) BuildFromAudit(
 	}, nil
 }
 
+// SetPolicyOrigin records the policy and rule that generated an activity, so
+// activities can be traced back to the rule that produced them.
+func SetPolicyOrigin(activity *v1alpha1.Activity, policyName string, ruleIndex int) {
+	idx := int32(ruleIndex)
+	activity.Spec.Origin.PolicyName = policyName
+	activity.Spec.Origin.RuleIndex = &idx
+}
+
 // extractResponseUID extracts the UID from an audit response object's metadata.
 func extractResponseUID(responseObject *runtime.Unknown) string {
 	if responseObject == nil || len(responseObject.Raw) == 0 {
@@ -0,0 +1,334 @@
+package processor
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+)
+
+// ActorLabelPrefix prefixes the activity labels copied from an actor's
+// directory entry, e.g. activity.miloapis.com/actor-team.
+const ActorLabelPrefix = "activity.miloapis.com/actor-"
+
+var actorLookups = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "activity_processor",
+		Subsystem: "actor_enrichment",
+		Name:      "lookups_total",
+		Help:      "Actor directory cache lookups by result",
+	},
+	[]string{"result"}, // hit, miss, error, dropped, evicted
+)
+
+func init() {
+	metrics.Registry.MustRegister(actorLookups)
+}
+
+// ActorInfo is what a directory knows about an actor.
+type ActorInfo struct {
+	// DisplayName replaces the raw username in Activity.Spec.Actor.Name.
+	DisplayName string `json:"displayName,omitempty"`
+
+	// Labels are attached to the activity with ActorLabelPrefix, e.g.
+	// {"team": "payments"} becomes activity.miloapis.com/actor-team=payments.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// ActorResolver looks up directory information for an actor. Implementations
+// return nil info with no error when the actor is unknown.
+type ActorResolver interface {
+	LookupActor(ctx context.Context, actor v1alpha1.ActivityActor) (*ActorInfo, error)
+}
+
+// ActorEnricherConfig tunes the actor enrichment cache.
+type ActorEnricherConfig struct {
+	// TTL is how long a successful lookup is cached.
+	TTL time.Duration
+
+	// FailureTTL is how long a failed or empty lookup is cached before it is
+	// retried.
+	FailureTTL time.Duration
+
+	// LookupTimeout bounds a single call to the resolver.
+	LookupTimeout time.Duration
+
+	// Workers is the number of concurrent lookups.
+	Workers int
+
+	// QueueSize bounds the pending lookups. Lookups beyond it are dropped
+	// and retried the next time the actor is seen.
+	QueueSize int
+
+	// MaxEntries bounds the number of cached actors. The least recently seen
+	// actors are evicted beyond it and looked up again when they return.
+	MaxEntries int
+}
+
+// DefaultActorEnricherConfig returns the default enrichment cache settings.
+func DefaultActorEnricherConfig() ActorEnricherConfig {
+	return ActorEnricherConfig{
+		TTL:           15 * time.Minute,
+		FailureTTL:    time.Minute,
+		LookupTimeout: 5 * time.Second,
+		Workers:       2,
+		QueueSize:     1000,
+		MaxEntries:    10000,
+	}
+}
+
+type actorCacheEntry struct {
+	key     string
+	info    *ActorInfo
+	expires time.Time
+}
+
+// ActorEnricher replaces raw actor names with directory display names and
+// labels. Lookups run in the background so event processing never waits on
+// the directory: the first activity for an actor keeps the raw username, and
+// later activities pick up the cached result.
+type ActorEnricher struct {
+	resolver ActorResolver
+	config   ActorEnricherConfig
+
+	mu       sync.Mutex
+	cache    map[string]*list.Element
+	order    *list.List // of *actorCacheEntry, least recently seen first
+	inflight map[string]bool
+	queue    chan v1alpha1.ActivityActor
+
+	now func() time.Time
+}
+
+// NewActorEnricher creates an enricher backed by resolver. Call Start to begin
+// resolving actors.
+func NewActorEnricher(resolver ActorResolver, config ActorEnricherConfig) *ActorEnricher {
+	defaults := DefaultActorEnricherConfig()
+	if config.TTL <= 0 {
+		config.TTL = defaults.TTL
+	}
+	if config.FailureTTL <= 0 {
+		config.FailureTTL = defaults.FailureTTL
+	}
+	if config.LookupTimeout <= 0 {
+		config.LookupTimeout = defaults.LookupTimeout
+	}
+	if config.Workers <= 0 {
+		config.Workers = defaults.Workers
+	}
+	if config.QueueSize <= 0 {
+		config.QueueSize = defaults.QueueSize
+	}
+	if config.MaxEntries <= 0 {
+		config.MaxEntries = defaults.MaxEntries
+	}
+
+	return &ActorEnricher{
+		resolver: resolver,
+		config:   config,
+		cache:    make(map[string]*list.Element),
+		order:    list.New(),
+		inflight: make(map[string]bool),
+		queue:    make(chan v1alpha1.ActivityActor, config.QueueSize),
+		now:      time.Now,
+	}
+}
+
+// Start runs the lookup workers until ctx is cancelled.
+func (e *ActorEnricher) Start(ctx context.Context) {
+	for i := 0; i < e.config.Workers; i++ {
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case actor := <-e.queue:
+					e.lookup(ctx, actor)
+				}
+			}
+		}()
+	}
+}
+
+// Enrich applies cached directory information to a user activity. On a cache
+// miss or expired entry it schedules a lookup and leaves the activity as is.
+// A nil enricher does nothing.
+func (e *ActorEnricher) Enrich(activity *v1alpha1.Activity) {
+	if e == nil || activity == nil || activity.Spec.Actor.Type != ActorTypeUser {
+		return
+	}
+
+	info, ok := e.cached(activity.Spec.Actor)
+	if !ok || info == nil {
+		return
+	}
+
+	if info.DisplayName != "" {
+		activity.Spec.Actor.Name = info.DisplayName
+	}
+	for key, value := range info.Labels {
+		label := ActorLabelPrefix + key
+		if len(validation.IsQualifiedName(label)) > 0 || len(validation.IsValidLabelValue(value)) > 0 {
+			continue
+		}
+		if activity.Labels == nil {
+			activity.Labels = make(map[string]string, len(info.Labels))
+		}
+		activity.Labels[label] = value
+	}
+}
+
+// cached returns the cached info for actor, scheduling a lookup when there is
+// no fresh entry. ok is false when nothing usable is cached.
+func (e *ActorEnricher) cached(actor v1alpha1.ActivityActor) (info *ActorInfo, ok bool) {
+	key := actorKey(actor)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var entry *actorCacheEntry
+	elem, found := e.cache[key]
+	if found {
+		entry = elem.Value.(*actorCacheEntry)
+		e.order.MoveToBack(elem)
+		if e.now().Before(entry.expires) {
+			actorLookups.WithLabelValues("hit").Inc()
+			return entry.info, true
+		}
+	}
+
+	actorLookups.WithLabelValues("miss").Inc()
+	if !e.inflight[key] {
+		select {
+		case e.queue <- actor:
+			e.inflight[key] = true
+		default:
+			actorLookups.WithLabelValues("dropped").Inc()
+		}
+	}
+
+	if !found {
+		return nil, false
+	}
+	// A stale entry is still better than the raw username while the refresh runs
+	return entry.info, true
+}
+
+// lookup resolves actor and caches the result. Failures fall back to the raw
+// username until FailureTTL expires.
+func (e *ActorEnricher) lookup(ctx context.Context, actor v1alpha1.ActivityActor) {
+	key := actorKey(actor)
+
+	lookupCtx, cancel := context.WithTimeout(ctx, e.config.LookupTimeout)
+	info, err := e.resolver.LookupActor(lookupCtx, actor)
+	cancel()
+
+	ttl := e.config.TTL
+	if err != nil {
+		actorLookups.WithLabelValues("error").Inc()
+		klog.V(2).InfoS("Actor lookup failed, using raw username", "actor", actor.Name, "error", err)
+		info = nil
+		ttl = e.config.FailureTTL
+	} else if info == nil {
+		ttl = e.config.FailureTTL
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.inflight, key)
+	expires := e.now().Add(ttl)
+	if elem, found := e.cache[key]; found {
+		entry := elem.Value.(*actorCacheEntry)
+		// Keep serving a previous good result rather than dropping back to the raw username
+		if err == nil {
+			entry.info = info
+		}
+		entry.expires = expires
+		e.order.MoveToBack(elem)
+		return
+	}
+
+	e.cache[key] = e.order.PushBack(&actorCacheEntry{key: key, info: info, expires: expires})
+	for e.order.Len() > e.config.MaxEntries {
+		oldest := e.order.Remove(e.order.Front()).(*actorCacheEntry)
+		delete(e.cache, oldest.key)
+		actorLookups.WithLabelValues("evicted").Inc()
+	}
+}
+
+// Len returns the number of cached actors.
+func (e *ActorEnricher) Len() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.order.Len()
+}
+
+// actorKey identifies an actor in the cache. The UID is stable across
+// username changes, so it is preferred when present.
+func actorKey(actor v1alpha1.ActivityActor) string {
+	if actor.UID != "" {
+		return "uid:" + actor.UID
+	}
+	return "name:" + actor.Name
+}
+
+// HTTPActorResolver looks actors up in a directory service over HTTP. It sends
+// GET <url>?username=<name>&uid=<uid> and expects an ActorInfo JSON body. A 404
+// response means the actor is unknown.
+type HTTPActorResolver struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPActorResolver creates a resolver for the directory at url.
+func NewHTTPActorResolver(url string, client *http.Client) *HTTPActorResolver {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &HTTPActorResolver{url: url, client: client}
+}
+
+// LookupActor implements ActorResolver.
+func (r *HTTPActorResolver) LookupActor(ctx context.Context, actor v1alpha1.ActivityActor) (*ActorInfo, error) {
+	query := url.Values{"username": {actor.Name}}
+	if actor.UID != "" {
+		query.Set("uid", actor.UID)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.url+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build directory request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("directory request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, nil
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("directory returned %s", resp.Status)
+	}
+
+	var info ActorInfo
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 64*1024)).Decode(&info); err != nil {
+		return nil, fmt.Errorf("failed to decode directory response: %w", err)
+	}
+	return &info, nil
+}
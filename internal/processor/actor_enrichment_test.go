@@ -0,0 +1,195 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+)
+
+// fakeActorResolver returns canned directory entries and counts lookups.
+type fakeActorResolver struct {
+	mu      sync.Mutex
+	entries map[string]*ActorInfo
+	err     error
+	calls   int
+}
+
+func (f *fakeActorResolver) LookupActor(ctx context.Context, actor v1alpha1.ActivityActor) (*ActorInfo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.entries[actor.UID], nil
+}
+
+func userActivity(uid, username string) *v1alpha1.Activity {
+	activity := &v1alpha1.Activity{}
+	activity.Labels = map[string]string{"activity.miloapis.com/origin-type": "audit"}
+	activity.Spec.Actor = v1alpha1.ActivityActor{Type: ActorTypeUser, Name: username, UID: uid}
+	return activity
+}
+
+// drain runs queued lookups synchronously so tests don't depend on timing.
+func drain(e *ActorEnricher) {
+	for {
+		select {
+		case actor := <-e.queue:
+			e.lookup(context.Background(), actor)
+		default:
+			return
+		}
+	}
+}
+
+func TestActorEnricher_Enrich(t *testing.T) {
+	resolver := &fakeActorResolver{entries: map[string]*ActorInfo{
+		"u-123": {
+			DisplayName: "Alice Liddell",
+			Labels:      map[string]string{"team": "payments", "department": "engineering", "bad key!": "x"},
+		},
+	}}
+	e := NewActorEnricher(resolver, ActorEnricherConfig{})
+
+	// The first activity is published with the raw username while the lookup runs
+	first := userActivity("u-123", "0f8c2a")
+	e.Enrich(first)
+	assert.Equal(t, "0f8c2a", first.Spec.Actor.Name)
+
+	// Repeated misses don't queue duplicate lookups
+	e.Enrich(userActivity("u-123", "0f8c2a"))
+	drain(e)
+	assert.Equal(t, 1, resolver.calls)
+
+	second := userActivity("u-123", "0f8c2a")
+	e.Enrich(second)
+	assert.Equal(t, "Alice Liddell", second.Spec.Actor.Name)
+	assert.Equal(t, "u-123", second.Spec.Actor.UID)
+	assert.Equal(t, "payments", second.Labels[ActorLabelPrefix+"team"])
+	assert.Equal(t, "engineering", second.Labels[ActorLabelPrefix+"department"])
+	assert.Equal(t, "audit", second.Labels["activity.miloapis.com/origin-type"])
+	assert.NotContains(t, second.Labels, ActorLabelPrefix+"bad key!")
+}
+
+func TestActorEnricher_SkipsSystemActors(t *testing.T) {
+	resolver := &fakeActorResolver{}
+	e := NewActorEnricher(resolver, ActorEnricherConfig{})
+
+	activity := userActivity("", "serviceaccount:kube-system:gc")
+	activity.Spec.Actor.Type = ActorTypeSystem
+	e.Enrich(activity)
+	drain(e)
+
+	assert.Equal(t, 0, resolver.calls)
+}
+
+func TestActorEnricher_LookupFailure(t *testing.T) {
+	resolver := &fakeActorResolver{entries: map[string]*ActorInfo{
+		"u-123": {DisplayName: "Alice Liddell"},
+	}}
+	e := NewActorEnricher(resolver, ActorEnricherConfig{TTL: time.Minute, FailureTTL: time.Second})
+	now := time.Now()
+	e.now = func() time.Time { return now }
+
+	e.Enrich(userActivity("u-123", "0f8c2a"))
+	drain(e)
+
+	// Once the entry expires a failed refresh keeps the last good name
+	now = now.Add(2 * time.Minute)
+	resolver.err = errors.New("directory unavailable")
+	e.Enrich(userActivity("u-123", "0f8c2a"))
+	drain(e)
+
+	activity := userActivity("u-123", "0f8c2a")
+	e.Enrich(activity)
+	assert.Equal(t, "Alice Liddell", activity.Spec.Actor.Name)
+
+	// An actor that never resolved falls back to the raw username
+	unknown := userActivity("u-999", "7be1d0")
+	e.Enrich(unknown)
+	drain(e)
+	e.Enrich(unknown)
+	assert.Equal(t, "7be1d0", unknown.Spec.Actor.Name)
+}
+
+func TestActorEnricher_NilIsNoop(t *testing.T) {
+	var e *ActorEnricher
+	activity := userActivity("u-123", "0f8c2a")
+	e.Enrich(activity)
+	assert.Equal(t, "0f8c2a", activity.Spec.Actor.Name)
+}
+
+func TestHTTPActorResolver(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("uid") {
+		case "u-123":
+			assert.Equal(t, "0f8c2a", r.URL.Query().Get("username"))
+			_, _ = w.Write([]byte(`{"displayName":"Alice Liddell","labels":{"team":"payments"}}`))
+		case "u-404":
+			http.NotFound(w, r)
+		default:
+			http.Error(w, "boom", http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	r := NewHTTPActorResolver(server.URL, server.Client())
+
+	info, err := r.LookupActor(context.Background(), v1alpha1.ActivityActor{Name: "0f8c2a", UID: "u-123"})
+	require.NoError(t, err)
+	require.NotNil(t, info)
+	assert.Equal(t, "Alice Liddell", info.DisplayName)
+	assert.Equal(t, map[string]string{"team": "payments"}, info.Labels)
+
+	info, err = r.LookupActor(context.Background(), v1alpha1.ActivityActor{Name: "gone", UID: "u-404"})
+	require.NoError(t, err)
+	assert.Nil(t, info)
+
+	_, err = r.LookupActor(context.Background(), v1alpha1.ActivityActor{Name: "broken", UID: "u-500"})
+	assert.Error(t, err)
+}
+
+func TestActorEnricher_MaxEntries(t *testing.T) {
+	resolver := &fakeActorResolver{entries: map[string]*ActorInfo{
+		"u-1": {DisplayName: "One"},
+		"u-2": {DisplayName: "Two"},
+		"u-3": {DisplayName: "Three"},
+	}}
+	e := NewActorEnricher(resolver, ActorEnricherConfig{MaxEntries: 2})
+
+	e.Enrich(userActivity("u-1", "a"))
+	e.Enrich(userActivity("u-2", "b"))
+	drain(e)
+
+	// Seeing u-1 again makes u-2 the least recently seen actor
+	e.Enrich(userActivity("u-1", "a"))
+	e.Enrich(userActivity("u-3", "c"))
+	drain(e)
+	assert.Equal(t, 2, e.Len())
+
+	kept := userActivity("u-1", "a")
+	e.Enrich(kept)
+	assert.Equal(t, "One", kept.Spec.Actor.Name)
+
+	newest := userActivity("u-3", "c")
+	e.Enrich(newest)
+	assert.Equal(t, "Three", newest.Spec.Actor.Name)
+
+	// An evicted actor is looked up again and keeps its raw username meanwhile
+	evicted := userActivity("u-2", "b")
+	e.Enrich(evicted)
+	assert.Equal(t, "b", evicted.Spec.Actor.Name)
+	drain(e)
+	assert.Equal(t, 4, resolver.calls)
+	assert.Equal(t, 2, e.Len())
+}
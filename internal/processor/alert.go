@@ -0,0 +1,109 @@
+package processor
+
+import (
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+)
+
+var alertsPublished = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "activity_processor",
+		Name:      "alerts_published_total",
+		Help:      "Total number of high-severity activities published to the alert subject",
+	},
+	[]string{"origin", "policy"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(alertsPublished)
+}
+
+// alertMsgIDSuffix distinguishes the alert copy of an activity from the
+// regular publish for NATS deduplication.
+const alertMsgIDSuffix = "-alert"
+
+// Publisher publishes messages to NATS JetStream. nats.JetStreamContext
+// satisfies it.
+type Publisher interface {
+	Publish(subj string, data []byte, opts ...nats.PubOpt) (*nats.PubAck, error)
+}
+
+// AsyncPublisher publishes messages to NATS JetStream without waiting for the
+// acknowledgement. nats.JetStreamContext satisfies it.
+type AsyncPublisher interface {
+	PublishAsync(subj string, data []byte, opts ...nats.PubOpt) (nats.PubAckFuture, error)
+}
+
+// ActivitySubject returns the NATS subject an activity is published to under prefix.
+// Format: <prefix>.<tenant_type>.<tenant_name>.<api_group>.<origin>.<kind>.<namespace>.<name>
+func ActivitySubject(prefix string, activity *v1alpha1.Activity) string {
+	tenantType := activity.Spec.Tenant.Type
+	if tenantType == "" {
+		tenantType = "platform"
+	}
+	tenantName := activity.Spec.Tenant.Name
+	if tenantName == "" {
+		tenantName = "_"
+	}
+
+	apiGroup := activity.Spec.Resource.APIGroup
+	if apiGroup == "" {
+		apiGroup = "core"
+	}
+
+	origin := activity.Spec.Origin.Type
+	kind := activity.Spec.Resource.Kind
+	namespace := activity.Spec.Resource.Namespace
+	if namespace == "" {
+		namespace = "_"
+	}
+	name := activity.Name
+
+	return fmt.Sprintf("%s.%s.%s.%s.%s.%s.%s.%s",
+		prefix, tenantType, tenantName, apiGroup, origin, kind, namespace, name)
+}
+
+// AlertMsgID returns the NATS message ID for the alert copy of an activity.
+// It differs from the activity name used for the regular publish, so the alert
+// copy is not discarded as a duplicate when the alert subject is captured by
+// the same stream, and redeliveries of either copy still deduplicate.
+func AlertMsgID(activityName string) string {
+	return activityName + alertMsgIDSuffix
+}
+
+// ShouldAlert reports whether activities from a rule with the given severity
+// are routed to the alert subject.
+func ShouldAlert(severity v1alpha1.ActivityPolicyRuleSeverity) bool {
+	return severity == v1alpha1.ActivityPolicyRuleSeverityHigh
+}
+
+// PublishAlert publishes an already-marshalled activity to the alert subject
+// hierarchy under prefix, mirroring the regular activity subject.
+func PublishAlert(js Publisher, prefix string, activity *v1alpha1.Activity, data []byte) error {
+	subject := ActivitySubject(prefix, activity)
+	if _, err := js.Publish(subject, data, nats.MsgId(AlertMsgID(activity.Name))); err != nil {
+		return fmt.Errorf("failed to publish alert to NATS: %w", err)
+	}
+
+	alertsPublished.WithLabelValues(activity.Spec.Origin.Type, activity.Spec.Origin.PolicyName).Inc()
+	return nil
+}
+
+// PublishAlertAsync queues the alert copy of an already-marshalled activity
+// without waiting for JetStream to acknowledge it. The caller must wait on the
+// returned future; the alert is counted once it is queued.
+func PublishAlertAsync(js AsyncPublisher, prefix string, activity *v1alpha1.Activity, data []byte) (nats.PubAckFuture, error) {
+	subject := ActivitySubject(prefix, activity)
+	future, err := js.PublishAsync(subject, data, nats.MsgId(AlertMsgID(activity.Name)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to publish alert to NATS: %w", err)
+	}
+
+	alertsPublished.WithLabelValues(activity.Spec.Origin.Type, activity.Spec.Origin.PolicyName).Inc()
+	return future, nil
+}
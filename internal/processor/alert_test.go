@@ -0,0 +1,78 @@
+package processor
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+)
+
+// fakePublisher records the subjects it is asked to publish to.
+type fakePublisher struct {
+	subjects []string
+	err      error
+}
+
+func (f *fakePublisher) Publish(subj string, data []byte, opts ...nats.PubOpt) (*nats.PubAck, error) {
+	f.subjects = append(f.subjects, subj)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &nats.PubAck{}, nil
+}
+
+func testAlertActivity() *v1alpha1.Activity {
+	activity := &v1alpha1.Activity{
+		Spec: v1alpha1.ActivitySpec{
+			Tenant:   v1alpha1.ActivityTenant{Type: "project", Name: "prod"},
+			Resource: v1alpha1.ActivityResource{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRoleBinding", Name: "admins"},
+			Origin:   v1alpha1.ActivityOrigin{Type: "audit", PolicyName: "clusterrolebindings"},
+		},
+	}
+	activity.Name = "act-0123456789ab"
+	return activity
+}
+
+func TestActivitySubject(t *testing.T) {
+	activity := testAlertActivity()
+	assert.Equal(t, "activities.project.prod.rbac.authorization.k8s.io.audit.ClusterRoleBinding._.act-0123456789ab",
+		ActivitySubject("activities", activity))
+	assert.Equal(t, "activity.alerts.project.prod.rbac.authorization.k8s.io.audit.ClusterRoleBinding._.act-0123456789ab",
+		ActivitySubject("activity.alerts", activity))
+
+	core := &v1alpha1.Activity{Spec: v1alpha1.ActivitySpec{
+		Resource: v1alpha1.ActivityResource{Kind: "Secret", Namespace: "default"},
+		Origin:   v1alpha1.ActivityOrigin{Type: "event"},
+	}}
+	core.Name = "act-x"
+	assert.Equal(t, "activities.platform._.core.event.Secret.default.act-x", ActivitySubject("activities", core))
+}
+
+func TestAlertMsgID(t *testing.T) {
+	assert.NotEqual(t, "act-0123456789ab", AlertMsgID("act-0123456789ab"))
+	assert.Equal(t, AlertMsgID("act-0123456789ab"), AlertMsgID("act-0123456789ab"))
+	assert.NotEqual(t, AlertMsgID("act-0123456789ab"), AlertMsgID("act-ba9876543210"))
+}
+
+func TestShouldAlert(t *testing.T) {
+	assert.True(t, ShouldAlert(v1alpha1.ActivityPolicyRuleSeverityHigh))
+	assert.False(t, ShouldAlert(v1alpha1.ActivityPolicyRuleSeverityNormal))
+	assert.False(t, ShouldAlert(""))
+}
+
+func TestPublishAlert(t *testing.T) {
+	js := &fakePublisher{}
+	require.NoError(t, PublishAlert(js, "activity.alerts", testAlertActivity(), []byte("{}")))
+	assert.Equal(t, []string{
+		"activity.alerts.project.prod.rbac.authorization.k8s.io.audit.ClusterRoleBinding._.act-0123456789ab",
+	}, js.subjects)
+
+	js = &fakePublisher{err: errors.New("no responders")}
+	err := PublishAlert(js, "activity.alerts", testAlertActivity(), []byte("{}"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to publish alert")
+}
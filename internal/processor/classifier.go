@@ -5,6 +5,7 @@ import (
 
 	authnv1 "k8s.io/api/authentication/v1"
 
+	"go.miloapis.com/activity/internal/changesource"
 	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
 )
 
@@ -15,10 +16,10 @@ const (
 )
 
 // ClassifyChangeSource determines whether an activity was initiated by a human
-// or by the system (controllers, service accounts, etc.).
-// System accounts always use a "system:" prefix for the username.
+// or by the system (controllers, service accounts, etc.). Which usernames count
+// as system actors is configured with --system-users-config; see changesource.
 func ClassifyChangeSource(user authnv1.UserInfo) string {
-	if strings.HasPrefix(user.Username, "system:") {
+	if changesource.Default().IsSystem(user.Username) {
 		return ChangeSourceSystem
 	}
 
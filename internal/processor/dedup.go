@@ -0,0 +1,179 @@
+package processor
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+
+	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+)
+
+// DefaultDedupMaxEntries bounds the number of recently published activities
+// remembered by an ActivityDeduplicator.
+const DefaultDedupMaxEntries = 10000
+
+// ActivityDeduplicator suppresses identical activities published in quick
+// succession, such as the flood produced by a controller issuing no-op
+// updates. It only remembers activities for a short window and a bounded
+// number of entries, so it never grows with traffic.
+//
+// The first activity for a key is always published; repeats are suppressed
+// until the window since that first activity has passed, after which the next
+// repeat is published and starts a new window.
+type ActivityDeduplicator struct {
+	window     time.Duration
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List // of *dedupEntry, oldest first
+
+	now func() time.Time
+}
+
+type dedupEntry struct {
+	key  string
+	seen time.Time
+}
+
+// NewActivityDeduplicator creates a deduplicator with the given window. A
+// maxEntries of zero or less uses DefaultDedupMaxEntries.
+func NewActivityDeduplicator(window time.Duration, maxEntries int) *ActivityDeduplicator {
+	if maxEntries <= 0 {
+		maxEntries = DefaultDedupMaxEntries
+	}
+	return &ActivityDeduplicator{
+		window:     window,
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+		now:        time.Now,
+	}
+}
+
+// IsDuplicate reports whether an activity with the same key was recorded
+// within the window. A nil deduplicator never reports duplicates.
+func (d *ActivityDeduplicator) IsDuplicate(key string) bool {
+	if d == nil {
+		return false
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	elem, ok := d.entries[key]
+	if !ok {
+		return false
+	}
+	return d.now().Sub(elem.Value.(*dedupEntry).seen) < d.window
+}
+
+// Record remembers key as published now. Call it only after the activity was
+// published, so a failed publish that is redelivered isn't suppressed.
+func (d *ActivityDeduplicator) Record(key string) {
+	if d == nil {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := d.now()
+	if elem, ok := d.entries[key]; ok {
+		elem.Value.(*dedupEntry).seen = now
+		d.order.MoveToBack(elem)
+	} else {
+		d.entries[key] = d.order.PushBack(&dedupEntry{key: key, seen: now})
+	}
+	d.evict(now)
+}
+
+// evict drops expired entries and, if the cache is still over capacity, the
+// oldest ones. Entries are kept in the order they were recorded, so both
+// stop at the first entry that can stay.
+func (d *ActivityDeduplicator) evict(now time.Time) {
+	for front := d.order.Front(); front != nil; front = d.order.Front() {
+		entry := front.Value.(*dedupEntry)
+		if now.Sub(entry.seen) < d.window && d.order.Len() <= d.maxEntries {
+			return
+		}
+		d.order.Remove(front)
+		delete(d.entries, entry.key)
+	}
+}
+
+// Len returns the number of remembered activities.
+func (d *ActivityDeduplicator) Len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.order.Len()
+}
+
+// DedupKey identifies an activity generated from an audit event for
+// deduplication. Beyond the resource, verb and summary it includes the actor,
+// the recorded field changes and the resourceVersion the API server returned,
+// so real changes that happen to share a summary get distinct keys: every
+// write that modifies an object bumps its resourceVersion, while no-op
+// updates return the existing one.
+//
+// It returns "" when the audit event has no response object to read the
+// resourceVersion from (e.g. Metadata-level audit policies). Such activities
+// can't be told apart from distinct changes and must not be deduplicated.
+func DedupKey(activity *v1alpha1.Activity, audit *auditv1.Event) string {
+	resourceVersion := extractResponseResourceVersion(audit.ResponseObject)
+	if resourceVersion == "" {
+		return ""
+	}
+
+	resource := activity.Spec.Resource
+	resourceID := resource.UID
+	if resourceID == "" {
+		resourceID = resource.APIGroup + "/" + resource.Kind + "/" + resource.Namespace + "/" + resource.Name
+	}
+
+	h := sha256.New()
+	for _, part := range []string{
+		resourceID,
+		audit.Verb,
+		activity.Spec.Summary,
+		activity.Spec.Actor.UID,
+		activity.Spec.Actor.Name,
+		resourceVersion,
+	} {
+		h.Write([]byte(part))
+		h.Write([]byte{0}) // separator
+	}
+	for _, change := range activity.Spec.Changes {
+		h.Write([]byte(change.Field))
+		h.Write([]byte{0})
+		h.Write([]byte(change.Old))
+		h.Write([]byte{0})
+		h.Write([]byte(change.New))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// extractResponseResourceVersion extracts metadata.resourceVersion from an
+// audit response object.
+func extractResponseResourceVersion(responseObject *runtime.Unknown) string {
+	if responseObject == nil || len(responseObject.Raw) == 0 {
+		return ""
+	}
+
+	var obj struct {
+		Metadata struct {
+			ResourceVersion string `json:"resourceVersion"`
+		} `json:"metadata"`
+	}
+	if err := json.Unmarshal(responseObject.Raw, &obj); err != nil {
+		return ""
+	}
+	return obj.Metadata.ResourceVersion
+}
@@ -0,0 +1,102 @@
+package processor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+
+	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+)
+
+// fakeClock is a manually advanced clock for deduplicator tests.
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+func newTestDeduplicator(window time.Duration, maxEntries int) (*ActivityDeduplicator, *fakeClock) {
+	clock := &fakeClock{t: time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)}
+	d := NewActivityDeduplicator(window, maxEntries)
+	d.now = clock.now
+	return d, clock
+}
+
+func dedupActivity(summary string, changes ...v1alpha1.ActivityChange) *v1alpha1.Activity {
+	activity := &v1alpha1.Activity{}
+	activity.Spec.Summary = summary
+	activity.Spec.Actor = v1alpha1.ActivityActor{Type: ActorTypeController, Name: "deployment-controller", UID: "c-1"}
+	activity.Spec.Resource = v1alpha1.ActivityResource{APIGroup: "apps", Kind: "Deployment", Namespace: "default", Name: "api", UID: "r-1"}
+	activity.Spec.Changes = changes
+	return activity
+}
+
+func dedupAudit(verb, resourceVersion string) *auditv1.Event {
+	audit := &auditv1.Event{Verb: verb}
+	if resourceVersion != "" {
+		audit.ResponseObject = &runtime.Unknown{
+			Raw: []byte(`{"metadata":{"name":"api","resourceVersion":"` + resourceVersion + `"}}`),
+		}
+	}
+	return audit
+}
+
+func TestActivityDeduplicator_Window(t *testing.T) {
+	d, clock := newTestDeduplicator(10*time.Second, 0)
+
+	assert.False(t, d.IsDuplicate("k"))
+	d.Record("k")
+
+	clock.t = clock.t.Add(9 * time.Second)
+	assert.True(t, d.IsDuplicate("k"), "repeat within the window")
+	assert.False(t, d.IsDuplicate("other"))
+
+	clock.t = clock.t.Add(time.Second)
+	assert.False(t, d.IsDuplicate("k"), "repeat once the window has passed")
+}
+
+func TestActivityDeduplicator_Bounded(t *testing.T) {
+	d, clock := newTestDeduplicator(time.Minute, 3)
+
+	for _, key := range []string{"a", "b", "c", "d"} {
+		d.Record(key)
+		clock.t = clock.t.Add(time.Second)
+	}
+	assert.Equal(t, 3, d.Len())
+	assert.False(t, d.IsDuplicate("a"), "oldest entry evicted at capacity")
+	assert.True(t, d.IsDuplicate("d"))
+
+	// Expired entries are dropped on the next Record.
+	clock.t = clock.t.Add(time.Minute)
+	d.Record("e")
+	assert.Equal(t, 1, d.Len())
+}
+
+func TestActivityDeduplicator_NilIsNoop(t *testing.T) {
+	var d *ActivityDeduplicator
+	d.Record("k")
+	assert.False(t, d.IsDuplicate("k"))
+}
+
+func TestDedupKey(t *testing.T) {
+	base := DedupKey(dedupActivity("scaled deployment api"), dedupAudit("update", "100"))
+	assert.NotEmpty(t, base)
+
+	// A no-op update returns the same resourceVersion.
+	assert.Equal(t, base, DedupKey(dedupActivity("scaled deployment api"), dedupAudit("update", "100")))
+
+	// Real changes bump the resourceVersion or record different values.
+	assert.NotEqual(t, base, DedupKey(dedupActivity("scaled deployment api"), dedupAudit("update", "101")))
+	assert.NotEqual(t, base, DedupKey(
+		dedupActivity("scaled deployment api", v1alpha1.ActivityChange{Field: "spec.replicas", Old: "2", New: "3"}),
+		dedupAudit("update", "100"),
+	))
+	assert.NotEqual(t, base, DedupKey(dedupActivity("scaled deployment api"), dedupAudit("patch", "100")))
+	assert.NotEqual(t, base, DedupKey(dedupActivity("updated deployment api"), dedupAudit("update", "100")))
+
+	// Without a response object distinct changes can't be told apart.
+	assert.Empty(t, DedupKey(dedupActivity("scaled deployment api"), dedupAudit("update", "")))
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/nats-io/nats.go"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 
 	"go.miloapis.com/activity/internal/cel"
 	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
@@ -23,7 +24,10 @@ type EventProcessor struct {
 	streamName     string
 	consumerName   string
 	activityPrefix string
+	alertPrefix    string
 	batchSize      int
+	fetchMaxWait   time.Duration
+	maxSummary     int
 	policyLookup   EventPolicyLookup
 	workers        int
 	dlqPublisher   DLQPublisher
@@ -34,26 +38,41 @@ type EventProcessor struct {
 // streamName is the NATS stream to consume from (e.g., "EVENTS").
 // consumerName is the durable pull consumer name.
 // activityPrefix is the subject prefix for publishing generated activities.
+// alertPrefix is the subject prefix for the alert copy of high-severity
+// activities; empty disables alert routing.
 // policyLookup is used to evaluate events against ActivityPolicy event rules.
+// fetchMaxWait is how long each fetch waits for a full batch; zero uses
+// DefaultFetchMaxWait.
+// maxSummaryLength truncates generated summaries to that many bytes; zero
+// disables truncation.
 // dlqPublisher is used to publish failed events to the dead-letter queue.
 func NewEventProcessor(
 	js nats.JetStreamContext,
 	streamName string,
 	consumerName string,
 	activityPrefix string,
+	alertPrefix string,
 	policyLookup EventPolicyLookup,
 	workers int,
 	batchSize int,
+	fetchMaxWait time.Duration,
+	maxSummaryLength int,
 	dlqPublisher DLQPublisher,
 ) *EventProcessor {
+	if fetchMaxWait <= 0 {
+		fetchMaxWait = DefaultFetchMaxWait
+	}
 	return &EventProcessor{
 		js:             js,
 		streamName:     streamName,
 		consumerName:   consumerName,
 		activityPrefix: activityPrefix,
+		alertPrefix:    alertPrefix,
 		policyLookup:   policyLookup,
 		workers:        workers,
 		batchSize:      batchSize,
+		fetchMaxWait:   fetchMaxWait,
+		maxSummary:     maxSummaryLength,
 		dlqPublisher:   dlqPublisher,
 	}
 }
@@ -109,14 +128,16 @@ func (p *EventProcessor) worker(ctx context.Context, id int) {
 		default:
 		}
 
-		msgs, err := sub.Fetch(p.batchSize, nats.MaxWait(5*time.Second))
+		msgs, err := sub.Fetch(p.batchSize, nats.MaxWait(p.fetchMaxWait))
 		if err != nil {
 			if err == nats.ErrTimeout {
+				ObserveFetchBatch("control_plane_event", 0)
 				continue
 			}
 			klog.ErrorS(err, "Failed to fetch event messages", "worker", id)
 			continue
 		}
+		ObserveFetchBatch("control_plane_event", len(msgs))
 
 		for _, msg := range msgs {
 			if err := p.processMessage(ctx, msg); err != nil {
@@ -219,9 +240,10 @@ func (p *EventProcessor) processMessage(ctx context.Context, msg *nats.Msg) erro
 		return nil
 	}
 
-	activity := p.buildActivity(event, matched, involvedObject, matched.Summary, matched.Links)
+	summary := LimitSummary(matched.Summary, p.maxSummary, matched.PolicyName, matched.RuleIndex)
+	activity := p.buildActivity(event, matched, involvedObject, summary, matched.Links)
 
-	if err := p.publishActivity(ctx, activity); err != nil {
+	if err := p.publishActivity(ctx, activity, ShouldAlert(matched.Severity)); err != nil {
 		return fmt.Errorf("failed to publish activity: %w", err)
 	}
 
@@ -380,8 +402,10 @@ func (p *EventProcessor) buildActivity(
 			// Extract tenant from scope annotations; fall back to platform scope when absent.
 			Tenant: ExtractTenantFromAnnotations(event),
 			Origin: v1alpha1.ActivityOrigin{
-				Type: "event",
-				ID:   eventUID,
+				Type:       "event",
+				ID:         eventUID,
+				PolicyName: matched.PolicyName,
+				RuleIndex:  ptr.To(int32(matched.RuleIndex)),
 			},
 		},
 	}
@@ -412,13 +436,15 @@ func (p *EventProcessor) resolveEventActor(event map[string]interface{}) v1alpha
 }
 
 // publishActivity serializes and publishes an Activity to the NATS ACTIVITIES stream.
-func (p *EventProcessor) publishActivity(ctx context.Context, activity *v1alpha1.Activity) error {
+// When alert is set and an alert prefix is configured, the activity is also
+// published to the alert subject.
+func (p *EventProcessor) publishActivity(ctx context.Context, activity *v1alpha1.Activity, alert bool) error {
 	data, err := json.Marshal(activity)
 	if err != nil {
 		return fmt.Errorf("failed to marshal activity: %w", err)
 	}
 
-	subject := p.buildActivitySubject(activity)
+	subject := ActivitySubject(p.activityPrefix, activity)
 
 	// Use activity name as MsgID for NATS deduplication.
 	_, err = p.js.Publish(subject, data, nats.MsgId(activity.Name))
@@ -426,38 +452,15 @@ func (p *EventProcessor) publishActivity(ctx context.Context, activity *v1alpha1
 		return fmt.Errorf("failed to publish activity to NATS: %w", err)
 	}
 
-	return nil
-}
-
-// buildActivitySubject returns the NATS subject for routing activities.
-// Format: <prefix>.<tenant_type>.<tenant_name>.<api_group>.<origin>.<kind>.<namespace>.<name>
-func (p *EventProcessor) buildActivitySubject(activity *v1alpha1.Activity) string {
-	prefix := p.activityPrefix
-
-	tenantType := activity.Spec.Tenant.Type
-	if tenantType == "" {
-		tenantType = "platform"
-	}
-	tenantName := activity.Spec.Tenant.Name
-	if tenantName == "" {
-		tenantName = "_"
-	}
-
-	apiGroup := activity.Spec.Resource.APIGroup
-	if apiGroup == "" {
-		apiGroup = "core"
-	}
-
-	origin := activity.Spec.Origin.Type
-	kind := activity.Spec.Resource.Kind
-	namespace := activity.Spec.Resource.Namespace
-	if namespace == "" {
-		namespace = "_"
+	if alert && p.alertPrefix != "" {
+		// On failure the event is redelivered; the regular copy is then
+		// deduplicated by NATS while the alert copy is retried.
+		if err := PublishAlert(p.js, p.alertPrefix, activity, data); err != nil {
+			return err
+		}
 	}
-	name := activity.Name
 
-	return fmt.Sprintf("%s.%s.%s.%s.%s.%s.%s.%s",
-		prefix, tenantType, tenantName, apiGroup, origin, kind, namespace, name)
+	return nil
 }
 
 // parseAPIGroup extracts the API group from an apiVersion string.
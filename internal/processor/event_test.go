@@ -379,6 +379,7 @@ func TestBuildActivityFromEvent(t *testing.T) {
 	matched := &MatchedPolicy{
 		PolicyName: "core-pods",
 		Generation: 1,
+		RuleIndex:  1,
 		APIGroup:   "",
 		Kind:       "Pod",
 		Summary:    "Pod my-pod was scheduled",
@@ -419,6 +420,14 @@ func TestBuildActivityFromEvent(t *testing.T) {
 		t.Errorf("Origin.Type = %q, want %q", activity.Spec.Origin.Type, "event")
 	}
 
+	if activity.Spec.Origin.PolicyName != "core-pods" {
+		t.Errorf("Origin.PolicyName = %q, want %q", activity.Spec.Origin.PolicyName, "core-pods")
+	}
+
+	if activity.Spec.Origin.RuleIndex == nil || *activity.Spec.Origin.RuleIndex != 1 {
+		t.Errorf("Origin.RuleIndex = %v, want 1", activity.Spec.Origin.RuleIndex)
+	}
+
 	if activity.Spec.Origin.ID != "event-123" {
 		t.Errorf("Origin.ID = %q, want %q", activity.Spec.Origin.ID, "event-123")
 	}
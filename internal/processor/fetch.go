@@ -0,0 +1,34 @@
+package processor
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// DefaultFetchMaxWait is how long a pull consumer waits for a batch to fill
+// before returning the messages it has.
+const DefaultFetchMaxWait = 5 * time.Second
+
+var fetchBatchSize = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Namespace: "activity_processor",
+		Subsystem: "nats",
+		Name:      "fetch_batch_size",
+		Help:      "Number of messages returned by each pull consumer fetch",
+		Buckets:   []float64{0, 1, 5, 10, 25, 50, 100, 250, 500, 1000},
+	},
+	[]string{"source"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(fetchBatchSize)
+}
+
+// ObserveFetchBatch records how many messages one fetch returned. Fetches that
+// time out with nothing to deliver should be recorded as zero so idle workers
+// show up in the distribution.
+func ObserveFetchBatch(source string, size int) {
+	fetchBatchSize.WithLabelValues(source).Observe(float64(size))
+}
@@ -1,6 +1,9 @@
 package processor
 
-import "go.miloapis.com/activity/internal/cel"
+import (
+	"go.miloapis.com/activity/internal/cel"
+	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+)
 
 // PolicyRule represents a compiled activity policy rule for CEL evaluation.
 // This is the minimal interface that EventProcessor and AuditProcessor need
@@ -24,6 +27,8 @@ type MatchedPolicy struct {
 	PolicyName string
 	// Generation is the policy generation (version) that produced this match.
 	Generation int64
+	// RuleIndex is the index of the matching rule within the policy's rules.
+	RuleIndex int
 	// APIGroup is the API group of the target resource.
 	APIGroup string
 	// Kind is the kind of the target resource.
@@ -32,6 +37,8 @@ type MatchedPolicy struct {
 	Summary string
 	// Links contains clickable references extracted from link() calls in the summary template.
 	Links []cel.Link
+	// Severity is the matching rule's severity, which decides alert routing.
+	Severity v1alpha1.ActivityPolicyRuleSeverity
 }
 
 // EventPolicyLookup is the interface used by EventProcessor to look up and
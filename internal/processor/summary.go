@@ -0,0 +1,66 @@
+package processor
+
+import (
+	"unicode/utf8"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// DefaultMaxSummaryLength is the default limit, in bytes, on generated
+// activity summaries.
+const DefaultMaxSummaryLength = 2048
+
+// summaryEllipsis marks a summary that was cut short.
+const summaryEllipsis = "…"
+
+var summariesTruncated = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "activity_processor",
+		Name:      "summaries_truncated_total",
+		Help:      "Total number of generated activity summaries truncated to the maximum summary length",
+	},
+	[]string{"policy_name"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(summariesTruncated)
+}
+
+// TruncateSummary shortens summary to at most maxLength bytes, ending it with
+// an ellipsis. The cut is made on a rune boundary so multi-byte characters are
+// never split. A maxLength of zero or less disables truncation.
+func TruncateSummary(summary string, maxLength int) (string, bool) {
+	if maxLength <= 0 || len(summary) <= maxLength {
+		return summary, false
+	}
+
+	cut := maxLength - len(summaryEllipsis)
+	if cut <= 0 {
+		return summaryEllipsis, true
+	}
+	for cut > 0 && !utf8.RuneStart(summary[cut]) {
+		cut--
+	}
+	return summary[:cut] + summaryEllipsis, true
+}
+
+// LimitSummary truncates a summary generated by a policy rule to maxLength and
+// records when it had to. The full length is logged so policy authors can find
+// the rule whose template produced it.
+func LimitSummary(summary string, maxLength int, policyName string, ruleIndex int) string {
+	limited, truncated := TruncateSummary(summary, maxLength)
+	if !truncated {
+		return summary
+	}
+
+	summariesTruncated.WithLabelValues(policyName).Inc()
+	klog.V(4).InfoS("Truncated activity summary",
+		"policy", policyName,
+		"ruleIndex", ruleIndex,
+		"length", len(summary),
+		"maxLength", maxLength,
+	)
+	return limited
+}
@@ -0,0 +1,49 @@
+package processor
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncateSummary(t *testing.T) {
+	tests := []struct {
+		name          string
+		summary       string
+		maxLength     int
+		want          string
+		wantTruncated bool
+	}{
+		{name: "shorter than limit", summary: "alice created pod web", maxLength: 100, want: "alice created pod web"},
+		{name: "exactly the limit", summary: "abcdef", maxLength: 6, want: "abcdef"},
+		{name: "zero disables truncation", summary: strings.Repeat("a", 5000), maxLength: 0, want: strings.Repeat("a", 5000)},
+		{name: "ascii truncated", summary: "abcdefghij", maxLength: 8, want: "abcde…", wantTruncated: true},
+		// "é" is two bytes; cutting at byte 5 would split the third one.
+		{name: "multi-byte cut on rune boundary", summary: "ééééé", maxLength: 8, want: "éé…", wantTruncated: true},
+		{name: "limit smaller than ellipsis", summary: "abcdef", maxLength: 2, want: "…", wantTruncated: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, truncated := TruncateSummary(tt.summary, tt.maxLength)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantTruncated, truncated)
+			assert.True(t, utf8.ValidString(got), "truncated summary must be valid UTF-8")
+			if truncated && tt.maxLength >= len(summaryEllipsis) {
+				assert.LessOrEqual(t, len(got), tt.maxLength)
+			}
+		})
+	}
+}
+
+func TestLimitSummary_CountsTruncations(t *testing.T) {
+	before := testutil.ToFloat64(summariesTruncated.WithLabelValues("noisy-policy"))
+
+	assert.Equal(t, "short", LimitSummary("short", 10, "noisy-policy", 0))
+	assert.Equal(t, "abcdefg…", LimitSummary(strings.Repeat("abcdefghij", 10), 10, "noisy-policy", 2))
+
+	assert.Equal(t, before+1, testutil.ToFloat64(summariesTruncated.WithLabelValues("noisy-policy")))
+}
@@ -0,0 +1,286 @@
+package activitymetrics
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/klog/v2"
+
+	"go.miloapis.com/activity/internal/apierrors"
+	"go.miloapis.com/activity/internal/cel"
+	"go.miloapis.com/activity/internal/registry/scope"
+	"go.miloapis.com/activity/internal/storage"
+	"go.miloapis.com/activity/internal/timeutil"
+	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+)
+
+const (
+	// DefaultBucketSize is applied when spec.bucketSize is empty.
+	DefaultBucketSize = time.Hour
+
+	// MinBucketSize is the smallest accepted bucket. Activities are stored with
+	// millisecond precision, but sub-minute buckets are rarely useful on a
+	// dashboard and multiply the number of points.
+	MinBucketSize = time.Minute
+
+	// MaxBuckets limits how many buckets a single query window may contain.
+	MaxBuckets = 1000
+)
+
+// StorageInterface defines the storage operations needed by QueryStorage.
+type StorageInterface interface {
+	QueryActivityMetrics(ctx context.Context, spec storage.ActivityMetricsQuerySpec, scope storage.ScopeContext) (*storage.ActivityMetricsResult, error)
+	GetMaxQueryWindow() time.Duration
+	GetMaxQueryTimeout() time.Duration
+	GetTenantMaxExecutionTime(scope storage.ScopeContext) time.Duration
+	Now() time.Time
+}
+
+// QueryStorage implements REST storage for ActivityMetricsQuery.
+// This is an ephemeral resource - it only supports Create operations and
+// returns aggregated counts without persisting anything.
+type QueryStorage struct {
+	storage StorageInterface
+}
+
+// NewQueryStorage creates a new REST storage for ActivityMetricsQuery.
+func NewQueryStorage(s StorageInterface) *QueryStorage {
+	return &QueryStorage{
+		storage: s,
+	}
+}
+
+var (
+	_ rest.Scoper               = &QueryStorage{}
+	_ rest.Creater              = &QueryStorage{}
+	_ rest.Storage              = &QueryStorage{}
+	_ rest.SingularNameProvider = &QueryStorage{}
+)
+
+// New returns an empty ActivityMetricsQuery.
+func (s *QueryStorage) New() runtime.Object {
+	return &v1alpha1.ActivityMetricsQuery{}
+}
+
+// Destroy cleans up resources.
+func (s *QueryStorage) Destroy() {}
+
+// NamespaceScoped returns false because ActivityMetricsQuery is cluster-scoped.
+func (s *QueryStorage) NamespaceScoped() bool {
+	return false
+}
+
+// GetSingularName returns the singular name of the resource.
+func (s *QueryStorage) GetSingularName() string {
+	return "activitymetricsquery"
+}
+
+// Create executes the metrics query and returns the aggregated counts.
+func (s *QueryStorage) Create(ctx context.Context, obj runtime.Object, createValidation rest.ValidateObjectFunc, options *metav1.CreateOptions) (runtime.Object, error) {
+	query, ok := obj.(*v1alpha1.ActivityMetricsQuery)
+	if !ok {
+		return nil, errors.NewBadRequest("expected ActivityMetricsQuery object")
+	}
+
+	// Use a single reference time so relative start and end times resolve consistently
+	now := s.storage.Now()
+
+	// Validate input - collect all errors so users can fix everything in one request
+	if errs := s.validateQuerySpec(query, now); len(errs) > 0 {
+		return nil, apierrors.NewValidationStatusError(
+			v1alpha1.SchemeGroupVersion.WithKind("ActivityMetricsQuery").GroupKind(), query.Name, errs)
+	}
+
+	reqUser, ok := request.UserFrom(ctx)
+	if !ok {
+		return nil, errors.NewInternalError(fmt.Errorf("no user in context"))
+	}
+	scopeCtx := scope.ExtractScopeFromUser(reqUser)
+
+	// Validation guarantees these parse
+	startTime, _ := timeutil.ParseFlexibleTime(query.Spec.StartTime, now)
+	endTime, _ := timeutil.ParseFlexibleTime(query.Spec.EndTime, now)
+	bucketSize, _ := ParseBucketSize(query.Spec.BucketSize)
+	bucketSeconds := int64(bucketSize / time.Second)
+
+	// Align the start down to a bucket boundary so the first bucket is complete.
+	// ClickHouse's toStartOfInterval aligns to the Unix epoch, so do the same here.
+	alignedStart := time.Unix(startTime.Unix()/bucketSeconds*bucketSeconds, 0).UTC()
+
+	spec := storage.ActivityMetricsQuerySpec{
+		StartTime:     alignedStart,
+		EndTime:       endTime,
+		BucketSeconds: bucketSeconds,
+		GroupBy:       query.Spec.GroupBy,
+		Filter:        query.Spec.Filter,
+	}
+
+	klog.V(4).InfoS("Executing activity metrics query",
+		"query", query.Name,
+		"scopeType", scopeCtx.Type,
+		"scopeName", scopeCtx.Name,
+		"startTime", alignedStart,
+		"endTime", endTime,
+		"bucketSeconds", bucketSeconds,
+		"groupBy", query.Spec.GroupBy,
+	)
+
+	queryCtx, cancel := storage.TenantQueryContext(ctx, query.Spec.TimeoutSeconds, s.storage.GetTenantMaxExecutionTime(scopeCtx))
+	defer cancel()
+
+	result, err := s.storage.QueryActivityMetrics(queryCtx, spec, scopeCtx)
+	if err != nil {
+		if stderrors.Is(err, storage.ErrTooManyMetricsPoints) {
+			return nil, errors.NewBadRequest(fmt.Sprintf(
+				"The query produced more than %d data points. Use a larger bucketSize, a shorter time range, or a filter to reduce the number of series.",
+				storage.MaxActivityMetricsPoints))
+		}
+		if storage.IsQueryQueueTimeout(err) {
+			return nil, errors.NewServiceUnavailable(storage.QueryQueueTimeoutMessage)
+		}
+		if storage.IsQueryTimeout(queryCtx) {
+			return nil, errors.NewTimeoutError(storage.QueryTimeoutMessage, 0)
+		}
+		// Log the actual error for debugging but return a generic message to avoid leaking internal details
+		klog.ErrorS(err, "Failed to query activity metrics",
+			"filter", query.Spec.Filter,
+			"groupBy", query.Spec.GroupBy,
+			"startTime", query.Spec.StartTime,
+			"endTime", query.Spec.EndTime,
+		)
+		return nil, errors.NewServiceUnavailable("Failed to retrieve activity metrics. Try again or contact support if the problem persists.")
+	}
+
+	response := query.DeepCopy()
+	response.Status = v1alpha1.ActivityMetricsQueryStatus{
+		Points:             make([]v1alpha1.ActivityMetricsPoint, len(result.Points)),
+		EffectiveStartTime: alignedStart.Format(time.RFC3339),
+		EffectiveEndTime:   endTime.UTC().Format(time.RFC3339),
+		BucketSeconds:      bucketSeconds,
+	}
+	for i, p := range result.Points {
+		response.Status.Points[i] = v1alpha1.ActivityMetricsPoint{
+			Timestamp: p.Bucket.UTC().Format(time.RFC3339),
+			Series:    p.Series,
+			Count:     p.Count,
+		}
+	}
+
+	return response, nil
+}
+
+// validateQuerySpec validates the query specification and returns all field errors.
+func (s *QueryStorage) validateQuerySpec(query *v1alpha1.ActivityMetricsQuery, now time.Time) field.ErrorList {
+	allErrs := field.ErrorList{}
+	specPath := field.NewPath("spec")
+
+	var startTime, endTime time.Time
+	var startErr, endErr error
+
+	if query.Spec.StartTime == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("startTime"), "must specify a start time"))
+	} else if startTime, startErr = timeutil.ParseFlexibleTime(query.Spec.StartTime, now); startErr != nil {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("startTime"), query.Spec.StartTime, startErr.Error()))
+	}
+
+	if query.Spec.EndTime == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("endTime"), "must specify an end time"))
+	} else if endTime, endErr = timeutil.ParseFlexibleTime(query.Spec.EndTime, now); endErr != nil {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("endTime"), query.Spec.EndTime, endErr.Error()))
+	}
+
+	bucketSize, bucketErr := ParseBucketSize(query.Spec.BucketSize)
+	if bucketErr != nil {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("bucketSize"), query.Spec.BucketSize, bucketErr.Error()))
+	}
+
+	if query.Spec.StartTime != "" && query.Spec.EndTime != "" && startErr == nil && endErr == nil {
+		if !endTime.After(startTime) {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("endTime"), query.Spec.EndTime, "endTime must be after startTime"))
+		} else {
+			window := endTime.Sub(startTime)
+			maxWindow := s.storage.GetMaxQueryWindow()
+			if maxWindow > 0 && window > maxWindow {
+				allErrs = append(allErrs, field.Invalid(specPath, fmt.Sprintf("%s to %s", query.Spec.StartTime, query.Spec.EndTime),
+					fmt.Sprintf("time range of %v exceeds maximum of %v", window, maxWindow)))
+			}
+			if bucketErr == nil {
+				if buckets := int64((window + bucketSize - 1) / bucketSize); buckets > MaxBuckets {
+					allErrs = append(allErrs, field.Invalid(specPath.Child("bucketSize"), query.Spec.BucketSize,
+						fmt.Sprintf("time range would contain %d buckets, maximum is %d. Use a larger bucket size or a shorter time range", buckets, MaxBuckets)))
+				}
+			}
+		}
+	}
+
+	if query.Spec.GroupBy != "" && !storage.IsValidActivityMetricsGroupBy(query.Spec.GroupBy) {
+		if query.Spec.GroupBy == "verb" {
+			// Activities summarize changes and do not record the API verb; verb
+			// breakdowns are available from audit logs instead.
+			allErrs = append(allErrs, field.Invalid(specPath.Child("groupBy"), query.Spec.GroupBy,
+				"activities do not record the API verb. Use an AuditLogFacetsQuery with the verb facet for verb breakdowns"))
+		} else {
+			allErrs = append(allErrs, field.NotSupported(specPath.Child("groupBy"), query.Spec.GroupBy, storage.GetActivityMetricsGroupByNames()))
+		}
+	}
+
+	if query.Spec.Filter != "" {
+		if _, err := cel.CompileActivityFilter(query.Spec.Filter); err != nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("filter"), query.Spec.Filter, err.Error()))
+		}
+	}
+
+	if query.Spec.TimeoutSeconds != nil {
+		if err := storage.ValidateQueryTimeout(*query.Spec.TimeoutSeconds, s.storage.GetMaxQueryTimeout()); err != nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("timeoutSeconds"), *query.Spec.TimeoutSeconds, err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
+// ParseBucketSize parses a bucket size expressed as a Go duration ("5m", "1h")
+// or a whole number of days ("1d", "7d"). An empty string yields DefaultBucketSize.
+func ParseBucketSize(s string) (time.Duration, error) {
+	if s == "" {
+		return DefaultBucketSize, nil
+	}
+
+	var d time.Duration
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("must be a duration like \"5m\", \"1h\", or \"1d\"")
+		}
+		d = time.Duration(n) * 24 * time.Hour
+	} else {
+		parsed, err := time.ParseDuration(s)
+		if err != nil {
+			return 0, fmt.Errorf("must be a duration like \"5m\", \"1h\", or \"1d\"")
+		}
+		d = parsed
+	}
+
+	if d < MinBucketSize {
+		return 0, fmt.Errorf("must be at least %v", MinBucketSize)
+	}
+	if d%time.Second != 0 {
+		return 0, fmt.Errorf("must be a whole number of seconds")
+	}
+	return d, nil
+}
+
+// ConvertToTable converts to table format.
+func (s *QueryStorage) ConvertToTable(ctx context.Context, object runtime.Object, tableOptions runtime.Object) (*metav1.Table, error) {
+	return rest.NewDefaultTableConvertor(v1alpha1.Resource("activitymetricsquery")).ConvertToTable(ctx, object, tableOptions)
+}
@@ -0,0 +1,243 @@
+package activitymetrics
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
+
+	"go.miloapis.com/activity/internal/registry/scope"
+	"go.miloapis.com/activity/internal/storage"
+	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+)
+
+// mockMetricsStorage is a test double for StorageInterface
+type mockMetricsStorage struct {
+	queryFunc func(ctx context.Context, spec storage.ActivityMetricsQuerySpec, scope storage.ScopeContext) (*storage.ActivityMetricsResult, error)
+	now       func() time.Time
+}
+
+func (m *mockMetricsStorage) QueryActivityMetrics(ctx context.Context, spec storage.ActivityMetricsQuerySpec, scope storage.ScopeContext) (*storage.ActivityMetricsResult, error) {
+	if m.queryFunc != nil {
+		return m.queryFunc(ctx, spec, scope)
+	}
+	return &storage.ActivityMetricsResult{}, nil
+}
+
+func (m *mockMetricsStorage) GetMaxQueryWindow() time.Duration {
+	return 30 * 24 * time.Hour
+}
+
+func (m *mockMetricsStorage) GetMaxQueryTimeout() time.Duration {
+	return storage.DefaultMaxQueryTimeout
+}
+
+func (m *mockMetricsStorage) GetTenantMaxExecutionTime(scope storage.ScopeContext) time.Duration {
+	return 0
+}
+
+func (m *mockMetricsStorage) Now() time.Time {
+	if m.now != nil {
+		return m.now()
+	}
+	return time.Now()
+}
+
+func testContext() context.Context {
+	return request.WithUser(context.Background(), &user.DefaultInfo{
+		Name: "test-user",
+		Extra: map[string][]string{
+			scope.ParentKindExtraKey: {"Organization"},
+			scope.ParentNameExtraKey: {"test-org"},
+		},
+	})
+}
+
+func TestParseBucketSize(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{input: "", want: time.Hour},
+		{input: "5m", want: 5 * time.Minute},
+		{input: "1h", want: time.Hour},
+		{input: "1d", want: 24 * time.Hour},
+		{input: "7d", want: 7 * 24 * time.Hour},
+		{input: "30s", wantErr: true},
+		{input: "0d", wantErr: true},
+		{input: "1w", wantErr: true},
+		{input: "61500ms", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseBucketSize(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseBucketSize(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseBucketSize(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryStorage_Create_Success(t *testing.T) {
+	bucket := time.Date(2026, 10, 15, 10, 0, 0, 0, time.UTC)
+
+	var captured storage.ActivityMetricsQuerySpec
+	mock := &mockMetricsStorage{
+		queryFunc: func(ctx context.Context, spec storage.ActivityMetricsQuerySpec, scope storage.ScopeContext) (*storage.ActivityMetricsResult, error) {
+			captured = spec
+			return &storage.ActivityMetricsResult{
+				Points: []storage.ActivityMetricsPointResult{
+					{Bucket: bucket, Series: "Deployment", Count: 12},
+					{Bucket: bucket, Series: "HTTPProxy", Count: 3},
+				},
+			}, nil
+		},
+	}
+	s := NewQueryStorage(mock)
+
+	query := &v1alpha1.ActivityMetricsQuery{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-metrics"},
+		Spec: v1alpha1.ActivityMetricsQuerySpec{
+			StartTime:  "2026-10-15T09:47:12Z",
+			EndTime:    "2026-10-15T12:00:00Z",
+			BucketSize: "15m",
+			GroupBy:    "resource",
+			Filter:     "spec.changeSource == 'human'",
+		},
+	}
+
+	result, err := s.Create(testContext(), query, nil, nil)
+	if err != nil {
+		t.Fatalf("Create() error = %v, want nil", err)
+	}
+
+	if captured.BucketSeconds != 900 {
+		t.Errorf("BucketSeconds = %d, want 900", captured.BucketSeconds)
+	}
+	if want := time.Date(2026, 10, 15, 9, 45, 0, 0, time.UTC); !captured.StartTime.Equal(want) {
+		t.Errorf("StartTime = %v, want aligned %v", captured.StartTime, want)
+	}
+	if captured.GroupBy != "resource" || captured.Filter != query.Spec.Filter {
+		t.Errorf("storage spec = %+v, want groupBy and filter passed through", captured)
+	}
+
+	resp := result.(*v1alpha1.ActivityMetricsQuery)
+	if len(resp.Status.Points) != 2 {
+		t.Fatalf("Status.Points has %d points, want 2", len(resp.Status.Points))
+	}
+	if got := resp.Status.Points[0]; got.Timestamp != "2026-10-15T10:00:00Z" || got.Series != "Deployment" || got.Count != 12 {
+		t.Errorf("Points[0] = %+v", got)
+	}
+	if resp.Status.EffectiveStartTime != "2026-10-15T09:45:00Z" {
+		t.Errorf("EffectiveStartTime = %q, want 2026-10-15T09:45:00Z", resp.Status.EffectiveStartTime)
+	}
+	if resp.Status.BucketSeconds != 900 {
+		t.Errorf("Status.BucketSeconds = %d, want 900", resp.Status.BucketSeconds)
+	}
+}
+
+func TestQueryStorage_Create_StorageClock(t *testing.T) {
+	var captured storage.ActivityMetricsQuerySpec
+	mock := &mockMetricsStorage{
+		queryFunc: func(ctx context.Context, spec storage.ActivityMetricsQuerySpec, scope storage.ScopeContext) (*storage.ActivityMetricsResult, error) {
+			captured = spec
+			return &storage.ActivityMetricsResult{}, nil
+		},
+		now: func() time.Time { return time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC) },
+	}
+	s := NewQueryStorage(mock)
+
+	query := &v1alpha1.ActivityMetricsQuery{
+		Spec: v1alpha1.ActivityMetricsQuerySpec{StartTime: "now-1h", EndTime: "now", BucketSize: "15m"},
+	}
+	if _, err := s.Create(testContext(), query, nil, nil); err != nil {
+		t.Fatalf("Create() error = %v, want nil", err)
+	}
+
+	// Relative times resolve against the storage's clock
+	if want := time.Date(2026, 10, 16, 11, 0, 0, 0, time.UTC); !captured.StartTime.Equal(want) {
+		t.Errorf("StartTime = %v, want %v", captured.StartTime, want)
+	}
+	if want := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC); !captured.EndTime.Equal(want) {
+		t.Errorf("EndTime = %v, want %v", captured.EndTime, want)
+	}
+}
+
+func TestQueryStorage_Create_Validation(t *testing.T) {
+	s := NewQueryStorage(&mockMetricsStorage{})
+
+	tests := []struct {
+		name     string
+		spec     v1alpha1.ActivityMetricsQuerySpec
+		wantText string
+	}{
+		{
+			name:     "missing times",
+			spec:     v1alpha1.ActivityMetricsQuerySpec{},
+			wantText: "missing or invalid",
+		},
+		{
+			name:     "verb is not an activity dimension",
+			spec:     v1alpha1.ActivityMetricsQuerySpec{StartTime: "now-1d", EndTime: "now", GroupBy: "verb"},
+			wantText: "AuditLogFacetsQuery",
+		},
+		{
+			name:     "unknown dimension",
+			spec:     v1alpha1.ActivityMetricsQuerySpec{StartTime: "now-1d", EndTime: "now", GroupBy: "namespace"},
+			wantText: "Supported values",
+		},
+		{
+			name:     "too many buckets",
+			spec:     v1alpha1.ActivityMetricsQuerySpec{StartTime: "now-7d", EndTime: "now", BucketSize: "1m"},
+			wantText: "buckets",
+		},
+		{
+			name:     "invalid filter",
+			spec:     v1alpha1.ActivityMetricsQuerySpec{StartTime: "now-1d", EndTime: "now", Filter: "spec.bogus == 'x'"},
+			wantText: "spec.bogus",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := &v1alpha1.ActivityMetricsQuery{Spec: tt.spec}
+			_, err := s.Create(testContext(), query, nil, nil)
+			if err == nil {
+				t.Fatal("Create() error = nil, want validation error")
+			}
+			if !apierrors.IsInvalid(err) {
+				t.Errorf("Create() error = %v, want Invalid", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantText) {
+				t.Errorf("Create() error = %q, want it to mention %q", err.Error(), tt.wantText)
+			}
+		})
+	}
+}
+
+func TestQueryStorage_Create_TooManyPoints(t *testing.T) {
+	mock := &mockMetricsStorage{
+		queryFunc: func(ctx context.Context, spec storage.ActivityMetricsQuerySpec, scope storage.ScopeContext) (*storage.ActivityMetricsResult, error) {
+			return nil, storage.ErrTooManyMetricsPoints
+		},
+	}
+	s := NewQueryStorage(mock)
+
+	query := &v1alpha1.ActivityMetricsQuery{
+		Spec: v1alpha1.ActivityMetricsQuerySpec{StartTime: "now-1d", EndTime: "now", GroupBy: "actor"},
+	}
+	_, err := s.Create(testContext(), query, nil, nil)
+	if !apierrors.IsBadRequest(err) {
+		t.Fatalf("Create() error = %v, want BadRequest", err)
+	}
+}
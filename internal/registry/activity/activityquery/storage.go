@@ -2,8 +2,8 @@ package activityquery
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"slices"
 	"time"
 
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -12,12 +12,14 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/apiserver/pkg/endpoints/request"
 	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/apiserver/pkg/warning"
 	"k8s.io/klog/v2"
 
 	"go.miloapis.com/activity/internal/cel"
 	"go.miloapis.com/activity/internal/registry/scope"
 	"go.miloapis.com/activity/internal/storage"
 	"go.miloapis.com/activity/internal/timeutil"
+	"go.miloapis.com/activity/internal/types"
 	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
 )
 
@@ -26,6 +28,10 @@ type StorageInterface interface {
 	QueryActivities(ctx context.Context, spec storage.ActivityQuerySpec, scope storage.ScopeContext) (*storage.ActivityQueryResult, error)
 	GetMaxQueryWindow() time.Duration
 	GetMaxPageSize() int32
+	GetMaxQueryTimeout() time.Duration
+	GetTenantMaxExecutionTime(scope storage.ScopeContext) time.Duration
+	GetActivityRetentionWindow() time.Duration
+	Now() time.Time
 }
 
 // QueryStorage implements REST storage for ActivityQuery.
@@ -92,7 +98,7 @@ func (s *QueryStorage) Create(ctx context.Context, obj runtime.Object, createVal
 	)
 
 	// Validate query spec
-	if errs := s.validateQuerySpec(query); len(errs) > 0 {
+	if errs := s.validateQuerySpec(query, scopeCtx); len(errs) > 0 {
 		return nil, errors.NewInvalid(
 			v1alpha1.SchemeGroupVersion.WithKind("ActivityQuery").GroupKind(),
 			query.Name,
@@ -101,7 +107,7 @@ func (s *QueryStorage) Create(ctx context.Context, obj runtime.Object, createVal
 	}
 
 	// Parse effective timestamps
-	now := time.Now()
+	now := s.storage.Now()
 	effectiveStartTime, err := timeutil.ParseFlexibleTime(query.Spec.StartTime, now)
 	if err != nil {
 		return nil, errors.NewInternalError(fmt.Errorf("failed to parse startTime: %w", err))
@@ -111,6 +117,18 @@ func (s *QueryStorage) Create(ctx context.Context, obj runtime.Object, createVal
 		return nil, errors.NewInternalError(fmt.Errorf("failed to parse endTime: %w", err))
 	}
 
+	replay, err := replaySpec(query.Spec, effectiveStartTime, effectiveEndTime)
+	if err != nil {
+		return nil, errors.NewInternalError(err)
+	}
+
+	// Report where results actually start when startTime predates the
+	// retention window; the spec is left as-is so pagination cursors stay valid.
+	effectiveStartTime, retentionClamped := storage.ClampToRetention(effectiveStartTime, now, s.storage.GetActivityRetentionWindow())
+	if retentionClamped {
+		warning.AddWarning(ctx, "", storage.RetentionWarning(s.storage.GetActivityRetentionWindow(), effectiveStartTime))
+	}
+
 	// Build storage query spec from API spec
 	storageSpec := storage.ActivityQuerySpec{
 		StartTime: query.Spec.StartTime,
@@ -118,40 +136,71 @@ func (s *QueryStorage) Create(ctx context.Context, obj runtime.Object, createVal
 		Filter:    query.Spec.Filter,
 		Search:    query.Spec.Search,
 		Limit:     query.Spec.Limit,
+		OrderBy:   query.Spec.OrderBy,
 		Continue:  query.Spec.Continue,
+
+		IncludeTotal: query.Spec.IncludeTotal,
+	}
+	if query.Spec.Tenant != nil {
+		storageSpec.TenantType = query.Spec.Tenant.Type
+		storageSpec.TenantName = query.Spec.Tenant.Name
 	}
 
-	result, err := s.storage.QueryActivities(ctx, storageSpec, scopeCtx)
+	queryCtx, cancel := storage.TenantQueryContext(ctx, query.Spec.TimeoutSeconds, s.storage.GetTenantMaxExecutionTime(scopeCtx))
+	defer cancel()
+
+	result, err := s.storage.QueryActivities(queryCtx, storageSpec, scopeCtx)
 	if err != nil {
+		if storage.IsQueryQueueTimeout(err) {
+			return nil, errors.NewServiceUnavailable(storage.QueryQueueTimeoutMessage)
+		}
+		if storage.IsQueryTimeout(queryCtx) {
+			return nil, errors.NewTimeoutError(storage.QueryTimeoutMessage, 0)
+		}
 		klog.ErrorS(err, "Failed to query activities")
 		return nil, errors.NewServiceUnavailable("Failed to execute query. Try again or contact support if the problem persists.")
 	}
 
-	// Convert JSON results to Activity objects
-	activities := make([]v1alpha1.Activity, 0, len(result.Activities))
-	for _, activityJSON := range result.Activities {
-		var activity v1alpha1.Activity
-		if err := json.Unmarshal([]byte(activityJSON), &activity); err != nil {
-			klog.ErrorS(err, "Corrupt activity record in storage")
-			return nil, errors.NewInternalError(fmt.Errorf("unable to load activity records. Please contact support if the problem persists"))
-		}
-		activities = append(activities, activity)
+	activities := result.Activities
+	if activities == nil {
+		activities = []v1alpha1.Activity{}
 	}
 
 	query.Status.Results = activities
 	query.Status.Continue = result.Continue
 	query.Status.EffectiveStartTime = effectiveStartTime.Format(time.RFC3339)
 	query.Status.EffectiveEndTime = effectiveEndTime.Format(time.RFC3339)
+	query.Status.RetentionClamped = retentionClamped
+	query.Status.Total = result.Total
+	query.Status.TotalCapped = result.TotalCapped
+	query.Status.ReplaySpec = replay
 
 	return query, nil
 }
 
+// replaySpec returns spec with its times resolved to start and end and its
+// filter normalized, for status.replaySpec. Times keep sub-second precision so
+// a replay covers exactly the same window.
+func replaySpec(spec v1alpha1.ActivityQuerySpec, start, end time.Time) (*v1alpha1.ActivityQuerySpec, error) {
+	replay := spec.DeepCopy()
+	replay.StartTime = start.UTC().Format(time.RFC3339Nano)
+	replay.EndTime = end.UTC().Format(time.RFC3339Nano)
+	replay.Continue = ""
+
+	filter, err := cel.NormalizeActivityFilter(spec.Filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to normalize filter: %w", err)
+	}
+	replay.Filter = filter
+	return replay, nil
+}
+
 // validateQuerySpec validates the query specification and returns field errors.
-func (s *QueryStorage) validateQuerySpec(query *v1alpha1.ActivityQuery) field.ErrorList {
+func (s *QueryStorage) validateQuerySpec(query *v1alpha1.ActivityQuery, scopeCtx storage.ScopeContext) field.ErrorList {
 	allErrs := field.ErrorList{}
 	specPath := field.NewPath("spec")
 
-	now := time.Now()
+	now := s.storage.Now()
 
 	// Validate startTime
 	if query.Spec.StartTime == "" {
@@ -189,6 +238,11 @@ func (s *QueryStorage) validateQuerySpec(query *v1alpha1.ActivityQuery) field.Er
 				allErrs = append(allErrs, field.Invalid(specPath, fmt.Sprintf("%s to %s", query.Spec.StartTime, query.Spec.EndTime),
 					fmt.Sprintf("time range of %v exceeds maximum of %v", queryWindow, maxWindow)))
 			}
+
+			if !storage.IsTimestampOrder(query.Spec.OrderBy) && queryWindow > storage.MaxOrderedQueryWindow {
+				allErrs = append(allErrs, field.Invalid(specPath.Child("orderBy"), query.Spec.OrderBy,
+					fmt.Sprintf("ordering by %s requires a time range of at most %v, got %v", query.Spec.OrderBy, storage.MaxOrderedQueryWindow, queryWindow)))
+			}
 		}
 	}
 
@@ -203,6 +257,18 @@ func (s *QueryStorage) validateQuerySpec(query *v1alpha1.ActivityQuery) field.Er
 			fmt.Sprintf("limit of %d exceeds maximum of %d", query.Spec.Limit, maxPageSize)))
 	}
 
+	// Validate timeout override
+	if query.Spec.TimeoutSeconds != nil {
+		if err := storage.ValidateQueryTimeout(*query.Spec.TimeoutSeconds, s.storage.GetMaxQueryTimeout()); err != nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("timeoutSeconds"), *query.Spec.TimeoutSeconds, err.Error()))
+		}
+	}
+
+	// Validate orderBy
+	if !storage.IsTimestampOrder(query.Spec.OrderBy) && !slices.Contains(storage.SupportedActivityOrders(), query.Spec.OrderBy) {
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("orderBy"), query.Spec.OrderBy, storage.SupportedActivityOrders()))
+	}
+
 	// Validate CEL filter
 	if query.Spec.Filter != "" {
 		_, err := cel.CompileActivityFilter(query.Spec.Filter)
@@ -211,6 +277,23 @@ func (s *QueryStorage) validateQuerySpec(query *v1alpha1.ActivityQuery) field.Er
 		}
 	}
 
+	// Validate tenant filter
+	if tenant := query.Spec.Tenant; tenant != nil {
+		tenantPath := specPath.Child("tenant")
+		if scopeCtx.Type != types.TenantTypePlatform {
+			allErrs = append(allErrs, field.Forbidden(tenantPath,
+				fmt.Sprintf("only platform-scoped callers can filter by tenant; your queries are already limited to %s %q", scopeCtx.Type, scopeCtx.Name)))
+		} else {
+			if tenant.Type != types.TenantTypeOrganization && tenant.Type != types.TenantTypeProject {
+				allErrs = append(allErrs, field.NotSupported(tenantPath.Child("type"), tenant.Type,
+					[]string{types.TenantTypeOrganization, types.TenantTypeProject}))
+			}
+			if tenant.Name == "" {
+				allErrs = append(allErrs, field.Required(tenantPath.Child("name"), "must specify the tenant name"))
+			}
+		}
+	}
+
 	return allErrs
 }
 
@@ -0,0 +1,251 @@
+package auditlog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/apiserver/pkg/warning"
+	"k8s.io/klog/v2"
+
+	"go.miloapis.com/activity/internal/cel"
+	"go.miloapis.com/activity/internal/registry/scope"
+	"go.miloapis.com/activity/internal/storage"
+	"go.miloapis.com/activity/internal/timeutil"
+	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+)
+
+// ExportSubresource is the auditlogqueries subresource that streams a query's
+// full result set.
+const ExportSubresource = "export"
+
+// exportContentType is the media type of an export: one JSON audit event per
+// line.
+const exportContentType = "application/x-ndjson"
+
+// exportFlushInterval is how many events are written between flushes, so
+// clients see progress without a flush per line.
+const exportFlushInterval = 100
+
+// ExportStorage implements the auditlogqueries/export subresource. A GET on
+// auditlogqueries/{name}/export with startTime, endTime, relativeTo, filter and
+// mutationsOnly query parameters streams every matching event as NDJSON in a chunked response,
+// read straight from ClickHouse, instead of returning one page. The name only
+// labels the export in logs and the apiserver audit log; like AuditLogQuery
+// itself, nothing is persisted.
+//
+// A groupBy parameter, a comma-separated list of AuditLogGroupByFields such
+// as "user,day", streams one AuditLogGroup per line instead: the count of
+// matching events for each combination of the fields, aggregated in
+// ClickHouse.
+//
+// Exports apply the same validation, maximum query window, scope and
+// redaction as AuditLogQuery.
+type ExportStorage struct {
+	queries *QueryStorage
+}
+
+// NewExportStorage returns the export subresource for the AuditLogQuery
+// storage, sharing its storage backend and redaction.
+func NewExportStorage(queries *QueryStorage) *ExportStorage {
+	return &ExportStorage{queries: queries}
+}
+
+var (
+	_ rest.Storage   = &ExportStorage{}
+	_ rest.Scoper    = &ExportStorage{}
+	_ rest.Connecter = &ExportStorage{}
+)
+
+// New returns an empty AuditLogQuery.
+func (r *ExportStorage) New() runtime.Object {
+	return &v1alpha1.AuditLogQuery{}
+}
+
+// Destroy cleans up resources.
+func (r *ExportStorage) Destroy() {}
+
+// NamespaceScoped returns false
+func (r *ExportStorage) NamespaceScoped() bool {
+	return false
+}
+
+// NewConnectOptions returns no options type; the query parameters are read
+// from the request itself.
+func (r *ExportStorage) NewConnectOptions() (runtime.Object, bool, string) {
+	return nil, false, ""
+}
+
+// ConnectMethods returns the HTTP methods the export accepts.
+func (r *ExportStorage) ConnectMethods() []string {
+	return []string{http.MethodGet}
+}
+
+// Connect returns the handler that streams the export named id.
+func (r *ExportStorage) Connect(ctx context.Context, id string, options runtime.Object, responder rest.Responder) (http.Handler, error) {
+	reqUser, ok := request.UserFrom(ctx)
+	if !ok {
+		return nil, errors.NewInternalError(fmt.Errorf("no user in context"))
+	}
+	scopeCtx := scope.ExtractScopeFromUser(reqUser)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		params := req.URL.Query()
+		query := &v1alpha1.AuditLogQuery{
+			ObjectMeta: metav1.ObjectMeta{Name: id},
+			Spec: v1alpha1.AuditLogQuerySpec{
+				StartTime:     params.Get("startTime"),
+				EndTime:       params.Get("endTime"),
+				RelativeTo:    params.Get("relativeTo"),
+				Filter:        params.Get("filter"),
+				MutationsOnly: params.Get("mutationsOnly") == "true",
+			},
+		}
+		var groupBy []string
+		if param := params.Get("groupBy"); param != "" {
+			groupBy = strings.Split(param, ",")
+		}
+		r.export(req.Context(), w, responder, query, groupBy, scopeCtx)
+	}), nil
+}
+
+// export validates query and streams its results to w, or its groups when
+// groupBy is set. Errors found before the first line is written are returned
+// through responder as a normal status response. Once streaming has begun the
+// status code is already sent, so a later failure is reported as a final
+// metav1.Status line.
+func (r *ExportStorage) export(ctx context.Context, w http.ResponseWriter, responder rest.Responder, query *v1alpha1.AuditLogQuery, groupBy []string, scopeCtx storage.ScopeContext) {
+	q := r.queries
+	errs := q.validateQuerySpec(query, scopeCtx)
+	if groupBy != nil {
+		if err := storage.ValidateAuditLogGroupBy(groupBy); err != nil {
+			errs = append(errs, field.Invalid(field.NewPath("groupBy"), strings.Join(groupBy, ","), err.Error()))
+		}
+	}
+	if len(errs) > 0 {
+		responder.Error(errors.NewInvalid(v1alpha1.SchemeGroupVersion.WithKind("AuditLogQuery").GroupKind(), query.Name, errs))
+		return
+	}
+	if cel.UsesJSONExtract(query.Spec.Filter) {
+		warning.AddWarning(ctx, "", cel.JSONExtractWarning)
+	}
+
+	now := q.storage.Now()
+	reference, err := referenceTime(query.Spec, now)
+	if err != nil {
+		responder.Error(errors.NewInternalError(err))
+		return
+	}
+	if start, err := timeutil.ParseFlexibleTime(query.Spec.StartTime, reference); err == nil {
+		if clamped, ok := storage.ClampToRetention(start, now, q.storage.GetAuditLogRetentionWindow()); ok {
+			warning.AddWarning(ctx, "", storage.RetentionWarning(q.storage.GetAuditLogRetentionWindow(), clamped))
+		}
+	}
+
+	klog.InfoS("Executing scope-aware audit log export",
+		"export", query.Name,
+		"scopeType", scopeCtx.Type,
+		"scopeName", scopeCtx.Name,
+		"startTime", query.Spec.StartTime,
+		"endTime", query.Spec.EndTime,
+		"groupBy", groupBy,
+	)
+
+	// An export reads far more rows than a page, so it gets the longest
+	// timeout a query may ask for.
+	timeoutSeconds := int32(q.storage.GetMaxQueryTimeout() / time.Second)
+	limitedTimeout, _ := storage.ClampQueryTimeout(&timeoutSeconds, q.storage.GetTenantMaxExecutionTime(scopeCtx))
+	queryCtx, cancel := storage.WithQueryTimeout(ctx, limitedTimeout)
+	defer cancel()
+
+	redact := q.redactor.appliesTo(scopeCtx)
+	controller := http.NewResponseController(w)
+	encoder := json.NewEncoder(w)
+	flushed := 0
+	started := false
+	start := func() {
+		w.Header().Set("Content-Type", exportContentType)
+		w.WriteHeader(http.StatusOK)
+		started = true
+	}
+
+	write := func(line any) error {
+		if !started {
+			start()
+		}
+		if err := encoder.Encode(line); err != nil {
+			return err
+		}
+		flushed++
+		if flushed%exportFlushInterval == 0 {
+			// Writers that cannot flush still deliver the lines at the end
+			_ = controller.Flush()
+		}
+		return nil
+	}
+
+	var written int
+	if groupBy != nil {
+		// Groups carry no object bodies, so there is nothing to redact
+		written, err = q.storage.AggregateAuditLogs(queryCtx, query.Spec, scopeCtx, groupBy, func(group *v1alpha1.AuditLogGroup) error {
+			return write(group)
+		})
+	} else {
+		written, err = q.storage.StreamAuditLogs(queryCtx, query.Spec, scopeCtx, func(event *auditv1.Event) error {
+			if redact {
+				events := []auditv1.Event{*event}
+				q.redactor.redactEvents(events, nil)
+				event = &events[0]
+			}
+			return write(event)
+		})
+	}
+
+	if err != nil {
+		if ctx.Err() != nil {
+			// The client went away, which cancelled the ClickHouse query
+			klog.V(2).InfoS("Audit log export cancelled by client", "export", query.Name, "linesWritten", written)
+			return
+		}
+		status := q.exportError(queryCtx, query, err)
+		if !started {
+			responder.Error(status)
+			return
+		}
+		final := status.Status()
+		final.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "Status"}
+		_ = encoder.Encode(&final)
+		_ = controller.Flush()
+		return
+	}
+
+	if !started {
+		start()
+	}
+	_ = controller.Flush()
+}
+
+// exportError converts a failed export into the status error reported to the
+// client.
+func (r *QueryStorage) exportError(queryCtx context.Context, query *v1alpha1.AuditLogQuery, err error) *errors.StatusError {
+	if storage.IsQueryTimeout(queryCtx) {
+		return errors.NewTimeoutError(storage.QueryTimeoutMessage, 0)
+	}
+	if storage.IsFacetTooManyValues(err) {
+		return errors.NewBadRequest(err.Error())
+	}
+	if status, ok := r.convertToStructuredError(query, traceIDFromContext(queryCtx), err).(*errors.StatusError); ok {
+		return status
+	}
+	return errors.NewInternalError(err)
+}
@@ -0,0 +1,251 @@
+package auditlog
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
+
+	"go.miloapis.com/activity/internal/registry/scope"
+	"go.miloapis.com/activity/internal/storage"
+	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+)
+
+// fakeResponder records the error an export reports before streaming.
+type fakeResponder struct {
+	err error
+}
+
+func (f *fakeResponder) Object(statusCode int, obj runtime.Object) {}
+
+func (f *fakeResponder) Error(err error) {
+	f.err = err
+}
+
+// runExport serves one export request with the given query parameters.
+func runExport(t *testing.T, mockStorage *mockStorageInterface, params url.Values) (*httptest.ResponseRecorder, *fakeResponder) {
+	t.Helper()
+
+	testUser := &user.DefaultInfo{
+		Name: "test-user",
+		Extra: map[string][]string{
+			scope.ParentKindExtraKey: {"Organization"},
+			scope.ParentNameExtraKey: {"test-org"},
+		},
+	}
+	ctx := request.WithUser(context.Background(), testUser)
+
+	es := NewExportStorage(&QueryStorage{storage: mockStorage})
+	responder := &fakeResponder{}
+	handler, err := es.Connect(ctx, "my-export", nil, responder)
+	if err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/export?"+params.Encode(), nil).WithContext(ctx)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec, responder
+}
+
+func exportParams() url.Values {
+	now := time.Now()
+	return url.Values{
+		"startTime":     {now.Add(-24 * time.Hour).Format(time.RFC3339)},
+		"endTime":       {now.Format(time.RFC3339)},
+		"filter":        {"verb == 'delete'"},
+		"mutationsOnly": {"true"},
+	}
+}
+
+func TestExportStorage_StreamsNDJSON(t *testing.T) {
+	var gotSpec v1alpha1.AuditLogQuerySpec
+	var gotScope storage.ScopeContext
+	mockStorage := &mockStorageInterface{
+		maxQueryWindow: 7 * 24 * time.Hour,
+		maxPageSize:    1000,
+		streamFunc: func(ctx context.Context, spec v1alpha1.AuditLogQuerySpec, scope storage.ScopeContext, emit func(event *auditv1.Event) error) (int, error) {
+			gotSpec, gotScope = spec, scope
+			for i := 0; i < 3; i++ {
+				if err := emit(&auditv1.Event{AuditID: types.UID(fmt.Sprintf("audit-%c", 'a'+i)), Verb: "delete"}); err != nil {
+					return i, err
+				}
+			}
+			return 3, nil
+		},
+	}
+
+	rec, responder := runExport(t, mockStorage, exportParams())
+	if responder.err != nil {
+		t.Fatalf("export reported error: %v", responder.err)
+	}
+	if got := rec.Header().Get("Content-Type"); got != exportContentType {
+		t.Errorf("Content-Type = %q, want %q", got, exportContentType)
+	}
+	if gotSpec.Filter != "verb == 'delete'" {
+		t.Errorf("spec.Filter = %q, want the filter parameter", gotSpec.Filter)
+	}
+	if !gotSpec.MutationsOnly {
+		t.Error("spec.MutationsOnly = false, want the mutationsOnly parameter")
+	}
+	if gotScope.Type != "Organization" || gotScope.Name != "test-org" {
+		t.Errorf("scope = %+v, want the caller's organization", gotScope)
+	}
+
+	lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want 3: %q", len(lines), rec.Body.String())
+	}
+	if !strings.Contains(lines[0], `"auditID":"audit-a"`) {
+		t.Errorf("first line = %s, want audit-a", lines[0])
+	}
+}
+
+func TestExportStorage_ValidationError(t *testing.T) {
+	called := false
+	mockStorage := &mockStorageInterface{
+		maxQueryWindow: 24 * time.Hour,
+		maxPageSize:    1000,
+		streamFunc: func(ctx context.Context, spec v1alpha1.AuditLogQuerySpec, scope storage.ScopeContext, emit func(event *auditv1.Event) error) (int, error) {
+			called = true
+			return 0, nil
+		},
+	}
+
+	params := exportParams()
+	params.Set("startTime", time.Now().Add(-72*time.Hour).Format(time.RFC3339))
+	_, responder := runExport(t, mockStorage, params)
+
+	if !apierrors.IsInvalid(responder.err) {
+		t.Errorf("error = %v, want Invalid for a window over the maximum", responder.err)
+	}
+	if called {
+		t.Error("storage was queried for an invalid export")
+	}
+}
+
+func TestExportStorage_GroupBy(t *testing.T) {
+	var gotGroupBy []string
+	mockStorage := &mockStorageInterface{
+		maxQueryWindow: 7 * 24 * time.Hour,
+		maxPageSize:    1000,
+		streamFunc: func(ctx context.Context, spec v1alpha1.AuditLogQuerySpec, scope storage.ScopeContext, emit func(event *auditv1.Event) error) (int, error) {
+			t.Error("events were streamed for a grouped export")
+			return 0, nil
+		},
+		aggregateFunc: func(ctx context.Context, spec v1alpha1.AuditLogQuerySpec, scope storage.ScopeContext, groupBy []string, emit func(group *v1alpha1.AuditLogGroup) error) (int, error) {
+			gotGroupBy = groupBy
+			groups := []v1alpha1.AuditLogGroup{
+				{Values: map[string]string{"user": "alice", "day": "2026-10-15"}, Count: 4},
+				{Values: map[string]string{"user": "bob", "day": "2026-10-15"}, Count: 1},
+			}
+			for i := range groups {
+				if err := emit(&groups[i]); err != nil {
+					return i, err
+				}
+			}
+			return len(groups), nil
+		},
+	}
+
+	params := exportParams()
+	params.Set("groupBy", "user,day")
+	rec, responder := runExport(t, mockStorage, params)
+	if responder.err != nil {
+		t.Fatalf("export reported error: %v", responder.err)
+	}
+	if strings.Join(gotGroupBy, ",") != "user,day" {
+		t.Errorf("groupBy = %v, want [user day]", gotGroupBy)
+	}
+
+	lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2: %q", len(lines), rec.Body.String())
+	}
+	if want := `{"values":{"day":"2026-10-15","user":"alice"},"count":4}`; lines[0] != want {
+		t.Errorf("first line = %s, want %s", lines[0], want)
+	}
+}
+
+func TestExportStorage_InvalidGroupBy(t *testing.T) {
+	called := false
+	mockStorage := &mockStorageInterface{
+		maxQueryWindow: 7 * 24 * time.Hour,
+		maxPageSize:    1000,
+		aggregateFunc: func(ctx context.Context, spec v1alpha1.AuditLogQuerySpec, scope storage.ScopeContext, groupBy []string, emit func(group *v1alpha1.AuditLogGroup) error) (int, error) {
+			called = true
+			return 0, nil
+		},
+	}
+
+	params := exportParams()
+	params.Set("groupBy", "user,sourceIP")
+	_, responder := runExport(t, mockStorage, params)
+
+	if !apierrors.IsInvalid(responder.err) {
+		t.Errorf("error = %v, want Invalid for an unknown groupBy field", responder.err)
+	}
+	if called {
+		t.Error("storage was queried for an invalid export")
+	}
+}
+
+func TestExportStorage_ErrorAfterStreaming(t *testing.T) {
+	mockStorage := &mockStorageInterface{
+		maxQueryWindow: 7 * 24 * time.Hour,
+		maxPageSize:    1000,
+		streamFunc: func(ctx context.Context, spec v1alpha1.AuditLogQuerySpec, scope storage.ScopeContext, emit func(event *auditv1.Event) error) (int, error) {
+			if err := emit(&auditv1.Event{AuditID: "audit-a"}); err != nil {
+				return 0, err
+			}
+			return 1, fmt.Errorf("connection reset")
+		},
+	}
+
+	rec, responder := runExport(t, mockStorage, exportParams())
+	if responder.err != nil {
+		t.Fatalf("error after streaming began went to the responder: %v", responder.err)
+	}
+
+	scanner := bufio.NewScanner(rec.Body)
+	var lines []string
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want the event and a final status: %q", len(lines), lines)
+	}
+	if !strings.Contains(lines[1], `"kind":"Status"`) || !strings.Contains(lines[1], `"code":503`) {
+		t.Errorf("final line = %s, want a 503 Status", lines[1])
+	}
+}
+
+func TestExportStorage_ErrorBeforeStreaming(t *testing.T) {
+	mockStorage := &mockStorageInterface{
+		maxQueryWindow: 7 * 24 * time.Hour,
+		maxPageSize:    1000,
+		streamFunc: func(ctx context.Context, spec v1alpha1.AuditLogQuerySpec, scope storage.ScopeContext, emit func(event *auditv1.Event) error) (int, error) {
+			return 0, fmt.Errorf("connection refused")
+		},
+	}
+
+	rec, responder := runExport(t, mockStorage, exportParams())
+	if !apierrors.IsServiceUnavailable(responder.err) {
+		t.Errorf("error = %v, want ServiceUnavailable", responder.err)
+	}
+	if rec.Body.Len() != 0 {
+		t.Errorf("body = %q, want nothing written", rec.Body.String())
+	}
+}
@@ -0,0 +1,193 @@
+package auditlog
+
+import (
+	"encoding/json"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+	"k8s.io/klog/v2"
+
+	"go.miloapis.com/activity/internal/storage"
+	"go.miloapis.com/activity/internal/types"
+	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+)
+
+// lastAppliedAnnotation holds a full copy of the object as last applied by
+// kubectl, including any secret data, so it is dropped from redacted bodies.
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// RedactionConfig controls which audit event bodies are redacted before query
+// results are returned.
+type RedactionConfig struct {
+	// Resources whose request and response objects are redacted, in
+	// resource.group form (e.g. "secrets", "configmaps", "widgets.example.com").
+	Resources []string
+
+	// ExemptScopes lists the scope types that receive unredacted bodies
+	// (e.g. "platform"). Matching is case-insensitive.
+	ExemptScopes []string
+
+	// FieldRules null out individual fields of the request and response
+	// objects of matching events, for any resource type.
+	FieldRules []FieldRedactionRule
+}
+
+// DefaultRedactionConfig redacts Secret bodies for every caller except
+// platform administrators.
+func DefaultRedactionConfig() RedactionConfig {
+	return RedactionConfig{
+		Resources:    []string{"secrets"},
+		ExemptScopes: []string{types.TenantTypePlatform},
+	}
+}
+
+// redactor strips object bodies from audit events for sensitive resources and
+// sensitive fields from the bodies of any resource.
+type redactor struct {
+	resources    map[schema.GroupResource]bool
+	fieldRules   []compiledFieldRule
+	exemptScopes []string
+}
+
+// newRedactor builds a redactor from config. A nil redactor redacts nothing.
+func newRedactor(config RedactionConfig) (*redactor, error) {
+	if len(config.Resources) == 0 && len(config.FieldRules) == 0 {
+		return nil, nil
+	}
+
+	fieldRules, err := compileFieldRules(config.FieldRules)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &redactor{
+		resources:    make(map[schema.GroupResource]bool, len(config.Resources)),
+		fieldRules:   fieldRules,
+		exemptScopes: config.ExemptScopes,
+	}
+	for _, resource := range config.Resources {
+		r.resources[schema.ParseGroupResource(strings.TrimSpace(resource))] = true
+	}
+	return r, nil
+}
+
+// AppliesTo reports whether query results for the given scope are redacted
+// under this config. Query types that don't return event bodies use it to
+// refuse filters that could probe redacted fields.
+func (c RedactionConfig) AppliesTo(scopeCtx storage.ScopeContext) bool {
+	if len(c.Resources) == 0 && len(c.FieldRules) == 0 {
+		return false
+	}
+	return !scopeExempt(c.ExemptScopes, scopeCtx)
+}
+
+// appliesTo reports whether results for the given scope must be redacted.
+func (r *redactor) appliesTo(scopeCtx storage.ScopeContext) bool {
+	if r == nil {
+		return false
+	}
+	return !scopeExempt(r.exemptScopes, scopeCtx)
+}
+
+// scopeExempt reports whether scopeCtx is one of the exempt scope types.
+func scopeExempt(exemptScopes []string, scopeCtx storage.ScopeContext) bool {
+	for _, exempt := range exemptScopes {
+		if strings.EqualFold(exempt, scopeCtx.Type) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactEvents redacts the request and response objects of every event that
+// touches a configured resource, and the fields named by matching field rules
+// from the rest. Event metadata (who, what, when) is kept so the audit trail
+// stays useful. When raw holds the stored JSON of each event, the entries for
+// redacted events are replaced with the redacted event so the bodies can't
+// leak through it.
+func (r *redactor) redactEvents(events []auditv1.Event, raw []string) {
+	for i := range events {
+		event := &events[i]
+		if r.redactsResource(event) {
+			event.RequestObject = redactObject(event.RequestObject)
+			event.ResponseObject = redactObject(event.ResponseObject)
+		} else if !r.redactEventFields(event) {
+			continue
+		}
+
+		if event.Annotations == nil {
+			event.Annotations = make(map[string]string, 1)
+		}
+		event.Annotations[v1alpha1.AuditEventRedactedAnnotation] = "true"
+
+		if i < len(raw) {
+			redacted, err := json.Marshal(event)
+			if err != nil {
+				klog.V(4).InfoS("Dropping raw audit event that failed to encode after redaction", "error", err)
+				redacted = nil
+			}
+			raw[i] = string(redacted)
+		}
+	}
+}
+
+// redactsResource reports whether the event touches a resource whose bodies
+// are redacted as a whole.
+func (r *redactor) redactsResource(event *auditv1.Event) bool {
+	if event.ObjectRef == nil {
+		return false
+	}
+	gr := schema.GroupResource{Group: event.ObjectRef.APIGroup, Resource: event.ObjectRef.Resource}
+	return r.resources[gr]
+}
+
+// redactObject keeps only the apiVersion, kind and metadata of an object, and
+// of each item when the object is a list. Bodies that can't be parsed are
+// dropped entirely rather than returned as-is.
+func redactObject(obj *runtime.Unknown) *runtime.Unknown {
+	if obj == nil || len(obj.Raw) == 0 {
+		return obj
+	}
+
+	var body map[string]any
+	if err := json.Unmarshal(obj.Raw, &body); err != nil {
+		klog.V(4).InfoS("Dropping unparseable audit object body during redaction", "error", err)
+		return nil
+	}
+
+	redacted := redactFields(body)
+	if items, ok := body["items"].([]any); ok {
+		redactedItems := make([]any, 0, len(items))
+		for _, item := range items {
+			if itemMap, ok := item.(map[string]any); ok {
+				redactedItems = append(redactedItems, redactFields(itemMap))
+			}
+		}
+		redacted["items"] = redactedItems
+	}
+
+	raw, err := json.Marshal(redacted)
+	if err != nil {
+		return nil
+	}
+	return &runtime.Unknown{Raw: raw, ContentType: runtime.ContentTypeJSON}
+}
+
+// redactFields returns the apiVersion, kind and metadata of a single object.
+func redactFields(body map[string]any) map[string]any {
+	redacted := make(map[string]any, 3)
+	for _, key := range []string{"apiVersion", "kind"} {
+		if v, ok := body[key]; ok {
+			redacted[key] = v
+		}
+	}
+	if metadata, ok := body["metadata"].(map[string]any); ok {
+		if annotations, ok := metadata["annotations"].(map[string]any); ok {
+			delete(annotations, lastAppliedAnnotation)
+		}
+		redacted["metadata"] = metadata
+	}
+	return redacted
+}
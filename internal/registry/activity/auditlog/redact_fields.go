@@ -0,0 +1,221 @@
+package auditlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"k8s.io/apimachinery/pkg/runtime"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+
+	internalcel "go.miloapis.com/activity/internal/cel"
+)
+
+// FieldRedactionRule nulls out fields of the request and response objects of
+// the audit events it matches, whatever their resource type.
+type FieldRedactionRule struct {
+	// Match is a CEL expression over the audit event, using the same variables
+	// as ActivityPolicy audit rules (e.g. "audit.objectRef.resource ==
+	// 'configmaps'"). Empty matches every event.
+	Match string `json:"match,omitempty"`
+
+	// Paths are dot-separated field paths within the object, such as
+	// "spec.data.password". A path that reaches a list applies to every
+	// element of it, and paths also apply to each item of a list response.
+	Paths []string `json:"paths"`
+}
+
+// fieldRedactionRulesFile is the file format read by LoadFieldRedactionRules.
+type fieldRedactionRulesFile struct {
+	Rules []FieldRedactionRule `json:"rules"`
+}
+
+// LoadFieldRedactionRules reads field redaction rules from a YAML file. An
+// empty path returns no rules.
+func LoadFieldRedactionRules(path string) ([]FieldRedactionRule, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read redaction rules %s: %w", path, err)
+	}
+	var file fieldRedactionRulesFile
+	if err := yaml.UnmarshalStrict(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse redaction rules %s: %w", path, err)
+	}
+	return file.Rules, nil
+}
+
+// compiledFieldRule is a FieldRedactionRule ready to evaluate.
+type compiledFieldRule struct {
+	// match is nil when the rule applies to every event.
+	match cel.Program
+	paths [][]string
+}
+
+// compileFieldRules compiles the match expressions and splits the paths of
+// rules.
+func compileFieldRules(rules []FieldRedactionRule) ([]compiledFieldRule, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	env, err := internalcel.NewAuditEnvironment(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create audit environment: %w", err)
+	}
+
+	compiled := make([]compiledFieldRule, 0, len(rules))
+	for i, rule := range rules {
+		if len(rule.Paths) == 0 {
+			return nil, fmt.Errorf("redaction rule %d: at least one path is required", i)
+		}
+		c := compiledFieldRule{paths: make([][]string, 0, len(rule.Paths))}
+		for _, path := range rule.Paths {
+			segments := strings.Split(strings.TrimSpace(path), ".")
+			for _, segment := range segments {
+				if segment == "" {
+					return nil, fmt.Errorf("redaction rule %d: invalid path %q", i, path)
+				}
+			}
+			c.paths = append(c.paths, segments)
+		}
+
+		if rule.Match != "" {
+			ast, issues := env.Compile(rule.Match)
+			if issues != nil && issues.Err() != nil {
+				return nil, fmt.Errorf("redaction rule %d: invalid match expression: %w", i, issues.Err())
+			}
+			if !ast.OutputType().IsExactType(cel.BoolType) {
+				return nil, fmt.Errorf("redaction rule %d: match expression must return a boolean, got %v", i, ast.OutputType())
+			}
+			c.match, err = env.Program(ast)
+			if err != nil {
+				return nil, fmt.Errorf("redaction rule %d: %w", i, err)
+			}
+		}
+		compiled = append(compiled, c)
+	}
+	return compiled, nil
+}
+
+// redactEventFields applies the field rules that match event and reports
+// whether any field was removed.
+func (r *redactor) redactEventFields(event *auditv1.Event) bool {
+	if event.RequestObject == nil && event.ResponseObject == nil {
+		return false
+	}
+
+	var vars map[string]any
+	redacted := false
+	for _, rule := range r.fieldRules {
+		if rule.match != nil {
+			if vars == nil {
+				vars = auditVars(event)
+			}
+			if !rule.matches(vars) {
+				continue
+			}
+		}
+		var changed bool
+		event.RequestObject, changed = redactObjectPaths(event.RequestObject, rule.paths)
+		redacted = redacted || changed
+		event.ResponseObject, changed = redactObjectPaths(event.ResponseObject, rule.paths)
+		redacted = redacted || changed
+	}
+	return redacted
+}
+
+// matches evaluates the rule's match expression. A rule that fails to
+// evaluate is treated as matching, so an unexpected event shape can't leak
+// the fields it protects.
+func (c compiledFieldRule) matches(vars map[string]any) bool {
+	out, _, err := c.match.Eval(vars)
+	if err != nil {
+		klog.V(4).InfoS("Redaction rule failed to evaluate, redacting anyway", "error", err)
+		return true
+	}
+	matched, ok := out.Value().(bool)
+	return !ok || matched
+}
+
+// auditVars builds the CEL variables for event from its JSON form, as the
+// processor does for ActivityPolicy rules.
+func auditVars(event *auditv1.Event) map[string]any {
+	auditMap := map[string]any{}
+	if data, err := json.Marshal(event); err == nil {
+		if err := json.Unmarshal(data, &auditMap); err != nil {
+			klog.V(4).InfoS("Failed to decode audit event for redaction rules", "error", err)
+		}
+	}
+	return internalcel.BuildAuditVars(auditMap)
+}
+
+// redactObjectPaths nulls out paths in obj, and in each item when obj is a
+// list. It reports whether anything was removed. Bodies that can't be parsed
+// are dropped entirely rather than returned as-is.
+func redactObjectPaths(obj *runtime.Unknown, paths [][]string) (*runtime.Unknown, bool) {
+	if obj == nil || len(obj.Raw) == 0 {
+		return obj, false
+	}
+
+	var body map[string]any
+	if err := json.Unmarshal(obj.Raw, &body); err != nil {
+		klog.V(4).InfoS("Dropping unparseable audit object body during redaction", "error", err)
+		return nil, true
+	}
+
+	changed := false
+	for _, path := range paths {
+		if nullPath(body, path) {
+			changed = true
+		}
+		if items, ok := body["items"].([]any); ok && path[0] != "items" {
+			for _, item := range items {
+				if nullPath(item, path) {
+					changed = true
+				}
+			}
+		}
+	}
+	if !changed {
+		return obj, false
+	}
+
+	raw, err := json.Marshal(body)
+	if err != nil {
+		return nil, true
+	}
+	return &runtime.Unknown{Raw: raw, ContentType: runtime.ContentTypeJSON}, true
+}
+
+// nullPath sets the field at path within value to null, descending into every
+// element of the lists along the way. It reports whether a field was found.
+func nullPath(value any, path []string) bool {
+	switch v := value.(type) {
+	case map[string]any:
+		child, ok := v[path[0]]
+		if !ok {
+			return false
+		}
+		if len(path) == 1 {
+			v[path[0]] = nil
+			return true
+		}
+		return nullPath(child, path[1:])
+	case []any:
+		found := false
+		for _, element := range v {
+			if nullPath(element, path) {
+				found = true
+			}
+		}
+		return found
+	default:
+		return false
+	}
+}
@@ -0,0 +1,126 @@
+package auditlog
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+
+	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+)
+
+func TestRedactor_FieldRules(t *testing.T) {
+	r := mustNewRedactor(t, RedactionConfig{FieldRules: []FieldRedactionRule{
+		{
+			Match: "audit.objectRef.resource == 'databases'",
+			Paths: []string{"spec.data.password", "spec.replicas.credentials.token"},
+		},
+		{Paths: []string{"metadata.annotations.apiKey"}},
+	}})
+
+	events := []auditv1.Event{
+		{
+			AuditID:   "database",
+			ObjectRef: &auditv1.ObjectReference{APIGroup: "example.com", Resource: "databases", Name: "orders"},
+			RequestObject: &runtime.Unknown{Raw: []byte(`{"kind":"Database","spec":{"size":3,"data":{"user":"app","password":"czNjcjN0"},` +
+				`"replicas":[{"name":"r1","credentials":{"token":"plaintext-token"}},{"name":"r2"}]}}`)},
+			ResponseObject: &runtime.Unknown{Raw: []byte(`{"kind":"DatabaseList","items":[{"spec":{"data":{"password":"czNjcjN0"}}}]}`)},
+		},
+		{
+			AuditID:        "widget",
+			ObjectRef:      &auditv1.ObjectReference{APIGroup: "example.com", Resource: "widgets", Name: "w"},
+			ResponseObject: &runtime.Unknown{Raw: []byte(`{"kind":"Widget","spec":{"data":{"password":"visible"}}}`)},
+		},
+		{
+			AuditID:        "annotated",
+			ObjectRef:      &auditv1.ObjectReference{Resource: "configmaps", Name: "c"},
+			ResponseObject: &runtime.Unknown{Raw: []byte(`{"kind":"ConfigMap","metadata":{"annotations":{"apiKey":"czNjcjN0","team":"payments"}}}`)},
+		},
+	}
+	raw := []string{"", `{"stored":"widget"}`, ""}
+
+	r.redactEvents(events, raw)
+
+	for _, i := range []int{0, 2} {
+		assertNoSecretData(t, events[i])
+		if events[i].Annotations[v1alpha1.AuditEventRedactedAnnotation] != "true" {
+			t.Errorf("event %s: expected redacted annotation", events[i].AuditID)
+		}
+	}
+
+	request := string(events[0].RequestObject.Raw)
+	for _, want := range []string{`"password":null`, `"user":"app"`, `"size":3`, `"name":"r2"`, `"token":null`} {
+		if !strings.Contains(request, want) {
+			t.Errorf("expected redacted request object to contain %s, got %s", want, request)
+		}
+	}
+	if !strings.Contains(string(events[2].ResponseObject.Raw), `"team":"payments"`) {
+		t.Errorf("expected other annotations to be kept, got %s", events[2].ResponseObject.Raw)
+	}
+
+	// The widget doesn't match the first rule and has no apiKey annotation
+	if !strings.Contains(string(events[1].ResponseObject.Raw), "visible") {
+		t.Error("expected widget body to be returned unchanged")
+	}
+	if _, ok := events[1].Annotations[v1alpha1.AuditEventRedactedAnnotation]; ok {
+		t.Error("expected widget event not to be marked redacted")
+	}
+	if raw[1] != `{"stored":"widget"}` {
+		t.Errorf("expected widget raw JSON to be unchanged, got %s", raw[1])
+	}
+}
+
+func TestNewRedactor_InvalidFieldRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    FieldRedactionRule
+		wantErr string
+	}{
+		{name: "no paths", rule: FieldRedactionRule{Match: "true"}, wantErr: "at least one path"},
+		{name: "empty segment", rule: FieldRedactionRule{Paths: []string{"spec..password"}}, wantErr: "invalid path"},
+		{name: "bad expression", rule: FieldRedactionRule{Match: "audit.verb ==", Paths: []string{"spec"}}, wantErr: "invalid match expression"},
+		{name: "not boolean", rule: FieldRedactionRule{Match: "audit.verb", Paths: []string{"spec"}}, wantErr: "must return a boolean"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newRedactor(RedactionConfig{FieldRules: []FieldRedactionRule{tt.rule}})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("newRedactor() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadFieldRedactionRules(t *testing.T) {
+	rules, err := LoadFieldRedactionRules("")
+	if err != nil || rules != nil {
+		t.Fatalf("LoadFieldRedactionRules(\"\") = %v, %v, want no rules", rules, err)
+	}
+
+	path := filepath.Join(t.TempDir(), "redaction-rules.yaml")
+	data := `rules:
+- match: audit.objectRef.resource == 'databases'
+  paths: [spec.data.password]
+`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	rules, err = LoadFieldRedactionRules(path)
+	if err != nil {
+		t.Fatalf("LoadFieldRedactionRules() error = %v", err)
+	}
+	if len(rules) != 1 || rules[0].Paths[0] != "spec.data.password" {
+		t.Errorf("LoadFieldRedactionRules() = %+v", rules)
+	}
+
+	if err := os.WriteFile(path, []byte("rules:\n- path: spec\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadFieldRedactionRules(path); err == nil {
+		t.Error("expected unknown fields to be rejected")
+	}
+}
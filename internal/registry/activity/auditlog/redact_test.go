@@ -0,0 +1,281 @@
+package auditlog
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
+
+	"go.miloapis.com/activity/internal/registry/scope"
+	"go.miloapis.com/activity/internal/storage"
+	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+)
+
+const secretBody = `{
+	"apiVersion": "v1",
+	"kind": "Secret",
+	"metadata": {
+		"name": "db-credentials",
+		"namespace": "default",
+		"annotations": {
+			"kubectl.kubernetes.io/last-applied-configuration": "{\"data\":{\"password\":\"czNjcjN0\"}}",
+			"team": "payments"
+		}
+	},
+	"type": "Opaque",
+	"data": {"password": "czNjcjN0"},
+	"stringData": {"token": "plaintext-token"}
+}`
+
+const secretListBody = `{
+	"apiVersion": "v1",
+	"kind": "SecretList",
+	"metadata": {"resourceVersion": "42"},
+	"items": [
+		{"metadata": {"name": "a"}, "data": {"password": "czNjcjN0"}},
+		{"metadata": {"name": "b"}, "data": {"password": "czNjcjN0"}}
+	]
+}`
+
+// secretValues must never appear in a redacted event.
+var secretValues = []string{"czNjcjN0", "plaintext-token"}
+
+func secretEvents() []auditv1.Event {
+	return []auditv1.Event{
+		{
+			AuditID:        "secret-create",
+			Verb:           "create",
+			ObjectRef:      &auditv1.ObjectReference{Resource: "secrets", Namespace: "default", Name: "db-credentials"},
+			RequestObject:  &runtime.Unknown{Raw: []byte(secretBody)},
+			ResponseObject: &runtime.Unknown{Raw: []byte(secretBody)},
+		},
+		{
+			AuditID:        "secret-list",
+			Verb:           "list",
+			ObjectRef:      &auditv1.ObjectReference{Resource: "secrets", Namespace: "default"},
+			ResponseObject: &runtime.Unknown{Raw: []byte(secretListBody)},
+		},
+		{
+			AuditID:       "secret-patch-unparseable",
+			Verb:          "patch",
+			ObjectRef:     &auditv1.ObjectReference{Resource: "secrets", Namespace: "default", Name: "db-credentials"},
+			RequestObject: &runtime.Unknown{Raw: []byte(`[{"op":"replace","path":"/data/password","value":"czNjcjN0"}]`)},
+		},
+		{
+			AuditID:        "configmap-create",
+			Verb:           "create",
+			ObjectRef:      &auditv1.ObjectReference{Resource: "configmaps", Namespace: "default", Name: "settings"},
+			ResponseObject: &runtime.Unknown{Raw: []byte(`{"kind":"ConfigMap","data":{"mode":"fast"}}`)},
+		},
+	}
+}
+
+func assertNoSecretData(t *testing.T, event auditv1.Event) {
+	t.Helper()
+	for _, obj := range []*runtime.Unknown{event.RequestObject, event.ResponseObject} {
+		if obj == nil {
+			continue
+		}
+		for _, value := range secretValues {
+			if strings.Contains(string(obj.Raw), value) {
+				t.Errorf("event %s leaked secret value %q: %s", event.AuditID, value, obj.Raw)
+			}
+		}
+	}
+}
+
+func mustNewRedactor(t *testing.T, config RedactionConfig) *redactor {
+	t.Helper()
+	r, err := newRedactor(config)
+	if err != nil {
+		t.Fatalf("newRedactor() error = %v", err)
+	}
+	return r
+}
+
+func TestRedactor_RedactEvents(t *testing.T) {
+	r := mustNewRedactor(t, DefaultRedactionConfig())
+	events := secretEvents()
+
+	r.redactEvents(events, nil)
+
+	for _, event := range events[:3] {
+		assertNoSecretData(t, event)
+		if event.Annotations[v1alpha1.AuditEventRedactedAnnotation] != "true" {
+			t.Errorf("event %s: expected redacted annotation", event.AuditID)
+		}
+	}
+
+	// Metadata is kept so the audit trail still says which secret changed
+	created := string(events[0].ResponseObject.Raw)
+	for _, want := range []string{`"name":"db-credentials"`, `"kind":"Secret"`, `"team":"payments"`} {
+		if !strings.Contains(created, want) {
+			t.Errorf("expected redacted object to keep %s, got %s", want, created)
+		}
+	}
+	if strings.Contains(created, "last-applied-configuration") {
+		t.Errorf("expected last-applied-configuration annotation to be dropped, got %s", created)
+	}
+
+	listed := string(events[1].ResponseObject.Raw)
+	if !strings.Contains(listed, `"name":"a"`) || !strings.Contains(listed, `"name":"b"`) {
+		t.Errorf("expected list item metadata to be kept, got %s", listed)
+	}
+
+	if events[2].RequestObject != nil {
+		t.Errorf("expected unparseable body to be dropped, got %s", events[2].RequestObject.Raw)
+	}
+
+	// ConfigMaps are not redacted by default
+	if !strings.Contains(string(events[3].ResponseObject.Raw), "fast") {
+		t.Error("expected configmap body to be returned unchanged")
+	}
+	if _, ok := events[3].Annotations[v1alpha1.AuditEventRedactedAnnotation]; ok {
+		t.Error("expected configmap event not to be marked redacted")
+	}
+}
+
+func TestRedactor_RedactEvents_Raw(t *testing.T) {
+	r := mustNewRedactor(t, DefaultRedactionConfig())
+	events := secretEvents()
+	raw := make([]string, len(events))
+	for i := range events {
+		stored, err := json.Marshal(events[i])
+		if err != nil {
+			t.Fatalf("marshal event %s: %v", events[i].AuditID, err)
+		}
+		raw[i] = string(stored)
+	}
+	configMapRaw := raw[3]
+
+	r.redactEvents(events, raw)
+
+	for i, stored := range raw[:3] {
+		for _, value := range secretValues {
+			if strings.Contains(stored, value) {
+				t.Errorf("raw event %s leaked secret value %q: %s", events[i].AuditID, value, stored)
+			}
+		}
+		if !strings.Contains(stored, v1alpha1.AuditEventRedactedAnnotation) {
+			t.Errorf("raw event %s: expected redacted annotation, got %s", events[i].AuditID, stored)
+		}
+	}
+
+	// Unredacted events keep their stored bytes
+	if raw[3] != configMapRaw {
+		t.Errorf("expected configmap raw JSON to be unchanged, got %s", raw[3])
+	}
+}
+
+func TestRedactor_ConfiguredResources(t *testing.T) {
+	r := mustNewRedactor(t, RedactionConfig{Resources: []string{"secrets", " configmaps", "widgets.example.com"}})
+
+	events := secretEvents()
+	events = append(events, auditv1.Event{
+		AuditID:        "widget",
+		ObjectRef:      &auditv1.ObjectReference{APIGroup: "example.com", Resource: "widgets", Name: "w"},
+		ResponseObject: &runtime.Unknown{Raw: []byte(`{"kind":"Widget","spec":{"apiKey":"czNjcjN0"}}`)},
+	})
+	r.redactEvents(events, nil)
+
+	for _, event := range events {
+		assertNoSecretData(t, event)
+	}
+	if strings.Contains(string(events[3].ResponseObject.Raw), "fast") {
+		t.Error("expected configmap body to be redacted when configured")
+	}
+}
+
+func TestRedactor_AppliesTo(t *testing.T) {
+	r := mustNewRedactor(t, RedactionConfig{Resources: []string{"secrets"}, ExemptScopes: []string{"platform", "organization"}})
+
+	tests := []struct {
+		scope storage.ScopeContext
+		want  bool
+	}{
+		{scope: storage.ScopeContext{Type: "platform"}, want: false},
+		{scope: storage.ScopeContext{Type: "Organization", Name: "acme"}, want: false},
+		{scope: storage.ScopeContext{Type: "Project", Name: "prod"}, want: true},
+		{scope: storage.ScopeContext{Type: "User", Name: "alice"}, want: true},
+	}
+
+	for _, tt := range tests {
+		if got := r.appliesTo(tt.scope); got != tt.want {
+			t.Errorf("appliesTo(%s) = %v, want %v", tt.scope.Type, got, tt.want)
+		}
+	}
+
+	if mustNewRedactor(t, RedactionConfig{}).appliesTo(storage.ScopeContext{Type: "Project"}) {
+		t.Error("expected an empty config to disable redaction")
+	}
+}
+
+func TestQueryStorage_Create_RedactsSecrets(t *testing.T) {
+	mockStorage := &mockStorageInterface{
+		maxQueryWindow: 7 * 24 * time.Hour,
+		maxPageSize:    1000,
+		queryFunc: func(ctx context.Context, spec v1alpha1.AuditLogQuerySpec, scope storage.ScopeContext) (*storage.QueryResult, error) {
+			return &storage.QueryResult{Events: secretEvents()}, nil
+		},
+	}
+	qs := &QueryStorage{storage: mockStorage, redactor: mustNewRedactor(t, DefaultRedactionConfig())}
+
+	tests := []struct {
+		name       string
+		user       user.Info
+		wantRedact bool
+	}{
+		{
+			name: "organization scope",
+			user: &user.DefaultInfo{Name: "alice", Extra: map[string][]string{
+				scope.ParentKindExtraKey: {"Organization"},
+				scope.ParentNameExtraKey: {"acme"},
+			}},
+			wantRedact: true,
+		},
+		{
+			name: "project scope",
+			user: &user.DefaultInfo{Name: "bob", Extra: map[string][]string{
+				scope.ParentKindExtraKey: {"Project"},
+				scope.ParentNameExtraKey: {"prod"},
+			}},
+			wantRedact: true,
+		},
+		{
+			name:       "platform scope",
+			user:       &user.DefaultInfo{Name: "admin"},
+			wantRedact: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := &v1alpha1.AuditLogQuery{
+				Spec: v1alpha1.AuditLogQuerySpec{StartTime: "now-1h", EndTime: "now", Limit: 10},
+			}
+			ctx := request.WithUser(context.Background(), tt.user)
+
+			obj, err := qs.Create(ctx, query, nil, nil)
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			results := obj.(*v1alpha1.AuditLogQuery).Status.Results
+
+			if !tt.wantRedact {
+				if !strings.Contains(string(results[0].ResponseObject.Raw), "czNjcjN0") {
+					t.Error("expected platform scope to receive the unredacted body")
+				}
+				return
+			}
+			for _, event := range results {
+				assertNoSecretData(t, event)
+			}
+		})
+	}
+}
@@ -5,12 +5,15 @@ import (
 	"fmt"
 	"time"
 
+	"go.opentelemetry.io/otel/trace"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
 	"k8s.io/apiserver/pkg/endpoints/request"
 	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/apiserver/pkg/warning"
 	"k8s.io/klog/v2"
 
 	"go.miloapis.com/activity/internal/cel"
@@ -24,20 +27,45 @@ import (
 // StorageInterface defines the interface for storage operations needed by QueryStorage
 type StorageInterface interface {
 	QueryAuditLogs(ctx context.Context, spec v1alpha1.AuditLogQuerySpec, scope storage.ScopeContext) (*storage.QueryResult, error)
+	StreamAuditLogs(ctx context.Context, spec v1alpha1.AuditLogQuerySpec, scope storage.ScopeContext, emit func(event *auditv1.Event) error) (int, error)
+	AggregateAuditLogs(ctx context.Context, spec v1alpha1.AuditLogQuerySpec, scope storage.ScopeContext, groupBy []string, emit func(group *v1alpha1.AuditLogGroup) error) (int, error)
 	GetMaxQueryWindow() time.Duration
 	GetMaxPageSize() int32
+	GetMaxQueryTimeout() time.Duration
+	GetTenantMaxExecutionTime(scope storage.ScopeContext) time.Duration
+	GetAuditLogRetentionWindow() time.Duration
+	JSONExtractEnabled() bool
+	Now() time.Time
 }
 
 // QueryStorage implements REST storage for AuditLogQuery
 type QueryStorage struct {
-	storage StorageInterface
+	storage  StorageInterface
+	redactor *redactor
 }
 
-// NewQueryStorage returns a RESTStorage object for AuditLogQuery
-func NewQueryStorage(storage *storage.ClickHouseStorage) *QueryStorage {
+// NewQueryStorage returns a RESTStorage object for AuditLogQuery. Object bodies
+// of the resources listed in redaction, and the fields named by its field
+// rules, are stripped from results returned to non-exempt scopes.
+func NewQueryStorage(storage *storage.ClickHouseStorage, redaction RedactionConfig) (*QueryStorage, error) {
+	redactor, err := newRedactor(redaction)
+	if err != nil {
+		return nil, fmt.Errorf("invalid audit redaction config: %w", err)
+	}
 	return &QueryStorage{
-		storage: storage,
+		storage:  storage,
+		redactor: redactor,
+	}, nil
+}
+
+// referenceTime returns the time the spec's relative times resolve against,
+// given the current time. The spec is expected to have passed validation.
+func referenceTime(spec v1alpha1.AuditLogQuerySpec, now time.Time) (time.Time, error) {
+	reference, err := timeutil.ResolveReferenceTime(spec.RelativeTo, now)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse relativeTo: %w", err)
 	}
+	return reference, nil
 }
 
 var (
@@ -103,7 +131,7 @@ func (r *QueryStorage) Create(ctx context.Context, obj runtime.Object, createVal
 	)
 
 	// Reject invalid queries early to prevent expensive database operations
-	if errs := r.validateQuerySpec(query); len(errs) > 0 {
+	if errs := r.validateQuerySpec(query, scopeCtx); len(errs) > 0 {
 		return nil, errors.NewInvalid(
 			v1alpha1.SchemeGroupVersion.WithKind("AuditLogQuery").GroupKind(),
 			query.Name,
@@ -111,45 +139,121 @@ func (r *QueryStorage) Create(ctx context.Context, obj runtime.Object, createVal
 		)
 	}
 
+	if cel.UsesJSONExtract(query.Spec.Filter) {
+		warning.AddWarning(ctx, "", cel.JSONExtractWarning)
+	}
+
 	// Parse effective timestamps using a single reference time for consistency
-	now := time.Now()
-	effectiveStartTime, err := timeutil.ParseFlexibleTime(query.Spec.StartTime, now)
+	now := r.storage.Now()
+	reference, err := referenceTime(query.Spec, now)
+	if err != nil {
+		// This should not happen as validation already passed, but handle defensively
+		return nil, errors.NewInternalError(err)
+	}
+	effectiveStartTime, err := timeutil.ParseFlexibleTime(query.Spec.StartTime, reference)
 	if err != nil {
 		// This should not happen as validation already passed, but handle defensively
 		return nil, errors.NewInternalError(fmt.Errorf("failed to parse startTime: %w", err))
 	}
-	effectiveEndTime, err := timeutil.ParseFlexibleTime(query.Spec.EndTime, now)
+	effectiveEndTime, err := timeutil.ParseFlexibleTime(query.Spec.EndTime, reference)
 	if err != nil {
 		// This should not happen as validation already passed, but handle defensively
 		return nil, errors.NewInternalError(fmt.Errorf("failed to parse endTime: %w", err))
 	}
 
-	result, err := r.storage.QueryAuditLogs(ctx, query.Spec, scopeCtx)
+	replay, err := replaySpec(query.Spec, effectiveStartTime, effectiveEndTime)
 	if err != nil {
-		return nil, r.convertToStructuredError(query, err)
+		return nil, errors.NewInternalError(err)
+	}
+
+	// Data older than the retention window has aged out of ClickHouse, so
+	// report where results actually start rather than returning an unexplained
+	// gap. The spec is left as-is so pagination cursors stay valid.
+	effectiveStartTime, retentionClamped := storage.ClampToRetention(effectiveStartTime, now, r.storage.GetAuditLogRetentionWindow())
+	if retentionClamped {
+		warning.AddWarning(ctx, "", storage.RetentionWarning(r.storage.GetAuditLogRetentionWindow(), effectiveStartTime))
+	}
+
+	// Surface the request's trace ID so support can find the server-side logs
+	// and spans for a query a user reports as slow or failing.
+	traceID := traceIDFromContext(ctx)
+
+	queryCtx, cancel := storage.TenantQueryContext(ctx, query.Spec.TimeoutSeconds, r.storage.GetTenantMaxExecutionTime(scopeCtx))
+	defer cancel()
+
+	result, err := r.storage.QueryAuditLogs(queryCtx, query.Spec, scopeCtx)
+	if err != nil {
+		if storage.IsQueryTimeout(queryCtx) {
+			return nil, errors.NewTimeoutError(storage.QueryTimeoutMessage, 0)
+		}
+		return nil, r.convertToStructuredError(query, traceID, err)
+	}
+
+	// Strip sensitive object bodies (e.g. Secret data) and fields before they
+	// leave the server for callers outside the exempt scopes.
+	if r.redactor.appliesTo(scopeCtx) {
+		r.redactor.redactEvents(result.Events, result.RawEvents)
 	}
 
+	query.Status.TraceID = traceID
+	query.Status.QueryID = result.QueryID
 	query.Status.Results = result.Events
+	query.Status.RawResults = result.RawEvents
 	query.Status.Continue = result.Continue
+	query.Status.Previous = result.Previous
 	query.Status.EffectiveStartTime = effectiveStartTime.Format(time.RFC3339)
 	query.Status.EffectiveEndTime = effectiveEndTime.Format(time.RFC3339)
+	query.Status.RetentionClamped = retentionClamped
+	query.Status.Partial = result.Partial
+	query.Status.ReplaySpec = replay
+
+	if result.Partial {
+		warning.AddWarning(ctx, "", partialResultsWarning)
+	}
 
 	return query, nil
 }
 
-// validateQuerySpec validates the query specification and returns field errors
-func (r *QueryStorage) validateQuerySpec(query *v1alpha1.AuditLogQuery) field.ErrorList {
+// partialResultsWarning explains a page cut short by spec.bestEffort.
+const partialResultsWarning = "the query timed out while reading results; returning the events read so far. Use status.continue to fetch the rest, or narrow the time range or filter"
+
+// replaySpec returns spec with its times resolved to start and end and its
+// filter normalized, for status.replaySpec. Times keep sub-second precision so
+// a replay covers exactly the same window.
+func replaySpec(spec v1alpha1.AuditLogQuerySpec, start, end time.Time) (*v1alpha1.AuditLogQuerySpec, error) {
+	replay := spec.DeepCopy()
+	replay.StartTime = start.UTC().Format(time.RFC3339Nano)
+	replay.EndTime = end.UTC().Format(time.RFC3339Nano)
+	replay.RelativeTo = ""
+	replay.Continue = ""
+
+	filter, err := cel.NormalizeFilter(spec.Filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to normalize filter: %w", err)
+	}
+	replay.Filter = filter
+	return replay, nil
+}
+
+// validateQuerySpec validates the query specification for a caller in
+// scopeCtx and returns field errors
+func (r *QueryStorage) validateQuerySpec(query *v1alpha1.AuditLogQuery, scopeCtx storage.ScopeContext) field.ErrorList {
 	allErrs := field.ErrorList{}
 	specPath := field.NewPath("spec")
 
 	// Use a single reference time for all time parsing to prevent sub-second drift
 	// when using relative times like "now-7d" and "now"
-	now := time.Now()
+	now := r.storage.Now()
+	reference, err := timeutil.ResolveReferenceTime(query.Spec.RelativeTo, now)
+	if err != nil {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("relativeTo"), query.Spec.RelativeTo, err.Error()))
+		reference = now
+	}
 
 	if query.Spec.StartTime == "" {
 		allErrs = append(allErrs, field.Required(specPath.Child("startTime"), "must specify a start time"))
 	} else {
-		_, err := timeutil.ParseFlexibleTime(query.Spec.StartTime, now)
+		_, err := timeutil.ParseFlexibleTime(query.Spec.StartTime, reference)
 		if err != nil {
 			allErrs = append(allErrs, field.Invalid(specPath.Child("startTime"), query.Spec.StartTime, err.Error()))
 		}
@@ -158,15 +262,15 @@ func (r *QueryStorage) validateQuerySpec(query *v1alpha1.AuditLogQuery) field.Er
 	if query.Spec.EndTime == "" {
 		allErrs = append(allErrs, field.Required(specPath.Child("endTime"), "must specify an end time"))
 	} else {
-		_, err := timeutil.ParseFlexibleTime(query.Spec.EndTime, now)
+		_, err := timeutil.ParseFlexibleTime(query.Spec.EndTime, reference)
 		if err != nil {
 			allErrs = append(allErrs, field.Invalid(specPath.Child("endTime"), query.Spec.EndTime, err.Error()))
 		}
 	}
 
 	if query.Spec.StartTime != "" && query.Spec.EndTime != "" {
-		startTime, err1 := timeutil.ParseFlexibleTime(query.Spec.StartTime, now)
-		endTime, err2 := timeutil.ParseFlexibleTime(query.Spec.EndTime, now)
+		startTime, err1 := timeutil.ParseFlexibleTime(query.Spec.StartTime, reference)
+		endTime, err2 := timeutil.ParseFlexibleTime(query.Spec.EndTime, reference)
 
 		if err1 == nil && err2 == nil {
 			if !endTime.After(startTime) {
@@ -198,6 +302,12 @@ func (r *QueryStorage) validateQuerySpec(query *v1alpha1.AuditLogQuery) field.Er
 			fmt.Sprintf("limit of %d exceeds maximum of %d. Set limit to %d or less", query.Spec.Limit, maxPageSize, maxPageSize)))
 	}
 
+	if query.Spec.TimeoutSeconds != nil {
+		if err := storage.ValidateQueryTimeout(*query.Spec.TimeoutSeconds, r.storage.GetMaxQueryTimeout()); err != nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("timeoutSeconds"), *query.Spec.TimeoutSeconds, err.Error()))
+		}
+	}
+
 	// Validate cursor if provided (delegates to storage layer for cursor internals)
 	if query.Spec.Continue != "" {
 		if err := storage.ValidateCursor(query.Spec.Continue, query.Spec); err != nil {
@@ -207,8 +317,12 @@ func (r *QueryStorage) validateQuerySpec(query *v1alpha1.AuditLogQuery) field.Er
 
 	// Validate CEL filter syntax at API layer to fail fast before database operations
 	if query.Spec.Filter != "" {
-		_, err := cel.CompileFilter(query.Spec.Filter)
-		if err != nil {
+		if cel.UsesJSONExtract(query.Spec.Filter) && !r.storage.JSONExtractEnabled() {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("filter"), cel.JSONExtractDisabledMessage))
+		} else if r.redactor.appliesTo(scopeCtx) && cel.JSONExtractReadsObjectBody(query.Spec.Filter) {
+			// Matching on a body field would reveal what redaction hides
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("filter"), cel.JSONExtractRedactedBodyMessage))
+		} else if _, err := cel.CompileFilter(query.Spec.Filter); err != nil {
 			// CompileFilter returns friendly error messages with helpful context
 			allErrs = append(allErrs, field.Invalid(specPath.Child("filter"), query.Spec.Filter, err.Error()))
 		}
@@ -219,10 +333,26 @@ func (r *QueryStorage) validateQuerySpec(query *v1alpha1.AuditLogQuery) field.Er
 
 // convertToStructuredError translates internal database errors into actionable
 // Kubernetes status errors with appropriate HTTP codes and retry semantics.
-func (r *QueryStorage) convertToStructuredError(query *v1alpha1.AuditLogQuery, err error) error {
-	klog.ErrorS(err, "failed to execute query against clickhouse")
+func (r *QueryStorage) convertToStructuredError(query *v1alpha1.AuditLogQuery, traceID string, err error) error {
+	if storage.IsQueryQueueTimeout(err) {
+		return errors.NewServiceUnavailable(storage.QueryQueueTimeoutMessage)
+	}
+	klog.ErrorS(err, "failed to execute query against clickhouse", "query", query.Name, "traceID", traceID)
 
-	return errors.NewServiceUnavailable("Failed to execute query. Please try again later or contact support for help.")
+	if traceID == "" {
+		return errors.NewServiceUnavailable("Failed to execute query. Please try again later or contact support for help.")
+	}
+	return errors.NewServiceUnavailable(fmt.Sprintf("Failed to execute query. Please try again later or contact support with trace ID %s.", traceID))
+}
+
+// traceIDFromContext returns the trace ID of the span in ctx, or an empty
+// string when the request is not being traced.
+func traceIDFromContext(ctx context.Context) string {
+	spanCtx := trace.SpanFromContext(ctx).SpanContext()
+	if !spanCtx.HasTraceID() {
+		return ""
+	}
+	return spanCtx.TraceID().String()
 }
 
 // ConvertToTable converts to table format
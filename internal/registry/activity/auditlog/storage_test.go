@@ -7,10 +7,12 @@ import (
 	"testing"
 	"time"
 
+	"go.opentelemetry.io/otel/trace"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apiserver/pkg/authentication/user"
 	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/warning"
 	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
 
 	"go.miloapis.com/activity/internal/registry/scope"
@@ -21,8 +23,14 @@ import (
 // mockStorageInterface is a test double for StorageInterface
 type mockStorageInterface struct {
 	queryFunc       func(ctx context.Context, spec v1alpha1.AuditLogQuerySpec, scope storage.ScopeContext) (*storage.QueryResult, error)
+	streamFunc      func(ctx context.Context, spec v1alpha1.AuditLogQuerySpec, scope storage.ScopeContext, emit func(event *auditv1.Event) error) (int, error)
+	aggregateFunc   func(ctx context.Context, spec v1alpha1.AuditLogQuerySpec, scope storage.ScopeContext, groupBy []string, emit func(group *v1alpha1.AuditLogGroup) error) (int, error)
 	maxQueryWindow  time.Duration
 	maxPageSize     int32
+	jsonExtractEnabled bool
+	retentionWindow time.Duration
+	tenantMaxExecutionTime time.Duration
+	now func() time.Time
 }
 
 func (m *mockStorageInterface) QueryAuditLogs(ctx context.Context, spec v1alpha1.AuditLogQuerySpec, scope storage.ScopeContext) (*storage.QueryResult, error) {
@@ -35,6 +43,20 @@ func (m *mockStorageInterface) QueryAuditLogs(ctx context.Context, spec v1alpha1
 	}, nil
 }
 
+func (m *mockStorageInterface) StreamAuditLogs(ctx context.Context, spec v1alpha1.AuditLogQuerySpec, scope storage.ScopeContext, emit func(event *auditv1.Event) error) (int, error) {
+	if m.streamFunc != nil {
+		return m.streamFunc(ctx, spec, scope, emit)
+	}
+	return 0, nil
+}
+
+func (m *mockStorageInterface) AggregateAuditLogs(ctx context.Context, spec v1alpha1.AuditLogQuerySpec, scope storage.ScopeContext, groupBy []string, emit func(group *v1alpha1.AuditLogGroup) error) (int, error) {
+	if m.aggregateFunc != nil {
+		return m.aggregateFunc(ctx, spec, scope, groupBy, emit)
+	}
+	return 0, nil
+}
+
 func (m *mockStorageInterface) GetMaxQueryWindow() time.Duration {
 	return m.maxQueryWindow
 }
@@ -43,6 +65,29 @@ func (m *mockStorageInterface) GetMaxPageSize() int32 {
 	return m.maxPageSize
 }
 
+func (m *mockStorageInterface) GetMaxQueryTimeout() time.Duration {
+	return storage.DefaultMaxQueryTimeout
+}
+
+func (m *mockStorageInterface) GetTenantMaxExecutionTime(scope storage.ScopeContext) time.Duration {
+	return m.tenantMaxExecutionTime
+}
+
+func (m *mockStorageInterface) GetAuditLogRetentionWindow() time.Duration {
+	return m.retentionWindow
+}
+
+func (m *mockStorageInterface) JSONExtractEnabled() bool {
+	return m.jsonExtractEnabled
+}
+
+func (m *mockStorageInterface) Now() time.Time {
+	if m.now != nil {
+		return m.now()
+	}
+	return time.Now()
+}
+
 // TestQueryStorage_RESTInterface verifies the REST interface contracts
 func TestQueryStorage_RESTInterface(t *testing.T) {
 	mockStorage := &mockStorageInterface{
@@ -593,6 +638,151 @@ func TestQueryStorage_Create_StorageErrors(t *testing.T) {
 	}
 }
 
+// TestQueryStorage_Create_TraceID tests that the request's trace ID is
+// returned to the client on both success and failure
+func TestQueryStorage_Create_TraceID(t *testing.T) {
+	testUser := &user.DefaultInfo{Name: "test-user"}
+	spanCtx := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:  trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+	})
+	wantTraceID := "4bf92f3577b34da6a3ce929d0e0e4736"
+
+	newQuery := func() *v1alpha1.AuditLogQuery {
+		return &v1alpha1.AuditLogQuery{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec: v1alpha1.AuditLogQuerySpec{
+				StartTime: "now-1h",
+				EndTime:   "now",
+			},
+		}
+	}
+
+	t.Run("success sets status.traceID", func(t *testing.T) {
+		qs := &QueryStorage{storage: &mockStorageInterface{}}
+		ctx := trace.ContextWithSpanContext(request.WithUser(context.Background(), testUser), spanCtx)
+
+		obj, err := qs.Create(ctx, newQuery(), nil, nil)
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		if got := obj.(*v1alpha1.AuditLogQuery).Status.TraceID; got != wantTraceID {
+			t.Errorf("Status.TraceID = %q, want %q", got, wantTraceID)
+		}
+	})
+
+	t.Run("success sets status.queryID from storage", func(t *testing.T) {
+		wantQueryID := wantTraceID + "-00f067aa0ba902b7"
+		qs := &QueryStorage{storage: &mockStorageInterface{
+			queryFunc: func(ctx context.Context, spec v1alpha1.AuditLogQuerySpec, scope storage.ScopeContext) (*storage.QueryResult, error) {
+				return &storage.QueryResult{QueryID: wantQueryID}, nil
+			},
+		}}
+		ctx := trace.ContextWithSpanContext(request.WithUser(context.Background(), testUser), spanCtx)
+
+		obj, err := qs.Create(ctx, newQuery(), nil, nil)
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		if got := obj.(*v1alpha1.AuditLogQuery).Status.QueryID; got != wantQueryID {
+			t.Errorf("Status.QueryID = %q, want %q", got, wantQueryID)
+		}
+	})
+
+	t.Run("failure includes trace ID in message", func(t *testing.T) {
+		qs := &QueryStorage{storage: &mockStorageInterface{
+			queryFunc: func(ctx context.Context, spec v1alpha1.AuditLogQuerySpec, scope storage.ScopeContext) (*storage.QueryResult, error) {
+				return nil, fmt.Errorf("connection failed")
+			},
+		}}
+		ctx := trace.ContextWithSpanContext(request.WithUser(context.Background(), testUser), spanCtx)
+
+		_, err := qs.Create(ctx, newQuery(), nil, nil)
+		if err == nil {
+			t.Fatal("Create() error = nil, want error")
+		}
+		want := "contact support with trace ID " + wantTraceID
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Error message %q doesn't contain %q", err.Error(), want)
+		}
+	})
+
+	t.Run("untraced request leaves traceID empty", func(t *testing.T) {
+		qs := &QueryStorage{storage: &mockStorageInterface{}}
+		ctx := request.WithUser(context.Background(), testUser)
+
+		obj, err := qs.Create(ctx, newQuery(), nil, nil)
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		if got := obj.(*v1alpha1.AuditLogQuery).Status.TraceID; got != "" {
+			t.Errorf("Status.TraceID = %q, want empty", got)
+		}
+	})
+}
+
+// TestQueryStorage_Create_ReplaySpec tests that relative queries report a
+// fully resolved spec that can be re-run.
+func TestQueryStorage_Create_ReplaySpec(t *testing.T) {
+	qs := &QueryStorage{storage: &mockStorageInterface{
+		queryFunc: func(ctx context.Context, spec v1alpha1.AuditLogQuerySpec, scope storage.ScopeContext) (*storage.QueryResult, error) {
+			return &storage.QueryResult{Continue: "next-page-token"}, nil
+		},
+	}}
+	ctx := request.WithUser(context.Background(), &user.DefaultInfo{Name: "test-user"})
+
+	query := &v1alpha1.AuditLogQuery{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: v1alpha1.AuditLogQuerySpec{
+			StartTime: "now-1h",
+			EndTime:   "now",
+			Filter:    "verb=='delete'  &&  (objectRef.namespace == 'prod')",
+			Limit:     50,
+		},
+	}
+
+	obj, err := qs.Create(ctx, query, nil, nil)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	replay := obj.(*v1alpha1.AuditLogQuery).Status.ReplaySpec
+	if replay == nil {
+		t.Fatal("Status.ReplaySpec is nil, want resolved spec")
+	}
+
+	start, err := time.Parse(time.RFC3339Nano, replay.StartTime)
+	if err != nil {
+		t.Fatalf("ReplaySpec.StartTime %q is not absolute: %v", replay.StartTime, err)
+	}
+	end, err := time.Parse(time.RFC3339Nano, replay.EndTime)
+	if err != nil {
+		t.Fatalf("ReplaySpec.EndTime %q is not absolute: %v", replay.EndTime, err)
+	}
+	if end.Sub(start) != time.Hour {
+		t.Errorf("ReplaySpec window = %v, want exactly 1h", end.Sub(start))
+	}
+
+	wantFilter := `verb == "delete" && objectRef.namespace == "prod"`
+	if replay.Filter != wantFilter {
+		t.Errorf("ReplaySpec.Filter = %q, want %q", replay.Filter, wantFilter)
+	}
+	if replay.Limit != 50 {
+		t.Errorf("ReplaySpec.Limit = %d, want 50", replay.Limit)
+	}
+	if replay.Continue != "" {
+		t.Errorf("ReplaySpec.Continue = %q, want empty", replay.Continue)
+	}
+
+	// Replaying the resolved spec reports the same spec again.
+	obj, err = qs.Create(ctx, &v1alpha1.AuditLogQuery{Spec: *replay}, nil, nil)
+	if err != nil {
+		t.Fatalf("Create() with replay spec error = %v", err)
+	}
+	if again := obj.(*v1alpha1.AuditLogQuery).Status.ReplaySpec; *again != *replay {
+		t.Errorf("Replayed ReplaySpec = %+v, want %+v", *again, *replay)
+	}
+}
+
 // TestQueryStorage_Create_NoUserContext tests that missing user context returns error
 func TestQueryStorage_Create_NoUserContext(t *testing.T) {
 	mockStorage := &mockStorageInterface{
@@ -947,3 +1137,367 @@ func TestQueryStorage_Create_EffectiveTimestamps(t *testing.T) {
 		})
 	}
 }
+
+// recordedWarnings collects the warnings added to a request context.
+type recordedWarnings []string
+
+func (w *recordedWarnings) AddWarning(agent, text string) {
+	*w = append(*w, text)
+}
+
+// TestQueryStorage_Create_JSONExtractGate tests that jsonExtract() filters are
+// rejected unless the server enables them, and carry a warning when it does.
+func TestQueryStorage_Create_JSONExtractGate(t *testing.T) {
+	testUser := &user.DefaultInfo{
+		Name: "test-user",
+		Extra: map[string][]string{
+			scope.ParentKindExtraKey: {"Organization"},
+			scope.ParentNameExtraKey: {"test-org"},
+		},
+	}
+
+	newQuery := func() *v1alpha1.AuditLogQuery {
+		return &v1alpha1.AuditLogQuery{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec: v1alpha1.AuditLogQuerySpec{
+				StartTime: "now-1h",
+				EndTime:   "now",
+				Filter:    "jsonExtract('userAgent').startsWith('kubectl/')",
+			},
+		}
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		qs := &QueryStorage{storage: &mockStorageInterface{maxQueryWindow: 7 * 24 * time.Hour}}
+		ctx := request.WithUser(context.Background(), testUser)
+
+		_, err := qs.Create(ctx, newQuery(), nil, nil)
+		if !apierrors.IsInvalid(err) {
+			t.Fatalf("Create() error = %v, want Invalid", err)
+		}
+		if !strings.Contains(err.Error(), "--enable-cel-json-extract") {
+			t.Errorf("Create() error = %q, want it to mention --enable-cel-json-extract", err.Error())
+		}
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		qs := &QueryStorage{storage: &mockStorageInterface{maxQueryWindow: 7 * 24 * time.Hour, jsonExtractEnabled: true}}
+		warnings := &recordedWarnings{}
+		ctx := warning.WithWarningRecorder(request.WithUser(context.Background(), testUser), warnings)
+
+		if _, err := qs.Create(ctx, newQuery(), nil, nil); err != nil {
+			t.Fatalf("Create() error = %v, want nil", err)
+		}
+		if len(*warnings) != 1 || !strings.Contains((*warnings)[0], "can't use indexes or projections") {
+			t.Errorf("warnings = %q, want one about indexes and projections", *warnings)
+		}
+	})
+
+	t.Run("enabled with invalid path", func(t *testing.T) {
+		qs := &QueryStorage{storage: &mockStorageInterface{maxQueryWindow: 7 * 24 * time.Hour, jsonExtractEnabled: true}}
+		ctx := request.WithUser(context.Background(), testUser)
+
+		query := newQuery()
+		query.Spec.Filter = "jsonExtract('user agent') == 'x'"
+		if _, err := qs.Create(ctx, query, nil, nil); !apierrors.IsInvalid(err) {
+			t.Fatalf("Create() error = %v, want Invalid", err)
+		}
+	})
+
+	t.Run("object body path for a redacted scope", func(t *testing.T) {
+		redactor, err := newRedactor(DefaultRedactionConfig())
+		if err != nil {
+			t.Fatalf("newRedactor() error = %v", err)
+		}
+		qs := &QueryStorage{storage: &mockStorageInterface{maxQueryWindow: 7 * 24 * time.Hour, jsonExtractEnabled: true}, redactor: redactor}
+		ctx := request.WithUser(context.Background(), testUser)
+
+		query := newQuery()
+		query.Spec.Filter = "objectRef.resource == 'secrets' && jsonExtract('requestObject.data.password') == 'aHVudGVyMg=='"
+		_, err = qs.Create(ctx, query, nil, nil)
+		if !apierrors.IsInvalid(err) {
+			t.Fatalf("Create() error = %v, want Invalid", err)
+		}
+		if !strings.Contains(err.Error(), "redacted for your scope") {
+			t.Errorf("Create() error = %q, want it to explain that bodies are redacted", err.Error())
+		}
+	})
+
+	t.Run("object body path for an exempt scope", func(t *testing.T) {
+		redactor, err := newRedactor(DefaultRedactionConfig())
+		if err != nil {
+			t.Fatalf("newRedactor() error = %v", err)
+		}
+		qs := &QueryStorage{storage: &mockStorageInterface{maxQueryWindow: 7 * 24 * time.Hour, jsonExtractEnabled: true}, redactor: redactor}
+		ctx := request.WithUser(context.Background(), &user.DefaultInfo{Name: "platform-admin"})
+
+		query := newQuery()
+		query.Spec.Filter = "jsonExtract('requestObject.data.password') == 'aHVudGVyMg=='"
+		if _, err := qs.Create(ctx, query, nil, nil); err != nil {
+			t.Fatalf("Create() error = %v, want nil", err)
+		}
+	})
+}
+
+// TestQueryStorage_Create_RetentionClamp tests that queries reaching past the
+// retention window report the clamped start time and carry a warning.
+func TestQueryStorage_Create_RetentionClamp(t *testing.T) {
+	testUser := &user.DefaultInfo{
+		Name: "test-user",
+		Extra: map[string][]string{
+			scope.ParentKindExtraKey: {"Organization"},
+			scope.ParentNameExtraKey: {"test-org"},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		startTime    string
+		wantClamped  bool
+		wantWarnings int
+	}{
+		{name: "within retention", startTime: "now-7d", wantClamped: false, wantWarnings: 0},
+		{name: "before retention", startTime: "now-20d", wantClamped: true, wantWarnings: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotSpec v1alpha1.AuditLogQuerySpec
+			mockStorage := &mockStorageInterface{
+				maxQueryWindow:  30 * 24 * time.Hour,
+				retentionWindow: 14 * 24 * time.Hour,
+				queryFunc: func(ctx context.Context, spec v1alpha1.AuditLogQuerySpec, scope storage.ScopeContext) (*storage.QueryResult, error) {
+					gotSpec = spec
+					return &storage.QueryResult{}, nil
+				},
+			}
+			qs := &QueryStorage{storage: mockStorage}
+			warnings := &recordedWarnings{}
+			ctx := warning.WithWarningRecorder(request.WithUser(context.Background(), testUser), warnings)
+
+			query := &v1alpha1.AuditLogQuery{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec:       v1alpha1.AuditLogQuerySpec{StartTime: tt.startTime, EndTime: "now"},
+			}
+			before := time.Now()
+			obj, err := qs.Create(ctx, query, nil, nil)
+			if err != nil {
+				t.Fatalf("Create() error = %v, want nil", err)
+			}
+			result := obj.(*v1alpha1.AuditLogQuery)
+
+			if result.Status.RetentionClamped != tt.wantClamped {
+				t.Errorf("RetentionClamped = %v, want %v", result.Status.RetentionClamped, tt.wantClamped)
+			}
+			if len(*warnings) != tt.wantWarnings {
+				t.Errorf("warnings = %q, want %d", *warnings, tt.wantWarnings)
+			}
+			if gotSpec.StartTime != tt.startTime {
+				t.Errorf("storage startTime = %q, want the spec left as %q", gotSpec.StartTime, tt.startTime)
+			}
+			if tt.wantClamped {
+				start, err := time.Parse(time.RFC3339, result.Status.EffectiveStartTime)
+				if err != nil {
+					t.Fatalf("EffectiveStartTime %q: %v", result.Status.EffectiveStartTime, err)
+				}
+				oldest := before.Add(-14 * 24 * time.Hour).Truncate(time.Second)
+				if start.Before(oldest) {
+					t.Errorf("EffectiveStartTime = %v, want no earlier than %v", start, oldest)
+				}
+				if !strings.Contains((*warnings)[0], "14-day retention window") {
+					t.Errorf("warning = %q, want it to name the retention window", (*warnings)[0])
+				}
+			}
+		})
+	}
+}
+
+// TestQueryStorage_Create_TenantTimeoutClamp tests a spec.timeoutSeconds
+// longer than the tenant's execution time limit: the query runs with the
+// tenant limit as its deadline and the caller is warned.
+func TestQueryStorage_Create_TenantTimeoutClamp(t *testing.T) {
+	testUser := &user.DefaultInfo{
+		Name: "test-user",
+		Extra: map[string][]string{
+			scope.ParentKindExtraKey: {"Organization"},
+			scope.ParentNameExtraKey: {"test-org"},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		timeout      int32
+		wantDeadline time.Duration
+		wantWarnings int
+	}{
+		{name: "within tenant limit", timeout: 10, wantDeadline: 10 * time.Second, wantWarnings: 0},
+		{name: "above tenant limit", timeout: 50, wantDeadline: 20 * time.Second, wantWarnings: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var remaining time.Duration
+			mockStorage := &mockStorageInterface{
+				maxQueryWindow:         7 * 24 * time.Hour,
+				tenantMaxExecutionTime: 20 * time.Second,
+				queryFunc: func(ctx context.Context, spec v1alpha1.AuditLogQuerySpec, scope storage.ScopeContext) (*storage.QueryResult, error) {
+					deadline, _ := ctx.Deadline()
+					remaining = time.Until(deadline)
+					return &storage.QueryResult{}, nil
+				},
+			}
+			qs := &QueryStorage{storage: mockStorage}
+			warnings := &recordedWarnings{}
+			ctx := warning.WithWarningRecorder(request.WithUser(context.Background(), testUser), warnings)
+
+			timeout := tt.timeout
+			query := &v1alpha1.AuditLogQuery{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec:       v1alpha1.AuditLogQuerySpec{StartTime: "now-1h", EndTime: "now", TimeoutSeconds: &timeout},
+			}
+			if _, err := qs.Create(ctx, query, nil, nil); err != nil {
+				t.Fatalf("Create() error = %v, want nil", err)
+			}
+
+			if remaining > tt.wantDeadline || remaining < tt.wantDeadline-time.Second {
+				t.Errorf("deadline = %v away, want about %v", remaining, tt.wantDeadline)
+			}
+			if len(*warnings) != tt.wantWarnings {
+				t.Fatalf("warnings = %q, want %d", *warnings, tt.wantWarnings)
+			}
+			if tt.wantWarnings > 0 && !strings.Contains((*warnings)[0], "20-second execution time limit") {
+				t.Errorf("warning = %q, want it to name the tenant limit", (*warnings)[0])
+			}
+		})
+	}
+}
+
+// TestQueryStorage_Create_Partial tests that a best-effort page cut short by a
+// timeout is returned with status.partial and a warning.
+func TestQueryStorage_Create_Partial(t *testing.T) {
+	qs := &QueryStorage{storage: &mockStorageInterface{
+		queryFunc: func(ctx context.Context, spec v1alpha1.AuditLogQuerySpec, scope storage.ScopeContext) (*storage.QueryResult, error) {
+			if !spec.BestEffort {
+				t.Error("spec.BestEffort was not passed to storage")
+			}
+			return &storage.QueryResult{
+				Events:   []auditv1.Event{{AuditID: "a1"}, {AuditID: "a2"}},
+				Continue: "resume-token",
+				Partial:  true,
+			}, nil
+		},
+	}}
+	warnings := &recordedWarnings{}
+	ctx := warning.WithWarningRecorder(request.WithUser(context.Background(), &user.DefaultInfo{Name: "test-user"}), warnings)
+
+	query := &v1alpha1.AuditLogQuery{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: v1alpha1.AuditLogQuerySpec{
+			StartTime:  "now-1h",
+			EndTime:    "now",
+			BestEffort: true,
+		},
+	}
+
+	obj, err := qs.Create(ctx, query, nil, nil)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	status := obj.(*v1alpha1.AuditLogQuery).Status
+	if !status.Partial {
+		t.Error("Status.Partial = false, want true")
+	}
+	if len(status.Results) != 2 || status.Continue != "resume-token" {
+		t.Errorf("Status = %d results, continue %q; want 2 results and the resume token", len(status.Results), status.Continue)
+	}
+	if len(*warnings) != 1 || !strings.Contains((*warnings)[0], "timed out while reading results") {
+		t.Errorf("warnings = %q, want one about the timeout", *warnings)
+	}
+}
+
+// TestQueryStorage_Create_RelativeTo tests that relative times resolve against
+// spec.relativeTo instead of the current time
+func TestQueryStorage_Create_RelativeTo(t *testing.T) {
+	var captured v1alpha1.AuditLogQuerySpec
+	mockStorage := &mockStorageInterface{
+		maxQueryWindow: 30 * 24 * time.Hour,
+		maxPageSize:    1000,
+		queryFunc: func(ctx context.Context, spec v1alpha1.AuditLogQuerySpec, scope storage.ScopeContext) (*storage.QueryResult, error) {
+			captured = spec
+			return &storage.QueryResult{Events: []auditv1.Event{}}, nil
+		},
+	}
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	mockStorage.now = func() time.Time { return now }
+	qs := &QueryStorage{storage: mockStorage}
+
+	ctx := request.WithUser(context.Background(), &user.DefaultInfo{Name: "test-user"})
+
+	t.Run("pinned clock", func(t *testing.T) {
+		query := &v1alpha1.AuditLogQuery{Spec: v1alpha1.AuditLogQuerySpec{StartTime: "now-1h", EndTime: "now"}}
+		result, err := qs.Create(ctx, query, nil, nil)
+		if err != nil {
+			t.Fatalf("Create() error = %v, want nil", err)
+		}
+		status := result.(*v1alpha1.AuditLogQuery).Status
+		if status.EffectiveStartTime != "2026-10-16T11:00:00Z" || status.EffectiveEndTime != "2026-10-16T12:00:00Z" {
+			t.Errorf("effective times = %s to %s, want the hour before the pinned clock", status.EffectiveStartTime, status.EffectiveEndTime)
+		}
+	})
+
+	t.Run("relative to a fixed point", func(t *testing.T) {
+		query := &v1alpha1.AuditLogQuery{Spec: v1alpha1.AuditLogQuerySpec{
+			StartTime:  "now-7d",
+			EndTime:    "now",
+			RelativeTo: "2026-06-01T00:00:00Z",
+		}}
+		result, err := qs.Create(ctx, query, nil, nil)
+		if err != nil {
+			t.Fatalf("Create() error = %v, want nil", err)
+		}
+		status := result.(*v1alpha1.AuditLogQuery).Status
+		if status.EffectiveStartTime != "2026-05-25T00:00:00Z" || status.EffectiveEndTime != "2026-06-01T00:00:00Z" {
+			t.Errorf("effective times = %s to %s, want the week before relativeTo", status.EffectiveStartTime, status.EffectiveEndTime)
+		}
+		if captured.RelativeTo != "2026-06-01T00:00:00Z" {
+			t.Errorf("storage spec relativeTo = %q, want it passed through", captured.RelativeTo)
+		}
+		// The replay spec has absolute times, so it needs no reference
+		if status.ReplaySpec == nil || status.ReplaySpec.RelativeTo != "" || status.ReplaySpec.StartTime != "2026-05-25T00:00:00Z" {
+			t.Errorf("ReplaySpec = %+v, want absolute times without relativeTo", status.ReplaySpec)
+		}
+	})
+
+	invalid := []struct {
+		name      string
+		spec      v1alpha1.AuditLogQuerySpec
+		wantError string
+	}{
+		{
+			name:      "relativeTo in the future",
+			spec:      v1alpha1.AuditLogQuerySpec{StartTime: "now-1h", EndTime: "now", RelativeTo: "2026-10-17T00:00:00Z"},
+			wantError: "spec.relativeTo",
+		},
+		{
+			name:      "relativeTo not a timestamp",
+			spec:      v1alpha1.AuditLogQuerySpec{StartTime: "now-1h", EndTime: "now", RelativeTo: "now-1d"},
+			wantError: "spec.relativeTo",
+		},
+		{
+			name:      "endTime after relativeTo",
+			spec:      v1alpha1.AuditLogQuerySpec{StartTime: "now-1h", EndTime: "2026-07-01T00:00:00Z", RelativeTo: "2026-06-01T00:00:00Z"},
+			wantError: "spec.endTime",
+		},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := qs.Create(ctx, &v1alpha1.AuditLogQuery{Spec: tt.spec}, nil, nil)
+			if !apierrors.IsInvalid(err) {
+				t.Fatalf("Create() error = %v, want Invalid", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantError) {
+				t.Errorf("Create() error = %q, want it to mention %q", err.Error(), tt.wantError)
+			}
+		})
+	}
+}
@@ -3,14 +3,20 @@ package auditlogfacet
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/apiserver/pkg/endpoints/request"
 	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/apiserver/pkg/warning"
 	"k8s.io/klog/v2"
 
+	"go.miloapis.com/activity/internal/cel"
+	"go.miloapis.com/activity/internal/registry/activity/auditlog"
 	"go.miloapis.com/activity/internal/storage"
 	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
 )
@@ -18,6 +24,9 @@ import (
 // AuditLogFacetStorageInterface defines the storage operations needed by AuditLogFacetsQueryStorage.
 type AuditLogFacetStorageInterface interface {
 	QueryAuditLogFacets(ctx context.Context, spec storage.AuditLogFacetQuerySpec, scope storage.ScopeContext) (*storage.FacetQueryResult, error)
+	GetMaxQueryTimeout() time.Duration
+	GetTenantMaxExecutionTime(scope storage.ScopeContext) time.Duration
+	JSONExtractEnabled() bool
 }
 
 // AuditLogFacetsQueryStorage implements REST storage for AuditLogFacetsQuery resources.
@@ -25,12 +34,17 @@ type AuditLogFacetStorageInterface interface {
 // returns facet results without persisting anything.
 type AuditLogFacetsQueryStorage struct {
 	storage AuditLogFacetStorageInterface
+
+	// redaction decides which callers may not filter on object bodies, since
+	// facet counts would reveal what audit log query results redact.
+	redaction auditlog.RedactionConfig
 }
 
 // NewAuditLogFacetsQueryStorage creates a new REST storage for AuditLogFacetsQuery.
-func NewAuditLogFacetsQueryStorage(s AuditLogFacetStorageInterface) *AuditLogFacetsQueryStorage {
+func NewAuditLogFacetsQueryStorage(s AuditLogFacetStorageInterface, redaction auditlog.RedactionConfig) *AuditLogFacetsQueryStorage {
 	return &AuditLogFacetsQueryStorage{
-		storage: s,
+		storage:   s,
+		redaction: redaction,
 	}
 }
 
@@ -70,6 +84,26 @@ func (s *AuditLogFacetsQueryStorage) Create(ctx context.Context, obj runtime.Obj
 	if err := validateAuditLogFacetQueryInput(query); err != nil {
 		return nil, errors.NewBadRequest(err.Error())
 	}
+	if query.Spec.TimeoutSeconds != nil {
+		if err := storage.ValidateQueryTimeout(*query.Spec.TimeoutSeconds, s.storage.GetMaxQueryTimeout()); err != nil {
+			return nil, errors.NewInvalid(
+				v1alpha1.SchemeGroupVersion.WithKind("AuditLogFacetsQuery").GroupKind(),
+				query.Name,
+				field.ErrorList{field.Invalid(field.NewPath("spec", "timeoutSeconds"), *query.Spec.TimeoutSeconds, err.Error())},
+			)
+		}
+	}
+
+	if cel.UsesJSONExtract(query.Spec.Filter) {
+		if !s.storage.JSONExtractEnabled() {
+			return nil, errors.NewInvalid(
+				v1alpha1.SchemeGroupVersion.WithKind("AuditLogFacetsQuery").GroupKind(),
+				query.Name,
+				field.ErrorList{field.Forbidden(field.NewPath("spec", "filter"), cel.JSONExtractDisabledMessage)},
+			)
+		}
+		warning.AddWarning(ctx, "", cel.JSONExtractWarning)
+	}
 
 	// Extract user for scope context
 	reqUser, ok := request.UserFrom(ctx)
@@ -78,24 +112,47 @@ func (s *AuditLogFacetsQueryStorage) Create(ctx context.Context, obj runtime.Obj
 	}
 	scope := extractScopeFromUser(reqUser)
 
+	if s.redaction.AppliesTo(scope) && cel.JSONExtractReadsObjectBody(query.Spec.Filter) {
+		return nil, errors.NewInvalid(
+			v1alpha1.SchemeGroupVersion.WithKind("AuditLogFacetsQuery").GroupKind(),
+			query.Name,
+			field.ErrorList{field.Forbidden(field.NewPath("spec", "filter"), cel.JSONExtractRedactedBodyMessage)},
+		)
+	}
+
 	// Build storage spec from query spec
 	spec := storage.AuditLogFacetQuerySpec{
-		StartTime: query.Spec.TimeRange.Start,
-		EndTime:   query.Spec.TimeRange.End,
-		Filter:    query.Spec.Filter,
-		Facets:    make([]storage.FacetFieldSpec, len(query.Spec.Facets)),
+		StartTime:      query.Spec.TimeRange.Start,
+		EndTime:        query.Spec.TimeRange.End,
+		Filter:         query.Spec.Filter,
+		MutationsOnly:  query.Spec.MutationsOnly,
+		Facets:         make([]storage.FacetFieldSpec, len(query.Spec.Facets)),
+		PartialResults: query.Spec.PartialResults,
 	}
 
 	for i, f := range query.Spec.Facets {
 		spec.Facets[i] = storage.FacetFieldSpec{
 			Field: f.Field,
 			Limit: f.Limit,
+			Order: f.Order,
 		}
 	}
 
 	// Execute facet query
-	result, err := s.storage.QueryAuditLogFacets(ctx, spec, scope)
+	queryCtx, cancel := storage.TenantQueryContext(ctx, query.Spec.TimeoutSeconds, s.storage.GetTenantMaxExecutionTime(scope))
+	defer cancel()
+
+	result, err := s.storage.QueryAuditLogFacets(queryCtx, spec, scope)
 	if err != nil {
+		if storage.IsQueryQueueTimeout(err) {
+			return nil, errors.NewServiceUnavailable(storage.QueryQueueTimeoutMessage)
+		}
+		if storage.IsQueryTimeout(queryCtx) {
+			return nil, errors.NewTimeoutError(storage.QueryTimeoutMessage, 0)
+		}
+		if storage.IsFacetTooManyValues(err) {
+			return nil, errors.NewBadRequest(err.Error())
+		}
 		// Log the actual error for debugging but return a generic message to avoid leaking internal details
 		klog.ErrorS(err, "Failed to query audit log facets",
 			"filter", query.Spec.Filter,
@@ -124,6 +181,13 @@ func (s *AuditLogFacetsQueryStorage) Create(ctx context.Context, obj runtime.Obj
 		}
 	}
 
+	for _, e := range result.Errors {
+		response.Status.FacetErrors = append(response.Status.FacetErrors, v1alpha1.FacetError{
+			Field:   e.Field,
+			Message: e.Err.Error(),
+		})
+	}
+
 	return response, nil
 }
 
@@ -150,6 +214,10 @@ func validateAuditLogFacetQueryInput(query *v1alpha1.AuditLogFacetsQuery) error
 		if f.Limit < 0 {
 			return fmt.Errorf("facet %d: limit must be non-negative", i)
 		}
+
+		if !storage.IsValidFacetOrder(f.Order) {
+			return fmt.Errorf("facet %d: unsupported order %q. Supported orders: %s", i, f.Order, strings.Join(storage.FacetOrderNames(), ", "))
+		}
 	}
 
 	return nil
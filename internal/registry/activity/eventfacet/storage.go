@@ -3,6 +3,7 @@ package eventfacet
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -20,6 +21,8 @@ import (
 // EventFacetStorageInterface defines the storage operations needed by EventFacetQueryStorage.
 type EventFacetStorageInterface interface {
 	QueryEventFacets(ctx context.Context, spec storage.EventFacetQuerySpec, scope storage.ScopeContext) (*storage.FacetQueryResult, error)
+	GetMaxQueryTimeout() time.Duration
+	GetTenantMaxExecutionTime(scope storage.ScopeContext) time.Duration
 }
 
 // EventFacetQueryStorage implements REST storage for EventFacetQuery resources.
@@ -69,7 +72,7 @@ func (s *EventFacetQueryStorage) Create(ctx context.Context, obj runtime.Object,
 	}
 
 	// Validate input - collect all errors so users can fix everything in one request
-	if errs := validateEventFacetQueryInput(query); len(errs) > 0 {
+	if errs := validateEventFacetQueryInput(query, s.storage.GetMaxQueryTimeout()); len(errs) > 0 {
 		return nil, apierrors.NewValidationStatusError(
 			v1alpha1.SchemeGroupVersion.WithKind("EventFacetQuery").GroupKind(), "", errs)
 	}
@@ -92,12 +95,25 @@ func (s *EventFacetQueryStorage) Create(ctx context.Context, obj runtime.Object,
 		spec.Facets[i] = storage.FacetFieldSpec{
 			Field: f.Field,
 			Limit: f.Limit,
+			Order: f.Order,
 		}
 	}
 
 	// Execute facet query
-	result, err := s.storage.QueryEventFacets(ctx, spec, scope)
+	queryCtx, cancel := storage.TenantQueryContext(ctx, query.Spec.TimeoutSeconds, s.storage.GetTenantMaxExecutionTime(scope))
+	defer cancel()
+
+	result, err := s.storage.QueryEventFacets(queryCtx, spec, scope)
 	if err != nil {
+		if storage.IsQueryQueueTimeout(err) {
+			return nil, errors.NewServiceUnavailable(storage.QueryQueueTimeoutMessage)
+		}
+		if storage.IsQueryTimeout(queryCtx) {
+			return nil, errors.NewTimeoutError(storage.QueryTimeoutMessage, 0)
+		}
+		if storage.IsFacetTooManyValues(err) {
+			return nil, errors.NewBadRequest(err.Error())
+		}
 		// Log the actual error for debugging but return a generic message to avoid leaking internal details
 		klog.ErrorS(err, "Failed to query event facets",
 			"timeRange.start", query.Spec.TimeRange.Start,
@@ -129,11 +145,17 @@ func (s *EventFacetQueryStorage) Create(ctx context.Context, obj runtime.Object,
 }
 
 // validateEventFacetQueryInput validates the EventFacetQuery input and returns all field errors.
-func validateEventFacetQueryInput(query *v1alpha1.EventFacetQuery) field.ErrorList {
+func validateEventFacetQueryInput(query *v1alpha1.EventFacetQuery, maxTimeout time.Duration) field.ErrorList {
 	allErrs := field.ErrorList{}
 	specPath := field.NewPath("spec")
 	facetsPath := specPath.Child("facets")
 
+	if query.Spec.TimeoutSeconds != nil {
+		if err := storage.ValidateQueryTimeout(*query.Spec.TimeoutSeconds, maxTimeout); err != nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("timeoutSeconds"), *query.Spec.TimeoutSeconds, err.Error()))
+		}
+	}
+
 	if len(query.Spec.Facets) == 0 {
 		allErrs = append(allErrs, field.Required(facetsPath, "provide at least one facet to query"))
 		return allErrs
@@ -155,6 +177,10 @@ func validateEventFacetQueryInput(query *v1alpha1.EventFacetQuery) field.ErrorLi
 		if f.Limit < 0 {
 			allErrs = append(allErrs, field.Invalid(facetPath.Child("limit"), f.Limit, "must be non-negative"))
 		}
+
+		if !storage.IsValidFacetOrder(f.Order) {
+			allErrs = append(allErrs, field.NotSupported(facetPath.Child("order"), f.Order, storage.FacetOrderNames()))
+		}
 	}
 
 	return allErrs
@@ -11,6 +11,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/apiserver/pkg/endpoints/request"
 	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/apiserver/pkg/warning"
 	"k8s.io/klog/v2"
 
 	"go.miloapis.com/activity/internal/registry/scope"
@@ -24,6 +25,10 @@ type StorageInterface interface {
 	QueryEvents(ctx context.Context, spec v1alpha1.EventQuerySpec, scope storage.ScopeContext) (*storage.EventQueryResult, error)
 	GetMaxQueryWindow() time.Duration
 	GetMaxPageSize() int32
+	GetMaxQueryTimeout() time.Duration
+	GetRetentionWindow() time.Duration
+	GetTenantMaxExecutionTime(scope storage.ScopeContext) time.Duration
+	Now() time.Time
 }
 
 // EventQueryREST implements REST storage for EventQuery.
@@ -35,7 +40,7 @@ type EventQueryREST struct {
 }
 
 // NewEventQueryREST returns a RESTStorage object for EventQuery.
-func NewEventQueryREST(backend storage.EventQueryBackend) *EventQueryREST {
+func NewEventQueryREST(backend StorageInterface) *EventQueryREST {
 	return &EventQueryREST{
 		storage: backend,
 	}
@@ -112,7 +117,7 @@ func (r *EventQueryREST) Create(ctx context.Context, obj runtime.Object, createV
 
 	// Parse effective timestamps using a single reference time for consistency.
 	// This prevents sub-second drift when both startTime and endTime use relative formats.
-	now := time.Now()
+	now := r.storage.Now()
 	effectiveStartTime, err := timeutil.ParseFlexibleTime(query.Spec.StartTime, now)
 	if err != nil {
 		// Should not happen — validation already confirmed the format is valid
@@ -124,8 +129,23 @@ func (r *EventQueryREST) Create(ctx context.Context, obj runtime.Object, createV
 		return nil, errors.NewInternalError(fmt.Errorf("failed to parse endTime: %w", err))
 	}
 
-	result, err := r.storage.QueryEvents(ctx, query.Spec, scopeCtx)
+	replay := replaySpec(query.Spec, effectiveStartTime, effectiveEndTime)
+
+	// Report where results actually start when startTime predates the
+	// retention window; the spec is left as-is so pagination cursors stay valid.
+	effectiveStartTime, retentionClamped := storage.ClampToRetention(effectiveStartTime, now, r.storage.GetRetentionWindow())
+	if retentionClamped {
+		warning.AddWarning(ctx, "", storage.RetentionWarning(r.storage.GetRetentionWindow(), effectiveStartTime))
+	}
+
+	queryCtx, cancel := storage.TenantQueryContext(ctx, query.Spec.TimeoutSeconds, r.storage.GetTenantMaxExecutionTime(scopeCtx))
+	defer cancel()
+
+	result, err := r.storage.QueryEvents(queryCtx, query.Spec, scopeCtx)
 	if err != nil {
+		if storage.IsQueryTimeout(queryCtx) {
+			return nil, errors.NewTimeoutError(storage.QueryTimeoutMessage, 0)
+		}
 		return nil, r.convertToStructuredError(query, err)
 	}
 
@@ -133,17 +153,30 @@ func (r *EventQueryREST) Create(ctx context.Context, obj runtime.Object, createV
 	query.Status.Continue = result.Continue
 	query.Status.EffectiveStartTime = effectiveStartTime.Format(time.RFC3339)
 	query.Status.EffectiveEndTime = effectiveEndTime.Format(time.RFC3339)
+	query.Status.RetentionClamped = retentionClamped
+	query.Status.ReplaySpec = replay
 
 	return query, nil
 }
 
+// replaySpec returns spec with its times resolved to start and end, for
+// status.replaySpec. Times keep sub-second precision so a replay covers
+// exactly the same window.
+func replaySpec(spec v1alpha1.EventQuerySpec, start, end time.Time) *v1alpha1.EventQuerySpec {
+	replay := spec.DeepCopy()
+	replay.StartTime = start.UTC().Format(time.RFC3339Nano)
+	replay.EndTime = end.UTC().Format(time.RFC3339Nano)
+	replay.Continue = ""
+	return replay
+}
+
 // validateQuerySpec validates the EventQuerySpec and returns field-level errors.
 func (r *EventQueryREST) validateQuerySpec(query *v1alpha1.EventQuery) field.ErrorList {
 	allErrs := field.ErrorList{}
 	specPath := field.NewPath("spec")
 
 	// Use a single reference time for all time parsing to prevent sub-second drift
-	now := time.Now()
+	now := r.storage.Now()
 
 	if query.Spec.StartTime == "" {
 		allErrs = append(allErrs, field.Required(specPath.Child("startTime"), "must specify a start time"))
@@ -205,6 +238,39 @@ func (r *EventQueryREST) validateQuerySpec(query *v1alpha1.EventQuery) field.Err
 		))
 	}
 
+	if query.Spec.TimeoutSeconds != nil {
+		if err := storage.ValidateQueryTimeout(*query.Spec.TimeoutSeconds, r.storage.GetMaxQueryTimeout()); err != nil {
+			allErrs = append(allErrs, field.Invalid(
+				specPath.Child("timeoutSeconds"),
+				*query.Spec.TimeoutSeconds,
+				err.Error(),
+			))
+		}
+	}
+
+	for _, list := range []struct {
+		name   string
+		values []string
+	}{
+		{name: "reasons", values: query.Spec.Reasons},
+		{name: "types", values: query.Spec.Types},
+		{name: "sourceComponents", values: query.Spec.SourceComponents},
+	} {
+		for i, value := range list.values {
+			if value == "" {
+				allErrs = append(allErrs, field.Invalid(specPath.Child(list.name).Index(i), value, "must not be empty"))
+			}
+		}
+	}
+
+	if err := storage.ValidateEventProjection(query.Spec.Fields); err != nil {
+		allErrs = append(allErrs, field.Invalid(
+			specPath.Child("fields"),
+			query.Spec.Fields,
+			err.Error(),
+		))
+	}
+
 	// Validate continue cursor if provided
 	if query.Spec.Continue != "" {
 		if err := storage.ValidateEventQueryCursor(query.Spec.Continue, query.Spec); err != nil {
@@ -222,6 +288,9 @@ func (r *EventQueryREST) validateQuerySpec(query *v1alpha1.EventQuery) field.Err
 // convertToStructuredError translates internal database errors into actionable
 // Kubernetes status errors with appropriate HTTP codes and retry semantics.
 func (r *EventQueryREST) convertToStructuredError(query *v1alpha1.EventQuery, err error) error {
+	if storage.IsQueryQueueTimeout(err) {
+		return errors.NewServiceUnavailable(storage.QueryQueueTimeoutMessage)
+	}
 	klog.ErrorS(err, "failed to execute EventQuery against ClickHouse", "query", query.Name)
 	return errors.NewServiceUnavailable("Failed to execute query. Please try again later or contact support for help.")
 }
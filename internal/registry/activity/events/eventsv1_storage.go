@@ -17,6 +17,7 @@ import (
 	"k8s.io/klog/v2"
 
 	"go.miloapis.com/activity/internal/storage"
+	"go.miloapis.com/activity/internal/types"
 	eventwatch "go.miloapis.com/activity/internal/watch"
 )
 
@@ -100,6 +101,9 @@ func (r *EventsV1REST) Create(ctx context.Context, obj runtime.Object, createVal
 	}
 
 	scope := ExtractScopeFromUser(reqUser)
+	if scope.Type == types.TenantTypeNone {
+		return nil, errors.NewForbidden(eventsv1.Resource("events"), "", fmt.Errorf("events can only be created by requests scoped to a tenant"))
+	}
 
 	klog.V(4).InfoS("Creating events.k8s.io/v1 event",
 		"namespace", namespace,
@@ -390,6 +394,9 @@ func (r *EventsV1REST) convertToStatusError(err error) error {
 		return err
 	}
 
+	if storage.IsQueryQueueTimeout(err) {
+		return errors.NewServiceUnavailable(storage.QueryQueueTimeoutMessage)
+	}
 	klog.ErrorS(err, "Error in events storage")
 	return errors.NewServiceUnavailable("Failed to access events storage. Please try again later.")
 }
@@ -3,6 +3,7 @@ package events
 import (
 	"k8s.io/apiserver/pkg/authentication/user"
 
+	"go.miloapis.com/activity/internal/registry/scope"
 	"go.miloapis.com/activity/internal/types"
 )
 
@@ -21,7 +22,8 @@ type ScopeInfo struct {
 }
 
 // ExtractScopeFromUser determines the events query scope from user authentication metadata.
-// Defaults to platform-wide scope when no parent resource is specified.
+// Falls back to the server's default scope when no parent resource is
+// specified, like scope.ExtractScopeFromUser.
 //
 // The returned Type uses Kubernetes Kind naming convention (PascalCase) to match
 // how scope types are stored in ClickHouse.
@@ -29,15 +31,16 @@ type ScopeInfo struct {
 // For user scope, the Name field contains the user's UID (not username), which enables
 // querying all events within that user's context across all organizations and projects.
 func ExtractScopeFromUser(u user.Info) ScopeInfo {
+	unscoped := scope.Unscoped()
 	if u.GetExtra() == nil {
-		return ScopeInfo{Type: types.TenantTypePlatform, Name: ""}
+		return ScopeInfo{Type: unscoped.Type, Name: unscoped.Name}
 	}
 
 	parentKind := u.GetExtra()[ParentKindExtraKey]
 	parentName := u.GetExtra()[ParentNameExtraKey]
 
 	if len(parentKind) == 0 || len(parentName) == 0 {
-		return ScopeInfo{Type: types.TenantTypePlatform, Name: ""}
+		return ScopeInfo{Type: unscoped.Type, Name: unscoped.Name}
 	}
 
 	switch parentKind[0] {
@@ -48,6 +51,6 @@ func ExtractScopeFromUser(u user.Info) ScopeInfo {
 	case "User":
 		return ScopeInfo{Type: types.TenantTypeUser, Name: parentName[0]}
 	default:
-		return ScopeInfo{Type: types.TenantTypePlatform, Name: ""}
+		return ScopeInfo{Type: unscoped.Type, Name: unscoped.Name}
 	}
 }
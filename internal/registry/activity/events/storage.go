@@ -17,6 +17,7 @@ import (
 	"k8s.io/klog/v2"
 
 	"go.miloapis.com/activity/internal/storage"
+	"go.miloapis.com/activity/internal/types"
 	eventwatch "go.miloapis.com/activity/internal/watch"
 )
 
@@ -100,6 +101,9 @@ func (r *EventsREST) Create(ctx context.Context, obj runtime.Object, createValid
 	}
 
 	scope := ExtractScopeFromUser(reqUser)
+	if scope.Type == types.TenantTypeNone {
+		return nil, errors.NewForbidden(corev1.Resource("events"), "", fmt.Errorf("events can only be created by requests scoped to a tenant"))
+	}
 
 	klog.V(4).InfoS("Creating event",
 		"namespace", namespace,
@@ -402,6 +406,9 @@ func (r *EventsREST) convertToStatusError(err error) error {
 		return err
 	}
 
+	if storage.IsQueryQueueTimeout(err) {
+		return errors.NewServiceUnavailable(storage.QueryQueueTimeoutMessage)
+	}
 	klog.ErrorS(err, "Error in events storage")
 	return errors.NewServiceUnavailable("Failed to access events storage. Please try again later.")
 }
@@ -3,6 +3,7 @@ package facet
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -21,6 +22,8 @@ import (
 // FacetStorageInterface defines the storage operations needed by FacetQueryStorage.
 type FacetStorageInterface interface {
 	QueryFacets(ctx context.Context, spec storage.FacetQuerySpec, scope storage.ScopeContext) (*storage.FacetQueryResult, error)
+	GetMaxQueryTimeout() time.Duration
+	GetTenantMaxExecutionTime(scope storage.ScopeContext) time.Duration
 }
 
 // FacetQueryStorage implements REST storage for ActivityFacetQuery resources.
@@ -70,7 +73,7 @@ func (s *FacetQueryStorage) Create(ctx context.Context, obj runtime.Object, crea
 	}
 
 	// Validate input - collect all errors so users can fix everything in one request
-	if errs := validateFacetQueryInput(query); len(errs) > 0 {
+	if errs := validateFacetQueryInput(query, s.storage.GetMaxQueryTimeout()); len(errs) > 0 {
 		return nil, apierrors.NewValidationStatusError(
 			v1alpha1.SchemeGroupVersion.WithKind("ActivityFacetQuery").GroupKind(), "", errs)
 	}
@@ -94,12 +97,25 @@ func (s *FacetQueryStorage) Create(ctx context.Context, obj runtime.Object, crea
 		spec.Facets[i] = storage.FacetFieldSpec{
 			Field: f.Field,
 			Limit: f.Limit,
+			Order: f.Order,
 		}
 	}
 
 	// Execute facet query
-	result, err := s.storage.QueryFacets(ctx, spec, scopeCtx)
+	queryCtx, cancel := storage.TenantQueryContext(ctx, query.Spec.TimeoutSeconds, s.storage.GetTenantMaxExecutionTime(scopeCtx))
+	defer cancel()
+
+	result, err := s.storage.QueryFacets(queryCtx, spec, scopeCtx)
 	if err != nil {
+		if storage.IsQueryQueueTimeout(err) {
+			return nil, errors.NewServiceUnavailable(storage.QueryQueueTimeoutMessage)
+		}
+		if storage.IsQueryTimeout(queryCtx) {
+			return nil, errors.NewTimeoutError(storage.QueryTimeoutMessage, 0)
+		}
+		if storage.IsFacetTooManyValues(err) {
+			return nil, errors.NewBadRequest(err.Error())
+		}
 		// Log the actual error for debugging but return a generic message to avoid leaking internal details
 		klog.ErrorS(err, "Failed to query activity facets",
 			"filter", query.Spec.Filter,
@@ -132,11 +148,17 @@ func (s *FacetQueryStorage) Create(ctx context.Context, obj runtime.Object, crea
 }
 
 // validateFacetQueryInput validates the ActivityFacetQuery input and returns all field errors.
-func validateFacetQueryInput(query *v1alpha1.ActivityFacetQuery) field.ErrorList {
+func validateFacetQueryInput(query *v1alpha1.ActivityFacetQuery, maxTimeout time.Duration) field.ErrorList {
 	allErrs := field.ErrorList{}
 	specPath := field.NewPath("spec")
 	facetsPath := specPath.Child("facets")
 
+	if query.Spec.TimeoutSeconds != nil {
+		if err := storage.ValidateQueryTimeout(*query.Spec.TimeoutSeconds, maxTimeout); err != nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("timeoutSeconds"), *query.Spec.TimeoutSeconds, err.Error()))
+		}
+	}
+
 	if len(query.Spec.Facets) == 0 {
 		allErrs = append(allErrs, field.Required(facetsPath, "provide at least one facet to query"))
 		return allErrs
@@ -158,6 +180,10 @@ func validateFacetQueryInput(query *v1alpha1.ActivityFacetQuery) field.ErrorList
 		if f.Limit < 0 {
 			allErrs = append(allErrs, field.Invalid(facetPath.Child("limit"), f.Limit, "must be non-negative"))
 		}
+
+		if !storage.IsValidFacetOrder(f.Order) {
+			allErrs = append(allErrs, field.NotSupported(facetPath.Child("order"), f.Order, storage.FacetOrderNames()))
+		}
 	}
 
 	return allErrs
@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apiserver/pkg/authentication/user"
 	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/utils/ptr"
 
 	"go.miloapis.com/activity/internal/registry/scope"
 	"go.miloapis.com/activity/internal/storage"
@@ -28,6 +30,14 @@ func (m *mockFacetStorage) QueryFacets(ctx context.Context, spec storage.FacetQu
 	return &storage.FacetQueryResult{Facets: []storage.FacetFieldResult{}}, nil
 }
 
+func (m *mockFacetStorage) GetMaxQueryTimeout() time.Duration {
+	return storage.DefaultMaxQueryTimeout
+}
+
+func (m *mockFacetStorage) GetTenantMaxExecutionTime(scope storage.ScopeContext) time.Duration {
+	return 0
+}
+
 // TestFacetQueryStorage_RESTInterface verifies the REST interface contracts
 func TestFacetQueryStorage_RESTInterface(t *testing.T) {
 	s := NewFacetQueryStorage(&mockFacetStorage{})
@@ -320,6 +330,40 @@ func TestFacetQueryStorage_Create_ValidationErrors(t *testing.T) {
 			},
 			wantError: "Must be non-negative",
 		},
+		{
+			name: "unsupported order",
+			query: &v1alpha1.ActivityFacetQuery{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: v1alpha1.ActivityFacetQuerySpec{
+					Facets: []v1alpha1.FacetSpec{
+						{Field: "spec.actor.name", Order: "alphabetical"},
+					},
+				},
+			},
+			wantError: "value_asc",
+		},
+		{
+			name: "zero timeout",
+			query: &v1alpha1.ActivityFacetQuery{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: v1alpha1.ActivityFacetQuerySpec{
+					Facets:         []v1alpha1.FacetSpec{{Field: "spec.actor.name"}},
+					TimeoutSeconds: ptr.To[int32](0),
+				},
+			},
+			wantError: "positive number of seconds",
+		},
+		{
+			name: "timeout above server maximum",
+			query: &v1alpha1.ActivityFacetQuery{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: v1alpha1.ActivityFacetQuerySpec{
+					Facets:         []v1alpha1.FacetSpec{{Field: "spec.actor.name"}},
+					TimeoutSeconds: ptr.To[int32](61),
+				},
+			},
+			wantError: "maximum of 60 seconds",
+		},
 		{
 			name: "multiple errors - empty and invalid field",
 			query: &v1alpha1.ActivityFacetQuery{
@@ -399,6 +443,12 @@ func TestFacetQueryStorage_Create_StorageErrors(t *testing.T) {
 			wantStatus:   503,
 			wantContains: "Failed to retrieve facets",
 		},
+		{
+			name:         "too many distinct values",
+			storageError: fmt.Errorf("failed to query facet spec.resource.name: %w", &storage.FacetTooManyValuesError{Field: "spec.resource.name", MaxRows: 1000}),
+			wantStatus:   400,
+			wantContains: "add a filter or narrow the time range",
+		},
 	}
 
 	for _, tt := range tests {
@@ -442,6 +492,43 @@ func TestFacetQueryStorage_Create_StorageErrors(t *testing.T) {
 	}
 }
 
+// TestFacetQueryStorage_Create_Timeout tests that spec.timeoutSeconds bounds
+// the storage query and that running past it returns 504
+func TestFacetQueryStorage_Create_Timeout(t *testing.T) {
+	testUser := &user.DefaultInfo{Name: "test-user"}
+	ctx := request.WithUser(context.Background(), testUser)
+
+	var remaining time.Duration
+	mock := &mockFacetStorage{
+		queryFunc: func(ctx context.Context, spec storage.FacetQuerySpec, scope storage.ScopeContext) (*storage.FacetQueryResult, error) {
+			deadline, ok := ctx.Deadline()
+			if !ok {
+				t.Fatal("query context has no deadline")
+			}
+			remaining = time.Until(deadline)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	}
+	s := NewFacetQueryStorage(mock)
+
+	query := &v1alpha1.ActivityFacetQuery{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: v1alpha1.ActivityFacetQuerySpec{
+			Facets:         []v1alpha1.FacetSpec{{Field: "spec.actor.name"}},
+			TimeoutSeconds: ptr.To[int32](1),
+		},
+	}
+
+	_, err := s.Create(ctx, query, nil, nil)
+	if !apierrors.IsTimeout(err) {
+		t.Fatalf("Create() error = %v, want Timeout", err)
+	}
+	if remaining > time.Second {
+		t.Errorf("query deadline = %v away, want at most 1s", remaining)
+	}
+}
+
 // TestFacetQueryStorage_Create_NoUserContext tests that missing user context returns error
 func TestFacetQueryStorage_Create_NoUserContext(t *testing.T) {
 	s := NewFacetQueryStorage(&mockFacetStorage{})
@@ -514,7 +601,7 @@ func TestFacetQueryStorage_Create_SpecPassthrough(t *testing.T) {
 			Filter: "spec.changeSource == 'human'",
 			Facets: []v1alpha1.FacetSpec{
 				{Field: "spec.actor.name", Limit: 25},
-				{Field: "spec.resource.kind", Limit: 50},
+				{Field: "spec.resource.kind", Limit: 50, Order: v1alpha1.FacetOrderValueAsc},
 			},
 		},
 	}
@@ -556,6 +643,9 @@ func TestFacetQueryStorage_Create_SpecPassthrough(t *testing.T) {
 	if capturedSpec.Facets[1].Limit != 50 {
 		t.Errorf("Facets[1].Limit = %d, want 50", capturedSpec.Facets[1].Limit)
 	}
+	if capturedSpec.Facets[1].Order != v1alpha1.FacetOrderValueAsc {
+		t.Errorf("Facets[1].Order = %q, want %q", capturedSpec.Facets[1].Order, v1alpha1.FacetOrderValueAsc)
+	}
 }
 
 // TestFacetQueryStorage_Create_EmptyResults tests handling of empty results from storage
@@ -0,0 +1,314 @@
+package facettrend
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/apiserver/pkg/warning"
+	"k8s.io/klog/v2"
+
+	"go.miloapis.com/activity/internal/apierrors"
+	"go.miloapis.com/activity/internal/cel"
+	"go.miloapis.com/activity/internal/registry/activity/activitymetrics"
+	"go.miloapis.com/activity/internal/registry/activity/auditlog"
+	"go.miloapis.com/activity/internal/registry/scope"
+	"go.miloapis.com/activity/internal/storage"
+	"go.miloapis.com/activity/internal/timeutil"
+	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+)
+
+const (
+	// DefaultStartTime is applied when spec.timeRange.start is empty.
+	DefaultStartTime = "now-7d"
+
+	// DefaultBucketSize is applied when spec.bucketSize is empty. Trends are
+	// usually read per day; finer buckets can be requested explicitly.
+	DefaultBucketSize = "1d"
+
+	// DefaultLimit is how many values get a series when spec.limit is unset.
+	DefaultLimit = 5
+
+	// MaxLimit caps the number of series. A stacked chart with more series than
+	// this is unreadable, and each one multiplies the number of points.
+	MaxLimit = 20
+)
+
+// StorageInterface defines the storage operations needed by QueryStorage.
+type StorageInterface interface {
+	QueryFacetTrend(ctx context.Context, spec storage.FacetTrendQuerySpec, scope storage.ScopeContext) (*storage.FacetTrendResult, error)
+	GetMaxQueryWindow() time.Duration
+	GetMaxQueryTimeout() time.Duration
+	GetTenantMaxExecutionTime(scope storage.ScopeContext) time.Duration
+	JSONExtractEnabled() bool
+}
+
+// QueryStorage implements REST storage for FacetTrendQuery.
+// This is an ephemeral resource - it only supports Create operations and
+// returns aggregated counts without persisting anything.
+type QueryStorage struct {
+	storage StorageInterface
+
+	// redaction decides which callers may not filter on object bodies, since
+	// trend counts would reveal what audit log query results redact.
+	redaction auditlog.RedactionConfig
+}
+
+// NewQueryStorage creates a new REST storage for FacetTrendQuery.
+func NewQueryStorage(s StorageInterface, redaction auditlog.RedactionConfig) *QueryStorage {
+	return &QueryStorage{
+		storage:   s,
+		redaction: redaction,
+	}
+}
+
+var (
+	_ rest.Scoper               = &QueryStorage{}
+	_ rest.Creater              = &QueryStorage{}
+	_ rest.Storage              = &QueryStorage{}
+	_ rest.SingularNameProvider = &QueryStorage{}
+)
+
+// New returns an empty FacetTrendQuery.
+func (s *QueryStorage) New() runtime.Object {
+	return &v1alpha1.FacetTrendQuery{}
+}
+
+// Destroy cleans up resources.
+func (s *QueryStorage) Destroy() {}
+
+// NamespaceScoped returns false because FacetTrendQuery is cluster-scoped.
+func (s *QueryStorage) NamespaceScoped() bool {
+	return false
+}
+
+// GetSingularName returns the singular name of the resource.
+func (s *QueryStorage) GetSingularName() string {
+	return "facettrendquery"
+}
+
+// Create executes the trend query and returns one count series per top value.
+func (s *QueryStorage) Create(ctx context.Context, obj runtime.Object, createValidation rest.ValidateObjectFunc, options *metav1.CreateOptions) (runtime.Object, error) {
+	query, ok := obj.(*v1alpha1.FacetTrendQuery)
+	if !ok {
+		return nil, errors.NewBadRequest("expected FacetTrendQuery object")
+	}
+
+	// Use a single reference time so relative start and end times resolve consistently
+	now := time.Now()
+
+	// Validate input - collect all errors so users can fix everything in one request
+	if errs := s.validateQuerySpec(query, now); len(errs) > 0 {
+		return nil, apierrors.NewValidationStatusError(
+			v1alpha1.SchemeGroupVersion.WithKind("FacetTrendQuery").GroupKind(), query.Name, errs)
+	}
+	if cel.UsesJSONExtract(query.Spec.Filter) {
+		warning.AddWarning(ctx, "", cel.JSONExtractWarning)
+	}
+
+	reqUser, ok := request.UserFrom(ctx)
+	if !ok {
+		return nil, errors.NewInternalError(fmt.Errorf("no user in context"))
+	}
+	scopeCtx := scope.ExtractScopeFromUser(reqUser)
+
+	if s.redaction.AppliesTo(scopeCtx) && cel.JSONExtractReadsObjectBody(query.Spec.Filter) {
+		return nil, apierrors.NewValidationStatusError(
+			v1alpha1.SchemeGroupVersion.WithKind("FacetTrendQuery").GroupKind(), query.Name,
+			field.ErrorList{field.Forbidden(field.NewPath("spec", "filter"), cel.JSONExtractRedactedBodyMessage)})
+	}
+
+	// Validation guarantees these parse
+	startTime, endTime, _ := resolveTimeRange(query.Spec.TimeRange, now)
+	bucketSize, _ := parseBucketSize(query.Spec.BucketSize)
+	bucketSeconds := int64(bucketSize / time.Second)
+
+	// Align the start down to a bucket boundary so the first bucket is complete.
+	// ClickHouse's toStartOfInterval aligns to the Unix epoch, so do the same here.
+	alignedStart := time.Unix(startTime.Unix()/bucketSeconds*bucketSeconds, 0).UTC()
+
+	limit := query.Spec.Limit
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+
+	spec := storage.FacetTrendQuerySpec{
+		StartTime:     alignedStart,
+		EndTime:       endTime,
+		Field:         query.Spec.Field,
+		Limit:         limit,
+		BucketSeconds: bucketSeconds,
+		Filter:        query.Spec.Filter,
+	}
+
+	klog.V(4).InfoS("Executing facet trend query",
+		"query", query.Name,
+		"scopeType", scopeCtx.Type,
+		"scopeName", scopeCtx.Name,
+		"field", query.Spec.Field,
+		"startTime", alignedStart,
+		"endTime", endTime,
+		"bucketSeconds", bucketSeconds,
+	)
+
+	queryCtx, cancel := storage.TenantQueryContext(ctx, query.Spec.TimeoutSeconds, s.storage.GetTenantMaxExecutionTime(scopeCtx))
+	defer cancel()
+
+	result, err := s.storage.QueryFacetTrend(queryCtx, spec, scopeCtx)
+	if err != nil {
+		if storage.IsQueryQueueTimeout(err) {
+			return nil, errors.NewServiceUnavailable(storage.QueryQueueTimeoutMessage)
+		}
+		if storage.IsQueryTimeout(queryCtx) {
+			return nil, errors.NewTimeoutError(storage.QueryTimeoutMessage, 0)
+		}
+		if storage.IsFacetTooManyValues(err) {
+			return nil, errors.NewBadRequest(err.Error())
+		}
+		// Log the actual error for debugging but return a generic message to avoid leaking internal details
+		klog.ErrorS(err, "Failed to query facet trend",
+			"field", query.Spec.Field,
+			"filter", query.Spec.Filter,
+			"timeRange.start", query.Spec.TimeRange.Start,
+			"timeRange.end", query.Spec.TimeRange.End,
+		)
+		return nil, errors.NewServiceUnavailable("Failed to retrieve facet trend. Try again or contact support if the problem persists.")
+	}
+
+	response := query.DeepCopy()
+	response.Status = v1alpha1.FacetTrendQueryStatus{
+		Series:             make([]v1alpha1.FacetTrendSeries, len(result.Series)),
+		EffectiveStartTime: alignedStart.Format(time.RFC3339),
+		EffectiveEndTime:   endTime.UTC().Format(time.RFC3339),
+		BucketSeconds:      bucketSeconds,
+	}
+	for i, series := range result.Series {
+		response.Status.Series[i] = v1alpha1.FacetTrendSeries{
+			Value:  series.Value,
+			Total:  series.Total,
+			Points: make([]v1alpha1.FacetTrendPoint, len(series.Points)),
+		}
+		for j, p := range series.Points {
+			response.Status.Series[i].Points[j] = v1alpha1.FacetTrendPoint{
+				Timestamp: p.Bucket.UTC().Format(time.RFC3339),
+				Count:     p.Count,
+			}
+		}
+	}
+
+	return response, nil
+}
+
+// validateQuerySpec validates the query specification and returns all field errors.
+func (s *QueryStorage) validateQuerySpec(query *v1alpha1.FacetTrendQuery, now time.Time) field.ErrorList {
+	allErrs := field.ErrorList{}
+	specPath := field.NewPath("spec")
+	timeRangePath := specPath.Child("timeRange")
+
+	if query.Spec.Field == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("field"), "must specify a field"))
+	} else if !storage.IsValidAuditLogFacetField(query.Spec.Field) {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("field"), query.Spec.Field,
+			fmt.Sprintf("unsupported field. Supported fields: %s", storage.FormatSupportedFields(storage.AuditLogFacetFields))))
+	}
+
+	if query.Spec.Limit < 0 || query.Spec.Limit > MaxLimit {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("limit"), query.Spec.Limit,
+			fmt.Sprintf("must be between 1 and %d", MaxLimit)))
+	}
+
+	bucketSize, bucketErr := parseBucketSize(query.Spec.BucketSize)
+	if bucketErr != nil {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("bucketSize"), query.Spec.BucketSize, bucketErr.Error()))
+	}
+
+	startTime, endTime, timeErrs := resolveTimeRange(query.Spec.TimeRange, now)
+	for _, err := range timeErrs {
+		allErrs = append(allErrs, field.Invalid(timeRangePath.Child(err.field), err.value, err.err.Error()))
+	}
+	if len(timeErrs) == 0 {
+		if !endTime.After(startTime) {
+			allErrs = append(allErrs, field.Invalid(timeRangePath.Child("end"), query.Spec.TimeRange.End, "end must be after start"))
+		} else {
+			window := endTime.Sub(startTime)
+			maxWindow := s.storage.GetMaxQueryWindow()
+			if maxWindow > 0 && window > maxWindow {
+				allErrs = append(allErrs, field.Invalid(timeRangePath, fmt.Sprintf("%s to %s", startTime.Format(time.RFC3339), endTime.Format(time.RFC3339)),
+					fmt.Sprintf("time range of %v exceeds maximum of %v", window, maxWindow)))
+			}
+			if bucketErr == nil {
+				if buckets := int64((window + bucketSize - 1) / bucketSize); buckets > activitymetrics.MaxBuckets {
+					allErrs = append(allErrs, field.Invalid(specPath.Child("bucketSize"), query.Spec.BucketSize,
+						fmt.Sprintf("time range would contain %d buckets, maximum is %d. Use a larger bucket size or a shorter time range", buckets, activitymetrics.MaxBuckets)))
+				}
+			}
+		}
+	}
+
+	if query.Spec.Filter != "" {
+		if cel.UsesJSONExtract(query.Spec.Filter) && !s.storage.JSONExtractEnabled() {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("filter"), cel.JSONExtractDisabledMessage))
+		} else if _, err := cel.CompileFilter(query.Spec.Filter); err != nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("filter"), query.Spec.Filter, err.Error()))
+		}
+	}
+
+	if query.Spec.TimeoutSeconds != nil {
+		if err := storage.ValidateQueryTimeout(*query.Spec.TimeoutSeconds, s.storage.GetMaxQueryTimeout()); err != nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("timeoutSeconds"), *query.Spec.TimeoutSeconds, err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
+// timeRangeError is a time range bound that failed to parse.
+type timeRangeError struct {
+	field string
+	value string
+	err   error
+}
+
+// resolveTimeRange parses the time range, defaulting an empty start to
+// DefaultStartTime and an empty end to now.
+func resolveTimeRange(r v1alpha1.FacetTimeRange, now time.Time) (time.Time, time.Time, []timeRangeError) {
+	var errs []timeRangeError
+
+	start := r.Start
+	if start == "" {
+		start = DefaultStartTime
+	}
+	startTime, err := timeutil.ParseFlexibleTime(start, now)
+	if err != nil {
+		errs = append(errs, timeRangeError{field: "start", value: r.Start, err: err})
+	}
+
+	endTime := now
+	if r.End != "" {
+		if endTime, err = timeutil.ParseFlexibleTime(r.End, now); err != nil {
+			errs = append(errs, timeRangeError{field: "end", value: r.End, err: err})
+		}
+	}
+
+	return startTime, endTime, errs
+}
+
+// parseBucketSize parses spec.bucketSize like ActivityMetricsQuery does, but
+// defaults to DefaultBucketSize.
+func parseBucketSize(s string) (time.Duration, error) {
+	if s == "" {
+		s = DefaultBucketSize
+	}
+	return activitymetrics.ParseBucketSize(s)
+}
+
+// ConvertToTable converts to table format.
+func (s *QueryStorage) ConvertToTable(ctx context.Context, object runtime.Object, tableOptions runtime.Object) (*metav1.Table, error) {
+	return rest.NewDefaultTableConvertor(v1alpha1.Resource("facettrendquery")).ConvertToTable(ctx, object, tableOptions)
+}
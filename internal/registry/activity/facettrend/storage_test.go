@@ -0,0 +1,236 @@
+package facettrend
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
+
+	"go.miloapis.com/activity/internal/registry/activity/auditlog"
+	"go.miloapis.com/activity/internal/registry/scope"
+	"go.miloapis.com/activity/internal/storage"
+	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+)
+
+// mockTrendStorage is a test double for StorageInterface
+type mockTrendStorage struct {
+	queryFunc          func(ctx context.Context, spec storage.FacetTrendQuerySpec, scope storage.ScopeContext) (*storage.FacetTrendResult, error)
+	jsonExtractEnabled bool
+}
+
+func (m *mockTrendStorage) QueryFacetTrend(ctx context.Context, spec storage.FacetTrendQuerySpec, scope storage.ScopeContext) (*storage.FacetTrendResult, error) {
+	if m.queryFunc != nil {
+		return m.queryFunc(ctx, spec, scope)
+	}
+	return &storage.FacetTrendResult{}, nil
+}
+
+func (m *mockTrendStorage) GetMaxQueryWindow() time.Duration {
+	return 30 * 24 * time.Hour
+}
+
+func (m *mockTrendStorage) GetMaxQueryTimeout() time.Duration {
+	return storage.DefaultMaxQueryTimeout
+}
+
+func (m *mockTrendStorage) GetTenantMaxExecutionTime(scope storage.ScopeContext) time.Duration {
+	return 0
+}
+
+func (m *mockTrendStorage) JSONExtractEnabled() bool {
+	return m.jsonExtractEnabled
+}
+
+func testContext() context.Context {
+	return request.WithUser(context.Background(), &user.DefaultInfo{
+		Name: "test-user",
+		Extra: map[string][]string{
+			scope.ParentKindExtraKey: {"Organization"},
+			scope.ParentNameExtraKey: {"test-org"},
+		},
+	})
+}
+
+func TestQueryStorage_Create_Success(t *testing.T) {
+	day1 := time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)
+	day2 := day1.Add(24 * time.Hour)
+
+	var captured storage.FacetTrendQuerySpec
+	var capturedScope storage.ScopeContext
+	mock := &mockTrendStorage{
+		queryFunc: func(ctx context.Context, spec storage.FacetTrendQuerySpec, scope storage.ScopeContext) (*storage.FacetTrendResult, error) {
+			captured = spec
+			capturedScope = scope
+			return &storage.FacetTrendResult{
+				Series: []storage.FacetTrendSeriesResult{
+					{Value: "get", Total: 30, Points: []storage.FacetTrendPointResult{{Bucket: day1, Count: 10}, {Bucket: day2, Count: 20}}},
+					{Value: "delete", Total: 2, Points: []storage.FacetTrendPointResult{{Bucket: day2, Count: 2}}},
+				},
+			}, nil
+		},
+	}
+	s := NewQueryStorage(mock, auditlog.RedactionConfig{})
+
+	query := &v1alpha1.FacetTrendQuery{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-trend"},
+		Spec: v1alpha1.FacetTrendQuerySpec{
+			TimeRange: v1alpha1.FacetTimeRange{Start: "2026-10-14T09:30:00Z", End: "2026-10-16T00:00:00Z"},
+			Field:     "verb",
+			Filter:    "objectRef.namespace == 'production'",
+		},
+	}
+
+	result, err := s.Create(testContext(), query, nil, nil)
+	if err != nil {
+		t.Fatalf("Create() error = %v, want nil", err)
+	}
+
+	if captured.BucketSeconds != 86400 {
+		t.Errorf("BucketSeconds = %d, want the 1d default", captured.BucketSeconds)
+	}
+	if captured.Limit != DefaultLimit {
+		t.Errorf("Limit = %d, want default %d", captured.Limit, DefaultLimit)
+	}
+	if !captured.StartTime.Equal(day1) {
+		t.Errorf("StartTime = %v, want aligned %v", captured.StartTime, day1)
+	}
+	if captured.Field != "verb" || captured.Filter != query.Spec.Filter {
+		t.Errorf("storage spec = %+v, want field and filter passed through", captured)
+	}
+	if capturedScope.Type != "Organization" || capturedScope.Name != "test-org" {
+		t.Errorf("scope = %+v, want Organization/test-org", capturedScope)
+	}
+
+	resp := result.(*v1alpha1.FacetTrendQuery)
+	if len(resp.Status.Series) != 2 {
+		t.Fatalf("Status.Series has %d series, want 2", len(resp.Status.Series))
+	}
+	get := resp.Status.Series[0]
+	if get.Value != "get" || get.Total != 30 || len(get.Points) != 2 {
+		t.Fatalf("Series[0] = %+v", get)
+	}
+	if got := get.Points[1]; got.Timestamp != "2026-10-15T00:00:00Z" || got.Count != 20 {
+		t.Errorf("Series[0].Points[1] = %+v", got)
+	}
+	if resp.Status.EffectiveStartTime != "2026-10-14T00:00:00Z" {
+		t.Errorf("EffectiveStartTime = %q, want 2026-10-14T00:00:00Z", resp.Status.EffectiveStartTime)
+	}
+	if resp.Status.BucketSeconds != 86400 {
+		t.Errorf("Status.BucketSeconds = %d, want 86400", resp.Status.BucketSeconds)
+	}
+}
+
+func TestQueryStorage_Create_DefaultTimeRange(t *testing.T) {
+	var captured storage.FacetTrendQuerySpec
+	mock := &mockTrendStorage{
+		queryFunc: func(ctx context.Context, spec storage.FacetTrendQuerySpec, scope storage.ScopeContext) (*storage.FacetTrendResult, error) {
+			captured = spec
+			return &storage.FacetTrendResult{}, nil
+		},
+	}
+	s := NewQueryStorage(mock, auditlog.RedactionConfig{})
+
+	query := &v1alpha1.FacetTrendQuery{Spec: v1alpha1.FacetTrendQuerySpec{Field: "verb", BucketSize: "1h"}}
+	if _, err := s.Create(testContext(), query, nil, nil); err != nil {
+		t.Fatalf("Create() error = %v, want nil", err)
+	}
+
+	if window := captured.EndTime.Sub(captured.StartTime); window < 7*24*time.Hour || window > 7*24*time.Hour+time.Hour {
+		t.Errorf("window = %v, want the last 7 days", window)
+	}
+}
+
+func TestQueryStorage_Create_Validation(t *testing.T) {
+	s := NewQueryStorage(&mockTrendStorage{}, auditlog.RedactionConfig{})
+
+	tests := []struct {
+		name     string
+		spec     v1alpha1.FacetTrendQuerySpec
+		wantText string
+	}{
+		{
+			name:     "missing field",
+			spec:     v1alpha1.FacetTrendQuerySpec{},
+			wantText: "Must specify a field",
+		},
+		{
+			name:     "unsupported field",
+			spec:     v1alpha1.FacetTrendQuerySpec{Field: "spec.resource.kind"},
+			wantText: "Unsupported field",
+		},
+		{
+			name:     "limit too high",
+			spec:     v1alpha1.FacetTrendQuerySpec{Field: "verb", Limit: MaxLimit + 1},
+			wantText: "Must be between 1 and 20",
+		},
+		{
+			name:     "too many buckets",
+			spec:     v1alpha1.FacetTrendQuerySpec{Field: "verb", BucketSize: "1m"},
+			wantText: "buckets",
+		},
+		{
+			name:     "end before start",
+			spec:     v1alpha1.FacetTrendQuerySpec{Field: "verb", TimeRange: v1alpha1.FacetTimeRange{Start: "now-1d", End: "now-2d"}},
+			wantText: "End must be after start",
+		},
+		{
+			name:     "invalid filter",
+			spec:     v1alpha1.FacetTrendQuerySpec{Field: "verb", Filter: "bogus == 'x'"},
+			wantText: "undeclared reference to 'bogus'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := &v1alpha1.FacetTrendQuery{Spec: tt.spec}
+			_, err := s.Create(testContext(), query, nil, nil)
+			if err == nil {
+				t.Fatal("Create() error = nil, want validation error")
+			}
+			if !apierrors.IsInvalid(err) {
+				t.Errorf("Create() error = %v, want Invalid", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantText) {
+				t.Errorf("Create() error = %q, want it to mention %q", err.Error(), tt.wantText)
+			}
+		})
+	}
+}
+
+// TestQueryStorage_Create_RedactedBodyFilter tests that tenant callers can't
+// count events by the contents of object bodies that audit log queries redact.
+func TestQueryStorage_Create_RedactedBodyFilter(t *testing.T) {
+	queried := false
+	mock := &mockTrendStorage{
+		jsonExtractEnabled: true,
+		queryFunc: func(ctx context.Context, spec storage.FacetTrendQuerySpec, scope storage.ScopeContext) (*storage.FacetTrendResult, error) {
+			queried = true
+			return &storage.FacetTrendResult{}, nil
+		},
+	}
+	s := NewQueryStorage(mock, auditlog.DefaultRedactionConfig())
+
+	query := &v1alpha1.FacetTrendQuery{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-trend"},
+		Spec: v1alpha1.FacetTrendQuerySpec{
+			Field:  "user.username",
+			Filter: "jsonExtract('responseObject.data.token').startsWith('ey')",
+		},
+	}
+
+	_, err := s.Create(testContext(), query, nil, nil)
+	if !apierrors.IsInvalid(err) {
+		t.Fatalf("Create() error = %v, want Invalid", err)
+	}
+	if !strings.Contains(err.Error(), "redacted for your scope") {
+		t.Errorf("Create() error = %q, want it to explain that bodies are redacted", err.Error())
+	}
+	if queried {
+		t.Error("storage was queried, want the filter refused first")
+	}
+}
@@ -61,6 +61,7 @@ func (c *policyTableConvertor) ConvertToTable(ctx context.Context, object runtim
 			{Name: "Audit Rules", Type: "integer", Description: "Number of audit log translation rules"},
 			{Name: "Event Rules", Type: "integer", Description: "Number of event translation rules"},
 			{Name: "Ready", Type: "string", Description: "Whether the policy compiled successfully"},
+			{Name: "Resolved", Type: "string", Description: "Whether the activity processor found the target resource"},
 			{Name: "Age", Type: "string", Description: "Time since policy was created"},
 		},
 	}
@@ -97,6 +98,16 @@ func policyToTableRow(policy *activity.ActivityPolicy) metav1.TableRow {
 		ready = string(cond.Status)
 	}
 
+	// Show why the processor can't use the policy (e.g. the CRD isn't
+	// installed yet) rather than just False
+	resolved := "Unknown"
+	if cond := apimeta.FindStatusCondition(policy.Status.Conditions, v1alpha1.ActivityPolicyConditionResourceResolved); cond != nil {
+		resolved = string(cond.Status)
+		if cond.Status == metav1.ConditionFalse {
+			resolved = cond.Reason
+		}
+	}
+
 	return metav1.TableRow{
 		Object: runtime.RawExtension{Object: policy},
 		Cells: []interface{}{
@@ -106,6 +117,7 @@ func policyToTableRow(policy *activity.ActivityPolicy) metav1.TableRow {
 			len(policy.Spec.AuditRules),
 			len(policy.Spec.EventRules),
 			ready,
+			resolved,
 			age,
 		},
 	}
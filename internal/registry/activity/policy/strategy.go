@@ -75,7 +75,7 @@ func (s activityPolicyStrategy) Validate(ctx context.Context, obj runtime.Object
 // WarningsOnCreate returns warnings for the creation of the given object.
 func (s activityPolicyStrategy) WarningsOnCreate(ctx context.Context, obj runtime.Object) []string {
 	policy := obj.(*activity.ActivityPolicy)
-	return warningsForPolicy(policy)
+	return WarningsForPolicy(policy)
 }
 
 // AllowCreateOnUpdate returns false because ActivityPolicy should be created via POST.
@@ -116,7 +116,7 @@ func (s activityPolicyStrategy) ValidateUpdate(ctx context.Context, obj, old run
 // WarningsOnUpdate returns warnings for the update of the given object.
 func (s activityPolicyStrategy) WarningsOnUpdate(ctx context.Context, obj, old runtime.Object) []string {
 	policy := obj.(*activity.ActivityPolicy)
-	return warningsForPolicy(policy)
+	return WarningsForPolicy(policy)
 }
 
 // ValidateActivityPolicy validates an ActivityPolicy and returns field errors.
@@ -177,8 +177,8 @@ func ValidateActivityPolicySpec(spec *activity.ActivityPolicySpec, specPath *fie
 	return allErrs
 }
 
-// warningsForPolicy returns warnings for an ActivityPolicy.
-func warningsForPolicy(policy *activity.ActivityPolicy) []string {
+// WarningsForPolicy returns warnings for an ActivityPolicy.
+func WarningsForPolicy(policy *activity.ActivityPolicy) []string {
 	var warnings []string
 	if len(policy.Spec.AuditRules) == 0 && len(policy.Spec.EventRules) == 0 {
 		warnings = append(warnings, "policy has no rules defined and will have no effect")
@@ -221,6 +221,16 @@ func validatePolicyRule(rule activity.ActivityPolicyRule, path *field.Path, rule
 		}
 	}
 
+	// Validate severity - optional, defaults to Normal
+	switch rule.Severity {
+	case "", activity.ActivityPolicyRuleSeverityNormal, activity.ActivityPolicyRuleSeverityHigh:
+	default:
+		allErrs = append(allErrs, field.NotSupported(path.Child("severity"), rule.Severity, []string{
+			activity.ActivityPolicyRuleSeverityNormal,
+			activity.ActivityPolicyRuleSeverityHigh,
+		}))
+	}
+
 	return allErrs
 }
 
@@ -293,6 +293,36 @@ func TestValidateActivityPolicy(t *testing.T) {
 			wantErrs:  1,
 			wantPaths: []string{"spec.auditRules[0].name"},
 		},
+		{
+			name: "unsupported rule severity",
+			policy: &v1alpha1.ActivityPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-policy",
+				},
+				Spec: v1alpha1.ActivityPolicySpec{
+					Resource: v1alpha1.ActivityPolicyResource{
+						APIGroup: "networking.datumapis.com",
+						Kind:     "HTTPProxy",
+					},
+					AuditRules: []v1alpha1.ActivityPolicyRule{
+						{
+							Name:     "delete",
+							Match:    "audit.verb == 'delete'",
+							Summary:  "{{ actor }} deleted HTTPProxy",
+							Severity: v1alpha1.ActivityPolicyRuleSeverityHigh,
+						},
+						{
+							Name:     "create",
+							Match:    "audit.verb == 'create'",
+							Summary:  "{{ actor }} created HTTPProxy",
+							Severity: "Critical",
+						},
+					},
+				},
+			},
+			wantErrs:  1,
+			wantPaths: []string{"spec.auditRules[1].severity"},
+		},
 		{
 			name: "invalid DNS subdomain with underscores in event rule name",
 			policy: &v1alpha1.ActivityPolicy{
@@ -181,6 +181,7 @@ func convertPolicySpecToInternal(in *v1alpha1.ActivityPolicySpec) *activity.Acti
 			Description: rule.Description,
 			Match:       rule.Match,
 			Summary:     rule.Summary,
+			Severity:    string(rule.Severity),
 		}
 	}
 
@@ -191,6 +192,7 @@ func convertPolicySpecToInternal(in *v1alpha1.ActivityPolicySpec) *activity.Acti
 			Description: rule.Description,
 			Match:       rule.Match,
 			Summary:     rule.Summary,
+			Severity:    string(rule.Severity),
 		}
 	}
 
@@ -2,7 +2,6 @@ package record
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
@@ -57,6 +56,7 @@ var (
 	_ rest.Scoper               = &ActivityStorage{}
 	_ rest.Storage              = &ActivityStorage{}
 	_ rest.Lister               = &ActivityStorage{}
+	_ rest.Getter               = &ActivityStorage{}
 	_ rest.Watcher              = &ActivityStorage{}
 	_ rest.SingularNameProvider = &ActivityStorage{}
 	_ rest.TableConvertor       = &ActivityStorage{}
@@ -129,6 +129,9 @@ func (s *ActivityStorage) List(ctx context.Context, options *metainternalversion
 
 	result, err := s.storage.QueryActivities(ctx, spec, scopeCtx)
 	if err != nil {
+		if storage.IsQueryQueueTimeout(err) {
+			return nil, errors.NewServiceUnavailable(storage.QueryQueueTimeoutMessage)
+		}
 		klog.ErrorS(err, "Failed to query activities", "namespace", namespace, "scope", scopeCtx.Type)
 		return nil, errors.NewServiceUnavailable("Failed to retrieve activities. Please try again later.")
 	}
@@ -140,18 +143,54 @@ func (s *ActivityStorage) List(ctx context.Context, options *metainternalversion
 		},
 	}
 
-	for _, activityJSON := range result.Activities {
-		var activity v1alpha1.Activity
-		if err := json.Unmarshal([]byte(activityJSON), &activity); err != nil {
-			klog.ErrorS(err, "Failed to unmarshal activity")
-			continue
+	list.Items = result.Activities
+	list.Continue = result.Continue
+
+	return list, nil
+}
+
+// Get returns a single activity by name. Activity names are derived from the
+// originating event rather than a timestamp, so the lookup is not limited to
+// DefaultListTimeWindow and searches the full retention period.
+func (s *ActivityStorage) Get(ctx context.Context, name string, options *metav1.GetOptions) (runtime.Object, error) {
+	namespace, _ := request.NamespaceFrom(ctx)
+
+	reqUser, ok := request.UserFrom(ctx)
+	if !ok {
+		klog.Error("No user in context for activity get request")
+		return nil, errors.NewServiceUnavailable("Unable to process request. Please try again.")
+	}
+	scopeCtx := scope.ExtractScopeFromUser(reqUser)
+
+	spec := storage.ActivityQuerySpec{
+		Filter: celEquality("metadata.name", name),
+		Limit:  1,
+	}
+	if namespace != "" {
+		spec.Filter += " && " + celEquality("metadata.namespace", namespace)
+	}
+
+	klog.V(4).InfoS("Getting activity",
+		"name", name,
+		"namespace", namespace,
+		"scope", scopeCtx.Type,
+	)
+
+	result, err := s.storage.QueryActivities(ctx, spec, scopeCtx)
+	if err != nil {
+		if storage.IsQueryQueueTimeout(err) {
+			return nil, errors.NewServiceUnavailable(storage.QueryQueueTimeoutMessage)
 		}
-		list.Items = append(list.Items, activity)
+		klog.ErrorS(err, "Failed to get activity", "name", name, "namespace", namespace, "scope", scopeCtx.Type)
+		return nil, errors.NewServiceUnavailable("Failed to retrieve activity. Please try again later.")
 	}
 
-	list.Continue = result.Continue
+	if len(result.Activities) == 0 {
+		return nil, errors.NewNotFound(v1alpha1.Resource("activities"), name)
+	}
 
-	return list, nil
+	activity := result.Activities[0]
+	return &activity, nil
 }
 
 // Watch returns a watch.Interface that watches activities matching the query options.
@@ -0,0 +1,127 @@
+package scopestats
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/klog/v2"
+
+	"go.miloapis.com/activity/internal/registry/scope"
+	"go.miloapis.com/activity/internal/storage"
+	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+)
+
+// StorageInterface defines the storage operations needed by StatsStorage.
+type StorageInterface interface {
+	QueryScopeStats(ctx context.Context, startTime, endTime time.Time, scope storage.ScopeContext) (*storage.ScopeStatsResult, error)
+}
+
+// StatsStorage implements REST storage for ScopeStats.
+// This is an ephemeral resource - it only supports Create operations and
+// returns summary stats for the caller's scope without persisting anything.
+type StatsStorage struct {
+	storage StorageInterface
+	now     func() time.Time
+}
+
+// NewStatsStorage creates a new REST storage for ScopeStats.
+func NewStatsStorage(s StorageInterface) *StatsStorage {
+	return &StatsStorage{
+		storage: s,
+		now:     time.Now,
+	}
+}
+
+var (
+	_ rest.Scoper               = &StatsStorage{}
+	_ rest.Creater              = &StatsStorage{}
+	_ rest.Storage              = &StatsStorage{}
+	_ rest.SingularNameProvider = &StatsStorage{}
+)
+
+// New returns an empty ScopeStats.
+func (s *StatsStorage) New() runtime.Object {
+	return &v1alpha1.ScopeStats{}
+}
+
+// Destroy cleans up resources.
+func (s *StatsStorage) Destroy() {}
+
+// NamespaceScoped returns false because ScopeStats is cluster-scoped.
+func (s *StatsStorage) NamespaceScoped() bool {
+	return false
+}
+
+// GetSingularName returns the singular name of the resource.
+func (s *StatsStorage) GetSingularName() string {
+	return "scopestats"
+}
+
+// Create computes the stats for the caller's scope over the last 24 hours.
+func (s *StatsStorage) Create(ctx context.Context, obj runtime.Object, createValidation rest.ValidateObjectFunc, options *metav1.CreateOptions) (runtime.Object, error) {
+	stats, ok := obj.(*v1alpha1.ScopeStats)
+	if !ok {
+		return nil, errors.NewBadRequest("expected ScopeStats object")
+	}
+
+	reqUser, ok := request.UserFrom(ctx)
+	if !ok {
+		return nil, errors.NewInternalError(fmt.Errorf("no user in context"))
+	}
+	scopeCtx := scope.ExtractScopeFromUser(reqUser)
+
+	endTime := s.now().UTC().Truncate(time.Second)
+	startTime := endTime.Add(-storage.ScopeStatsWindow)
+
+	klog.V(4).InfoS("Computing scope stats",
+		"scopeType", scopeCtx.Type,
+		"scopeName", scopeCtx.Name,
+		"startTime", startTime,
+		"endTime", endTime,
+	)
+
+	result, err := s.storage.QueryScopeStats(ctx, startTime, endTime, scopeCtx)
+	if err != nil {
+		if storage.IsQueryQueueTimeout(err) {
+			return nil, errors.NewServiceUnavailable(storage.QueryQueueTimeoutMessage)
+		}
+		klog.ErrorS(err, "Failed to query scope stats",
+			"scopeType", scopeCtx.Type,
+			"scopeName", scopeCtx.Name,
+		)
+		return nil, errors.NewServiceUnavailable("Failed to retrieve stats. Try again or contact support if the problem persists.")
+	}
+
+	response := stats.DeepCopy()
+	response.Status = v1alpha1.ScopeStatsStatus{
+		WindowStart:     startTime.Format(time.RFC3339),
+		WindowEnd:       endTime.Format(time.RFC3339),
+		AuditEventCount: result.AuditEventCount,
+		ActivityCount:   result.ActivityCount,
+		UniqueActors:    result.UniqueActors,
+		TopResources:    make([]v1alpha1.ScopeStatsResource, len(result.TopResources)),
+	}
+	for i, r := range result.TopResources {
+		response.Status.TopResources[i] = v1alpha1.ScopeStatsResource{
+			APIGroup: r.APIGroup,
+			Kind:     r.Kind,
+			Count:    r.Count,
+		}
+	}
+	if !result.NewestAuditEventTime.IsZero() {
+		response.Status.NewestAuditEventTime = result.NewestAuditEventTime.UTC().Format(time.RFC3339)
+	}
+
+	return response, nil
+}
+
+// ConvertToTable converts to table format.
+func (s *StatsStorage) ConvertToTable(ctx context.Context, object runtime.Object, tableOptions runtime.Object) (*metav1.Table, error) {
+	return rest.NewDefaultTableConvertor(v1alpha1.Resource("scopestats")).ConvertToTable(ctx, object, tableOptions)
+}
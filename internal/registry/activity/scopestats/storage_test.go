@@ -0,0 +1,130 @@
+package scopestats
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
+
+	"go.miloapis.com/activity/internal/registry/scope"
+	"go.miloapis.com/activity/internal/storage"
+	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+)
+
+// mockStatsStorage is a test double for StorageInterface
+type mockStatsStorage struct {
+	queryFunc func(ctx context.Context, startTime, endTime time.Time, scope storage.ScopeContext) (*storage.ScopeStatsResult, error)
+}
+
+func (m *mockStatsStorage) QueryScopeStats(ctx context.Context, startTime, endTime time.Time, scope storage.ScopeContext) (*storage.ScopeStatsResult, error) {
+	return m.queryFunc(ctx, startTime, endTime, scope)
+}
+
+func testContext() context.Context {
+	return request.WithUser(context.Background(), &user.DefaultInfo{
+		Name: "test-user",
+		Extra: map[string][]string{
+			scope.ParentKindExtraKey: {"Project"},
+			scope.ParentNameExtraKey: {"prod"},
+		},
+	})
+}
+
+func TestStatsStorage_Create(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 500, time.UTC)
+	newest := time.Date(2026, 10, 16, 11, 59, 58, 0, time.UTC)
+
+	var gotStart, gotEnd time.Time
+	var gotScope storage.ScopeContext
+	s := NewStatsStorage(&mockStatsStorage{
+		queryFunc: func(ctx context.Context, startTime, endTime time.Time, scope storage.ScopeContext) (*storage.ScopeStatsResult, error) {
+			gotStart, gotEnd, gotScope = startTime, endTime, scope
+			return &storage.ScopeStatsResult{
+				AuditEventCount: 48210,
+				ActivityCount:   1312,
+				UniqueActors:    27,
+				TopResources: []storage.ScopeStatsResourceCount{
+					{APIGroup: "apps", Kind: "Deployment", Count: 402},
+					{Kind: "ConfigMap", Count: 120},
+				},
+				NewestAuditEventTime: newest,
+			}, nil
+		},
+	})
+	s.now = func() time.Time { return now }
+
+	obj, err := s.Create(testContext(), &v1alpha1.ScopeStats{}, nil, nil)
+	if err != nil {
+		t.Fatalf("Create() error = %v, want nil", err)
+	}
+
+	if gotScope.Type != "Project" || gotScope.Name != "prod" {
+		t.Errorf("scope = %+v, want Project/prod", gotScope)
+	}
+	if gotEnd.Sub(gotStart) != storage.ScopeStatsWindow {
+		t.Errorf("window = %v, want %v", gotEnd.Sub(gotStart), storage.ScopeStatsWindow)
+	}
+
+	status := obj.(*v1alpha1.ScopeStats).Status
+	if status.WindowStart != "2026-10-15T12:00:00Z" || status.WindowEnd != "2026-10-16T12:00:00Z" {
+		t.Errorf("window = %s to %s, want 2026-10-15T12:00:00Z to 2026-10-16T12:00:00Z", status.WindowStart, status.WindowEnd)
+	}
+	if status.AuditEventCount != 48210 || status.ActivityCount != 1312 || status.UniqueActors != 27 {
+		t.Errorf("counts = %+v", status)
+	}
+	if len(status.TopResources) != 2 || status.TopResources[0].Kind != "Deployment" || status.TopResources[0].APIGroup != "apps" {
+		t.Errorf("TopResources = %+v", status.TopResources)
+	}
+	if status.NewestAuditEventTime != "2026-10-16T11:59:58Z" {
+		t.Errorf("NewestAuditEventTime = %q, want 2026-10-16T11:59:58Z", status.NewestAuditEventTime)
+	}
+}
+
+func TestStatsStorage_Create_NoEvents(t *testing.T) {
+	s := NewStatsStorage(&mockStatsStorage{
+		queryFunc: func(ctx context.Context, startTime, endTime time.Time, scope storage.ScopeContext) (*storage.ScopeStatsResult, error) {
+			return &storage.ScopeStatsResult{}, nil
+		},
+	})
+
+	obj, err := s.Create(testContext(), &v1alpha1.ScopeStats{}, nil, nil)
+	if err != nil {
+		t.Fatalf("Create() error = %v, want nil", err)
+	}
+	if got := obj.(*v1alpha1.ScopeStats).Status.NewestAuditEventTime; got != "" {
+		t.Errorf("NewestAuditEventTime = %q, want empty", got)
+	}
+}
+
+func TestStatsStorage_Create_StorageError(t *testing.T) {
+	s := NewStatsStorage(&mockStatsStorage{
+		queryFunc: func(ctx context.Context, startTime, endTime time.Time, scope storage.ScopeContext) (*storage.ScopeStatsResult, error) {
+			return nil, errors.New("connection refused")
+		},
+	})
+
+	_, err := s.Create(testContext(), &v1alpha1.ScopeStats{}, nil, nil)
+	if !apierrors.IsServiceUnavailable(err) {
+		t.Fatalf("Create() error = %v, want ServiceUnavailable", err)
+	}
+}
+
+func TestStatsStorage_Create_QueryQueueTimeout(t *testing.T) {
+	s := NewStatsStorage(&mockStatsStorage{
+		queryFunc: func(ctx context.Context, startTime, endTime time.Time, scope storage.ScopeContext) (*storage.ScopeStatsResult, error) {
+			return nil, storage.ErrQueryQueueTimeout
+		},
+	})
+
+	_, err := s.Create(testContext(), &v1alpha1.ScopeStats{}, nil, nil)
+	if !apierrors.IsServiceUnavailable(err) {
+		t.Fatalf("Create() error = %v, want ServiceUnavailable", err)
+	}
+	if got := err.Error(); got != storage.QueryQueueTimeoutMessage {
+		t.Errorf("Create() error = %q, want %q", got, storage.QueryQueueTimeoutMessage)
+	}
+}
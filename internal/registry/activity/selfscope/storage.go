@@ -0,0 +1,79 @@
+package selfscope
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+
+	"go.miloapis.com/activity/internal/registry/scope"
+	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+)
+
+// SelfScopeStorage implements REST storage for SelfScope.
+// This is an ephemeral resource - it only supports Create operations and
+// reports the caller's resolved scope without persisting anything.
+type SelfScopeStorage struct{}
+
+// NewSelfScopeStorage creates a new REST storage for SelfScope.
+func NewSelfScopeStorage() *SelfScopeStorage {
+	return &SelfScopeStorage{}
+}
+
+var (
+	_ rest.Scoper               = &SelfScopeStorage{}
+	_ rest.Creater              = &SelfScopeStorage{}
+	_ rest.Storage              = &SelfScopeStorage{}
+	_ rest.SingularNameProvider = &SelfScopeStorage{}
+)
+
+// New returns an empty SelfScope.
+func (s *SelfScopeStorage) New() runtime.Object {
+	return &v1alpha1.SelfScope{}
+}
+
+// Destroy cleans up resources.
+func (s *SelfScopeStorage) Destroy() {}
+
+// NamespaceScoped returns false because SelfScope is cluster-scoped.
+func (s *SelfScopeStorage) NamespaceScoped() bool {
+	return false
+}
+
+// GetSingularName returns the singular name of the resource.
+func (s *SelfScopeStorage) GetSingularName() string {
+	return "selfscope"
+}
+
+// Create resolves the caller's scope with the same logic the query resources
+// use, so the answer always matches what their queries see.
+func (s *SelfScopeStorage) Create(ctx context.Context, obj runtime.Object, createValidation rest.ValidateObjectFunc, options *metav1.CreateOptions) (runtime.Object, error) {
+	selfScope, ok := obj.(*v1alpha1.SelfScope)
+	if !ok {
+		return nil, errors.NewBadRequest("expected SelfScope object")
+	}
+
+	reqUser, ok := request.UserFrom(ctx)
+	if !ok {
+		return nil, errors.NewInternalError(fmt.Errorf("no user in context"))
+	}
+	scopeCtx := scope.ExtractScopeFromUser(reqUser)
+
+	response := selfScope.DeepCopy()
+	response.Status = v1alpha1.SelfScopeStatus{
+		Username:  reqUser.GetName(),
+		ScopeType: scopeCtx.Type,
+		ScopeName: scopeCtx.Name,
+	}
+
+	return response, nil
+}
+
+// ConvertToTable converts to table format.
+func (s *SelfScopeStorage) ConvertToTable(ctx context.Context, object runtime.Object, tableOptions runtime.Object) (*metav1.Table, error) {
+	return rest.NewDefaultTableConvertor(v1alpha1.Resource("selfscopes")).ConvertToTable(ctx, object, tableOptions)
+}
@@ -0,0 +1,82 @@
+package selfscope
+
+import (
+	"context"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
+
+	"go.miloapis.com/activity/internal/registry/scope"
+	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+)
+
+func TestSelfScopeStorage_Create(t *testing.T) {
+	tests := []struct {
+		name     string
+		user     user.Info
+		wantType string
+		wantName string
+		wantUser string
+	}{
+		{
+			name: "project scope",
+			user: &user.DefaultInfo{
+				Name: "alice@example.com",
+				Extra: map[string][]string{
+					scope.ParentKindExtraKey: {"Project"},
+					scope.ParentNameExtraKey: {"backend-api"},
+				},
+			},
+			wantType: "Project",
+			wantName: "backend-api",
+			wantUser: "alice@example.com",
+		},
+		{
+			name: "user scope uses the UID",
+			user: &user.DefaultInfo{
+				Name: "bob@example.com",
+				Extra: map[string][]string{
+					scope.ParentKindExtraKey: {"User"},
+					scope.ParentNameExtraKey: {"uid-123"},
+				},
+			},
+			wantType: "User",
+			wantName: "uid-123",
+			wantUser: "bob@example.com",
+		},
+		{
+			name:     "no parent resource is platform scope",
+			user:     &user.DefaultInfo{Name: "admin@example.com", Groups: []string{"system:masters"}},
+			wantType: "platform",
+			wantUser: "admin@example.com",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := request.WithUser(context.Background(), tt.user)
+
+			obj, err := NewSelfScopeStorage().Create(ctx, &v1alpha1.SelfScope{}, nil, nil)
+			if err != nil {
+				t.Fatalf("Create() error = %v, want nil", err)
+			}
+
+			status := obj.(*v1alpha1.SelfScope).Status
+			if status.ScopeType != tt.wantType || status.ScopeName != tt.wantName {
+				t.Errorf("scope = %s/%s, want %s/%s", status.ScopeType, status.ScopeName, tt.wantType, tt.wantName)
+			}
+			if status.Username != tt.wantUser {
+				t.Errorf("Username = %q, want %q", status.Username, tt.wantUser)
+			}
+		})
+	}
+}
+
+func TestSelfScopeStorage_Create_NoUser(t *testing.T) {
+	_, err := NewSelfScopeStorage().Create(context.Background(), &v1alpha1.SelfScope{}, nil, nil)
+	if !apierrors.IsInternalError(err) {
+		t.Fatalf("Create() error = %v, want InternalError", err)
+	}
+}
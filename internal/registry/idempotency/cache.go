@@ -0,0 +1,141 @@
+package idempotency
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Cache remembers the results of recent query creates by idempotency key so a
+// retried request gets the earlier result instead of re-running the query.
+// Entries expire after the window and the cache holds at most maxEntries
+// results, evicting the oldest first. A nil Cache caches nothing.
+type Cache struct {
+	window     time.Duration
+	maxEntries int
+	now        func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*entry
+	// order lists keys oldest first for eviction
+	order []string
+}
+
+// entry is one cached create. done is closed once the create finishes, so a
+// retry that arrives while the original is still running waits for it.
+type entry struct {
+	bodyHash string
+	created  time.Time
+	done     chan struct{}
+	result   runtime.Object
+	err      error
+}
+
+// NewCache returns a cache that keeps results for window, or nil when window
+// or maxEntries is not positive, which disables idempotency.
+func NewCache(window time.Duration, maxEntries int) *Cache {
+	if window <= 0 || maxEntries <= 0 {
+		return nil
+	}
+	return &Cache{
+		window:     window,
+		maxEntries: maxEntries,
+		now:        time.Now,
+		entries:    make(map[string]*entry),
+	}
+}
+
+// Do returns the cached result for key, or runs create and caches what it
+// returns. The boolean reports whether the result came from the cache.
+// bodyHash identifies the request body; reusing a key with a different body
+// returns errKeyReused. Failed creates are not cached: a retry waiting on one
+// runs create itself, since the failure may have been the very network blip it
+// is retrying.
+func (c *Cache) Do(ctx context.Context, key, bodyHash string, create func() (runtime.Object, error)) (runtime.Object, bool, error) {
+	for {
+		c.mu.Lock()
+		c.expireLocked()
+		e, ok := c.entries[key]
+		if !ok {
+			break
+		}
+		c.mu.Unlock()
+
+		if e.bodyHash != bodyHash {
+			return nil, false, errKeyReused
+		}
+		select {
+		case <-e.done:
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		}
+		if e.err == nil {
+			return e.result.DeepCopyObject(), true, nil
+		}
+	}
+
+	e := &entry{bodyHash: bodyHash, created: c.now(), done: make(chan struct{})}
+	c.entries[key] = e
+	c.order = append(c.order, key)
+	c.evictLocked()
+	c.mu.Unlock()
+
+	e.result, e.err = create()
+	if e.err != nil {
+		c.mu.Lock()
+		if c.entries[key] == e {
+			c.removeLocked(key)
+		}
+		c.mu.Unlock()
+	}
+	close(e.done)
+
+	if e.err != nil {
+		return nil, false, e.err
+	}
+	return e.result.DeepCopyObject(), false, nil
+}
+
+// expireLocked drops entries older than the window. Keys are appended in
+// creation order, so it stops at the first entry still inside the window.
+func (c *Cache) expireLocked() {
+	cutoff := c.now().Add(-c.window)
+	for len(c.order) > 0 {
+		e, ok := c.entries[c.order[0]]
+		if ok && e.created.After(cutoff) {
+			return
+		}
+		if ok {
+			delete(c.entries, c.order[0])
+		}
+		c.order = c.order[1:]
+	}
+}
+
+// evictLocked drops the oldest entries until the cache is within maxEntries.
+func (c *Cache) evictLocked() {
+	for len(c.entries) > c.maxEntries && len(c.order) > 0 {
+		delete(c.entries, c.order[0])
+		c.order = c.order[1:]
+	}
+}
+
+// removeLocked drops key from the cache.
+func (c *Cache) removeLocked(key string) {
+	delete(c.entries, key)
+	for i, k := range c.order {
+		if k == key {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			return
+		}
+	}
+}
+
+// Len returns the number of cached results.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
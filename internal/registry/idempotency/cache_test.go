@@ -0,0 +1,143 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+)
+
+func queryResult(cont string) func() (runtime.Object, error) {
+	return func() (runtime.Object, error) {
+		return &v1alpha1.AuditLogQuery{Status: v1alpha1.AuditLogQueryStatus{Continue: cont}}, nil
+	}
+}
+
+func TestNewCacheDisabled(t *testing.T) {
+	assert.Nil(t, NewCache(0, 10))
+	assert.Nil(t, NewCache(time.Minute, 0))
+}
+
+func TestCacheDo(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("repeat returns the cached result", func(t *testing.T) {
+		c := NewCache(time.Minute, 10)
+		calls := 0
+		create := func() (runtime.Object, error) {
+			calls++
+			return queryResult("page-2")()
+		}
+
+		_, hit, err := c.Do(ctx, "k", "body", create)
+		require.NoError(t, err)
+		assert.False(t, hit)
+
+		result, hit, err := c.Do(ctx, "k", "body", create)
+		require.NoError(t, err)
+		assert.True(t, hit)
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, "page-2", result.(*v1alpha1.AuditLogQuery).Status.Continue)
+	})
+
+	t.Run("callers get independent copies", func(t *testing.T) {
+		c := NewCache(time.Minute, 10)
+		first, _, err := c.Do(ctx, "k", "body", queryResult("page-2"))
+		require.NoError(t, err)
+		first.(*v1alpha1.AuditLogQuery).Status.Continue = "changed"
+
+		second, _, err := c.Do(ctx, "k", "body", queryResult("other"))
+		require.NoError(t, err)
+		assert.Equal(t, "page-2", second.(*v1alpha1.AuditLogQuery).Status.Continue)
+	})
+
+	t.Run("reusing a key with a different body is rejected", func(t *testing.T) {
+		c := NewCache(time.Minute, 10)
+		_, _, err := c.Do(ctx, "k", "body", queryResult(""))
+		require.NoError(t, err)
+
+		_, _, err = c.Do(ctx, "k", "other-body", queryResult(""))
+		assert.Equal(t, errKeyReused, err)
+	})
+
+	t.Run("failures are not cached", func(t *testing.T) {
+		c := NewCache(time.Minute, 10)
+		_, _, err := c.Do(ctx, "k", "body", func() (runtime.Object, error) {
+			return nil, errors.New("clickhouse unavailable")
+		})
+		require.Error(t, err)
+		assert.Equal(t, 0, c.Len())
+
+		_, hit, err := c.Do(ctx, "k", "body", queryResult(""))
+		require.NoError(t, err)
+		assert.False(t, hit)
+	})
+
+	t.Run("entries expire after the window", func(t *testing.T) {
+		c := NewCache(time.Minute, 10)
+		now := time.Now()
+		c.now = func() time.Time { return now }
+
+		_, _, err := c.Do(ctx, "k", "body", queryResult(""))
+		require.NoError(t, err)
+
+		now = now.Add(2 * time.Minute)
+		_, hit, err := c.Do(ctx, "k", "body", queryResult(""))
+		require.NoError(t, err)
+		assert.False(t, hit)
+	})
+
+	t.Run("oldest entries are evicted past the bound", func(t *testing.T) {
+		c := NewCache(time.Minute, 2)
+		for i := 0; i < 3; i++ {
+			_, _, err := c.Do(ctx, fmt.Sprintf("k%d", i), "body", queryResult(""))
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 2, c.Len())
+
+		_, hit, err := c.Do(ctx, "k0", "body", queryResult(""))
+		require.NoError(t, err)
+		assert.False(t, hit, "k0 should have been evicted")
+	})
+
+	t.Run("a retry during the original waits for its result", func(t *testing.T) {
+		c := NewCache(time.Minute, 10)
+		started := make(chan struct{})
+		release := make(chan struct{})
+		calls := 0
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, _ = c.Do(ctx, "k", "body", func() (runtime.Object, error) {
+				calls++
+				close(started)
+				<-release
+				return queryResult("page-2")()
+			})
+		}()
+
+		<-started
+		done := make(chan struct{})
+		var hit bool
+		go func() {
+			defer close(done)
+			_, hit, _ = c.Do(ctx, "k", "body", queryResult("other"))
+		}()
+		close(release)
+		wg.Wait()
+		<-done
+
+		assert.True(t, hit)
+		assert.Equal(t, 1, calls)
+	})
+}
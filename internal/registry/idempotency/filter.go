@@ -0,0 +1,53 @@
+package idempotency
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+
+	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+)
+
+// MaxKeyLength bounds the Idempotency-Key header so keys can't be used to
+// bloat the result cache.
+const MaxKeyLength = 256
+
+type keyContextKey struct{}
+
+// WithIdempotencyKey copies the Idempotency-Key header of create requests into
+// the request context, where the query storages wrapped by Wrap pick it up.
+// Other requests pass through untouched.
+func WithIdempotencyKey(handler http.Handler, s runtime.NegotiatedSerializer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		key := req.Header.Get(v1alpha1.IdempotencyKeyHeader)
+		if key == "" || req.Method != http.MethodPost {
+			handler.ServeHTTP(w, req)
+			return
+		}
+
+		if len(key) > MaxKeyLength {
+			err := apierrors.NewBadRequest(fmt.Sprintf("%s must be at most %d characters", v1alpha1.IdempotencyKeyHeader, MaxKeyLength))
+			responsewriters.ErrorNegotiated(err, s, schema.GroupVersion{}, w, req)
+			return
+		}
+
+		handler.ServeHTTP(w, req.WithContext(WithKey(req.Context(), key)))
+	})
+}
+
+// WithKey returns a copy of ctx carrying an idempotency key.
+func WithKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, keyContextKey{}, key)
+}
+
+// KeyFrom returns the idempotency key of the request, or "" when the caller
+// didn't send one.
+func KeyFrom(ctx context.Context) string {
+	key, _ := ctx.Value(keyContextKey{}).(string)
+	return key
+}
@@ -0,0 +1,50 @@
+package idempotency
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+
+	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+)
+
+func TestWithIdempotencyKey(t *testing.T) {
+	codecs := serializer.NewCodecFactory(runtime.NewScheme())
+
+	tests := []struct {
+		name     string
+		method   string
+		key      string
+		wantCode int
+		wantKey  string
+	}{
+		{name: "create with a key", method: http.MethodPost, key: "retry-1", wantCode: http.StatusOK, wantKey: "retry-1"},
+		{name: "create without a key", method: http.MethodPost, wantCode: http.StatusOK},
+		{name: "key ignored on other verbs", method: http.MethodGet, key: "retry-1", wantCode: http.StatusOK},
+		{name: "oversized key rejected", method: http.MethodPost, key: strings.Repeat("k", MaxKeyLength+1), wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotKey string
+			handler := WithIdempotencyKey(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				gotKey = KeyFrom(req.Context())
+			}), codecs)
+
+			req := httptest.NewRequest(tt.method, "/apis/activity.miloapis.com/v1alpha1/auditlogqueries", nil)
+			if tt.key != "" {
+				req.Header.Set(v1alpha1.IdempotencyKeyHeader, tt.key)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantCode, rec.Code)
+			assert.Equal(t, tt.wantKey, gotKey)
+		})
+	}
+}
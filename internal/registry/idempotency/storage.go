@@ -0,0 +1,99 @@
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+
+	"go.miloapis.com/activity/internal/metrics"
+	"go.miloapis.com/activity/internal/registry/scope"
+	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+)
+
+var errKeyReused = fmt.Errorf("%s was already used for a different request. Use a new key for each distinct query", v1alpha1.IdempotencyKeyHeader)
+
+// QueryStorage is the REST storage of an ephemeral query resource, which only
+// supports Create.
+type QueryStorage interface {
+	rest.Storage
+	rest.Scoper
+	rest.Creater
+	rest.SingularNameProvider
+}
+
+// REST wraps a query resource's storage so creates carrying an Idempotency-Key
+// are answered from the cache when the same caller repeats them.
+type REST struct {
+	QueryStorage
+	resource string
+	cache    *Cache
+}
+
+var _ rest.TableConvertor = &REST{}
+
+// Wrap returns storage with idempotent creates, or storage itself when cache
+// is nil.
+func Wrap(resource string, storage QueryStorage, cache *Cache) rest.Storage {
+	if cache == nil {
+		return storage
+	}
+	return &REST{QueryStorage: storage, resource: resource, cache: cache}
+}
+
+// Create runs the wrapped create, or returns the cached result of an earlier
+// create with the same key. Cache entries are keyed on the caller and the scope
+// their queries are limited to as well as the key, so a result is never
+// returned to a different user or tenant.
+func (r *REST) Create(ctx context.Context, obj runtime.Object, createValidation rest.ValidateObjectFunc, options *metav1.CreateOptions) (runtime.Object, error) {
+	key := KeyFrom(ctx)
+	if key == "" {
+		return r.QueryStorage.Create(ctx, obj, createValidation, options)
+	}
+
+	reqUser, ok := request.UserFrom(ctx)
+	if !ok {
+		return nil, apierrors.NewInternalError(fmt.Errorf("no user in context"))
+	}
+	scopeCtx := scope.ExtractScopeFromUser(reqUser)
+
+	body, err := json.Marshal(obj)
+	if err != nil {
+		return nil, apierrors.NewInternalError(fmt.Errorf("failed to hash request: %w", err))
+	}
+	bodyHash := sha256.Sum256(body)
+
+	cacheKey := strings.Join([]string{r.resource, scopeCtx.Type, scopeCtx.Name, reqUser.GetName(), key}, "\x00")
+	result, hit, err := r.cache.Do(ctx, cacheKey, hex.EncodeToString(bodyHash[:]), func() (runtime.Object, error) {
+		return r.QueryStorage.Create(ctx, obj, createValidation, options)
+	})
+	if err == errKeyReused {
+		return nil, apierrors.NewConflict(v1alpha1.Resource(r.resource), "", err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	outcome := "miss"
+	if hit {
+		outcome = "hit"
+	}
+	metrics.IdempotentQueryCreates.WithLabelValues(r.resource, outcome).Inc()
+	return result, nil
+}
+
+// ConvertToTable uses the wrapped storage's table conversion when it has one.
+func (r *REST) ConvertToTable(ctx context.Context, object runtime.Object, tableOptions runtime.Object) (*metav1.Table, error) {
+	if convertor, ok := r.QueryStorage.(rest.TableConvertor); ok {
+		return convertor.ConvertToTable(ctx, object, tableOptions)
+	}
+	return rest.NewDefaultTableConvertor(v1alpha1.Resource(r.resource)).ConvertToTable(ctx, object, tableOptions)
+}
@@ -0,0 +1,86 @@
+package idempotency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+
+	"go.miloapis.com/activity/internal/registry/scope"
+	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+)
+
+// countingStorage is a query storage that counts how often it runs.
+type countingStorage struct {
+	calls int
+}
+
+func (s *countingStorage) New() runtime.Object     { return &v1alpha1.AuditLogQuery{} }
+func (s *countingStorage) Destroy()                {}
+func (s *countingStorage) NamespaceScoped() bool   { return false }
+func (s *countingStorage) GetSingularName() string { return "auditlogquery" }
+
+func (s *countingStorage) Create(ctx context.Context, obj runtime.Object, _ rest.ValidateObjectFunc, _ *metav1.CreateOptions) (runtime.Object, error) {
+	s.calls++
+	query := obj.(*v1alpha1.AuditLogQuery)
+	query.Status.Continue = "page-2"
+	return query, nil
+}
+
+func tenantUser(name, org string) user.Info {
+	return &user.DefaultInfo{
+		Name: name,
+		Extra: map[string][]string{
+			scope.ParentKindExtraKey: {"Organization"},
+			scope.ParentNameExtraKey: {org},
+		},
+	}
+}
+
+func TestWrap(t *testing.T) {
+	inner := &countingStorage{}
+	assert.Same(t, inner, Wrap("auditlogqueries", inner, nil), "a nil cache should leave the storage unwrapped")
+
+	store := Wrap("auditlogqueries", inner, NewCache(time.Minute, 10)).(*REST)
+	create := func(u user.Info, key, filter string) (runtime.Object, error) {
+		ctx := request.WithUser(context.Background(), u)
+		if key != "" {
+			ctx = WithKey(ctx, key)
+		}
+		query := &v1alpha1.AuditLogQuery{Spec: v1alpha1.AuditLogQuerySpec{Filter: filter}}
+		return store.Create(ctx, query, nil, &metav1.CreateOptions{})
+	}
+
+	alice := tenantUser("alice", "acme")
+
+	_, err := create(alice, "retry-1", "verb == 'delete'")
+	require.NoError(t, err)
+	result, err := create(alice, "retry-1", "verb == 'delete'")
+	require.NoError(t, err)
+	assert.Equal(t, 1, inner.calls, "the retry should be served from the cache")
+	assert.Equal(t, "page-2", result.(*v1alpha1.AuditLogQuery).Status.Continue)
+
+	_, err = create(alice, "", "verb == 'delete'")
+	require.NoError(t, err)
+	assert.Equal(t, 2, inner.calls, "requests without a key always run")
+
+	_, err = create(tenantUser("alice", "other-org"), "retry-1", "verb == 'delete'")
+	require.NoError(t, err)
+	assert.Equal(t, 3, inner.calls, "the same key in another scope must not share results")
+
+	_, err = create(tenantUser("bob", "acme"), "retry-1", "verb == 'delete'")
+	require.NoError(t, err)
+	assert.Equal(t, 4, inner.calls, "the same key from another user must not share results")
+
+	_, err = create(alice, "retry-1", "verb == 'create'")
+	require.Error(t, err)
+	assert.True(t, apierrors.IsConflict(err), "reusing a key for a different query should conflict, got %v", err)
+}
@@ -0,0 +1,98 @@
+package scope
+
+import (
+	"fmt"
+	"net/http"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/klog/v2"
+
+	"go.miloapis.com/activity/internal/types"
+	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+)
+
+// WithScopeOverride applies the scope override headers to the authenticated
+// user. The override is written into the user's parent extras so every
+// registry resolves it exactly like a tenant-scoped token.
+//
+// Only platform-scoped callers may override their scope. They can already read
+// every tenant's data, so an override only ever narrows what they see. Tenant
+// callers are rejected with 403 Forbidden rather than silently ignored.
+//
+// The filter must run after authentication and authorization so RBAC is
+// evaluated against the caller's real identity.
+func WithScopeOverride(handler http.Handler, s runtime.NegotiatedSerializer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		kind := req.Header.Get(v1alpha1.ScopeKindHeader)
+		name := req.Header.Get(v1alpha1.ScopeNameHeader)
+		if kind == "" && name == "" {
+			handler.ServeHTTP(w, req)
+			return
+		}
+
+		ctx := req.Context()
+		u, ok := request.UserFrom(ctx)
+		if !ok {
+			responsewriters.InternalError(w, req, fmt.Errorf("no user in context"))
+			return
+		}
+
+		overridden, err := applyScopeOverride(u, kind, name)
+		if err != nil {
+			responsewriters.ErrorNegotiated(err, s, schema.GroupVersion{}, w, req)
+			return
+		}
+
+		klog.InfoS("Applying scope override",
+			"user", u.GetName(),
+			"scopeType", kind,
+			"scopeName", name,
+		)
+
+		handler.ServeHTTP(w, req.WithContext(request.WithUser(ctx, overridden)))
+	})
+}
+
+// applyScopeOverride returns a copy of u scoped to the requested tenant, or a
+// status error when the override is malformed or the caller may not use it.
+func applyScopeOverride(u user.Info, kind, name string) (user.Info, error) {
+	if kind == "" || name == "" {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("%s and %s must be set together", v1alpha1.ScopeKindHeader, v1alpha1.ScopeNameHeader))
+	}
+
+	switch kind {
+	case types.TenantTypeOrganization, types.TenantTypeProject, types.TenantTypeUser:
+	default:
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("unsupported scope kind %q. Use %s, %s, or %s",
+			kind, types.TenantTypeOrganization, types.TenantTypeProject, types.TenantTypeUser))
+	}
+
+	current := ExtractScopeFromUser(u)
+	if current.Type == types.TenantTypeNone {
+		return nil, apierrors.NewForbidden(schema.GroupResource{}, "",
+			fmt.Errorf("scope overrides are only available to platform administrators; your requests have no scope"))
+	}
+	if current.Type != types.TenantTypePlatform {
+		return nil, apierrors.NewForbidden(schema.GroupResource{}, "",
+			fmt.Errorf("scope overrides are only available to platform administrators; your requests are already scoped to %s %q", current.Type, current.Name))
+	}
+
+	extra := make(map[string][]string, len(u.GetExtra())+2)
+	for k, v := range u.GetExtra() {
+		extra[k] = v
+	}
+	extra[ParentKindExtraKey] = []string{kind}
+	extra[ParentNameExtraKey] = []string{name}
+
+	return &user.DefaultInfo{
+		Name:   u.GetName(),
+		UID:    u.GetUID(),
+		Groups: u.GetGroups(),
+		Extra:  extra,
+	}, nil
+}
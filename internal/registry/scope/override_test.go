@@ -0,0 +1,131 @@
+package scope
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
+
+	"go.miloapis.com/activity/internal/storage"
+	"go.miloapis.com/activity/internal/types"
+	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+)
+
+func TestWithScopeOverride(t *testing.T) {
+	platformAdmin := &user.DefaultInfo{
+		Name:   "admin@example.com",
+		Groups: []string{"system:masters"},
+		Extra:  map[string][]string{"other": {"value"}},
+	}
+	tenantUser := &user.DefaultInfo{
+		Name: "alice@example.com",
+		Extra: map[string][]string{
+			ParentKindExtraKey: {"Organization"},
+			ParentNameExtraKey: {"acme-corp"},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		user      user.Info
+		kind      string
+		scopeName string
+		wantCode  int
+		wantScope storage.ScopeContext
+	}{
+		{
+			name:      "no headers keeps credential scope",
+			user:      tenantUser,
+			wantCode:  http.StatusOK,
+			wantScope: storage.ScopeContext{Type: types.TenantTypeOrganization, Name: "acme-corp"},
+		},
+		{
+			name:      "platform admin targets a project",
+			user:      platformAdmin,
+			kind:      "Project",
+			scopeName: "backend-api",
+			wantCode:  http.StatusOK,
+			wantScope: storage.ScopeContext{Type: types.TenantTypeProject, Name: "backend-api"},
+		},
+		{
+			name:      "platform admin targets a user",
+			user:      platformAdmin,
+			kind:      "User",
+			scopeName: "550e8400-e29b-41d4-a716-446655440000",
+			wantCode:  http.StatusOK,
+			wantScope: storage.ScopeContext{Type: types.TenantTypeUser, Name: "550e8400-e29b-41d4-a716-446655440000"},
+		},
+		{
+			name:      "tenant user is forbidden",
+			user:      tenantUser,
+			kind:      "Organization",
+			scopeName: "other-corp",
+			wantCode:  http.StatusForbidden,
+		},
+		{
+			name:     "kind without name is rejected",
+			user:     platformAdmin,
+			kind:     "Project",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:      "unknown kind is rejected",
+			user:      platformAdmin,
+			kind:      "Cluster",
+			scopeName: "prod",
+			wantCode:  http.StatusBadRequest,
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	codecs := serializer.NewCodecFactory(scheme)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotScope storage.ScopeContext
+			var gotUser user.Info
+			handler := WithScopeOverride(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				gotUser, _ = request.UserFrom(req.Context())
+				gotScope = ExtractScopeFromUser(gotUser)
+			}), codecs)
+
+			req := httptest.NewRequest(http.MethodPost, "/apis/activity.miloapis.com/v1alpha1/auditlogqueries", nil)
+			req = req.WithContext(request.WithUser(req.Context(), tt.user))
+			if tt.kind != "" {
+				req.Header.Set(v1alpha1.ScopeKindHeader, tt.kind)
+			}
+			if tt.scopeName != "" {
+				req.Header.Set(v1alpha1.ScopeNameHeader, tt.scopeName)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d (body: %s)", rec.Code, tt.wantCode, rec.Body.String())
+			}
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			if gotScope != tt.wantScope {
+				t.Errorf("scope = %+v, want %+v", gotScope, tt.wantScope)
+			}
+			if gotUser.GetName() != tt.user.GetName() {
+				t.Errorf("user = %q, want %q", gotUser.GetName(), tt.user.GetName())
+			}
+		})
+	}
+
+	t.Run("override does not modify the caller's extras", func(t *testing.T) {
+		if _, err := applyScopeOverride(platformAdmin, "Project", "backend-api"); err != nil {
+			t.Fatalf("applyScopeOverride() error = %v", err)
+		}
+		if _, ok := platformAdmin.Extra[ParentKindExtraKey]; ok {
+			t.Error("expected original user extras to be left untouched")
+		}
+	})
+}
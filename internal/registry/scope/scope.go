@@ -1,6 +1,9 @@
 package scope
 
 import (
+	"fmt"
+	"sync/atomic"
+
 	"k8s.io/apiserver/pkg/authentication/user"
 
 	"go.miloapis.com/activity/internal/storage"
@@ -14,8 +17,54 @@ const (
 	ParentNameExtraKey     = "iam.miloapis.com/parent-name"
 )
 
+const (
+	// DefaultScopePlatform gives requests without tenant extras platform-wide
+	// scope, so they see every tenant's data.
+	DefaultScopePlatform = "platform"
+
+	// DefaultScopeDeny gives requests without tenant extras an empty scope, so
+	// their queries return nothing.
+	DefaultScopeDeny = "deny"
+)
+
+var denyUnscoped atomic.Bool
+
+// DefaultScope returns the process-wide scope for requests without tenant
+// extras: DefaultScopePlatform or DefaultScopeDeny.
+func DefaultScope() string {
+	if denyUnscoped.Load() {
+		return DefaultScopeDeny
+	}
+	return DefaultScopePlatform
+}
+
+// SetDefaultScope replaces the process-wide scope for requests without tenant
+// extras. It returns an error for anything but DefaultScopePlatform or
+// DefaultScopeDeny.
+func SetDefaultScope(mode string) error {
+	switch mode {
+	case DefaultScopePlatform:
+		denyUnscoped.Store(false)
+	case DefaultScopeDeny:
+		denyUnscoped.Store(true)
+	default:
+		return fmt.Errorf("unknown default scope %q: must be %s or %s", mode, DefaultScopePlatform, DefaultScopeDeny)
+	}
+	return nil
+}
+
+// Unscoped returns the scope for a request without tenant extras, or with a
+// parent kind that isn't a tenant type.
+func Unscoped() storage.ScopeContext {
+	if denyUnscoped.Load() {
+		return storage.ScopeContext{Type: types.TenantTypeNone, Name: ""}
+	}
+	return storage.ScopeContext{Type: types.TenantTypePlatform, Name: ""}
+}
+
 // ExtractScopeFromUser determines the query scope from user authentication metadata.
-// Defaults to platform-wide scope when no parent resource is specified.
+// Falls back to Unscoped when no parent resource is specified: platform-wide
+// scope, or no scope at all when the default scope is deny.
 //
 // The returned Type uses Kubernetes Kind naming convention (PascalCase) to match
 // how tenant types are stored by the activity processor.
@@ -24,14 +73,14 @@ const (
 // querying all activity performed by that user across all organizations and projects.
 func ExtractScopeFromUser(u user.Info) storage.ScopeContext {
 	if u.GetExtra() == nil {
-		return storage.ScopeContext{Type: types.TenantTypePlatform, Name: ""}
+		return Unscoped()
 	}
 
 	parentKind := u.GetExtra()[ParentKindExtraKey]
 	parentName := u.GetExtra()[ParentNameExtraKey]
 
 	if len(parentKind) == 0 || len(parentName) == 0 {
-		return storage.ScopeContext{Type: types.TenantTypePlatform, Name: ""}
+		return Unscoped()
 	}
 
 	switch parentKind[0] {
@@ -42,6 +91,6 @@ func ExtractScopeFromUser(u user.Info) storage.ScopeContext {
 	case "User":
 		return storage.ScopeContext{Type: types.TenantTypeUser, Name: parentName[0]}
 	default:
-		return storage.ScopeContext{Type: types.TenantTypePlatform, Name: ""}
+		return Unscoped()
 	}
 }
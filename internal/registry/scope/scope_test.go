@@ -106,3 +106,77 @@ func TestExtractScopeFromUser(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractScopeFromUser_DefaultScopeDeny(t *testing.T) {
+	if err := SetDefaultScope(DefaultScopeDeny); err != nil {
+		t.Fatalf("SetDefaultScope() error = %v", err)
+	}
+	t.Cleanup(func() { _ = SetDefaultScope(DefaultScopePlatform) })
+
+	tests := []struct {
+		name     string
+		user     user.Info
+		expected storage.ScopeContext
+	}{
+		{
+			name: "organization scope is unaffected",
+			user: &user.DefaultInfo{
+				Extra: map[string][]string{
+					ParentKindExtraKey: {"Organization"},
+					ParentNameExtraKey: {"acme-corp"},
+				},
+			},
+			expected: storage.ScopeContext{Type: types.TenantTypeOrganization, Name: "acme-corp"},
+		},
+		{
+			name:     "no scope",
+			user:     &user.DefaultInfo{Name: "test-user"},
+			expected: storage.ScopeContext{Type: types.TenantTypeNone, Name: ""},
+		},
+		{
+			name: "missing parent name",
+			user: &user.DefaultInfo{
+				Extra: map[string][]string{
+					ParentKindExtraKey: {"Organization"},
+				},
+			},
+			expected: storage.ScopeContext{Type: types.TenantTypeNone, Name: ""},
+		},
+		{
+			name: "unknown parent kind",
+			user: &user.DefaultInfo{
+				Extra: map[string][]string{
+					ParentKindExtraKey: {"UnknownType"},
+					ParentNameExtraKey: {"some-name"},
+				},
+			},
+			expected: storage.ScopeContext{Type: types.TenantTypeNone, Name: ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ExtractScopeFromUser(tt.user)
+			if result != tt.expected {
+				t.Errorf("got %+v, want %+v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSetDefaultScope(t *testing.T) {
+	t.Cleanup(func() { _ = SetDefaultScope(DefaultScopePlatform) })
+
+	if err := SetDefaultScope("none"); err == nil {
+		t.Error("SetDefaultScope(\"none\") error = nil, want an error")
+	}
+	if got := DefaultScope(); got != DefaultScopePlatform {
+		t.Errorf("DefaultScope() = %q after a rejected value, want %q", got, DefaultScopePlatform)
+	}
+	if err := SetDefaultScope(DefaultScopeDeny); err != nil {
+		t.Fatalf("SetDefaultScope() error = %v", err)
+	}
+	if got := DefaultScope(); got != DefaultScopeDeny {
+		t.Errorf("DefaultScope() = %q, want %q", got, DefaultScopeDeny)
+	}
+}
@@ -149,7 +149,7 @@ func (r *Reindexer) evaluateAuditBatch(ctx context.Context, batch []*auditv1.Eve
 
 		// Try each policy (first match wins)
 		for _, policy := range compiledPolicies {
-			activity, _, err := activityprocessor.EvaluateCompiledAuditRules(policy, auditMap, audit, r.kindResolver)
+			activity, _, err := activityprocessor.EvaluateCompiledAuditRules(policy, auditMap, audit, r.kindResolver, processor.DefaultMaxSummaryLength)
 			if err != nil {
 				klog.ErrorS(err, "Failed to evaluate compiled audit rules",
 					"policy", policy.Name,
@@ -220,7 +220,8 @@ func (r *Reindexer) evaluateEventBatch(ctx context.Context, batch []map[string]i
 			APIGroup: matched.APIGroup,
 			Kind:     matched.Kind,
 		}
-		activity, err := builder.BuildFromEvent(eventMap, matched.Summary, matched.Links, r.kindResolver)
+		summary := processor.LimitSummary(matched.Summary, processor.DefaultMaxSummaryLength, matched.PolicyName, matched.RuleIndex)
+		activity, err := builder.BuildFromEvent(eventMap, summary, matched.Links, r.kindResolver)
 		if err != nil {
 			klog.ErrorS(err, "Failed to build activity from event match",
 				"policy", matched.PolicyName,
@@ -230,6 +231,7 @@ func (r *Reindexer) evaluateEventBatch(ctx context.Context, batch []map[string]i
 		}
 
 		if activity != nil {
+			processor.SetPolicyOrigin(activity, matched.PolicyName, matched.RuleIndex)
 			if activity.Labels == nil {
 				activity.Labels = make(map[string]string)
 			}
@@ -0,0 +1,197 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/klog/v2"
+
+	"go.miloapis.com/activity/internal/cel"
+	"go.miloapis.com/activity/internal/types"
+)
+
+// MaxActivityMetricsPoints caps the number of (bucket, series) rows a single
+// metrics query may return. High-cardinality group-bys over long windows are
+// rejected rather than silently truncated, since a partial time series would
+// be misleading on a dashboard.
+const MaxActivityMetricsPoints = 10000
+
+// ErrTooManyMetricsPoints is returned when a metrics query would produce more
+// than MaxActivityMetricsPoints rows.
+var ErrTooManyMetricsPoints = errors.New("the query produced too many data points")
+
+// ActivityMetricsQuerySpec defines the parameters for an activity metrics query.
+type ActivityMetricsQuerySpec struct {
+	// StartTime and EndTime bound the query window. Both are resolved times;
+	// StartTime is expected to be aligned to a bucket boundary.
+	StartTime time.Time
+	EndTime   time.Time
+
+	// BucketSeconds is the width of each time bucket.
+	BucketSeconds int64
+
+	// GroupBy is the dimension to split series on. Empty returns a single "total" series.
+	GroupBy string
+
+	// Filter is a CEL expression to filter activities before counting.
+	Filter string
+}
+
+// ActivityMetricsResult contains the aggregated counts of a metrics query.
+type ActivityMetricsResult struct {
+	Points []ActivityMetricsPointResult
+}
+
+// ActivityMetricsPointResult is a single count for one bucket and series.
+type ActivityMetricsPointResult struct {
+	Bucket time.Time
+	Series string
+	Count  int64
+}
+
+// ActivityMetricsTotalSeries is the series label used when no group-by dimension is requested.
+const ActivityMetricsTotalSeries = "total"
+
+// QueryActivityMetrics counts activities per time bucket and, optionally, per
+// group-by dimension value. Aggregation happens in ClickHouse so the response
+// size depends on the number of buckets and series, not the number of activities.
+func (s *ClickHouseStorage) QueryActivityMetrics(ctx context.Context, spec ActivityMetricsQuerySpec, scope ScopeContext) (*ActivityMetricsResult, error) {
+	ctx, span := tracer.Start(ctx, "clickhouse.query_activity_metrics",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "clickhouse"),
+			attribute.String("db.name", s.config.Database),
+			attribute.String("db.operation", "SELECT"),
+			attribute.Int64("metrics.bucket_seconds", spec.BucketSeconds),
+			attribute.String("metrics.group_by", spec.GroupBy),
+		),
+	)
+	defer span.End()
+
+	if spec.BucketSeconds <= 0 {
+		return nil, fmt.Errorf("bucket size must be positive")
+	}
+
+	seriesExpr := "'" + ActivityMetricsTotalSeries + "'"
+	if spec.GroupBy != "" {
+		column, err := GetActivityMetricsGroupByColumn(spec.GroupBy)
+		if err != nil {
+			return nil, err
+		}
+		seriesExpr = column
+	}
+
+	var args []interface{}
+	var conditions []string
+
+	// Scope filtering - same pattern as activity facet queries
+	if scope.Type != types.TenantTypePlatform {
+		if scope.Type == types.TenantTypeUser {
+			conditions = append(conditions, "actor_uid = ?")
+			args = append(args, scope.Name)
+		} else {
+			conditions = append(conditions, "tenant_type = ?")
+			args = append(args, scope.Type)
+			conditions = append(conditions, "tenant_name = ?")
+			args = append(args, scope.Name)
+		}
+	}
+
+	conditions = append(conditions, "timestamp >= ?", "timestamp < ?")
+	args = append(args, spec.StartTime, spec.EndTime)
+
+	// CEL filter (optional)
+	if spec.Filter != "" {
+		celWhere, celArgs, err := cel.ConvertActivityToClickHouseSQL(ctx, spec.Filter)
+		if err != nil {
+			return nil, err
+		}
+		if celWhere != "" {
+			processedWhere := celWhere
+			for i := range celArgs {
+				oldParam := fmt.Sprintf("{arg%d}", i+1)
+				processedWhere = strings.ReplaceAll(processedWhere, oldParam, "?")
+			}
+			args = append(args, celArgs...)
+			conditions = append(conditions, processedWhere)
+		}
+	}
+
+	// BucketSeconds is an integer validated by the caller, so it is safe to
+	// inline; ClickHouse does not accept a bound parameter inside INTERVAL.
+	query := fmt.Sprintf(
+		"SELECT toStartOfInterval(timestamp, INTERVAL %d SECOND) AS bucket, %s AS series, COUNT(*) AS count FROM %s WHERE %s GROUP BY bucket, series ORDER BY bucket ASC, series ASC LIMIT %d",
+		spec.BucketSeconds, seriesExpr, s.table("activities"), strings.Join(conditions, " AND "), MaxActivityMetricsPoints+1,
+	) + s.querySettings(scope)
+
+	traceID := span.SpanContext().TraceID().String()
+	spanID := span.SpanContext().SpanID().String()
+
+	klog.V(4).InfoS("Executing activity metrics query",
+		"query", query,
+		"groupBy", spec.GroupBy,
+		"bucketSeconds", spec.BucketSeconds,
+		"traceID", traceID,
+		"spanID", spanID,
+	)
+
+	rows, err := s.conn.Query(ctx, query, args...)
+	if err != nil {
+		span.RecordError(err)
+		if IsQueryQueueTimeout(err) {
+			return nil, err
+		}
+		klog.ErrorS(err, "Failed to execute activity metrics query",
+			"traceID", traceID,
+			"spanID", spanID,
+		)
+		return nil, fmt.Errorf("unable to retrieve activity metrics. Try again or contact support if the problem persists")
+	}
+	defer rows.Close()
+
+	result := &ActivityMetricsResult{
+		Points: make([]ActivityMetricsPointResult, 0),
+	}
+
+	for rows.Next() {
+		var bucket time.Time
+		var series string
+		var count uint64
+		if err := rows.Scan(&bucket, &series, &count); err != nil {
+			span.RecordError(err)
+			klog.ErrorS(err, "Failed to scan activity metrics row",
+				"traceID", traceID,
+				"spanID", spanID,
+			)
+			return nil, fmt.Errorf("unable to retrieve activity metrics. Try again or contact support if the problem persists")
+		}
+		result.Points = append(result.Points, ActivityMetricsPointResult{
+			Bucket: bucket.UTC(),
+			Series: series,
+			Count:  int64(count),
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		klog.ErrorS(err, "Error iterating activity metrics rows",
+			"traceID", traceID,
+			"spanID", spanID,
+		)
+		return nil, fmt.Errorf("unable to retrieve activity metrics. Try again or contact support if the problem persists")
+	}
+
+	if len(result.Points) > MaxActivityMetricsPoints {
+		return nil, ErrTooManyMetricsPoints
+	}
+
+	span.SetAttributes(attribute.Int("metrics.point_count", len(result.Points)))
+	span.SetStatus(codes.Ok, "activity metrics query successful")
+	return result, nil
+}
@@ -7,8 +7,10 @@ import (
 	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -26,10 +28,31 @@ import (
 	"go.miloapis.com/activity/internal/timeutil"
 	"go.miloapis.com/activity/internal/types"
 	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+	"go.miloapis.com/activity/pkg/celutil"
 )
 
 var tracer = otel.Tracer("activity-clickhouse-storage")
 
+// errQueryCancelled is returned when the client cancels a request while its
+// ClickHouse query is still running.
+var errQueryCancelled = errors.New("query cancelled by client")
+
+// isQueryCancelled reports whether a query failed because its context was
+// cancelled, such as a kubectl or UI client disconnecting. Deadline expiry is
+// a real timeout and is still treated as an error.
+func isQueryCancelled(ctx context.Context, err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(ctx.Err(), context.Canceled)
+}
+
+// recordQueryCancelled records a cancelled query separately from failures so
+// clients giving up don't count against the query error rate. The span is
+// marked as cancelled instead of being given an error status.
+func recordQueryCancelled(span trace.Span) {
+	metrics.ClickHouseQueryCancelled.Inc()
+	span.SetAttributes(attribute.Bool("query.cancelled", true))
+	span.AddEvent("query cancelled by client")
+}
+
 const (
 	// cursorTTL limits cursor lifetime to prevent replay attacks and stale queries.
 	cursorTTL = 1 * time.Hour
@@ -41,10 +64,15 @@ type cursorData struct {
 	AuditID   string    `json:"a"` // Audit ID for tie-breaking
 	QueryHash string    `json:"h"` // Hash of query parameters
 	IssuedAt  time.Time `json:"i"` // When cursor was created (for expiration)
+
+	// Previous marks a status.previous cursor, which pages back toward newer
+	// events from the first event of a page instead of forward from its last.
+	Previous bool `json:"p,omitempty"`
 }
 
 // hashQueryParams creates a hash to validate cursors are used with matching queries.
-// Excludes continueAfter since it changes between pagination requests.
+// Excludes continueAfter since it changes between pagination requests, and limit
+// since it only sets the size of the next page, not where that page starts.
 func hashQueryParams(spec v1alpha1.AuditLogQuerySpec) string {
 	h := sha256.New()
 	h.Write([]byte(spec.StartTime))
@@ -52,21 +80,43 @@ func hashQueryParams(spec v1alpha1.AuditLogQuerySpec) string {
 	h.Write([]byte(spec.EndTime))
 	h.Write([]byte("|"))
 	h.Write([]byte(spec.Filter))
-	h.Write([]byte("|"))
-	h.Write([]byte(fmt.Sprintf("%d", spec.Limit)))
+	// Only hashed when set, so cursors issued before relativeTo existed stay
+	// valid
+	if spec.RelativeTo != "" {
+		h.Write([]byte("|"))
+		h.Write([]byte(spec.RelativeTo))
+	}
+	// Likewise for mutationsOnly
+	if spec.MutationsOnly {
+		h.Write([]byte("|mutationsOnly"))
+	}
 
 	return base64.URLEncoding.EncodeToString(h.Sum(nil)[:16])
 }
 
 // encodeCursor creates a base64-encoded pagination token containing position and validation data.
 func encodeCursor(timestamp time.Time, auditID string, spec v1alpha1.AuditLogQuerySpec) string {
-	data := cursorData{
+	return encodeCursorData(cursorData{
 		Timestamp: timestamp,
 		AuditID:   auditID,
 		QueryHash: hashQueryParams(spec),
 		IssuedAt:  time.Now(),
-	}
+	})
+}
+
+// encodePreviousCursor creates a token for the page before the one starting
+// at the given event, i.e. the newer events just above it.
+func encodePreviousCursor(timestamp time.Time, auditID string, spec v1alpha1.AuditLogQuerySpec) string {
+	return encodeCursorData(cursorData{
+		Timestamp: timestamp,
+		AuditID:   auditID,
+		QueryHash: hashQueryParams(spec),
+		IssuedAt:  time.Now(),
+		Previous:  true,
+	})
+}
 
+func encodeCursorData(data cursorData) string {
 	jsonData, _ := json.Marshal(data)
 	return base64.URLEncoding.EncodeToString(jsonData)
 }
@@ -82,34 +132,44 @@ func ValidateCursor(cursor string, spec v1alpha1.AuditLogQuerySpec) error {
 // decodeCursor validates and extracts pagination state from a cursor token.
 // Returns an error if the cursor is malformed, expired, or doesn't match the current query.
 func decodeCursor(cursor string, spec v1alpha1.AuditLogQuerySpec) (time.Time, string, error) {
+	data, err := decodeCursorData(cursor, spec)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+	return data.Timestamp, data.AuditID, nil
+}
+
+// decodeCursorData validates a cursor token and returns all of its state,
+// including which direction it pages in.
+func decodeCursorData(cursor string, spec v1alpha1.AuditLogQuerySpec) (cursorData, error) {
 	decoded, err := base64.URLEncoding.DecodeString(cursor)
 	if err != nil {
-		return time.Time{}, "", fmt.Errorf("cannot decode pagination cursor: %w", err)
+		return cursorData{}, fmt.Errorf("cannot decode pagination cursor: %w", err)
 	}
 
 	var data cursorData
 	if err := json.Unmarshal(decoded, &data); err != nil {
-		return time.Time{}, "", fmt.Errorf("cursor format is invalid. Start a new query")
+		return cursorData{}, fmt.Errorf("cursor format is invalid. Start a new query")
 	}
 
 	currentHash := hashQueryParams(spec)
 	if data.QueryHash != currentHash {
-		return time.Time{}, "", fmt.Errorf("cannot use cursor because query parameters changed. Start a new query without the continueAfter parameter")
+		return cursorData{}, fmt.Errorf("cannot use cursor because query parameters changed. Start a new query without the continueAfter parameter")
 	}
 
 	if data.IssuedAt.IsZero() {
-		return time.Time{}, "", fmt.Errorf("cursor format is invalid. Start a new query")
+		return cursorData{}, fmt.Errorf("cursor format is invalid. Start a new query")
 	}
 
 	age := time.Since(data.IssuedAt)
 	if age > cursorTTL {
-		return time.Time{}, "", fmt.Errorf("cursor expired after %v. Cursors are valid for %v. Start a new query without the continueAfter parameter",
+		return cursorData{}, fmt.Errorf("cursor expired after %v. Cursors are valid for %v. Start a new query without the continueAfter parameter",
 			age.Round(time.Second),
 			cursorTTL,
 		)
 	}
 
-	return data.Timestamp, data.AuditID, nil
+	return data, nil
 }
 
 // ClickHouseConfig configures the ClickHouse connection and query limits.
@@ -119,6 +179,12 @@ type ClickHouseConfig struct {
 	Username string
 	Password string
 
+	// Cluster is the name of a sharded ClickHouse cluster. When set, reads
+	// and event inserts go through the Distributed tables (see
+	// DistributedTableSuffix) with settings that merge aggregates across
+	// shards. Empty queries the tables directly.
+	Cluster string
+
 	// TLS configuration (optional - disabled by default)
 	TLSEnabled  bool   // Enable TLS for ClickHouse connection
 	TLSCertFile string // Path to client certificate file
@@ -127,12 +193,48 @@ type ClickHouseConfig struct {
 
 	MaxQueryWindow time.Duration // Maximum allowed time range for queries
 	MaxPageSize    int32         // Maximum results per page
+
+	MaxQueryTimeout time.Duration // Maximum spec.timeoutSeconds a query may request
+
+	// Query concurrency limits (optional - unlimited by default)
+	MaxConcurrentQueries int           // Maximum ClickHouse queries running at once; zero or less is unlimited
+	QueryQueueTimeout    time.Duration // How long a query waits for a free slot before failing
+
+	// EnableJSONExtract allows jsonExtract() in audit log filters. It reads the
+	// raw event JSON and can't use indexes or projections, so it is off by default.
+	EnableJSONExtract bool
+
+	// MaxFacetGroupByRows caps the distinct values a single facet query may
+	// aggregate. Facets on fields with more values in the queried range fail
+	// with a FacetTooManyValuesError. Zero or less is unlimited.
+	MaxFacetGroupByRows int
+
+	// InjectTraceComment prefixes each query with a /* traceparent: ... */
+	// comment so it can be matched to its trace in system.query_log. Spans and
+	// metrics carry the trace either way.
+	InjectTraceComment bool
+
+	// TenantQueryLimits holds the ClickHouse limits applied to each query,
+	// keyed by the scope type it runs for (platform, Organization, Project or
+	// User). Scope types without an entry are not limited.
+	TenantQueryLimits map[string]TenantQueryLimits
+
+	// Retention windows, matching the TTLs on each table. Queries reaching
+	// further back are clamped to the window and warned that older data has aged
+	// out. Zero means no retention limit.
+	AuditLogRetentionWindow time.Duration
+	ActivityRetentionWindow time.Duration
+	EventRetentionWindow    time.Duration
 }
 
 // ClickHouseStorage implements audit log storage using ClickHouse.
 type ClickHouseStorage struct {
 	conn   driver.Conn
 	config ClickHouseConfig
+
+	// now returns the current time that relative query times resolve
+	// against. Tests replace it to pin "now".
+	now func() time.Time
 }
 
 // NewClickHouseStorage establishes a connection to ClickHouse and validates connectivity.
@@ -145,7 +247,7 @@ func NewClickHouseStorage(config ClickHouseConfig) (*ClickHouseStorage, error) {
 			Password: config.Password,
 		},
 		Settings: clickhouse.Settings{
-			"max_execution_time": 60,
+			"max_execution_time": int(DefaultQueryTimeout.Seconds()),
 		},
 		DialTimeout: 5 * time.Second,
 		Compression: &clickhouse.Compression{
@@ -153,6 +255,20 @@ func NewClickHouseStorage(config ClickHouseConfig) (*ClickHouseStorage, error) {
 		},
 	}
 
+	for key, value := range clusterSettings(config.Cluster) {
+		options.Settings[key] = value
+	}
+	if config.Cluster != "" {
+		klog.InfoS("Querying ClickHouse through Distributed tables", "cluster", config.Cluster)
+	}
+	for tenantType, limits := range config.TenantQueryLimits {
+		klog.InfoS("Limiting ClickHouse queries for tenant type",
+			"tenantType", tenantType,
+			"maxMemoryUsage", limits.MaxMemoryUsage,
+			"maxExecutionTime", limits.MaxExecutionTime,
+		)
+	}
+
 	// Configure TLS if enabled
 	if config.TLSEnabled {
 		tlsConfig, err := loadTLSConfig(config)
@@ -173,11 +289,22 @@ func NewClickHouseStorage(config ClickHouseConfig) (*ClickHouseStorage, error) {
 	}
 
 	return &ClickHouseStorage{
-		conn:   conn,
+		conn:   newLimitedConn(conn, config.MaxConcurrentQueries, config.QueryQueueTimeout),
 		config: config,
+		now:    time.Now,
 	}, nil
 }
 
+// Now returns the time relative query times resolve against, falling back to
+// the wall clock for storages built without a clock. The query registries and
+// the event backends share it so "now" means the same thing everywhere.
+func (s *ClickHouseStorage) Now() time.Time {
+	if s.now == nil {
+		return time.Now()
+	}
+	return s.now()
+}
+
 // loadTLSConfig loads TLS certificates and creates a tls.Config for ClickHouse connection.
 func loadTLSConfig(config ClickHouseConfig) (*tls.Config, error) {
 	tlsConfig := &tls.Config{}
@@ -235,10 +362,92 @@ func (s *ClickHouseStorage) GetMaxPageSize() int32 {
 	return s.config.MaxPageSize
 }
 
+// GetAuditLogRetentionWindow returns how far back audit logs are retained, or
+// zero when they are kept indefinitely.
+func (s *ClickHouseStorage) GetAuditLogRetentionWindow() time.Duration {
+	return s.config.AuditLogRetentionWindow
+}
+
+// GetActivityRetentionWindow returns how far back activities are retained, or
+// zero when they are kept indefinitely.
+func (s *ClickHouseStorage) GetActivityRetentionWindow() time.Duration {
+	return s.config.ActivityRetentionWindow
+}
+
+// JSONExtractEnabled reports whether audit log filters may use jsonExtract().
+func (s *ClickHouseStorage) JSONExtractEnabled() bool {
+	return s.config.EnableJSONExtract
+}
+
+// withTraceComment prefixes query with the span's traceparent as a SQL comment,
+// unless InjectTraceComment is off or the span isn't being traced.
+func (s *ClickHouseStorage) withTraceComment(span trace.Span, query string) string {
+	if !s.config.InjectTraceComment {
+		return query
+	}
+	spanContext := span.SpanContext()
+	if !spanContext.IsValid() {
+		return query
+	}
+	traceparent := fmt.Sprintf("00-%s-%s-%02x",
+		spanContext.TraceID().String(),
+		spanContext.SpanID().String(),
+		byte(spanContext.TraceFlags()))
+	return fmt.Sprintf("/* traceparent: %s */ %s", traceparent, query)
+}
+
+// withQueryID sets the ClickHouse query_id of the queries run with the
+// returned context to queryID(span), so the query can be looked up in
+// system.query_log by the ID rather than by matching its SQL text. The context
+// is returned unchanged, with an empty ID, when the span isn't being traced.
+// Only one query may run under an ID at a time, so each span should issue one.
+func withQueryID(ctx context.Context, span trace.Span) (context.Context, string) {
+	id := queryID(span.SpanContext())
+	if id == "" {
+		return ctx, ""
+	}
+	return clickhouse.Context(ctx, clickhouse.WithQueryID(id)), id
+}
+
+// queryID derives a query_id from a span: its trace ID and span ID joined by a
+// dash. The trace ID ties it to the request's logs and spans, and the span ID
+// keeps it unique among the queries of one request.
+func queryID(spanContext trace.SpanContext) string {
+	if !spanContext.IsValid() {
+		return ""
+	}
+	return spanContext.TraceID().String() + "-" + spanContext.SpanID().String()
+}
+
+// GetMaxQueryTimeout returns the largest spec.timeoutSeconds override allowed.
+func (s *ClickHouseStorage) GetMaxQueryTimeout() time.Duration {
+	if s.config.MaxQueryTimeout <= 0 {
+		return DefaultMaxQueryTimeout
+	}
+	return s.config.MaxQueryTimeout
+}
+
 // QueryResult contains audit events and pagination state.
 type QueryResult struct {
 	Events   []auditv1.Event
 	Continue string
+
+	// Previous is a cursor for the page of newer events before this one. It
+	// is empty on the first page.
+	Previous string
+
+	// RawEvents holds the stored JSON of each event when spec.IncludeRaw is
+	// set, in the same order as Events.
+	RawEvents []string
+
+	// Partial is true when spec.BestEffort is set and the query timed out
+	// while rows were being read. Events holds the rows read before the
+	// timeout, and Continue resumes after the last of them.
+	Partial bool
+
+	// QueryID is the query_id the query ran with in ClickHouse, the key of
+	// its row in system.query_log. Empty when the request isn't traced.
+	QueryID string
 }
 
 // ScopeContext defines the hierarchical scope boundary for audit log queries.
@@ -247,6 +456,19 @@ type ScopeContext struct {
 	Name string // scope identifier (org name, project name, etc.)
 }
 
+// annotateDuration records the request latency on the event so clients can
+// display it without computing it from the timestamps themselves.
+func annotateDuration(event *auditv1.Event) {
+	if event.RequestReceivedTimestamp.IsZero() || event.StageTimestamp.IsZero() {
+		return
+	}
+	duration := event.StageTimestamp.Sub(event.RequestReceivedTimestamp.Time)
+	if event.Annotations == nil {
+		event.Annotations = make(map[string]string, 1)
+	}
+	event.Annotations[v1alpha1.AuditEventDurationAnnotation] = strconv.FormatInt(duration.Milliseconds(), 10)
+}
+
 // QueryAuditLogs retrieves audit logs matching the query specification and scope.
 // The spec parameter must be pre-validated by the API layer.
 func (s *ClickHouseStorage) QueryAuditLogs(ctx context.Context, spec v1alpha1.AuditLogQuerySpec, scope ScopeContext) (*QueryResult, error) {
@@ -269,7 +491,7 @@ func (s *ClickHouseStorage) QueryAuditLogs(ctx context.Context, spec v1alpha1.Au
 
 	query, args, err := s.buildQuery(ctx, spec, scope)
 	if err != nil {
-		metrics.ClickHouseQueryErrors.WithLabelValues("build_query").Inc()
+		metrics.IncClickHouseQueryErrors("build_query")
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "failed to build query")
 		// Return the error directly - buildQuery returns user-friendly validation errors
@@ -289,13 +511,10 @@ func (s *ClickHouseStorage) QueryAuditLogs(ctx context.Context, spec v1alpha1.Au
 	span.SetAttributes(attribute.String("db.statement", truncatedQuery))
 
 	// Add trace context as SQL comment for correlation
-	spanContext := span.SpanContext()
-	if spanContext.IsValid() {
-		traceparent := fmt.Sprintf("00-%s-%s-%02x",
-			spanContext.TraceID().String(),
-			spanContext.SpanID().String(),
-			spanContext.TraceFlags())
-		query = fmt.Sprintf("/* traceparent: %s */ %s", traceparent, query)
+	query = s.withTraceComment(span, query)
+	ctx, queryID := withQueryID(ctx, span)
+	if queryID != "" {
+		span.SetAttributes(attribute.String("db.clickhouse.query_id", queryID))
 	}
 
 	// Extract trace ID for logging
@@ -305,6 +524,7 @@ func (s *ClickHouseStorage) QueryAuditLogs(ctx context.Context, spec v1alpha1.Au
 	klog.InfoS("Executing ClickHouse query",
 		"traceID", traceID,
 		"spanID", spanID,
+		"queryID", queryID,
 		"filter", spec.Filter,
 		"limit", spec.Limit,
 		"continue", spec.Continue,
@@ -317,8 +537,24 @@ func (s *ClickHouseStorage) QueryAuditLogs(ctx context.Context, spec v1alpha1.Au
 	queryDuration := time.Since(queryStartTime).Seconds()
 
 	if err != nil {
-		metrics.ClickHouseQueryDuration.WithLabelValues("query").Observe(queryDuration)
-		metrics.ClickHouseQueryTotal.WithLabelValues("error").Inc()
+		metrics.ObserveClickHouseQueryDuration("query", queryDuration)
+
+		if isQueryCancelled(ctx, err) {
+			recordQueryCancelled(span)
+			klog.V(2).InfoS("ClickHouse query cancelled by client",
+				"traceID", traceID,
+				"spanID", spanID,
+				"duration", queryDuration,
+			)
+			return nil, errQueryCancelled
+		}
+		if IsQueryQueueTimeout(err) {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "query queue timeout")
+			return nil, err
+		}
+
+		metrics.IncClickHouseQueryTotal("error")
 
 		// Classify error type
 		errorType := "unknown"
@@ -333,7 +569,7 @@ func (s *ClickHouseStorage) QueryAuditLogs(ctx context.Context, spec v1alpha1.Au
 		} else if strings.Contains(err.Error(), "parameter") {
 			errorType = "parameter"
 		}
-		metrics.ClickHouseQueryErrors.WithLabelValues(errorType).Inc()
+		metrics.IncClickHouseQueryErrors(errorType)
 
 		// Record error in span
 		span.RecordError(err)
@@ -344,6 +580,7 @@ func (s *ClickHouseStorage) QueryAuditLogs(ctx context.Context, spec v1alpha1.Au
 		klog.ErrorS(err, "ClickHouse query failed",
 			"traceID", traceID,
 			"spanID", spanID,
+			"queryID", queryID,
 			"errorType", errorType,
 			"filter", spec.Filter,
 			"limit", spec.Limit,
@@ -357,7 +594,7 @@ func (s *ClickHouseStorage) QueryAuditLogs(ctx context.Context, spec v1alpha1.Au
 	defer rows.Close()
 
 	// Record successful query execution time
-	metrics.ClickHouseQueryDuration.WithLabelValues("query").Observe(queryDuration)
+	metrics.ObserveClickHouseQueryDuration("query", queryDuration)
 	span.SetAttributes(attribute.Float64("db.query_duration_seconds", queryDuration))
 
 	// Determine the limit
@@ -370,6 +607,7 @@ func (s *ClickHouseStorage) QueryAuditLogs(ctx context.Context, spec v1alpha1.Au
 	}
 
 	var events []auditv1.Event
+	var rawEvents []string
 	var unmarshalErrors int
 	for rows.Next() {
 		var eventJSON string
@@ -391,21 +629,41 @@ func (s *ClickHouseStorage) QueryAuditLogs(ctx context.Context, spec v1alpha1.Au
 			continue
 		}
 
+		annotateDuration(&event)
 		events = append(events, event)
+		if spec.IncludeRaw {
+			rawEvents = append(rawEvents, eventJSON)
+		}
 	}
 
+	var partial bool
 	if err := rows.Err(); err != nil {
-		metrics.ClickHouseQueryTotal.WithLabelValues("error").Inc()
-		metrics.ClickHouseQueryErrors.WithLabelValues("iteration").Inc()
+		if isQueryCancelled(ctx, err) {
+			recordQueryCancelled(span)
+			klog.V(2).InfoS("ClickHouse query cancelled by client while reading results",
+				"traceID", traceID,
+				"spanID", spanID,
+			)
+			return nil, errQueryCancelled
+		}
 
-		klog.ErrorS(err, "Error iterating ClickHouse rows",
-			"traceID", traceID,
-			"spanID", spanID,
-			"filter", spec.Filter,
-			"limit", spec.Limit,
-		)
+		if spec.BestEffort && isIterationTimeout(ctx, err) {
+			// The rows read before the timeout are still a contiguous prefix of
+			// the page. Scan errors never get here; they fail in the loop above.
+			partial = true
+		} else {
+			metrics.IncClickHouseQueryTotal("error")
+			metrics.IncClickHouseQueryErrors("iteration")
 
-		return nil, fmt.Errorf("unable to retrieve audit logs. Try again or contact support if the problem persists")
+			klog.ErrorS(err, "Error iterating ClickHouse rows",
+				"traceID", traceID,
+				"spanID", spanID,
+				"filter", spec.Filter,
+				"limit", spec.Limit,
+			)
+
+			return nil, fmt.Errorf("unable to retrieve audit logs. Try again or contact support if the problem persists")
+		}
 	}
 
 	if unmarshalErrors > 0 {
@@ -418,22 +676,71 @@ func (s *ClickHouseStorage) QueryAuditLogs(ctx context.Context, spec v1alpha1.Au
 	}
 
 	// Check if we have more results (we fetched limit+1)
-	var continueAfter string
-	if int32(len(events)) > limit {
+	hasMore := int32(len(events)) > limit
+	if hasMore {
 		events = events[:limit]
-		if len(events) > 0 {
-			lastEvent := events[len(events)-1]
+		if rawEvents != nil {
+			rawEvents = rawEvents[:limit]
+		}
+		// The page filled up before the timeout, so nothing is missing from it
+		partial = false
+	}
+
+	// A previous page is read oldest first, starting next to the cursor, so
+	// "more" lies further back toward newer events
+	previousPage := isPreviousPageCursor(spec)
+	if previousPage {
+		reverseEvents(events, rawEvents)
+	}
+
+	var continueAfter, previous string
+	if len(events) > 0 {
+		firstEvent, lastEvent := events[0], events[len(events)-1]
+		if previousPage {
+			// The page the cursor came from follows this one
 			continueAfter = encodeCursor(lastEvent.StageTimestamp.Time, string(lastEvent.AuditID), spec)
+			if hasMore || partial {
+				previous = encodePreviousCursor(firstEvent.StageTimestamp.Time, string(firstEvent.AuditID), spec)
+			}
+		} else {
+			if hasMore || partial {
+				continueAfter = encodeCursor(lastEvent.StageTimestamp.Time, string(lastEvent.AuditID), spec)
+			}
+			if spec.Continue != "" {
+				previous = encodePreviousCursor(firstEvent.StageTimestamp.Time, string(firstEvent.AuditID), spec)
+			}
 		}
 	}
 
+	if partial {
+		metrics.IncClickHouseQueryTotal("partial")
+		metrics.IncClickHouseQueryErrors("timeout")
+		klog.InfoS("ClickHouse query timed out while reading results; returning partial results",
+			"traceID", traceID,
+			"spanID", spanID,
+			"rowsReturned", len(events),
+			"filter", spec.Filter,
+			"limit", spec.Limit,
+		)
+		span.SetAttributes(attribute.Bool("query.partial", true))
+		span.SetStatus(codes.Ok, "query returned partial results")
+		return &QueryResult{
+			Events:    events,
+			Continue:  continueAfter,
+			Previous:  previous,
+			RawEvents: rawEvents,
+			Partial:   true,
+			QueryID:   queryID,
+		}, nil
+	}
+
 	// Record successful query metrics
-	metrics.ClickHouseQueryTotal.WithLabelValues("success").Inc()
+	metrics.IncClickHouseQueryTotal("success")
 	metrics.AuditLogQueryResults.Observe(float64(len(events)))
 
 	// Record end-to-end query duration (includes result processing)
 	totalDuration := time.Since(overallStartTime).Seconds()
-	metrics.ClickHouseQueryDuration.WithLabelValues("total").Observe(totalDuration)
+	metrics.ObserveClickHouseQueryDuration("total", totalDuration)
 
 	// Add result metrics to span
 	span.SetAttributes(
@@ -447,6 +754,7 @@ func (s *ClickHouseStorage) QueryAuditLogs(ctx context.Context, spec v1alpha1.Au
 	klog.InfoS("ClickHouse query completed successfully",
 		"traceID", traceID,
 		"spanID", spanID,
+		"queryID", queryID,
 		"rowsReturned", len(events),
 		"hasMore", continueAfter != "",
 		"queryDuration", queryDuration,
@@ -456,11 +764,34 @@ func (s *ClickHouseStorage) QueryAuditLogs(ctx context.Context, spec v1alpha1.Au
 	)
 
 	return &QueryResult{
-		Events:   events,
-		Continue: continueAfter,
+		Events:    events,
+		Continue:  continueAfter,
+		Previous:  previous,
+		RawEvents: rawEvents,
+		QueryID:   queryID,
 	}, nil
 }
 
+// isPreviousPageCursor reports whether spec.Continue holds a status.previous
+// cursor. The cursor has already been validated by buildQuery.
+func isPreviousPageCursor(spec v1alpha1.AuditLogQuerySpec) bool {
+	if spec.Continue == "" {
+		return false
+	}
+	data, err := decodeCursorData(spec.Continue, spec)
+	return err == nil && data.Previous
+}
+
+// reverseEvents reverses events, and rawEvents when present, in place.
+func reverseEvents(events []auditv1.Event, rawEvents []string) {
+	for i, j := 0, len(events)-1; i < j; i, j = i+1, j-1 {
+		events[i], events[j] = events[j], events[i]
+		if rawEvents != nil {
+			rawEvents[i], rawEvents[j] = rawEvents[j], rawEvents[i]
+		}
+	}
+}
+
 // hasUserFilter checks if the CEL filter contains user-based filtering
 func hasUserFilter(filter string) bool {
 	if filter == "" {
@@ -502,13 +833,43 @@ func hasActorFilter(filter string) bool {
 		strings.Contains(filter, "actor_uid")
 }
 
-// buildQuery constructs a ClickHouse SQL query from the query spec
+// buildQuery constructs a ClickHouse SQL query for one page of the query spec
 func (s *ClickHouseStorage) buildQuery(ctx context.Context, spec v1alpha1.AuditLogQuerySpec, scope ScopeContext) (string, []interface{}, error) {
-	var args []interface{}
+	query, args, err := s.buildOrderedQuery(ctx, spec, scope)
+	if err != nil {
+		return "", nil, err
+	}
 
-	query := fmt.Sprintf("SELECT event_json FROM %s.audit_logs", s.config.Database)
+	limit := spec.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+	if limit > s.config.MaxPageSize {
+		limit = s.config.MaxPageSize
+	}
+
+	query += fmt.Sprintf(" LIMIT %d", limit+1)
+	query += s.querySettings(scope)
+
+	return query, args, nil
+}
 
+// mutationsOnlyCondition returns the condition spec.mutationsOnly adds to an
+// audit log query: only requests with a verb that changes resources.
+func mutationsOnlyCondition() (string, []interface{}) {
+	args := make([]interface{}, len(celutil.MutatingVerbs))
+	for i, verb := range celutil.MutatingVerbs {
+		args[i] = verb
+	}
+	return "verb IN (" + strings.TrimSuffix(strings.Repeat("?, ", len(args)), ", ") + ")", args
+}
+
+// auditLogConditions returns the WHERE conditions and their arguments shared
+// by every audit log query for spec: the caller's scope, the time range, the
+// CEL filter and spec.mutationsOnly. Pagination is left to the caller.
+func (s *ClickHouseStorage) auditLogConditions(ctx context.Context, spec v1alpha1.AuditLogQuerySpec, scope ScopeContext) ([]string, []interface{}, error) {
 	var conditions []string
+	var args []interface{}
 
 	// Only add scope filters if not platform-wide query
 	if scope.Type != types.TenantTypePlatform {
@@ -530,12 +891,15 @@ func (s *ClickHouseStorage) buildQuery(ctx context.Context, spec v1alpha1.AuditL
 
 	// Use a single reference time for both timestamps to prevent sub-second drift
 	// when using relative times like "now-7d" and "now"
-	now := time.Now()
+	now, err := timeutil.ResolveReferenceTime(spec.RelativeTo, s.Now())
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid relativeTo: %w", err)
+	}
 
 	if spec.StartTime != "" {
 		startTime, err := timeutil.ParseFlexibleTime(spec.StartTime, now)
 		if err != nil {
-			return "", nil, fmt.Errorf("invalid startTime: %w", err)
+			return nil, nil, fmt.Errorf("invalid startTime: %w", err)
 		}
 		conditions = append(conditions, "timestamp >= ?")
 		args = append(args, startTime)
@@ -544,7 +908,7 @@ func (s *ClickHouseStorage) buildQuery(ctx context.Context, spec v1alpha1.AuditL
 	if spec.EndTime != "" {
 		endTime, err := timeutil.ParseFlexibleTime(spec.EndTime, now)
 		if err != nil {
-			return "", nil, fmt.Errorf("invalid endTime: %w", err)
+			return nil, nil, fmt.Errorf("invalid endTime: %w", err)
 		}
 		conditions = append(conditions, "timestamp < ?")
 		args = append(args, endTime)
@@ -554,7 +918,7 @@ func (s *ClickHouseStorage) buildQuery(ctx context.Context, spec v1alpha1.AuditL
 		celWhere, celArgs, err := cel.ConvertToClickHouseSQL(ctx, spec.Filter)
 		if err != nil {
 			// Return the error directly - it already has user-friendly messaging
-			return "", nil, err
+			return nil, nil, err
 		}
 		if celWhere != "" {
 			processedWhere := celWhere
@@ -567,21 +931,49 @@ func (s *ClickHouseStorage) buildQuery(ctx context.Context, spec v1alpha1.AuditL
 		}
 	}
 
+	if spec.MutationsOnly {
+		condition, verbs := mutationsOnlyCondition()
+		conditions = append(conditions, condition)
+		args = append(args, verbs...)
+	}
+
+	return conditions, args, nil
+}
+
+// buildOrderedQuery constructs the filtered, ordered ClickHouse SQL query for
+// the query spec, without a LIMIT.
+func (s *ClickHouseStorage) buildOrderedQuery(ctx context.Context, spec v1alpha1.AuditLogQuerySpec, scope ScopeContext) (string, []interface{}, error) {
+	query := fmt.Sprintf("SELECT event_json FROM %s", s.table("audit_logs"))
+
+	conditions, args, err := s.auditLogConditions(ctx, spec, scope)
+	if err != nil {
+		return "", nil, err
+	}
+
 	// Cursor pagination using timestamp and audit_id.
 	// Since timestamp is the second sort key (after toStartOfHour), we need to handle
 	// both hour boundaries and exact timestamps for correct pagination.
+	var previous bool
 	if spec.Continue != "" {
-		cursorTime, cursorAuditID, err := decodeCursor(spec.Continue, spec)
+		cursor, err := decodeCursorData(spec.Continue, spec)
 		if err != nil {
 			return "", nil, err
 		}
+		previous = cursor.Previous
 
-		// Pagination logic: continue from where we left off
-		// 1. Hour bucket is earlier, OR
-		// 2. Same hour bucket but timestamp is earlier, OR
-		// 3. Same timestamp but audit_id is earlier (for tie-breaking)
-		conditions = append(conditions, "(toStartOfHour(timestamp) < toStartOfHour(?) OR (toStartOfHour(timestamp) = toStartOfHour(?) AND timestamp < ?) OR (timestamp = ? AND audit_id < ?))")
-		args = append(args, cursorTime, cursorTime, cursorTime, cursorTime, cursorAuditID)
+		if previous {
+			// Previous page: the events just newer than the cursor, mirroring
+			// the forward conditions below. They are read oldest first and
+			// reversed by the caller.
+			conditions = append(conditions, "(toStartOfHour(timestamp) > toStartOfHour(?) OR (toStartOfHour(timestamp) = toStartOfHour(?) AND timestamp > ?) OR (timestamp = ? AND audit_id > ?))")
+		} else {
+			// Pagination logic: continue from where we left off
+			// 1. Hour bucket is earlier, OR
+			// 2. Same hour bucket but timestamp is earlier, OR
+			// 3. Same timestamp but audit_id is earlier (for tie-breaking)
+			conditions = append(conditions, "(toStartOfHour(timestamp) < toStartOfHour(?) OR (toStartOfHour(timestamp) = toStartOfHour(?) AND timestamp < ?) OR (timestamp = ? AND audit_id < ?))")
+		}
+		args = append(args, cursor.Timestamp, cursor.Timestamp, cursor.Timestamp, cursor.Timestamp, cursor.AuditID)
 	}
 
 	if len(conditions) > 0 {
@@ -591,31 +983,27 @@ func (s *ClickHouseStorage) buildQuery(ctx context.Context, spec v1alpha1.AuditL
 	// ORDER BY must match projection/primary key sort order for ClickHouse
 	// to efficiently use indexes and projections.
 	// Timestamp is second to ensure strict chronological ordering within each hour.
+	var orderBy string
 	if scope.Type == "platform" {
 		if hasUserFilter(spec.Filter) {
 			// User filter present: use user_query_projection
-			query += " ORDER BY toStartOfHour(timestamp) DESC, timestamp DESC, user DESC, api_group DESC, resource DESC, audit_id DESC"
+			orderBy = " ORDER BY toStartOfHour(timestamp) DESC, timestamp DESC, user DESC, api_group DESC, resource DESC, audit_id DESC"
 		} else {
 			// No user filter: use platform_query_projection
-			query += " ORDER BY toStartOfHour(timestamp) DESC, timestamp DESC, api_group DESC, resource DESC, audit_id DESC"
+			orderBy = " ORDER BY toStartOfHour(timestamp) DESC, timestamp DESC, api_group DESC, resource DESC, audit_id DESC"
 		}
 	} else if scope.Type == types.TenantTypeUser {
 		// User-scoped: use user_uid_query_projection to filter by UID
-		query += " ORDER BY toStartOfHour(timestamp) DESC, timestamp DESC, user_uid DESC, api_group DESC, resource DESC, audit_id DESC"
+		orderBy = " ORDER BY toStartOfHour(timestamp) DESC, timestamp DESC, user_uid DESC, api_group DESC, resource DESC, audit_id DESC"
 	} else {
 		// Tenant-scoped: match hour-bucketed primary key for efficient index use
-		query += " ORDER BY toStartOfHour(timestamp) DESC, timestamp DESC, scope_type DESC, scope_name DESC, user DESC, audit_id DESC"
-	}
-
-	limit := spec.Limit
-	if limit <= 0 {
-		limit = 100
+		orderBy = " ORDER BY toStartOfHour(timestamp) DESC, timestamp DESC, scope_type DESC, scope_name DESC, user DESC, audit_id DESC"
 	}
-	if limit > s.config.MaxPageSize {
-		limit = s.config.MaxPageSize
+	if previous {
+		// The same key read in reverse, so the projections still apply
+		orderBy = strings.ReplaceAll(orderBy, " DESC", " ASC")
 	}
-
-	query += fmt.Sprintf(" LIMIT %d", limit+1)
+	query += orderBy
 
 	return query, args, nil
 }
@@ -638,14 +1026,76 @@ type ActivityQuerySpec struct {
 	// Limit is the maximum number of results to return.
 	Limit int32
 
+	// OrderBy selects the sort order. Empty means timestamp, newest first.
+	OrderBy string
+
+	// TenantType and TenantName narrow a platform-scoped query to one tenant.
+	// Both are empty for an unrestricted query. They are ignored for
+	// tenant-scoped callers, whose scope already pins the tenant.
+	TenantType string
+	TenantName string
+
 	// Continue is the pagination cursor.
 	Continue string
+
+	// IncludeTotal also counts every matching activity, up to
+	// ActivityTotalCap, with a second query run alongside the list.
+	IncludeTotal bool
+}
+
+// Sort orders accepted in ActivityQuerySpec.OrderBy.
+const (
+	ActivityOrderByTimestamp = "timestamp"
+	ActivityOrderByActorName = "spec.actor.name"
+	ActivityOrderByAPIGroup  = "spec.resource.apiGroup"
+)
+
+// activityOrderColumns maps the non-timestamp sort orders to the columns they
+// sort on. Both columns are part of a query projection and carry a bloom
+// filter index.
+var activityOrderColumns = map[string]string{
+	ActivityOrderByActorName: "actor_name",
+	ActivityOrderByAPIGroup:  "api_group",
+}
+
+// MaxOrderedQueryWindow bounds the time range of activity queries that are not
+// ordered by timestamp. Every table ordering leads with the timestamp, so any
+// other sort has to read and sort the whole window before returning a page.
+const MaxOrderedQueryWindow = 24 * time.Hour
+
+// IsTimestampOrder reports whether orderBy sorts activities newest first.
+func IsTimestampOrder(orderBy string) bool {
+	return orderBy == "" || orderBy == ActivityOrderByTimestamp
+}
+
+// SupportedActivityOrders lists the accepted ActivityQuerySpec.OrderBy values.
+func SupportedActivityOrders() []string {
+	return []string{ActivityOrderByTimestamp, ActivityOrderByActorName, ActivityOrderByAPIGroup}
+}
+
+// activityOrderKey returns the sort key an activity contributes to a cursor
+// for the given ordering.
+func activityOrderKey(activity *v1alpha1.Activity, orderBy string) string {
+	switch orderBy {
+	case ActivityOrderByActorName:
+		return activity.Spec.Actor.Name
+	case ActivityOrderByAPIGroup:
+		return activity.Spec.Resource.APIGroup
+	default:
+		return ""
+	}
 }
 
 // ActivityQueryResult contains activities and pagination state.
 type ActivityQueryResult struct {
-	Activities []string // JSON activity records
+	Activities []v1alpha1.Activity
 	Continue   string
+
+	// Total is the number of matching activities across all pages, set when
+	// IncludeTotal was requested. TotalCapped means there are more than
+	// Total, which is then ActivityTotalCap.
+	Total       *int64
+	TotalCapped bool
 }
 
 // QueryActivities retrieves activities matching the query specification and scope.
@@ -675,18 +1125,44 @@ func (s *ClickHouseStorage) QueryActivities(ctx context.Context, spec ActivityQu
 	)
 
 	// Add trace context
-	spanContext := span.SpanContext()
-	if spanContext.IsValid() {
-		traceparent := fmt.Sprintf("00-%s-%s-%02x",
-			spanContext.TraceID().String(),
-			spanContext.SpanID().String(),
-			spanContext.TraceFlags())
-		query = fmt.Sprintf("/* traceparent: %s */ %s", traceparent, query)
+	query = s.withTraceComment(span, query)
+
+	// The count runs alongside the list so it adds little latency. It is
+	// collected before returning on every path so the goroutine never leaks.
+	var countDone chan activityCount
+	if spec.IncludeTotal {
+		countQuery, countArgs, err := s.buildActivityCountQuery(ctx, spec, scope)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "failed to build count query")
+			return nil, err
+		}
+		countQuery = s.withTraceComment(span, countQuery)
+
+		countDone = make(chan activityCount, 1)
+		go func() {
+			var c activityCount
+			c.err = s.conn.QueryRow(ctx, countQuery, countArgs...).Scan(&c.total)
+			countDone <- c
+		}()
+		defer func() {
+			if countDone != nil {
+				<-countDone
+			}
+		}()
 	}
 
 	rows, err := s.conn.Query(ctx, query, args...)
 	if err != nil {
+		if isQueryCancelled(ctx, err) {
+			recordQueryCancelled(span)
+			return nil, errQueryCancelled
+		}
 		span.RecordError(err)
+		if IsQueryQueueTimeout(err) {
+			span.SetStatus(codes.Error, "query queue timeout")
+			return nil, err
+		}
 		span.SetStatus(codes.Error, "query execution failed")
 		klog.ErrorS(err, "Failed to query activities")
 		return nil, fmt.Errorf("unable to retrieve activities. Try again or contact support if the problem persists")
@@ -701,29 +1177,106 @@ func (s *ClickHouseStorage) QueryActivities(ctx context.Context, spec ActivityQu
 		limit = s.config.MaxPageSize
 	}
 
-	var activities []string
+	traceID := span.SpanContext().TraceID().String()
+	spanID := span.SpanContext().SpanID().String()
+
+	// The query fetches one row more than the limit to detect another page.
+	// Count rows rather than decoded activities, so a row that fails to
+	// unmarshal doesn't hide the next page.
+	var activities []v1alpha1.Activity
+	var unmarshalErrors int
+	var scanned int32
+	hasMore := false
 	for rows.Next() {
+		scanned++
+		if scanned > limit {
+			hasMore = true
+			break
+		}
+
 		var activityJSON string
 		if err := rows.Scan(&activityJSON); err != nil {
 			klog.ErrorS(err, "Failed to scan activity row")
 			return nil, fmt.Errorf("unable to retrieve activities. Try again or contact support if the problem persists")
 		}
-		activities = append(activities, activityJSON)
+
+		var activity v1alpha1.Activity
+		if err := json.Unmarshal([]byte(activityJSON), &activity); err != nil {
+			unmarshalErrors++
+			klog.ErrorS(err, "Failed to unmarshal activity",
+				"traceID", traceID,
+				"spanID", spanID,
+			)
+			continue
+		}
+
+		activities = append(activities, activity)
 	}
 
 	if err := rows.Err(); err != nil {
 		klog.ErrorS(err, "Error iterating activity rows")
 		return nil, fmt.Errorf("unable to retrieve activities. Try again or contact support if the problem persists")
 	}
+	// Free this query's concurrency slot now; the count may be queued behind it
+	_ = rows.Close()
+
+	if unmarshalErrors > 0 {
+		klog.InfoS("Activity query completed with unmarshal errors",
+			"traceID", traceID,
+			"spanID", spanID,
+			"unmarshalErrors", unmarshalErrors,
+			"successfulActivities", len(activities),
+		)
+	}
 
-	// Check for more results
+	// Create continue token from the last activity on the page. If the rows
+	// after it failed to unmarshal, the next page starts with them and skips
+	// them again. A full page with nothing decoded leaves no position to
+	// continue from, so it fails rather than look like the last page.
 	var continueToken string
-	if int32(len(activities)) > limit {
-		activities = activities[:limit]
-		// Create continue token from last activity timestamp
-		if len(activities) > 0 {
-			continueToken = encodeActivityCursor(activities[len(activities)-1], spec)
+	if hasMore {
+		if len(activities) == 0 {
+			err := fmt.Errorf("none of the %d activities on the page could be unmarshalled", unmarshalErrors)
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "unable to continue")
+			klog.ErrorS(err, "Unable to continue activity query",
+				"traceID", traceID,
+				"spanID", spanID,
+			)
+			return nil, fmt.Errorf("unable to read the activities on this page. Narrow the time range or filter, or contact support if the problem persists")
 		}
+		continueToken = encodeActivityCursor(&activities[len(activities)-1], spec)
+	}
+
+	result := &ActivityQueryResult{
+		Activities: activities,
+		Continue:   continueToken,
+	}
+
+	if countDone != nil {
+		c := <-countDone
+		countDone = nil
+		if c.err != nil {
+			if isQueryCancelled(ctx, c.err) {
+				recordQueryCancelled(span)
+				return nil, errQueryCancelled
+			}
+			span.RecordError(c.err)
+			if IsQueryQueueTimeout(c.err) {
+				span.SetStatus(codes.Error, "query queue timeout")
+				return nil, c.err
+			}
+			span.SetStatus(codes.Error, "count query failed")
+			klog.ErrorS(c.err, "Failed to count activities")
+			return nil, fmt.Errorf("unable to count activities. Try again without includeTotal or contact support if the problem persists")
+		}
+		total := int64(c.total)
+		if total > ActivityTotalCap {
+			total = ActivityTotalCap
+			result.TotalCapped = true
+		}
+		result.Total = &total
+		span.SetAttributes(attribute.Int64("query.total", total))
 	}
 
 	span.SetAttributes(
@@ -732,18 +1285,126 @@ func (s *ClickHouseStorage) QueryActivities(ctx context.Context, spec ActivityQu
 	)
 	span.SetStatus(codes.Ok, "query successful")
 
-	return &ActivityQueryResult{
-		Activities: activities,
-		Continue:   continueToken,
-	}, nil
+	return result, nil
+}
+
+// activityCount is the outcome of an activity count query.
+type activityCount struct {
+	total uint64
+	err   error
 }
 
 // buildActivityQuery constructs a ClickHouse SQL query for activities.
 func (s *ClickHouseStorage) buildActivityQuery(ctx context.Context, spec ActivityQuerySpec, scope ScopeContext) (string, []interface{}, error) {
-	var args []interface{}
-	query := fmt.Sprintf("SELECT activity_json FROM %s.activities", s.config.Database)
+	query := fmt.Sprintf("SELECT activity_json FROM %s", s.table("activities"))
+
+	conditions, args, err := buildActivityConditions(ctx, spec, scope, s.Now())
+	if err != nil {
+		return "", nil, err
+	}
+
+	orderColumn, ok := activityOrderColumns[spec.OrderBy]
+	if !ok && !IsTimestampOrder(spec.OrderBy) {
+		return "", nil, fmt.Errorf("unsupported orderBy %q. Supported values: %s", spec.OrderBy, strings.Join(SupportedActivityOrders(), ", "))
+	}
+
+	// Pagination cursor aligned with the new time-bucketed ORDER BY clauses.
+	// The 3-level toStartOfHour pattern ensures correct pagination across hour boundaries.
+	if spec.Continue != "" {
+		cursor, err := decodeActivityCursor(spec.Continue, spec)
+		if err != nil {
+			return "", nil, err
+		}
+		if orderColumn != "" {
+			// Sort key ascending, then newest first within the same key
+			conditions = append(conditions, fmt.Sprintf("(%[1]s > ? OR (%[1]s = ? AND timestamp < ?) OR (%[1]s = ? AND timestamp = ? AND resource_uid < ?))", orderColumn))
+			args = append(args, cursor.SortKey, cursor.SortKey, cursor.Timestamp, cursor.SortKey, cursor.Timestamp, cursor.ResourceUID)
+		} else {
+			// Pagination logic: continue from where we left off
+			// 1. Hour bucket is earlier, OR
+			// 2. Same hour bucket but timestamp is earlier, OR
+			// 3. Same timestamp but resource_uid is earlier (for tie-breaking)
+			conditions = append(conditions, "(toStartOfHour(timestamp) < toStartOfHour(?) OR (toStartOfHour(timestamp) = toStartOfHour(?) AND timestamp < ?) OR (timestamp = ? AND resource_uid < ?))")
+			args = append(args, cursor.Timestamp, cursor.Timestamp, cursor.Timestamp, cursor.Timestamp, cursor.ResourceUID)
+		}
+	}
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
 
+	// ORDER BY must match projection/primary key sort order for ClickHouse
+	// to efficiently use indexes and projections.
+	//
+	// Primary key: (toStartOfHour(timestamp), timestamp, tenant_type, tenant_name, origin_id)
+	// Projections:
+	//   - platform_query_projection: (toStartOfHour(timestamp), timestamp, api_group, resource_kind, resource_uid)
+	//   - actor_query_projection:    (toStartOfHour(timestamp), timestamp, actor_name, api_group, resource_kind, resource_uid)
+	//   - actor_uid_query_projection: (toStartOfHour(timestamp), timestamp, actor_uid, api_group, resource_kind, resource_uid)
+	//
+	// Other orderings still prune by time range through the same indexes but
+	// sort the window in memory, which is why their window is capped.
+	if orderColumn != "" {
+		query += fmt.Sprintf(" ORDER BY %s ASC, timestamp DESC, resource_uid DESC", orderColumn)
+	} else if scope.Type == "platform" && spec.TenantType == "" {
+		if hasActorFilter(spec.Filter) {
+			// Actor filter present: use actor_query_projection
+			query += " ORDER BY toStartOfHour(timestamp) DESC, timestamp DESC, actor_name DESC, api_group DESC, resource_kind DESC, resource_uid DESC"
+		} else {
+			// No actor filter: use platform_query_projection
+			query += " ORDER BY toStartOfHour(timestamp) DESC, timestamp DESC, api_group DESC, resource_kind DESC, resource_uid DESC"
+		}
+	} else if scope.Type == types.TenantTypeUser {
+		// User-scoped: use actor_uid_query_projection to filter by UID
+		query += " ORDER BY toStartOfHour(timestamp) DESC, timestamp DESC, actor_uid DESC, api_group DESC, resource_kind DESC, resource_uid DESC"
+	} else {
+		// Tenant-scoped, or a platform query narrowed to one tenant: match
+		// hour-bucketed primary key for efficient index use
+		query += " ORDER BY toStartOfHour(timestamp) DESC, timestamp DESC, tenant_type DESC, tenant_name DESC, origin_id DESC"
+	}
+
+	// Limit
+	limit := spec.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+	if limit > s.config.MaxPageSize {
+		limit = s.config.MaxPageSize
+	}
+	query += fmt.Sprintf(" LIMIT %d", limit+1)
+	query += s.querySettings(scope)
+
+	return query, args, nil
+}
+
+// ActivityTotalCap is the most activities an includeTotal count reads. Larger
+// totals are reported as the cap with TotalCapped set, which bounds the cost
+// of counting a broad query over a long time range.
+const ActivityTotalCap = 10000
+
+// buildActivityCountQuery constructs a query counting the activities that
+// match spec across all pages, up to ActivityTotalCap. It shares the list
+// query's conditions but ignores the cursor, ordering and limit.
+func (s *ClickHouseStorage) buildActivityCountQuery(ctx context.Context, spec ActivityQuerySpec, scope ScopeContext) (string, []interface{}, error) {
+	conditions, args, err := buildActivityConditions(ctx, spec, scope, s.Now())
+	if err != nil {
+		return "", nil, err
+	}
+
+	inner := fmt.Sprintf("SELECT 1 FROM %s", s.table("activities"))
+	if len(conditions) > 0 {
+		inner += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	// Counting a limited subquery lets ClickHouse stop reading at the cap
+	// instead of scanning the whole window.
+	return fmt.Sprintf("SELECT count() FROM (%s LIMIT %d)", inner, ActivityTotalCap+1) + s.querySettings(scope), args, nil
+}
+
+// buildActivityConditions returns the WHERE conditions shared by the activity
+// list and count queries: scope, tenant, time range, search and filter.
+func buildActivityConditions(ctx context.Context, spec ActivityQuerySpec, scope ScopeContext, now time.Time) ([]string, []interface{}, error) {
 	var conditions []string
+	var args []interface{}
 
 	// Scope filtering
 	if scope.Type != types.TenantTypePlatform {
@@ -759,14 +1420,19 @@ func (s *ClickHouseStorage) buildActivityQuery(ctx context.Context, spec Activit
 			conditions = append(conditions, "tenant_name = ?")
 			args = append(args, scope.Name)
 		}
+	} else if spec.TenantType != "" {
+		// Platform caller narrowing to one tenant
+		conditions = append(conditions, "tenant_type = ?")
+		args = append(args, spec.TenantType)
+		conditions = append(conditions, "tenant_name = ?")
+		args = append(args, spec.TenantName)
 	}
 
 	// Time range
-	now := time.Now()
 	if spec.StartTime != "" {
 		startTime, err := timeutil.ParseFlexibleTime(spec.StartTime, now)
 		if err != nil {
-			return "", nil, fmt.Errorf("invalid startTime: %w", err)
+			return nil, nil, fmt.Errorf("invalid startTime: %w", err)
 		}
 		conditions = append(conditions, "timestamp >= ?")
 		args = append(args, startTime)
@@ -775,7 +1441,7 @@ func (s *ClickHouseStorage) buildActivityQuery(ctx context.Context, spec Activit
 	if spec.EndTime != "" {
 		endTime, err := timeutil.ParseFlexibleTime(spec.EndTime, now)
 		if err != nil {
-			return "", nil, fmt.Errorf("invalid endTime: %w", err)
+			return nil, nil, fmt.Errorf("invalid endTime: %w", err)
 		}
 		conditions = append(conditions, "timestamp < ?")
 		args = append(args, endTime)
@@ -795,7 +1461,7 @@ func (s *ClickHouseStorage) buildActivityQuery(ctx context.Context, spec Activit
 	if spec.Filter != "" {
 		celWhere, celArgs, err := cel.ConvertActivityToClickHouseSQL(ctx, spec.Filter)
 		if err != nil {
-			return "", nil, err
+			return nil, nil, err
 		}
 		if celWhere != "" {
 			processedWhere := celWhere
@@ -808,71 +1474,20 @@ func (s *ClickHouseStorage) buildActivityQuery(ctx context.Context, spec Activit
 		}
 	}
 
-	// Pagination cursor aligned with the new time-bucketed ORDER BY clauses.
-	// The 3-level toStartOfHour pattern ensures correct pagination across hour boundaries.
-	if spec.Continue != "" {
-		cursorTime, cursorUID, err := decodeActivityCursor(spec.Continue, spec)
-		if err != nil {
-			return "", nil, err
-		}
-		// Pagination logic: continue from where we left off
-		// 1. Hour bucket is earlier, OR
-		// 2. Same hour bucket but timestamp is earlier, OR
-		// 3. Same timestamp but resource_uid is earlier (for tie-breaking)
-		conditions = append(conditions, "(toStartOfHour(timestamp) < toStartOfHour(?) OR (toStartOfHour(timestamp) = toStartOfHour(?) AND timestamp < ?) OR (timestamp = ? AND resource_uid < ?))")
-		args = append(args, cursorTime, cursorTime, cursorTime, cursorTime, cursorUID)
-	}
-
-	if len(conditions) > 0 {
-		query += " WHERE " + strings.Join(conditions, " AND ")
-	}
-
-	// ORDER BY must match projection/primary key sort order for ClickHouse
-	// to efficiently use indexes and projections.
-	//
-	// Primary key: (toStartOfHour(timestamp), timestamp, tenant_type, tenant_name, origin_id)
-	// Projections:
-	//   - platform_query_projection: (toStartOfHour(timestamp), timestamp, api_group, resource_kind, resource_uid)
-	//   - actor_query_projection:    (toStartOfHour(timestamp), timestamp, actor_name, api_group, resource_kind, resource_uid)
-	//   - actor_uid_query_projection: (toStartOfHour(timestamp), timestamp, actor_uid, api_group, resource_kind, resource_uid)
-	if scope.Type == "platform" {
-		if hasActorFilter(spec.Filter) {
-			// Actor filter present: use actor_query_projection
-			query += " ORDER BY toStartOfHour(timestamp) DESC, timestamp DESC, actor_name DESC, api_group DESC, resource_kind DESC, resource_uid DESC"
-		} else {
-			// No actor filter: use platform_query_projection
-			query += " ORDER BY toStartOfHour(timestamp) DESC, timestamp DESC, api_group DESC, resource_kind DESC, resource_uid DESC"
-		}
-	} else if scope.Type == types.TenantTypeUser {
-		// User-scoped: use actor_uid_query_projection to filter by UID
-		query += " ORDER BY toStartOfHour(timestamp) DESC, timestamp DESC, actor_uid DESC, api_group DESC, resource_kind DESC, resource_uid DESC"
-	} else {
-		// Tenant-scoped: match hour-bucketed primary key for efficient index use
-		query += " ORDER BY toStartOfHour(timestamp) DESC, timestamp DESC, tenant_type DESC, tenant_name DESC, origin_id DESC"
-	}
-
-	// Limit
-	limit := spec.Limit
-	if limit <= 0 {
-		limit = 100
-	}
-	if limit > s.config.MaxPageSize {
-		limit = s.config.MaxPageSize
-	}
-	query += fmt.Sprintf(" LIMIT %d", limit+1)
-
-	return query, args, nil
+	return conditions, args, nil
 }
 
 // activityCursorData encodes pagination state for activity queries.
 type activityCursorData struct {
 	Timestamp   time.Time `json:"t"`
 	ResourceUID string    `json:"r"`
+	SortKey     string    `json:"k,omitempty"`
 	QueryHash   string    `json:"h"`
 	IssuedAt    time.Time `json:"i"`
 }
 
-// hashActivityQueryParams creates a hash to validate cursors.
+// hashActivityQueryParams creates a hash to validate cursors. Limit is excluded
+// so clients can change the page size between pages.
 func hashActivityQueryParams(spec ActivityQuerySpec) string {
 	h := sha256.New()
 	h.Write([]byte(spec.StartTime))
@@ -882,35 +1497,28 @@ func hashActivityQueryParams(spec ActivityQuerySpec) string {
 	h.Write([]byte(spec.Filter))
 	h.Write([]byte("|"))
 	h.Write([]byte(spec.Search))
-	h.Write([]byte("|"))
-	h.Write([]byte(fmt.Sprintf("%d", spec.Limit)))
+	if !IsTimestampOrder(spec.OrderBy) {
+		// Only hashed when set so cursors issued before orderBy existed stay valid
+		h.Write([]byte("|"))
+		h.Write([]byte(spec.OrderBy))
+	}
+	if spec.TenantType != "" {
+		// Only hashed when set so cursors issued before tenant filters existed stay valid
+		h.Write([]byte("|"))
+		h.Write([]byte(spec.TenantType))
+		h.Write([]byte("|"))
+		h.Write([]byte(spec.TenantName))
+	}
 
 	return base64.URLEncoding.EncodeToString(h.Sum(nil)[:16])
 }
 
 // encodeActivityCursor creates a pagination token from the last activity.
-func encodeActivityCursor(lastActivityJSON string, spec ActivityQuerySpec) string {
-	// Extract timestamp and resource_uid from JSON
-	var activity struct {
-		Metadata struct {
-			CreationTimestamp string `json:"creationTimestamp"`
-		} `json:"metadata"`
-		Spec struct {
-			Resource struct {
-				UID string `json:"uid"`
-			} `json:"resource"`
-		} `json:"spec"`
-	}
-
-	if err := json.Unmarshal([]byte(lastActivityJSON), &activity); err != nil {
-		return ""
-	}
-
-	timestamp, _ := time.Parse(time.RFC3339, activity.Metadata.CreationTimestamp)
-
+func encodeActivityCursor(lastActivity *v1alpha1.Activity, spec ActivityQuerySpec) string {
 	data := activityCursorData{
-		Timestamp:   timestamp,
-		ResourceUID: activity.Spec.Resource.UID,
+		Timestamp:   lastActivity.CreationTimestamp.Time,
+		ResourceUID: lastActivity.Spec.Resource.UID,
+		SortKey:     activityOrderKey(lastActivity, spec.OrderBy),
 		QueryHash:   hashActivityQueryParams(spec),
 		IssuedAt:    time.Now(),
 	}
@@ -920,41 +1528,61 @@ func encodeActivityCursor(lastActivityJSON string, spec ActivityQuerySpec) strin
 }
 
 // decodeActivityCursor validates and extracts pagination state.
-func decodeActivityCursor(cursor string, spec ActivityQuerySpec) (time.Time, string, error) {
+func decodeActivityCursor(cursor string, spec ActivityQuerySpec) (*activityCursorData, error) {
 	decoded, err := base64.URLEncoding.DecodeString(cursor)
 	if err != nil {
-		return time.Time{}, "", fmt.Errorf("the continue token is invalid. Remove the continue parameter to start a new query")
+		return nil, fmt.Errorf("the continue token is invalid. Remove the continue parameter to start a new query")
 	}
 
 	var data activityCursorData
 	if err := json.Unmarshal(decoded, &data); err != nil {
-		return time.Time{}, "", fmt.Errorf("the continue token is invalid. Remove the continue parameter to start a new query")
+		return nil, fmt.Errorf("the continue token is invalid. Remove the continue parameter to start a new query")
 	}
 
 	currentHash := hashActivityQueryParams(spec)
 	if data.QueryHash != currentHash {
-		return time.Time{}, "", fmt.Errorf("query parameters changed since the continue token was issued. Remove the continue parameter and use consistent query parameters when paginating")
+		return nil, fmt.Errorf("query parameters changed since the continue token was issued. Remove the continue parameter and use consistent query parameters when paginating")
 	}
 
 	if time.Since(data.IssuedAt) > cursorTTL {
-		return time.Time{}, "", fmt.Errorf("the continue token expired after %v. Tokens are valid for %v. Remove the continue parameter to start a new query",
+		return nil, fmt.Errorf("the continue token expired after %v. Tokens are valid for %v. Remove the continue parameter to start a new query",
 			time.Since(data.IssuedAt).Round(time.Second),
 			cursorTTL,
 		)
 	}
 
-	return data.Timestamp, data.ResourceUID, nil
+	return &data, nil
 }
 
 // FacetFieldSpec defines a single facet field to query.
 type FacetFieldSpec struct {
 	Field string
 	Limit int32
+	Order v1alpha1.FacetOrder
 }
 
 // FacetQueryResult contains the results of a facet query.
 type FacetQueryResult struct {
 	Facets []FacetFieldResult
+
+	// Errors lists facets that failed when partial results were requested.
+	Errors []FacetFieldError
+}
+
+// FacetFieldError records a facet that could not be computed.
+type FacetFieldError struct {
+	Field string
+	Err   error
+}
+
+// transientFacetError marks a facet failure caused by ClickHouse rather than
+// the request itself. These are worth retrying; validation errors are not.
+type transientFacetError struct {
+	msg string
+}
+
+func (e *transientFacetError) Error() string {
+	return e.msg
 }
 
 // FacetFieldResult contains the distinct values for a single facet.
@@ -978,8 +1606,15 @@ type AuditLogFacetQuerySpec struct {
 	// Filter is a CEL expression to filter audit logs before computing facets.
 	Filter string
 
+	// MutationsOnly counts only requests that change resources.
+	MutationsOnly bool
+
 	// Facets are the fields to compute distinct values for.
 	Facets []FacetFieldSpec
+
+	// PartialResults collects per-facet failures into the result instead of
+	// failing the whole query.
+	PartialResults bool
 }
 
 // QueryAuditLogFacets retrieves distinct field values with counts for audit log faceted search.
@@ -995,6 +1630,11 @@ func (s *ClickHouseStorage) QueryAuditLogFacets(ctx context.Context, spec AuditL
 	)
 	defer span.End()
 
+	startTime := time.Now()
+	defer func() {
+		metrics.ObserveClickHouseQueryDuration("facet", time.Since(startTime).Seconds())
+	}()
+
 	result := &FacetQueryResult{
 		Facets: make([]FacetFieldResult, 0, len(spec.Facets)),
 	}
@@ -1002,15 +1642,32 @@ func (s *ClickHouseStorage) QueryAuditLogFacets(ctx context.Context, spec AuditL
 	// Execute each facet query
 	for _, facet := range spec.Facets {
 		facetResult, err := s.queryAuditLogFacet(ctx, facet, spec, scope)
+		var transient *transientFacetError
+		if err != nil && errors.As(err, &transient) && ctx.Err() == nil {
+			// Facet queries are independent and cheap, so retry once to ride out
+			// transient ClickHouse errors before giving up on this field.
+			klog.V(2).InfoS("Retrying audit log facet after transient error", "field", facet.Field, "error", err)
+			facetResult, err = s.queryAuditLogFacet(ctx, facet, spec, scope)
+		}
 		if err != nil {
 			span.RecordError(err)
 			klog.ErrorS(err, "Failed to query audit log facet", "field", facet.Field)
-			// Return the error directly - queryAuditLogFacet returns user-friendly validation errors
-			return nil, err
+			if !spec.PartialResults {
+				// Return the error directly - queryAuditLogFacet returns user-friendly validation errors
+				return nil, err
+			}
+			result.Errors = append(result.Errors, FacetFieldError{Field: facet.Field, Err: err})
+			continue
 		}
 		result.Facets = append(result.Facets, *facetResult)
 	}
 
+	// Partial results are only useful if something succeeded
+	if len(spec.Facets) > 0 && len(result.Facets) == 0 && len(result.Errors) > 0 {
+		return nil, result.Errors[0].Err
+	}
+
+	span.SetAttributes(attribute.Int("facet.error_count", len(result.Errors)))
 	span.SetStatus(codes.Ok, "audit log facet query successful")
 	return result, nil
 }
@@ -1049,7 +1706,7 @@ func (s *ClickHouseStorage) queryAuditLogFacet(ctx context.Context, facet FacetF
 	}
 
 	// Time range
-	now := time.Now()
+	now := s.Now()
 	if spec.StartTime != "" {
 		startTime, err := timeutil.ParseFlexibleTime(spec.StartTime, now)
 		if err != nil {
@@ -1085,16 +1742,28 @@ func (s *ClickHouseStorage) queryAuditLogFacet(ctx context.Context, facet FacetF
 		}
 	}
 
+	if spec.MutationsOnly {
+		condition, verbs := mutationsOnlyCondition()
+		conditions = append(conditions, condition)
+		args = append(args, verbs...)
+	}
+
 	// Build query against the audit logs table
 	// Use toString() to ensure consistent string output for all column types (including UInt16 status_code)
-	query := fmt.Sprintf("SELECT toString(%s) as value, COUNT(*) as count FROM %s.audit_logs", column, s.config.Database)
+	query := fmt.Sprintf("SELECT toString(%s) as value, COUNT(*) as count FROM %s", column, s.table("audit_logs"))
 
 	if len(conditions) > 0 {
 		query += " WHERE " + strings.Join(conditions, " AND ")
 	}
 
-	// Group by the facet column and order by count descending, then value ascending for stability
-	query += fmt.Sprintf(" GROUP BY %s ORDER BY count DESC, value ASC LIMIT %d", column, limit)
+	// Identical arrayJoin expressions in SELECT and GROUP BY are unnested once, not twice.
+	// Values are strings here, so numeric fields sort on their own sort key.
+	sortKey := "value"
+	if key, ok := auditLogFacetSortKeys[facet.Field]; ok {
+		sortKey = key
+	}
+	query += fmt.Sprintf(" GROUP BY %s ORDER BY %s LIMIT %d", column, facetOrderBy(facet.Order, sortKey), limit)
+	query += s.querySettings(scope, facetGroupBySettings(s.config.MaxFacetGroupByRows)...)
 
 	klog.V(4).InfoS("Executing audit log facet query",
 		"field", facet.Field,
@@ -1104,8 +1773,14 @@ func (s *ClickHouseStorage) queryAuditLogFacet(ctx context.Context, facet FacetF
 
 	rows, err := s.conn.Query(ctx, query, args...)
 	if err != nil {
+		if IsQueryQueueTimeout(err) {
+			return nil, err
+		}
+		if overflow := facetGroupByOverflow(err, facet.Field, s.config.MaxFacetGroupByRows); overflow != nil {
+			return nil, overflow
+		}
 		klog.ErrorS(err, "Failed to execute audit log facet query", "field", facet.Field)
-		return nil, fmt.Errorf("unable to retrieve facet data for field '%s'. Try again or contact support if the problem persists", facet.Field)
+		return nil, &transientFacetError{msg: fmt.Sprintf("unable to retrieve facet data for field '%s'. Try again or contact support if the problem persists", facet.Field)}
 	}
 	defer rows.Close()
 
@@ -1119,7 +1794,7 @@ func (s *ClickHouseStorage) queryAuditLogFacet(ctx context.Context, facet FacetF
 		var count uint64
 		if err := rows.Scan(&value, &count); err != nil {
 			klog.ErrorS(err, "Failed to scan audit log facet row", "field", facet.Field)
-			return nil, fmt.Errorf("unable to retrieve facet data for field '%s'. Try again or contact support if the problem persists", facet.Field)
+			return nil, &transientFacetError{msg: fmt.Sprintf("unable to retrieve facet data for field '%s'. Try again or contact support if the problem persists", facet.Field)}
 		}
 		result.Values = append(result.Values, FacetValueResult{
 			Value: value,
@@ -1128,8 +1803,11 @@ func (s *ClickHouseStorage) queryAuditLogFacet(ctx context.Context, facet FacetF
 	}
 
 	if err := rows.Err(); err != nil {
+		if overflow := facetGroupByOverflow(err, facet.Field, s.config.MaxFacetGroupByRows); overflow != nil {
+			return nil, overflow
+		}
 		klog.ErrorS(err, "Error iterating audit log facet rows", "field", facet.Field)
-		return nil, fmt.Errorf("unable to retrieve facet data for field '%s'. Try again or contact support if the problem persists", facet.Field)
+		return nil, &transientFacetError{msg: fmt.Sprintf("unable to retrieve facet data for field '%s'. Try again or contact support if the problem persists", facet.Field)}
 	}
 
 	return result, nil
@@ -1161,6 +1839,11 @@ func (s *ClickHouseStorage) QueryFacets(ctx context.Context, spec FacetQuerySpec
 	)
 	defer span.End()
 
+	startTime := time.Now()
+	defer func() {
+		metrics.ObserveClickHouseQueryDuration("facet", time.Since(startTime).Seconds())
+	}()
+
 	result := &FacetQueryResult{
 		Facets: make([]FacetFieldResult, 0, len(spec.Facets)),
 	}
@@ -1214,7 +1897,7 @@ func (s *ClickHouseStorage) queryFacet(ctx context.Context, facet FacetFieldSpec
 	}
 
 	// Time range
-	now := time.Now()
+	now := s.Now()
 	if spec.StartTime != "" {
 		startTime, err := timeutil.ParseFlexibleTime(spec.StartTime, now)
 		if err != nil {
@@ -1250,14 +1933,14 @@ func (s *ClickHouseStorage) queryFacet(ctx context.Context, facet FacetFieldSpec
 		}
 	}
 
-	query := fmt.Sprintf("SELECT %s, COUNT(*) as count FROM %s.activities", column, s.config.Database)
+	query := fmt.Sprintf("SELECT %s, COUNT(*) as count FROM %s", column, s.table("activities"))
 
 	if len(conditions) > 0 {
 		query += " WHERE " + strings.Join(conditions, " AND ")
 	}
 
-	// Group by the facet column and order by count descending, then value ascending for stability
-	query += fmt.Sprintf(" GROUP BY %s ORDER BY count DESC, %s ASC LIMIT %d", column, column, limit)
+	query += fmt.Sprintf(" GROUP BY %s ORDER BY %s LIMIT %d", column, facetOrderBy(facet.Order, column), limit)
+	query += s.querySettings(scope, facetGroupBySettings(s.config.MaxFacetGroupByRows)...)
 
 	klog.V(4).InfoS("Executing facet query",
 		"field", facet.Field,
@@ -1267,6 +1950,12 @@ func (s *ClickHouseStorage) queryFacet(ctx context.Context, facet FacetFieldSpec
 
 	rows, err := s.conn.Query(ctx, query, args...)
 	if err != nil {
+		if IsQueryQueueTimeout(err) {
+			return nil, err
+		}
+		if overflow := facetGroupByOverflow(err, facet.Field, s.config.MaxFacetGroupByRows); overflow != nil {
+			return nil, overflow
+		}
 		klog.ErrorS(err, "Failed to execute facet query", "field", facet.Field)
 		return nil, fmt.Errorf("unable to retrieve facet data for field '%s'. Try again or contact support if the problem persists", facet.Field)
 	}
@@ -1291,6 +1980,9 @@ func (s *ClickHouseStorage) queryFacet(ctx context.Context, facet FacetFieldSpec
 	}
 
 	if err := rows.Err(); err != nil {
+		if overflow := facetGroupByOverflow(err, facet.Field, s.config.MaxFacetGroupByRows); overflow != nil {
+			return nil, overflow
+		}
 		klog.ErrorS(err, "Error iterating facet rows", "field", facet.Field)
 		return nil, fmt.Errorf("unable to retrieve facet data for field '%s'. Try again or contact support if the problem persists", facet.Field)
 	}
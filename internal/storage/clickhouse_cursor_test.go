@@ -1,13 +1,18 @@
 package storage
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+
 	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+	"go.miloapis.com/activity/pkg/celutil"
 )
 
 func TestCursorEncodeDecodeRoundtrip(t *testing.T) {
@@ -144,17 +149,41 @@ func TestCursorValidation_LimitChanged(t *testing.T) {
 
 	cursor := encodeCursor(timestamp, auditID, originalSpec)
 
-	// Change limit
-	modifiedSpec := v1alpha1.AuditLogQuerySpec{
-		StartTime: "2024-01-01T00:00:00Z",
-		EndTime:   "2024-01-02T00:00:00Z",
-		Filter:    "verb == 'delete'",
-		Limit:     500, // Changed!
+	// Change limit: only the size of the next page changes, not its position
+	modifiedSpec := originalSpec
+	modifiedSpec.Limit = 500
+
+	gotTime, gotAuditID, err := decodeCursor(cursor, modifiedSpec)
+	if err != nil {
+		t.Fatalf("unexpected error when limit changed: %v", err)
 	}
+	if !gotTime.Equal(timestamp) || gotAuditID != auditID {
+		t.Errorf("cursor position = (%v, %q), want (%v, %q)", gotTime, gotAuditID, timestamp, auditID)
+	}
+}
 
-	_, _, err := decodeCursor(cursor, modifiedSpec)
-	if err == nil {
-		t.Fatal("expected error when limit changed, got nil")
+func TestBuildQuery_LimitChangedBetweenPages(t *testing.T) {
+	s := &ClickHouseStorage{config: ClickHouseConfig{Database: "audit", MaxPageSize: 1000}}
+	platform := ScopeContext{Type: "platform"}
+	cursorTime := time.Now().Add(-30 * time.Minute)
+
+	firstPage := v1alpha1.AuditLogQuerySpec{StartTime: "now-1h", EndTime: "now", Limit: 10}
+	nextPage := firstPage
+	nextPage.Limit = 50
+	nextPage.Continue = encodeCursor(cursorTime, "audit-10", firstPage)
+
+	query, args, err := s.buildQuery(context.Background(), nextPage, platform)
+	if err != nil {
+		t.Fatalf("buildQuery failed: %v", err)
+	}
+	if !strings.Contains(query, "(timestamp = ? AND audit_id < ?)") {
+		t.Errorf("expected the cursor condition in query, got: %s", query)
+	}
+	if !strings.HasSuffix(query, " LIMIT 51") {
+		t.Errorf("expected the new page size in query, got: %s", query)
+	}
+	if args[len(args)-1] != "audit-10" {
+		t.Errorf("expected the query to continue after audit-10, got args: %v", args)
 	}
 }
 
@@ -398,3 +427,446 @@ func TestCursorExpiration_EdgeCase_ExactlyAtTTL(t *testing.T) {
 		t.Fatal("expected error for cursor at TTL boundary, got nil")
 	}
 }
+
+func TestActivityCursor_OrderBySortKey(t *testing.T) {
+	spec := ActivityQuerySpec{
+		StartTime: "2024-01-01T00:00:00Z",
+		EndTime:   "2024-01-01T12:00:00Z",
+		Limit:     50,
+		OrderBy:   ActivityOrderByActorName,
+	}
+	activity := &v1alpha1.Activity{}
+	activity.CreationTimestamp.Time = time.Date(2024, 1, 1, 6, 0, 0, 0, time.UTC)
+	activity.Spec.Actor.Name = "alice@example.com"
+	activity.Spec.Resource.UID = "uid-1"
+
+	cursor := encodeActivityCursor(activity, spec)
+
+	data, err := decodeActivityCursor(cursor, spec)
+	if err != nil {
+		t.Fatalf("decodeActivityCursor failed: %v", err)
+	}
+	if data.SortKey != "alice@example.com" {
+		t.Errorf("sort key mismatch: got %q, want %q", data.SortKey, "alice@example.com")
+	}
+	if data.ResourceUID != "uid-1" {
+		t.Errorf("resource UID mismatch: got %q, want %q", data.ResourceUID, "uid-1")
+	}
+
+	// A cursor from one ordering can't continue another
+	spec.OrderBy = ActivityOrderByAPIGroup
+	if _, err := decodeActivityCursor(cursor, spec); err == nil || !strings.Contains(err.Error(), "query parameters changed") {
+		t.Errorf("expected 'query parameters changed' error, got: %v", err)
+	}
+}
+
+func TestBuildActivityQuery_LimitChangedBetweenPages(t *testing.T) {
+	s := &ClickHouseStorage{config: ClickHouseConfig{Database: "audit", MaxPageSize: 1000}}
+	platform := ScopeContext{Type: "platform"}
+
+	firstPage := ActivityQuerySpec{StartTime: "now-1h", EndTime: "now", Limit: 10, OrderBy: ActivityOrderByActorName}
+	last := &v1alpha1.Activity{}
+	last.CreationTimestamp.Time = time.Now().Add(-30 * time.Minute)
+	last.Spec.Actor.Name = "alice"
+	last.Spec.Resource.UID = "uid-10"
+
+	nextPage := firstPage
+	nextPage.Limit = 25
+	nextPage.Continue = encodeActivityCursor(last, firstPage)
+
+	query, _, err := s.buildActivityQuery(context.Background(), nextPage, platform)
+	if err != nil {
+		t.Fatalf("buildActivityQuery failed: %v", err)
+	}
+	if !strings.Contains(query, "(actor_name > ? OR") {
+		t.Errorf("expected the cursor condition in query, got: %s", query)
+	}
+	if !strings.HasSuffix(query, " LIMIT 26") {
+		t.Errorf("expected the new page size in query, got: %s", query)
+	}
+}
+
+func TestEventQueryCursor_LimitChangedBetweenPages(t *testing.T) {
+	b := &ClickHouseEventQueryBackend{config: ClickHouseEventsConfig{Database: "audit"}}
+	platform := ScopeContext{Type: "platform"}
+
+	// Page 1 returns 10 events, page 2 asks for 50 more, page 3 for 5 more.
+	spec := v1alpha1.EventQuerySpec{StartTime: "now-1h", EndTime: "now", Limit: 10}
+	spec.Continue = encodeEventQueryCursor(v1alpha1.EventRecord{}, spec)
+
+	spec.Limit = 50
+	query, _, err := b.buildQuery(context.Background(), spec, platform)
+	if err != nil {
+		t.Fatalf("buildQuery failed for page 2: %v", err)
+	}
+	if !strings.HasSuffix(query, " LIMIT 51 OFFSET 10") {
+		t.Errorf("page 2 should skip the 10 events already returned, got: %s", query)
+	}
+
+	spec.Continue = encodeEventQueryCursor(v1alpha1.EventRecord{}, spec)
+	spec.Limit = 5
+	query, _, err = b.buildQuery(context.Background(), spec, platform)
+	if err != nil {
+		t.Fatalf("buildQuery failed for page 3: %v", err)
+	}
+	if !strings.HasSuffix(query, " LIMIT 6 OFFSET 60") {
+		t.Errorf("page 3 should skip the 60 events already returned, got: %s", query)
+	}
+}
+
+func TestHashActivityQueryParams_TimestampOrderUnchanged(t *testing.T) {
+	spec := ActivityQuerySpec{StartTime: "now-1h", EndTime: "now", Limit: 10}
+	withOrder := spec
+	withOrder.OrderBy = ActivityOrderByTimestamp
+
+	if hashActivityQueryParams(spec) != hashActivityQueryParams(withOrder) {
+		t.Error("expected an explicit timestamp order to hash the same as the default")
+	}
+}
+
+func TestBuildActivityQuery_OrderBy(t *testing.T) {
+	s := &ClickHouseStorage{config: ClickHouseConfig{Database: "audit", MaxPageSize: 1000}}
+	platform := ScopeContext{Type: "platform"}
+
+	tests := []struct {
+		name       string
+		orderBy    string
+		wantOrder  string
+		wantCursor string
+		wantErr    bool
+	}{
+		{
+			name:       "default",
+			wantOrder:  "ORDER BY toStartOfHour(timestamp) DESC, timestamp DESC, api_group DESC",
+			wantCursor: "toStartOfHour(timestamp) < toStartOfHour(?)",
+		},
+		{
+			name:       "actor name",
+			orderBy:    ActivityOrderByActorName,
+			wantOrder:  "ORDER BY actor_name ASC, timestamp DESC, resource_uid DESC",
+			wantCursor: "(actor_name > ? OR (actor_name = ? AND timestamp < ?) OR (actor_name = ? AND timestamp = ? AND resource_uid < ?))",
+		},
+		{
+			name:       "api group",
+			orderBy:    ActivityOrderByAPIGroup,
+			wantOrder:  "ORDER BY api_group ASC, timestamp DESC, resource_uid DESC",
+			wantCursor: "(api_group > ? OR (api_group = ? AND timestamp < ?) OR (api_group = ? AND timestamp = ? AND resource_uid < ?))",
+		},
+		{
+			name:    "unsupported",
+			orderBy: "spec.summary",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := ActivityQuerySpec{StartTime: "now-1h", EndTime: "now", Limit: 10, OrderBy: tt.orderBy}
+			if !tt.wantErr {
+				last := &v1alpha1.Activity{}
+				last.CreationTimestamp.Time = time.Now().Add(-30 * time.Minute)
+				spec.Continue = encodeActivityCursor(last, spec)
+			}
+
+			query, _, err := s.buildActivityQuery(context.Background(), spec, platform)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error for an unsupported orderBy")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("buildActivityQuery failed: %v", err)
+			}
+			if !strings.Contains(query, tt.wantOrder) {
+				t.Errorf("expected %q in query, got: %s", tt.wantOrder, query)
+			}
+			if !strings.Contains(query, tt.wantCursor) {
+				t.Errorf("expected cursor condition %q in query, got: %s", tt.wantCursor, query)
+			}
+		})
+	}
+}
+
+func TestBuildActivityQuery_TenantFilter(t *testing.T) {
+	s := &ClickHouseStorage{config: ClickHouseConfig{Database: "audit", MaxPageSize: 1000}}
+	spec := ActivityQuerySpec{StartTime: "now-1h", EndTime: "now", Limit: 10, TenantType: "Organization", TenantName: "acme"}
+
+	t.Run("platform scope narrows to the tenant", func(t *testing.T) {
+		query, args, err := s.buildActivityQuery(context.Background(), spec, ScopeContext{Type: "platform"})
+		if err != nil {
+			t.Fatalf("buildActivityQuery failed: %v", err)
+		}
+		if !strings.Contains(query, "WHERE tenant_type = ? AND tenant_name = ?") {
+			t.Errorf("expected tenant conditions in query, got: %s", query)
+		}
+		if len(args) < 2 || args[0] != "Organization" || args[1] != "acme" {
+			t.Errorf("expected tenant args first, got: %v", args)
+		}
+		if !strings.Contains(query, "ORDER BY toStartOfHour(timestamp) DESC, timestamp DESC, tenant_type DESC, tenant_name DESC") {
+			t.Errorf("expected the tenant-scoped ordering, got: %s", query)
+		}
+	})
+
+	t.Run("tenant scope ignores the filter", func(t *testing.T) {
+		_, args, err := s.buildActivityQuery(context.Background(), spec, ScopeContext{Type: "Project", Name: "prod"})
+		if err != nil {
+			t.Fatalf("buildActivityQuery failed: %v", err)
+		}
+		if len(args) < 2 || args[0] != "Project" || args[1] != "prod" {
+			t.Errorf("expected the caller's scope args, got: %v", args)
+		}
+		for _, arg := range args {
+			if arg == "acme" {
+				t.Errorf("expected the tenant filter to be ignored, got args: %v", args)
+			}
+		}
+	})
+}
+
+func TestBuildActivityCountQuery(t *testing.T) {
+	s := &ClickHouseStorage{config: ClickHouseConfig{Database: "audit", MaxPageSize: 1000}}
+	scope := ScopeContext{Type: "Organization", Name: "acme"}
+	spec := ActivityQuerySpec{
+		StartTime: "now-7d",
+		EndTime:   "now",
+		Search:    "deployment",
+		Filter:    "spec.changeSource == 'human'",
+		Limit:     10,
+		OrderBy:   ActivityOrderByActorName,
+	}
+
+	list, listArgs, err := s.buildActivityQuery(context.Background(), spec, scope)
+	if err != nil {
+		t.Fatalf("buildActivityQuery failed: %v", err)
+	}
+	count, countArgs, err := s.buildActivityCountQuery(context.Background(), spec, scope)
+	if err != nil {
+		t.Fatalf("buildActivityCountQuery failed: %v", err)
+	}
+
+	// The count applies exactly the list's conditions, without its ordering
+	where := list[strings.Index(list, " WHERE "):strings.Index(list, " ORDER BY ")]
+	if !strings.Contains(count, where+" LIMIT 10001)") {
+		t.Errorf("expected the list's conditions %q in the count query, got: %s", where, count)
+	}
+	if !strings.HasPrefix(count, "SELECT count() FROM (SELECT 1 FROM audit.activities WHERE ") {
+		t.Errorf("expected a capped count over the activities table, got: %s", count)
+	}
+	if strings.Contains(count, "ORDER BY") {
+		t.Errorf("expected no ORDER BY in the count query, got: %s", count)
+	}
+	if len(countArgs) != len(listArgs) {
+		t.Errorf("expected the list's %d args, got %d", len(listArgs), len(countArgs))
+	}
+
+	// Later pages count the whole result set, not what remains after the cursor
+	last := &v1alpha1.Activity{}
+	last.CreationTimestamp.Time = time.Now().Add(-time.Hour)
+	spec.Continue = encodeActivityCursor(last, spec)
+	paged, _, err := s.buildActivityCountQuery(context.Background(), spec, scope)
+	if err != nil {
+		t.Fatalf("buildActivityCountQuery failed: %v", err)
+	}
+	if paged != count {
+		t.Errorf("expected the cursor to be ignored, got: %s", paged)
+	}
+}
+
+func TestHashActivityQueryParams_TenantFilter(t *testing.T) {
+	spec := ActivityQuerySpec{StartTime: "now-1h", EndTime: "now"}
+	withTenant := spec
+	withTenant.TenantType = "Organization"
+	withTenant.TenantName = "acme"
+
+	if hashActivityQueryParams(spec) == hashActivityQueryParams(withTenant) {
+		t.Error("expected a tenant filter to change the cursor hash")
+	}
+}
+
+func TestBuildQuery_PreviousPage(t *testing.T) {
+	s := &ClickHouseStorage{config: ClickHouseConfig{Database: "audit", MaxPageSize: 1000}}
+	platform := ScopeContext{Type: "platform"}
+	cursorTime := time.Now().Add(-30 * time.Minute)
+
+	spec := v1alpha1.AuditLogQuerySpec{StartTime: "now-1h", EndTime: "now", Limit: 10}
+	spec.Continue = encodePreviousCursor(cursorTime, "audit-11", spec)
+
+	query, args, err := s.buildQuery(context.Background(), spec, platform)
+	if err != nil {
+		t.Fatalf("buildQuery failed: %v", err)
+	}
+	if !strings.Contains(query, "(timestamp = ? AND audit_id > ?)") {
+		t.Errorf("expected the reverse cursor condition in query, got: %s", query)
+	}
+	if !strings.Contains(query, " ORDER BY toStartOfHour(timestamp) ASC, timestamp ASC,") || strings.Contains(query, "DESC") {
+		t.Errorf("expected the sort key in ascending order, got: %s", query)
+	}
+	if args[len(args)-1] != "audit-11" {
+		t.Errorf("expected the query to page back from audit-11, got args: %v", args)
+	}
+	if !isPreviousPageCursor(spec) {
+		t.Error("isPreviousPageCursor() = false for a previous cursor, want true")
+	}
+
+	// Previous cursors are bound to the query parameters like forward ones
+	changed := spec
+	changed.Filter = "verb == 'delete'"
+	if _, _, err := s.buildQuery(context.Background(), changed, platform); err == nil || !strings.Contains(err.Error(), "query parameters changed") {
+		t.Errorf("expected a parameter mismatch error, got: %v", err)
+	}
+}
+
+func TestReverseEvents(t *testing.T) {
+	events := []auditv1.Event{{AuditID: "a"}, {AuditID: "b"}, {AuditID: "c"}}
+	raw := []string{"ra", "rb", "rc"}
+
+	reverseEvents(events, raw)
+
+	if events[0].AuditID != "c" || events[2].AuditID != "a" {
+		t.Errorf("events = %v, want c, b, a", events)
+	}
+	if raw[0] != "rc" || raw[2] != "ra" {
+		t.Errorf("rawEvents = %v, want rc, rb, ra", raw)
+	}
+
+	// Raw events are optional
+	reverseEvents(events, nil)
+	if events[0].AuditID != "a" {
+		t.Errorf("events = %v, want a, b, c", events)
+	}
+}
+
+func TestBuildQuery_RelativeTo(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	s := &ClickHouseStorage{
+		config: ClickHouseConfig{Database: "audit", MaxPageSize: 1000},
+		now:    func() time.Time { return now },
+	}
+	platform := ScopeContext{Type: "platform"}
+
+	tests := []struct {
+		name       string
+		relativeTo string
+		wantStart  time.Time
+		wantEnd    time.Time
+	}{
+		{name: "pinned clock", wantStart: now.Add(-time.Hour), wantEnd: now},
+		{
+			name:       "relative to a fixed point",
+			relativeTo: "2026-06-01T00:00:00Z",
+			wantStart:  time.Date(2026, 5, 31, 23, 0, 0, 0, time.UTC),
+			wantEnd:    time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := v1alpha1.AuditLogQuerySpec{StartTime: "now-1h", EndTime: "now", RelativeTo: tt.relativeTo, Limit: 10}
+			_, args, err := s.buildQuery(context.Background(), spec, platform)
+			if err != nil {
+				t.Fatalf("buildQuery failed: %v", err)
+			}
+			if len(args) != 2 {
+				t.Fatalf("expected the start and end times as args, got: %v", args)
+			}
+			if start := args[0].(time.Time); !start.Equal(tt.wantStart) {
+				t.Errorf("start = %v, want %v", start, tt.wantStart)
+			}
+			if end := args[1].(time.Time); !end.Equal(tt.wantEnd) {
+				t.Errorf("end = %v, want %v", end, tt.wantEnd)
+			}
+		})
+	}
+
+	future := v1alpha1.AuditLogQuerySpec{StartTime: "now-1h", EndTime: "now", RelativeTo: "2026-10-17T00:00:00Z"}
+	if _, _, err := s.buildQuery(context.Background(), future, platform); err == nil {
+		t.Error("expected a relativeTo in the future to be rejected")
+	}
+}
+
+func TestQueryClock_ActivitiesAndEvents(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	s := &ClickHouseStorage{
+		config: ClickHouseConfig{Database: "audit", MaxPageSize: 1000},
+		now:    func() time.Time { return now },
+	}
+	events := NewClickHouseEventQueryBackend(nil, ClickHouseEventsConfig{Database: "audit", Now: s.Now})
+	platform := ScopeContext{Type: "platform"}
+
+	timeArgs := func(args []interface{}) []time.Time {
+		var times []time.Time
+		for _, arg := range args {
+			if ts, ok := arg.(time.Time); ok {
+				times = append(times, ts)
+			}
+		}
+		return times
+	}
+	want := []time.Time{now.Add(-time.Hour), now}
+
+	_, args, err := s.buildActivityQuery(context.Background(), ActivityQuerySpec{StartTime: "now-1h", EndTime: "now", Limit: 10}, platform)
+	if err != nil {
+		t.Fatalf("buildActivityQuery failed: %v", err)
+	}
+	if got := timeArgs(args); !reflect.DeepEqual(got, want) {
+		t.Errorf("activity query times = %v, want %v", got, want)
+	}
+
+	_, args, err = events.buildQuery(context.Background(), v1alpha1.EventQuerySpec{StartTime: "now-1h", EndTime: "now", Limit: 10}, platform)
+	if err != nil {
+		t.Fatalf("event buildQuery failed: %v", err)
+	}
+	if got := timeArgs(args); !reflect.DeepEqual(got, want) {
+		t.Errorf("event query times = %v, want %v", got, want)
+	}
+}
+
+func TestHashQueryParams_RelativeTo(t *testing.T) {
+	spec := v1alpha1.AuditLogQuerySpec{StartTime: "now-7d", EndTime: "now"}
+	pinned := spec
+	pinned.RelativeTo = "2026-06-01T00:00:00Z"
+
+	if hashQueryParams(spec) == hashQueryParams(pinned) {
+		t.Error("expected a cursor to be tied to its relativeTo")
+	}
+}
+
+func TestBuildQuery_MutationsOnly(t *testing.T) {
+	s := &ClickHouseStorage{config: ClickHouseConfig{Database: "audit", MaxPageSize: 1000}}
+	platform := ScopeContext{Type: "platform"}
+
+	spec := v1alpha1.AuditLogQuerySpec{StartTime: "now-1h", EndTime: "now", Filter: "objectRef.namespace == 'prod'", MutationsOnly: true, Limit: 10}
+	query, args, err := s.buildQuery(context.Background(), spec, platform)
+	if err != nil {
+		t.Fatalf("buildQuery failed: %v", err)
+	}
+	if !strings.Contains(query, " AND verb IN (?, ?, ?, ?, ?)") {
+		t.Errorf("expected the verb condition ANDed with the filter, got: %s", query)
+	}
+	verbs := args[len(args)-len(celutil.MutatingVerbs):]
+	for i, verb := range celutil.MutatingVerbs {
+		if verbs[i] != verb {
+			t.Errorf("expected the mutating verbs as args, got: %v", args)
+			break
+		}
+	}
+
+	spec.MutationsOnly = false
+	query, _, err = s.buildQuery(context.Background(), spec, platform)
+	if err != nil {
+		t.Fatalf("buildQuery failed: %v", err)
+	}
+	if strings.Contains(query, "verb IN") {
+		t.Errorf("expected no verb condition without mutationsOnly, got: %s", query)
+	}
+}
+
+func TestHashQueryParams_MutationsOnly(t *testing.T) {
+	spec := v1alpha1.AuditLogQuerySpec{StartTime: "now-7d", EndTime: "now"}
+	mutations := spec
+	mutations.MutationsOnly = true
+
+	if hashQueryParams(spec) == hashQueryParams(mutations) {
+		t.Error("expected a cursor to be tied to mutationsOnly")
+	}
+}
@@ -0,0 +1,319 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"go.opentelemetry.io/otel/trace"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+	"k8s.io/component-base/metrics/testutil"
+
+	"go.miloapis.com/activity/internal/metrics"
+	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+	"go.miloapis.com/activity/pkg/celutil"
+)
+
+func TestAnnotateDuration(t *testing.T) {
+	received := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	t.Run("latency is recorded in milliseconds", func(t *testing.T) {
+		event := auditv1.Event{
+			RequestReceivedTimestamp: metav1.NewMicroTime(received),
+			StageTimestamp:           metav1.NewMicroTime(received.Add(1500*time.Millisecond + 300*time.Microsecond)),
+			Annotations:              map[string]string{"authorization.k8s.io/decision": "allow"},
+		}
+
+		annotateDuration(&event)
+
+		if got := event.Annotations[v1alpha1.AuditEventDurationAnnotation]; got != "1500" {
+			t.Errorf("duration annotation = %q, want %q", got, "1500")
+		}
+		if event.Annotations["authorization.k8s.io/decision"] != "allow" {
+			t.Error("expected existing annotations to be preserved")
+		}
+	})
+
+	t.Run("missing stage timestamp leaves event untouched", func(t *testing.T) {
+		event := auditv1.Event{RequestReceivedTimestamp: metav1.NewMicroTime(received)}
+
+		annotateDuration(&event)
+
+		if event.Annotations != nil {
+			t.Errorf("expected no annotations, got %v", event.Annotations)
+		}
+	})
+}
+
+// blockingConn is a ClickHouse connection whose queries run until the caller's
+// context is done, like a long-running query would.
+type blockingConn struct {
+	driver.Conn
+	started chan struct{}
+}
+
+func (c *blockingConn) Query(ctx context.Context, query string, args ...any) (driver.Rows, error) {
+	close(c.started)
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestQueryAuditLogs_Cancelled(t *testing.T) {
+	conn := &blockingConn{started: make(chan struct{})}
+	s := &ClickHouseStorage{
+		conn:   conn,
+		config: ClickHouseConfig{MaxQueryWindow: 30 * 24 * time.Hour, MaxPageSize: 1000},
+	}
+	spec := v1alpha1.AuditLogQuerySpec{
+		StartTime: "now-1h",
+		EndTime:   "now",
+		Limit:     10,
+	}
+
+	cancelledBefore, _ := testutil.GetCounterMetricValue(metrics.ClickHouseQueryCancelled)
+	errorsBefore, _ := testutil.GetCounterMetricValue(metrics.ClickHouseQueryTotal.WithLabelValues("error"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-conn.started
+		cancel()
+	}()
+
+	_, err := s.QueryAuditLogs(ctx, spec, ScopeContext{Type: "platform"})
+	if !errors.Is(err, errQueryCancelled) {
+		t.Fatalf("expected errQueryCancelled, got %v", err)
+	}
+
+	cancelledAfter, _ := testutil.GetCounterMetricValue(metrics.ClickHouseQueryCancelled)
+	if cancelledAfter-cancelledBefore != 1 {
+		t.Errorf("expected cancelled counter to increase by 1, got %v", cancelledAfter-cancelledBefore)
+	}
+	errorsAfter, _ := testutil.GetCounterMetricValue(metrics.ClickHouseQueryTotal.WithLabelValues("error"))
+	if errorsAfter != errorsBefore {
+		t.Errorf("expected cancellation not to count as an error, errors %v -> %v", errorsBefore, errorsAfter)
+	}
+}
+
+// activityRowsConn is a ClickHouse connection whose queries return rows of
+// activity JSON.
+type activityRowsConn struct {
+	driver.Conn
+	rows []string
+}
+
+func (c *activityRowsConn) Query(ctx context.Context, query string, args ...any) (driver.Rows, error) {
+	return &stringRows{values: c.rows}, nil
+}
+
+// stringRows returns one string column per row.
+type stringRows struct {
+	driver.Rows
+	values []string
+	next   int
+}
+
+func (r *stringRows) Next() bool {
+	r.next++
+	return r.next <= len(r.values)
+}
+
+func (r *stringRows) Scan(dest ...any) error {
+	*dest[0].(*string) = r.values[r.next-1]
+	return nil
+}
+
+func (r *stringRows) Err() error   { return nil }
+func (r *stringRows) Close() error { return nil }
+
+func TestQueryActivities_UnmarshalErrorKeepsContinue(t *testing.T) {
+	activityJSON := func(name string) string {
+		return fmt.Sprintf(`{"metadata":{"name":%q,"creationTimestamp":"2026-10-16T12:00:00Z"}}`, name)
+	}
+
+	tests := []struct {
+		name         string
+		rows         []string
+		wantNames    []string
+		wantContinue bool
+		wantErr      bool
+	}{
+		{
+			name:         "corrupt row on a full page",
+			rows:         []string{activityJSON("a"), "{corrupt", activityJSON("c")},
+			wantNames:    []string{"a"},
+			wantContinue: true,
+		},
+		{
+			name:         "corrupt row on the last page",
+			rows:         []string{activityJSON("a"), "{corrupt"},
+			wantNames:    []string{"a"},
+			wantContinue: false,
+		},
+		{
+			name:    "every row corrupt on a full page",
+			rows:    []string{"{corrupt", "{corrupt", activityJSON("c")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &ClickHouseStorage{
+				conn:   &activityRowsConn{rows: tt.rows},
+				config: ClickHouseConfig{MaxPageSize: 1000},
+			}
+			spec := ActivityQuerySpec{StartTime: "now-1h", EndTime: "now", Limit: 2}
+
+			result, err := s.QueryActivities(context.Background(), spec, ScopeContext{Type: "platform"})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("QueryActivities() = %+v, want an error instead of a page without a continue token", result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("QueryActivities() error = %v", err)
+			}
+			var names []string
+			for _, a := range result.Activities {
+				names = append(names, a.Name)
+			}
+			if fmt.Sprint(names) != fmt.Sprint(tt.wantNames) {
+				t.Errorf("activities = %v, want %v", names, tt.wantNames)
+			}
+			if got := result.Continue != ""; got != tt.wantContinue {
+				t.Errorf("continue set = %v, want %v", got, tt.wantContinue)
+			}
+		})
+	}
+}
+
+// capturingConn is a ClickHouse connection that records the last query it
+// ran and returns no rows.
+type capturingConn struct {
+	driver.Conn
+	query string
+	args  []any
+}
+
+func (c *capturingConn) Query(ctx context.Context, query string, args ...any) (driver.Rows, error) {
+	c.query, c.args = query, args
+	return &stringRows{}, nil
+}
+
+func TestQueryAuditLogFacets_MutationsOnly(t *testing.T) {
+	conn := &capturingConn{}
+	s := &ClickHouseStorage{conn: conn}
+	spec := AuditLogFacetQuerySpec{
+		StartTime:     "now-1h",
+		EndTime:       "now",
+		Filter:        "objectRef.namespace == 'prod'",
+		MutationsOnly: true,
+		Facets:        []FacetFieldSpec{{Field: "verb"}},
+	}
+
+	if _, err := s.QueryAuditLogFacets(context.Background(), spec, ScopeContext{Type: "platform"}); err != nil {
+		t.Fatalf("QueryAuditLogFacets() error = %v", err)
+	}
+	if !strings.Contains(conn.query, " AND verb IN (?, ?, ?, ?, ?) GROUP BY") {
+		t.Errorf("expected the verb condition ANDed with the filter, got: %s", conn.query)
+	}
+	if got := fmt.Sprint(conn.args[len(conn.args)-len(celutil.MutatingVerbs):]); got != fmt.Sprint(celutil.MutatingVerbs) {
+		t.Errorf("expected the mutating verbs as args, got: %v", conn.args)
+	}
+}
+
+func TestIsQueryCancelled(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancelExpired := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancelExpired()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		err  error
+		want bool
+	}{
+		{name: "context cancelled", ctx: cancelled, err: errors.New("read: connection reset"), want: true},
+		{name: "driver returns context.Canceled", ctx: context.Background(), err: fmt.Errorf("clickhouse: %w", context.Canceled), want: true},
+		{name: "deadline exceeded is a timeout", ctx: expired, err: context.DeadlineExceeded, want: false},
+		{name: "query error", ctx: context.Background(), err: errors.New("syntax error"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isQueryCancelled(tt.ctx, tt.err); got != tt.want {
+				t.Errorf("isQueryCancelled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithTraceComment(t *testing.T) {
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	span := trace.SpanFromContext(trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})))
+	query := "SELECT 1"
+
+	t.Run("enabled prefixes traceparent", func(t *testing.T) {
+		s := &ClickHouseStorage{config: ClickHouseConfig{InjectTraceComment: true}}
+		want := "/* traceparent: 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01 */ SELECT 1"
+		if got := s.withTraceComment(span, query); got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("disabled leaves query unchanged", func(t *testing.T) {
+		s := &ClickHouseStorage{config: ClickHouseConfig{InjectTraceComment: false}}
+		if got := s.withTraceComment(span, query); got != query {
+			t.Errorf("got %q, want %q", got, query)
+		}
+	})
+
+	t.Run("untraced span leaves query unchanged", func(t *testing.T) {
+		s := &ClickHouseStorage{config: ClickHouseConfig{InjectTraceComment: true}}
+		if got := s.withTraceComment(trace.SpanFromContext(context.Background()), query); got != query {
+			t.Errorf("got %q, want %q", got, query)
+		}
+	})
+}
+
+func TestWithQueryID(t *testing.T) {
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	span := trace.SpanFromContext(trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})))
+
+	t.Run("traced span sets trace-derived ID", func(t *testing.T) {
+		ctx, id := withQueryID(context.Background(), span)
+		if want := "4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7"; id != want {
+			t.Errorf("got %q, want %q", id, want)
+		}
+		if ctx == context.Background() {
+			t.Error("expected the query ID to be set on the returned context")
+		}
+	})
+
+	t.Run("untraced span leaves context unchanged", func(t *testing.T) {
+		ctx, id := withQueryID(context.Background(), trace.SpanFromContext(context.Background()))
+		if id != "" {
+			t.Errorf("got %q, want empty", id)
+		}
+		if ctx != context.Background() {
+			t.Error("expected the context to be returned unchanged")
+		}
+	})
+}
@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"github.com/ClickHouse/clickhouse-go/v2"
+)
+
+// DistributedTableSuffix names the Distributed table that fronts each
+// shard-local table on a sharded cluster, e.g. audit_logs_distributed over
+// audit_logs.
+const DistributedTableSuffix = "_distributed"
+
+// clusterTable returns the database-qualified table to read from or insert
+// into. On a sharded cluster that is the Distributed table, so queries fan out
+// to every shard instead of seeing only the local one.
+func clusterTable(database, cluster, table string) string {
+	if cluster != "" {
+		table += DistributedTableSuffix
+	}
+	return database + "." + table
+}
+
+// clusterSettings returns the connection settings needed for correct results
+// through Distributed tables. Aggregations are merged on the initiating node
+// so count() and uniqExact() are cluster-wide rather than per-shard partials,
+// and a query fails when a shard is unreachable instead of silently returning
+// counts that leave it out. Returns nil when cluster is empty.
+func clusterSettings(cluster string) clickhouse.Settings {
+	if cluster == "" {
+		return nil
+	}
+	return clickhouse.Settings{
+		"distributed_group_by_no_merge": 0,
+		"skip_unavailable_shards":       0,
+	}
+}
+
+// table returns the table audit log and activity queries use for name.
+func (s *ClickHouseStorage) table(name string) string {
+	return clusterTable(s.config.Database, s.config.Cluster, name)
+}
+
+// table returns the table event queries use for name.
+func (c ClickHouseEventsConfig) table(name string) string {
+	return clusterTable(c.Database, c.Cluster, name)
+}
@@ -0,0 +1,32 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClusterTable(t *testing.T) {
+	assert.Equal(t, "audit.audit_logs", clusterTable("audit", "", "audit_logs"))
+	assert.Equal(t, "audit.audit_logs_distributed", clusterTable("audit", "activity", "audit_logs"))
+}
+
+func TestClusterSettings(t *testing.T) {
+	assert.Nil(t, clusterSettings(""))
+
+	// Per-shard partial aggregates would undercount facets and scope stats
+	settings := clusterSettings("activity")
+	assert.Equal(t, 0, settings["distributed_group_by_no_merge"])
+	assert.Equal(t, 0, settings["skip_unavailable_shards"])
+}
+
+func TestClickHouseStorage_table(t *testing.T) {
+	s := &ClickHouseStorage{config: ClickHouseConfig{Database: "audit"}}
+	assert.Equal(t, "audit.activities", s.table("activities"))
+
+	s.config.Cluster = "activity"
+	assert.Equal(t, "audit.activities_distributed", s.table("activities"))
+
+	events := ClickHouseEventsConfig{Database: "audit", Cluster: "activity"}
+	assert.Equal(t, "audit.k8s_events_distributed", events.table("k8s_events"))
+}
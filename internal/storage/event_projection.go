@@ -0,0 +1,95 @@
+package storage
+
+import (
+	"fmt"
+	"sort"
+
+	eventsv1 "k8s.io/api/events/v1"
+)
+
+// eventProjectionFields maps each name accepted in EventQuerySpec.Fields to
+// the event fields it keeps. The core/v1 names (message, involvedObject,
+// source) are accepted as aliases for their events.k8s.io/v1 equivalents.
+var eventProjectionFields = map[string][]string{
+	"action":              {"action"},
+	"eventTime":           {"eventTime"},
+	"note":                {"note"},
+	"reason":              {"reason"},
+	"regarding":           {"regarding"},
+	"related":             {"related"},
+	"reportingController": {"reportingController"},
+	"reportingInstance":   {"reportingInstance"},
+	"series":              {"series"},
+	"type":                {"type"},
+
+	"message":        {"note"},
+	"involvedObject": {"regarding"},
+	"source":         {"reportingController", "reportingInstance"},
+}
+
+// EventProjectionFieldNames returns the names accepted in
+// EventQuerySpec.Fields, sorted for error messages.
+func EventProjectionFieldNames() []string {
+	names := make([]string, 0, len(eventProjectionFields))
+	for name := range eventProjectionFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidateEventProjection checks that every requested field can be projected.
+func ValidateEventProjection(fields []string) error {
+	for _, field := range fields {
+		if _, ok := eventProjectionFields[field]; !ok {
+			return fmt.Errorf("field %q cannot be projected. Supported fields: %v", field, EventProjectionFieldNames())
+		}
+	}
+	return nil
+}
+
+// projectEvent trims an event down to the requested fields. Identifying
+// metadata (name, namespace, uid, resourceVersion, creationTimestamp) is always
+// kept so results can still be told apart and paged; everything else,
+// including managed fields, labels and annotations, is dropped. An empty
+// fields list returns the event unchanged.
+func projectEvent(event *eventsv1.Event, fields []string) *eventsv1.Event {
+	if len(fields) == 0 {
+		return event
+	}
+
+	projected := &eventsv1.Event{}
+	projected.Name = event.Name
+	projected.Namespace = event.Namespace
+	projected.UID = event.UID
+	projected.ResourceVersion = event.ResourceVersion
+	projected.CreationTimestamp = event.CreationTimestamp
+
+	for _, field := range fields {
+		for _, target := range eventProjectionFields[field] {
+			switch target {
+			case "action":
+				projected.Action = event.Action
+			case "eventTime":
+				projected.EventTime = event.EventTime
+			case "note":
+				projected.Note = event.Note
+			case "reason":
+				projected.Reason = event.Reason
+			case "regarding":
+				projected.Regarding = event.Regarding
+			case "related":
+				projected.Related = event.Related
+			case "reportingController":
+				projected.ReportingController = event.ReportingController
+			case "reportingInstance":
+				projected.ReportingInstance = event.ReportingInstance
+			case "series":
+				projected.Series = event.Series
+			case "type":
+				projected.Type = event.Type
+			}
+		}
+	}
+	return projected
+}
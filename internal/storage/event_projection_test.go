@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testProjectionEvent() *eventsv1.Event {
+	return &eventsv1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "web-1.17a",
+			Namespace:       "prod",
+			UID:             "uid-1",
+			ResourceVersion: "42",
+			Labels:          map[string]string{"app": "web"},
+			ManagedFields:   []metav1.ManagedFieldsEntry{{Manager: "kubelet"}},
+		},
+		EventTime:           metav1.NewMicroTime(time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)),
+		Reason:              "BackOff",
+		Note:                "Back-off restarting failed container",
+		Type:                "Warning",
+		Action:              "Restarting",
+		Regarding:           corev1.ObjectReference{Kind: "Pod", Name: "web-1", Namespace: "prod"},
+		ReportingController: "kubelet",
+		ReportingInstance:   "node-a",
+		Series:              &eventsv1.EventSeries{Count: 5},
+	}
+}
+
+func TestProjectEvent(t *testing.T) {
+	event := testProjectionEvent()
+
+	t.Run("no fields returns the event unchanged", func(t *testing.T) {
+		assert.Same(t, event, projectEvent(event, nil))
+	})
+
+	t.Run("keeps requested fields and identifying metadata", func(t *testing.T) {
+		projected := projectEvent(event, []string{"reason", "message", "type", "involvedObject", "source"})
+
+		assert.Equal(t, "web-1.17a", projected.Name)
+		assert.Equal(t, "prod", projected.Namespace)
+		assert.Equal(t, "uid-1", string(projected.UID))
+		assert.Equal(t, "42", projected.ResourceVersion)
+
+		assert.Equal(t, "BackOff", projected.Reason)
+		assert.Equal(t, "Back-off restarting failed container", projected.Note)
+		assert.Equal(t, "Warning", projected.Type)
+		assert.Equal(t, "web-1", projected.Regarding.Name)
+		assert.Equal(t, "kubelet", projected.ReportingController)
+		assert.Equal(t, "node-a", projected.ReportingInstance)
+
+		assert.Empty(t, projected.ManagedFields)
+		assert.Empty(t, projected.Labels)
+		assert.Empty(t, projected.Action)
+		assert.Nil(t, projected.Series)
+		assert.True(t, projected.EventTime.IsZero())
+	})
+
+	t.Run("does not modify the original event", func(t *testing.T) {
+		projectEvent(event, []string{"reason"})
+		assert.Len(t, event.ManagedFields, 1)
+		assert.Equal(t, "Back-off restarting failed container", event.Note)
+	})
+}
+
+func TestValidateEventProjection(t *testing.T) {
+	require.NoError(t, ValidateEventProjection(nil))
+	require.NoError(t, ValidateEventProjection([]string{"reason", "note", "involvedObject", "series"}))
+
+	err := ValidateEventProjection([]string{"reason", "managedFields"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"managedFields"`)
+	assert.Contains(t, err.Error(), "involvedObject")
+}
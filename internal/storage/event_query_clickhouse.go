@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"go.opentelemetry.io/otel/trace"
 	eventsv1 "k8s.io/api/events/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -73,6 +74,22 @@ func (b *ClickHouseEventQueryBackend) GetMaxPageSize() int32 {
 	return eventQueryMaxLimit
 }
 
+// GetMaxQueryTimeout returns the largest spec.timeoutSeconds override allowed.
+func (b *ClickHouseEventQueryBackend) GetMaxQueryTimeout() time.Duration {
+	return b.config.maxQueryTimeout()
+}
+
+// GetRetentionWindow returns how far back events are retained, or zero when
+// they are kept indefinitely.
+func (b *ClickHouseEventQueryBackend) GetRetentionWindow() time.Duration {
+	return b.config.RetentionWindow
+}
+
+// Now returns the time relative query times resolve against.
+func (b *ClickHouseEventQueryBackend) Now() time.Time {
+	return b.config.now()
+}
+
 // QueryEvents retrieves Kubernetes Events matching the query specification and scope.
 // The spec must be pre-validated by the API layer (startTime, endTime required,
 // window <= 60 days, limit <= 1000).
@@ -89,6 +106,14 @@ func (b *ClickHouseEventQueryBackend) QueryEvents(ctx context.Context, spec v1al
 
 	rows, err := b.conn.Query(ctx, query, args...)
 	if err != nil {
+		if isQueryCancelled(ctx, err) {
+			recordQueryCancelled(trace.SpanFromContext(ctx))
+			return nil, errQueryCancelled
+		}
+		if IsQueryQueueTimeout(err) {
+			return nil, err
+		}
+
 		// Classify error type
 		errorType := "unknown"
 		errStr := err.Error()
@@ -99,7 +124,7 @@ func (b *ClickHouseEventQueryBackend) QueryEvents(ctx context.Context, spec v1al
 		} else if strings.Contains(errStr, "syntax") {
 			errorType = "syntax"
 		}
-		metrics.ClickHouseQueryErrors.WithLabelValues(errorType).Inc()
+		metrics.IncClickHouseQueryErrors(errorType)
 
 		klog.ErrorS(err, "EventQuery ClickHouse query failed",
 			"fieldSelector", spec.FieldSelector,
@@ -117,7 +142,7 @@ func (b *ClickHouseEventQueryBackend) QueryEvents(ctx context.Context, spec v1al
 	for rows.Next() {
 		var eventJSON string
 		if err := rows.Scan(&eventJSON); err != nil {
-			metrics.ClickHouseQueryErrors.WithLabelValues("scan").Inc()
+			metrics.IncClickHouseQueryErrors("scan")
 			klog.ErrorS(err, "Failed to scan EventQuery row")
 			return nil, fmt.Errorf("unable to retrieve events. Try again or contact support if the problem persists")
 		}
@@ -128,12 +153,13 @@ func (b *ClickHouseEventQueryBackend) QueryEvents(ctx context.Context, spec v1al
 			continue
 		}
 
-		// Convert eventsv1.Event to v1alpha1.EventRecord
-		events = append(events, convertEventsV1ToEventRecord(&event))
+		// Trim before holding on to the event so large pulls only keep the
+		// requested fields in memory
+		events = append(events, convertEventsV1ToEventRecord(projectEvent(&event, spec.Fields)))
 	}
 
 	if err := rows.Err(); err != nil {
-		metrics.ClickHouseQueryErrors.WithLabelValues("iteration").Inc()
+		metrics.IncClickHouseQueryErrors("iteration")
 		klog.ErrorS(err, "Error iterating EventQuery rows")
 		return nil, fmt.Errorf("unable to retrieve events. Try again or contact support if the problem persists")
 	}
@@ -178,7 +204,7 @@ func (b *ClickHouseEventQueryBackend) buildQuery(_ context.Context, spec v1alpha
 	}
 
 	// Time range — use a single reference time to prevent sub-second drift
-	now := time.Now()
+	now := b.config.now()
 
 	if spec.StartTime != "" {
 		startTime, err := timeutil.ParseFlexibleTime(spec.StartTime, now)
@@ -209,6 +235,22 @@ func (b *ClickHouseEventQueryBackend) buildQuery(_ context.Context, spec v1alpha
 		args = append(args, fieldArgs...)
 	}
 
+	// Value lists (optional) — match any listed value, which field selectors can't express
+	for _, list := range []struct {
+		column string
+		values []string
+	}{
+		{column: "reason", values: spec.Reasons},
+		{column: "type", values: spec.Types},
+		{column: "source_component", values: spec.SourceComponents},
+	} {
+		if len(list.values) > 0 {
+			cond, condArgs := ValuesInSQL(list.column, list.values)
+			conditions = append(conditions, cond)
+			args = append(args, condArgs...)
+		}
+	}
+
 	// Pagination cursor — decode offset from opaque continue token
 	if spec.Continue != "" {
 		offset, err := decodeEventQueryCursor(spec.Continue, spec)
@@ -217,16 +259,17 @@ func (b *ClickHouseEventQueryBackend) buildQuery(_ context.Context, spec v1alpha
 		}
 		// Offset-based pagination: skip rows already returned in previous pages
 		limit := resolveEventQueryLimit(spec.Limit)
-		query := fmt.Sprintf("SELECT event_json FROM %s.%s", b.config.Database, "k8s_events")
+		query := fmt.Sprintf("SELECT event_json FROM %s", b.config.table("k8s_events"))
 		if len(conditions) > 0 {
 			query += " WHERE " + strings.Join(conditions, " AND ")
 		}
 		query += buildEventQueryOrderBy(scope)
 		query += fmt.Sprintf(" LIMIT %d OFFSET %d", limit+1, offset)
+		query += tenantQuerySettings(b.config.TenantQueryLimits, scope)
 		return query, args, nil
 	}
 
-	query := fmt.Sprintf("SELECT event_json FROM %s.%s", b.config.Database, "k8s_events")
+	query := fmt.Sprintf("SELECT event_json FROM %s", b.config.table("k8s_events"))
 	if len(conditions) > 0 {
 		query += " WHERE " + strings.Join(conditions, " AND ")
 	}
@@ -234,6 +277,7 @@ func (b *ClickHouseEventQueryBackend) buildQuery(_ context.Context, spec v1alpha
 
 	limit := resolveEventQueryLimit(spec.Limit)
 	query += fmt.Sprintf(" LIMIT %d", limit+1)
+	query += tenantQuerySettings(b.config.TenantQueryLimits, scope)
 
 	return query, args, nil
 }
@@ -270,14 +314,16 @@ func (b *ClickHouseEventQueryBackend) buildScopeConditions(scope ScopeContext) (
 	}
 
 	switch scope.Type {
-	case types.TenantTypeOrganization, types.TenantTypeProject:
-		conditions = append(conditions, "scope_type = ?", "scope_name = ?")
-		args = append(args, scope.Type, scope.Name)
 	case types.TenantTypeUser:
 		// User scope falls back to organization/project filtering for events
 		// since events don't carry user-level attribution the same way audit logs do.
 		conditions = append(conditions, "scope_type = ?", "scope_name = ?")
 		args = append(args, scope.Type, scope.Name)
+	default:
+		// Organization, Project, and any other scope type, so a scope without
+		// tenant data (types.TenantTypeNone) matches nothing
+		conditions = append(conditions, "scope_type = ?", "scope_name = ?")
+		args = append(args, scope.Type, scope.Name)
 	}
 
 	return conditions, args
@@ -304,7 +350,8 @@ type eventQueryCursorData struct {
 }
 
 // hashEventQueryParams creates a stable hash of the query parameters.
-// Excludes Continue since it changes between pagination requests.
+// Excludes Continue since it changes between pagination requests, and Limit
+// since the cursor stores an absolute offset that doesn't depend on page size.
 func hashEventQueryParams(spec v1alpha1.EventQuerySpec) string {
 	h := sha256.New()
 	h.Write([]byte(spec.StartTime))
@@ -314,8 +361,14 @@ func hashEventQueryParams(spec v1alpha1.EventQuerySpec) string {
 	h.Write([]byte(spec.Namespace))
 	h.Write([]byte("|"))
 	h.Write([]byte(spec.FieldSelector))
-	h.Write([]byte("|"))
-	h.Write([]byte(fmt.Sprintf("%d", spec.Limit)))
+	// Only hash the value lists when set, so cursors from queries without them
+	// keep their hash
+	if len(spec.Reasons) > 0 || len(spec.Types) > 0 || len(spec.SourceComponents) > 0 {
+		for _, values := range [][]string{spec.Reasons, spec.Types, spec.SourceComponents} {
+			h.Write([]byte("|"))
+			h.Write([]byte(strings.Join(values, ",")))
+		}
+	}
 	return base64.URLEncoding.EncodeToString(h.Sum(nil)[:16])
 }
 
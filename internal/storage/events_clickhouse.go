@@ -53,6 +53,45 @@ type NATSSubscription interface {
 // ClickHouseEventsConfig configures the ClickHouse events storage.
 type ClickHouseEventsConfig struct {
 	Database string
+
+	// Cluster is the sharded cluster name; see ClickHouseConfig.Cluster.
+	Cluster string
+
+	// MaxQueryTimeout is the largest spec.timeoutSeconds a query may request.
+	// Defaults to DefaultMaxQueryTimeout.
+	MaxQueryTimeout time.Duration
+
+	// RetentionWindow is how far back events are retained, matching the table
+	// TTL. Zero means no retention limit.
+	RetentionWindow time.Duration
+
+	// MaxFacetGroupByRows caps the distinct values a single facet query may
+	// aggregate; see ClickHouseConfig.MaxFacetGroupByRows.
+	MaxFacetGroupByRows int
+
+	// TenantQueryLimits holds the ClickHouse limits applied to each event
+	// query; see ClickHouseConfig.TenantQueryLimits.
+	TenantQueryLimits map[string]TenantQueryLimits
+
+	// Now returns the time relative query times resolve against. Set it to
+	// ClickHouseStorage.Now to share one clock. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// now returns the configured clock's current time or the wall clock.
+func (c ClickHouseEventsConfig) now() time.Time {
+	if c.Now == nil {
+		return time.Now()
+	}
+	return c.Now()
+}
+
+// maxQueryTimeout returns the configured query timeout cap or its default.
+func (c ClickHouseEventsConfig) maxQueryTimeout() time.Duration {
+	if c.MaxQueryTimeout <= 0 {
+		return DefaultMaxQueryTimeout
+	}
+	return c.MaxQueryTimeout
 }
 
 // NewClickHouseEventsBackend creates a new ClickHouse-backed events storage.
@@ -63,6 +102,11 @@ func NewClickHouseEventsBackend(conn driver.Conn, config ClickHouseEventsConfig)
 	}
 }
 
+// GetMaxQueryTimeout returns the largest spec.timeoutSeconds override allowed.
+func (b *ClickHouseEventsBackend) GetMaxQueryTimeout() time.Duration {
+	return b.config.maxQueryTimeout()
+}
+
 // SetPublisher sets the NATS publisher for publishing events to the data pipeline.
 // When a publisher is set, Create/Update operations will publish to NATS instead of
 // writing directly to ClickHouse. Vector will consume from NATS and write to ClickHouse.
@@ -151,11 +195,11 @@ func (b *ClickHouseEventsBackend) Create(ctx context.Context, event *eventsv1.Ev
 
 	// Insert into ClickHouse
 	insertTime := time.Now()
-	query := fmt.Sprintf("INSERT INTO %s.%s (event_json, inserted_at) VALUES (?, ?)",
-		b.config.Database, "k8s_events")
+	query := fmt.Sprintf("INSERT INTO %s (event_json, inserted_at) VALUES (?, ?)",
+		b.config.table("k8s_events"))
 
 	if err := b.conn.Exec(ctx, query, string(eventJSON), insertTime); err != nil {
-		metrics.ClickHouseQueryErrors.WithLabelValues("insert").Inc()
+		metrics.IncClickHouseQueryErrors("insert")
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "insert failed")
 		klog.ErrorS(err, "Failed to insert event",
@@ -205,8 +249,9 @@ func (b *ClickHouseEventsBackend) Get(ctx context.Context, namespace, name strin
 	args = append(args, scopeArgs...)
 
 	query := fmt.Sprintf(
-		"SELECT event_json, inserted_at FROM %s.%s WHERE %s ORDER BY inserted_at DESC LIMIT 1",
-		b.config.Database, "k8s_events", strings.Join(conditions, " AND "))
+		"SELECT event_json, inserted_at FROM %s WHERE %s ORDER BY inserted_at DESC LIMIT 1",
+		b.config.table("k8s_events"), strings.Join(conditions, " AND "))
+	query += tenantQuerySettings(b.config.TenantQueryLimits, scope)
 
 	row := b.conn.QueryRow(ctx, query, args...)
 
@@ -309,8 +354,9 @@ func (b *ClickHouseEventsBackend) List(ctx context.Context, namespace string, op
 	// Order by inserted_at DESC for consistent pagination with continue token
 	// inserted_at is the ResourceVersion, so this maintains chronological order
 	query := fmt.Sprintf(
-		"SELECT event_json, inserted_at FROM %s.%s %s ORDER BY inserted_at DESC LIMIT %d",
-		b.config.Database, "k8s_events", whereClause, limit+1)
+		"SELECT event_json, inserted_at FROM %s %s ORDER BY inserted_at DESC LIMIT %d",
+		b.config.table("k8s_events"), whereClause, limit+1)
+	query += tenantQuerySettings(b.config.TenantQueryLimits, scope)
 
 	klog.V(4).InfoS("Executing events list query",
 		"query", query,
@@ -319,7 +365,7 @@ func (b *ClickHouseEventsBackend) List(ctx context.Context, namespace string, op
 
 	rows, err := b.conn.Query(ctx, query, args...)
 	if err != nil {
-		metrics.ClickHouseQueryErrors.WithLabelValues("query").Inc()
+		metrics.IncClickHouseQueryErrors("query")
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "query failed")
 		return nil, fmt.Errorf("failed to list events: %w", err)
@@ -471,11 +517,11 @@ func (b *ClickHouseEventsBackend) Update(ctx context.Context, event *eventsv1.Ev
 
 	// Insert new version (ReplacingMergeTree will deduplicate by namespace, name, uid)
 	insertTime := time.Now()
-	query := fmt.Sprintf("INSERT INTO %s.%s (event_json, inserted_at) VALUES (?, ?)",
-		b.config.Database, "k8s_events")
+	query := fmt.Sprintf("INSERT INTO %s (event_json, inserted_at) VALUES (?, ?)",
+		b.config.table("k8s_events"))
 
 	if err := b.conn.Exec(ctx, query, string(eventJSON), insertTime); err != nil {
-		metrics.ClickHouseQueryErrors.WithLabelValues("insert").Inc()
+		metrics.IncClickHouseQueryErrors("insert")
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "update failed")
 		return nil, fmt.Errorf("failed to update event: %w", err)
@@ -535,13 +581,15 @@ func (b *ClickHouseEventsBackend) Delete(ctx context.Context, namespace, name st
 	conditions = append(conditions, scopeConds...)
 	args = append(args, scopeArgs...)
 
-	// Use lightweight delete (ALTER TABLE ... DELETE)
+	// Use lightweight delete (ALTER TABLE ... DELETE). Mutations can't go
+	// through a Distributed table, so this always targets the shard-local
+	// table; the Replicated database engine runs it on every shard.
 	query := fmt.Sprintf(
 		"ALTER TABLE %s.%s DELETE WHERE %s",
 		b.config.Database, "k8s_events", strings.Join(conditions, " AND "))
 
 	if err := b.conn.Exec(ctx, query, args...); err != nil {
-		metrics.ClickHouseQueryErrors.WithLabelValues("delete").Inc()
+		metrics.IncClickHouseQueryErrors("delete")
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "delete failed")
 		return fmt.Errorf("failed to delete event: %w", err)
@@ -583,7 +631,9 @@ func (b *ClickHouseEventsBackend) buildScopeConditions(scope ScopeContext) ([]st
 		// Events don't have a user field in the same way as audit logs
 		// For now, fall through to organization/project filtering
 		fallthrough
-	case types.TenantTypeOrganization, types.TenantTypeProject:
+	default:
+		// Organization, Project, and any other scope type, so a scope without
+		// tenant data (types.TenantTypeNone) matches nothing
 		conditions = append(conditions, "scope_type = ?", "scope_name = ?")
 		args = append(args, scope.Type, scope.Name)
 	}
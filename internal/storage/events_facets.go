@@ -38,6 +38,11 @@ func (b *ClickHouseEventsBackend) QueryEventFacets(ctx context.Context, spec Eve
 	)
 	defer span.End()
 
+	startTime := time.Now()
+	defer func() {
+		metrics.ObserveClickHouseQueryDuration("facet", time.Since(startTime).Seconds())
+	}()
+
 	result := &FacetQueryResult{
 		Facets: make([]FacetFieldResult, 0, len(spec.Facets)),
 	}
@@ -80,7 +85,7 @@ func (b *ClickHouseEventsBackend) queryEventFacet(ctx context.Context, facet Fac
 	args = append(args, scopeArgs...)
 
 	// Time range
-	now := time.Now()
+	now := b.config.now()
 	if spec.StartTime != "" {
 		startTime, err := timeutil.ParseFlexibleTime(spec.StartTime, now)
 		if err != nil {
@@ -107,14 +112,14 @@ func (b *ClickHouseEventsBackend) queryEventFacet(ctx context.Context, facet Fac
 	}
 
 	// Build query against the events table
-	query := fmt.Sprintf("SELECT %s, COUNT(*) as count FROM %s.%s", column, b.config.Database, "k8s_events")
+	query := fmt.Sprintf("SELECT %s, COUNT(*) as count FROM %s", column, b.config.table("k8s_events"))
 
 	if len(conditions) > 0 {
 		query += " WHERE " + strings.Join(conditions, " AND ")
 	}
 
-	// Group by the facet column and order by count descending, then value ascending for stability
-	query += fmt.Sprintf(" GROUP BY %s ORDER BY count DESC, %s ASC LIMIT %d", column, column, limit)
+	query += fmt.Sprintf(" GROUP BY %s ORDER BY %s LIMIT %d", column, facetOrderBy(facet.Order, column), limit)
+	query += tenantQuerySettings(b.config.TenantQueryLimits, scope, facetGroupBySettings(b.config.MaxFacetGroupByRows)...)
 
 	klog.V(4).InfoS("Executing event facet query",
 		"field", facet.Field,
@@ -124,6 +129,9 @@ func (b *ClickHouseEventsBackend) queryEventFacet(ctx context.Context, facet Fac
 
 	rows, err := b.conn.Query(ctx, query, args...)
 	if err != nil {
+		if overflow := facetGroupByOverflow(err, facet.Field, b.config.MaxFacetGroupByRows); overflow != nil {
+			return nil, overflow
+		}
 		// Classify error type
 		errorType := "unknown"
 		errStr := err.Error()
@@ -134,7 +142,7 @@ func (b *ClickHouseEventsBackend) queryEventFacet(ctx context.Context, facet Fac
 		} else if strings.Contains(errStr, "syntax") {
 			errorType = "syntax"
 		}
-		metrics.ClickHouseQueryErrors.WithLabelValues(errorType).Inc()
+		metrics.IncClickHouseQueryErrors(errorType)
 		klog.ErrorS(err, "Event facet query failed", "field", facet.Field, "errorType", errorType)
 		return nil, fmt.Errorf("failed to execute event facet query: %w", err)
 	}
@@ -149,7 +157,7 @@ func (b *ClickHouseEventsBackend) queryEventFacet(ctx context.Context, facet Fac
 		var value string
 		var count uint64
 		if err := rows.Scan(&value, &count); err != nil {
-			metrics.ClickHouseQueryErrors.WithLabelValues("scan").Inc()
+			metrics.IncClickHouseQueryErrors("scan")
 			klog.ErrorS(err, "Failed to scan event facet row", "field", facet.Field)
 			return nil, fmt.Errorf("failed to scan event facet row: %w", err)
 		}
@@ -160,7 +168,10 @@ func (b *ClickHouseEventsBackend) queryEventFacet(ctx context.Context, facet Fac
 	}
 
 	if err := rows.Err(); err != nil {
-		metrics.ClickHouseQueryErrors.WithLabelValues("iteration").Inc()
+		if overflow := facetGroupByOverflow(err, facet.Field, b.config.MaxFacetGroupByRows); overflow != nil {
+			return nil, overflow
+		}
+		metrics.IncClickHouseQueryErrors("iteration")
 		klog.ErrorS(err, "Error iterating event facet rows", "field", facet.Field)
 		return nil, fmt.Errorf("error iterating event facet rows: %w", err)
 	}
@@ -153,6 +153,20 @@ func (t FieldSelectorTerm) ToSQL() (string, interface{}) {
 	}
 }
 
+// ValuesInSQL returns a condition matching rows whose column holds any of
+// values, and its arguments. Field selectors can only AND terms together, so
+// this backs the list filters that need an OR.
+// Example: ("reason", ["BackOff", "FailedMount"]) -> "reason IN (?, ?)"
+func ValuesInSQL(column string, values []string) (string, []interface{}) {
+	placeholders := make([]string, len(values))
+	args := make([]interface{}, len(values))
+	for i, value := range values {
+		placeholders[i] = "?"
+		args[i] = value
+	}
+	return fmt.Sprintf("%s IN (%s)", column, strings.Join(placeholders, ", ")), args
+}
+
 // FieldSelectorTermsToSQL converts multiple field selector terms to SQL.
 // Returns a slice of SQL conditions and corresponding arguments.
 func FieldSelectorTermsToSQL(terms []FieldSelectorTerm) ([]string, []interface{}) {
@@ -1,10 +1,13 @@
 package storage
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
 )
 
 func TestParseFieldSelector_RegardingFields(t *testing.T) {
@@ -237,3 +240,31 @@ func TestResolveEventFieldSelector_UnsupportedRelatedField(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "unsupported field selector")
 }
+
+func TestValuesInSQL(t *testing.T) {
+	sql, args := ValuesInSQL("reason", []string{"FailedScheduling", "FailedMount", "BackOff"})
+	assert.Equal(t, "reason IN (?, ?, ?)", sql)
+	assert.Equal(t, []interface{}{"FailedScheduling", "FailedMount", "BackOff"}, args)
+}
+
+func TestEventQueryBuildQuery_ValueLists(t *testing.T) {
+	b := NewClickHouseEventQueryBackend(nil, ClickHouseEventsConfig{Database: "audit"})
+	spec := v1alpha1.EventQuerySpec{
+		StartTime:        "now-1h",
+		EndTime:          "now",
+		FieldSelector:    "regarding.kind=Pod",
+		Reasons:          []string{"FailedMount", "BackOff"},
+		Types:            []string{"Warning"},
+		SourceComponents: []string{"kubelet", "default-scheduler"},
+	}
+
+	query, args, err := b.buildQuery(context.Background(), spec, ScopeContext{})
+	require.NoError(t, err)
+	assert.Contains(t, query, "regarding_kind = ? AND reason IN (?, ?) AND type IN (?) AND source_component IN (?, ?)")
+	assert.Equal(t, []interface{}{"Pod", "FailedMount", "BackOff", "Warning", "kubelet", "default-scheduler"}, args[2:])
+
+	// The lists are part of the cursor's query hash
+	assert.NotEqual(t, hashEventQueryParams(spec), hashEventQueryParams(v1alpha1.EventQuerySpec{
+		StartTime: spec.StartTime, EndTime: spec.EndTime, FieldSelector: spec.FieldSelector,
+	}))
+}
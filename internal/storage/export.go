@@ -0,0 +1,304 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+	"k8s.io/klog/v2"
+
+	"go.miloapis.com/activity/internal/metrics"
+	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+)
+
+// StreamAuditLogs runs one query for every audit event matching spec and
+// scope, newest first, and passes each event to emit as its row is read. No
+// page is materialized, so the events held in memory stay constant however
+// many match. spec.Limit and spec.Continue are ignored.
+//
+// Cancelling ctx, such as when the client disconnects, cancels the ClickHouse
+// query. An error from emit stops the stream and is returned as is. Returns
+// the number of events emitted.
+//
+// The spec parameter must be pre-validated by the API layer.
+func (s *ClickHouseStorage) StreamAuditLogs(ctx context.Context, spec v1alpha1.AuditLogQuerySpec, scope ScopeContext, emit func(event *auditv1.Event) error) (int, error) {
+	ctx, span := tracer.Start(ctx, "clickhouse.stream",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "clickhouse"),
+			attribute.String("db.name", s.config.Database),
+			attribute.String("db.operation", "SELECT"),
+			attribute.String("query.filter", spec.Filter),
+			attribute.String("query.start_time", spec.StartTime),
+			attribute.String("query.end_time", spec.EndTime),
+		),
+	)
+	defer span.End()
+
+	spec.Continue = ""
+	query, args, err := s.buildOrderedQuery(ctx, spec, scope)
+	if err != nil {
+		metrics.IncClickHouseQueryErrors("build_query")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to build query")
+		return 0, err
+	}
+	query = s.withTraceComment(span, query+s.querySettings(scope))
+	traceID := span.SpanContext().TraceID().String()
+
+	startTime := time.Now()
+	rows, err := s.conn.Query(ctx, query, args...)
+	if err != nil {
+		metrics.ObserveClickHouseQueryDuration("query", time.Since(startTime).Seconds())
+		if isQueryCancelled(ctx, err) {
+			recordQueryCancelled(span)
+			return 0, errQueryCancelled
+		}
+		if IsQueryQueueTimeout(err) {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "query queue timeout")
+			return 0, err
+		}
+		metrics.IncClickHouseQueryTotal("error")
+		metrics.IncClickHouseQueryErrors("stream")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "query execution failed")
+		klog.ErrorS(err, "ClickHouse export query failed", "traceID", traceID, "filter", spec.Filter)
+		return 0, fmt.Errorf("unable to retrieve audit logs. Try again or contact support if the problem persists")
+	}
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		var eventJSON string
+		if err := rows.Scan(&eventJSON); err != nil {
+			klog.ErrorS(err, "Failed to scan row", "traceID", traceID)
+			return count, fmt.Errorf("unable to retrieve audit logs. Try again or contact support if the problem persists")
+		}
+
+		var event auditv1.Event
+		if err := json.Unmarshal([]byte(eventJSON), &event); err != nil {
+			klog.ErrorS(err, "Failed to unmarshal audit event", "traceID", traceID)
+			continue
+		}
+		annotateDuration(&event)
+
+		if err := emit(&event); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "export stopped")
+			return count, err
+		}
+		count++
+	}
+
+	if err := rows.Err(); err != nil {
+		if isQueryCancelled(ctx, err) {
+			recordQueryCancelled(span)
+			klog.V(2).InfoS("ClickHouse export cancelled by client", "traceID", traceID, "rowsStreamed", count)
+			return count, errQueryCancelled
+		}
+		metrics.IncClickHouseQueryTotal("error")
+		metrics.IncClickHouseQueryErrors("iteration")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "row iteration failed")
+		klog.ErrorS(err, "Error iterating ClickHouse rows", "traceID", traceID, "rowsStreamed", count)
+		return count, fmt.Errorf("unable to retrieve audit logs. Try again or contact support if the problem persists")
+	}
+
+	duration := time.Since(startTime).Seconds()
+	metrics.IncClickHouseQueryTotal("success")
+	metrics.ObserveClickHouseQueryDuration("total", duration)
+	span.SetAttributes(attribute.Int("db.rows_returned", count))
+	span.SetStatus(codes.Ok, "export successful")
+
+	klog.InfoS("ClickHouse export completed",
+		"traceID", traceID,
+		"rowsStreamed", count,
+		"duration", duration,
+		"filter", spec.Filter,
+	)
+
+	return count, nil
+}
+
+// auditLogGroupByColumns maps each field a grouped export can be grouped by
+// to the column expression it groups on. Days are bucketed in UTC so exports
+// don't depend on the ClickHouse server's time zone.
+var auditLogGroupByColumns = map[string]string{
+	"user":      "user",
+	"verb":      "verb",
+	"resource":  "resource",
+	"namespace": "namespace",
+	"day":       "toString(toDate(timestamp, 'UTC'))",
+}
+
+// ValidateAuditLogGroupBy checks the fields of a grouped export. Returns an
+// error describing the problem, suitable for a field.Invalid detail.
+func ValidateAuditLogGroupBy(groupBy []string) error {
+	if len(groupBy) == 0 {
+		return fmt.Errorf("must name at least one field")
+	}
+	seen := make(map[string]bool, len(groupBy))
+	for _, field := range groupBy {
+		if _, ok := auditLogGroupByColumns[field]; !ok {
+			return fmt.Errorf("unsupported field %q. Supported fields: %s", field, strings.Join(v1alpha1.AuditLogGroupByFields, ", "))
+		}
+		if seen[field] {
+			return fmt.Errorf("field %q is listed more than once", field)
+		}
+		seen[field] = true
+	}
+	return nil
+}
+
+// buildAuditLogGroupQuery constructs the GROUP BY query for a grouped export:
+// one row per distinct combination of the groupBy fields, with its count,
+// ordered by the fields in the order given.
+func (s *ClickHouseStorage) buildAuditLogGroupQuery(ctx context.Context, spec v1alpha1.AuditLogQuerySpec, scope ScopeContext, groupBy []string) (string, []interface{}, error) {
+	conditions, args, err := s.auditLogConditions(ctx, spec, scope)
+	if err != nil {
+		return "", nil, err
+	}
+
+	columns := make([]string, len(groupBy))
+	for i, field := range groupBy {
+		columns[i] = auditLogGroupByColumns[field]
+	}
+	groupColumns := strings.Join(columns, ", ")
+
+	query := fmt.Sprintf("SELECT %s, count() FROM %s", groupColumns, s.table("audit_logs"))
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += fmt.Sprintf(" GROUP BY %s ORDER BY %s", groupColumns, groupColumns)
+	query += s.querySettings(scope, facetGroupBySettings(s.config.MaxFacetGroupByRows)...)
+	return query, args, nil
+}
+
+// AggregateAuditLogs counts the audit events matching spec and scope for each
+// distinct combination of the groupBy fields, and passes each group to emit as
+// its row is read. ClickHouse does the aggregation, so a summary of millions
+// of events never sends the events themselves. The number of groups is capped
+// like a facet's distinct values; a query with more fails with a
+// FacetTooManyValuesError. spec.Limit and spec.Continue are ignored.
+//
+// Cancelling ctx cancels the ClickHouse query. An error from emit stops the
+// stream and is returned as is. Returns the number of groups emitted.
+//
+// The spec and groupBy parameters must be pre-validated by the API layer.
+func (s *ClickHouseStorage) AggregateAuditLogs(ctx context.Context, spec v1alpha1.AuditLogQuerySpec, scope ScopeContext, groupBy []string, emit func(group *v1alpha1.AuditLogGroup) error) (int, error) {
+	ctx, span := tracer.Start(ctx, "clickhouse.aggregate",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "clickhouse"),
+			attribute.String("db.name", s.config.Database),
+			attribute.String("db.operation", "SELECT"),
+			attribute.String("query.filter", spec.Filter),
+			attribute.String("query.start_time", spec.StartTime),
+			attribute.String("query.end_time", spec.EndTime),
+			attribute.StringSlice("query.group_by", groupBy),
+		),
+	)
+	defer span.End()
+
+	query, args, err := s.buildAuditLogGroupQuery(ctx, spec, scope, groupBy)
+	if err != nil {
+		metrics.IncClickHouseQueryErrors("build_query")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to build query")
+		return 0, err
+	}
+	query = s.withTraceComment(span, query)
+	traceID := span.SpanContext().TraceID().String()
+
+	// failed converts a query or iteration error into the error returned to
+	// the API layer.
+	failed := func(err error) error {
+		if tooMany := facetGroupByOverflow(err, strings.Join(groupBy, ","), s.config.MaxFacetGroupByRows); tooMany != nil {
+			span.RecordError(tooMany)
+			span.SetStatus(codes.Error, "too many groups")
+			return tooMany
+		}
+		metrics.IncClickHouseQueryTotal("error")
+		metrics.IncClickHouseQueryErrors("aggregate")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "query execution failed")
+		klog.ErrorS(err, "ClickHouse grouped export query failed", "traceID", traceID, "filter", spec.Filter, "groupBy", groupBy)
+		return fmt.Errorf("unable to aggregate audit logs. Try again or contact support if the problem persists")
+	}
+
+	startTime := time.Now()
+	rows, err := s.conn.Query(ctx, query, args...)
+	if err != nil {
+		metrics.ObserveClickHouseQueryDuration("query", time.Since(startTime).Seconds())
+		if isQueryCancelled(ctx, err) {
+			recordQueryCancelled(span)
+			return 0, errQueryCancelled
+		}
+		if IsQueryQueueTimeout(err) {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "query queue timeout")
+			return 0, err
+		}
+		return 0, failed(err)
+	}
+	defer rows.Close()
+
+	values := make([]string, len(groupBy))
+	var count uint64
+	dest := make([]any, 0, len(groupBy)+1)
+	for i := range values {
+		dest = append(dest, &values[i])
+	}
+	dest = append(dest, &count)
+
+	emitted := 0
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			klog.ErrorS(err, "Failed to scan row", "traceID", traceID)
+			return emitted, fmt.Errorf("unable to aggregate audit logs. Try again or contact support if the problem persists")
+		}
+
+		group := &v1alpha1.AuditLogGroup{Values: make(map[string]string, len(groupBy)), Count: int64(count)}
+		for i, field := range groupBy {
+			group.Values[field] = values[i]
+		}
+		if err := emit(group); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "export stopped")
+			return emitted, err
+		}
+		emitted++
+	}
+
+	if err := rows.Err(); err != nil {
+		if isQueryCancelled(ctx, err) {
+			recordQueryCancelled(span)
+			klog.V(2).InfoS("ClickHouse grouped export cancelled by client", "traceID", traceID, "groupsStreamed", emitted)
+			return emitted, errQueryCancelled
+		}
+		return emitted, failed(err)
+	}
+
+	duration := time.Since(startTime).Seconds()
+	metrics.IncClickHouseQueryTotal("success")
+	metrics.ObserveClickHouseQueryDuration("total", duration)
+	span.SetAttributes(attribute.Int("db.rows_returned", emitted))
+	span.SetStatus(codes.Ok, "export successful")
+
+	klog.InfoS("ClickHouse grouped export completed",
+		"traceID", traceID,
+		"groupsStreamed", emitted,
+		"duration", duration,
+		"filter", spec.Filter,
+		"groupBy", groupBy,
+	)
+
+	return emitted, nil
+}
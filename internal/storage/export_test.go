@@ -0,0 +1,153 @@
+package storage
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+
+	"go.miloapis.com/activity/internal/types"
+	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+)
+
+func TestStreamAuditLogs_UnlimitedQuery(t *testing.T) {
+	conn := &failingConn{}
+	s := &ClickHouseStorage{conn: conn, config: ClickHouseConfig{Database: "audit", MaxQueryWindow: 30 * 24 * time.Hour, MaxPageSize: 1000}}
+
+	spec := v1alpha1.AuditLogQuerySpec{StartTime: "now-1h", EndTime: "now", Filter: "verb == 'delete'", Limit: 10, Continue: "ignored"}
+	count, err := s.StreamAuditLogs(context.Background(), spec, ScopeContext{Type: types.TenantTypePlatform}, func(event *auditv1.Event) error {
+		t.Error("emit called for a failed query")
+		return nil
+	})
+	if err == nil {
+		t.Fatal("expected the query failure to be returned")
+	}
+	if count != 0 {
+		t.Errorf("count = %d, want 0", count)
+	}
+
+	if len(conn.queries) != 1 {
+		t.Fatalf("expected one query, got %d", len(conn.queries))
+	}
+	query := conn.queries[0]
+	if strings.Contains(query, " LIMIT ") {
+		t.Errorf("export query should not be limited: %s", query)
+	}
+	if !strings.Contains(query, " ORDER BY ") {
+		t.Errorf("export query should be ordered: %s", query)
+	}
+}
+
+func TestValidateAuditLogGroupBy(t *testing.T) {
+	tests := []struct {
+		name    string
+		groupBy []string
+		wantErr string
+	}{
+		{name: "single field", groupBy: []string{"user"}},
+		{name: "every field", groupBy: []string{"user", "verb", "resource", "namespace", "day"}},
+		{name: "empty", groupBy: nil, wantErr: "at least one field"},
+		{name: "unsupported field", groupBy: []string{"user", "objectRef.name"}, wantErr: `unsupported field "objectRef.name"`},
+		{name: "duplicate field", groupBy: []string{"day", "day"}, wantErr: "more than once"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAuditLogGroupBy(tt.groupBy)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateAuditLogGroupBy(%q) error = %v, want nil", tt.groupBy, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateAuditLogGroupBy(%q) error = %v, want it to contain %q", tt.groupBy, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// groupRows returns rows of string group values followed by a count.
+type groupRows struct {
+	driver.Rows
+	rows [][]string
+	next int
+}
+
+func (r *groupRows) Next() bool {
+	r.next++
+	return r.next <= len(r.rows)
+}
+
+func (r *groupRows) Scan(dest ...any) error {
+	row := r.rows[r.next-1]
+	for i, value := range row[:len(row)-1] {
+		*dest[i].(*string) = value
+	}
+	count, err := strconv.ParseUint(row[len(row)-1], 10, 64)
+	if err != nil {
+		return err
+	}
+	*dest[len(dest)-1].(*uint64) = count
+	return nil
+}
+
+func (r *groupRows) Err() error   { return nil }
+func (r *groupRows) Close() error { return nil }
+
+// groupConn records the query it is sent and returns rows.
+type groupConn struct {
+	driver.Conn
+	query string
+	args  []any
+	rows  [][]string
+}
+
+func (c *groupConn) Query(ctx context.Context, query string, args ...any) (driver.Rows, error) {
+	c.query, c.args = query, args
+	return &groupRows{rows: c.rows}, nil
+}
+
+func TestAggregateAuditLogs(t *testing.T) {
+	conn := &groupConn{rows: [][]string{
+		{"alice@example.com", "2026-10-15", "12"},
+		{"bob@example.com", "2026-10-15", "3"},
+	}}
+	s := &ClickHouseStorage{conn: conn, config: ClickHouseConfig{Database: "audit", MaxFacetGroupByRows: 1000}}
+
+	spec := v1alpha1.AuditLogQuerySpec{StartTime: "now-7d", EndTime: "now", Filter: "verb == 'delete'", MutationsOnly: true}
+	scope := ScopeContext{Type: types.TenantTypeOrganization, Name: "acme"}
+	var groups []v1alpha1.AuditLogGroup
+	count, err := s.AggregateAuditLogs(context.Background(), spec, scope, []string{"user", "day"}, func(group *v1alpha1.AuditLogGroup) error {
+		groups = append(groups, *group)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("AggregateAuditLogs() error = %v", err)
+	}
+	if count != 2 || len(groups) != 2 {
+		t.Fatalf("AggregateAuditLogs() emitted %d groups (count %d), want 2", len(groups), count)
+	}
+	if groups[0].Values["user"] != "alice@example.com" || groups[0].Values["day"] != "2026-10-15" || groups[0].Count != 12 {
+		t.Errorf("groups[0] = %+v", groups[0])
+	}
+
+	for _, want := range []string{
+		"SELECT user, toString(toDate(timestamp, 'UTC')), count() FROM audit.audit_logs WHERE ",
+		"scope_type = ? AND scope_name = ?",
+		"verb IN (?, ?, ?, ?, ?)",
+		" GROUP BY user, toString(toDate(timestamp, 'UTC')) ORDER BY user, toString(toDate(timestamp, 'UTC'))",
+		"max_rows_to_group_by = 1000",
+	} {
+		if !strings.Contains(conn.query, want) {
+			t.Errorf("query is missing %q: %s", want, conn.query)
+		}
+	}
+	if strings.Contains(conn.query, "event_json") || strings.Contains(conn.query, " LIMIT ") {
+		t.Errorf("grouped export should aggregate in ClickHouse without reading events: %s", conn.query)
+	}
+}
@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+)
+
+// DefaultMaxFacetGroupByRows is the default cap on distinct values a single
+// facet query may aggregate before ClickHouse gives up on it.
+const DefaultMaxFacetGroupByRows = 1000000
+
+// clickhouseTooManyRows is the ClickHouse error code raised when a GROUP BY
+// grows past max_rows_to_group_by with group_by_overflow_mode = 'throw'.
+const clickhouseTooManyRows = 158
+
+// FacetTooManyValuesError is returned for a facet whose field has more distinct
+// values in the queried range than the server will aggregate. It describes a
+// problem with the request, so it is reported to the client rather than
+// retried.
+type FacetTooManyValuesError struct {
+	Field   string
+	MaxRows int
+}
+
+func (e *FacetTooManyValuesError) Error() string {
+	return fmt.Sprintf("field '%s' has too many distinct values to count (more than %d); add a filter or narrow the time range", e.Field, e.MaxRows)
+}
+
+// IsFacetTooManyValues reports whether err means a facet field had too many
+// distinct values to aggregate.
+func IsFacetTooManyValues(err error) bool {
+	var tooMany *FacetTooManyValuesError
+	return errors.As(err, &tooMany)
+}
+
+// facetGroupBySettings returns the settings that cap a facet query's GROUP BY
+// state at maxRows distinct values. The LIMIT on a facet query only
+// trims the result; without this cap a high-cardinality field such as
+// objectRef.name builds a hash table of every value before the LIMIT applies.
+// Zero or less leaves the query uncapped.
+func facetGroupBySettings(maxRows int) []string {
+	if maxRows <= 0 {
+		return nil
+	}
+	return []string{fmt.Sprintf("max_rows_to_group_by = %d", maxRows), "group_by_overflow_mode = 'throw'"}
+}
+
+// facetGroupByOverflow converts a ClickHouse error raised by the
+// facetGroupBySettings cap into a FacetTooManyValuesError. Other errors are
+// returned as nil.
+func facetGroupByOverflow(err error, field string, maxRows int) error {
+	var exception *clickhouse.Exception
+	if maxRows > 0 && errors.As(err, &exception) && exception.Code == clickhouseTooManyRows {
+		return &FacetTooManyValuesError{Field: field, MaxRows: maxRows}
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+)
+
+func TestFacetGroupBySettings(t *testing.T) {
+	if got := settingsClause(facetGroupBySettings(0)); got != "" {
+		t.Errorf("facetGroupBySettings(0) = %q, want no SETTINGS clause", got)
+	}
+
+	want := " SETTINGS max_rows_to_group_by = 5000, group_by_overflow_mode = 'throw'"
+	if got := settingsClause(facetGroupBySettings(5000)); got != want {
+		t.Errorf("facetGroupBySettings(5000) = %q, want %q", got, want)
+	}
+}
+
+func TestFacetGroupByOverflow(t *testing.T) {
+	tooManyRows := fmt.Errorf("query failed: %w", &clickhouse.Exception{
+		Code:    clickhouseTooManyRows,
+		Name:    "DB::Exception",
+		Message: "Limit for rows (controlled by 'max_rows_to_group_by' setting) exceeded",
+	})
+
+	err := facetGroupByOverflow(tooManyRows, "objectRef.name", 5000)
+	if !IsFacetTooManyValues(err) {
+		t.Fatalf("facetGroupByOverflow() = %v, want FacetTooManyValuesError", err)
+	}
+	want := "field 'objectRef.name' has too many distinct values to count (more than 5000); add a filter or narrow the time range"
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+
+	// Other ClickHouse errors are left to the caller.
+	other := &clickhouse.Exception{Code: 241, Message: "Memory limit exceeded"}
+	if err := facetGroupByOverflow(other, "objectRef.name", 5000); err != nil {
+		t.Errorf("facetGroupByOverflow(memory limit) = %v, want nil", err)
+	}
+
+	// Without a cap the error can't have come from it.
+	if err := facetGroupByOverflow(tooManyRows, "objectRef.name", 0); err != nil {
+		t.Errorf("facetGroupByOverflow(uncapped) = %v, want nil", err)
+	}
+}
@@ -0,0 +1,211 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/klog/v2"
+
+	"go.miloapis.com/activity/internal/cel"
+	"go.miloapis.com/activity/internal/metrics"
+	"go.miloapis.com/activity/internal/types"
+)
+
+// FacetTrendQuerySpec defines the parameters for a facet trend query.
+type FacetTrendQuerySpec struct {
+	// StartTime and EndTime bound the query window. Both are resolved times;
+	// StartTime is expected to be aligned to a bucket boundary.
+	StartTime time.Time
+	EndTime   time.Time
+
+	// Field is the audit log facet field to split series on.
+	Field string
+
+	// Limit is how many of the most frequent values get a series.
+	Limit int32
+
+	// BucketSeconds is the width of each time bucket.
+	BucketSeconds int64
+
+	// Filter is a CEL expression to filter audit logs before counting.
+	Filter string
+}
+
+// FacetTrendResult contains one series per top value, most frequent first.
+type FacetTrendResult struct {
+	Series []FacetTrendSeriesResult
+}
+
+// FacetTrendSeriesResult is the per-bucket count of one value.
+type FacetTrendSeriesResult struct {
+	Value  string
+	Total  int64
+	Points []FacetTrendPointResult
+}
+
+// FacetTrendPointResult is the count of one value in one bucket.
+type FacetTrendPointResult struct {
+	Bucket time.Time
+	Count  int64
+}
+
+// QueryFacetTrend counts audit logs per time bucket for the most frequent
+// values of a facet field. A subquery picks the top spec.Limit values over the
+// whole window, and the outer query buckets only those, so the result size
+// depends on the limit and the number of buckets rather than the field's
+// cardinality.
+func (s *ClickHouseStorage) QueryFacetTrend(ctx context.Context, spec FacetTrendQuerySpec, scope ScopeContext) (*FacetTrendResult, error) {
+	ctx, span := tracer.Start(ctx, "clickhouse.query_facet_trend",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "clickhouse"),
+			attribute.String("db.name", s.config.Database),
+			attribute.String("db.operation", "SELECT"),
+			attribute.String("facet.field", spec.Field),
+			attribute.Int64("facet.bucket_seconds", spec.BucketSeconds),
+		),
+	)
+	defer span.End()
+
+	startTime := time.Now()
+	defer func() {
+		metrics.ObserveClickHouseQueryDuration("facet", time.Since(startTime).Seconds())
+	}()
+
+	if spec.BucketSeconds <= 0 {
+		return nil, fmt.Errorf("bucket size must be positive")
+	}
+	if spec.Limit <= 0 {
+		return nil, fmt.Errorf("limit must be positive")
+	}
+
+	column, err := GetAuditLogFacetColumn(spec.Field)
+	if err != nil {
+		return nil, err
+	}
+
+	var args []interface{}
+	var conditions []string
+
+	// Scope filtering - same pattern as audit log facet queries
+	if scope.Type != types.TenantTypePlatform {
+		if scope.Type == types.TenantTypeUser {
+			conditions = append(conditions, "user_uid = ?")
+			args = append(args, scope.Name)
+		} else {
+			conditions = append(conditions, "scope_type = ?")
+			args = append(args, scope.Type)
+			conditions = append(conditions, "scope_name = ?")
+			args = append(args, scope.Name)
+		}
+	}
+
+	conditions = append(conditions, "timestamp >= ?", "timestamp < ?")
+	args = append(args, spec.StartTime, spec.EndTime)
+
+	// CEL filter (optional)
+	if spec.Filter != "" {
+		celWhere, celArgs, err := cel.ConvertToClickHouseSQL(ctx, spec.Filter)
+		if err != nil {
+			return nil, err
+		}
+		if celWhere != "" {
+			processedWhere := celWhere
+			for i := range celArgs {
+				oldParam := fmt.Sprintf("{arg%d}", i+1)
+				processedWhere = strings.ReplaceAll(processedWhere, oldParam, "?")
+			}
+			args = append(args, celArgs...)
+			conditions = append(conditions, processedWhere)
+		}
+	}
+
+	where := strings.Join(conditions, " AND ")
+
+	// The subquery applies the same conditions, so its placeholders follow the
+	// outer query's. BucketSeconds and Limit are integers validated by the
+	// caller, so it is safe to inline them; ClickHouse does not accept a bound
+	// parameter inside INTERVAL.
+	query := fmt.Sprintf(
+		"SELECT toString(%[1]s) AS value, toStartOfInterval(timestamp, INTERVAL %[2]d SECOND) AS bucket, COUNT(*) AS count FROM %[3]s "+
+			"WHERE %[4]s AND value IN (SELECT toString(%[1]s) AS value FROM %[3]s WHERE %[4]s GROUP BY value ORDER BY COUNT(*) DESC, value ASC LIMIT %[5]d) "+
+			"GROUP BY value, bucket ORDER BY value ASC, bucket ASC",
+		column, spec.BucketSeconds, s.table("audit_logs"), where, spec.Limit,
+	)
+	query += s.querySettings(scope, facetGroupBySettings(s.config.MaxFacetGroupByRows)...)
+	args = append(args, args...)
+
+	traceID := span.SpanContext().TraceID().String()
+
+	klog.V(4).InfoS("Executing facet trend query",
+		"field", spec.Field,
+		"query", query,
+		"bucketSeconds", spec.BucketSeconds,
+		"traceID", traceID,
+	)
+
+	rows, err := s.conn.Query(ctx, query, args...)
+	if err != nil {
+		span.RecordError(err)
+		if IsQueryQueueTimeout(err) {
+			return nil, err
+		}
+		if overflow := facetGroupByOverflow(err, spec.Field, s.config.MaxFacetGroupByRows); overflow != nil {
+			return nil, overflow
+		}
+		klog.ErrorS(err, "Failed to execute facet trend query", "field", spec.Field, "traceID", traceID)
+		return nil, fmt.Errorf("unable to retrieve facet trend for field '%s'. Try again or contact support if the problem persists", spec.Field)
+	}
+	defer rows.Close()
+
+	// Rows arrive grouped by value, so a series ends when the value changes
+	result := &FacetTrendResult{
+		Series: make([]FacetTrendSeriesResult, 0, spec.Limit),
+	}
+	for rows.Next() {
+		var value string
+		var bucket time.Time
+		var count uint64
+		if err := rows.Scan(&value, &bucket, &count); err != nil {
+			span.RecordError(err)
+			klog.ErrorS(err, "Failed to scan facet trend row", "field", spec.Field, "traceID", traceID)
+			return nil, fmt.Errorf("unable to retrieve facet trend for field '%s'. Try again or contact support if the problem persists", spec.Field)
+		}
+		if n := len(result.Series); n == 0 || result.Series[n-1].Value != value {
+			result.Series = append(result.Series, FacetTrendSeriesResult{Value: value})
+		}
+		series := &result.Series[len(result.Series)-1]
+		series.Points = append(series.Points, FacetTrendPointResult{
+			Bucket: bucket.UTC(),
+			Count:  int64(count),
+		})
+		series.Total += int64(count)
+	}
+
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		if overflow := facetGroupByOverflow(err, spec.Field, s.config.MaxFacetGroupByRows); overflow != nil {
+			return nil, overflow
+		}
+		klog.ErrorS(err, "Error iterating facet trend rows", "field", spec.Field, "traceID", traceID)
+		return nil, fmt.Errorf("unable to retrieve facet trend for field '%s'. Try again or contact support if the problem persists", spec.Field)
+	}
+
+	// Most frequent first, matching the order the subquery picked them in
+	sort.SliceStable(result.Series, func(i, j int) bool {
+		if result.Series[i].Total != result.Series[j].Total {
+			return result.Series[i].Total > result.Series[j].Total
+		}
+		return result.Series[i].Value < result.Series[j].Value
+	})
+
+	span.SetAttributes(attribute.Int("facet.series_count", len(result.Series)))
+	span.SetStatus(codes.Ok, "facet trend query successful")
+	return result, nil
+}
@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"go.miloapis.com/activity/internal/types"
+)
+
+func TestQueryFacetTrend_TopValuesSubquery(t *testing.T) {
+	conn := &failingConn{}
+	s := &ClickHouseStorage{conn: conn, config: ClickHouseConfig{Database: "audit", MaxQueryWindow: 30 * 24 * time.Hour, MaxPageSize: 1000}}
+
+	end := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	spec := FacetTrendQuerySpec{
+		StartTime:     end.Add(-7 * 24 * time.Hour),
+		EndTime:       end,
+		Field:         "verb",
+		Limit:         5,
+		BucketSeconds: 86400,
+		Filter:        "objectRef.namespace == 'production'",
+	}
+	if _, err := s.QueryFacetTrend(context.Background(), spec, ScopeContext{Type: types.TenantTypeOrganization, Name: "acme"}); err == nil {
+		t.Fatal("expected the query failure to be returned")
+	}
+
+	if len(conn.queries) != 1 {
+		t.Fatalf("expected one query, got %d", len(conn.queries))
+	}
+	query := conn.queries[0]
+	for _, want := range []string{
+		"toStartOfInterval(timestamp, INTERVAL 86400 SECOND) AS bucket",
+		"value IN (SELECT toString(verb) AS value",
+		"ORDER BY COUNT(*) DESC, value ASC LIMIT 5)",
+		"GROUP BY value, bucket",
+	} {
+		if !strings.Contains(query, want) {
+			t.Errorf("query missing %q: %s", want, query)
+		}
+	}
+	// The top values must be picked from the same scope, window and filter
+	if n := strings.Count(query, "scope_name = ?"); n != 2 {
+		t.Errorf("scope condition appears %d times, want 2 (outer query and subquery): %s", n, query)
+	}
+}
+
+func TestQueryFacetTrend_UnsupportedField(t *testing.T) {
+	conn := &failingConn{}
+	s := &ClickHouseStorage{conn: conn, config: ClickHouseConfig{Database: "audit"}}
+
+	spec := FacetTrendQuerySpec{StartTime: time.Now().Add(-time.Hour), EndTime: time.Now(), Field: "bogus", Limit: 5, BucketSeconds: 3600}
+	if _, err := s.QueryFacetTrend(context.Background(), spec, ScopeContext{Type: types.TenantTypePlatform}); err == nil {
+		t.Fatal("expected an error for an unsupported field")
+	}
+	if len(conn.queries) != 0 {
+		t.Errorf("expected no query to run, got %d", len(conn.queries))
+	}
+}
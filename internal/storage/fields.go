@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+
+	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
 )
 
 // AuditLogFacetFields defines the supported fields for audit log facet queries.
@@ -14,10 +16,16 @@ var AuditLogFacetFields = map[string]string{
 	"verb":                "The API verb (get, list, create, update, delete, etc.)",
 	"user.username":       "The username of the actor",
 	"user.uid":            "The UID of the actor",
+	"user.groups":         "The groups of the actor, counting each membership separately",
 	"responseStatus.code": "The HTTP response status code",
+	"durationMs":          "Request latency histogram (<100ms, 100ms-1s, 1s-5s, 5s-30s, >=30s)",
+	"level":               "The audit level the event was recorded at (Metadata, Request, RequestResponse)",
 	"objectRef.namespace": "The namespace of the target object",
 	"objectRef.resource":  "The resource type",
 	"objectRef.apiGroup":  "The API group of the target resource",
+	"requestURI":          "The request path prefix (first two segments, without the query string), e.g. /apis/apps or /healthz",
+	"authzDecision":       "The authorization decision (allow, forbid), empty when the authorizer did not record one",
+	"admissionWebhook":    "The admission webhook that rejected the request, empty when none did",
 }
 
 // IsValidAuditLogFacetField checks if a field is supported for audit log faceting.
@@ -49,14 +57,69 @@ func sortedKeys(m map[string]string) []string {
 
 // auditLogFacetColumnMapping maps API field paths to ClickHouse column names for audit logs.
 // This is internal to the storage layer - only the field names are exposed publicly.
+// Array columns are unnested with arrayJoin so each element is counted as its own value.
 var auditLogFacetColumnMapping = map[string]string{
 	"verb":                "verb",
 	"user.username":       "user",
 	"user.uid":            "user_uid",
+	"user.groups":         "arrayJoin(user_groups)",
 	"responseStatus.code": "status_code",
+	"durationMs":          durationBucketExpr,
+	"level":               "level",
 	"objectRef.namespace": "namespace",
 	"objectRef.resource":  "resource",
 	"objectRef.apiGroup":  "api_group",
+	"requestURI":          requestURIPrefixExpr,
+	"authzDecision":       "authz_decision",
+	"admissionWebhook":    "admission_webhook",
+}
+
+// durationBucketExpr groups request latency into fixed histogram buckets.
+// Raw millisecond values are nearly unique per request and would make a
+// useless facet.
+const durationBucketExpr = "multiIf(duration_ms < 100, '<100ms', duration_ms < 1000, '100ms-1s', duration_ms < 5000, '1s-5s', duration_ms < 30000, '5s-30s', '>=30s')"
+
+// requestURIPrefixExpr trims request URIs to their first two path segments
+// without the query string, e.g. /apis/apps or /healthz. Full URIs embed
+// namespaces, names and query parameters and would rarely repeat; prefixes show
+// which endpoints are being hit.
+const requestURIPrefixExpr = "arrayStringConcat(arraySlice(splitByChar('/', splitByChar('?', request_uri)[1]), 1, 3), '/')"
+
+// auditLogFacetSortKeys are the expressions numeric audit log facets sort on
+// when ordered by value. Facet values are returned as strings, which would
+// sort 429 before 5xx codes and "<100ms" after ">=30s".
+var auditLogFacetSortKeys = map[string]string{
+	"responseStatus.code": "status_code",
+	"durationMs":          "min(duration_ms)",
+}
+
+// IsValidFacetOrder checks if order is a supported facet ordering. Empty means
+// the default, by count.
+func IsValidFacetOrder(order v1alpha1.FacetOrder) bool {
+	switch order {
+	case "", v1alpha1.FacetOrderCount, v1alpha1.FacetOrderValueAsc, v1alpha1.FacetOrderValueDesc:
+		return true
+	}
+	return false
+}
+
+// FacetOrderNames returns the supported facet orderings for error messages.
+func FacetOrderNames() []string {
+	return []string{string(v1alpha1.FacetOrderCount), string(v1alpha1.FacetOrderValueAsc), string(v1alpha1.FacetOrderValueDesc)}
+}
+
+// facetOrderBy returns the ORDER BY clause for a facet query. sortKey is the
+// expression values sort on. Count ordering breaks ties by value so results
+// are stable.
+func facetOrderBy(order v1alpha1.FacetOrder, sortKey string) string {
+	switch order {
+	case v1alpha1.FacetOrderValueAsc:
+		return sortKey + " ASC"
+	case v1alpha1.FacetOrderValueDesc:
+		return sortKey + " DESC"
+	default:
+		return "count DESC, " + sortKey + " ASC"
+	}
 }
 
 // GetAuditLogFacetColumn returns the ClickHouse column name for an audit log facet field.
@@ -78,6 +141,9 @@ var ActivityFacetFields = map[string]string{
 	"spec.resource.kind":      "The kind of the target resource",
 	"spec.resource.namespace": "The namespace of the target resource",
 	"spec.changeSource":       "The source of the change (human, automation, system)",
+	"spec.origin.policyName":  "The name of the ActivityPolicy that generated the activity",
+	"spec.tenant.type":        "The type of tenant the activity belongs to (Organization, Project)",
+	"spec.tenant.name":        "The name of the tenant the activity belongs to",
 }
 
 // IsValidActivityFacetField checks if a field is supported for activity faceting.
@@ -100,6 +166,9 @@ var activityFacetColumnMapping = map[string]string{
 	"spec.resource.kind":      "resource_kind",
 	"spec.resource.namespace": "resource_namespace",
 	"spec.changeSource":       "change_source",
+	"spec.origin.policyName":  "origin_policy",
+	"spec.tenant.type":        "tenant_type",
+	"spec.tenant.name":        "tenant_name",
 }
 
 // GetActivityFacetColumn returns the ClickHouse column name for an activity facet field.
@@ -112,6 +181,42 @@ func GetActivityFacetColumn(field string) (string, error) {
 	return col, nil
 }
 
+// ActivityMetricsGroupByFields defines the dimensions an ActivityMetricsQuery can group by.
+// Keys are API dimension names, values are human-readable descriptions.
+var ActivityMetricsGroupByFields = map[string]string{
+	"resource":     "The kind of the target resource (spec.resource.kind)",
+	"actor":        "The name of the actor who performed the action (spec.actor.name)",
+	"changeSource": "The source of the change (spec.changeSource)",
+}
+
+// IsValidActivityMetricsGroupBy checks if a dimension is supported for activity metrics grouping.
+func IsValidActivityMetricsGroupBy(groupBy string) bool {
+	_, ok := ActivityMetricsGroupByFields[groupBy]
+	return ok
+}
+
+// GetActivityMetricsGroupByNames returns a sorted list of supported activity metrics dimensions.
+func GetActivityMetricsGroupByNames() []string {
+	return sortedKeys(ActivityMetricsGroupByFields)
+}
+
+// activityMetricsGroupByColumnMapping maps metrics dimensions to ClickHouse column names for activities.
+var activityMetricsGroupByColumnMapping = map[string]string{
+	"resource":     "resource_kind",
+	"actor":        "actor_name",
+	"changeSource": "change_source",
+}
+
+// GetActivityMetricsGroupByColumn returns the ClickHouse column name for a metrics dimension.
+// Returns an error if the dimension is not supported.
+func GetActivityMetricsGroupByColumn(groupBy string) (string, error) {
+	col, ok := activityMetricsGroupByColumnMapping[groupBy]
+	if !ok {
+		return "", fmt.Errorf("unsupported activity metrics dimension: %s", groupBy)
+	}
+	return col, nil
+}
+
 // EventFacetFields defines the supported fields for Kubernetes Event facet queries.
 // Keys are API field paths (as used in queries), values are human-readable descriptions.
 var EventFacetFields = map[string]string{
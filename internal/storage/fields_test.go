@@ -7,6 +7,8 @@ import (
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+
+	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
 )
 
 func TestGetEventFieldValue_RelatedField(t *testing.T) {
@@ -192,3 +194,93 @@ func TestEventFacetColumnMapping(t *testing.T) {
 		})
 	}
 }
+
+func TestAuditLogFacetColumnMapping_UserGroups(t *testing.T) {
+	assert.True(t, IsValidAuditLogFacetField("user.groups"))
+
+	// Groups are stored as an array; each membership is counted as its own value.
+	got, err := GetAuditLogFacetColumn("user.groups")
+	require.NoError(t, err)
+	assert.Equal(t, "arrayJoin(user_groups)", got)
+}
+
+func TestAuditLogFacetColumnMapping_Duration(t *testing.T) {
+	assert.True(t, IsValidAuditLogFacetField("durationMs"))
+
+	// Latency is bucketed so the facet works as a histogram.
+	got, err := GetAuditLogFacetColumn("durationMs")
+	require.NoError(t, err)
+	assert.Contains(t, got, "duration_ms < 1000, '100ms-1s'")
+	assert.Contains(t, got, "'>=30s'")
+}
+
+func TestAuditLogFacetColumnMapping_Level(t *testing.T) {
+	assert.True(t, IsValidAuditLogFacetField("level"))
+
+	got, err := GetAuditLogFacetColumn("level")
+	require.NoError(t, err)
+	assert.Equal(t, "level", got)
+}
+
+func TestAuditLogFacetColumnMapping_RequestURI(t *testing.T) {
+	assert.True(t, IsValidAuditLogFacetField("requestURI"))
+
+	// URIs are trimmed to a path prefix so requests to the same endpoint group.
+	got, err := GetAuditLogFacetColumn("requestURI")
+	require.NoError(t, err)
+	assert.Contains(t, got, "splitByChar('?', request_uri)[1]")
+	assert.Contains(t, got, "arraySlice(")
+}
+
+func TestAuditLogFacetColumnMapping_AuthzDecision(t *testing.T) {
+	assert.True(t, IsValidAuditLogFacetField("authzDecision"))
+
+	got, err := GetAuditLogFacetColumn("authzDecision")
+	require.NoError(t, err)
+	assert.Equal(t, "authz_decision", got)
+}
+
+func TestAuditLogFacetColumnMapping_AdmissionWebhook(t *testing.T) {
+	assert.True(t, IsValidAuditLogFacetField("admissionWebhook"))
+
+	got, err := GetAuditLogFacetColumn("admissionWebhook")
+	require.NoError(t, err)
+	assert.Equal(t, "admission_webhook", got)
+}
+
+func TestActivityFacetColumnMapping_Tenant(t *testing.T) {
+	for field, want := range map[string]string{
+		"spec.tenant.type": "tenant_type",
+		"spec.tenant.name": "tenant_name",
+	} {
+		assert.True(t, IsValidActivityFacetField(field))
+
+		got, err := GetActivityFacetColumn(field)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestFacetOrderBy(t *testing.T) {
+	assert.Equal(t, "count DESC, verb ASC", facetOrderBy("", "verb"))
+	assert.Equal(t, "count DESC, verb ASC", facetOrderBy(v1alpha1.FacetOrderCount, "verb"))
+	assert.Equal(t, "verb ASC", facetOrderBy(v1alpha1.FacetOrderValueAsc, "verb"))
+	assert.Equal(t, "verb DESC", facetOrderBy(v1alpha1.FacetOrderValueDesc, "verb"))
+
+	assert.True(t, IsValidFacetOrder(""))
+	assert.True(t, IsValidFacetOrder(v1alpha1.FacetOrderValueDesc))
+	assert.False(t, IsValidFacetOrder("alphabetical"))
+}
+
+func TestAuditLogFacetSortKeys(t *testing.T) {
+	// Status codes sort on the numeric column, not the string value, so 429
+	// comes after 100 and before 500.
+	assert.Equal(t, "status_code ASC", facetOrderBy(v1alpha1.FacetOrderValueAsc, auditLogFacetSortKeys["responseStatus.code"]))
+
+	// Latency buckets sort from fastest to slowest rather than by label.
+	assert.Equal(t, "min(duration_ms) ASC", facetOrderBy(v1alpha1.FacetOrderValueAsc, auditLogFacetSortKeys["durationMs"]))
+
+	for field := range auditLogFacetSortKeys {
+		assert.True(t, IsValidAuditLogFacetField(field), field)
+	}
+}
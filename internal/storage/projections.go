@@ -0,0 +1,168 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.miloapis.com/activity/internal/types"
+	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+)
+
+// ProjectionCheckResult reports whether a query pattern selects the projection
+// its ORDER BY is written for.
+type ProjectionCheckResult struct {
+	// Pattern describes the query pattern, e.g. "audit logs, platform scope".
+	Pattern string
+
+	// Expected is the projection the pattern is meant to use.
+	Expected string
+
+	// Selected lists the projections named in the query plan. Empty means
+	// ClickHouse reads the table by its primary key.
+	Selected []string
+
+	// Err is set when the plan could not be fetched.
+	Err error
+}
+
+// OK reports whether the expected projection was selected.
+func (r ProjectionCheckResult) OK() bool {
+	if r.Err != nil {
+		return false
+	}
+	for _, name := range r.Selected {
+		if name == r.Expected {
+			return true
+		}
+	}
+	return false
+}
+
+// projectionCheck is a representative query built by the same code that
+// serves real queries, so a check fails when the ORDER BY drifts from the
+// projection's sort key.
+type projectionCheck struct {
+	pattern    string
+	projection string
+	build      func(ctx context.Context) (string, []interface{}, error)
+}
+
+// projectionChecks returns one check per query pattern that relies on a
+// projection. Tenant-scoped queries read the primary key and are not checked.
+func (s *ClickHouseStorage) projectionChecks() []projectionCheck {
+	platform := ScopeContext{Type: types.TenantTypePlatform}
+	user := ScopeContext{Type: types.TenantTypeUser, Name: "projection-check"}
+
+	auditSpec := func(filter string) v1alpha1.AuditLogQuerySpec {
+		return v1alpha1.AuditLogQuerySpec{StartTime: "now-1h", EndTime: "now", Filter: filter, Limit: 1}
+	}
+	activitySpec := func(filter string) ActivityQuerySpec {
+		return ActivityQuerySpec{StartTime: "now-1h", EndTime: "now", Filter: filter, Limit: 1}
+	}
+	events := NewClickHouseEventQueryBackend(s.conn, ClickHouseEventsConfig{Database: s.config.Database})
+
+	return []projectionCheck{
+		{"audit logs, platform scope", "platform_query_projection", func(ctx context.Context) (string, []interface{}, error) {
+			return s.buildQuery(ctx, auditSpec(""), platform)
+		}},
+		{"audit logs, platform scope filtered by user", "user_query_projection", func(ctx context.Context) (string, []interface{}, error) {
+			return s.buildQuery(ctx, auditSpec("user.username == 'projection-check'"), platform)
+		}},
+		{"audit logs, user scope", "user_uid_query_projection", func(ctx context.Context) (string, []interface{}, error) {
+			return s.buildQuery(ctx, auditSpec(""), user)
+		}},
+		{"activities, platform scope", "platform_query_projection", func(ctx context.Context) (string, []interface{}, error) {
+			return s.buildActivityQuery(ctx, activitySpec(""), platform)
+		}},
+		{"activities, platform scope filtered by actor", "actor_query_projection", func(ctx context.Context) (string, []interface{}, error) {
+			return s.buildActivityQuery(ctx, activitySpec("spec.actor.name == 'projection-check'"), platform)
+		}},
+		{"activities, user scope", "actor_uid_query_projection", func(ctx context.Context) (string, []interface{}, error) {
+			return s.buildActivityQuery(ctx, activitySpec(""), user)
+		}},
+		{"events, platform scope", "platform_query_projection", func(ctx context.Context) (string, []interface{}, error) {
+			return events.buildQuery(ctx, v1alpha1.EventQuerySpec{StartTime: "now-1h", EndTime: "now", Limit: 1}, platform)
+		}},
+	}
+}
+
+// VerifyProjections asks ClickHouse which projection each query pattern
+// selects, without running the queries. A projection that exists but is not
+// selected, usually because the query's ORDER BY no longer matches its sort
+// key, is otherwise invisible: results stay correct, but queries read far more
+// data.
+//
+// Projections live on the local tables, so verification is not supported when
+// querying a cluster through Distributed tables.
+func (s *ClickHouseStorage) VerifyProjections(ctx context.Context) ([]ProjectionCheckResult, error) {
+	if s.config.Cluster != "" {
+		return nil, fmt.Errorf("projection verification is not supported with a ClickHouse cluster, whose Distributed tables have no projections")
+	}
+
+	checks := s.projectionChecks()
+	results := make([]ProjectionCheckResult, 0, len(checks))
+	for _, check := range checks {
+		result := ProjectionCheckResult{Pattern: check.pattern, Expected: check.projection}
+
+		query, args, err := check.build(ctx)
+		if err != nil {
+			result.Err = fmt.Errorf("failed to build query: %w", err)
+			results = append(results, result)
+			continue
+		}
+
+		plan, err := s.explain(ctx, query, args)
+		if err != nil {
+			result.Err = err
+		} else {
+			result.Selected = selectedProjections(plan)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// explain returns the query plan ClickHouse would use for query. Only the
+// selected projection is named in it; EXPLAIN projections = 1 would also list
+// ones that were analyzed and rejected.
+func (s *ClickHouseStorage) explain(ctx context.Context, query string, args []interface{}) (string, error) {
+	rows, err := s.conn.Query(ctx, "EXPLAIN "+query, args...)
+	if err != nil {
+		return "", fmt.Errorf("EXPLAIN failed: %w", err)
+	}
+	defer rows.Close()
+
+	var plan strings.Builder
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			return "", fmt.Errorf("failed to read query plan: %w", err)
+		}
+		plan.WriteString(line)
+		plan.WriteByte('\n')
+	}
+	if err := rows.Err(); err != nil {
+		return "", fmt.Errorf("failed to read query plan: %w", err)
+	}
+	return plan.String(), nil
+}
+
+// projectionNamePattern matches the projection names used in the schema.
+var projectionNamePattern = regexp.MustCompile(`\b[a-z_]+_projection\b`)
+
+// selectedProjections returns the distinct projection names in a query plan,
+// in order of appearance. Plans name the projection on the read step that uses
+// it.
+func selectedProjections(plan string) []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, name := range projectionNamePattern.FindAllString(plan, -1) {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,106 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+)
+
+func TestSelectedProjections(t *testing.T) {
+	plan := `Expression (Project names)
+  Limit (preliminary LIMIT (without OFFSET))
+    Sorting (Sorting for ORDER BY)
+      Expression ((Before ORDER BY + (Projection + Change column names to column identifiers)))
+        ReadFromMergeTree (user_query_projection)
+`
+	if got := selectedProjections(plan); !reflect.DeepEqual(got, []string{"user_query_projection"}) {
+		t.Errorf("selectedProjections() = %v, want [user_query_projection]", got)
+	}
+
+	primaryKey := `Expression ((Project names + Projection))
+  Limit (preliminary LIMIT (without OFFSET))
+    ReadFromMergeTree (audit.audit_logs)
+`
+	if got := selectedProjections(primaryKey); len(got) != 0 {
+		t.Errorf("selectedProjections() = %v, want none for a primary key read", got)
+	}
+}
+
+func TestProjectionCheckResultOK(t *testing.T) {
+	tests := []struct {
+		name   string
+		result ProjectionCheckResult
+		want   bool
+	}{
+		{"selected", ProjectionCheckResult{Expected: "platform_query_projection", Selected: []string{"platform_query_projection"}}, true},
+		{"primary key", ProjectionCheckResult{Expected: "platform_query_projection"}, false},
+		{"other projection", ProjectionCheckResult{Expected: "user_query_projection", Selected: []string{"platform_query_projection"}}, false},
+		{"explain failed", ProjectionCheckResult{Expected: "platform_query_projection", Selected: []string{"platform_query_projection"}, Err: errors.New("boom")}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.result.OK(); got != tt.want {
+				t.Errorf("OK() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProjectionChecksBuild(t *testing.T) {
+	s := &ClickHouseStorage{config: ClickHouseConfig{Database: "audit", MaxQueryWindow: 30 * 24 * time.Hour, MaxPageSize: 1000}}
+
+	for _, check := range s.projectionChecks() {
+		query, _, err := check.build(context.Background())
+		if err != nil {
+			t.Errorf("%s: failed to build query: %v", check.pattern, err)
+			continue
+		}
+		if !strings.Contains(query, " ORDER BY ") {
+			t.Errorf("%s: expected an ORDER BY, got %s", check.pattern, query)
+		}
+	}
+}
+
+// failingConn is a ClickHouse connection whose queries all fail.
+type failingConn struct {
+	driver.Conn
+	queries []string
+}
+
+func (c *failingConn) Query(ctx context.Context, query string, args ...any) (driver.Rows, error) {
+	c.queries = append(c.queries, query)
+	return nil, errors.New("connection refused")
+}
+
+func TestVerifyProjections(t *testing.T) {
+	conn := &failingConn{}
+	s := &ClickHouseStorage{conn: conn, config: ClickHouseConfig{Database: "audit", MaxQueryWindow: 30 * 24 * time.Hour, MaxPageSize: 1000}}
+
+	results, err := s.VerifyProjections(context.Background())
+	if err != nil {
+		t.Fatalf("VerifyProjections() error = %v", err)
+	}
+	if len(results) != len(s.projectionChecks()) {
+		t.Fatalf("expected one result per check, got %d", len(results))
+	}
+	for _, result := range results {
+		if result.Err == nil || result.OK() {
+			t.Errorf("%s: expected the EXPLAIN failure to be reported", result.Pattern)
+		}
+	}
+	for _, query := range conn.queries {
+		if !strings.HasPrefix(query, "EXPLAIN SELECT ") {
+			t.Errorf("expected only EXPLAIN queries, got %s", query)
+		}
+	}
+
+	s.config.Cluster = "activity"
+	if _, err := s.VerifyProjections(context.Background()); err == nil {
+		t.Error("expected verification to be refused for a cluster")
+	}
+}
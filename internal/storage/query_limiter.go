@@ -0,0 +1,170 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+
+	"go.miloapis.com/activity/internal/metrics"
+)
+
+// ErrQueryQueueTimeout is returned when a query waited QueryQueueTimeout for
+// one of the MaxConcurrentQueries slots without getting one. The query never
+// reached ClickHouse.
+var ErrQueryQueueTimeout = errors.New("timed out waiting for a free query slot")
+
+// QueryQueueTimeoutMessage is the user-facing message for ErrQueryQueueTimeout.
+const QueryQueueTimeoutMessage = "Too many queries are in progress. Try again in a few seconds."
+
+// IsQueryQueueTimeout reports whether err means the query was rejected because
+// every query slot stayed busy.
+func IsQueryQueueTimeout(err error) bool {
+	return errors.Is(err, ErrQueryQueueTimeout)
+}
+
+// limitedConn caps the number of concurrent reads against ClickHouse,
+// independently of the apiserver's own max-in-flight limits, since a single
+// API request can fan out into several expensive queries. Writes (Exec,
+// PrepareBatch) are not limited.
+//
+// A slot is held until the query's rows are closed, so the limit covers
+// streaming results as well as executing the query.
+type limitedConn struct {
+	driver.Conn
+
+	slots   chan struct{} // nil when unlimited
+	timeout time.Duration
+}
+
+// newLimitedConn wraps conn so that at most maxConcurrent queries run at once.
+// Queries wait up to timeout for a slot; zero or less fails immediately when
+// all slots are busy. A maxConcurrent of zero or less only tracks in-flight
+// queries.
+func newLimitedConn(conn driver.Conn, maxConcurrent int, timeout time.Duration) *limitedConn {
+	c := &limitedConn{Conn: conn, timeout: timeout}
+	if maxConcurrent > 0 {
+		c.slots = make(chan struct{}, maxConcurrent)
+	}
+	return c
+}
+
+// acquire waits for a query slot and returns a func that releases it. The
+// release func is safe to call more than once.
+func (c *limitedConn) acquire(ctx context.Context) (func(), error) {
+	if c.slots != nil {
+		start := time.Now()
+		if err := c.wait(ctx); err != nil {
+			metrics.ClickHouseQueryQueueWait.Observe(time.Since(start).Seconds())
+			return nil, err
+		}
+		metrics.ClickHouseQueryQueueWait.Observe(time.Since(start).Seconds())
+	}
+
+	metrics.ClickHouseInflightQueries.Inc()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			metrics.ClickHouseInflightQueries.Dec()
+			if c.slots != nil {
+				<-c.slots
+			}
+		})
+	}, nil
+}
+
+func (c *limitedConn) wait(ctx context.Context) error {
+	select {
+	case c.slots <- struct{}{}:
+		return nil
+	default:
+	}
+	if c.timeout <= 0 {
+		return ErrQueryQueueTimeout
+	}
+
+	timer := time.NewTimer(c.timeout)
+	defer timer.Stop()
+
+	select {
+	case c.slots <- struct{}{}:
+		return nil
+	case <-timer.C:
+		return ErrQueryQueueTimeout
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *limitedConn) Query(ctx context.Context, query string, args ...any) (driver.Rows, error) {
+	release, err := c.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := c.Conn.Query(ctx, query, args...)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	return &limitedRows{Rows: rows, release: release}, nil
+}
+
+func (c *limitedConn) QueryRow(ctx context.Context, query string, args ...any) driver.Row {
+	release, err := c.acquire(ctx)
+	if err != nil {
+		return &errRow{err: err}
+	}
+	return &limitedRow{Row: c.Conn.QueryRow(ctx, query, args...), release: release}
+}
+
+func (c *limitedConn) Select(ctx context.Context, dest any, query string, args ...any) error {
+	release, err := c.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return c.Conn.Select(ctx, dest, query, args...)
+}
+
+// limitedRows releases its query slot when closed.
+type limitedRows struct {
+	driver.Rows
+	release func()
+}
+
+func (r *limitedRows) Close() error {
+	defer r.release()
+	return r.Rows.Close()
+}
+
+// limitedRow releases its query slot once the row has been read.
+type limitedRow struct {
+	driver.Row
+	release func()
+}
+
+func (r *limitedRow) Err() error {
+	defer r.release()
+	return r.Row.Err()
+}
+
+func (r *limitedRow) Scan(dest ...any) error {
+	defer r.release()
+	return r.Row.Scan(dest...)
+}
+
+func (r *limitedRow) ScanStruct(dest any) error {
+	defer r.release()
+	return r.Row.ScanStruct(dest)
+}
+
+// errRow is a row for a query that never ran.
+type errRow struct {
+	err error
+}
+
+func (r *errRow) Err() error                { return r.err }
+func (r *errRow) Scan(dest ...any) error    { return r.err }
+func (r *errRow) ScanStruct(dest any) error { return r.err }
@@ -0,0 +1,156 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"k8s.io/component-base/metrics/testutil"
+
+	"go.miloapis.com/activity/internal/metrics"
+	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+)
+
+// fakeRowsConn is a ClickHouse connection whose queries succeed immediately
+// with empty results.
+type fakeRowsConn struct {
+	driver.Conn
+}
+
+func (c *fakeRowsConn) Query(ctx context.Context, query string, args ...any) (driver.Rows, error) {
+	return &fakeRows{}, nil
+}
+
+func (c *fakeRowsConn) QueryRow(ctx context.Context, query string, args ...any) driver.Row {
+	return &errRow{}
+}
+
+type fakeRows struct {
+	driver.Rows
+}
+
+func (r *fakeRows) Close() error { return nil }
+
+func TestLimitedConn_HoldsSlotUntilRowsClosed(t *testing.T) {
+	conn := newLimitedConn(&fakeRowsConn{}, 1, 0)
+	ctx := context.Background()
+
+	inflightBefore, _ := testutil.GetGaugeMetricValue(metrics.ClickHouseInflightQueries)
+
+	rows, err := conn.Query(ctx, "SELECT 1")
+	if err != nil {
+		t.Fatalf("first Query() error = %v", err)
+	}
+	if inflight, _ := testutil.GetGaugeMetricValue(metrics.ClickHouseInflightQueries); inflight-inflightBefore != 1 {
+		t.Errorf("in-flight queries = %v, want 1", inflight-inflightBefore)
+	}
+
+	if _, err := conn.Query(ctx, "SELECT 1"); !errors.Is(err, ErrQueryQueueTimeout) {
+		t.Fatalf("second Query() error = %v, want ErrQueryQueueTimeout", err)
+	}
+	if err := conn.QueryRow(ctx, "SELECT 1").Scan(); !errors.Is(err, ErrQueryQueueTimeout) {
+		t.Fatalf("QueryRow() error = %v, want ErrQueryQueueTimeout", err)
+	}
+
+	// Closing twice must only release the slot once.
+	_ = rows.Close()
+	_ = rows.Close()
+	if inflight, _ := testutil.GetGaugeMetricValue(metrics.ClickHouseInflightQueries); inflight != inflightBefore {
+		t.Errorf("in-flight queries after close = %v, want %v", inflight, inflightBefore)
+	}
+
+	rows, err = conn.Query(ctx, "SELECT 1")
+	if err != nil {
+		t.Fatalf("Query() after release error = %v", err)
+	}
+	_ = rows.Close()
+
+	if err := conn.QueryRow(ctx, "SELECT 1").Scan(); err != nil {
+		t.Fatalf("QueryRow() error = %v", err)
+	}
+	rows, err = conn.Query(ctx, "SELECT 1")
+	if err != nil {
+		t.Fatalf("Query() after QueryRow error = %v, want the row to release its slot", err)
+	}
+	_ = rows.Close()
+}
+
+func TestLimitedConn_WaitsForSlot(t *testing.T) {
+	conn := newLimitedConn(&fakeRowsConn{}, 1, 5*time.Second)
+	ctx := context.Background()
+
+	rows, err := conn.Query(ctx, "SELECT 1")
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		_ = rows.Close()
+	}()
+
+	second, err := conn.Query(ctx, "SELECT 1")
+	if err != nil {
+		t.Fatalf("waiting Query() error = %v, want slot once the first query closes", err)
+	}
+	_ = second.Close()
+}
+
+func TestLimitedConn_WaitTimeout(t *testing.T) {
+	conn := newLimitedConn(&fakeRowsConn{}, 1, 10*time.Millisecond)
+
+	rows, err := conn.Query(context.Background(), "SELECT 1")
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	defer rows.Close()
+
+	if _, err := conn.Query(context.Background(), "SELECT 1"); !IsQueryQueueTimeout(err) {
+		t.Fatalf("Query() error = %v, want ErrQueryQueueTimeout", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	conn.timeout = time.Minute
+	if _, err := conn.Query(ctx, "SELECT 1"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Query() with cancelled context error = %v, want context.Canceled", err)
+	}
+}
+
+func TestLimitedConn_Unlimited(t *testing.T) {
+	conn := newLimitedConn(&fakeRowsConn{}, 0, 0)
+
+	for i := 0; i < 10; i++ {
+		rows, err := conn.Query(context.Background(), "SELECT 1")
+		if err != nil {
+			t.Fatalf("Query() %d error = %v", i, err)
+		}
+		defer rows.Close()
+	}
+}
+
+func TestQueryAuditLogs_QueueTimeout(t *testing.T) {
+	conn := newLimitedConn(&fakeRowsConn{}, 1, 0)
+	held, err := conn.Query(context.Background(), "SELECT 1")
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	defer held.Close()
+
+	s := &ClickHouseStorage{
+		conn:   conn,
+		config: ClickHouseConfig{MaxQueryWindow: 30 * 24 * time.Hour, MaxPageSize: 1000},
+	}
+	spec := v1alpha1.AuditLogQuerySpec{
+		StartTime: "now-1h",
+		EndTime:   "now",
+		Limit:     10,
+	}
+
+	_, err = s.QueryAuditLogs(context.Background(), spec, ScopeContext{Type: "platform"})
+	if !IsQueryQueueTimeout(err) {
+		t.Fatalf("QueryAuditLogs() error = %v, want ErrQueryQueueTimeout", err)
+	}
+}
@@ -0,0 +1,121 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"k8s.io/apiserver/pkg/warning"
+)
+
+// DefaultQueryTimeout is how long a query may run in ClickHouse when the
+// request does not set spec.timeoutSeconds.
+const DefaultQueryTimeout = 60 * time.Second
+
+// DefaultMaxQueryTimeout is the default upper bound for spec.timeoutSeconds.
+const DefaultMaxQueryTimeout = 60 * time.Second
+
+// QueryTimeoutMessage is the user-facing message for a query that ran past its timeout.
+const QueryTimeoutMessage = "Query did not finish within its timeout. Narrow the time range or filter, or raise spec.timeoutSeconds."
+
+// ValidateQueryTimeout checks a spec.timeoutSeconds override against the
+// server's maximum. Returns an error describing the problem, suitable for a
+// field.Invalid detail.
+func ValidateQueryTimeout(timeoutSeconds int32, max time.Duration) error {
+	if timeoutSeconds <= 0 {
+		return fmt.Errorf("must be a positive number of seconds")
+	}
+	if time.Duration(timeoutSeconds)*time.Second > max {
+		return fmt.Errorf("exceeds the maximum of %d seconds. Set timeoutSeconds to %d or less",
+			int64(max.Seconds()), int64(max.Seconds()))
+	}
+	return nil
+}
+
+// ClampQueryTimeout lowers a query's timeout to limit, the max_execution_time
+// of the caller's tenant type. The tenant limit is set in the query's
+// SETTINGS clause, where it overrides the max_execution_time WithQueryTimeout
+// derives from the timeout, so a longer timeout could never be reached. A nil
+// timeoutSeconds stands for DefaultQueryTimeout, and a limit of zero or less
+// means no tenant limit. Returns the timeout to pass to WithQueryTimeout and
+// whether an explicit spec.timeoutSeconds was lowered.
+func ClampQueryTimeout(timeoutSeconds *int32, limit time.Duration) (*int32, bool) {
+	if limit <= 0 {
+		return timeoutSeconds, false
+	}
+	timeout := DefaultQueryTimeout
+	if timeoutSeconds != nil {
+		timeout = time.Duration(*timeoutSeconds) * time.Second
+	}
+	if timeout <= limit {
+		return timeoutSeconds, false
+	}
+	clamped := int32(limit / time.Second)
+	return &clamped, timeoutSeconds != nil
+}
+
+// QueryTimeoutClampedWarning is the warning returned with a query whose
+// spec.timeoutSeconds was lowered to its tenant's execution time limit.
+func QueryTimeoutClampedWarning(timeoutSeconds, limitSeconds int32) string {
+	return fmt.Sprintf("timeoutSeconds of %d exceeds the %d-second execution time limit for your tenant, so the query may run for at most %d seconds",
+		timeoutSeconds, limitSeconds, limitSeconds)
+}
+
+// WithQueryTimeout bounds the queries run with the returned context to the
+// spec.timeoutSeconds override, or DefaultQueryTimeout when it is nil. The
+// timeout becomes both a context deadline and the ClickHouse
+// max_execution_time setting. When the deadline is more than a second away the
+// driver sets max_execution_time a few seconds past it instead, so the client
+// side gives up first and the caller sees context.DeadlineExceeded.
+//
+// The override must already be validated with ValidateQueryTimeout.
+func WithQueryTimeout(ctx context.Context, timeoutSeconds *int32) (context.Context, context.CancelFunc) {
+	timeout := DefaultQueryTimeout
+	if timeoutSeconds != nil {
+		timeout = time.Duration(*timeoutSeconds) * time.Second
+	}
+
+	ctx = clickhouse.Context(ctx, clickhouse.WithSettings(clickhouse.Settings{
+		"max_execution_time": int(timeout.Seconds()),
+	}))
+	return context.WithTimeout(ctx, timeout)
+}
+
+// TenantQueryContext bounds the queries run with the returned context like
+// WithQueryTimeout, after lowering timeoutSeconds to tenantLimit, the
+// max_execution_time of the caller's tenant type (see ClampQueryTimeout). An
+// explicit timeoutSeconds that is lowered is reported to the client as a
+// warning. Every query a tenant can issue should get its context here, so the
+// timeout it is held to is the one ClickHouse enforces.
+//
+// The override must already be validated with ValidateQueryTimeout.
+func TenantQueryContext(ctx context.Context, timeoutSeconds *int32, tenantLimit time.Duration) (context.Context, context.CancelFunc) {
+	clampedSeconds, clamped := ClampQueryTimeout(timeoutSeconds, tenantLimit)
+	if clamped {
+		warning.AddWarning(ctx, "", QueryTimeoutClampedWarning(*timeoutSeconds, *clampedSeconds))
+	}
+	return WithQueryTimeout(ctx, clampedSeconds)
+}
+
+// IsQueryTimeout reports whether a query run with ctx failed because ctx's
+// deadline passed, as opposed to the client cancelling the request.
+func IsQueryTimeout(ctx context.Context) bool {
+	return errors.Is(ctx.Err(), context.DeadlineExceeded)
+}
+
+// clickhouseTimeoutExceeded is the ClickHouse error code raised when a query
+// runs past max_execution_time.
+const clickhouseTimeoutExceeded = 159
+
+// isIterationTimeout reports whether err, returned while reading a query's
+// rows, means the query ran out of time: either ctx's deadline passed or
+// ClickHouse stopped the query at max_execution_time.
+func isIterationTimeout(ctx context.Context, err error) bool {
+	if IsQueryTimeout(ctx) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var exception *clickhouse.Exception
+	return errors.As(err, &exception) && exception.Code == clickhouseTimeoutExceeded
+}
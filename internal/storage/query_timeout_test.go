@@ -0,0 +1,163 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"k8s.io/apiserver/pkg/warning"
+	"k8s.io/utils/ptr"
+)
+
+func TestValidateQueryTimeout(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout int32
+		max     time.Duration
+		wantErr bool
+	}{
+		{name: "within maximum", timeout: 30, max: time.Minute},
+		{name: "equal to maximum", timeout: 60, max: time.Minute},
+		{name: "above maximum", timeout: 61, max: time.Minute, wantErr: true},
+		{name: "zero", timeout: 0, max: time.Minute, wantErr: true},
+		{name: "negative", timeout: -5, max: time.Minute, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateQueryTimeout(tt.timeout, tt.max)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateQueryTimeout(%d, %v) error = %v, wantErr %v", tt.timeout, tt.max, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWithQueryTimeout(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout *int32
+		want    time.Duration
+	}{
+		{name: "default", timeout: nil, want: DefaultQueryTimeout},
+		{name: "override", timeout: ptr.To[int32](5), want: 5 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := WithQueryTimeout(context.Background(), tt.timeout)
+			defer cancel()
+
+			deadline, ok := ctx.Deadline()
+			if !ok {
+				t.Fatal("WithQueryTimeout() context has no deadline")
+			}
+			if remaining := time.Until(deadline); remaining > tt.want || remaining < tt.want-time.Second {
+				t.Errorf("deadline = %v away, want about %v", remaining, tt.want)
+			}
+			if IsQueryTimeout(ctx) {
+				t.Error("IsQueryTimeout() = true before the deadline")
+			}
+		})
+	}
+}
+
+func TestClampQueryTimeout(t *testing.T) {
+	tests := []struct {
+		name        string
+		timeout     *int32
+		limit       time.Duration
+		want        *int32
+		wantClamped bool
+	}{
+		{name: "no tenant limit", timeout: ptr.To[int32](90), limit: 0, want: ptr.To[int32](90)},
+		{name: "override within limit", timeout: ptr.To[int32](10), limit: 20 * time.Second, want: ptr.To[int32](10)},
+		{name: "override above limit", timeout: ptr.To[int32](90), limit: 20 * time.Second, want: ptr.To[int32](20), wantClamped: true},
+		{name: "default within limit", timeout: nil, limit: 2 * time.Minute, want: nil},
+		{name: "default above limit", timeout: nil, limit: 20 * time.Second, want: ptr.To[int32](20)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, clamped := ClampQueryTimeout(tt.timeout, tt.limit)
+			if !ptr.Equal(got, tt.want) {
+				t.Errorf("ClampQueryTimeout() = %v, want %v", ptr.Deref(got, -1), ptr.Deref(tt.want, -1))
+			}
+			if clamped != tt.wantClamped {
+				t.Errorf("ClampQueryTimeout() clamped = %v, want %v", clamped, tt.wantClamped)
+			}
+		})
+	}
+}
+
+// recordedWarnings collects the warnings added to a request context.
+type recordedWarnings []string
+
+func (w *recordedWarnings) AddWarning(agent, text string) {
+	*w = append(*w, text)
+}
+
+func TestTenantQueryContext(t *testing.T) {
+	tests := []struct {
+		name         string
+		timeout      *int32
+		limit        time.Duration
+		wantDeadline time.Duration
+		wantWarnings int
+	}{
+		{name: "no tenant limit", timeout: ptr.To[int32](90), wantDeadline: 90 * time.Second},
+		{name: "override within limit", timeout: ptr.To[int32](10), limit: 20 * time.Second, wantDeadline: 10 * time.Second},
+		{name: "override above limit", timeout: ptr.To[int32](50), limit: 20 * time.Second, wantDeadline: 20 * time.Second, wantWarnings: 1},
+		{name: "default above limit", limit: 20 * time.Second, wantDeadline: 20 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings := &recordedWarnings{}
+			ctx, cancel := TenantQueryContext(warning.WithWarningRecorder(context.Background(), warnings), tt.timeout, tt.limit)
+			defer cancel()
+
+			deadline, _ := ctx.Deadline()
+			if remaining := time.Until(deadline); remaining > tt.wantDeadline || remaining < tt.wantDeadline-time.Second {
+				t.Errorf("deadline = %v away, want about %v", remaining, tt.wantDeadline)
+			}
+			if len(*warnings) != tt.wantWarnings {
+				t.Errorf("warnings = %q, want %d", *warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestIsQueryTimeout(t *testing.T) {
+	expired, cancel := context.WithTimeout(context.Background(), -time.Second)
+	defer cancel()
+	if !IsQueryTimeout(expired) {
+		t.Error("IsQueryTimeout() = false for an expired deadline, want true")
+	}
+
+	cancelled, cancelFn := context.WithCancel(context.Background())
+	cancelFn()
+	if IsQueryTimeout(cancelled) {
+		t.Error("IsQueryTimeout() = true for a cancelled request, want false")
+	}
+}
+
+func TestIsIterationTimeout(t *testing.T) {
+	expired, cancel := context.WithTimeout(context.Background(), -time.Second)
+	defer cancel()
+	if !isIterationTimeout(expired, expired.Err()) {
+		t.Error("isIterationTimeout() = false for an expired deadline, want true")
+	}
+
+	serverTimeout := fmt.Errorf("read: %w", &clickhouse.Exception{Code: clickhouseTimeoutExceeded, Message: "Timeout exceeded"})
+	if !isIterationTimeout(context.Background(), serverTimeout) {
+		t.Error("isIterationTimeout() = false for a ClickHouse TIMEOUT_EXCEEDED error, want true")
+	}
+
+	if isIterationTimeout(context.Background(), errors.New("connection reset by peer")) {
+		t.Error("isIterationTimeout() = true for a connection error, want false")
+	}
+}
@@ -0,0 +1,43 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultActivityRetentionWindow matches the TTL on the activities table.
+const DefaultActivityRetentionWindow = 60 * 24 * time.Hour
+
+// DefaultEventRetentionWindow matches the TTL on the k8s_events table.
+const DefaultEventRetentionWindow = 60 * 24 * time.Hour
+
+// ClampToRetention moves start forward to the oldest time still retained when it
+// reaches further back than the retention window. Returns the start to report
+// and whether it was clamped. A window of zero or less means no retention limit.
+func ClampToRetention(start, now time.Time, window time.Duration) (time.Time, bool) {
+	if window <= 0 {
+		return start, false
+	}
+	oldest := now.Add(-window)
+	if start.Before(oldest) {
+		return oldest, true
+	}
+	return start, false
+}
+
+// RetentionWarning is the warning returned with a query whose startTime was
+// clamped to the retention window.
+func RetentionWarning(window time.Duration, oldest time.Time) string {
+	return fmt.Sprintf("startTime is older than the %s retention window, so results start at %s. Data from before then has aged out and is no longer available",
+		formatRetentionWindow(window), oldest.UTC().Format(time.RFC3339))
+}
+
+// formatRetentionWindow renders whole-day windows in days, which is how
+// retention is usually configured, and anything else as a duration.
+func formatRetentionWindow(window time.Duration) string {
+	day := 24 * time.Hour
+	if window%day == 0 {
+		return fmt.Sprintf("%d-day", window/day)
+	}
+	return window.String()
+}
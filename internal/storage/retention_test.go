@@ -0,0 +1,50 @@
+package storage
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClampToRetention(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	window := 60 * 24 * time.Hour
+	oldest := now.Add(-window)
+
+	tests := []struct {
+		name        string
+		start       time.Time
+		window      time.Duration
+		wantStart   time.Time
+		wantClamped bool
+	}{
+		{name: "within window", start: now.Add(-24 * time.Hour), window: window, wantStart: now.Add(-24 * time.Hour)},
+		{name: "at window boundary", start: oldest, window: window, wantStart: oldest},
+		{name: "before window", start: now.Add(-90 * 24 * time.Hour), window: window, wantStart: oldest, wantClamped: true},
+		{name: "no retention limit", start: now.Add(-365 * 24 * time.Hour), window: 0, wantStart: now.Add(-365 * 24 * time.Hour)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotStart, gotClamped := ClampToRetention(tt.start, now, tt.window)
+			if !gotStart.Equal(tt.wantStart) || gotClamped != tt.wantClamped {
+				t.Errorf("ClampToRetention() = (%v, %v), want (%v, %v)", gotStart, gotClamped, tt.wantStart, tt.wantClamped)
+			}
+		})
+	}
+}
+
+func TestRetentionWarning(t *testing.T) {
+	oldest := time.Date(2026, 8, 17, 12, 0, 0, 0, time.UTC)
+
+	got := RetentionWarning(60*24*time.Hour, oldest)
+	for _, want := range []string{"60-day retention window", "2026-08-17T12:00:00Z"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("RetentionWarning() = %q, want it to contain %q", got, want)
+		}
+	}
+
+	if got := RetentionWarning(36*time.Hour, oldest); !strings.Contains(got, "36h0m0s retention window") {
+		t.Errorf("RetentionWarning() = %q, want a duration for partial days", got)
+	}
+}
@@ -0,0 +1,173 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/klog/v2"
+
+	"go.miloapis.com/activity/internal/types"
+)
+
+const (
+	// ScopeStatsWindow is the fixed look-back window for scope stats. It is
+	// not configurable so the aggregates stay cheap enough for a landing page.
+	ScopeStatsWindow = 24 * time.Hour
+
+	// ScopeStatsTopResources is the number of resource kinds returned in
+	// ScopeStatsResult.TopResources.
+	ScopeStatsTopResources = 5
+)
+
+// ScopeStatsResult contains high-level counts for a scope over a time window.
+type ScopeStatsResult struct {
+	// AuditEventCount is the number of audit events in the window.
+	AuditEventCount int64
+
+	// ActivityCount is the number of activities in the window.
+	ActivityCount int64
+
+	// UniqueActors is the approximate number of distinct activity actors.
+	UniqueActors int64
+
+	// TopResources are the resource kinds with the most activities, most
+	// active first.
+	TopResources []ScopeStatsResourceCount
+
+	// NewestAuditEventTime is the timestamp of the most recent audit event,
+	// or the zero time when the window has none.
+	NewestAuditEventTime time.Time
+}
+
+// ScopeStatsResourceCount is the number of activities for one resource kind.
+type ScopeStatsResourceCount struct {
+	APIGroup string
+	Kind     string
+	Count    int64
+}
+
+// QueryScopeStats computes summary counts for the caller's scope between
+// startTime and endTime. It runs three aggregate queries: one against audit
+// logs and two against activities.
+func (s *ClickHouseStorage) QueryScopeStats(ctx context.Context, startTime, endTime time.Time, scope ScopeContext) (*ScopeStatsResult, error) {
+	ctx, span := tracer.Start(ctx, "clickhouse.query_scope_stats",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "clickhouse"),
+			attribute.String("db.name", s.config.Database),
+			attribute.String("db.operation", "SELECT"),
+			attribute.String("scope.type", scope.Type),
+		),
+	)
+	defer span.End()
+
+	traceID := span.SpanContext().TraceID().String()
+	spanID := span.SpanContext().SpanID().String()
+
+	fail := func(err error, msg string) (*ScopeStatsResult, error) {
+		if isQueryCancelled(ctx, err) {
+			recordQueryCancelled(span)
+			return nil, errQueryCancelled
+		}
+		span.RecordError(err)
+		if IsQueryQueueTimeout(err) {
+			span.SetStatus(codes.Error, "query queue timeout")
+			return nil, err
+		}
+		span.SetStatus(codes.Error, msg)
+		klog.ErrorS(err, "Failed to query scope stats",
+			"step", msg,
+			"traceID", traceID,
+			"spanID", spanID,
+		)
+		return nil, fmt.Errorf("unable to retrieve stats. Try again or contact support if the problem persists")
+	}
+
+	result := &ScopeStatsResult{TopResources: make([]ScopeStatsResourceCount, 0, ScopeStatsTopResources)}
+
+	auditWhere, auditArgs := scopeStatsConditions(scope, startTime, endTime, "scope_type", "scope_name", "user_uid")
+	auditQuery := fmt.Sprintf("SELECT count(), max(timestamp) FROM %s WHERE %s", s.table("audit_logs"), auditWhere) + s.querySettings(scope)
+
+	var auditCount uint64
+	var newest time.Time
+	if err := s.conn.QueryRow(ctx, auditQuery, auditArgs...).Scan(&auditCount, &newest); err != nil {
+		return fail(err, "audit log totals")
+	}
+	result.AuditEventCount = int64(auditCount)
+	// max() over an empty set returns the epoch rather than NULL
+	if auditCount > 0 {
+		result.NewestAuditEventTime = newest.UTC()
+	}
+
+	activityWhere, activityArgs := scopeStatsConditions(scope, startTime, endTime, "tenant_type", "tenant_name", "actor_uid")
+	activityQuery := fmt.Sprintf("SELECT count(), uniq(actor_name) FROM %s WHERE %s", s.table("activities"), activityWhere) + s.querySettings(scope)
+
+	var activityCount, uniqueActors uint64
+	if err := s.conn.QueryRow(ctx, activityQuery, activityArgs...).Scan(&activityCount, &uniqueActors); err != nil {
+		return fail(err, "activity totals")
+	}
+	result.ActivityCount = int64(activityCount)
+	result.UniqueActors = int64(uniqueActors)
+
+	topQuery := fmt.Sprintf(
+		"SELECT api_group, resource_kind, count() AS c FROM %s WHERE %s GROUP BY api_group, resource_kind ORDER BY c DESC, api_group ASC, resource_kind ASC LIMIT %d",
+		s.table("activities"), activityWhere, ScopeStatsTopResources,
+	) + s.querySettings(scope)
+
+	rows, err := s.conn.Query(ctx, topQuery, activityArgs...)
+	if err != nil {
+		return fail(err, "top resources")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var apiGroup, kind string
+		var count uint64
+		if err := rows.Scan(&apiGroup, &kind, &count); err != nil {
+			return fail(err, "top resources")
+		}
+		result.TopResources = append(result.TopResources, ScopeStatsResourceCount{
+			APIGroup: apiGroup,
+			Kind:     kind,
+			Count:    int64(count),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return fail(err, "top resources")
+	}
+
+	span.SetAttributes(
+		attribute.Int64("stats.audit_events", result.AuditEventCount),
+		attribute.Int64("stats.activities", result.ActivityCount),
+	)
+	span.SetStatus(codes.Ok, "scope stats query successful")
+	return result, nil
+}
+
+// scopeStatsConditions builds the WHERE clause shared by the stats queries.
+// Audit logs and activities name their scope columns differently, so the
+// caller passes the tenant type, tenant name and user UID columns to use.
+func scopeStatsConditions(scope ScopeContext, startTime, endTime time.Time, typeColumn, nameColumn, userColumn string) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if scope.Type != types.TenantTypePlatform {
+		if scope.Type == types.TenantTypeUser {
+			conditions = append(conditions, userColumn+" = ?")
+			args = append(args, scope.Name)
+		} else {
+			conditions = append(conditions, typeColumn+" = ?", nameColumn+" = ?")
+			args = append(args, scope.Type, scope.Name)
+		}
+	}
+
+	conditions = append(conditions, "timestamp >= ?", "timestamp < ?")
+	args = append(args, startTime, endTime)
+
+	return strings.Join(conditions, " AND "), args
+}
@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"go.miloapis.com/activity/internal/types"
+)
+
+func TestScopeStatsConditions(t *testing.T) {
+	start := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	end := start.Add(ScopeStatsWindow)
+
+	tests := []struct {
+		name      string
+		scope     ScopeContext
+		wantWhere string
+		wantArgs  int
+	}{
+		{
+			name:      "platform",
+			scope:     ScopeContext{Type: types.TenantTypePlatform},
+			wantWhere: "timestamp >= ? AND timestamp < ?",
+			wantArgs:  2,
+		},
+		{
+			name:      "user",
+			scope:     ScopeContext{Type: types.TenantTypeUser, Name: "u-123"},
+			wantWhere: "user_uid = ? AND timestamp >= ? AND timestamp < ?",
+			wantArgs:  3,
+		},
+		{
+			name:      "project",
+			scope:     ScopeContext{Type: types.TenantTypeProject, Name: "prod"},
+			wantWhere: "scope_type = ? AND scope_name = ? AND timestamp >= ? AND timestamp < ?",
+			wantArgs:  4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			where, args := scopeStatsConditions(tt.scope, start, end, "scope_type", "scope_name", "user_uid")
+			if where != tt.wantWhere {
+				t.Errorf("where = %q, want %q", where, tt.wantWhere)
+			}
+			if len(args) != tt.wantArgs {
+				t.Errorf("got %d args, want %d", len(args), tt.wantArgs)
+			}
+		})
+	}
+}
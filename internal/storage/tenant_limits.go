@@ -0,0 +1,143 @@
+package storage
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"go.miloapis.com/activity/internal/types"
+)
+
+// TenantQueryLimits caps the ClickHouse resources a single query may use when
+// it runs on behalf of one type of tenant. They complement the API layer's
+// concurrency limits: one expensive query from a noisy tenant fails in
+// ClickHouse instead of starving everyone else's.
+type TenantQueryLimits struct {
+	// MaxMemoryUsage is the ClickHouse max_memory_usage setting, in bytes.
+	// Zero leaves the server default.
+	MaxMemoryUsage int64
+
+	// MaxExecutionTime is the ClickHouse max_execution_time setting. The
+	// query's own timeout still cancels it from the client side, so a limit
+	// longer than the timeout has no effect, and a shorter one caps the
+	// timeout (see ClampQueryTimeout). Zero leaves the query's timeout as the
+	// only limit.
+	MaxExecutionTime time.Duration
+}
+
+// maxExecutionSeconds returns MaxExecutionTime in whole seconds, rounded up
+// so a sub-second limit does not become "no limit".
+func (l TenantQueryLimits) maxExecutionSeconds() int64 {
+	return int64((l.MaxExecutionTime + time.Second - 1) / time.Second)
+}
+
+// settings returns the limits as ClickHouse setting assignments.
+func (l TenantQueryLimits) settings() []string {
+	var settings []string
+	if l.MaxMemoryUsage > 0 {
+		settings = append(settings, fmt.Sprintf("max_memory_usage = %d", l.MaxMemoryUsage))
+	}
+	if l.MaxExecutionTime > 0 {
+		settings = append(settings, fmt.Sprintf("max_execution_time = %d", l.maxExecutionSeconds()))
+	}
+	return settings
+}
+
+// tenantLimitTypes are the scope types limits may be configured for.
+var tenantLimitTypes = []string{types.TenantTypePlatform, types.TenantTypeOrganization, types.TenantTypeProject, types.TenantTypeUser}
+
+// ParseTenantQueryLimits builds per-tenant-type limits from flag values keyed
+// by scope type (platform, Organization, Project or User). Memory values are
+// byte quantities such as "2Gi"; execution times are durations such as "20s".
+func ParseTenantQueryLimits(maxMemoryUsage, maxExecutionTime map[string]string) (map[string]TenantQueryLimits, error) {
+	limits := map[string]TenantQueryLimits{}
+
+	for _, tenantType := range sortedKeys(maxMemoryUsage) {
+		if err := validateTenantLimitType(tenantType); err != nil {
+			return nil, err
+		}
+		quantity, err := resource.ParseQuantity(maxMemoryUsage[tenantType])
+		if err != nil || quantity.Sign() <= 0 {
+			return nil, fmt.Errorf("invalid memory limit %q for %s: must be a positive byte quantity such as 2Gi", maxMemoryUsage[tenantType], tenantType)
+		}
+		l := limits[tenantType]
+		l.MaxMemoryUsage = quantity.Value()
+		limits[tenantType] = l
+	}
+
+	for _, tenantType := range sortedKeys(maxExecutionTime) {
+		if err := validateTenantLimitType(tenantType); err != nil {
+			return nil, err
+		}
+		d, err := time.ParseDuration(maxExecutionTime[tenantType])
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid execution time limit %q for %s: must be a positive duration such as 20s", maxExecutionTime[tenantType], tenantType)
+		}
+		l := limits[tenantType]
+		l.MaxExecutionTime = d
+		limits[tenantType] = l
+	}
+
+	return limits, nil
+}
+
+func validateTenantLimitType(tenantType string) error {
+	for _, t := range tenantLimitTypes {
+		if tenantType == t {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown tenant type %q: must be one of %s", tenantType, strings.Join(tenantLimitTypes, ", "))
+}
+
+// tenantMaxExecutionTime returns the max_execution_time limit in limits for
+// queries run on behalf of scope, in the whole seconds ClickHouse enforces, or
+// zero when its tenant type has none.
+func tenantMaxExecutionTime(limits map[string]TenantQueryLimits, scope ScopeContext) time.Duration {
+	return time.Duration(limits[scope.Type].maxExecutionSeconds()) * time.Second
+}
+
+// tenantQuerySettings returns the SETTINGS clause for a query run on behalf
+// of scope: the limits configured for its tenant type followed by extra. A
+// SETTINGS clause in the query takes precedence over the max_execution_time
+// the driver derives from the context deadline, which is what lets a tenant
+// limit lower it. Returns an empty string when there is nothing to set.
+func tenantQuerySettings(limits map[string]TenantQueryLimits, scope ScopeContext, extra ...string) string {
+	settings := append(limits[scope.Type].settings(), extra...)
+	return settingsClause(settings)
+}
+
+// GetTenantMaxExecutionTime returns the max_execution_time limit for queries
+// run on behalf of scope, or zero when its tenant type has none.
+func (s *ClickHouseStorage) GetTenantMaxExecutionTime(scope ScopeContext) time.Duration {
+	return tenantMaxExecutionTime(s.config.TenantQueryLimits, scope)
+}
+
+// querySettings returns the SETTINGS clause for an audit log or activity
+// query run on behalf of scope; see tenantQuerySettings.
+func (s *ClickHouseStorage) querySettings(scope ScopeContext, extra ...string) string {
+	return tenantQuerySettings(s.config.TenantQueryLimits, scope, extra...)
+}
+
+// GetTenantMaxExecutionTime returns the max_execution_time limit for event
+// queries run on behalf of scope, or zero when its tenant type has none.
+func (b *ClickHouseEventsBackend) GetTenantMaxExecutionTime(scope ScopeContext) time.Duration {
+	return tenantMaxExecutionTime(b.config.TenantQueryLimits, scope)
+}
+
+// GetTenantMaxExecutionTime returns the max_execution_time limit for event
+// queries run on behalf of scope, or zero when its tenant type has none.
+func (b *ClickHouseEventQueryBackend) GetTenantMaxExecutionTime(scope ScopeContext) time.Duration {
+	return tenantMaxExecutionTime(b.config.TenantQueryLimits, scope)
+}
+
+// settingsClause joins setting assignments into a SETTINGS clause, or returns
+// an empty string when there are none.
+func settingsClause(settings []string) string {
+	if len(settings) == 0 {
+		return ""
+	}
+	return " SETTINGS " + strings.Join(settings, ", ")
+}
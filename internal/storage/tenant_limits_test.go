@@ -0,0 +1,189 @@
+package storage
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"go.miloapis.com/activity/internal/types"
+	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+)
+
+func TestParseTenantQueryLimits(t *testing.T) {
+	limits, err := ParseTenantQueryLimits(
+		map[string]string{"Project": "1Gi", "Organization": "4000000000"},
+		map[string]string{"Project": "20s"},
+	)
+	if err != nil {
+		t.Fatalf("ParseTenantQueryLimits() error = %v", err)
+	}
+
+	if got := limits[types.TenantTypeProject]; got.MaxMemoryUsage != 1<<30 || got.MaxExecutionTime != 20*time.Second {
+		t.Errorf("Project limits = %+v, want 1Gi and 20s", got)
+	}
+	if got := limits[types.TenantTypeOrganization]; got.MaxMemoryUsage != 4000000000 || got.MaxExecutionTime != 0 {
+		t.Errorf("Organization limits = %+v, want 4000000000 bytes and no execution limit", got)
+	}
+	if _, ok := limits[types.TenantTypeUser]; ok {
+		t.Error("User should have no limits")
+	}
+
+	invalid := []struct {
+		name      string
+		memory    map[string]string
+		execution map[string]string
+	}{
+		{name: "unknown tenant type", memory: map[string]string{"Team": "1Gi"}},
+		{name: "lowercase tenant type", execution: map[string]string{"project": "20s"}},
+		{name: "invalid memory", memory: map[string]string{"Project": "lots"}},
+		{name: "zero memory", memory: map[string]string{"Project": "0"}},
+		{name: "invalid duration", execution: map[string]string{"Project": "20"}},
+		{name: "negative duration", execution: map[string]string{"Project": "-5s"}},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseTenantQueryLimits(tt.memory, tt.execution); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestQuerySettings(t *testing.T) {
+	s := &ClickHouseStorage{config: ClickHouseConfig{
+		TenantQueryLimits: map[string]TenantQueryLimits{
+			types.TenantTypeProject: {MaxMemoryUsage: 1 << 30, MaxExecutionTime: 1500 * time.Millisecond},
+		},
+	}}
+
+	project := ScopeContext{Type: types.TenantTypeProject, Name: "p"}
+	if got, want := s.querySettings(project), " SETTINGS max_memory_usage = 1073741824, max_execution_time = 2"; got != want {
+		t.Errorf("querySettings(Project) = %q, want %q", got, want)
+	}
+	if got, want := s.querySettings(project, "group_by_overflow_mode = 'throw'"), " SETTINGS max_memory_usage = 1073741824, max_execution_time = 2, group_by_overflow_mode = 'throw'"; got != want {
+		t.Errorf("querySettings(Project, extra) = %q, want %q", got, want)
+	}
+	if got := s.querySettings(ScopeContext{Type: types.TenantTypeOrganization, Name: "o"}); got != "" {
+		t.Errorf("querySettings(Organization) = %q, want no SETTINGS clause", got)
+	}
+}
+
+// TestTenantLimitClampsQueryTimeout tests a tenant execution time limit
+// together with a longer spec.timeoutSeconds: the timeout is lowered to the
+// limit the query's SETTINGS clause enforces.
+func TestTenantLimitClampsQueryTimeout(t *testing.T) {
+	s := &ClickHouseStorage{config: ClickHouseConfig{
+		TenantQueryLimits: map[string]TenantQueryLimits{
+			types.TenantTypeProject: {MaxExecutionTime: 1500 * time.Millisecond},
+		},
+	}}
+	project := ScopeContext{Type: types.TenantTypeProject, Name: "p"}
+
+	limit := s.GetTenantMaxExecutionTime(project)
+	if limit != 2*time.Second {
+		t.Fatalf("GetTenantMaxExecutionTime(Project) = %v, want the 2s in the SETTINGS clause", limit)
+	}
+	timeout, clamped := ClampQueryTimeout(ptr.To[int32](30), limit)
+	if !clamped || timeout == nil || *timeout != 2 {
+		t.Fatalf("ClampQueryTimeout(30s, %v) = %v, %v; want 2s, clamped", limit, ptr.Deref(timeout, -1), clamped)
+	}
+
+	ctx, cancel := WithQueryTimeout(context.Background(), timeout)
+	defer cancel()
+	deadline, _ := ctx.Deadline()
+	if remaining := time.Until(deadline); remaining > 2*time.Second {
+		t.Errorf("deadline = %v away, want no more than the 2s tenant limit", remaining)
+	}
+
+	if got := s.GetTenantMaxExecutionTime(ScopeContext{Type: types.TenantTypeOrganization, Name: "o"}); got != 0 {
+		t.Errorf("GetTenantMaxExecutionTime(Organization) = %v, want 0", got)
+	}
+}
+
+func TestBuildQuery_TenantLimits(t *testing.T) {
+	s := &ClickHouseStorage{config: ClickHouseConfig{
+		Database:       "audit",
+		MaxQueryWindow: 30 * 24 * time.Hour,
+		MaxPageSize:    1000,
+		TenantQueryLimits: map[string]TenantQueryLimits{
+			types.TenantTypeProject: {MaxMemoryUsage: 1 << 30},
+		},
+	}}
+	spec := v1alpha1.AuditLogQuerySpec{StartTime: "now-1h", EndTime: "now", Limit: 10}
+
+	query, _, err := s.buildQuery(context.Background(), spec, ScopeContext{Type: types.TenantTypeProject, Name: "p"})
+	if err != nil {
+		t.Fatalf("buildQuery() error = %v", err)
+	}
+	if !strings.HasSuffix(query, " LIMIT 11 SETTINGS max_memory_usage = 1073741824") {
+		t.Errorf("project query should end with its limits: %s", query)
+	}
+
+	query, _, err = s.buildQuery(context.Background(), spec, ScopeContext{Type: types.TenantTypePlatform})
+	if err != nil {
+		t.Fatalf("buildQuery() error = %v", err)
+	}
+	if strings.Contains(query, "SETTINGS") {
+		t.Errorf("platform query should not be limited: %s", query)
+	}
+}
+
+func TestEventQueries_TenantLimits(t *testing.T) {
+	config := ClickHouseEventsConfig{
+		Database: "audit",
+		TenantQueryLimits: map[string]TenantQueryLimits{
+			types.TenantTypeProject: {MaxMemoryUsage: 1 << 30, MaxExecutionTime: 5 * time.Second},
+		},
+	}
+	project := ScopeContext{Type: types.TenantTypeProject, Name: "p"}
+	const settings = " SETTINGS max_memory_usage = 1073741824, max_execution_time = 5"
+
+	eventQuery := NewClickHouseEventQueryBackend(nil, config)
+	spec := v1alpha1.EventQuerySpec{StartTime: "now-1h", EndTime: "now", Limit: 10}
+	query, _, err := eventQuery.buildQuery(context.Background(), spec, project)
+	if err != nil {
+		t.Fatalf("buildQuery() error = %v", err)
+	}
+	if !strings.HasSuffix(query, " LIMIT 11"+settings) {
+		t.Errorf("EventQuery should end with the project's limits: %s", query)
+	}
+	spec.Continue = encodeEventQueryCursor(v1alpha1.EventRecord{}, spec)
+	query, _, err = eventQuery.buildQuery(context.Background(), spec, project)
+	if err != nil {
+		t.Fatalf("buildQuery() error = %v", err)
+	}
+	if !strings.HasSuffix(query, " OFFSET 10"+settings) {
+		t.Errorf("EventQuery page 2 should end with the project's limits: %s", query)
+	}
+	if got := eventQuery.GetTenantMaxExecutionTime(project); got != 5*time.Second {
+		t.Errorf("GetTenantMaxExecutionTime(Project) = %v, want 5s", got)
+	}
+
+	conn := &capturingConn{}
+	events := NewClickHouseEventsBackend(conn, config)
+	facetSpec := EventFacetQuerySpec{StartTime: "now-1h", EndTime: "now", Facets: []FacetFieldSpec{{Field: "reason"}}}
+	if _, err := events.QueryEventFacets(context.Background(), facetSpec, project); err != nil {
+		t.Fatalf("QueryEventFacets() error = %v", err)
+	}
+	if !strings.HasSuffix(conn.query, settings) {
+		t.Errorf("EventFacetQuery should carry the project's limits: %s", conn.query)
+	}
+
+	if _, err := events.List(context.Background(), "default", metav1.ListOptions{}, project); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if !strings.HasSuffix(conn.query, settings) {
+		t.Errorf("event list should end with the project's limits: %s", conn.query)
+	}
+
+	if _, err := events.List(context.Background(), "default", metav1.ListOptions{}, ScopeContext{Type: types.TenantTypePlatform}); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if strings.Contains(conn.query, "SETTINGS") {
+		t.Errorf("platform event list should not be limited: %s", conn.query)
+	}
+}
@@ -49,6 +49,27 @@ func ParseFlexibleTime(timeStr string, now time.Time) (time.Time, error) {
 	return parsedTime, nil
 }
 
+// ResolveReferenceTime returns the time relative expressions are resolved
+// against: now when relativeTo is empty, otherwise the absolute time it names.
+//
+// Passing relativeTo pins "now" to a fixed point, so a query like "now-7d" to
+// "now" returns the same window whenever it runs. It must be an RFC3339
+// timestamp and cannot be in the future.
+func ResolveReferenceTime(relativeTo string, now time.Time) (time.Time, error) {
+	if relativeTo == "" {
+		return now, nil
+	}
+
+	reference, err := time.Parse(time.RFC3339Nano, relativeTo)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid reference time: %s (use RFC3339 like '2024-01-01T00:00:00Z')", relativeTo)
+	}
+	if reference.After(now) {
+		return time.Time{}, fmt.Errorf("reference time cannot be in the future: %s", relativeTo)
+	}
+	return reference, nil
+}
+
 // ParseRelativeTime parses relative time expressions using a specific reference time.
 //
 // The now parameter is used as the reference point for relative expressions.
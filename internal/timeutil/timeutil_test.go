@@ -443,3 +443,25 @@ func TestDSTTransitions(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveReferenceTime(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	reference, err := ResolveReferenceTime("", now)
+	require.NoError(t, err)
+	assert.Equal(t, now, reference)
+
+	reference, err = ResolveReferenceTime("2026-10-01T00:00:00Z", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), reference)
+
+	// Relative times resolve against the reference instead of now
+	start, err := ParseFlexibleTime("now-7d", reference)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 9, 24, 0, 0, 0, 0, time.UTC), start)
+
+	for _, invalid := range []string{"now-7d", "2026-10-01", "2026-10-17T00:00:00Z"} {
+		_, err := ResolveReferenceTime(invalid, now)
+		assert.Error(t, err, "relativeTo %q should be rejected", invalid)
+	}
+}
@@ -26,4 +26,9 @@ const (
 	// TenantTypeUser represents user-level scope for querying activities
 	// performed by a specific user across all organizations and projects.
 	TenantTypeUser = "User"
+
+	// TenantTypeNone is the scope of a request without tenant extras when the
+	// server's default scope is deny. No stored data has this type, so queries
+	// in it return nothing.
+	TenantTypeNone = "none"
 )
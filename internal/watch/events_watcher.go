@@ -16,6 +16,7 @@ import (
 	"k8s.io/klog/v2"
 
 	"go.miloapis.com/activity/internal/storage"
+	"go.miloapis.com/activity/internal/types"
 )
 
 // EventsWatcher watches for Kubernetes events via NATS JetStream.
@@ -67,6 +68,10 @@ func (w *EventsWatcher) Watch(ctx context.Context, scope storage.ScopeContext, f
 		return nil, fmt.Errorf("Watch API is not available: NATS not configured")
 	}
 
+	if scope.Type == types.TenantTypeNone {
+		return idleWatch(ctx), nil
+	}
+
 	// Build the NATS subject filter based on scope and filters
 	subject := w.buildSubject(scope, filter)
 
@@ -19,6 +19,7 @@ import (
 
 	"go.miloapis.com/activity/internal/cel"
 	"go.miloapis.com/activity/internal/storage"
+	"go.miloapis.com/activity/internal/types"
 	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
 )
 
@@ -183,6 +184,10 @@ func (w *NATSWatcher) Watch(ctx context.Context, scope storage.ScopeContext, fil
 		}
 	}
 
+	if scope.Type == types.TenantTypeNone {
+		return idleWatch(ctx), nil
+	}
+
 	// Build the NATS subject filter based on scope
 	subject := w.buildSubject(scope, filter)
 
@@ -452,3 +457,15 @@ func (w *ActivityWatch) LastResourceVersion() string {
 type WatcherInterface interface {
 	Watch(ctx context.Context, scope storage.ScopeContext, filter WatchFilter) (watch.Interface, error)
 }
+
+// idleWatch returns a watch that delivers no events and stops when ctx is
+// done. It serves requests whose scope has no tenant data, so they see an
+// empty stream rather than one that ends straight away and gets re-opened.
+func idleWatch(ctx context.Context) watch.Interface {
+	w := watch.NewFake()
+	go func() {
+		<-ctx.Done()
+		w.Stop()
+	}()
+	return w
+}
@@ -0,0 +1,19 @@
+-- Migration: 010_user_groups_column
+-- Description: Materialize user.groups from audit events as an array column so CEL
+-- filters can test group membership ('admins' in user.groups compiles to
+-- has(user_groups, ?)) and facet queries can enumerate active groups.
+-- Author: Activity System
+-- Date: 2026-10-16
+
+-- Group memberships of the requesting user (empty array when absent)
+ALTER TABLE audit.audit_logs
+    ADD COLUMN IF NOT EXISTS user_groups Array(String) MATERIALIZED
+        JSONExtract(event_json, 'user', 'groups', 'Array(String)');
+
+-- Bloom filter index supports has() lookups on array columns
+ALTER TABLE audit.audit_logs
+    ADD INDEX IF NOT EXISTS idx_user_groups_bloom user_groups TYPE bloom_filter(0.01) GRANULARITY 1;
+
+-- Materialize the column and index for existing data
+ALTER TABLE audit.audit_logs MATERIALIZE COLUMN user_groups;
+ALTER TABLE audit.audit_logs MATERIALIZE INDEX idx_user_groups_bloom;
@@ -0,0 +1,23 @@
+-- Migration: 011_request_duration_column
+-- Description: Materialize request latency (stageTimestamp - requestReceivedTimestamp)
+-- as duration_ms so CEL filters like 'durationMs > 1000' and the durationMs facet
+-- can find slow API requests without parsing event JSON at query time.
+-- Author: Activity System
+-- Date: 2026-10-16
+
+-- Request latency in milliseconds (0 when either timestamp is missing)
+ALTER TABLE audit.audit_logs
+    ADD COLUMN IF NOT EXISTS duration_ms Int64 MATERIALIZED
+        ifNull(
+            toUnixTimestamp64Milli(parseDateTime64BestEffortOrNull(JSONExtractString(event_json, 'stageTimestamp'), 6))
+            - toUnixTimestamp64Milli(parseDateTime64BestEffortOrNull(JSONExtractString(event_json, 'requestReceivedTimestamp'), 6)),
+            0
+        );
+
+-- Minmax index lets range filters (durationMs > N) skip granules of fast requests
+ALTER TABLE audit.audit_logs
+    ADD INDEX IF NOT EXISTS idx_duration_ms_minmax duration_ms TYPE minmax GRANULARITY 4;
+
+-- Materialize the column and index for existing data
+ALTER TABLE audit.audit_logs MATERIALIZE COLUMN duration_ms;
+ALTER TABLE audit.audit_logs MATERIALIZE INDEX idx_duration_ms_minmax;
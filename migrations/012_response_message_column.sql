@@ -0,0 +1,22 @@
+-- Migration: 012_response_message_column
+-- Description: Materialize responseStatus.message as status_message so CEL filters
+-- like "responseStatus.message.contains('admission webhook')" can find failures
+-- caused by a specific webhook or validation rule without parsing event JSON at
+-- query time.
+-- Author: Activity System
+-- Date: 2026-10-16
+
+-- Response status message (empty when the event has no responseStatus or message)
+ALTER TABLE audit.audit_logs
+    ADD COLUMN IF NOT EXISTS status_message String MATERIALIZED
+        JSONExtractString(event_json, 'responseStatus', 'message');
+
+-- contains() compiles to position(), which an ngram bloom filter can serve, so
+-- message searches skip granules without the substring. Needles shorter than
+-- four characters can't use the index; pair them with responseStatus.code.
+ALTER TABLE audit.audit_logs
+    ADD INDEX IF NOT EXISTS idx_status_message_ngram status_message TYPE ngrambf_v1(4, 8192, 3, 0) GRANULARITY 1;
+
+-- Materialize the column and index for existing data
+ALTER TABLE audit.audit_logs MATERIALIZE COLUMN status_message;
+ALTER TABLE audit.audit_logs MATERIALIZE INDEX idx_status_message_ngram;
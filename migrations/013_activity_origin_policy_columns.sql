@@ -0,0 +1,30 @@
+-- Migration: 013_activity_origin_policy_columns
+-- Description: Materialize the generating policy and rule of each activity so
+-- filters like "spec.origin.policyName == 'deployments' && spec.origin.ruleIndex == 2"
+-- can find every activity a specific rule produced, and so policy names can be
+-- faceted.
+-- Author: Activity System
+-- Date: 2026-10-16
+
+-- Name of the ActivityPolicy that generated the activity (empty for activities
+-- written before the processor recorded it)
+ALTER TABLE audit.activities
+    ADD COLUMN IF NOT EXISTS origin_policy LowCardinality(String) MATERIALIZED
+        coalesce(JSONExtractString(activity_json, 'spec', 'origin', 'policyName'), '');
+
+-- Index of the matching rule within the policy's auditRules or eventRules
+-- (-1 when not recorded, since 0 is a valid rule index)
+ALTER TABLE audit.activities
+    ADD COLUMN IF NOT EXISTS origin_rule_index Int32 MATERIALIZED
+        if(JSONHas(activity_json, 'spec', 'origin', 'ruleIndex'),
+           toInt32(JSONExtractInt(activity_json, 'spec', 'origin', 'ruleIndex')),
+           -1);
+
+-- Set index for origin_policy (low-cardinality: one value per ActivityPolicy)
+ALTER TABLE audit.activities
+    ADD INDEX IF NOT EXISTS idx_origin_policy_set origin_policy TYPE set(100) GRANULARITY 4;
+
+-- Materialize the columns and index for existing data
+ALTER TABLE audit.activities MATERIALIZE COLUMN origin_policy;
+ALTER TABLE audit.activities MATERIALIZE COLUMN origin_rule_index;
+ALTER TABLE audit.activities MATERIALIZE INDEX idx_origin_policy_set;
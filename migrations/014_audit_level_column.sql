@@ -0,0 +1,21 @@
+-- Migration: 014_audit_level_column
+-- Description: Materialize the audit level (Metadata, Request, RequestResponse)
+-- as level so CEL filters like "level == 'RequestResponse'" can find events that
+-- captured object bodies, and the level facet can show whether the audit policy
+-- records enough detail for a resource.
+-- Author: Activity System
+-- Date: 2026-10-16
+
+-- Audit level the event was recorded at (empty when missing)
+ALTER TABLE audit.audit_logs
+    ADD COLUMN IF NOT EXISTS level LowCardinality(String) MATERIALIZED
+        coalesce(JSONExtractString(event_json, 'level'), '');
+
+-- Only a handful of levels exist, so a set index skips granules that hold none
+-- of the requested level
+ALTER TABLE audit.audit_logs
+    ADD INDEX IF NOT EXISTS idx_level_set level TYPE set(10) GRANULARITY 4;
+
+-- Materialize the column and index for existing data
+ALTER TABLE audit.audit_logs MATERIALIZE COLUMN level;
+ALTER TABLE audit.audit_logs MATERIALIZE INDEX idx_level_set;
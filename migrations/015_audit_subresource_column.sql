@@ -0,0 +1,14 @@
+-- Migration: 015_audit_subresource_column
+-- Description: Materialize objectRef.subresource as subresource so CEL filters
+-- like "!has(objectRef.subresource)" can tell writes to a resource apart from
+-- writes to its status, scale, exec and other subresources.
+-- Author: Activity System
+-- Date: 2026-10-16
+
+-- Subresource the request targeted (empty for the main resource)
+ALTER TABLE audit.audit_logs
+    ADD COLUMN IF NOT EXISTS subresource LowCardinality(String) MATERIALIZED
+        coalesce(JSONExtractString(event_json, 'objectRef', 'subresource'), '');
+
+-- Materialize the column for existing data
+ALTER TABLE audit.audit_logs MATERIALIZE COLUMN subresource;
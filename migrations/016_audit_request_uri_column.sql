@@ -0,0 +1,14 @@
+-- Migration: 016_audit_request_uri_column
+-- Description: Materialize requestURI as request_uri so CEL filters like
+-- "requestURI.startsWith('/metrics')" can find requests to non-resource
+-- endpoints (/healthz, /metrics, /api, /version), which have no objectRef.
+-- Author: Activity System
+-- Date: 2026-10-16
+
+-- Request path and query string as sent by the client
+ALTER TABLE audit.audit_logs
+    ADD COLUMN IF NOT EXISTS request_uri String MATERIALIZED
+        coalesce(JSONExtractString(event_json, 'requestURI'), '');
+
+-- Materialize the column for existing data
+ALTER TABLE audit.audit_logs MATERIALIZE COLUMN request_uri;
@@ -0,0 +1,22 @@
+-- Migration: 017_audit_authz_decision_column
+-- Description: Materialize the authorizer's decision from the
+-- authorization.k8s.io/decision annotation as authz_decision so CEL filters
+-- like "authzDecision == 'forbid'" can find requests denied by RBAC (or another
+-- authorizer), as opposed to 403s from admission webhooks and policies.
+-- Author: Activity System
+-- Date: 2026-10-16
+
+-- Authorization decision: allow or forbid (empty when the annotation is missing,
+-- e.g. for unauthenticated requests)
+ALTER TABLE audit.audit_logs
+    ADD COLUMN IF NOT EXISTS authz_decision LowCardinality(String) MATERIALIZED
+        coalesce(JSONExtractString(event_json, 'annotations', 'authorization.k8s.io/decision'), '');
+
+-- Almost every request is allowed, so a set index lets forbid filters skip most
+-- granules
+ALTER TABLE audit.audit_logs
+    ADD INDEX IF NOT EXISTS idx_authz_decision_set authz_decision TYPE set(10) GRANULARITY 4;
+
+-- Materialize the column and index for existing data
+ALTER TABLE audit.audit_logs MATERIALIZE COLUMN authz_decision;
+ALTER TABLE audit.audit_logs MATERIALIZE INDEX idx_authz_decision_set;
@@ -0,0 +1,24 @@
+-- Migration: 018_audit_admission_webhook_column
+-- Description: Materialize the name of the admission webhook that rejected a
+-- request, or failed while being called, as admission_webhook so CEL filters
+-- like "admissionWebhook == 'validate.example.com'" and facets can rank webhooks
+-- by how many requests they block.
+-- Author: Activity System
+-- Date: 2026-10-16
+
+-- The API server names the webhook in the response message:
+--   admission webhook "validate.example.com" denied the request: ...
+--   Internal error occurred: failed calling webhook "validate.example.com": ...
+-- Empty for requests no webhook rejected
+ALTER TABLE audit.audit_logs
+    ADD COLUMN IF NOT EXISTS admission_webhook LowCardinality(String) MATERIALIZED
+        extract(JSONExtractString(event_json, 'responseStatus', 'message'), 'webhook "([^"]+)"');
+
+-- Webhook rejections are rare, so a set index lets webhook filters skip most
+-- granules
+ALTER TABLE audit.audit_logs
+    ADD INDEX IF NOT EXISTS idx_admission_webhook_set admission_webhook TYPE set(100) GRANULARITY 4;
+
+-- Materialize the column and index for existing data
+ALTER TABLE audit.audit_logs MATERIALIZE COLUMN admission_webhook;
+ALTER TABLE audit.audit_logs MATERIALIZE INDEX idx_admission_webhook_set;
@@ -0,0 +1,202 @@
+// Package activitysummary aggregates activities and deletions into the
+// summary reported by the summarize_recent_activity MCP tool and the
+// summary command.
+package activitysummary
+
+import (
+	"fmt"
+	"sort"
+
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+
+	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+)
+
+// DeletionFilter selects successful deletions in an AuditLogQuery. Deletions
+// are counted from audit logs because activities don't record the verb.
+const DeletionFilter = "verb in ['delete', 'deletecollection'] && responseStatus.code < 300"
+
+// DefaultTopN is the number of entries in each ranking when none is given.
+const DefaultTopN = 5
+
+// Highlight types.
+const (
+	HighlightTotalActivities = "total_activities"
+	HighlightTopActor        = "top_actor"
+	HighlightTopResourceKind = "top_resource_kind"
+	HighlightDeletions       = "deletions"
+)
+
+// Count is a named entry in a ranking.
+type Count struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// Deletions breaks down the deletions in the summary window.
+type Deletions struct {
+	Total        int     `json:"total"`
+	TopActors    []Count `json:"topActors"`
+	TopResources []Count `json:"topResources"`
+}
+
+// Summary is the aggregate view of a time window.
+type Summary struct {
+	TotalActivities int      `json:"totalActivities"`
+	HumanChanges    int      `json:"humanChanges"`
+	SystemChanges   int      `json:"systemChanges"`
+	TopActors       []Count  `json:"topActors"`
+	TopResources    []Count  `json:"topResources"`
+	RecentSummaries []string `json:"recentSummaries"`
+
+	// Deletions comes from audit logs, which carry no change source, so it
+	// covers every actor regardless of the activities' change source.
+	Deletions Deletions `json:"deletions"`
+}
+
+// Highlight is a single notable fact about a summary. Text is the rendered
+// sentence; the other fields carry the same facts so consumers don't have to
+// parse it.
+type Highlight struct {
+	Type    string         `json:"type"`
+	Label   string         `json:"label"`
+	Name    string         `json:"name,omitempty"`
+	Count   int            `json:"count"`
+	Metric  string         `json:"metric"`
+	Text    string         `json:"text"`
+	Details map[string]any `json:"details,omitempty"`
+}
+
+// Summarize aggregates activities, newest first, and deletion audit events.
+// Rankings hold at most topN entries, and the first topN activity summaries
+// are kept as the most recent.
+func Summarize(activities []v1alpha1.Activity, deletions []auditv1.Event, topN int) *Summary {
+	if topN <= 0 {
+		topN = DefaultTopN
+	}
+
+	s := &Summary{
+		TotalActivities: len(activities),
+		RecentSummaries: []string{},
+	}
+
+	actorCounts := make(map[string]int)
+	resourceKindCounts := make(map[string]int)
+	for i, activity := range activities {
+		actorCounts[activity.Spec.Actor.Name]++
+		resourceKindCounts[activity.Spec.Resource.Kind]++
+
+		if activity.Spec.ChangeSource == "human" {
+			s.HumanChanges++
+		} else {
+			s.SystemChanges++
+		}
+
+		if i < topN {
+			s.RecentSummaries = append(s.RecentSummaries, activity.Spec.Summary)
+		}
+	}
+	s.TopActors = TopN(actorCounts, topN)
+	s.TopResources = TopN(resourceKindCounts, topN)
+
+	deleterCounts := make(map[string]int)
+	deletedCounts := make(map[string]int)
+	for _, event := range deletions {
+		if event.Verb != "delete" && event.Verb != "deletecollection" {
+			continue
+		}
+		s.Deletions.Total++
+		deleterCounts[event.User.Username]++
+		deletedCounts[deletedResource(event)]++
+	}
+	s.Deletions.TopActors = TopN(deleterCounts, topN)
+	s.Deletions.TopResources = TopN(deletedCounts, topN)
+
+	return s
+}
+
+// deletedResource names the resource type of a deletion as resource.group,
+// or just the resource for the core group.
+func deletedResource(event auditv1.Event) string {
+	if event.ObjectRef == nil {
+		return "<unknown>"
+	}
+	if event.ObjectRef.APIGroup == "" {
+		return event.ObjectRef.Resource
+	}
+	return event.ObjectRef.Resource + "." + event.ObjectRef.APIGroup
+}
+
+// Highlights returns the notable facts of the summary, starting with the
+// totals.
+func (s *Summary) Highlights() []Highlight {
+	highlights := []Highlight{{
+		Type:    HighlightTotalActivities,
+		Label:   "Total activities",
+		Count:   s.TotalActivities,
+		Metric:  "activities",
+		Text:    fmt.Sprintf("%d total activities (%d human, %d system)", s.TotalActivities, s.HumanChanges, s.SystemChanges),
+		Details: map[string]any{"human": s.HumanChanges, "system": s.SystemChanges},
+	}}
+
+	if len(s.TopActors) > 0 {
+		top := s.TopActors[0]
+		highlights = append(highlights, Highlight{
+			Type:   HighlightTopActor,
+			Label:  "Most active",
+			Name:   top.Name,
+			Count:  top.Count,
+			Metric: "activities",
+			Text:   fmt.Sprintf("Most active: %s (%d activities)", top.Name, top.Count),
+		})
+	}
+
+	if len(s.TopResources) > 0 {
+		top := s.TopResources[0]
+		highlights = append(highlights, Highlight{
+			Type:   HighlightTopResourceKind,
+			Label:  "Most changed resource type",
+			Name:   top.Name,
+			Count:  top.Count,
+			Metric: "activities",
+			Text:   fmt.Sprintf("Most changed resource type: %s (%d activities)", top.Name, top.Count),
+		})
+	}
+
+	if s.Deletions.Total > 0 {
+		top := s.Deletions.TopActors[0]
+		highlights = append(highlights, Highlight{
+			Type:    HighlightDeletions,
+			Label:   "Deletions",
+			Name:    top.Name,
+			Count:   s.Deletions.Total,
+			Metric:  "deletions",
+			Text:    fmt.Sprintf("%d deletions, most by %s (%d)", s.Deletions.Total, top.Name, top.Count),
+			Details: map[string]any{"topActorCount": top.Count},
+		})
+	}
+
+	return highlights
+}
+
+// TopN returns the n largest counts, largest first. Ties are broken by name
+// so the ranking is stable.
+func TopN(counts map[string]int, n int) []Count {
+	if n < 0 {
+		n = 0
+	}
+	sorted := make([]Count, 0, len(counts))
+	for name, count := range counts {
+		sorted = append(sorted, Count{Name: name, Count: count})
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Count != sorted[j].Count {
+			return sorted[i].Count > sorted[j].Count
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	if len(sorted) > n {
+		sorted = sorted[:n]
+	}
+	return sorted
+}
@@ -0,0 +1,103 @@
+package activitysummary
+
+import (
+	"reflect"
+	"testing"
+
+	authnv1 "k8s.io/api/authentication/v1"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+
+	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+)
+
+func activity(actor, kind, source, summary string) v1alpha1.Activity {
+	return v1alpha1.Activity{Spec: v1alpha1.ActivitySpec{
+		Summary:      summary,
+		ChangeSource: source,
+		Actor:        v1alpha1.ActivityActor{Name: actor},
+		Resource:     v1alpha1.ActivityResource{Kind: kind},
+	}}
+}
+
+func deletion(verb, user, resource, group string) auditv1.Event {
+	return auditv1.Event{
+		Verb:      verb,
+		User:      authnv1.UserInfo{Username: user},
+		ObjectRef: &auditv1.ObjectReference{Resource: resource, APIGroup: group},
+	}
+}
+
+func TestTopN(t *testing.T) {
+	counts := map[string]int{"a": 10, "b": 50, "c": 30, "d": 5, "e": 30}
+
+	got := TopN(counts, 3)
+	want := []Count{{"b", 50}, {"c", 30}, {"e", 30}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TopN() = %v, want %v", got, want)
+	}
+
+	if got := TopN(counts, 10); len(got) != len(counts) {
+		t.Errorf("TopN() returned %d entries, want all %d", len(got), len(counts))
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	activities := []v1alpha1.Activity{
+		activity("alice", "Domain", "human", "alice created Domain a"),
+		activity("alice", "Domain", "human", "alice updated Domain a"),
+		activity("controller", "Pod", "system", "controller scaled Pod b"),
+	}
+	deletions := []auditv1.Event{
+		deletion("delete", "bob", "domains", "networking.datumapis.com"),
+		deletion("deletecollection", "bob", "configmaps", ""),
+		deletion("delete", "alice", "configmaps", ""),
+		// Anything else that slips through the filter is ignored
+		deletion("create", "carol", "configmaps", ""),
+	}
+
+	s := Summarize(activities, deletions, 2)
+
+	if s.TotalActivities != 3 || s.HumanChanges != 2 || s.SystemChanges != 1 {
+		t.Errorf("counts = %d total, %d human, %d system; want 3, 2, 1", s.TotalActivities, s.HumanChanges, s.SystemChanges)
+	}
+	if want := []Count{{"alice", 2}, {"controller", 1}}; !reflect.DeepEqual(s.TopActors, want) {
+		t.Errorf("TopActors = %v, want %v", s.TopActors, want)
+	}
+	if want := []Count{{"Domain", 2}, {"Pod", 1}}; !reflect.DeepEqual(s.TopResources, want) {
+		t.Errorf("TopResources = %v, want %v", s.TopResources, want)
+	}
+	if want := []string{"alice created Domain a", "alice updated Domain a"}; !reflect.DeepEqual(s.RecentSummaries, want) {
+		t.Errorf("RecentSummaries = %v, want %v", s.RecentSummaries, want)
+	}
+
+	if s.Deletions.Total != 3 {
+		t.Errorf("Deletions.Total = %d, want 3", s.Deletions.Total)
+	}
+	if want := []Count{{"bob", 2}, {"alice", 1}}; !reflect.DeepEqual(s.Deletions.TopActors, want) {
+		t.Errorf("Deletions.TopActors = %v, want %v", s.Deletions.TopActors, want)
+	}
+	if want := []Count{{"configmaps", 2}, {"domains.networking.datumapis.com", 1}}; !reflect.DeepEqual(s.Deletions.TopResources, want) {
+		t.Errorf("Deletions.TopResources = %v, want %v", s.Deletions.TopResources, want)
+	}
+}
+
+func TestHighlights(t *testing.T) {
+	empty := Summarize(nil, nil, 0)
+	if got := empty.Highlights(); len(got) != 1 || got[0].Type != HighlightTotalActivities {
+		t.Errorf("Highlights() of an empty summary = %v, want only the total", got)
+	}
+
+	s := Summarize(
+		[]v1alpha1.Activity{activity("alice", "Domain", "human", "alice created Domain a")},
+		[]auditv1.Event{deletion("delete", "bob", "configmaps", "")},
+		0,
+	)
+	var types []string
+	for _, h := range s.Highlights() {
+		types = append(types, h.Type)
+	}
+	want := []string{HighlightTotalActivities, HighlightTopActor, HighlightTopResourceKind, HighlightDeletions}
+	if !reflect.DeepEqual(types, want) {
+		t.Errorf("Highlight types = %v, want %v", types, want)
+	}
+}
@@ -35,8 +35,15 @@ type ActivityPolicyRule struct {
 	Description string
 	Match       string
 	Summary     string
+	Severity    string
 }
 
+// Rule severities, mirroring v1alpha1.ActivityPolicyRuleSeverity.
+const (
+	ActivityPolicyRuleSeverityNormal = "Normal"
+	ActivityPolicyRuleSeverityHigh   = "High"
+)
+
 // Condition is an alias for metav1.Condition to simplify conversions
 type Condition = metav1.Condition
 
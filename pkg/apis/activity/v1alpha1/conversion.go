@@ -71,6 +71,7 @@ func Convert_v1alpha1_ActivityPolicy_To_activity_ActivityPolicy(in *ActivityPoli
 		out.Spec.AuditRules[i].Description = rule.Description
 		out.Spec.AuditRules[i].Match = rule.Match
 		out.Spec.AuditRules[i].Summary = rule.Summary
+		out.Spec.AuditRules[i].Severity = string(rule.Severity)
 	}
 
 	out.Spec.EventRules = make([]activity.ActivityPolicyRule, len(in.Spec.EventRules))
@@ -79,6 +80,7 @@ func Convert_v1alpha1_ActivityPolicy_To_activity_ActivityPolicy(in *ActivityPoli
 		out.Spec.EventRules[i].Description = rule.Description
 		out.Spec.EventRules[i].Match = rule.Match
 		out.Spec.EventRules[i].Summary = rule.Summary
+		out.Spec.EventRules[i].Severity = string(rule.Severity)
 	}
 
 	// Convert Status - Conditions are the same type (metav1.Condition)
@@ -105,6 +107,7 @@ func Convert_activity_ActivityPolicy_To_v1alpha1_ActivityPolicy(in *activity.Act
 		out.Spec.AuditRules[i].Description = rule.Description
 		out.Spec.AuditRules[i].Match = rule.Match
 		out.Spec.AuditRules[i].Summary = rule.Summary
+		out.Spec.AuditRules[i].Severity = ActivityPolicyRuleSeverity(rule.Severity)
 	}
 
 	out.Spec.EventRules = make([]ActivityPolicyRule, len(in.Spec.EventRules))
@@ -113,6 +116,7 @@ func Convert_activity_ActivityPolicy_To_v1alpha1_ActivityPolicy(in *activity.Act
 		out.Spec.EventRules[i].Description = rule.Description
 		out.Spec.EventRules[i].Match = rule.Match
 		out.Spec.EventRules[i].Summary = rule.Summary
+		out.Spec.EventRules[i].Severity = ActivityPolicyRuleSeverity(rule.Severity)
 	}
 
 	// Convert Status - Conditions are the same type (metav1.Condition)
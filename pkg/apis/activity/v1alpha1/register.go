@@ -11,6 +11,19 @@ import (
 // GroupName is the group name for the activity API
 const GroupName = "activity.miloapis.com"
 
+const (
+	// ScopeKindHeader and ScopeNameHeader let platform administrators query a
+	// specific Organization, Project, or User instead of the scope derived
+	// from their credentials. The server rejects them from tenant-scoped users.
+	ScopeKindHeader = "X-Activity-Scope-Kind"
+	ScopeNameHeader = "X-Activity-Scope-Name"
+
+	// IdempotencyKeyHeader makes creating a query resource safe to retry.
+	// Repeating a create with the same key and body shortly afterwards returns
+	// the earlier result instead of running the query again.
+	IdempotencyKeyHeader = "Idempotency-Key"
+)
+
 // SchemeGroupVersion is group version used to register these objects
 var SchemeGroupVersion = schema.GroupVersion{Group: GroupName, Version: "v1alpha1"}
 
@@ -37,7 +50,11 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&ActivityList{},
 		&ActivityQuery{},
 		&ActivityFacetQuery{},
+		&ActivityMetricsQuery{},
+		&ScopeStats{},
+		&SelfScope{},
 		&EventFacetQuery{},
+		&FacetTrendQuery{},
 		&EventQuery{},
 		&EventQueryList{},
 		&PolicyPreview{},
@@ -17,11 +17,12 @@ import (
 //
 // # Accessing Activities
 //
-// There are three ways to get activity data, depending on what you need:
+// There are four ways to get activity data, depending on what you need:
 //
 // | What you need | API to use | Notes |
 // | --- | --- | --- |
 // | Live feed | GET /activities?watch=true | Streams new activities as they happen. List only returns the last hour. |
+// | One activity | GET /namespaces/{namespace}/activities/{name} | Look up an activity you already know the name of, e.g. from an alert. Searches the full retention period. |
 // | Search history | POST /activityqueries | Query any time range with filters, search, and pagination. |
 // | Filter options | POST /activityfacetqueries | Get values for dropdowns (e.g., "which actors have activities?"). |
 //
@@ -35,6 +36,10 @@ import (
 //
 //	kubectl get activities --field-selector spec.changeSource=human
 //
+// Get a single activity by name:
+//
+//	kubectl get activity act-1a2b3c4d5e6f -n production -o yaml
+//
 // For historical queries or advanced filtering, use ActivityQuery instead.
 type Activity struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -227,6 +232,17 @@ type ActivityOrigin struct {
 	//
 	// +required
 	ID string `json:"id"`
+
+	// PolicyName is the name of the ActivityPolicy that generated this activity.
+	//
+	// +optional
+	PolicyName string `json:"policyName,omitempty"`
+
+	// RuleIndex is the index of the matching rule within the policy's
+	// auditRules (for audit origins) or eventRules (for event origins).
+	//
+	// +optional
+	RuleIndex *int32 `json:"ruleIndex,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -89,6 +89,15 @@ type ActivityFacetQuerySpec struct {
 	// +required
 	// +listType=atomic
 	Facets []FacetSpec `json:"facets"`
+
+	// TimeoutSeconds overrides how long this query may run before it is
+	// cancelled. Raise it for facets over long time ranges, or lower it
+	// to fail fast in interactive filter pickers.
+	// Must be positive and no more than the server maximum (60 seconds unless
+	// the operator raised it). Defaults to 60 seconds.
+	//
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
 }
 
 // ActivityFacetQueryStatus contains the facet results.
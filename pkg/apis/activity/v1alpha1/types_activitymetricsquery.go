@@ -0,0 +1,133 @@
+// +k8s:openapi-gen=true
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:onlyVerbs=create
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ActivityMetricsQuery returns activity counts aggregated into time buckets.
+//
+// Use this to drive time-series panels (Grafana, dashboards) without paging
+// through individual activities. Each result point is a (bucket, series, count)
+// tuple, where the series is the value of the optional group-by dimension.
+//
+// # Example: Hourly human changes per resource kind over the last day
+//
+//	apiVersion: activity.miloapis.com/v1alpha1
+//	kind: ActivityMetricsQuery
+//	spec:
+//	  startTime: "now-24h"
+//	  endTime: "now"
+//	  bucketSize: "1h"
+//	  groupBy: resource
+//	  filter: "spec.changeSource == 'human'"
+//
+// This returns something like:
+//
+//	status:
+//	  points:
+//	    - timestamp: "2026-10-15T10:00:00Z"
+//	      series: Deployment
+//	      count: 12
+//	    - timestamp: "2026-10-15T10:00:00Z"
+//	      series: HTTPProxy
+//	      count: 3
+type ActivityMetricsQuery struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ActivityMetricsQuerySpec   `json:"spec"`
+	Status ActivityMetricsQueryStatus `json:"status,omitempty"`
+}
+
+// ActivityMetricsQuerySpec defines the time window, bucketing, and grouping for the counts.
+type ActivityMetricsQuerySpec struct {
+	// StartTime is the beginning of the window. Accepts relative ("now-7d") or
+	// absolute RFC3339 timestamps.
+	//
+	// +required
+	StartTime string `json:"startTime"`
+
+	// EndTime is the end of the window (exclusive). Accepts relative ("now") or
+	// absolute RFC3339 timestamps.
+	//
+	// +required
+	EndTime string `json:"endTime"`
+
+	// BucketSize is the width of each time bucket, as a Go duration ("5m", "1h")
+	// or a whole number of days ("1d"). Defaults to "1h". The window may contain
+	// at most 1000 buckets.
+	//
+	// +optional
+	BucketSize string `json:"bucketSize,omitempty"`
+
+	// GroupBy splits counts into one series per distinct value of a dimension.
+	// Leave empty to return a single "total" series.
+	//
+	// Supported dimensions:
+	//   resource     - spec.resource.kind
+	//   actor        - spec.actor.name
+	//   changeSource - spec.changeSource
+	//
+	// +optional
+	GroupBy string `json:"groupBy,omitempty"`
+
+	// Filter narrows which activities are counted. Uses the same CEL fields as
+	// ActivityQuery (for example "spec.resource.namespace == 'production'").
+	//
+	// +optional
+	Filter string `json:"filter,omitempty"`
+
+	// TimeoutSeconds overrides how long this query may run before it is
+	// cancelled. Raise it for long windows with fine buckets, or lower
+	// it so dashboards fail fast.
+	// Must be positive and no more than the server maximum (60 seconds unless
+	// the operator raised it). Defaults to 60 seconds.
+	//
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// ActivityMetricsQueryStatus contains the aggregated counts.
+type ActivityMetricsQueryStatus struct {
+	// Points are the (bucket, series, count) tuples, ordered by timestamp and
+	// then by series. Buckets with no matching activities are omitted.
+	//
+	// +optional
+	// +listType=atomic
+	Points []ActivityMetricsPoint `json:"points,omitempty"`
+
+	// EffectiveStartTime is the resolved start of the window, aligned down to a
+	// bucket boundary (RFC3339).
+	//
+	// +optional
+	EffectiveStartTime string `json:"effectiveStartTime,omitempty"`
+
+	// EffectiveEndTime is the resolved end of the window (RFC3339).
+	//
+	// +optional
+	EffectiveEndTime string `json:"effectiveEndTime,omitempty"`
+
+	// BucketSeconds is the bucket width that was applied, in seconds.
+	//
+	// +optional
+	BucketSeconds int64 `json:"bucketSeconds,omitempty"`
+}
+
+// ActivityMetricsPoint is a single count for one time bucket and series.
+type ActivityMetricsPoint struct {
+	// Timestamp is the start of the bucket (RFC3339).
+	Timestamp string `json:"timestamp"`
+
+	// Series is the group-by value for this count, or "total" when no
+	// group-by dimension was requested.
+	Series string `json:"series"`
+
+	// Count is the number of activities in the bucket for this series.
+	Count int64 `json:"count"`
+}
@@ -37,10 +37,28 @@ type ActivityPolicy struct {
 	Status ActivityPolicyStatus `json:"status,omitempty"`
 }
 
+// Condition types and reasons reported on ActivityPolicy status.
+const (
+	// ActivityPolicyConditionResourceResolved is set by the activity processor
+	// and indicates whether the policy's Kind could be mapped to an API resource.
+	// A Ready policy whose resource is not resolved is not applied to any events.
+	ActivityPolicyConditionResourceResolved = "ResourceResolved"
+
+	// ActivityPolicyReasonResolved means the resource was found via discovery.
+	ActivityPolicyReasonResolved = "Resolved"
+	// ActivityPolicyReasonResourceNotFound means discovery has no such Kind,
+	// usually because the CRD is not installed yet.
+	ActivityPolicyReasonResourceNotFound = "ResourceNotFound"
+	// ActivityPolicyReasonDiscoveryFailed means discovery itself returned an error.
+	ActivityPolicyReasonDiscoveryFailed = "DiscoveryFailed"
+)
+
 // ActivityPolicyStatus represents the current state of an ActivityPolicy.
 type ActivityPolicyStatus struct {
 	// Conditions represent the current state of the policy.
 	// The "Ready" condition indicates whether all rules compile successfully.
+	// The "ResourceResolved" condition indicates whether the activity processor
+	// could map the policy's Kind to an API resource.
 	// +optional
 	// +listType=map
 	// +listMapKey=type
@@ -62,7 +80,8 @@ type ActivityPolicySpec struct {
 	// AuditRules define how to translate audit log entries into activity summaries.
 	// Rules are evaluated in order; the first matching rule wins.
 	// Available variables: audit (map with verb, objectRef, user, responseStatus,
-	//   responseObject, requestObject), actor, actorRef, kind
+	//   responseObject, requestObject), actor, actorRef, kind, objectNamespace, verb,
+	//   responseCode, timestamp
 	//
 	// +optional
 	// +listType=map
@@ -125,7 +144,7 @@ type ActivityPolicyRule struct {
 	// Use {{ }} delimiters to embed CEL expressions within strings.
 	//
 	// Available variables:
-	//   - For audit rules: audit (map), actor, actorRef, kind
+	//   - For audit rules: audit (map), actor, actorRef, kind, objectNamespace, verb, responseCode, timestamp
 	//     Access audit fields via: audit.verb, audit.objectRef, audit.user, audit.responseStatus, audit.responseObject
 	//   - For event rules: event, actor, actorRef
 	//
@@ -138,8 +157,27 @@ type ActivityPolicyRule struct {
 	//
 	// +required
 	Summary string `json:"summary"`
+
+	// Severity marks activities produced by this rule for alert routing.
+	// High-severity activities are also published to the activity processor's
+	// alert subject, so security-relevant changes such as deletes or RBAC
+	// updates can feed real-time alerting. Defaults to Normal.
+	//
+	// +optional
+	// +kubebuilder:validation:Enum=Normal;High
+	Severity ActivityPolicyRuleSeverity `json:"severity,omitempty"`
 }
 
+// ActivityPolicyRuleSeverity controls how activities produced by a rule are routed.
+type ActivityPolicyRuleSeverity string
+
+const (
+	// ActivityPolicyRuleSeverityNormal publishes activities to the regular activity subject only.
+	ActivityPolicyRuleSeverityNormal ActivityPolicyRuleSeverity = "Normal"
+	// ActivityPolicyRuleSeverityHigh also publishes activities to the alert subject.
+	ActivityPolicyRuleSeverityHigh ActivityPolicyRuleSeverity = "High"
+)
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // ActivityPolicyList is a list of ActivityPolicy objects
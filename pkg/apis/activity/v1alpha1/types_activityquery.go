@@ -42,7 +42,7 @@ type ActivityQuery struct {
 // ActivityQuerySpec defines the search parameters for activities.
 //
 // Required: startTime and endTime define your search window.
-// Optional: filter (CEL expression), search, limit, continue.
+// Optional: filter (CEL expression), search, tenant, limit, orderBy, continue.
 //
 // CEL is the primary filtering mechanism. All dedicated filter fields have been
 // removed in favor of the expressive filter field.
@@ -60,6 +60,10 @@ type ActivityQuery struct {
 //	spec.resource.uid      - resource UID
 //	spec.summary           - activity summary text
 //	spec.origin.type       - "audit" or "event"
+//	spec.origin.policyName - policy that generated the activity
+//	spec.origin.ruleIndex  - index of the matching rule in that policy
+//	spec.tenant.type       - "organization", "project", or "user"
+//	spec.tenant.name       - tenant name
 //	metadata.namespace     - activity namespace
 //
 // CEL Filter Examples:
@@ -70,6 +74,7 @@ type ActivityQuery struct {
 //	"spec.resource.kind in ['Deployment', 'StatefulSet']"
 //	"spec.resource.apiGroup == 'networking.datumapis.com'"
 //	"spec.actor.uid == 'abc123'"
+//	"spec.summary == '' || size(spec.summary) < 20"  (empty or suspiciously short summaries)
 type ActivityQuerySpec struct {
 	// StartTime is the beginning of your search window (inclusive).
 	//
@@ -93,12 +98,20 @@ type ActivityQuerySpec struct {
 	// This is the primary filtering mechanism. See the ActivityQuerySpec godoc
 	// for available fields and examples.
 	//
-	// Operators: ==, !=, &&, ||, !, in
-	// String Functions: startsWith(), endsWith(), contains()
+	// Operators: ==, !=, <, >, <=, >=, &&, ||, !, in
+	// String Functions: startsWith(), endsWith(), contains(), size()
 	//
 	// +optional
 	Filter string `json:"filter,omitempty"`
 
+	// Tenant narrows results to a single organization or project.
+	//
+	// Only platform-scoped callers may set it. Tenant-scoped callers already
+	// see only their own tenant, so the request is rejected for them.
+	//
+	// +optional
+	Tenant *ActivityQueryTenant `json:"tenant,omitempty"`
+
 	// Search performs full-text search on activity summaries.
 	//
 	// Example: "created deployment" matches activities with those words in the summary.
@@ -112,18 +125,66 @@ type ActivityQuerySpec struct {
 	// +optional
 	Limit int32 `json:"limit,omitempty"`
 
+	// OrderBy sorts results by a field other than time.
+	//
+	// Supported values:
+	// - "timestamp" (default): newest first
+	// - "spec.actor.name": actor name A-Z, newest first within each actor
+	// - "spec.resource.apiGroup": API group A-Z, newest first within each group
+	//
+	// Ordering by anything other than timestamp sorts the whole time window
+	// before returning a page, so those queries are limited to a 24 hour window.
+	//
+	// +optional
+	OrderBy string `json:"orderBy,omitempty"`
+
 	// Continue is the pagination cursor for fetching additional pages.
 	//
 	// Leave empty for the first page. Copy status.continue here to get the next page.
-	// Keep all other parameters identical across paginated requests.
+	// Keep all other parameters except limit identical across paginated requests.
 	//
 	// +optional
 	Continue string `json:"continue,omitempty"`
+
+	// TimeoutSeconds overrides how long this query may run before it is
+	// cancelled. Lower it to fail fast, or raise it for broad searches
+	// over long time ranges.
+	// Must be positive and no more than the server maximum (60 seconds unless
+	// the operator raised it). Defaults to 60 seconds.
+	//
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// IncludeTotal also counts every activity matching the query across all
+	// pages and returns it in status.total, e.g. for "showing 100 of 4,523".
+	//
+	// The count is a second ClickHouse query with the same filters, run
+	// alongside the list. It reads every matching row in the time window, so
+	// it is the more expensive of the two for broad queries; it stops at
+	// 10,000 and sets status.totalCapped beyond that. Request it on the first
+	// page only, since the total doesn't change between pages.
+	//
+	// +optional
+	IncludeTotal bool `json:"includeTotal,omitempty"`
+}
+
+// ActivityQueryTenant identifies the tenant an ActivityQuery is narrowed to.
+type ActivityQueryTenant struct {
+	// Type is the tenant type: "Organization" or "Project".
+	//
+	// +required
+	Type string `json:"type"`
+
+	// Name is the organization or project name.
+	//
+	// +required
+	Name string `json:"name"`
 }
 
 // ActivityQueryStatus contains the query results and pagination state.
 type ActivityQueryStatus struct {
-	// Results contains matching activities, sorted newest-first.
+	// Results contains matching activities, sorted newest-first unless
+	// spec.orderBy selects another order.
 	//
 	// +listType=atomic
 	Results []Activity `json:"results,omitempty"`
@@ -143,4 +204,32 @@ type ActivityQueryStatus struct {
 	//
 	// +optional
 	EffectiveEndTime string `json:"effectiveEndTime,omitempty"`
+
+	// RetentionClamped is true when startTime reached further back than the
+	// server's retention window. EffectiveStartTime shows the oldest retained time.
+	//
+	// +optional
+	RetentionClamped bool `json:"retentionClamped,omitempty"`
+
+	// Total is the number of activities matching the query across all pages.
+	// Only set when spec.includeTotal is true.
+	//
+	// +optional
+	Total *int64 `json:"total,omitempty"`
+
+	// TotalCapped is true when more activities match than were counted;
+	// total is then the 10,000 cap and a lower bound.
+	//
+	// +optional
+	TotalCapped bool `json:"totalCapped,omitempty"`
+
+	// ReplaySpec is this query's spec with every relative value resolved, so it
+	// can be saved and re-run later to return the same results. startTime and
+	// endTime hold the absolute times that values like "now-7d" and "now"
+	// resolved to when the query ran, the filter is in normalized form, and
+	// continue is cleared so a replay starts from the first page. Results can
+	// still differ if data in the window has since passed the retention window.
+	//
+	// +optional
+	ReplaySpec *ActivityQuerySpec `json:"replaySpec,omitempty"`
 }
@@ -52,9 +52,17 @@ type AuditLogFacetsQuerySpec struct {
 	//   verb               - API action: get, list, create, update, patch, delete, watch
 	//   user.username      - who made the request (user or service account)
 	//   user.uid           - unique user identifier
+	//   user.groups        - groups the user belongs to (list; use 'group' in user.groups)
 	//   responseStatus.code - HTTP response code (200, 201, 404, 500, etc.)
+	//   responseStatus.message - error detail returned with the response
+	//   durationMs         - request latency in milliseconds (integer)
+	//   level              - audit level: Metadata, Request, RequestResponse
+	//   requestURI         - request path and query string (/healthz, /apis/apps/v1/...)
+	//   authzDecision      - authorizer decision: allow, forbid (empty if not recorded)
+	//   admissionWebhook   - admission webhook that rejected the request (empty if none)
 	//   objectRef.namespace - target resource namespace
 	//   objectRef.resource  - resource type (pods, deployments, secrets, configmaps, etc.)
+	//   objectRef.subresource - subresource the request targeted (status, scale, exec, etc.)
 	//   objectRef.apiGroup  - API group of the resource
 	//   objectRef.name     - specific resource name
 	//
@@ -66,10 +74,19 @@ type AuditLogFacetsQuerySpec struct {
 	//   "!(verb in ['get', 'list', 'watch'])"           - Exclude read-only operations
 	//   "!user.username.startsWith('system:')"          - Exclude system users
 	//   "objectRef.namespace == 'production'"           - Facets for production namespace
+	//   "!has(objectRef.resource)"                      - Non-resource requests only
 	//
 	// +optional
 	Filter string `json:"filter,omitempty"`
 
+	// MutationsOnly counts only requests that change resources: the create,
+	// update, patch, delete and deletecollection verbs. It is ANDed with
+	// filter, as in AuditLogQuery.
+	// Defaults to false.
+	//
+	// +optional
+	MutationsOnly bool `json:"mutationsOnly,omitempty"`
+
 	// Facets specifies which fields to get distinct values for.
 	// Each facet returns the top N values with counts.
 	//
@@ -77,14 +94,36 @@ type AuditLogFacetsQuerySpec struct {
 	//   - verb: API action (get, list, create, update, patch, delete, watch)
 	//   - user.username: Actor display names
 	//   - user.uid: Unique user identifiers
+	//   - user.groups: Groups of the requesting users (each membership counted)
 	//   - responseStatus.code: HTTP response codes
+	//   - durationMs: Request latency histogram (<100ms, 100ms-1s, 1s-5s, 5s-30s, >=30s)
+	//   - level: Audit levels (Metadata, Request, RequestResponse)
 	//   - objectRef.namespace: Namespaces
 	//   - objectRef.resource: Resource types
 	//   - objectRef.apiGroup: API groups
+	//   - requestURI: Request path prefixes (/apis/apps, /healthz, /metrics)
+	//   - authzDecision: Authorization decisions (allow, forbid)
+	//   - admissionWebhook: Admission webhooks that rejected requests
 	//
 	// +required
 	// +listType=atomic
 	Facets []FacetSpec `json:"facets"`
+
+	// PartialResults returns the facets that succeeded even if others fail.
+	// Failed facets are listed in status.facetErrors instead of failing the
+	// whole request. The request still fails if every facet fails.
+	//
+	// +optional
+	PartialResults bool `json:"partialResults,omitempty"`
+
+	// TimeoutSeconds overrides how long this query may run before it is
+	// cancelled. Raise it for facets over long time ranges, or lower it
+	// to fail fast in interactive filter pickers.
+	// Must be positive and no more than the server maximum (60 seconds unless
+	// the operator raised it). Defaults to 60 seconds.
+	//
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
 }
 
 // AuditLogFacetsQueryStatus contains the facet results.
@@ -94,4 +133,11 @@ type AuditLogFacetsQueryStatus struct {
 	// +optional
 	// +listType=atomic
 	Facets []FacetResult `json:"facets,omitempty"`
+
+	// FacetErrors lists the facets that could not be computed when
+	// spec.partialResults is set. Empty when every facet succeeded.
+	//
+	// +optional
+	// +listType=atomic
+	FacetErrors []FacetError `json:"facetErrors,omitempty"`
 }
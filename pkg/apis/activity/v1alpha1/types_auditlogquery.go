@@ -75,21 +75,43 @@ type AuditLogQuerySpec struct {
 	// +required
 	EndTime string `json:"endTime"`
 
+	// RelativeTo is the moment relative times are resolved against, in place of
+	// the current time. Set it to run a query "as of" a fixed point: with
+	// relativeTo "2024-06-01T00:00:00Z", startTime "now-7d" means 2024-05-25T00:00:00Z
+	// and endTime "now" means 2024-06-01T00:00:00Z, whenever the query runs.
+	//
+	// Must be an RFC3339 timestamp that is not in the future. Times after it
+	// are rejected, as times after the current moment are without it.
+	//
+	// +optional
+	RelativeTo string `json:"relativeTo,omitempty"`
+
 	// Filter narrows results using CEL (Common Expression Language). Leave empty to get all events.
 	//
 	// Available Fields:
 	//   verb               - API action: get, list, create, update, patch, delete, watch
 	//   auditID            - unique event identifier
 	//   requestReceivedTimestamp - when the API server received the request (RFC3339 timestamp)
+	//   durationMs         - request latency in milliseconds (integer)
+	//   level              - audit level: Metadata, Request, RequestResponse
+	//   requestURI         - request path and query string (/healthz, /apis/apps/v1/...)
+	//   authzDecision      - authorizer decision: allow, forbid (empty if not recorded)
+	//   admissionWebhook   - admission webhook that rejected the request (empty if none)
 	//   user.username      - who made the request (user or service account)
 	//   user.uid           - unique user identifier (stable across username changes)
+	//   user.groups        - groups the user belongs to (list; membership tests only)
 	//   responseStatus.code - HTTP response code (200, 201, 404, 500, etc.)
+	//   responseStatus.message - error detail returned with the response
 	//   objectRef.namespace - target resource namespace
 	//   objectRef.resource  - resource type (pods, deployments, secrets, configmaps, etc.)
+	//   objectRef.subresource - subresource the request targeted (status, scale, exec, etc.)
 	//   objectRef.name     - specific resource name
 	//
 	// Operators: ==, !=, <, >, <=, >=, &&, ||, !, in
 	// String Functions: startsWith(), endsWith(), contains()
+	// Presence: has() on optional fields (objectRef.*, responseStatus.*, user.groups)
+	// Raw JSON: jsonExtract('path.to.field') reads any other field as a string, if the
+	// server enables it. It can't use indexes, so such queries are slower.
 	//
 	// Common Patterns:
 	//   "verb == 'delete'"                                    - All deletions
@@ -97,9 +119,19 @@ type AuditLogQuerySpec struct {
 	//   "verb in ['create', 'update', 'delete', 'patch']"     - All write operations
 	//   "!(verb in ['get', 'list', 'watch'])"                 - Exclude read-only operations
 	//   "responseStatus.code >= 400"                          - Failed requests
+	//   "responseStatus.message.contains('admission webhook')" - Rejected by a webhook
+	//   "authzDecision == 'forbid'"                           - Denied by RBAC or another authorizer
+	//   "admissionWebhook != ''"                              - Rejected by any admission webhook
+	//   "durationMs > 1000"                                   - Requests slower than one second
+	//   "level == 'RequestResponse'"                          - Events that captured object bodies
+	//   "!has(objectRef.resource)"                            - Non-resource requests (e.g. /healthz)
+	//   "requestURI.startsWith('/metrics')"                   - Requests to the metrics endpoint
+	//   "requestURI.contains('?watch=true')"                  - Watch requests
+	//   "objectRef.subresource == 'status'"                   - Status updates
 	//   "user.username.startsWith('system:serviceaccount:')"  - Service account activity
 	//   "!user.username.startsWith('system:')"                - Exclude system users
 	//   "user.uid == '550e8400-e29b-41d4-a716-446655440000'"  - Specific user by UID
+	//   "'system:masters' in user.groups"                     - Requests by cluster admins
 	//   "objectRef.resource == 'secrets'"                     - Secret access
 	//   "verb == 'delete' && objectRef.namespace == 'production'" - Production deletions
 	//
@@ -109,6 +141,15 @@ type AuditLogQuerySpec struct {
 	// +optional
 	Filter string `json:"filter,omitempty"`
 
+	// MutationsOnly limits results to requests that change resources: the
+	// create, update, patch, delete and deletecollection verbs. It is ANDed
+	// with filter, and saves repeating a verb clause in every query when
+	// reads (get, list, watch) are noise.
+	// Defaults to false.
+	//
+	// +optional
+	MutationsOnly bool `json:"mutationsOnly,omitempty"`
+
 	// Limit sets the maximum number of results per page.
 	// Default: 100, Maximum: 1000.
 	//
@@ -122,13 +163,68 @@ type AuditLogQuerySpec struct {
 	//
 	// Leave empty for the first page. If status.continue is non-empty after a query,
 	// copy that value here in a new query with identical parameters to get the next page.
-	// Repeat until status.continue is empty.
+	// Repeat until status.continue is empty. To go back a page, copy status.previous
+	// here instead.
 	//
-	// Important: Keep all other parameters (startTime, endTime, filter, limit) identical
-	// across paginated requests. The cursor is opaque - copy it exactly without modification.
+	// Important: Keep startTime, endTime, relativeTo, filter and mutationsOnly identical across paginated requests.
+	// Limit may change between pages. The cursor is opaque - copy it exactly without modification.
 	//
 	// +optional
 	Continue string `json:"continue,omitempty"`
+
+	// TimeoutSeconds overrides how long this query may run before it is
+	// cancelled. Lower it to fail fast, or raise it for broad filters
+	// over long time ranges.
+	// Must be positive and no more than the server maximum (60 seconds unless
+	// the operator raised it). Defaults to 60 seconds.
+	//
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// IncludeRaw also returns each result's audit event JSON exactly as it was
+	// stored, in status.rawResults. Use it for forensics: results are decoded
+	// into the audit.k8s.io/v1 Event type, which drops any fields it doesn't
+	// know about.
+	//
+	// +optional
+	IncludeRaw bool `json:"includeRaw,omitempty"`
+
+	// BestEffort returns the results read so far, instead of an error, when
+	// the query times out while results are being read. The response then has
+	// status.partial set, and status.continue resumes after the last returned
+	// event. A timeout before any results arrive still fails the query.
+	// Defaults to false.
+	//
+	// +optional
+	BestEffort bool `json:"bestEffort,omitempty"`
+}
+
+// AuditEventDurationAnnotation is added to each returned audit event and holds
+// the request latency in milliseconds (stageTimestamp - requestReceivedTimestamp).
+// It is omitted when either timestamp is missing.
+const AuditEventDurationAnnotation = "activity.miloapis.com/duration-ms"
+
+// AuditEventRedactedAnnotation is set to "true" on returned audit events whose
+// requestObject and responseObject were reduced to apiVersion, kind and
+// metadata because the resource holds sensitive data (Secrets by default), or
+// had fields nulled out by the server's redaction rules.
+const AuditEventRedactedAnnotation = "activity.miloapis.com/redacted"
+
+// AuditLogGroupByFields are the fields a grouped audit log export can be
+// grouped by, passed as the groupBy parameter of the auditlogqueries/export
+// subresource. day is the UTC date of the event.
+var AuditLogGroupByFields = []string{"user", "verb", "resource", "namespace", "day"}
+
+// AuditLogGroup is one line of a grouped audit log export: the values of the
+// groupBy fields and how many matching audit events share them.
+type AuditLogGroup struct {
+	// Values maps each groupBy field to its value in this group, e.g.
+	// {"user": "alice@example.com", "day": "2026-10-16"}. Empty for events
+	// without the field, such as the namespace of a cluster-scoped resource.
+	Values map[string]string `json:"values"`
+
+	// Count is the number of audit events in the group.
+	Count int64 `json:"count"`
 }
 
 // AuditLogQueryStatus contains the query results and pagination state.
@@ -139,17 +235,37 @@ type AuditLogQueryStatus struct {
 	//   verb, user.username, objectRef.{namespace,resource,name}, requestReceivedTimestamp,
 	//   stageTimestamp, responseStatus.code, requestObject, responseObject
 	//
+	// The request latency is added as the "activity.miloapis.com/duration-ms" annotation.
+	// Bodies of sensitive resources such as Secrets are reduced to their metadata
+	// and marked with the "activity.miloapis.com/redacted" annotation.
+	//
 	// Empty results? Try broadening your filter or time range.
 	// Full documentation: https://kubernetes.io/docs/reference/config-api/apiserver-audit.v1/
 	//
 	// +listType=atomic
 	Results []auditv1.Event `json:"results,omitempty"`
 
+	// RawResults holds the stored JSON of each result when spec.includeRaw is
+	// set, byte for byte and in the same order as results. It has no
+	// duration-ms annotation. Events whose bodies were redacted are returned
+	// as the redacted event instead of the stored bytes.
+	//
+	// +optional
+	// +listType=atomic
+	RawResults []string `json:"rawResults,omitempty"`
+
 	// Continue is the pagination cursor.
 	// Non-empty means more results are available - copy this to spec.continue for the next page.
 	// Empty means you have all results.
 	Continue string `json:"continue,omitempty"`
 
+	// Previous is the cursor for the page before this one, holding the newer
+	// events just above its first result. Copy it to spec.continue, with the
+	// other parameters unchanged, to step back a page. Empty on the first page.
+	//
+	// +optional
+	Previous string `json:"previous,omitempty"`
+
 	// EffectiveStartTime is the actual start time used for this query (RFC3339 format).
 	//
 	// When you use relative times like "now-7d", this shows the exact timestamp that was
@@ -172,5 +288,50 @@ type AuditLogQueryStatus struct {
 	//
 	// +optional
 	EffectiveEndTime string `json:"effectiveEndTime,omitempty"`
+
+	// RetentionClamped is true when startTime reached further back than the
+	// server's retention window. EffectiveStartTime is moved forward to the
+	// oldest retained time and a warning explains that older data has aged out.
+	//
+	// +optional
+	RetentionClamped bool `json:"retentionClamped,omitempty"`
+
+	// Partial is true when spec.bestEffort was set and the query timed out
+	// part-way through reading results. Results holds the events read before
+	// the timeout, newest-first with none skipped, and continue picks up after
+	// the last of them.
+	//
+	// +optional
+	Partial bool `json:"partial,omitempty"`
+
+	// TraceID identifies the server-side trace for this query.
+	//
+	// Include it when reporting a slow or unexpected query to support so the
+	// matching server logs can be found. Failed queries include the same ID in
+	// the error message. Empty when request tracing is disabled on the server.
+	//
+	// +optional
+	TraceID string `json:"traceID,omitempty"`
+
+	// QueryID is the query_id this query ran with in ClickHouse. It is the
+	// trace ID and a span ID joined by a dash, and finds the exact query in
+	// ClickHouse's own system.query_log:
+	//
+	//   SELECT * FROM system.query_log WHERE query_id = '<queryID>'
+	//
+	// Empty when request tracing is disabled on the server.
+	//
+	// +optional
+	QueryID string `json:"queryID,omitempty"`
+
+	// ReplaySpec is this query's spec with every relative value resolved, so it
+	// can be saved and re-run later to return the same results. startTime and
+	// endTime hold the absolute times that values like "now-7d" and "now"
+	// resolved to when the query ran, the filter is in normalized form, and
+	// continue is cleared so a replay starts from the first page. Results can
+	// still differ if data in the window has since passed the retention window.
+	//
+	// +optional
+	ReplaySpec *AuditLogQuerySpec `json:"replaySpec,omitempty"`
 }
 
@@ -44,7 +44,8 @@ type EventQuery struct {
 //
 // Required: startTime and endTime define your search window (max 60 days).
 // Optional: namespace (limit to namespace), fieldSelector (standard K8s syntax),
-// limit (page size, default 100), continue (pagination).
+// reasons, types and sourceComponents (match any listed value), limit (page
+// size, default 100), continue (pagination).
 type EventQuerySpec struct {
 	// StartTime is the beginning of your search window (inclusive).
 	//
@@ -117,6 +118,32 @@ type EventQuerySpec struct {
 	// +optional
 	FieldSelector string `json:"fieldSelector,omitempty"`
 
+	// Reasons limits results to events with any of the listed reasons. Field
+	// selectors can only require every condition, so use this to match several
+	// reasons at once. Combines with fieldSelector, which must also match.
+	//
+	// Example: ["FailedScheduling", "FailedMount", "BackOff"]
+	//
+	// +optional
+	// +listType=atomic
+	Reasons []string `json:"reasons,omitempty"`
+
+	// Types limits results to events of any of the listed types (Normal or
+	// Warning).
+	//
+	// +optional
+	// +listType=atomic
+	Types []string `json:"types,omitempty"`
+
+	// SourceComponents limits results to events reported by any of the listed
+	// components (source.component in field selectors).
+	//
+	// Example: ["kubelet", "default-scheduler"]
+	//
+	// +optional
+	// +listType=atomic
+	SourceComponents []string `json:"sourceComponents,omitempty"`
+
 	// Limit sets the maximum number of results per page.
 	// Default: 100, Maximum: 1000.
 	//
@@ -132,12 +159,41 @@ type EventQuerySpec struct {
 	// copy that value here in a new query with identical parameters to get the next page.
 	// Repeat until status.continue is empty.
 	//
-	// Important: Keep all other parameters (startTime, endTime, namespace, fieldSelector,
-	// limit) identical across paginated requests. The cursor is opaque - copy it exactly
-	// without modification.
+	// Important: Keep startTime, endTime, namespace, fieldSelector, reasons, types and
+	// sourceComponents identical across paginated requests. Limit may change between
+	// pages. The cursor is opaque - copy it exactly without modification.
 	//
 	// +optional
 	Continue string `json:"continue,omitempty"`
+
+	// TimeoutSeconds overrides how long this query may run before it is
+	// cancelled. Lower it to fail fast, or raise it for broad field
+	// selectors over long time ranges.
+	// Must be positive and no more than the server maximum (60 seconds unless
+	// the operator raised it). Defaults to 60 seconds.
+	//
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// Fields trims each result to the listed event fields, shrinking the
+	// response for bulk pulls. Events always keep their name, namespace, uid,
+	// resourceVersion and creationTimestamp; managed fields, labels,
+	// annotations and any unlisted fields are dropped. Leave empty to return
+	// full events.
+	//
+	// Supported Fields:
+	//   reason, note, type, action, eventTime, series, regarding, related,
+	//   reportingController, reportingInstance
+	//
+	// The core/v1 names are accepted as aliases: message (note),
+	// involvedObject (regarding), and source (reportingController and
+	// reportingInstance).
+	//
+	// Example: ["reason", "message", "type", "involvedObject", "source"]
+	//
+	// +optional
+	// +listType=atomic
+	Fields []string `json:"fields,omitempty"`
 }
 
 // EventQueryStatus contains the query results and pagination state.
@@ -180,6 +236,23 @@ type EventQueryStatus struct {
 	//
 	// +optional
 	EffectiveEndTime string `json:"effectiveEndTime,omitempty"`
+
+	// RetentionClamped is true when startTime reached further back than the
+	// server's retention window. EffectiveStartTime is moved forward to the
+	// oldest retained time and a warning explains that older data has aged out.
+	//
+	// +optional
+	RetentionClamped bool `json:"retentionClamped,omitempty"`
+
+	// ReplaySpec is this query's spec with every relative value resolved, so it
+	// can be saved and re-run later to return the same results. startTime and
+	// endTime hold the absolute times that values like "now-7d" and "now"
+	// resolved to when the query ran, and continue is cleared so a replay
+	// starts from the first page. Results can still differ if events in the
+	// window have since passed the retention window.
+	//
+	// +optional
+	ReplaySpec *EventQuerySpec `json:"replaySpec,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -27,6 +27,9 @@ type FacetSpec struct {
 	//   - spec.resource.kind: Resource kinds
 	//   - spec.resource.namespace: Namespaces
 	//   - spec.changeSource: Change sources (human, system)
+	//   - spec.origin.policyName: Policies that generated activities
+	//   - spec.tenant.type: Tenant types (Organization, Project)
+	//   - spec.tenant.name: Tenant names, most useful from platform scope
 	//
 	// +required
 	Field string `json:"field"`
@@ -36,8 +39,33 @@ type FacetSpec struct {
 	//
 	// +optional
 	Limit int32 `json:"limit,omitempty"`
+
+	// Order sets how values are sorted.
+	//   - count: Most frequent first, ties broken by value (default)
+	//   - value_asc: By value, ascending
+	//   - value_desc: By value, descending
+	//
+	// Numeric fields such as responseStatus.code sort numerically, so 429
+	// comes after 100. Value ordering is useful for stable dropdowns; combine
+	// it with a limit high enough to include every value.
+	//
+	// +optional
+	// +kubebuilder:validation:Enum=count;value_asc;value_desc
+	Order FacetOrder `json:"order,omitempty"`
 }
 
+// FacetOrder controls how facet values are sorted.
+type FacetOrder string
+
+const (
+	// FacetOrderCount sorts values by count, most frequent first.
+	FacetOrderCount FacetOrder = "count"
+	// FacetOrderValueAsc sorts values in ascending order.
+	FacetOrderValueAsc FacetOrder = "value_asc"
+	// FacetOrderValueDesc sorts values in descending order.
+	FacetOrderValueDesc FacetOrder = "value_desc"
+)
+
 // FacetResult contains the distinct values for a single facet.
 type FacetResult struct {
 	// Field is the field path that was queried.
@@ -58,3 +86,12 @@ type FacetValue struct {
 	// Count is the number of activities with this value.
 	Count int64 `json:"count"`
 }
+
+// FacetError describes a facet that could not be computed.
+type FacetError struct {
+	// Field is the field path of the failed facet.
+	Field string `json:"field"`
+
+	// Message explains why the facet failed.
+	Message string `json:"message"`
+}
@@ -0,0 +1,146 @@
+// +k8s:openapi-gen=true
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:onlyVerbs=create
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// FacetTrendQuery returns audit log counts over time for the most frequent
+// values of one field.
+//
+// An AuditLogFacetsQuery gives a single total per value. A FacetTrendQuery
+// first picks the top values of the field over the whole window, then counts
+// each of them per time bucket, returning one series per value. Use it to
+// drive stacked-area charts such as "top verbs per day over the last week".
+//
+// # Example: Daily counts of the five most common verbs over the last week
+//
+//	apiVersion: activity.miloapis.com/v1alpha1
+//	kind: FacetTrendQuery
+//	spec:
+//	  timeRange:
+//	    start: "now-7d"
+//	  field: verb
+//	  limit: 5
+//	  bucketSize: "1d"
+//
+// This returns something like:
+//
+//	status:
+//	  series:
+//	    - value: get
+//	      total: 5120
+//	      points:
+//	        - timestamp: "2026-10-09T00:00:00Z"
+//	          count: 701
+//	        - timestamp: "2026-10-10T00:00:00Z"
+//	          count: 688
+//	    - value: update
+//	      total: 940
+//	      points:
+//	        - timestamp: "2026-10-09T00:00:00Z"
+//	          count: 130
+type FacetTrendQuery struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FacetTrendQuerySpec   `json:"spec"`
+	Status FacetTrendQueryStatus `json:"status,omitempty"`
+}
+
+// FacetTrendQuerySpec defines the field, time window and bucketing for the trend.
+type FacetTrendQuerySpec struct {
+	// TimeRange is the window to count over. Start defaults to "now-7d" and
+	// End to "now".
+	//
+	// +optional
+	TimeRange FacetTimeRange `json:"timeRange,omitempty"`
+
+	// Filter narrows the audit logs before counting, using the same CEL fields
+	// as AuditLogFacetsQuery (for example "!user.username.startsWith('system:')").
+	//
+	// +optional
+	Filter string `json:"filter,omitempty"`
+
+	// Field is the audit log field to split series on. Accepts the same fields
+	// as AuditLogFacetsQuery facets, such as verb, user.username,
+	// objectRef.resource or responseStatus.code.
+	//
+	// +required
+	Field string `json:"field"`
+
+	// Limit is how many of the field's most frequent values get a series.
+	// Default: 5, Maximum: 20.
+	//
+	// +optional
+	Limit int32 `json:"limit,omitempty"`
+
+	// BucketSize is the width of each time bucket, as a Go duration ("1h") or
+	// a whole number of days ("1d"). Defaults to "1d". The window may contain
+	// at most 1000 buckets.
+	//
+	// +optional
+	BucketSize string `json:"bucketSize,omitempty"`
+
+	// TimeoutSeconds overrides how long this query may run before it is
+	// cancelled. Must be positive and no more than the server maximum (60
+	// seconds unless the operator raised it). Defaults to 60 seconds.
+	//
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// FacetTrendQueryStatus contains one count series per top value.
+type FacetTrendQueryStatus struct {
+	// Series holds one entry per top value, most frequent first.
+	//
+	// +optional
+	// +listType=atomic
+	Series []FacetTrendSeries `json:"series,omitempty"`
+
+	// EffectiveStartTime is the resolved start of the window, aligned down to a
+	// bucket boundary (RFC3339).
+	//
+	// +optional
+	EffectiveStartTime string `json:"effectiveStartTime,omitempty"`
+
+	// EffectiveEndTime is the resolved end of the window (RFC3339).
+	//
+	// +optional
+	EffectiveEndTime string `json:"effectiveEndTime,omitempty"`
+
+	// BucketSeconds is the bucket width that was applied, in seconds.
+	//
+	// +optional
+	BucketSeconds int64 `json:"bucketSeconds,omitempty"`
+}
+
+// FacetTrendSeries is the count over time for one value of the field.
+type FacetTrendSeries struct {
+	// Value is the field value this series counts.
+	Value string `json:"value"`
+
+	// Total is the value's count over the whole window.
+	Total int64 `json:"total"`
+
+	// Points are the per-bucket counts, ordered by timestamp. Buckets in which
+	// the value did not occur are omitted.
+	//
+	// +optional
+	// +listType=atomic
+	Points []FacetTrendPoint `json:"points,omitempty"`
+}
+
+// FacetTrendPoint is the count of one value in one time bucket.
+type FacetTrendPoint struct {
+	// Timestamp is the start of the bucket (RFC3339).
+	Timestamp string `json:"timestamp"`
+
+	// Count is the number of audit logs with the value in the bucket.
+	Count int64 `json:"count"`
+}
@@ -0,0 +1,88 @@
+// +k8s:openapi-gen=true
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:onlyVerbs=create
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ScopeStats returns high-level stats for the caller's scope over the last 24 hours.
+//
+// Use this to populate a landing dashboard with a single call instead of
+// issuing separate queries for each number. The window is fixed so the
+// aggregates stay cheap.
+//
+// Create an empty ScopeStats to get the current numbers:
+//
+//	apiVersion: activity.miloapis.com/v1alpha1
+//	kind: ScopeStats
+//
+// This returns something like:
+//
+//	status:
+//	  windowStart: "2026-10-15T12:00:00Z"
+//	  windowEnd: "2026-10-16T12:00:00Z"
+//	  auditEventCount: 48210
+//	  activityCount: 1312
+//	  uniqueActors: 27
+//	  topResources:
+//	    - kind: Deployment
+//	      apiGroup: apps
+//	      count: 402
+//	  newestAuditEventTime: "2026-10-16T11:59:58Z"
+type ScopeStats struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status ScopeStatsStatus `json:"status,omitempty"`
+}
+
+// ScopeStatsStatus contains the computed stats.
+type ScopeStatsStatus struct {
+	// WindowStart is the start of the 24 hour window (RFC3339).
+	WindowStart string `json:"windowStart"`
+
+	// WindowEnd is the end of the window, the time the stats were computed (RFC3339).
+	WindowEnd string `json:"windowEnd"`
+
+	// AuditEventCount is the number of audit events in the window.
+	AuditEventCount int64 `json:"auditEventCount"`
+
+	// ActivityCount is the number of activities in the window.
+	ActivityCount int64 `json:"activityCount"`
+
+	// UniqueActors is the approximate number of distinct actors across the
+	// window's activities.
+	UniqueActors int64 `json:"uniqueActors"`
+
+	// TopResources are the 5 resource kinds with the most activities in the
+	// window, most active first.
+	//
+	// +optional
+	// +listType=atomic
+	TopResources []ScopeStatsResource `json:"topResources,omitempty"`
+
+	// NewestAuditEventTime is the timestamp of the most recent audit event in
+	// the window (RFC3339). Empty when there were none.
+	//
+	// +optional
+	NewestAuditEventTime string `json:"newestAuditEventTime,omitempty"`
+}
+
+// ScopeStatsResource is the activity count for one resource kind.
+type ScopeStatsResource struct {
+	// APIGroup is the resource's API group (empty for the core group).
+	//
+	// +optional
+	APIGroup string `json:"apiGroup,omitempty"`
+
+	// Kind is the resource kind, e.g. "Deployment".
+	Kind string `json:"kind"`
+
+	// Count is the number of activities for this kind in the window.
+	Count int64 `json:"count"`
+}
@@ -0,0 +1,54 @@
+// +k8s:openapi-gen=true
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:onlyVerbs=create
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SelfScope reports the scope the server resolves for the caller.
+//
+// Every query is limited to the caller's scope, which comes from the parent
+// resource in their credentials. Use this to see why queries return
+// platform-wide results or nothing at all, without access to the server logs.
+//
+// Create an empty SelfScope to see your scope:
+//
+//	apiVersion: activity.miloapis.com/v1alpha1
+//	kind: SelfScope
+//
+// This returns something like:
+//
+//	status:
+//	  username: alice@example.com
+//	  scopeType: Project
+//	  scopeName: backend-api
+type SelfScope struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status SelfScopeStatus `json:"status,omitempty"`
+}
+
+// SelfScopeStatus contains the resolved scope.
+type SelfScopeStatus struct {
+	// Username is the authenticated user the scope was resolved for.
+	Username string `json:"username"`
+
+	// ScopeType is the kind of scope queries are limited to: "platform",
+	// "Organization", "Project", "User", or "none". Platform scope covers every
+	// tenant and is used when the credentials name no parent resource, unless
+	// the server runs with --default-scope=deny, which gives them "none" scope
+	// and no results.
+	ScopeType string `json:"scopeType"`
+
+	// ScopeName identifies the organization, project, or user (by UID) that
+	// queries are limited to. Empty for platform scope.
+	//
+	// +optional
+	ScopeName string `json:"scopeName,omitempty"`
+}
@@ -107,6 +107,11 @@ func (in *ActivityFacetQuerySpec) DeepCopyInto(out *ActivityFacetQuerySpec) {
 		*out = make([]FacetSpec, len(*in))
 		copy(*out, *in)
 	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -193,9 +198,100 @@ func (in *ActivityList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActivityMetricsPoint) DeepCopyInto(out *ActivityMetricsPoint) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActivityMetricsPoint.
+func (in *ActivityMetricsPoint) DeepCopy() *ActivityMetricsPoint {
+	if in == nil {
+		return nil
+	}
+	out := new(ActivityMetricsPoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActivityMetricsQuery) DeepCopyInto(out *ActivityMetricsQuery) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActivityMetricsQuery.
+func (in *ActivityMetricsQuery) DeepCopy() *ActivityMetricsQuery {
+	if in == nil {
+		return nil
+	}
+	out := new(ActivityMetricsQuery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ActivityMetricsQuery) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActivityMetricsQuerySpec) DeepCopyInto(out *ActivityMetricsQuerySpec) {
+	*out = *in
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActivityMetricsQuerySpec.
+func (in *ActivityMetricsQuerySpec) DeepCopy() *ActivityMetricsQuerySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ActivityMetricsQuerySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActivityMetricsQueryStatus) DeepCopyInto(out *ActivityMetricsQueryStatus) {
+	*out = *in
+	if in.Points != nil {
+		in, out := &in.Points, &out.Points
+		*out = make([]ActivityMetricsPoint, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActivityMetricsQueryStatus.
+func (in *ActivityMetricsQueryStatus) DeepCopy() *ActivityMetricsQueryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ActivityMetricsQueryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ActivityOrigin) DeepCopyInto(out *ActivityOrigin) {
 	*out = *in
+	if in.RuleIndex != nil {
+		in, out := &in.RuleIndex, &out.RuleIndex
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -357,7 +453,7 @@ func (in *ActivityQuery) DeepCopyInto(out *ActivityQuery) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 	return
 }
@@ -383,6 +479,16 @@ func (in *ActivityQuery) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ActivityQuerySpec) DeepCopyInto(out *ActivityQuerySpec) {
 	*out = *in
+	if in.Tenant != nil {
+		in, out := &in.Tenant, &out.Tenant
+		*out = new(ActivityQueryTenant)
+		**out = **in
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -406,6 +512,16 @@ func (in *ActivityQueryStatus) DeepCopyInto(out *ActivityQueryStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Total != nil {
+		in, out := &in.Total, &out.Total
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ReplaySpec != nil {
+		in, out := &in.ReplaySpec, &out.ReplaySpec
+		*out = new(ActivityQuerySpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -419,6 +535,22 @@ func (in *ActivityQueryStatus) DeepCopy() *ActivityQueryStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActivityQueryTenant) DeepCopyInto(out *ActivityQueryTenant) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActivityQueryTenant.
+func (in *ActivityQueryTenant) DeepCopy() *ActivityQueryTenant {
+	if in == nil {
+		return nil
+	}
+	out := new(ActivityQueryTenant)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ActivityResource) DeepCopyInto(out *ActivityResource) {
 	*out = *in
@@ -451,7 +583,7 @@ func (in *ActivitySpec) DeepCopyInto(out *ActivitySpec) {
 		*out = make([]ActivityChange, len(*in))
 		copy(*out, *in)
 	}
-	out.Origin = in.Origin
+	in.Origin.DeepCopyInto(&out.Origin)
 	return
 }
 
@@ -518,6 +650,11 @@ func (in *AuditLogFacetsQuerySpec) DeepCopyInto(out *AuditLogFacetsQuerySpec) {
 		*out = make([]FacetSpec, len(*in))
 		copy(*out, *in)
 	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -541,6 +678,11 @@ func (in *AuditLogFacetsQueryStatus) DeepCopyInto(out *AuditLogFacetsQueryStatus
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.FacetErrors != nil {
+		in, out := &in.FacetErrors, &out.FacetErrors
+		*out = make([]FacetError, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -554,12 +696,35 @@ func (in *AuditLogFacetsQueryStatus) DeepCopy() *AuditLogFacetsQueryStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditLogGroup) DeepCopyInto(out *AuditLogGroup) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditLogGroup.
+func (in *AuditLogGroup) DeepCopy() *AuditLogGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditLogGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AuditLogQuery) DeepCopyInto(out *AuditLogQuery) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 	return
 }
@@ -585,6 +750,11 @@ func (in *AuditLogQuery) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AuditLogQuerySpec) DeepCopyInto(out *AuditLogQuerySpec) {
 	*out = *in
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -608,6 +778,16 @@ func (in *AuditLogQueryStatus) DeepCopyInto(out *AuditLogQueryStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RawResults != nil {
+		in, out := &in.RawResults, &out.RawResults
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReplaySpec != nil {
+		in, out := &in.ReplaySpec, &out.ReplaySpec
+		*out = new(AuditLogQuerySpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -674,6 +854,11 @@ func (in *EventFacetQuerySpec) DeepCopyInto(out *EventFacetQuerySpec) {
 		*out = make([]FacetSpec, len(*in))
 		copy(*out, *in)
 	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -715,7 +900,7 @@ func (in *EventQuery) DeepCopyInto(out *EventQuery) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 	return
 }
@@ -774,6 +959,31 @@ func (in *EventQueryList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EventQuerySpec) DeepCopyInto(out *EventQuerySpec) {
 	*out = *in
+	if in.Reasons != nil {
+		in, out := &in.Reasons, &out.Reasons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Types != nil {
+		in, out := &in.Types, &out.Types
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SourceComponents != nil {
+		in, out := &in.SourceComponents, &out.SourceComponents
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -797,6 +1007,11 @@ func (in *EventQueryStatus) DeepCopyInto(out *EventQueryStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ReplaySpec != nil {
+		in, out := &in.ReplaySpec, &out.ReplaySpec
+		*out = new(EventQuerySpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -829,6 +1044,22 @@ func (in *EventRecord) DeepCopy() *EventRecord {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FacetError) DeepCopyInto(out *FacetError) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FacetError.
+func (in *FacetError) DeepCopy() *FacetError {
+	if in == nil {
+		return nil
+	}
+	out := new(FacetError)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FacetResult) DeepCopyInto(out *FacetResult) {
 	*out = *in
@@ -882,6 +1113,116 @@ func (in *FacetTimeRange) DeepCopy() *FacetTimeRange {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FacetTrendPoint) DeepCopyInto(out *FacetTrendPoint) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FacetTrendPoint.
+func (in *FacetTrendPoint) DeepCopy() *FacetTrendPoint {
+	if in == nil {
+		return nil
+	}
+	out := new(FacetTrendPoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FacetTrendQuery) DeepCopyInto(out *FacetTrendQuery) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FacetTrendQuery.
+func (in *FacetTrendQuery) DeepCopy() *FacetTrendQuery {
+	if in == nil {
+		return nil
+	}
+	out := new(FacetTrendQuery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FacetTrendQuery) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FacetTrendQuerySpec) DeepCopyInto(out *FacetTrendQuerySpec) {
+	*out = *in
+	out.TimeRange = in.TimeRange
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FacetTrendQuerySpec.
+func (in *FacetTrendQuerySpec) DeepCopy() *FacetTrendQuerySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FacetTrendQuerySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FacetTrendQueryStatus) DeepCopyInto(out *FacetTrendQueryStatus) {
+	*out = *in
+	if in.Series != nil {
+		in, out := &in.Series, &out.Series
+		*out = make([]FacetTrendSeries, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FacetTrendQueryStatus.
+func (in *FacetTrendQueryStatus) DeepCopy() *FacetTrendQueryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FacetTrendQueryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FacetTrendSeries) DeepCopyInto(out *FacetTrendSeries) {
+	*out = *in
+	if in.Points != nil {
+		in, out := &in.Points, &out.Points
+		*out = make([]FacetTrendPoint, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FacetTrendSeries.
+func (in *FacetTrendSeries) DeepCopy() *FacetTrendSeries {
+	if in == nil {
+		return nil
+	}
+	out := new(FacetTrendSeries)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FacetValue) DeepCopyInto(out *FacetValue) {
 	*out = *in
@@ -1236,3 +1577,110 @@ func (in *ReindexTimeRange) DeepCopy() *ReindexTimeRange {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScopeStats) DeepCopyInto(out *ScopeStats) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScopeStats.
+func (in *ScopeStats) DeepCopy() *ScopeStats {
+	if in == nil {
+		return nil
+	}
+	out := new(ScopeStats)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScopeStats) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScopeStatsResource) DeepCopyInto(out *ScopeStatsResource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScopeStatsResource.
+func (in *ScopeStatsResource) DeepCopy() *ScopeStatsResource {
+	if in == nil {
+		return nil
+	}
+	out := new(ScopeStatsResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScopeStatsStatus) DeepCopyInto(out *ScopeStatsStatus) {
+	*out = *in
+	if in.TopResources != nil {
+		in, out := &in.TopResources, &out.TopResources
+		*out = make([]ScopeStatsResource, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScopeStatsStatus.
+func (in *ScopeStatsStatus) DeepCopy() *ScopeStatsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ScopeStatsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SelfScope) DeepCopyInto(out *SelfScope) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Status = in.Status
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelfScope.
+func (in *SelfScope) DeepCopy() *SelfScope {
+	if in == nil {
+		return nil
+	}
+	out := new(SelfScope)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SelfScope) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SelfScopeStatus) DeepCopyInto(out *SelfScopeStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelfScopeStatus.
+func (in *SelfScopeStatus) DeepCopy() *SelfScopeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SelfScopeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
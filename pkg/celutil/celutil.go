@@ -0,0 +1,21 @@
+// Package celutil holds the pieces of CEL audit log filters shared by the
+// kubectl plugin and the MCP server.
+package celutil
+
+import "strings"
+
+// MutatingVerbs are the audit verbs of requests that change a resource.
+var MutatingVerbs = []string{"create", "update", "patch", "delete", "deletecollection"}
+
+// EscapeString escapes single quotes in a string to make it safe for use
+// in a CEL string literal. Single quotes are escaped by replacing ' with \'
+// to prevent CEL filter injection attacks.
+//
+// Example:
+//
+//	EscapeString("my-namespace") -> "my-namespace"
+//	EscapeString("prod' || true || '") -> "prod\\' || true || \\'"
+func EscapeString(s string) string {
+	// Escape single quotes to prevent breaking out of CEL string literals
+	return strings.ReplaceAll(s, "'", "\\'")
+}
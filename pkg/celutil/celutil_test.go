@@ -0,0 +1,156 @@
+package celutil
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestEscapeString(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "normal string unchanged",
+			input:    "my-namespace",
+			expected: "my-namespace",
+		},
+		{
+			name:     "empty string unchanged",
+			input:    "",
+			expected: "",
+		},
+		{
+			name:     "single quote escaped",
+			input:    "it's",
+			expected: "it\\'s",
+		},
+		{
+			name:     "injection attempt - OR operator",
+			input:    "prod' || true || '",
+			expected: "prod\\' || true || \\'",
+		},
+		{
+			name:     "injection attempt - AND operator",
+			input:    "prod' && 'x' == 'x",
+			expected: "prod\\' && \\'x\\' == \\'x",
+		},
+		{
+			name:     "injection attempt - closing quote",
+			input:    "namespace'",
+			expected: "namespace\\'",
+		},
+		{
+			name:     "injection attempt - starting quote",
+			input:    "'namespace",
+			expected: "\\'namespace",
+		},
+		{
+			name:     "multiple single quotes",
+			input:    "'''",
+			expected: "\\'\\'\\'",
+		},
+		{
+			name:     "SQL-like injection attempt",
+			input:    "'; DROP TABLE audit_logs; --",
+			expected: "\\'; DROP TABLE audit_logs; --",
+		},
+		{
+			name:     "special characters without quotes are OK",
+			input:    "namespace-123_test.example",
+			expected: "namespace-123_test.example",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := EscapeString(tt.input)
+			if result != tt.expected {
+				t.Errorf("EscapeString(%q) = %q, want %q", tt.input, result, tt.expected)
+			}
+
+			// Verify that the escaped string, when used in a CEL filter, doesn't break the syntax
+			// by ensuring it still has balanced quotes after escaping
+			if strings.Contains(tt.input, "'") {
+				// Count unescaped quotes in result (escaped quotes don't count)
+				unescapedQuotes := 0
+				for i := 0; i < len(result); i++ {
+					if result[i] == '\'' {
+						// Check if it's escaped
+						if i == 0 || result[i-1] != '\\' {
+							unescapedQuotes++
+						}
+					}
+				}
+				// After escaping, there should be no unescaped quotes
+				if unescapedQuotes > 0 {
+					t.Errorf("EscapeString(%q) still contains %d unescaped quotes: %q",
+						tt.input, unescapedQuotes, result)
+				}
+			}
+		})
+	}
+}
+
+// TestEscapeStringInContext verifies that escaped strings work correctly
+// when used in actual CEL filter construction
+func TestEscapeStringInContext(t *testing.T) {
+	tests := []struct {
+		name           string
+		userInput      string
+		expectedFilter string
+	}{
+		{
+			name:           "normal namespace",
+			userInput:      "production",
+			expectedFilter: "objectRef.namespace == 'production'",
+		},
+		{
+			name:           "namespace with quote",
+			userInput:      "prod'uction",
+			expectedFilter: "objectRef.namespace == 'prod\\'uction'",
+		},
+		{
+			name:           "injection attempt neutralized",
+			userInput:      "prod' || true || '",
+			expectedFilter: "objectRef.namespace == 'prod\\' || true || \\''",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Simulate how it's used in audit.go
+			escaped := EscapeString(tt.userInput)
+			filter := fmt.Sprintf("objectRef.namespace == '%s'", escaped)
+
+			if filter != tt.expectedFilter {
+				t.Errorf("Filter construction failed:\nGot:  %s\nWant: %s", filter, tt.expectedFilter)
+			}
+
+			// Verify the filter has balanced quotes
+			// After escaping and wrapping in quotes, count unescaped quotes
+			quoteCount := 0
+			inEscape := false
+			for _, ch := range filter {
+				if inEscape {
+					inEscape = false
+					continue
+				}
+				if ch == '\\' {
+					inEscape = true
+					continue
+				}
+				if ch == '\'' {
+					quoteCount++
+				}
+			}
+
+			// Should have exactly 2 quotes (opening and closing the string literal)
+			if quoteCount != 2 {
+				t.Errorf("Filter has unbalanced quotes: %s (found %d unescaped quotes)", filter, quoteCount)
+			}
+		})
+	}
+}
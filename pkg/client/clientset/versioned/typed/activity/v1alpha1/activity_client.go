@@ -14,14 +14,18 @@ type ActivityV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	ActivitiesGetter
 	ActivityFacetQueriesGetter
+	ActivityMetricsQueriesGetter
 	ActivityPoliciesGetter
 	ActivityQueriesGetter
 	AuditLogFacetsQueriesGetter
 	AuditLogQueriesGetter
 	EventFacetQueriesGetter
 	EventQueriesGetter
+	FacetTrendQueriesGetter
 	PolicyPreviewsGetter
 	ReindexJobsGetter
+	ScopeStatsGetter
+	SelfScopesGetter
 }
 
 // ActivityV1alpha1Client is used to interact with features provided by the activity.miloapis.com group.
@@ -37,6 +41,10 @@ func (c *ActivityV1alpha1Client) ActivityFacetQueries() ActivityFacetQueryInterf
 	return newActivityFacetQueries(c)
 }
 
+func (c *ActivityV1alpha1Client) ActivityMetricsQueries() ActivityMetricsQueryInterface {
+	return newActivityMetricsQueries(c)
+}
+
 func (c *ActivityV1alpha1Client) ActivityPolicies() ActivityPolicyInterface {
 	return newActivityPolicies(c)
 }
@@ -61,6 +69,10 @@ func (c *ActivityV1alpha1Client) EventQueries() EventQueryInterface {
 	return newEventQueries(c)
 }
 
+func (c *ActivityV1alpha1Client) FacetTrendQueries() FacetTrendQueryInterface {
+	return newFacetTrendQueries(c)
+}
+
 func (c *ActivityV1alpha1Client) PolicyPreviews() PolicyPreviewInterface {
 	return newPolicyPreviews(c)
 }
@@ -69,6 +81,14 @@ func (c *ActivityV1alpha1Client) ReindexJobs() ReindexJobInterface {
 	return newReindexJobs(c)
 }
 
+func (c *ActivityV1alpha1Client) ScopeStats() ScopeStatsInterface {
+	return newScopeStats(c)
+}
+
+func (c *ActivityV1alpha1Client) SelfScopes() SelfScopeInterface {
+	return newSelfScopes(c)
+}
+
 // NewForConfig creates a new ActivityV1alpha1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
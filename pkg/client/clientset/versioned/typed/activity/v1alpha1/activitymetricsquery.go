@@ -0,0 +1,42 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+
+	activityv1alpha1 "go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+	scheme "go.miloapis.com/activity/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// ActivityMetricsQueriesGetter has a method to return a ActivityMetricsQueryInterface.
+// A group's client should implement this interface.
+type ActivityMetricsQueriesGetter interface {
+	ActivityMetricsQueries() ActivityMetricsQueryInterface
+}
+
+// ActivityMetricsQueryInterface has methods to work with ActivityMetricsQuery resources.
+type ActivityMetricsQueryInterface interface {
+	Create(ctx context.Context, activityMetricsQuery *activityv1alpha1.ActivityMetricsQuery, opts v1.CreateOptions) (*activityv1alpha1.ActivityMetricsQuery, error)
+	ActivityMetricsQueryExpansion
+}
+
+// activityMetricsQueries implements ActivityMetricsQueryInterface
+type activityMetricsQueries struct {
+	*gentype.Client[*activityv1alpha1.ActivityMetricsQuery]
+}
+
+// newActivityMetricsQueries returns a ActivityMetricsQueries
+func newActivityMetricsQueries(c *ActivityV1alpha1Client) *activityMetricsQueries {
+	return &activityMetricsQueries{
+		gentype.NewClient[*activityv1alpha1.ActivityMetricsQuery](
+			"activitymetricsqueries",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			"",
+			func() *activityv1alpha1.ActivityMetricsQuery { return &activityv1alpha1.ActivityMetricsQuery{} },
+		),
+	}
+}
@@ -0,0 +1,42 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+
+	activityv1alpha1 "go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+	scheme "go.miloapis.com/activity/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// FacetTrendQueriesGetter has a method to return a FacetTrendQueryInterface.
+// A group's client should implement this interface.
+type FacetTrendQueriesGetter interface {
+	FacetTrendQueries() FacetTrendQueryInterface
+}
+
+// FacetTrendQueryInterface has methods to work with FacetTrendQuery resources.
+type FacetTrendQueryInterface interface {
+	Create(ctx context.Context, facetTrendQuery *activityv1alpha1.FacetTrendQuery, opts v1.CreateOptions) (*activityv1alpha1.FacetTrendQuery, error)
+	FacetTrendQueryExpansion
+}
+
+// facetTrendQueries implements FacetTrendQueryInterface
+type facetTrendQueries struct {
+	*gentype.Client[*activityv1alpha1.FacetTrendQuery]
+}
+
+// newFacetTrendQueries returns a FacetTrendQueries
+func newFacetTrendQueries(c *ActivityV1alpha1Client) *facetTrendQueries {
+	return &facetTrendQueries{
+		gentype.NewClient[*activityv1alpha1.FacetTrendQuery](
+			"facettrendqueries",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			"",
+			func() *activityv1alpha1.FacetTrendQuery { return &activityv1alpha1.FacetTrendQuery{} },
+		),
+	}
+}
@@ -20,6 +20,10 @@ func (c *FakeActivityV1alpha1) ActivityFacetQueries() v1alpha1.ActivityFacetQuer
 	return newFakeActivityFacetQueries(c)
 }
 
+func (c *FakeActivityV1alpha1) ActivityMetricsQueries() v1alpha1.ActivityMetricsQueryInterface {
+	return newFakeActivityMetricsQueries(c)
+}
+
 func (c *FakeActivityV1alpha1) ActivityPolicies() v1alpha1.ActivityPolicyInterface {
 	return newFakeActivityPolicies(c)
 }
@@ -44,6 +48,10 @@ func (c *FakeActivityV1alpha1) EventQueries() v1alpha1.EventQueryInterface {
 	return newFakeEventQueries(c)
 }
 
+func (c *FakeActivityV1alpha1) FacetTrendQueries() v1alpha1.FacetTrendQueryInterface {
+	return newFakeFacetTrendQueries(c)
+}
+
 func (c *FakeActivityV1alpha1) PolicyPreviews() v1alpha1.PolicyPreviewInterface {
 	return newFakePolicyPreviews(c)
 }
@@ -52,6 +60,14 @@ func (c *FakeActivityV1alpha1) ReindexJobs() v1alpha1.ReindexJobInterface {
 	return newFakeReindexJobs(c)
 }
 
+func (c *FakeActivityV1alpha1) ScopeStats() v1alpha1.ScopeStatsInterface {
+	return newFakeScopeStats(c)
+}
+
+func (c *FakeActivityV1alpha1) SelfScopes() v1alpha1.SelfScopeInterface {
+	return newFakeSelfScopes(c)
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeActivityV1alpha1) RESTClient() rest.Interface {
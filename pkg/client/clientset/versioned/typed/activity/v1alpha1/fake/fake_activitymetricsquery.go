@@ -0,0 +1,28 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha1 "go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+	activityv1alpha1 "go.miloapis.com/activity/pkg/client/clientset/versioned/typed/activity/v1alpha1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeActivityMetricsQueries implements ActivityMetricsQueryInterface
+type fakeActivityMetricsQueries struct {
+	*gentype.FakeClient[*v1alpha1.ActivityMetricsQuery]
+	Fake *FakeActivityV1alpha1
+}
+
+func newFakeActivityMetricsQueries(fake *FakeActivityV1alpha1) activityv1alpha1.ActivityMetricsQueryInterface {
+	return &fakeActivityMetricsQueries{
+		gentype.NewFakeClient[*v1alpha1.ActivityMetricsQuery](
+			fake.Fake,
+			"",
+			v1alpha1.SchemeGroupVersion.WithResource("activitymetricsqueries"),
+			v1alpha1.SchemeGroupVersion.WithKind("ActivityMetricsQuery"),
+			func() *v1alpha1.ActivityMetricsQuery { return &v1alpha1.ActivityMetricsQuery{} },
+		),
+		fake,
+	}
+}
@@ -0,0 +1,28 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha1 "go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+	activityv1alpha1 "go.miloapis.com/activity/pkg/client/clientset/versioned/typed/activity/v1alpha1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeFacetTrendQueries implements FacetTrendQueryInterface
+type fakeFacetTrendQueries struct {
+	*gentype.FakeClient[*v1alpha1.FacetTrendQuery]
+	Fake *FakeActivityV1alpha1
+}
+
+func newFakeFacetTrendQueries(fake *FakeActivityV1alpha1) activityv1alpha1.FacetTrendQueryInterface {
+	return &fakeFacetTrendQueries{
+		gentype.NewFakeClient[*v1alpha1.FacetTrendQuery](
+			fake.Fake,
+			"",
+			v1alpha1.SchemeGroupVersion.WithResource("facettrendqueries"),
+			v1alpha1.SchemeGroupVersion.WithKind("FacetTrendQuery"),
+			func() *v1alpha1.FacetTrendQuery { return &v1alpha1.FacetTrendQuery{} },
+		),
+		fake,
+	}
+}
@@ -0,0 +1,28 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha1 "go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+	activityv1alpha1 "go.miloapis.com/activity/pkg/client/clientset/versioned/typed/activity/v1alpha1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeScopeStats implements ScopeStatsInterface
+type fakeScopeStats struct {
+	*gentype.FakeClient[*v1alpha1.ScopeStats]
+	Fake *FakeActivityV1alpha1
+}
+
+func newFakeScopeStats(fake *FakeActivityV1alpha1) activityv1alpha1.ScopeStatsInterface {
+	return &fakeScopeStats{
+		gentype.NewFakeClient[*v1alpha1.ScopeStats](
+			fake.Fake,
+			"",
+			v1alpha1.SchemeGroupVersion.WithResource("scopestats"),
+			v1alpha1.SchemeGroupVersion.WithKind("ScopeStats"),
+			func() *v1alpha1.ScopeStats { return &v1alpha1.ScopeStats{} },
+		),
+		fake,
+	}
+}
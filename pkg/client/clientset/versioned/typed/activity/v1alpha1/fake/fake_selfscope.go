@@ -0,0 +1,28 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha1 "go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+	activityv1alpha1 "go.miloapis.com/activity/pkg/client/clientset/versioned/typed/activity/v1alpha1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeSelfScopes implements SelfScopeInterface
+type fakeSelfScopes struct {
+	*gentype.FakeClient[*v1alpha1.SelfScope]
+	Fake *FakeActivityV1alpha1
+}
+
+func newFakeSelfScopes(fake *FakeActivityV1alpha1) activityv1alpha1.SelfScopeInterface {
+	return &fakeSelfScopes{
+		gentype.NewFakeClient[*v1alpha1.SelfScope](
+			fake.Fake,
+			"",
+			v1alpha1.SchemeGroupVersion.WithResource("selfscopes"),
+			v1alpha1.SchemeGroupVersion.WithKind("SelfScope"),
+			func() *v1alpha1.SelfScope { return &v1alpha1.SelfScope{} },
+		),
+		fake,
+	}
+}
@@ -6,6 +6,8 @@ type ActivityExpansion interface{}
 
 type ActivityFacetQueryExpansion interface{}
 
+type ActivityMetricsQueryExpansion interface{}
+
 type ActivityPolicyExpansion interface{}
 
 type ActivityQueryExpansion interface{}
@@ -18,6 +20,12 @@ type EventFacetQueryExpansion interface{}
 
 type EventQueryExpansion interface{}
 
+type FacetTrendQueryExpansion interface{}
+
 type PolicyPreviewExpansion interface{}
 
 type ReindexJobExpansion interface{}
+
+type ScopeStatsExpansion interface{}
+
+type SelfScopeExpansion interface{}
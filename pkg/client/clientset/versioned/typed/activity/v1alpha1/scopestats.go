@@ -0,0 +1,42 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+
+	activityv1alpha1 "go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+	scheme "go.miloapis.com/activity/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// ScopeStatsGetter has a method to return a ScopeStatsInterface.
+// A group's client should implement this interface.
+type ScopeStatsGetter interface {
+	ScopeStats() ScopeStatsInterface
+}
+
+// ScopeStatsInterface has methods to work with ScopeStats resources.
+type ScopeStatsInterface interface {
+	Create(ctx context.Context, scopeStats *activityv1alpha1.ScopeStats, opts v1.CreateOptions) (*activityv1alpha1.ScopeStats, error)
+	ScopeStatsExpansion
+}
+
+// scopeStats implements ScopeStatsInterface
+type scopeStats struct {
+	*gentype.Client[*activityv1alpha1.ScopeStats]
+}
+
+// newScopeStats returns a ScopeStats
+func newScopeStats(c *ActivityV1alpha1Client) *scopeStats {
+	return &scopeStats{
+		gentype.NewClient[*activityv1alpha1.ScopeStats](
+			"scopestats",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			"",
+			func() *activityv1alpha1.ScopeStats { return &activityv1alpha1.ScopeStats{} },
+		),
+	}
+}
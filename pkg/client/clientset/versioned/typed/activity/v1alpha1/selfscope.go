@@ -0,0 +1,42 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+
+	activityv1alpha1 "go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+	scheme "go.miloapis.com/activity/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// SelfScopesGetter has a method to return a SelfScopeInterface.
+// A group's client should implement this interface.
+type SelfScopesGetter interface {
+	SelfScopes() SelfScopeInterface
+}
+
+// SelfScopeInterface has methods to work with SelfScope resources.
+type SelfScopeInterface interface {
+	Create(ctx context.Context, selfScope *activityv1alpha1.SelfScope, opts v1.CreateOptions) (*activityv1alpha1.SelfScope, error)
+	SelfScopeExpansion
+}
+
+// selfScopes implements SelfScopeInterface
+type selfScopes struct {
+	*gentype.Client[*activityv1alpha1.SelfScope]
+}
+
+// newSelfScopes returns a SelfScopes
+func newSelfScopes(c *ActivityV1alpha1Client) *selfScopes {
+	return &selfScopes{
+		gentype.NewClient[*activityv1alpha1.SelfScope](
+			"selfscopes",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			"",
+			func() *activityv1alpha1.SelfScope { return &activityv1alpha1.SelfScope{} },
+		),
+	}
+}
@@ -2,19 +2,25 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/cli-runtime/pkg/printers"
 	"k8s.io/kubectl/pkg/cmd/util"
 
+	"go.miloapis.com/activity/internal/timeutil"
 	activityv1alpha1 "go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+	"go.miloapis.com/activity/pkg/celutil"
 	clientset "go.miloapis.com/activity/pkg/client/clientset/versioned"
 	"go.miloapis.com/activity/pkg/cmd/common"
 )
@@ -28,6 +34,13 @@ type AuditOptions struct {
 	Verb      string
 	User      string
 
+	// MutationsOnly limits results to requests that change resources
+	MutationsOnly bool
+
+	// Parallel splits the time range into this many windows fetched
+	// concurrently with --all-pages
+	Parallel int
+
 	// Common flags
 	TimeRange  common.TimeRangeFlags
 	Pagination common.PaginationFlags
@@ -52,6 +65,7 @@ func NewAuditOptions(f util.Factory, ioStreams genericclioptions.IOStreams) *Aud
 		Pagination: common.PaginationFlags{
 			Limit: 25,
 		},
+		Parallel: 1,
 	}
 }
 
@@ -83,6 +97,12 @@ Common Filters:
   user.username.startsWith('system:serviceaccount:')  # Service account activity
   objectRef.resource == 'secrets'                     # Secret access
 
+Parallel Export:
+  --parallel N (with --all-pages) splits the time range into N equal windows
+  and pages through them concurrently. Results are still newest first, in the
+  same order as a sequential --all-pages, but nothing is printed until every
+  window has finished. --max-total keeps the newest results across all windows.
+
 Examples:
   # Recent activity (last 24 hours)
   kubectl activity audit
@@ -90,6 +110,9 @@ Examples:
   # Deletions in the last week
   kubectl activity audit --start-time "now-7d" --verb delete
 
+  # Everything that changed in production, without the reads
+  kubectl activity audit --namespace production --mutations-only
+
   # Production namespace activity
   kubectl activity audit --namespace production
 
@@ -102,11 +125,20 @@ Examples:
   # Export to JSON for processing
   kubectl activity audit --start-time "now-30d" --all-pages -o json > audit.json
 
+  # Dump each event exactly as stored, one JSON document per line
+  kubectl activity audit --start-time "now-7d" --verb delete -o raw
+
+  # Export faster by fetching 4 slices of the time range at once
+  kubectl activity audit --start-time "now-30d" --all-pages --parallel 4 -o json > audit.json
+
   # Discover what users have activity
   kubectl activity audit --suggest user.username
 
-  # Custom output format
-  kubectl activity audit -o jsonpath='{.items[*].objectRef.name}'
+  # One line per event with a Go template (fields use their JSON names)
+  kubectl activity audit --template '{{.user.username}} {{.verb}} {{.objectRef.name}}'
+
+  # Extract a field from each event with JSONPath
+  kubectl activity audit -o jsonpath='{.objectRef.name}'
 `,
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -132,6 +164,8 @@ Examples:
 	cmd.Flags().StringVar(&o.Resource, "resource", "", "Filter by resource type (e.g., secrets, pods)")
 	cmd.Flags().StringVar(&o.Verb, "verb", "", "Filter by API verb (create, update, delete, patch, get, list, watch)")
 	cmd.Flags().StringVar(&o.User, "user", "", "Filter by username")
+	cmd.Flags().BoolVar(&o.MutationsOnly, "mutations-only", false, "Only show requests that change resources (create, update, patch, delete, deletecollection)")
+	cmd.Flags().IntVar(&o.Parallel, "parallel", o.Parallel, fmt.Sprintf("Split the time range into N windows fetched concurrently with --all-pages (1-%d)", common.MaxParallel))
 
 	// Add printer flags (handles -o json, -o yaml, etc.)
 	o.PrintFlags.AddFlags(cmd)
@@ -161,6 +195,15 @@ func (o *AuditOptions) Validate() error {
 	if err := o.Pagination.Validate(); err != nil {
 		return err
 	}
+	if o.Parallel < 1 || o.Parallel > common.MaxParallel {
+		return fmt.Errorf("--parallel must be between 1 and %d", common.MaxParallel)
+	}
+	if o.Parallel > 1 && !o.Pagination.AllPages {
+		return fmt.Errorf("--parallel requires --all-pages")
+	}
+	if o.MutationsOnly && o.Suggest.IsSuggestMode() {
+		return fmt.Errorf("--mutations-only cannot be used with --suggest; add a verb condition to --filter instead")
+	}
 	return nil
 }
 
@@ -182,6 +225,9 @@ func (o *AuditOptions) Run(ctx context.Context) error {
 	}
 
 	// Regular query mode
+	if o.Pagination.AllPages && o.Parallel > 1 {
+		return o.runParallelPages(ctx, client)
+	}
 	if o.Pagination.AllPages {
 		return o.runAllPages(ctx, client)
 	}
@@ -194,16 +240,16 @@ func (o *AuditOptions) buildFilter() string {
 	var filters []string
 
 	if o.Namespace != "" {
-		filters = append(filters, fmt.Sprintf("objectRef.namespace == '%s'", common.EscapeCELString(o.Namespace)))
+		filters = append(filters, fmt.Sprintf("objectRef.namespace == '%s'", celutil.EscapeString(o.Namespace)))
 	}
 	if o.Resource != "" {
-		filters = append(filters, fmt.Sprintf("objectRef.resource == '%s'", common.EscapeCELString(o.Resource)))
+		filters = append(filters, fmt.Sprintf("objectRef.resource == '%s'", celutil.EscapeString(o.Resource)))
 	}
 	if o.Verb != "" {
-		filters = append(filters, fmt.Sprintf("verb == '%s'", common.EscapeCELString(o.Verb)))
+		filters = append(filters, fmt.Sprintf("verb == '%s'", celutil.EscapeString(o.Verb)))
 	}
 	if o.User != "" {
-		filters = append(filters, fmt.Sprintf("user.username == '%s'", common.EscapeCELString(o.User)))
+		filters = append(filters, fmt.Sprintf("user.username == '%s'", celutil.EscapeString(o.User)))
 	}
 
 	// Combine shorthand filters
@@ -228,11 +274,13 @@ func (o *AuditOptions) runSinglePage(ctx context.Context, client *clientset.Clie
 			GenerateName: "audit-",
 		},
 		Spec: activityv1alpha1.AuditLogQuerySpec{
-			StartTime: o.TimeRange.StartTime,
-			EndTime:   o.TimeRange.EndTime,
-			Filter:    o.buildFilter(),
-			Limit:     o.Pagination.Limit,
-			Continue:  o.Pagination.ContinueAfter,
+			StartTime:     o.TimeRange.StartTime,
+			EndTime:       o.TimeRange.EndTime,
+			Filter:        o.buildFilter(),
+			Limit:         o.Pagination.Limit,
+			Continue:      o.Pagination.ContinueAfter,
+			IncludeRaw:    common.IsRawOutputFormat(o.PrintFlags),
+			MutationsOnly: o.MutationsOnly,
 		},
 	}
 
@@ -254,8 +302,10 @@ func (o *AuditOptions) runAllPages(ctx context.Context, client *clientset.Client
 	continueAfter := ""
 	pageNum := 1
 	totalCount := 0
+	truncatedAfter := ""
 
 	isTableOutput := common.IsDefaultOutputFormat(o.PrintFlags)
+	isRawOutput := common.IsRawOutputFormat(o.PrintFlags)
 	var tablePrinter printers.ResourcePrinter
 	if isTableOutput {
 		tablePrinter = common.CreateTablePrinter(o.Output.NoHeaders)
@@ -267,11 +317,13 @@ func (o *AuditOptions) runAllPages(ctx context.Context, client *clientset.Client
 				GenerateName: "audit-",
 			},
 			Spec: activityv1alpha1.AuditLogQuerySpec{
-				StartTime: o.TimeRange.StartTime,
-				EndTime:   o.TimeRange.EndTime,
-				Filter:    o.buildFilter(),
-				Limit:     o.Pagination.Limit,
-				Continue:  continueAfter,
+				StartTime:     o.TimeRange.StartTime,
+				EndTime:       o.TimeRange.EndTime,
+				Filter:        o.buildFilter(),
+				Limit:         o.Pagination.PageLimit(totalCount),
+				Continue:      continueAfter,
+				IncludeRaw:    isRawOutput,
+				MutationsOnly: o.MutationsOnly,
 			},
 		}
 
@@ -296,6 +348,11 @@ func (o *AuditOptions) runAllPages(ctx context.Context, client *clientset.Client
 			if pageNum == 1 {
 				tablePrinter = common.CreateTablePrinter(true)
 			}
+		} else if isRawOutput {
+			// Raw output is one document per line, so it streams like the table
+			if err := common.PrintRaw(result.Status.RawResults, o.Out); err != nil {
+				return err
+			}
 		} else {
 			allEvents = append(allEvents, result.Status.Results...)
 		}
@@ -303,40 +360,182 @@ func (o *AuditOptions) runAllPages(ctx context.Context, client *clientset.Client
 		if result.Status.Continue == "" {
 			break
 		}
+		if o.Pagination.ReachedMaxTotal(totalCount) {
+			truncatedAfter = result.Status.Continue
+			break
+		}
 
 		continueAfter = result.Status.Continue
 		pageNum++
 	}
 
 	// Print collected results for JSON/YAML
-	if !isTableOutput {
-		printer, err := common.CreatePrinter(o.PrintFlags)
-		if err != nil {
-			return fmt.Errorf("failed to create printer: %w", err)
-		}
-		if err := printEvents(allEvents, printer, o.Out); err != nil {
+	if !isTableOutput && !isRawOutput {
+		if err := printEvents(allEvents, o.PrintFlags, o.Out); err != nil {
 			return err
 		}
 	}
 
 	tp := common.NewTablePrinter(o.PrintFlags, o.IOStreams, o.Output.NoHeaders)
 	tp.PrintAllPagesInfo(totalCount)
+	if truncatedAfter != "" {
+		tp.PrintTruncatedInfo(o.Pagination.MaxTotal, truncatedAfter)
+	}
 
 	return nil
 }
 
+// runParallelPages splits the time range into --parallel windows and pages
+// through them concurrently. The windows don't overlap and are queried newest
+// first, so concatenating their results keeps the server's newest-first order.
+func (o *AuditOptions) runParallelPages(ctx context.Context, client *clientset.Clientset) error {
+	now := time.Now()
+	start, err := timeutil.ParseFlexibleTime(o.TimeRange.StartTime, now)
+	if err != nil {
+		return fmt.Errorf("invalid --start-time: %w", err)
+	}
+	end, err := timeutil.ParseFlexibleTime(o.TimeRange.EndTime, now)
+	if err != nil {
+		return fmt.Errorf("invalid --end-time: %w", err)
+	}
+	windows := common.SplitTimeRange(start, end, o.Parallel)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]windowResult, len(windows))
+	errs := make([]error, len(windows))
+	var wg sync.WaitGroup
+	for i, window := range windows {
+		wg.Add(1)
+		go func(i int, window common.TimeWindow) {
+			defer wg.Done()
+			results[i], errs[i] = o.fetchWindow(ctx, client, window)
+			if errs[i] != nil {
+				// One failed window fails the export, so stop the others early
+				cancel()
+			}
+		}(i, window)
+	}
+	wg.Wait()
+
+	// Report the window that failed rather than the ones it cancelled
+	for _, err := range errs {
+		if err != nil && !errors.Is(err, context.Canceled) {
+			return err
+		}
+	}
+
+	var allEvents []auditv1.Event
+	var allRaw []string
+	anyTruncated := false
+	for i := range windows {
+		if errs[i] != nil {
+			return errs[i]
+		}
+		allEvents = append(allEvents, results[i].events...)
+		allRaw = append(allRaw, results[i].raw...)
+		anyTruncated = anyTruncated || results[i].truncated
+	}
+
+	// Each window fetched up to --max-total, so the newest --max-total overall
+	// are all present; keep those.
+	if o.Pagination.MaxTotal > 0 && len(allEvents) > o.Pagination.MaxTotal {
+		allEvents = allEvents[:o.Pagination.MaxTotal]
+		if len(allRaw) > o.Pagination.MaxTotal {
+			allRaw = allRaw[:o.Pagination.MaxTotal]
+		}
+		anyTruncated = true
+	}
+
+	if common.IsRawOutputFormat(o.PrintFlags) {
+		if err := common.PrintRaw(allRaw, o.Out); err != nil {
+			return err
+		}
+	} else if common.IsDefaultOutputFormat(o.PrintFlags) {
+		if err := common.CreateTablePrinter(o.Output.NoHeaders).PrintObj(eventsToTable(allEvents), o.Out); err != nil {
+			return err
+		}
+	} else if err := printEvents(allEvents, o.PrintFlags, o.Out); err != nil {
+		return err
+	}
+
+	tp := common.NewTablePrinter(o.PrintFlags, o.IOStreams, o.Output.NoHeaders)
+	tp.PrintAllPagesInfo(len(allEvents))
+	if anyTruncated {
+		tp.PrintParallelTruncatedInfo(o.Pagination.MaxTotal)
+	}
+
+	return nil
+}
+
+// windowResult holds what one window of a --parallel fetch returned
+type windowResult struct {
+	events []auditv1.Event
+	raw    []string
+
+	// truncated is set when the window stopped at --max-total with results left
+	truncated bool
+}
+
+// fetchWindow pages through one window of a --parallel fetch, stopping at
+// --max-total.
+func (o *AuditOptions) fetchWindow(ctx context.Context, client *clientset.Clientset, window common.TimeWindow) (windowResult, error) {
+	var result windowResult
+	continueAfter := ""
+	pageNum := 1
+
+	for {
+		query := &activityv1alpha1.AuditLogQuery{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "audit-",
+			},
+			Spec: activityv1alpha1.AuditLogQuerySpec{
+				StartTime:     window.Start.Format(time.RFC3339Nano),
+				EndTime:       window.End.Format(time.RFC3339Nano),
+				Filter:        o.buildFilter(),
+				Limit:         o.Pagination.PageLimit(len(result.events)),
+				Continue:      continueAfter,
+				IncludeRaw:    common.IsRawOutputFormat(o.PrintFlags),
+				MutationsOnly: o.MutationsOnly,
+			},
+		}
+
+		if o.Output.Debug {
+			fmt.Fprintf(o.ErrOut, "DEBUG: Fetching page %d of %s to %s\n", pageNum, query.Spec.StartTime, query.Spec.EndTime)
+		}
+
+		page, err := client.ActivityV1alpha1().AuditLogQueries().Create(ctx, query, metav1.CreateOptions{})
+		if err != nil {
+			return windowResult{}, fmt.Errorf("query failed on page %d of %s to %s: %w", pageNum, query.Spec.StartTime, query.Spec.EndTime, err)
+		}
+
+		result.events = append(result.events, page.Status.Results...)
+		result.raw = append(result.raw, page.Status.RawResults...)
+
+		if page.Status.Continue == "" {
+			return result, nil
+		}
+		if o.Pagination.ReachedMaxTotal(len(result.events)) {
+			result.truncated = true
+			return result, nil
+		}
+
+		continueAfter = page.Status.Continue
+		pageNum++
+	}
+}
+
 // printResults outputs the query results in the specified format
 func (o *AuditOptions) printResults(result *activityv1alpha1.AuditLogQuery) error {
+	if common.IsRawOutputFormat(o.PrintFlags) {
+		return common.PrintRaw(result.Status.RawResults, o.Out)
+	}
 	if common.IsDefaultOutputFormat(o.PrintFlags) {
 		return o.printTable(result.Status.Results, result.Status.Continue)
 	}
 
-	printer, err := common.CreatePrinter(o.PrintFlags)
-	if err != nil {
-		return fmt.Errorf("failed to create printer: %w", err)
-	}
-
-	return printEvents(result.Status.Results, printer, o.Out)
+	return printEvents(result.Status.Results, o.PrintFlags, o.Out)
 }
 
 // printTable prints events as a formatted table
@@ -408,8 +607,9 @@ func eventsToRows(events []auditv1.Event) []metav1.TableRow {
 	return rows
 }
 
-// printEvents prints audit events using the configured printer
-func printEvents(events []auditv1.Event, printer printers.ResourcePrinter, out io.Writer) error {
+// printEvents prints audit events using the configured output format.
+// Templates are evaluated per event; see common.PrintList.
+func printEvents(events []auditv1.Event, printFlags *genericclioptions.PrintFlags, out io.Writer) error {
 	eventList := &auditv1.EventList{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "EventList",
@@ -417,5 +617,12 @@ func printEvents(events []auditv1.Event, printer printers.ResourcePrinter, out i
 		},
 		Items: events,
 	}
-	return printer.PrintObj(eventList, out)
+
+	items := make([]runtime.Object, len(events))
+	for i := range events {
+		event := events[i]
+		event.TypeMeta = metav1.TypeMeta{Kind: "Event", APIVersion: "audit.k8s.io/v1"}
+		items[i] = &event
+	}
+	return common.PrintList(printFlags, eventList, items, out)
 }
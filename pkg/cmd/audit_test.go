@@ -1,6 +1,11 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,7 +15,10 @@ import (
 	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/rest"
 
+	activityv1alpha1 "go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+	clientset "go.miloapis.com/activity/pkg/client/clientset/versioned"
 	"go.miloapis.com/activity/pkg/cmd/common"
 )
 
@@ -131,11 +139,14 @@ func TestAuditOptions_buildFilter(t *testing.T) {
 
 func TestAuditOptions_Validate(t *testing.T) {
 	tests := []struct {
-		name       string
-		timeRange  common.TimeRangeFlags
-		pagination common.PaginationFlags
-		wantErr    bool
-		errMsg     string
+		name          string
+		timeRange     common.TimeRangeFlags
+		pagination    common.PaginationFlags
+		parallel      int
+		mutationsOnly bool
+		suggest       string
+		wantErr       bool
+		errMsg        string
 	}{
 		{
 			name: "valid options",
@@ -146,7 +157,8 @@ func TestAuditOptions_Validate(t *testing.T) {
 			pagination: common.PaginationFlags{
 				Limit: 25,
 			},
-			wantErr: false,
+			parallel: 1,
+			wantErr:  false,
 		},
 		{
 			name: "invalid time range - empty start",
@@ -157,8 +169,9 @@ func TestAuditOptions_Validate(t *testing.T) {
 			pagination: common.PaginationFlags{
 				Limit: 25,
 			},
-			wantErr: true,
-			errMsg:  "--start-time is required",
+			parallel: 1,
+			wantErr:  true,
+			errMsg:   "--start-time is required",
 		},
 		{
 			name: "invalid pagination - limit too low",
@@ -169,8 +182,9 @@ func TestAuditOptions_Validate(t *testing.T) {
 			pagination: common.PaginationFlags{
 				Limit: 0,
 			},
-			wantErr: true,
-			errMsg:  "--limit must be between 1 and 1000",
+			parallel: 1,
+			wantErr:  true,
+			errMsg:   "--limit must be between 1 and 1000",
 		},
 		{
 			name: "invalid pagination - limit too high",
@@ -181,8 +195,9 @@ func TestAuditOptions_Validate(t *testing.T) {
 			pagination: common.PaginationFlags{
 				Limit: 1001,
 			},
-			wantErr: true,
-			errMsg:  "--limit must be between 1 and 1000",
+			parallel: 1,
+			wantErr:  true,
+			errMsg:   "--limit must be between 1 and 1000",
 		},
 		{
 			name: "invalid pagination - all-pages with continue-after",
@@ -195,16 +210,102 @@ func TestAuditOptions_Validate(t *testing.T) {
 				AllPages:      true,
 				ContinueAfter: "cursor123",
 			},
-			wantErr: true,
-			errMsg:  "--all-pages and --continue-after are mutually exclusive",
+			parallel: 1,
+			wantErr:  true,
+			errMsg:   "--all-pages and --continue-after are mutually exclusive",
+		},
+		{
+			name: "valid parallel with all-pages",
+			timeRange: common.TimeRangeFlags{
+				StartTime: "now-7d",
+				EndTime:   "now",
+			},
+			pagination: common.PaginationFlags{
+				Limit:    25,
+				AllPages: true,
+			},
+			parallel: 4,
+			wantErr:  false,
+		},
+		{
+			name: "invalid parallel - without all-pages",
+			timeRange: common.TimeRangeFlags{
+				StartTime: "now-7d",
+				EndTime:   "now",
+			},
+			pagination: common.PaginationFlags{
+				Limit: 25,
+			},
+			parallel: 4,
+			wantErr:  true,
+			errMsg:   "--parallel requires --all-pages",
+		},
+		{
+			name: "invalid parallel - too many windows",
+			timeRange: common.TimeRangeFlags{
+				StartTime: "now-7d",
+				EndTime:   "now",
+			},
+			pagination: common.PaginationFlags{
+				Limit:    25,
+				AllPages: true,
+			},
+			parallel: 11,
+			wantErr:  true,
+			errMsg:   "--parallel must be between 1 and 10",
+		},
+		{
+			name: "invalid parallel - zero windows",
+			timeRange: common.TimeRangeFlags{
+				StartTime: "now-7d",
+				EndTime:   "now",
+			},
+			pagination: common.PaginationFlags{
+				Limit:    25,
+				AllPages: true,
+			},
+			parallel: 0,
+			wantErr:  true,
+			errMsg:   "--parallel must be between 1 and 10",
+		},
+		{
+			name: "valid mutations-only",
+			timeRange: common.TimeRangeFlags{
+				StartTime: "now-7d",
+				EndTime:   "now",
+			},
+			pagination: common.PaginationFlags{
+				Limit: 25,
+			},
+			parallel:      1,
+			mutationsOnly: true,
+			wantErr:       false,
+		},
+		{
+			name: "invalid mutations-only - with suggest",
+			timeRange: common.TimeRangeFlags{
+				StartTime: "now-7d",
+				EndTime:   "now",
+			},
+			pagination: common.PaginationFlags{
+				Limit: 25,
+			},
+			parallel:      1,
+			mutationsOnly: true,
+			suggest:       "user.username",
+			wantErr:       true,
+			errMsg:        "--mutations-only cannot be used with --suggest",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			o := &AuditOptions{
-				TimeRange:  tt.timeRange,
-				Pagination: tt.pagination,
+				TimeRange:     tt.timeRange,
+				Pagination:    tt.pagination,
+				Parallel:      tt.parallel,
+				MutationsOnly: tt.mutationsOnly,
+				Suggest:       common.SuggestFlags{Suggest: tt.suggest},
 			}
 
 			err := o.Validate()
@@ -461,3 +562,128 @@ func TestAuditOptions_Complete(t *testing.T) {
 	// In this implementation, Complete checks but doesn't modify
 	// This test verifies the method succeeds
 }
+
+func TestPrintEvents_Template(t *testing.T) {
+	events := []auditv1.Event{
+		{
+			Verb:      "delete",
+			User:      authnv1.UserInfo{Username: "alice@example.com"},
+			ObjectRef: &auditv1.ObjectReference{Resource: "configmaps", Name: "app-config"},
+		},
+		{
+			Verb:      "create",
+			User:      authnv1.UserInfo{Username: "bob@example.com"},
+			ObjectRef: &auditv1.ObjectReference{Resource: "secrets", Name: "db-password"},
+		},
+	}
+
+	printFlags := genericclioptions.NewPrintFlags("")
+	*printFlags.TemplatePrinterFlags.TemplateArgument = "{{.user.username}} {{.verb}} {{.objectRef.name}}"
+
+	var out bytes.Buffer
+	require.NoError(t, printEvents(events, printFlags, &out))
+	assert.Equal(t, "alice@example.com delete app-config\nbob@example.com create db-password\n", out.String())
+
+	printFlags = genericclioptions.NewPrintFlags("")
+	jsonpath := "jsonpath={.kind}"
+	printFlags.OutputFormat = &jsonpath
+
+	out.Reset()
+	require.NoError(t, printEvents(events, printFlags, &out))
+	assert.Equal(t, "Event\nEvent\n", out.String())
+}
+
+func TestAuditOptions_runParallelPages(t *testing.T) {
+	// Each window returns two pages: its two newest seconds, then its start.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var query activityv1alpha1.AuditLogQuery
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&query))
+		start, err := time.Parse(time.RFC3339Nano, query.Spec.StartTime)
+		require.NoError(t, err)
+		end, err := time.Parse(time.RFC3339Nano, query.Spec.EndTime)
+		require.NoError(t, err)
+
+		event := func(ts time.Time) auditv1.Event {
+			return auditv1.Event{StageTimestamp: metav1.NewMicroTime(ts)}
+		}
+		if query.Spec.Continue == "" {
+			query.Status.Results = []auditv1.Event{event(end.Add(-time.Second)), event(end.Add(-2 * time.Second))}
+			query.Status.Continue = "page-2"
+		} else {
+			query.Status.Results = []auditv1.Event{event(start)}
+		}
+		if query.Spec.IncludeRaw {
+			for _, e := range query.Status.Results {
+				query.Status.RawResults = append(query.Status.RawResults,
+					`{"stageTimestamp":"`+e.StageTimestamp.UTC().Format(time.RFC3339)+`","notInEventType":true}`)
+			}
+		}
+		query.APIVersion = activityv1alpha1.SchemeGroupVersion.String()
+		query.Kind = "AuditLogQuery"
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(query))
+	}))
+	defer server.Close()
+
+	client, err := clientset.NewForConfig(&rest.Config{Host: server.URL})
+	require.NoError(t, err)
+
+	newOptions := func(maxTotal int) (*AuditOptions, *bytes.Buffer, *bytes.Buffer) {
+		out, errOut := &bytes.Buffer{}, &bytes.Buffer{}
+		printFlags := genericclioptions.NewPrintFlags("")
+		*printFlags.TemplatePrinterFlags.TemplateArgument = "{{.stageTimestamp}}"
+		return &AuditOptions{
+			TimeRange: common.TimeRangeFlags{
+				StartTime: "2026-10-01T00:00:00Z",
+				EndTime:   "2026-10-05T00:00:00Z",
+			},
+			Pagination: common.PaginationFlags{Limit: 25, AllPages: true, MaxTotal: maxTotal},
+			Parallel:   4,
+			PrintFlags: printFlags,
+			IOStreams:  genericclioptions.IOStreams{Out: out, ErrOut: errOut},
+		}, out, errOut
+	}
+
+	t.Run("results from all windows are newest first", func(t *testing.T) {
+		o, out, errOut := newOptions(0)
+		require.NoError(t, o.runParallelPages(t.Context(), client))
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		require.Len(t, lines, 12)
+		for i := 1; i < len(lines); i++ {
+			prev, err := time.Parse(time.RFC3339Nano, lines[i-1])
+			require.NoError(t, err)
+			cur, err := time.Parse(time.RFC3339Nano, lines[i])
+			require.NoError(t, err)
+			assert.True(t, prev.After(cur), "result %d (%s) should be newer than result %d (%s)", i-1, lines[i-1], i, lines[i])
+		}
+		assert.Equal(t, "2026-10-04T23:59:59.000000Z", lines[0])
+		assert.Equal(t, "2026-10-01T00:00:00.000000Z", lines[11])
+		assert.NotContains(t, errOut.String(), "truncated")
+	})
+
+	t.Run("raw output prints the stored JSON", func(t *testing.T) {
+		o, out, _ := newOptions(0)
+		raw := common.RawOutputFormat
+		o.PrintFlags = genericclioptions.NewPrintFlags("")
+		o.PrintFlags.OutputFormat = &raw
+		require.NoError(t, o.runParallelPages(t.Context(), client))
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		require.Len(t, lines, 12)
+		assert.Equal(t, `{"stageTimestamp":"2026-10-04T23:59:59Z","notInEventType":true}`, lines[0])
+		assert.Equal(t, `{"stageTimestamp":"2026-10-01T00:00:00Z","notInEventType":true}`, lines[11])
+	})
+
+	t.Run("max-total keeps the newest results", func(t *testing.T) {
+		o, out, errOut := newOptions(5)
+		require.NoError(t, o.runParallelPages(t.Context(), client))
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		require.Len(t, lines, 5)
+		assert.Equal(t, "2026-10-04T23:59:59.000000Z", lines[0])
+		assert.Equal(t, "2026-10-03T23:59:58.000000Z", lines[4])
+		assert.Contains(t, errOut.String(), "results truncated at --max-total=5")
+	})
+}
@@ -5,18 +5,6 @@ import (
 	"strings"
 )
 
-// EscapeCELString escapes single quotes in a string to make it safe for use
-// in a CEL string literal. Single quotes are escaped by replacing ' with \'
-// to prevent CEL filter injection attacks.
-//
-// Example:
-//   EscapeCELString("my-namespace") -> "my-namespace"
-//   EscapeCELString("prod' || true || '") -> "prod\\' || true || \\'"
-func EscapeCELString(s string) string {
-	// Escape single quotes to prevent breaking out of CEL string literals
-	return strings.ReplaceAll(s, "'", "\\'")
-}
-
 // EscapeFieldSelectorValue escapes special characters in a field selector value
 // to prevent field selector injection. Field selectors use = and , as delimiters,
 // so these must be escaped or rejected.
@@ -0,0 +1,48 @@
+package common
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"go.miloapis.com/activity/internal/timeutil"
+)
+
+// AddExplainFlag adds the --explain flag to a query command
+func AddExplainFlag(cmd *cobra.Command, explain *bool) {
+	cmd.Flags().BoolVar(explain, "explain", false, "Print the CEL filter and absolute time range that would be queried, then exit without querying")
+}
+
+// PrintExplain writes the filter a query command would send and the absolute
+// times its time range resolves to at now. Relative times are shown next to
+// their resolved value so a surprising window is easy to spot.
+func PrintExplain(out io.Writer, filter string, timeRange TimeRangeFlags, now time.Time) error {
+	start, err := timeutil.ParseFlexibleTime(timeRange.StartTime, now)
+	if err != nil {
+		return fmt.Errorf("invalid --start-time: %w", err)
+	}
+	end, err := timeutil.ParseFlexibleTime(timeRange.EndTime, now)
+	if err != nil {
+		return fmt.Errorf("invalid --end-time: %w", err)
+	}
+
+	if filter == "" {
+		filter = "<none>"
+	}
+	fmt.Fprintf(out, "Filter:     %s\n", filter)
+	fmt.Fprintf(out, "Start time: %s\n", explainTime(start, timeRange.StartTime))
+	fmt.Fprintf(out, "End time:   %s\n", explainTime(end, timeRange.EndTime))
+	return nil
+}
+
+// explainTime formats a resolved time, followed by the flag value it came from
+// when that was not already absolute
+func explainTime(t time.Time, flagValue string) string {
+	resolved := t.UTC().Format(time.RFC3339)
+	if flagValue == resolved {
+		return resolved
+	}
+	return fmt.Sprintf("%s (%s)", resolved, flagValue)
+}
@@ -0,0 +1,28 @@
+package common
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintExplain(t *testing.T) {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	var out bytes.Buffer
+	err := PrintExplain(&out, "verb == 'delete'", TimeRangeFlags{StartTime: "now-7d", EndTime: "2026-03-15T00:00:00Z"}, now)
+	require.NoError(t, err)
+	assert.Equal(t, "Filter:     verb == 'delete'\n"+
+		"Start time: 2026-03-08T12:00:00Z (now-7d)\n"+
+		"End time:   2026-03-15T00:00:00Z\n", out.String())
+
+	out.Reset()
+	require.NoError(t, PrintExplain(&out, "", TimeRangeFlags{StartTime: "now-1h", EndTime: "now"}, now))
+	assert.Contains(t, out.String(), "Filter:     <none>\n")
+
+	err = PrintExplain(&out, "", TimeRangeFlags{StartTime: "yesterday", EndTime: "now"}, now)
+	assert.ErrorContains(t, err, "invalid --start-time")
+}
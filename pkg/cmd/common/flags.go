@@ -2,8 +2,13 @@ package common
 
 import (
 	"fmt"
+	"net/http"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"k8s.io/client-go/rest"
+
+	activityv1alpha1 "go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
 )
 
 // TimeRangeFlags contains common time range flags
@@ -29,11 +34,17 @@ func (f *TimeRangeFlags) Validate() error {
 	return nil
 }
 
+// DefaultMaxTotal is the default cap on the number of results fetched with --all-pages
+const DefaultMaxTotal = 10000
+
 // PaginationFlags contains common pagination flags
 type PaginationFlags struct {
 	Limit         int32
 	AllPages      bool
 	ContinueAfter string
+
+	// MaxTotal stops --all-pages after this many results. Zero means no cap.
+	MaxTotal int
 }
 
 // AddPaginationFlags adds pagination flags to a command
@@ -41,6 +52,7 @@ func AddPaginationFlags(cmd *cobra.Command, flags *PaginationFlags, defaultLimit
 	cmd.Flags().Int32Var(&flags.Limit, "limit", defaultLimit, "Maximum number of results per page (1-1000)")
 	cmd.Flags().BoolVar(&flags.AllPages, "all-pages", false, "Fetch all pages of results")
 	cmd.Flags().StringVar(&flags.ContinueAfter, "continue-after", "", "Pagination cursor from previous query")
+	cmd.Flags().IntVar(&flags.MaxTotal, "max-total", DefaultMaxTotal, "Maximum number of results to fetch with --all-pages (0 for no limit)")
 }
 
 // Validate checks that pagination flags are valid
@@ -51,9 +63,29 @@ func (f *PaginationFlags) Validate() error {
 	if f.AllPages && f.ContinueAfter != "" {
 		return fmt.Errorf("--all-pages and --continue-after are mutually exclusive")
 	}
+	if f.MaxTotal < 0 {
+		return fmt.Errorf("--max-total must be 0 or greater")
+	}
 	return nil
 }
 
+// PageLimit returns the limit for the next page of an --all-pages loop that has
+// already fetched the given number of results. The last page is shortened so the
+// loop stops exactly at --max-total and its continue token resumes from there.
+func (f *PaginationFlags) PageLimit(fetched int) int32 {
+	if f.MaxTotal > 0 {
+		if remaining := f.MaxTotal - fetched; remaining < int(f.Limit) {
+			return int32(remaining)
+		}
+	}
+	return f.Limit
+}
+
+// ReachedMaxTotal reports whether an --all-pages loop has fetched --max-total results
+func (f *PaginationFlags) ReachedMaxTotal(fetched int) bool {
+	return f.MaxTotal > 0 && fetched >= f.MaxTotal
+}
+
 // OutputFlags contains common output flags
 type OutputFlags struct {
 	NoHeaders bool
@@ -80,3 +112,112 @@ func AddSuggestFlags(cmd *cobra.Command, flags *SuggestFlags) {
 func (f *SuggestFlags) IsSuggestMode() bool {
 	return f.Suggest != ""
 }
+
+// Color modes accepted by the --color flag
+const (
+	ColorAuto   = "auto"
+	ColorAlways = "always"
+	ColorNever  = "never"
+)
+
+// ColorFlags contains flags that override terminal color auto-detection
+type ColorFlags struct {
+	Color   string
+	NoColor bool
+}
+
+// AddColorFlags adds color flags to a command
+func AddColorFlags(cmd *cobra.Command, flags *ColorFlags) {
+	cmd.Flags().StringVar(&flags.Color, "color", ColorAuto, "When to use colored output: auto, always, or never")
+	cmd.Flags().BoolVar(&flags.NoColor, "no-color", false, "Disable colored output (same as --color never)")
+}
+
+// Validate checks that color flags are valid
+func (f *ColorFlags) Validate() error {
+	switch f.Color {
+	case "", ColorAuto, ColorAlways, ColorNever:
+	default:
+		return fmt.Errorf("--color must be one of: auto, always, never")
+	}
+	if f.NoColor && f.Color == ColorAlways {
+		return fmt.Errorf("--no-color and --color always are mutually exclusive")
+	}
+	return nil
+}
+
+// Mode returns the effective color mode, folding --no-color into --color
+func (f *ColorFlags) Mode() string {
+	if f.NoColor {
+		return ColorNever
+	}
+	if f.Color == "" {
+		return ColorAuto
+	}
+	return f.Color
+}
+
+// ScopeFlags contains flags that target a specific tenant. The server only
+// honors them for platform administrators.
+type ScopeFlags struct {
+	Kind string
+	Name string
+}
+
+// scopeKinds maps accepted --scope-kind values to the kind sent to the server
+var scopeKinds = map[string]string{
+	"organization": "Organization",
+	"project":      "Project",
+	"user":         "User",
+}
+
+// AddScopeFlags adds scope override flags to a command and its subcommands
+func AddScopeFlags(cmd *cobra.Command, flags *ScopeFlags) {
+	cmd.PersistentFlags().StringVar(&flags.Kind, "scope-kind", "", "Query a specific tenant instead of your own scope: organization, project, or user (platform administrators only)")
+	cmd.PersistentFlags().StringVar(&flags.Name, "scope-name", "", "Name of the tenant to query; the user UID when --scope-kind is user")
+}
+
+// Validate checks that scope flags are valid
+func (f *ScopeFlags) Validate() error {
+	if f.Kind == "" && f.Name == "" {
+		return nil
+	}
+	if f.Kind == "" || f.Name == "" {
+		return fmt.Errorf("--scope-kind and --scope-name must be set together")
+	}
+	if _, ok := scopeKinds[strings.ToLower(f.Kind)]; !ok {
+		return fmt.Errorf("--scope-kind must be one of: organization, project, user")
+	}
+	return nil
+}
+
+// WrapConfig returns a copy of config that sends the scope override headers
+// on every request. The config is returned unchanged when no scope is set.
+func (f *ScopeFlags) WrapConfig(config *rest.Config) (*rest.Config, error) {
+	if err := f.Validate(); err != nil {
+		return nil, err
+	}
+	if f.Kind == "" {
+		return config, nil
+	}
+
+	kind := scopeKinds[strings.ToLower(f.Kind)]
+	name := f.Name
+
+	config = rest.CopyConfig(config)
+	config.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req = req.Clone(req.Context())
+			req.Header.Set(activityv1alpha1.ScopeKindHeader, kind)
+			req.Header.Set(activityv1alpha1.ScopeNameHeader, name)
+			return rt.RoundTrip(req)
+		})
+	})
+	return config, nil
+}
+
+// roundTripperFunc adapts a function to http.RoundTripper
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
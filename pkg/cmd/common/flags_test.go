@@ -1,11 +1,16 @@
 package common
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+
+	activityv1alpha1 "go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
 )
 
 func TestTimeRangeFlags_Validate(t *testing.T) {
@@ -87,6 +92,7 @@ func TestPaginationFlags_Validate(t *testing.T) {
 		limit         int32
 		allPages      bool
 		continueAfter string
+		maxTotal      int
 		wantErr       bool
 		errMsg        string
 	}{
@@ -143,6 +149,21 @@ func TestPaginationFlags_Validate(t *testing.T) {
 			allPages: true,
 			wantErr:  false,
 		},
+		{
+			name:     "max-total unlimited",
+			limit:    25,
+			allPages: true,
+			maxTotal: 0,
+			wantErr:  false,
+		},
+		{
+			name:     "negative max-total",
+			limit:    25,
+			allPages: true,
+			maxTotal: -1,
+			wantErr:  true,
+			errMsg:   "--max-total must be 0 or greater",
+		},
 	}
 
 	for _, tt := range tests {
@@ -151,6 +172,7 @@ func TestPaginationFlags_Validate(t *testing.T) {
 				Limit:         tt.limit,
 				AllPages:      tt.allPages,
 				ContinueAfter: tt.continueAfter,
+				MaxTotal:      tt.maxTotal,
 			}
 
 			err := flags.Validate()
@@ -177,11 +199,28 @@ func TestAddPaginationFlags(t *testing.T) {
 	assert.NotNil(t, cmd.Flags().Lookup("limit"))
 	assert.NotNil(t, cmd.Flags().Lookup("all-pages"))
 	assert.NotNil(t, cmd.Flags().Lookup("continue-after"))
+	assert.NotNil(t, cmd.Flags().Lookup("max-total"))
 
 	// Verify default values
 	assert.Equal(t, int32(50), flags.Limit)
 	assert.False(t, flags.AllPages)
 	assert.Empty(t, flags.ContinueAfter)
+	assert.Equal(t, DefaultMaxTotal, flags.MaxTotal)
+}
+
+func TestPaginationFlags_MaxTotal(t *testing.T) {
+	flags := &PaginationFlags{Limit: 100, MaxTotal: 250}
+
+	assert.Equal(t, int32(100), flags.PageLimit(0))
+	assert.Equal(t, int32(100), flags.PageLimit(150))
+	assert.Equal(t, int32(50), flags.PageLimit(200))
+	assert.False(t, flags.ReachedMaxTotal(200))
+	assert.True(t, flags.ReachedMaxTotal(250))
+
+	// Zero disables the cap
+	flags.MaxTotal = 0
+	assert.Equal(t, int32(100), flags.PageLimit(1000000))
+	assert.False(t, flags.ReachedMaxTotal(1000000))
 }
 
 func TestOutputFlags(t *testing.T) {
@@ -245,3 +284,147 @@ func TestAddSuggestFlags(t *testing.T) {
 	// Verify default value
 	assert.Empty(t, flags.Suggest)
 }
+
+func TestColorFlags_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		color   string
+		noColor bool
+		wantErr bool
+		errMsg  string
+	}{
+		{name: "auto", color: ColorAuto},
+		{name: "always", color: ColorAlways},
+		{name: "never", color: ColorNever},
+		{name: "no-color with auto", color: ColorAuto, noColor: true},
+		{
+			name:    "invalid mode",
+			color:   "sometimes",
+			wantErr: true,
+			errMsg:  "--color must be one of",
+		},
+		{
+			name:    "no-color conflicts with always",
+			color:   ColorAlways,
+			noColor: true,
+			wantErr: true,
+			errMsg:  "mutually exclusive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := &ColorFlags{Color: tt.color, NoColor: tt.noColor}
+
+			err := flags.Validate()
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestColorFlags_Mode(t *testing.T) {
+	assert.Equal(t, ColorAuto, (&ColorFlags{}).Mode())
+	assert.Equal(t, ColorAlways, (&ColorFlags{Color: ColorAlways}).Mode())
+	assert.Equal(t, ColorNever, (&ColorFlags{Color: ColorAuto, NoColor: true}).Mode())
+}
+
+func TestAddColorFlags(t *testing.T) {
+	cmd := &cobra.Command{
+		Use: "test",
+	}
+	flags := &ColorFlags{}
+
+	AddColorFlags(cmd, flags)
+
+	assert.NotNil(t, cmd.Flags().Lookup("color"))
+	assert.NotNil(t, cmd.Flags().Lookup("no-color"))
+
+	assert.Equal(t, ColorAuto, flags.Color)
+	assert.False(t, flags.NoColor)
+}
+
+func TestScopeFlags_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    string
+		scope   string
+		wantErr bool
+		errMsg  string
+	}{
+		{name: "no override"},
+		{name: "project scope", kind: "project", scope: "backend-api"},
+		{name: "kind is case-insensitive", kind: "Organization", scope: "acme-corp"},
+		{name: "kind without name", kind: "project", wantErr: true, errMsg: "must be set together"},
+		{name: "name without kind", scope: "backend-api", wantErr: true, errMsg: "must be set together"},
+		{name: "unknown kind", kind: "cluster", scope: "prod", wantErr: true, errMsg: "--scope-kind must be one of"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := &ScopeFlags{Kind: tt.kind, Name: tt.scope}
+
+			err := flags.Validate()
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestScopeFlags_WrapConfig(t *testing.T) {
+	var gotKind, gotName string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		gotKind = req.Header.Get(activityv1alpha1.ScopeKindHeader)
+		gotName = req.Header.Get(activityv1alpha1.ScopeNameHeader)
+	}))
+	defer server.Close()
+
+	t.Run("headers are sent when scope is set", func(t *testing.T) {
+		flags := &ScopeFlags{Kind: "project", Name: "backend-api"}
+		config, err := flags.WrapConfig(&rest.Config{Host: server.URL})
+		require.NoError(t, err)
+
+		client, err := rest.HTTPClientFor(config)
+		require.NoError(t, err)
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Equal(t, "Project", gotKind)
+		assert.Equal(t, "backend-api", gotName)
+	})
+
+	t.Run("config is unchanged without scope", func(t *testing.T) {
+		original := &rest.Config{Host: server.URL}
+		config, err := (&ScopeFlags{}).WrapConfig(original)
+		require.NoError(t, err)
+		assert.Same(t, original, config)
+	})
+
+	t.Run("invalid flags are rejected", func(t *testing.T) {
+		_, err := (&ScopeFlags{Kind: "project"}).WrapConfig(&rest.Config{Host: server.URL})
+		require.Error(t, err)
+	})
+}
+
+func TestAddScopeFlags(t *testing.T) {
+	cmd := &cobra.Command{
+		Use: "test",
+	}
+	flags := &ScopeFlags{}
+
+	AddScopeFlags(cmd, flags)
+
+	assert.NotNil(t, cmd.PersistentFlags().Lookup("scope-kind"))
+	assert.NotNil(t, cmd.PersistentFlags().Lookup("scope-name"))
+}
@@ -1,12 +1,15 @@
 package common
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"os"
+	"strings"
 
 	"golang.org/x/term"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/cli-runtime/pkg/printers"
 )
@@ -53,6 +56,20 @@ func (p *TablePrinter) PrintAllPagesInfo(totalCount int) {
 	_, _ = fmt.Fprintf(p.IOStreams.ErrOut, "\nShowing %d results.\n", totalCount)
 }
 
+// PrintTruncatedInfo warns that --all-pages stopped at --max-total before
+// reaching the last page
+func (p *TablePrinter) PrintTruncatedInfo(maxTotal int, continueToken string) {
+	_, _ = fmt.Fprintf(p.IOStreams.ErrOut, "\nWarning: results truncated at --max-total=%d. More results are available.\n", maxTotal)
+	_, _ = fmt.Fprintf(p.IOStreams.ErrOut, "Use --continue-after '%s' to resume, or raise --max-total (0 for no limit).\n", continueToken)
+}
+
+// PrintParallelTruncatedInfo warns that a --parallel fetch stopped at
+// --max-total. There is no single cursor to resume from across windows.
+func (p *TablePrinter) PrintParallelTruncatedInfo(maxTotal int) {
+	_, _ = fmt.Fprintf(p.IOStreams.ErrOut, "\nWarning: results truncated at --max-total=%d. More results are available.\n", maxTotal)
+	_, _ = fmt.Fprintf(p.IOStreams.ErrOut, "Narrow the time range, or raise --max-total (0 for no limit).\n")
+}
+
 // SupportsColor checks if the output stream supports ANSI color codes
 func SupportsColor(out io.Writer) bool {
 	// Check if NO_COLOR environment variable is set (universal opt-out)
@@ -82,8 +99,91 @@ func CreatePrinter(printFlags *genericclioptions.PrintFlags) (printers.ResourceP
 
 // IsDefaultOutputFormat checks if using default (table) output
 func IsDefaultOutputFormat(printFlags *genericclioptions.PrintFlags) bool {
-	outputFormat := printFlags.OutputFormat
-	return outputFormat == nil || *outputFormat == ""
+	return effectiveOutputFormat(printFlags) == ""
+}
+
+// RawOutputFormat is the -o value that prints each result's stored JSON
+// verbatim, one per line, instead of re-encoding the decoded result.
+const RawOutputFormat = "raw"
+
+// IsRawOutputFormat checks if using raw output
+func IsRawOutputFormat(printFlags *genericclioptions.PrintFlags) bool {
+	return effectiveOutputFormat(printFlags) == RawOutputFormat
+}
+
+// PrintRaw writes each stored JSON document verbatim on its own line
+func PrintRaw(raw []string, out io.Writer) error {
+	for _, doc := range raw {
+		if _, err := io.WriteString(out, doc); err != nil {
+			return err
+		}
+		if !strings.HasSuffix(doc, "\n") {
+			if _, err := io.WriteString(out, "\n"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// IsTemplateOutputFormat checks if output is rendered with a user-supplied
+// go-template or jsonpath, either via -o or via --template alone.
+func IsTemplateOutputFormat(printFlags *genericclioptions.PrintFlags) bool {
+	outputFormat := effectiveOutputFormat(printFlags)
+	for _, prefix := range []string{"go-template", "template", "jsonpath"} {
+		if strings.HasPrefix(outputFormat, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// effectiveOutputFormat returns the output format, treating --template without
+// -o as go-template the same way PrintFlags.ToPrinter does.
+func effectiveOutputFormat(printFlags *genericclioptions.PrintFlags) string {
+	outputFormat := ""
+	if printFlags.OutputFormat != nil {
+		outputFormat = *printFlags.OutputFormat
+	}
+
+	templateSpecified := printFlags.TemplatePrinterFlags != nil &&
+		printFlags.TemplatePrinterFlags.TemplateArgument != nil &&
+		*printFlags.TemplatePrinterFlags.TemplateArgument != ""
+	outputSpecified := printFlags.OutputFlagSpecified != nil && printFlags.OutputFlagSpecified()
+	if templateSpecified && !outputSpecified {
+		return "go-template"
+	}
+	return outputFormat
+}
+
+// PrintList prints a list of results with the configured printer. Template
+// output is evaluated once per item instead, each on its own line, so
+// templates refer to the item's fields directly (e.g. {{.verb}}) rather than
+// ranging over .items. JSON and YAML print the whole list.
+func PrintList(printFlags *genericclioptions.PrintFlags, list runtime.Object, items []runtime.Object, out io.Writer) error {
+	printer, err := CreatePrinter(printFlags)
+	if err != nil {
+		return fmt.Errorf("failed to create printer: %w", err)
+	}
+
+	if !IsTemplateOutputFormat(printFlags) {
+		return printer.PrintObj(list, out)
+	}
+
+	var buf bytes.Buffer
+	for _, item := range items {
+		buf.Reset()
+		if err := printer.PrintObj(item, &buf); err != nil {
+			return err
+		}
+		if buf.Len() > 0 && buf.Bytes()[buf.Len()-1] != '\n' {
+			buf.WriteByte('\n')
+		}
+		if _, err := out.Write(buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // CreateTablePrinter creates a configured table printer for consistent table output
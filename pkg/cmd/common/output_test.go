@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 func TestNewTablePrinter(t *testing.T) {
@@ -172,6 +173,23 @@ func TestTablePrinter_PrintAllPagesInfo(t *testing.T) {
 	assert.Contains(t, output, "Showing 42 results")
 }
 
+func TestTablePrinter_PrintTruncatedInfo(t *testing.T) {
+	var errBuf bytes.Buffer
+	printFlags := genericclioptions.NewPrintFlags("")
+	ioStreams := genericclioptions.IOStreams{
+		Out:    &bytes.Buffer{},
+		ErrOut: &errBuf,
+		In:     &bytes.Buffer{},
+	}
+
+	printer := NewTablePrinter(printFlags, ioStreams, false)
+	printer.PrintTruncatedInfo(10000, "cursor123")
+
+	output := errBuf.String()
+	assert.Contains(t, output, "results truncated at --max-total=10000")
+	assert.Contains(t, output, "--continue-after 'cursor123'")
+}
+
 func TestSupportsColor(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -281,6 +299,117 @@ func TestIsDefaultOutputFormat(t *testing.T) {
 	}
 }
 
+func TestIsDefaultOutputFormat_TemplateOnly(t *testing.T) {
+	printFlags := genericclioptions.NewPrintFlags("")
+	*printFlags.TemplatePrinterFlags.TemplateArgument = "{{.verb}}"
+
+	assert.False(t, IsDefaultOutputFormat(printFlags))
+	assert.True(t, IsTemplateOutputFormat(printFlags))
+}
+
+func TestIsTemplateOutputFormat(t *testing.T) {
+	tests := []struct {
+		outputFormat string
+		want         bool
+	}{
+		{outputFormat: "", want: false},
+		{outputFormat: "json", want: false},
+		{outputFormat: "yaml", want: false},
+		{outputFormat: "go-template", want: true},
+		{outputFormat: "go-template={{.verb}}", want: true},
+		{outputFormat: "go-template-file=/tmp/t", want: true},
+		{outputFormat: "template={{.verb}}", want: true},
+		{outputFormat: "jsonpath={.verb}", want: true},
+		{outputFormat: "jsonpath-as-json={.verb}", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.outputFormat, func(t *testing.T) {
+			printFlags := genericclioptions.NewPrintFlags("")
+			printFlags.OutputFormat = stringPtr(tt.outputFormat)
+
+			assert.Equal(t, tt.want, IsTemplateOutputFormat(printFlags))
+		})
+	}
+}
+
+func TestIsRawOutputFormat(t *testing.T) {
+	for outputFormat, want := range map[string]bool{"": false, "json": false, "raw": true} {
+		printFlags := genericclioptions.NewPrintFlags("")
+		printFlags.OutputFormat = stringPtr(outputFormat)
+
+		assert.Equal(t, want, IsRawOutputFormat(printFlags), "output format %q", outputFormat)
+	}
+}
+
+func TestPrintRaw(t *testing.T) {
+	var out bytes.Buffer
+	raw := []string{
+		`{"auditID":"a","verb":"get","unknownField":{"kept":true}}`,
+		"{\"auditID\":\"b\"}\n",
+	}
+
+	require.NoError(t, PrintRaw(raw, &out))
+	assert.Equal(t, raw[0]+"\n"+raw[1], out.String())
+}
+
+func TestPrintList(t *testing.T) {
+	items := []runtime.Object{
+		&metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: "a"}},
+		&metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: "b"}},
+	}
+	list := &metav1.PartialObjectMetadataList{
+		TypeMeta: metav1.TypeMeta{Kind: "List", APIVersion: "v1"},
+		Items: []metav1.PartialObjectMetadata{
+			*items[0].(*metav1.PartialObjectMetadata),
+			*items[1].(*metav1.PartialObjectMetadata),
+		},
+	}
+
+	tests := []struct {
+		name         string
+		outputFormat string
+		want         string
+	}{
+		{
+			name:         "go-template evaluated per item",
+			outputFormat: "go-template={{.metadata.name}}",
+			want:         "a\nb\n",
+		},
+		{
+			name:         "go-template newline not doubled",
+			outputFormat: "go-template={{.metadata.name}}{{\"\\n\"}}",
+			want:         "a\nb\n",
+		},
+		{
+			name:         "jsonpath evaluated per item",
+			outputFormat: "jsonpath={.metadata.name}",
+			want:         "a\nb\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			printFlags := genericclioptions.NewPrintFlags("")
+			printFlags.OutputFormat = stringPtr(tt.outputFormat)
+
+			var out bytes.Buffer
+			require.NoError(t, PrintList(printFlags, list, items, &out))
+			assert.Equal(t, tt.want, out.String())
+		})
+	}
+
+	t.Run("json prints the whole list", func(t *testing.T) {
+		printFlags := genericclioptions.NewPrintFlags("")
+		printFlags.OutputFormat = stringPtr("json")
+
+		var out bytes.Buffer
+		require.NoError(t, PrintList(printFlags, list, items, &out))
+		assert.Contains(t, out.String(), `"kind": "List"`)
+		assert.Contains(t, out.String(), `"items": [`)
+	})
+}
+
 func stringPtr(s string) *string {
 	return &s
 }
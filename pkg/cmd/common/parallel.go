@@ -0,0 +1,43 @@
+package common
+
+import "time"
+
+// MaxParallel is the most windows --parallel may split a time range into
+const MaxParallel = 10
+
+// TimeWindow is one contiguous slice of a query's time range
+type TimeWindow struct {
+	Start time.Time
+	End   time.Time
+}
+
+// SplitTimeRange divides [start, end) into n contiguous windows of roughly equal
+// length, newest first. Boundaries are whole seconds so they survive RFC3339
+// formatting, and windows that would be empty are dropped, so ranges shorter
+// than n seconds yield fewer windows. Because the server sorts results newest
+// first, concatenating each window's results in this order gives the same order
+// as querying the whole range.
+func SplitTimeRange(start, end time.Time, n int) []TimeWindow {
+	if n < 1 {
+		n = 1
+	}
+	step := end.Sub(start) / time.Duration(n)
+
+	windows := make([]TimeWindow, 0, n)
+	windowEnd := end
+	for i := n - 1; i >= 0; i-- {
+		windowStart := start
+		if i > 0 {
+			windowStart = start.Add(step * time.Duration(i)).Truncate(time.Second)
+			if !windowStart.After(start) {
+				continue
+			}
+		}
+		if !windowEnd.After(windowStart) {
+			continue
+		}
+		windows = append(windows, TimeWindow{Start: windowStart, End: windowEnd})
+		windowEnd = windowStart
+	}
+	return windows
+}
@@ -0,0 +1,51 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitTimeRange(t *testing.T) {
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)
+
+	t.Run("splits into contiguous windows newest first", func(t *testing.T) {
+		windows := SplitTimeRange(start, end, 4)
+		require.Len(t, windows, 4)
+
+		assert.Equal(t, end, windows[0].End)
+		assert.Equal(t, start, windows[3].Start)
+		for i := range windows {
+			assert.Equal(t, 24*time.Hour, windows[i].End.Sub(windows[i].Start))
+			if i > 0 {
+				assert.Equal(t, windows[i-1].Start, windows[i].End, "window %d should end where window %d starts", i, i-1)
+			}
+		}
+	})
+
+	t.Run("single window covers the whole range", func(t *testing.T) {
+		windows := SplitTimeRange(start, end, 1)
+		assert.Equal(t, []TimeWindow{{Start: start, End: end}}, windows)
+	})
+
+	t.Run("boundaries are whole seconds", func(t *testing.T) {
+		subSecondStart := start.Add(300 * time.Millisecond)
+		windows := SplitTimeRange(subSecondStart, start.Add(10*time.Second), 3)
+		require.Len(t, windows, 3)
+		assert.Equal(t, subSecondStart, windows[2].Start)
+		for _, w := range windows[:2] {
+			assert.Zero(t, w.Start.Nanosecond())
+		}
+	})
+
+	t.Run("short ranges drop empty windows", func(t *testing.T) {
+		windows := SplitTimeRange(start, start.Add(2*time.Second), 10)
+		require.Len(t, windows, 2)
+		assert.Equal(t, start.Add(2*time.Second), windows[0].End)
+		assert.Equal(t, start, windows[1].Start)
+		assert.Equal(t, windows[1].End, windows[0].Start)
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/spf13/cobra"
 	eventsv1 "k8s.io/api/events/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/cli-runtime/pkg/printers"
 	"k8s.io/kubectl/pkg/cmd/util"
@@ -272,6 +273,7 @@ func (o *EventsOptions) runAllPages(ctx context.Context, client *clientset.Clien
 	continueAfter := ""
 	pageNum := 1
 	totalCount := 0
+	truncatedAfter := ""
 
 	isTableOutput := common.IsDefaultOutputFormat(o.PrintFlags)
 	var tablePrinter printers.ResourcePrinter
@@ -289,7 +291,7 @@ func (o *EventsOptions) runAllPages(ctx context.Context, client *clientset.Clien
 				EndTime:       o.TimeRange.EndTime,
 				Namespace:     o.Namespace,
 				FieldSelector: o.buildFieldSelector(),
-				Limit:         o.Pagination.Limit,
+				Limit:         o.Pagination.PageLimit(totalCount),
 				Continue:      continueAfter,
 			},
 		}
@@ -321,23 +323,26 @@ func (o *EventsOptions) runAllPages(ctx context.Context, client *clientset.Clien
 		if result.Status.Continue == "" {
 			break
 		}
+		if o.Pagination.ReachedMaxTotal(totalCount) {
+			truncatedAfter = result.Status.Continue
+			break
+		}
 
 		continueAfter = result.Status.Continue
 		pageNum++
 	}
 
 	if !isTableOutput {
-		printer, err := common.CreatePrinter(o.PrintFlags)
-		if err != nil {
-			return fmt.Errorf("failed to create printer: %w", err)
-		}
-		if err := printEventRecords(allEvents, printer, o.Out); err != nil {
+		if err := printEventRecords(allEvents, o.PrintFlags, o.Out); err != nil {
 			return err
 		}
 	}
 
 	tp := common.NewTablePrinter(o.PrintFlags, o.IOStreams, o.Output.NoHeaders)
 	tp.PrintAllPagesInfo(totalCount)
+	if truncatedAfter != "" {
+		tp.PrintTruncatedInfo(o.Pagination.MaxTotal, truncatedAfter)
+	}
 
 	return nil
 }
@@ -348,12 +353,7 @@ func (o *EventsOptions) printResults(result *activityv1alpha1.EventQuery) error
 		return o.printTable(result.Status.Results, result.Status.Continue)
 	}
 
-	printer, err := common.CreatePrinter(o.PrintFlags)
-	if err != nil {
-		return fmt.Errorf("failed to create printer: %w", err)
-	}
-
-	return printEventRecords(result.Status.Results, printer, o.Out)
+	return printEventRecords(result.Status.Results, o.PrintFlags, o.Out)
 }
 
 // printTable prints events as a formatted table
@@ -423,12 +423,17 @@ func kubeEventsToRows(events []activityv1alpha1.EventRecord) []metav1.TableRow {
 	return rows
 }
 
-// printEventRecords prints EventRecords using the configured printer by extracting
-// the underlying eventsv1.Event list
-func printEventRecords(records []activityv1alpha1.EventRecord, printer printers.ResourcePrinter, out io.Writer) error {
+// printEventRecords prints EventRecords using the configured output format by
+// extracting the underlying eventsv1.Event list. Templates are evaluated per
+// event; see common.PrintList.
+func printEventRecords(records []activityv1alpha1.EventRecord, printFlags *genericclioptions.PrintFlags, out io.Writer) error {
 	items := make([]eventsv1.Event, 0, len(records))
+	objects := make([]runtime.Object, 0, len(records))
 	for i := range records {
 		items = append(items, records[i].Event)
+		event := records[i].Event
+		event.TypeMeta = metav1.TypeMeta{Kind: "Event", APIVersion: "events.k8s.io/v1"}
+		objects = append(objects, &event)
 	}
 	eventList := &eventsv1.EventList{
 		TypeMeta: metav1.TypeMeta{
@@ -437,5 +442,5 @@ func printEventRecords(records []activityv1alpha1.EventRecord, printer printers.
 		},
 		Items: items,
 	}
-	return printer.PrintObj(eventList, out)
+	return common.PrintList(printFlags, eventList, objects, out)
 }
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/rest"
+
+	clientset "go.miloapis.com/activity/pkg/client/clientset/versioned"
+)
+
+func TestExportOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		groupBy []string
+		output  string
+		wantErr string
+	}{
+		{name: "raw export", output: "json"},
+		{name: "grouped json", groupBy: []string{"user", "day"}, output: "json"},
+		{name: "grouped csv", groupBy: []string{"verb", "resource", "namespace"}, output: "csv"},
+		{name: "unknown field", groupBy: []string{"user", "sourceIP"}, output: "json", wantErr: `--group-by field "sourceIP" is not supported`},
+		{name: "csv without group-by", output: "csv", wantErr: "-o csv requires --group-by"},
+		{name: "unknown output", output: "yaml", wantErr: "--output must be json or csv"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := NewExportOptions(nil, genericclioptions.IOStreams{})
+			o.GroupBy = tt.groupBy
+			o.Output = tt.output
+
+			err := o.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestExportOptions_runExport(t *testing.T) {
+	var gotPath string
+	var gotQuery url.Values
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		gotPath, gotQuery = req.URL.Path, req.URL.Query()
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client, err := clientset.NewForConfig(&rest.Config{Host: server.URL})
+	require.NoError(t, err)
+
+	t.Run("raw events are copied through", func(t *testing.T) {
+		body = `{"auditID":"a","verb":"delete"}` + "\n" + `{"auditID":"b","verb":"create"}` + "\n"
+		streams, _, out, _ := genericclioptions.NewTestIOStreams()
+		o := NewExportOptions(nil, streams)
+		o.Filter = "objectRef.namespace == 'production'"
+		o.MutationsOnly = true
+
+		require.NoError(t, o.runExport(t.Context(), client))
+		assert.Equal(t, "/apis/activity.miloapis.com/v1alpha1/auditlogqueries/cli-export/export", gotPath)
+		assert.Equal(t, "now-24h", gotQuery.Get("startTime"))
+		assert.Equal(t, "now", gotQuery.Get("endTime"))
+		assert.Equal(t, "objectRef.namespace == 'production'", gotQuery.Get("filter"))
+		assert.Equal(t, "true", gotQuery.Get("mutationsOnly"))
+		assert.False(t, gotQuery.Has("groupBy"))
+		assert.Equal(t, body, out.String())
+	})
+
+	t.Run("groups are written as CSV", func(t *testing.T) {
+		body = `{"values":{"user":"alice@example.com","day":"2026-10-15"},"count":4}` + "\n" +
+			`{"values":{"user":"bob, jr.","day":"2026-10-15"},"count":1}` + "\n"
+		streams, _, out, _ := genericclioptions.NewTestIOStreams()
+		o := NewExportOptions(nil, streams)
+		o.GroupBy = []string{"user", "day"}
+		o.Output = "csv"
+
+		require.NoError(t, o.runExport(t.Context(), client))
+		assert.Equal(t, "user,day", gotQuery.Get("groupBy"))
+		assert.Equal(t, strings.Join([]string{
+			"user,day,count",
+			"alice@example.com,2026-10-15,4",
+			`"bob, jr.",2026-10-15,1`,
+		}, "\n")+"\n", out.String())
+	})
+
+	t.Run("a status line ends the export with an error", func(t *testing.T) {
+		body = `{"values":{"verb":"get"},"count":9}` + "\n" +
+			`{"kind":"Status","apiVersion":"v1","metadata":{},"status":"Failure","message":"query timed out","reason":"Timeout","code":504}` + "\n"
+		streams, _, out, _ := genericclioptions.NewTestIOStreams()
+		o := NewExportOptions(nil, streams)
+		o.GroupBy = []string{"verb"}
+		o.Output = "csv"
+
+		err := o.runExport(t.Context(), client)
+		require.Error(t, err)
+		assert.Equal(t, "export failed: query timed out", err.Error())
+		assert.Equal(t, "verb,count\nget,9\n", out.String())
+	})
+}
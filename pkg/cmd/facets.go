@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/kubectl/pkg/cmd/util"
+
+	activityv1alpha1 "go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+	clientset "go.miloapis.com/activity/pkg/client/clientset/versioned"
+	"go.miloapis.com/activity/pkg/cmd/common"
+)
+
+// maxFacetFields is the most fields the server accepts in one facet query
+const maxFacetFields = 10
+
+// FacetsOptions contains the options for querying audit log facets
+type FacetsOptions struct {
+	Fields []string
+	Filter string
+	Limit  int32
+	Order  string
+
+	// Common flags
+	TimeRange common.TimeRangeFlags
+
+	PrintFlags *genericclioptions.PrintFlags
+	genericclioptions.IOStreams
+	Factory util.Factory
+}
+
+// NewFacetsOptions creates a new FacetsOptions with default values
+func NewFacetsOptions(f util.Factory, ioStreams genericclioptions.IOStreams) *FacetsOptions {
+	return &FacetsOptions{
+		IOStreams:  ioStreams,
+		Factory:    f,
+		PrintFlags: genericclioptions.NewPrintFlags(""),
+		Limit:      20,
+		TimeRange: common.TimeRangeFlags{
+			StartTime: "now-7d",
+			EndTime:   "now",
+		},
+	}
+}
+
+// NewFacetsCommand creates the facets command
+func NewFacetsCommand(f util.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewFacetsOptions(f, ioStreams)
+
+	cmd := &cobra.Command{
+		Use:   "facets --fields FIELD[,FIELD...]",
+		Short: "Show the most common values of audit log fields",
+		Long: `Show the most common values of audit log fields, with counts.
+
+Each field is listed with its top values, most frequent first. Use it to
+discover which users, verbs, resources, and namespaces appear in the audit
+logs before building a filter, or to see how activity is distributed.
+
+Supported fields:
+  verb, user.username, user.uid, user.groups, responseStatus.code,
+  durationMs, level, objectRef.namespace, objectRef.resource, objectRef.apiGroup,
+  requestURI (path prefixes such as /apis/apps or /healthz), authzDecision,
+  admissionWebhook
+
+Examples:
+  # Top verbs and resource types over the last 7 days
+  activity facets --fields verb,objectRef.resource
+
+  # Top 50 users who deleted something in the last day
+  activity facets --fields user.username --filter "verb == 'delete'" --start-time now-1d --limit 50
+
+  # Every verb, alphabetically, for a stable dropdown
+  activity facets --fields verb --order value_asc --limit 100
+
+  # Which non-resource endpoints are being probed
+  activity facets --fields requestURI,user.username --filter "!has(objectRef.resource)"
+
+  # Who is being denied by RBAC, as opposed to by admission
+  activity facets --fields user.username,objectRef.resource --filter "authzDecision == 'forbid'"
+
+  # Which admission webhooks are rejecting the most requests
+  activity facets --fields admissionWebhook --filter "admissionWebhook != ''"
+
+  # Status codes in numeric order
+  activity facets --fields responseStatus.code --order value_asc
+
+  # Output the facet results as JSON
+  activity facets --fields verb,responseStatus.code -o json
+`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Complete(cmd); err != nil {
+				return err
+			}
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return o.Run(cmd.Context())
+		},
+	}
+
+	// Add flags
+	cmd.Flags().StringSliceVar(&o.Fields, "fields", nil, "Comma-separated audit log fields to get values for (required)")
+	cmd.Flags().StringVar(&o.Filter, "filter", "", "CEL filter expression to narrow the audit logs before counting")
+	cmd.Flags().Int32Var(&o.Limit, "limit", o.Limit, "Maximum number of values per field (1-100)")
+	cmd.Flags().StringVar(&o.Order, "order", string(activityv1alpha1.FacetOrderCount), "Sort values by count (most frequent first), value_asc, or value_desc")
+	common.AddTimeRangeFlags(cmd, &o.TimeRange, "now-7d")
+
+	// Add printer flags
+	o.PrintFlags.AddFlags(cmd)
+
+	return cmd
+}
+
+// Complete fills in missing options
+func (o *FacetsOptions) Complete(cmd *cobra.Command) error {
+	if o.Out == nil {
+		o.Out = os.Stdout
+	}
+	if o.ErrOut == nil {
+		o.ErrOut = os.Stderr
+	}
+	if o.In == nil {
+		o.In = os.Stdin
+	}
+	return nil
+}
+
+// Validate checks that required options are set correctly
+func (o *FacetsOptions) Validate() error {
+	if len(o.Fields) == 0 {
+		return fmt.Errorf("--fields is required")
+	}
+	if len(o.Fields) > maxFacetFields {
+		return fmt.Errorf("--fields accepts at most %d fields", maxFacetFields)
+	}
+	for _, field := range o.Fields {
+		if field == "" {
+			return fmt.Errorf("--fields must not contain empty field names")
+		}
+	}
+	if o.Limit < 1 || o.Limit > 100 {
+		return fmt.Errorf("--limit must be between 1 and 100")
+	}
+	switch activityv1alpha1.FacetOrder(o.Order) {
+	case "", activityv1alpha1.FacetOrderCount, activityv1alpha1.FacetOrderValueAsc, activityv1alpha1.FacetOrderValueDesc:
+	default:
+		return fmt.Errorf("--order must be count, value_asc, or value_desc")
+	}
+	return o.TimeRange.Validate()
+}
+
+// Run executes the facets command
+func (o *FacetsOptions) Run(ctx context.Context) error {
+	config, err := o.Factory.ToRESTConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get kubeconfig: %w", err)
+	}
+
+	client, err := clientset.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("failed to create activity client: %w", err)
+	}
+
+	result, err := client.ActivityV1alpha1().AuditLogFacetsQueries().Create(ctx, o.buildQuery(), metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("facet query failed: %w", err)
+	}
+
+	return o.printResults(result)
+}
+
+// buildQuery creates the facet query for the requested fields. Partial results
+// are requested so one bad field doesn't hide the others.
+func (o *FacetsOptions) buildQuery() *activityv1alpha1.AuditLogFacetsQuery {
+	facets := make([]activityv1alpha1.FacetSpec, len(o.Fields))
+	for i, field := range o.Fields {
+		facets[i] = activityv1alpha1.FacetSpec{Field: field, Limit: o.Limit, Order: activityv1alpha1.FacetOrder(o.Order)}
+	}
+
+	return &activityv1alpha1.AuditLogFacetsQuery{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "facets-",
+		},
+		Spec: activityv1alpha1.AuditLogFacetsQuerySpec{
+			TimeRange: activityv1alpha1.FacetTimeRange{
+				Start: o.TimeRange.StartTime,
+				End:   o.TimeRange.EndTime,
+			},
+			Filter:         o.Filter,
+			Facets:         facets,
+			PartialResults: true,
+		},
+	}
+}
+
+// printResults outputs the facet results in the specified format
+func (o *FacetsOptions) printResults(result *activityv1alpha1.AuditLogFacetsQuery) error {
+	if !common.IsDefaultOutputFormat(o.PrintFlags) {
+		printer, err := common.CreatePrinter(o.PrintFlags)
+		if err != nil {
+			return fmt.Errorf("failed to create printer: %w", err)
+		}
+		result.SetGroupVersionKind(activityv1alpha1.SchemeGroupVersion.WithKind("AuditLogFacetsQuery"))
+		return printer.PrintObj(result, o.Out)
+	}
+
+	for i, facet := range result.Status.Facets {
+		if i > 0 {
+			fmt.Fprintln(o.Out)
+		}
+		if len(facet.Values) == 0 {
+			fmt.Fprintf(o.Out, "No values found for field: %s\n", facet.Field)
+			continue
+		}
+		if err := common.PrintFacetTable(facet, o.Out); err != nil {
+			return err
+		}
+	}
+
+	for _, e := range result.Status.FacetErrors {
+		fmt.Fprintf(o.ErrOut, "Warning: facet %s failed: %s\n", e.Field, e.Message)
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	activityv1alpha1 "go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+)
+
+func TestFacetsOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		fields  []string
+		limit   int32
+		order   string
+		wantErr string
+	}{
+		{name: "valid", fields: []string{"verb", "objectRef.resource"}, limit: 20},
+		{name: "no fields", limit: 20, wantErr: "--fields is required"},
+		{name: "empty field", fields: []string{"verb", ""}, limit: 20, wantErr: "empty field names"},
+		{name: "too many fields", fields: make([]string, 11), limit: 20, wantErr: "at most 10 fields"},
+		{name: "limit too high", fields: []string{"verb"}, limit: 101, wantErr: "--limit must be between 1 and 100"},
+		{name: "value order", fields: []string{"verb"}, limit: 20, order: "value_desc"},
+		{name: "unknown order", fields: []string{"verb"}, limit: 20, order: "alphabetical", wantErr: "--order must be"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := NewFacetsOptions(nil, genericclioptions.IOStreams{})
+			o.Fields = tt.fields
+			o.Limit = tt.limit
+			o.Order = tt.order
+
+			err := o.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestFacetsOptions_buildQuery(t *testing.T) {
+	o := NewFacetsOptions(nil, genericclioptions.IOStreams{})
+	o.Fields = []string{"verb", "objectRef.resource"}
+	o.Filter = "verb == 'delete'"
+	o.Limit = 5
+	o.Order = "value_asc"
+
+	query := o.buildQuery()
+	assert.Equal(t, "now-7d", query.Spec.TimeRange.Start)
+	assert.Equal(t, "verb == 'delete'", query.Spec.Filter)
+	assert.True(t, query.Spec.PartialResults)
+	assert.Equal(t, []activityv1alpha1.FacetSpec{
+		{Field: "verb", Limit: 5, Order: activityv1alpha1.FacetOrderValueAsc},
+		{Field: "objectRef.resource", Limit: 5, Order: activityv1alpha1.FacetOrderValueAsc},
+	}, query.Spec.Facets)
+}
+
+func TestFacetsOptions_printResults(t *testing.T) {
+	result := &activityv1alpha1.AuditLogFacetsQuery{
+		Status: activityv1alpha1.AuditLogFacetsQueryStatus{
+			Facets: []activityv1alpha1.FacetResult{
+				{Field: "verb", Values: []activityv1alpha1.FacetValue{{Value: "get", Count: 120}, {Value: "delete", Count: 3}}},
+				{Field: "objectRef.namespace"},
+			},
+			FacetErrors: []activityv1alpha1.FacetError{{Field: "user.groups", Message: "query timed out"}},
+		},
+	}
+
+	t.Run("table", func(t *testing.T) {
+		streams, _, out, errOut := genericclioptions.NewTestIOStreams()
+		o := NewFacetsOptions(nil, streams)
+
+		require.NoError(t, o.printResults(result.DeepCopy()))
+		assert.Contains(t, out.String(), "FIELD: verb")
+		assert.Contains(t, out.String(), "delete")
+		assert.Contains(t, out.String(), "No values found for field: objectRef.namespace")
+		assert.Contains(t, errOut.String(), "Warning: facet user.groups failed: query timed out")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		streams, _, out, _ := genericclioptions.NewTestIOStreams()
+		o := NewFacetsOptions(nil, streams)
+		output := "json"
+		o.PrintFlags.OutputFormat = &output
+
+		require.NoError(t, o.printResults(result.DeepCopy()))
+		assert.Contains(t, out.String(), `"kind": "AuditLogFacetsQuery"`)
+		assert.Contains(t, out.String(), `"count": 120`)
+	})
+}
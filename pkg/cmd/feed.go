@@ -9,11 +9,13 @@ import (
 
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/cli-runtime/pkg/printers"
 	"k8s.io/kubectl/pkg/cmd/util"
 
 	activityv1alpha1 "go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+	"go.miloapis.com/activity/pkg/celutil"
 	clientset "go.miloapis.com/activity/pkg/client/clientset/versioned"
 	"go.miloapis.com/activity/pkg/cmd/common"
 )
@@ -27,6 +29,7 @@ type FeedOptions struct {
 	Kind         string
 	APIGroup     string
 	ChangeSource string
+	Origin       string
 	Search       string
 	ResourceUID  string
 
@@ -80,6 +83,8 @@ Output Formats:
   table (default): Structured view with timestamp, actor, source, and summary
   summary: Just the summaries, one per line
   json/yaml: Full activity objects
+  go-template/jsonpath: One line per activity, e.g.
+    --template '{{.spec.actor.name}} {{.spec.summary}}'
 
 CEL Filters:
   spec.changeSource       - "human" or "system"
@@ -106,6 +111,9 @@ Examples:
   # Live feed of human changes
   kubectl activity feed --change-source human --watch
 
+  # Activities generated from Kubernetes events rather than API requests
+  kubectl activity feed --origin event
+
   # Production namespace activity
   kubectl activity feed -n production
 
@@ -139,6 +147,7 @@ Examples:
 	cmd.Flags().StringVar(&o.Kind, "kind", "", "Filter by resource kind (Deployment, Pod, etc.)")
 	cmd.Flags().StringVar(&o.APIGroup, "api-group", "", "Filter by API group")
 	cmd.Flags().StringVar(&o.ChangeSource, "change-source", "", "Filter by change source: human, system")
+	cmd.Flags().StringVar(&o.Origin, "origin", "", "Filter by what the activity was generated from: audit (API requests), event (Kubernetes events)")
 	cmd.Flags().StringVar(&o.Search, "search", "", "Full-text search in summaries")
 	cmd.Flags().StringVar(&o.Filter, "filter", "", "CEL filter expression")
 	cmd.Flags().StringVar(&o.ResourceUID, "resource-uid", "", "Get history of specific resource by UID")
@@ -166,6 +175,10 @@ func (o *FeedOptions) Complete(cmd *cobra.Command) error {
 
 // Validate checks that required options are set correctly
 func (o *FeedOptions) Validate() error {
+	if o.Origin != "" && o.Origin != "audit" && o.Origin != "event" {
+		return fmt.Errorf("invalid --origin value %q: must be \"audit\" or \"event\"", o.Origin)
+	}
+
 	if o.Watch {
 		// Watch mode doesn't use time range
 		if o.Filter != "" {
@@ -222,22 +235,25 @@ func (o *FeedOptions) buildFilter() string {
 	var filters []string
 
 	if o.Namespace != "" {
-		filters = append(filters, fmt.Sprintf("spec.resource.namespace == '%s'", common.EscapeCELString(o.Namespace)))
+		filters = append(filters, fmt.Sprintf("spec.resource.namespace == '%s'", celutil.EscapeString(o.Namespace)))
 	}
 	if o.Actor != "" {
-		filters = append(filters, fmt.Sprintf("spec.actor.name == '%s'", common.EscapeCELString(o.Actor)))
+		filters = append(filters, fmt.Sprintf("spec.actor.name == '%s'", celutil.EscapeString(o.Actor)))
 	}
 	if o.Kind != "" {
-		filters = append(filters, fmt.Sprintf("spec.resource.kind == '%s'", common.EscapeCELString(o.Kind)))
+		filters = append(filters, fmt.Sprintf("spec.resource.kind == '%s'", celutil.EscapeString(o.Kind)))
 	}
 	if o.APIGroup != "" {
-		filters = append(filters, fmt.Sprintf("spec.resource.apiGroup == '%s'", common.EscapeCELString(o.APIGroup)))
+		filters = append(filters, fmt.Sprintf("spec.resource.apiGroup == '%s'", celutil.EscapeString(o.APIGroup)))
 	}
 	if o.ChangeSource != "" {
-		filters = append(filters, fmt.Sprintf("spec.changeSource == '%s'", common.EscapeCELString(o.ChangeSource)))
+		filters = append(filters, fmt.Sprintf("spec.changeSource == '%s'", celutil.EscapeString(o.ChangeSource)))
+	}
+	if o.Origin != "" {
+		filters = append(filters, fmt.Sprintf("spec.origin.type == '%s'", celutil.EscapeString(o.Origin)))
 	}
 	if o.ResourceUID != "" {
-		filters = append(filters, fmt.Sprintf("spec.resource.uid == '%s'", common.EscapeCELString(o.ResourceUID)))
+		filters = append(filters, fmt.Sprintf("spec.resource.uid == '%s'", celutil.EscapeString(o.ResourceUID)))
 	}
 
 	combined := strings.Join(filters, " && ")
@@ -287,6 +303,7 @@ func (o *FeedOptions) runAllPages(ctx context.Context, client *clientset.Clients
 	continueAfter := ""
 	pageNum := 1
 	totalCount := 0
+	truncatedAfter := ""
 
 	isTableOutput := common.IsDefaultOutputFormat(o.PrintFlags)
 	var tablePrinter printers.ResourcePrinter
@@ -304,7 +321,7 @@ func (o *FeedOptions) runAllPages(ctx context.Context, client *clientset.Clients
 				EndTime:   o.TimeRange.EndTime,
 				Filter:    o.buildFilter(),
 				Search:    o.Search,
-				Limit:     o.Pagination.Limit,
+				Limit:     o.Pagination.PageLimit(totalCount),
 				Continue:  continueAfter,
 			},
 		}
@@ -336,23 +353,26 @@ func (o *FeedOptions) runAllPages(ctx context.Context, client *clientset.Clients
 		if result.Status.Continue == "" {
 			break
 		}
+		if o.Pagination.ReachedMaxTotal(totalCount) {
+			truncatedAfter = result.Status.Continue
+			break
+		}
 
 		continueAfter = result.Status.Continue
 		pageNum++
 	}
 
 	if !isTableOutput {
-		printer, err := common.CreatePrinter(o.PrintFlags)
-		if err != nil {
-			return fmt.Errorf("failed to create printer: %w", err)
-		}
-		if err := printActivities(allActivities, printer, o.Out); err != nil {
+		if err := printActivities(allActivities, o.PrintFlags, o.Out); err != nil {
 			return err
 		}
 	}
 
 	tp := common.NewTablePrinter(o.PrintFlags, o.IOStreams, o.Output.NoHeaders)
 	tp.PrintAllPagesInfo(totalCount)
+	if truncatedAfter != "" {
+		tp.PrintTruncatedInfo(o.Pagination.MaxTotal, truncatedAfter)
+	}
 
 	return nil
 }
@@ -444,6 +464,11 @@ func (o *FeedOptions) matchesClientSideFilters(activity *activityv1alpha1.Activi
 		return false
 	}
 
+	// Origin filter
+	if o.Origin != "" && activity.Spec.Origin.Type != o.Origin {
+		return false
+	}
+
 	// Full-text search in summary
 	if o.Search != "" && !strings.Contains(strings.ToLower(activity.Spec.Summary), strings.ToLower(o.Search)) {
 		return false
@@ -468,12 +493,7 @@ func (o *FeedOptions) printResults(result *activityv1alpha1.ActivityQuery) error
 		return o.printTable(result.Status.Results, result.Status.Continue)
 	}
 
-	printer, err := common.CreatePrinter(o.PrintFlags)
-	if err != nil {
-		return fmt.Errorf("failed to create printer: %w", err)
-	}
-
-	return printActivities(result.Status.Results, printer, o.Out)
+	return printActivities(result.Status.Results, o.PrintFlags, o.Out)
 }
 
 // printTable prints activities as a formatted table
@@ -541,8 +561,9 @@ func activitiesToRows(activities []activityv1alpha1.Activity) []metav1.TableRow
 	return rows
 }
 
-// printActivities prints activities using the configured printer
-func printActivities(activities []activityv1alpha1.Activity, printer printers.ResourcePrinter, out io.Writer) error {
+// printActivities prints activities using the configured output format.
+// Templates are evaluated per activity; see common.PrintList.
+func printActivities(activities []activityv1alpha1.Activity, printFlags *genericclioptions.PrintFlags, out io.Writer) error {
 	activityList := &activityv1alpha1.ActivityList{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "ActivityList",
@@ -550,5 +571,12 @@ func printActivities(activities []activityv1alpha1.Activity, printer printers.Re
 		},
 		Items: activities,
 	}
-	return printer.PrintObj(activityList, out)
+
+	items := make([]runtime.Object, len(activities))
+	for i := range activities {
+		activity := activities[i]
+		activity.TypeMeta = metav1.TypeMeta{Kind: "Activity", APIVersion: "activity.miloapis.com/v1alpha1"}
+		items[i] = &activity
+	}
+	return common.PrintList(printFlags, activityList, items, out)
 }
@@ -21,6 +21,7 @@ func TestFeedOptions_buildFilter(t *testing.T) {
 		kind         string
 		apiGroup     string
 		changeSource string
+		origin       string
 		resourceUID  string
 		filter       string
 		want         string
@@ -54,6 +55,11 @@ func TestFeedOptions_buildFilter(t *testing.T) {
 			changeSource: "human",
 			want:         "spec.changeSource == 'human'",
 		},
+		{
+			name:   "origin only",
+			origin: "event",
+			want:   "spec.origin.type == 'event'",
+		},
 		{
 			name:        "resource uid only",
 			resourceUID: "uid-123",
@@ -135,6 +141,7 @@ func TestFeedOptions_buildFilter(t *testing.T) {
 				Kind:         tt.kind,
 				APIGroup:     tt.apiGroup,
 				ChangeSource: tt.changeSource,
+				Origin:       tt.origin,
 				ResourceUID:  tt.resourceUID,
 				Filter:       tt.filter,
 			}
@@ -149,6 +156,7 @@ func TestFeedOptions_Validate(t *testing.T) {
 	tests := []struct {
 		name       string
 		watch      bool
+		origin     string
 		timeRange  common.TimeRangeFlags
 		pagination common.PaginationFlags
 		wantErr    bool
@@ -204,12 +212,20 @@ func TestFeedOptions_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "--limit must be between 1 and 1000",
 		},
+		{
+			name:    "invalid origin in watch mode",
+			watch:   true,
+			origin:  "audit_log",
+			wantErr: true,
+			errMsg:  "invalid --origin value",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			o := &FeedOptions{
 				Watch:      tt.watch,
+				Origin:     tt.origin,
 				TimeRange:  tt.timeRange,
 				Pagination: tt.pagination,
 			}
@@ -243,6 +259,16 @@ func TestFeedOptions_matchesClientSideFilters(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name:    "origin filter excludes other origins",
+			options: FeedOptions{Origin: "event"},
+			activity: &activityv1alpha1.Activity{
+				Spec: activityv1alpha1.ActivitySpec{
+					Origin: activityv1alpha1.ActivityOrigin{Type: "audit"},
+				},
+			},
+			want: false,
+		},
 		{
 			name: "api group filter matches",
 			options: FeedOptions{
@@ -6,17 +6,20 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/pmezard/go-difflib/difflib"
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
-	"k8s.io/cli-runtime/pkg/printers"
 	"k8s.io/kubectl/pkg/cmd/util"
 
 	activityv1alpha1 "go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+	"go.miloapis.com/activity/pkg/celutil"
 	clientset "go.miloapis.com/activity/pkg/client/clientset/versioned"
 	"go.miloapis.com/activity/pkg/cmd/common"
 )
@@ -30,13 +33,60 @@ type HistoryOptions struct {
 	ContinueAfter string
 	AllPages      bool
 
+	// AllNamespaces drops the namespace condition so the history covers every
+	// resource with the given name, whichever namespace it lives in.
+	AllNamespaces bool
+
+	// IncludeSubresources also matches requests to the resource's
+	// subresources, such as status or exec. By default only requests to the
+	// resource itself are included.
+	IncludeSubresources bool
+
+	// FollowOwnerReferences also shows the history of resources owned by the
+	// resource, such as the ReplicaSets and Pods of a Deployment. Owned
+	// resources are found through the owner references in captured create
+	// bodies, up to MaxOwnerDepth levels down and MaxOwnedResources in total.
+	FollowOwnerReferences bool
+	MaxOwnerDepth         int
+	MaxOwnedResources     int
+
+	// Verbs overrides the audit verbs included in the history. Defaults to
+	// the verbs that change a resource when empty.
+	Verbs []string
+
+	// Filenames are manifests whose objects' histories are shown instead of
+	// the RESOURCE_TYPE NAME arguments. "-" reads from stdin.
+	Filenames []string
+
+	// Explain prints the filter and resolved time range instead of querying
+	Explain bool
+
+	// Resources are TYPE/NAME pairs whose histories are merged into one
+	// timeline, in addition to any given as arguments
+	Resources []string
+
 	// Common flags
 	TimeRange  common.TimeRangeFlags
 	Pagination common.PaginationFlags
+	Color      common.ColorFlags
 
 	PrintFlags *genericclioptions.PrintFlags
 	genericclioptions.IOStreams
 	Factory util.Factory
+
+	// restMapper resolves the resource type when a query comes back empty, to
+	// tell an unknown type apart from a resource with no recorded changes. Nil
+	// skips the check.
+	restMapper meta.RESTMapper
+
+	// owned holds the resources found with FollowOwnerReferences
+	owned []ownedResource
+
+	// targets holds the objects read from Filenames
+	targets []historyTarget
+
+	// resources holds the TYPE/NAME pairs of a merged timeline
+	resources []historyTarget
 }
 
 // NewHistoryOptions creates a new HistoryOptions with default values
@@ -52,6 +102,11 @@ func NewHistoryOptions(f util.Factory, ioStreams genericclioptions.IOStreams) *H
 		Pagination: common.PaginationFlags{
 			Limit: 100,
 		},
+		Color: common.ColorFlags{
+			Color: common.ColorAuto,
+		},
+		MaxOwnerDepth:     defaultMaxOwnerDepth,
+		MaxOwnedResources: defaultMaxOwnedResources,
 	}
 }
 
@@ -60,7 +115,7 @@ func NewHistoryCommand(f util.Factory, ioStreams genericclioptions.IOStreams) *c
 	o := NewHistoryOptions(f, ioStreams)
 
 	cmd := &cobra.Command{
-		Use:   "history RESOURCE_TYPE NAME",
+		Use:   "history (RESOURCE_TYPE NAME | TYPE/NAME [TYPE/NAME...] | -f FILENAME)",
 		Short: "View the change history of a specific resource",
 		Long: `View the change history of a specific resource over time by querying audit logs.
 
@@ -71,18 +126,52 @@ The command accepts the resource type and name as separate arguments:
   - RESOURCE_TYPE: The type of resource (e.g., domains, dnsrecordsets, configmaps, secrets)
   - NAME: The name of the specific resource instance
 
-Use the -n/--namespace flag for namespaced resources.
+To follow several related resources in one timeline, pass them as TYPE/NAME
+pairs, as arguments or with --resources. Their changes are merged in time
+order and the table gets a resource column; --diff compares each resource's
+versions separately. Up to 20 resources can be merged.
+
+Alternatively, pass a manifest with -f/--filename (or "-" for stdin) and the
+resource type, name, and namespace are read from each object's apiVersion,
+kind, and metadata. Files with several objects, or List objects, show the
+history of each object in turn.
+
+Use the -n/--namespace flag for namespaced resources. Use -A/--all-namespaces
+for cluster-scoped resources or to find a name across every namespace; the
+table output then includes a namespace column.
 
 Examples:
   # View change history of a domain
   activity history domains miloapis-com-0c8dxl -n default
 
+  # One timeline for an app's Deployment, Service and ConfigMap
+  activity history deployments/web services/web configmaps/web-config -n default
+  activity history --resources deployments/web,services/web -n default
+
+  # View change history of the objects in a manifest
+  activity history -f deployment.yaml
+
+  # Read the object from stdin
+  kubectl get configmap app-config -n default -o yaml | activity history -f -
+
   # View change history of a DNS record set
   activity history dnsrecordsets dns-record-www-example-com -n production
 
+  # Find changes to a name in any namespace
+  activity history configmaps app-config -A
+
+  # Include status updates, exec sessions and other subresource requests
+  activity history pods web-0 -n default --include-subresources
+
+  # Include the ReplicaSets and Pods the Deployment created
+  activity history deployments web -n default --follow-owner-references
+
   # View history with diff to see what changed
   activity history configmaps app-config -n default --diff
 
+  # Keep colors when piping the diff into a pager
+  activity history configmaps app-config -n default --diff --color always | less -R
+
   # View changes from the last 7 days
   activity history secrets api-credentials -n default --start-time "now-7d"
 
@@ -93,10 +182,22 @@ Examples:
   activity history configmaps app-settings -n default -o json
   activity history secrets db-password -n default -o yaml
 
+  # Show the filter and absolute time range without querying
+  activity history configmaps app-config -n default --start-time now-7d --explain
+
+  # One line per change with a Go template
+  activity history configmaps app-config -n default --template '{{.user.username}} {{.verb}} {{.objectRef.name}}'
+
 Output Modes:
-  Default (table): Shows a table with timestamp, verb, user, and status code
-  --diff: Shows unified diff between consecutive resource versions
+  Default (table): Shows a table with timestamp, verb, user, and status code,
+    plus the subresource with --include-subresources and the resource with
+    --follow-owner-references or several resources
+  --diff: Shows unified diff between consecutive resource versions, per
+    resource when several are merged
   -o json/yaml: Output raw audit events in JSON or YAML format
+  --template, -o go-template/jsonpath: Render each audit event with a template.
+    Templates are evaluated once per event and use the event's JSON field
+    names (e.g. {{.user.username}}, {.objectRef.name}).
 `,
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -108,12 +209,22 @@ Output Modes:
 			}
 			return o.Run(cmd.Context())
 		},
+		ValidArgsFunction: o.completeArgs,
 	}
 
 	// Add flags
 	common.AddTimeRangeFlags(cmd, &o.TimeRange, "now-30d")
 	common.AddPaginationFlags(cmd, &o.Pagination, 100)
+	cmd.Flags().StringSliceVar(&o.Resources, "resources", nil, fmt.Sprintf("Comma-separated TYPE/NAME pairs to merge into one timeline (at most %d)", maxHistoryResources))
+	cmd.Flags().StringSliceVarP(&o.Filenames, "filename", "f", nil, "Manifest whose objects' history to show, or - for stdin")
 	cmd.Flags().BoolVar(&o.ShowDiff, "diff", false, "Show diff between consecutive resource versions")
+	cmd.Flags().BoolVarP(&o.AllNamespaces, "all-namespaces", "A", false, "Show history for the named resource in all namespaces")
+	cmd.Flags().BoolVar(&o.IncludeSubresources, "include-subresources", false, "Include requests to subresources such as status, scale and exec")
+	cmd.Flags().BoolVar(&o.FollowOwnerReferences, "follow-owner-references", false, "Also show the history of resources owned by this one, found through owner references in captured create requests")
+	cmd.Flags().IntVar(&o.MaxOwnerDepth, "max-owner-depth", o.MaxOwnerDepth, fmt.Sprintf("Levels of owner references to follow with --follow-owner-references (1-%d)", maxOwnerDepthLimit))
+	cmd.Flags().IntVar(&o.MaxOwnedResources, "max-owned-resources", o.MaxOwnedResources, fmt.Sprintf("Maximum number of owned resources to include with --follow-owner-references (1-%d)", maxOwnedResourcesLimit))
+	common.AddColorFlags(cmd, &o.Color)
+	common.AddExplainFlag(cmd, &o.Explain)
 
 	// Add printer flags
 	o.PrintFlags.AddFlags(cmd)
@@ -134,17 +245,30 @@ func (o *HistoryOptions) Complete(cmd *cobra.Command, args []string) error {
 		o.In = os.Stdin
 	}
 
-	// Parse resource type and name from arguments
-	if len(args) != 2 {
-		return fmt.Errorf("exactly two arguments are required: RESOURCE_TYPE NAME")
-	}
+	// Parse resource type and name from arguments, unless they come from
+	// manifests
+	var pairs []string
+	if len(o.Filenames) > 0 {
+		if len(args) != 0 || len(o.Resources) > 0 {
+			return fmt.Errorf("resource arguments and --resources cannot be used with -f/--filename")
+		}
+	} else if len(o.Resources) > 0 || isResourcePairArgs(args) {
+		if len(args) > 0 && !isResourcePairArgs(args) {
+			return fmt.Errorf("arguments must be TYPE/NAME pairs when --resources is used")
+		}
+		pairs = append(append(pairs, args...), o.Resources...)
+	} else {
+		if len(args) != 2 {
+			return fmt.Errorf("exactly two arguments are required: RESOURCE_TYPE NAME, or one or more TYPE/NAME pairs")
+		}
 
-	o.Resource = args[0]
-	o.Name = args[1]
+		o.Resource = args[0]
+		o.Name = args[1]
+	}
 
 	// Get namespace from the factory's namespace flag if available
 	// The -n/--namespace flag is handled by the kubectl factory
-	if o.Factory != nil {
+	if o.Factory != nil && !o.AllNamespaces {
 		namespace, enforceNamespace, err := o.Factory.ToRawKubeConfigLoader().Namespace()
 		if err != nil {
 			return fmt.Errorf("failed to get namespace: %w", err)
@@ -155,6 +279,40 @@ func (o *HistoryOptions) Complete(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if o.Factory != nil {
+		// Discovery is deferred until the mapper is used, so a failure here
+		// only disables the empty-result hints
+		if mapper, err := o.Factory.ToRESTMapper(); err == nil {
+			o.restMapper = mapper
+		}
+	}
+
+	if len(pairs) > 0 {
+		resources, err := parseResourcePairs(pairs, o.Namespace)
+		if err != nil {
+			return err
+		}
+		o.Resource, o.Name = resources[0].Resource, resources[0].Name
+		if len(resources) > 1 {
+			o.resources = resources
+		}
+	}
+
+	if len(o.Filenames) > 0 {
+		if o.AllNamespaces {
+			return fmt.Errorf("--all-namespaces cannot be used with -f/--filename; each object's namespace is used")
+		}
+		if o.restMapper == nil {
+			return fmt.Errorf("cannot resolve resource types for -f/--filename without a connection to the cluster")
+		}
+		targets, err := o.loadTargets(o.restMapper, o.Namespace)
+		if err != nil {
+			return err
+		}
+		o.targets = targets
+		o.Resource, o.Name, o.Namespace = targets[0].Resource, targets[0].Name, targets[0].Namespace
+	}
+
 	return nil
 }
 
@@ -166,18 +324,48 @@ func (o *HistoryOptions) Validate() error {
 	if o.Name == "" {
 		return fmt.Errorf("resource name is required")
 	}
+	if len(o.targets) > 1 && o.Pagination.ContinueAfter != "" {
+		// A continue token belongs to a single object's query
+		return fmt.Errorf("--continue-after cannot be used with a manifest containing several objects")
+	}
+	if o.AllNamespaces && o.ShowDiff {
+		// Consecutive events may belong to different resources that share a name
+		return fmt.Errorf("--diff cannot be used with --all-namespaces; select a namespace with -n")
+	}
+	if o.FollowOwnerReferences {
+		if o.isMerged() {
+			return fmt.Errorf("--follow-owner-references cannot be used with several resources")
+		}
+		if o.ShowDiff {
+			// Consecutive events may belong to different resources
+			return fmt.Errorf("--diff cannot be used with --follow-owner-references")
+		}
+		if o.MaxOwnerDepth < 1 || o.MaxOwnerDepth > maxOwnerDepthLimit {
+			return fmt.Errorf("--max-owner-depth must be between 1 and %d", maxOwnerDepthLimit)
+		}
+		if o.MaxOwnedResources < 1 || o.MaxOwnedResources > maxOwnedResourcesLimit {
+			return fmt.Errorf("--max-owned-resources must be between 1 and %d", maxOwnedResourcesLimit)
+		}
+	}
 	if err := o.TimeRange.Validate(); err != nil {
 		return err
 	}
 	if err := o.Pagination.Validate(); err != nil {
 		return err
 	}
+	if err := o.Color.Validate(); err != nil {
+		return err
+	}
 
 	return nil
 }
 
 // Run executes the history command
 func (o *HistoryOptions) Run(ctx context.Context) error {
+	if o.Explain {
+		return o.explain()
+	}
+
 	// Get REST config from factory
 	config, err := o.Factory.ToRESTConfig()
 	if err != nil {
@@ -190,6 +378,78 @@ func (o *HistoryOptions) Run(ctx context.Context) error {
 		return fmt.Errorf("failed to create activity client: %w", err)
 	}
 
+	if len(o.targets) > 1 {
+		return o.runTargets(ctx, client)
+	}
+
+	return o.runHistory(ctx, client)
+}
+
+// explain prints the filter and time range each history query would use,
+// without contacting the server
+func (o *HistoryOptions) explain() error {
+	targets := o.targets
+	if len(targets) == 0 {
+		targets = []historyTarget{{Resource: o.Resource, Name: o.Name, Namespace: o.Namespace}}
+	}
+
+	now := time.Now()
+	for i, target := range targets {
+		o.Resource, o.Name, o.Namespace = target.Resource, target.Name, target.Namespace
+		if len(targets) > 1 {
+			if i > 0 {
+				fmt.Fprintln(o.Out)
+			}
+			fmt.Fprintf(o.Out, "==> %s <==\n", target)
+		}
+		if err := common.PrintExplain(o.Out, o.buildFilter(), o.TimeRange, now); err != nil {
+			return err
+		}
+	}
+
+	if o.FollowOwnerReferences {
+		// Owned resources are looked up with their own queries when the
+		// command runs, so only the named resource is in the filter above
+		fmt.Fprintln(o.ErrOut, "Note: --follow-owner-references adds the owned resources it finds to the filter when the query runs")
+	}
+	return nil
+}
+
+// runTargets shows the history of each object read from the manifests in turn.
+// Table and diff output get a heading per object; other formats are printed
+// back to back.
+func (o *HistoryOptions) runTargets(ctx context.Context, client *clientset.Clientset) error {
+	headings := common.IsDefaultOutputFormat(o.PrintFlags)
+	for i, target := range o.targets {
+		o.Resource, o.Name, o.Namespace = target.Resource, target.Name, target.Namespace
+		o.owned = nil
+
+		if headings {
+			if i > 0 {
+				fmt.Fprintln(o.Out)
+			}
+			fmt.Fprintf(o.Out, "==> %s <==\n", target)
+		}
+		if err := o.runHistory(ctx, client); err != nil {
+			return fmt.Errorf("%s: %w", target, err)
+		}
+	}
+	return nil
+}
+
+// runHistory shows the history of the current resource
+func (o *HistoryOptions) runHistory(ctx context.Context, client *clientset.Clientset) error {
+	if o.FollowOwnerReferences {
+		owned, truncated, err := o.findOwnedResources(ctx, client)
+		if err != nil {
+			return err
+		}
+		o.owned = owned
+		if truncated {
+			fmt.Fprintf(o.ErrOut, "Warning: stopped following owner references after %d resources; some owned resources are not shown\n", len(owned))
+		}
+	}
+
 	if o.Pagination.AllPages {
 		return o.runAllPages(ctx, client)
 	}
@@ -227,11 +487,11 @@ func (o *HistoryOptions) runAllPages(ctx context.Context, client *clientset.Clie
 	var allEvents []auditv1.Event
 	continueAfter := ""
 	pageNum := 1
+	truncatedAfter := ""
 	filter := o.buildFilter()
 
 	// Check if using custom output format
-	outputFormat := o.PrintFlags.OutputFormat
-	isCustomFormat := outputFormat != nil && *outputFormat != ""
+	isCustomFormat := !common.IsDefaultOutputFormat(o.PrintFlags)
 
 	// For table or diff output, we need all events before processing
 	for {
@@ -243,7 +503,7 @@ func (o *HistoryOptions) runAllPages(ctx context.Context, client *clientset.Clie
 				StartTime: o.TimeRange.StartTime,
 				EndTime:   o.TimeRange.EndTime,
 				Filter:    filter,
-				Limit:     o.Pagination.Limit,
+				Limit:     o.Pagination.PageLimit(len(allEvents)),
 				Continue:  continueAfter,
 			},
 		}
@@ -259,6 +519,10 @@ func (o *HistoryOptions) runAllPages(ctx context.Context, client *clientset.Clie
 		if result.Status.Continue == "" {
 			break
 		}
+		if o.Pagination.ReachedMaxTotal(len(allEvents)) {
+			truncatedAfter = result.Status.Continue
+			break
+		}
 
 		continueAfter = result.Status.Continue
 		pageNum++
@@ -271,30 +535,89 @@ func (o *HistoryOptions) runAllPages(ctx context.Context, client *clientset.Clie
 	}
 
 	// Print results based on output format
+	var err error
 	if isCustomFormat {
-		printer, err := o.PrintFlags.ToPrinter()
-		if err != nil {
-			return fmt.Errorf("failed to create printer: %w", err)
-		}
-		return o.printEvents(allEvents, printer)
+		err = printEvents(allEvents, o.PrintFlags, o.Out)
+	} else if o.ShowDiff && o.isMerged() {
+		err = o.printMergedDiff(allEvents)
 	} else if o.ShowDiff {
-		return o.printDiff(allEvents)
+		err = o.printDiff(allEvents)
 	} else {
-		return o.printTableAllEvents(allEvents)
+		err = o.printTableAllEvents(allEvents)
+	}
+	if err != nil {
+		return err
 	}
+
+	if truncatedAfter != "" {
+		tp := common.NewTablePrinter(o.PrintFlags, o.IOStreams, false)
+		tp.PrintTruncatedInfo(o.Pagination.MaxTotal, truncatedAfter)
+	}
+	return nil
+}
+
+// historyVerbs returns the verbs included in the history: by default those
+// that modify a resource and therefore make up its history
+func (o *HistoryOptions) historyVerbs() []string {
+	if len(o.Verbs) == 0 {
+		return celutil.MutatingVerbs
+	}
+	return o.Verbs
+}
+
+// nameFilter matches requests to the named resource. Collection deletes are
+// recorded without an object name, so when they are included they are matched
+// on resource type and namespace alone.
+func (o *HistoryOptions) nameFilter(name string) string {
+	filter := fmt.Sprintf("objectRef.name == '%s'", celutil.EscapeString(name))
+	for _, verb := range o.historyVerbs() {
+		if verb == "deletecollection" {
+			return fmt.Sprintf("(%s || verb == 'deletecollection')", filter)
+		}
+	}
+	return filter
 }
 
 // buildFilter creates a CEL filter for the specified resource
 func (o *HistoryOptions) buildFilter() string {
+	verbs := o.historyVerbs()
+	quoted := make([]string, len(verbs))
+	for i, verb := range verbs {
+		quoted[i] = fmt.Sprintf("'%s'", celutil.EscapeString(verb))
+	}
+
 	filters := []string{
-		fmt.Sprintf("objectRef.resource == '%s'", common.EscapeCELString(o.Resource)),
-		fmt.Sprintf("objectRef.name == '%s'", common.EscapeCELString(o.Name)),
-		// Only include verbs that modify the resource
-		"verb in ['create', 'update', 'patch', 'delete']",
+		fmt.Sprintf("objectRef.resource == '%s'", celutil.EscapeString(o.Resource)),
+		o.nameFilter(o.Name),
+		fmt.Sprintf("verb in [%s]", strings.Join(quoted, ", ")),
+	}
+
+	if o.Namespace != "" && !o.AllNamespaces {
+		filters = append(filters, fmt.Sprintf("objectRef.namespace == '%s'", celutil.EscapeString(o.Namespace)))
+	}
+
+	if o.FollowOwnerReferences {
+		filters = []string{
+			o.ownedResourcesFilter(),
+			fmt.Sprintf("verb in [%s]", strings.Join(quoted, ", ")),
+		}
+	} else if o.isMerged() {
+		filters = []string{
+			o.mergedResourcesFilter(),
+			fmt.Sprintf("verb in [%s]", strings.Join(quoted, ", ")),
+		}
 	}
 
-	if o.Namespace != "" {
-		filters = append(filters, fmt.Sprintf("objectRef.namespace == '%s'", common.EscapeCELString(o.Namespace)))
+	if !o.IncludeSubresources {
+		// objectRef.resource holds the base resource for subresource requests
+		// too, so they have to be excluded explicitly
+		filters = append(filters, "!has(objectRef.subresource)")
+	}
+
+	if o.ShowDiff {
+		// Only RequestResponse events carry the response object the diff is
+		// built from; skip the rest instead of fetching and ignoring them
+		filters = append(filters, "level == 'RequestResponse'")
 	}
 
 	return strings.Join(filters, " && ")
@@ -310,16 +633,14 @@ func (o *HistoryOptions) printResults(result *activityv1alpha1.AuditLogQuery) er
 	}
 
 	// Check output format
-	outputFormat := o.PrintFlags.OutputFormat
-	if outputFormat != nil && *outputFormat != "" {
-		printer, err := o.PrintFlags.ToPrinter()
-		if err != nil {
-			return fmt.Errorf("failed to create printer: %w", err)
-		}
-		return o.printEvents(events, printer)
+	if !common.IsDefaultOutputFormat(o.PrintFlags) {
+		return printEvents(events, o.PrintFlags, o.Out)
 	}
 
 	if o.ShowDiff {
+		if o.isMerged() {
+			return o.printMergedDiff(events)
+		}
 		return o.printDiff(events)
 	}
 
@@ -328,6 +649,11 @@ func (o *HistoryOptions) printResults(result *activityv1alpha1.AuditLogQuery) er
 
 // printTable prints events as a formatted table
 func (o *HistoryOptions) printTable(events []auditv1.Event, continueToken string) error {
+	if len(events) == 0 && continueToken == "" {
+		o.printNoChanges()
+		return nil
+	}
+
 	table := o.eventsToTable(events)
 	tablePrinter := common.CreateTablePrinter(false)
 
@@ -348,6 +674,11 @@ func (o *HistoryOptions) printTable(events []auditv1.Event, continueToken string
 
 // printTableAllEvents prints all events as a table (for --all-pages)
 func (o *HistoryOptions) printTableAllEvents(events []auditv1.Event) error {
+	if len(events) == 0 {
+		o.printNoChanges()
+		return nil
+	}
+
 	table := o.eventsToTable(events)
 	tablePrinter := common.CreateTablePrinter(false)
 
@@ -362,7 +693,7 @@ func (o *HistoryOptions) printTableAllEvents(events []auditv1.Event) error {
 // printDiff shows the diff between consecutive resource versions
 func (o *HistoryOptions) printDiff(events []auditv1.Event) error {
 	if len(events) == 0 {
-		fmt.Fprintf(o.Out, "No changes found for this resource.\n")
+		o.printNoChanges()
 		return nil
 	}
 
@@ -379,9 +710,10 @@ func (o *HistoryOptions) printDiff(events []auditv1.Event) error {
 		username := event.User.Username
 		verb := event.Verb
 
-		// Get current object state
+		// Get current object state. A collection delete responds with the
+		// list of deleted objects, not this resource.
 		var currObject map[string]interface{}
-		if event.ResponseObject != nil && len(event.ResponseObject.Raw) > 0 {
+		if verb != "deletecollection" && event.ResponseObject != nil && len(event.ResponseObject.Raw) > 0 {
 			if err := json.Unmarshal(event.ResponseObject.Raw, &currObject); err != nil {
 				fmt.Fprintf(o.ErrOut, "Warning: failed to parse response object for event %d: %v\n", i, err)
 				continue
@@ -432,6 +764,14 @@ func (o *HistoryOptions) printDiff(events []auditv1.Event) error {
 			if err := o.printObjectPretty(cleanCurr, useColor); err != nil {
 				fmt.Fprintf(o.ErrOut, "Warning: failed to print object: %v\n", err)
 			}
+		} else if verb == "deletecollection" {
+			// The request deleted every matching resource of the type, which
+			// may or may not have included this one
+			if useColor {
+				fmt.Fprintf(o.Out, "\n\033[31m🗑️  Collection delete of all matching %s\033[0m\n", o.Resource)
+			} else {
+				fmt.Fprintf(o.Out, "\nCollection delete of all matching %s\n", o.Resource)
+			}
 		} else if verb == "delete" && prevObject != nil {
 			if useColor {
 				fmt.Fprintf(o.Out, "\n\033[31m🗑️  Deleted resource\033[0m\n\n")
@@ -460,6 +800,65 @@ func (o *HistoryOptions) printDiff(events []auditv1.Event) error {
 	return nil
 }
 
+// printNoChanges explains an empty history. The resource type is checked
+// against the server's discovery information so a mistyped or singular type is
+// not mistaken for a resource that simply has no changes in the time range.
+func (o *HistoryOptions) printNoChanges() {
+	if o.isMerged() {
+		o.printNoMergedChanges()
+		return
+	}
+
+	where := fmt.Sprintf(" in namespace %q", o.Namespace)
+	if o.AllNamespaces {
+		where = " in any namespace"
+	} else if o.Namespace == "" {
+		where = ""
+	}
+
+	known, plural := o.lookupResourceType()
+	if !known {
+		fmt.Fprintf(o.Out, "No history found: resource type %q is not recognized by the server.\n", o.Resource)
+		fmt.Fprintf(o.ErrOut, "\nCheck the spelling, or run 'kubectl api-resources' to list the available resource types.\n")
+		return
+	}
+
+	fmt.Fprintf(o.Out, "No changes to %s %q%s between %s and %s.\n",
+		o.Resource, o.Name, where, o.TimeRange.StartTime, o.TimeRange.EndTime)
+	if o.ShowDiff {
+		fmt.Fprintf(o.ErrOut, "\n--diff only includes events recorded at the RequestResponse audit level. Run without --diff to list changes recorded at any level.\n")
+	}
+	if plural != "" && plural != o.Resource {
+		// Audit events record the plural resource name, so singular and short
+		// names never match
+		fmt.Fprintf(o.ErrOut, "\nAudit logs record the plural resource name. Did you mean:\n  activity history %s %s\n", plural, o.Name)
+		return
+	}
+	if o.AllNamespaces {
+		fmt.Fprintf(o.ErrOut, "\nCheck the resource name, or widen the time range with --start-time.\n")
+	} else {
+		fmt.Fprintf(o.ErrOut, "\nCheck the resource name and namespace, widen the time range with --start-time, or use -A to search every namespace.\n")
+	}
+}
+
+// lookupResourceType resolves o.Resource with the REST mapper. It reports the
+// type as known, with no plural, when there is no mapper or discovery fails, so
+// hints never claim a type is unknown without the server saying so.
+func (o *HistoryOptions) lookupResourceType() (known bool, plural string) {
+	if o.restMapper == nil {
+		return true, ""
+	}
+
+	gvr, err := o.restMapper.ResourceFor(schema.GroupVersionResource{Resource: strings.ToLower(o.Resource)})
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			return false, ""
+		}
+		return true, ""
+	}
+	return true, gvr.Resource
+}
+
 // printChangeHeader prints a nicely formatted header for each change
 func (o *HistoryOptions) printChangeHeader(changeNum int, timestamp, verb, username string, status *metav1.Status, useColor bool) {
 	if useColor {
@@ -731,8 +1130,16 @@ func (o *HistoryOptions) colorizeDiff(diff string) string {
 	return strings.Join(colorizedLines, "\n")
 }
 
-// supportsColor checks if the output stream supports ANSI color codes
+// supportsColor checks if the output stream supports ANSI color codes.
+// An explicit --color always/never (or --no-color) overrides auto-detection.
 func (o *HistoryOptions) supportsColor() bool {
+	switch o.Color.Mode() {
+	case common.ColorAlways:
+		return true
+	case common.ColorNever:
+		return false
+	}
+
 	// Check if NO_COLOR environment variable is set (universal opt-out)
 	if os.Getenv("NO_COLOR") != "" {
 		return false
@@ -754,32 +1161,42 @@ func (o *HistoryOptions) supportsColor() bool {
 	return term.IsTerminal(int(os.Stdout.Fd()))
 }
 
-// printEvents prints audit events using the configured printer
-func (o *HistoryOptions) printEvents(events []auditv1.Event, printer printers.ResourcePrinter) error {
-	eventList := &auditv1.EventList{
-		TypeMeta: metav1.TypeMeta{
-			Kind:       "EventList",
-			APIVersion: "audit.k8s.io/v1",
-		},
-		Items: events,
-	}
-	return printer.PrintObj(eventList, o.Out)
-}
-
 // eventsToTable converts audit events to a Table object
 func (o *HistoryOptions) eventsToTable(events []auditv1.Event) *metav1.Table {
+	columns := []metav1.TableColumnDefinition{
+		{Name: "Timestamp", Type: "string", Description: "Time of the event"},
+	}
+	if o.FollowOwnerReferences || o.isMerged() {
+		columns = append(columns, metav1.TableColumnDefinition{
+			Name: "Resource", Type: "string", Description: "Resource the request targeted",
+		})
+	}
+	columns = append(columns,
+		metav1.TableColumnDefinition{Name: "Verb", Type: "string", Description: "Action performed"},
+	)
+	if o.IncludeSubresources {
+		columns = append(columns, metav1.TableColumnDefinition{
+			Name: "Subresource", Type: "string", Description: "Subresource the request targeted",
+		})
+	}
+	columns = append(columns,
+		metav1.TableColumnDefinition{Name: "User", Type: "string", Description: "User who performed the action"},
+		metav1.TableColumnDefinition{Name: "Status", Type: "string", Description: "HTTP status code"},
+	)
+	if o.AllNamespaces {
+		// The same name may exist in several namespaces
+		columns = append([]metav1.TableColumnDefinition{
+			{Name: "Namespace", Type: "string", Description: "Namespace of the resource"},
+		}, columns...)
+	}
+
 	return &metav1.Table{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "Table",
 			APIVersion: "meta.k8s.io/v1",
 		},
-		ColumnDefinitions: []metav1.TableColumnDefinition{
-			{Name: "Timestamp", Type: "string", Description: "Time of the event"},
-			{Name: "Verb", Type: "string", Description: "Action performed"},
-			{Name: "User", Type: "string", Description: "User who performed the action"},
-			{Name: "Status", Type: "string", Description: "HTTP status code"},
-		},
-		Rows: o.eventsToRows(events),
+		ColumnDefinitions: columns,
+		Rows:              o.eventsToRows(events),
 	}
 }
 
@@ -802,7 +1219,34 @@ func (o *HistoryOptions) eventsToRows(events []auditv1.Event) []metav1.TableRow
 		}
 
 		row := metav1.TableRow{
-			Cells: []interface{}{timestamp, verb, username, status},
+			Cells: []interface{}{timestamp},
+		}
+		if o.FollowOwnerReferences || o.isMerged() {
+			resource := "<unknown>"
+			if events[i].ObjectRef != nil {
+				resource = events[i].ObjectRef.Resource + "/" + events[i].ObjectRef.Name
+				if events[i].ObjectRef.Name == "" {
+					// A collection delete names no object
+					resource = events[i].ObjectRef.Resource + "/*"
+				}
+			}
+			row.Cells = append(row.Cells, resource)
+		}
+		row.Cells = append(row.Cells, verb)
+		if o.IncludeSubresources {
+			subresource := "<none>"
+			if events[i].ObjectRef != nil && events[i].ObjectRef.Subresource != "" {
+				subresource = events[i].ObjectRef.Subresource
+			}
+			row.Cells = append(row.Cells, subresource)
+		}
+		row.Cells = append(row.Cells, username, status)
+		if o.AllNamespaces {
+			namespace := "<none>"
+			if events[i].ObjectRef != nil && events[i].ObjectRef.Namespace != "" {
+				namespace = events[i].ObjectRef.Namespace
+			}
+			row.Cells = append([]interface{}{namespace}, row.Cells...)
 		}
 		rows = append(rows, row)
 	}
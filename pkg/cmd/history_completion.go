@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	activityv1alpha1 "go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+	"go.miloapis.com/activity/pkg/celutil"
+	clientset "go.miloapis.com/activity/pkg/client/clientset/versioned"
+)
+
+const (
+	// completionLimit caps how many resource types or names are offered. It
+	// is the most values a facet query returns for one field.
+	completionLimit = 100
+
+	// completionTimeout bounds the queries made for one completion, so a slow
+	// server doesn't hang the shell
+	completionTimeout = 5 * time.Second
+)
+
+// completeArgs completes the RESOURCE_TYPE and NAME arguments from recent
+// activity: resource types with changes in the time range, then names of the
+// chosen type that were changed. TYPE/NAME pairs are completed the same way.
+// Any error yields no suggestions rather than a message in the shell.
+func (o *HistoryOptions) completeArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(o.Filenames) > 0 || o.Factory == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	pairs := isResourcePairArgs(args) || strings.Contains(toComplete, "/")
+	if len(args) > 1 && !pairs {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	config, err := o.Factory.ToRESTConfig()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	client, err := clientset.NewForConfig(config)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	if !o.AllNamespaces {
+		if namespace, _, err := o.Factory.ToRawKubeConfigLoader().Namespace(); err == nil {
+			o.Namespace = namespace
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), completionTimeout)
+	defer cancel()
+	return o.completions(ctx, client, args, toComplete)
+}
+
+// completions returns the suggestions for the next argument
+func (o *HistoryOptions) completions(ctx context.Context, client *clientset.Clientset, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	directive := cobra.ShellCompDirectiveNoFileComp
+
+	switch {
+	case strings.Contains(toComplete, "/"):
+		resource, prefix, _ := strings.Cut(toComplete, "/")
+		names, err := o.completeNames(ctx, client, resource)
+		if err != nil {
+			return nil, directive
+		}
+		var suggestions []string
+		for _, name := range filterPrefix(names, prefix) {
+			suggestions = append(suggestions, resource+"/"+name)
+		}
+		return suggestions, directive
+
+	case len(args) == 1 && !isResourcePairArgs(args):
+		names, err := o.completeNames(ctx, client, args[0])
+		if err != nil {
+			return nil, directive
+		}
+		return filterPrefix(names, toComplete), directive
+
+	default:
+		resources, err := o.completeResourceTypes(ctx, client)
+		if err != nil {
+			return nil, directive
+		}
+		resources = filterPrefix(resources, toComplete)
+		if isResourcePairArgs(args) {
+			// Further arguments must be pairs too, so complete to the slash
+			// and leave the cursor there for the name
+			for i := range resources {
+				resources[i] += "/"
+			}
+			directive |= cobra.ShellCompDirectiveNoSpace
+		}
+		return resources, directive
+	}
+}
+
+// completeResourceTypes returns the resource types with changes in the time
+// range, most active first
+func (o *HistoryOptions) completeResourceTypes(ctx context.Context, client *clientset.Clientset) ([]string, error) {
+	query := &activityv1alpha1.AuditLogFacetsQuery{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "history-completion-",
+		},
+		Spec: activityv1alpha1.AuditLogFacetsQuerySpec{
+			TimeRange: activityv1alpha1.FacetTimeRange{
+				Start: o.TimeRange.StartTime,
+				End:   o.TimeRange.EndTime,
+			},
+			Filter: o.completionFilter(""),
+			Facets: []activityv1alpha1.FacetSpec{
+				{Field: "objectRef.resource", Limit: completionLimit},
+			},
+		},
+	}
+
+	result, err := client.ActivityV1alpha1().AuditLogFacetsQueries().Create(ctx, query, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var resources []string
+	for _, facet := range result.Status.Facets {
+		for _, v := range facet.Values {
+			if v.Value != "" {
+				resources = append(resources, v.Value)
+			}
+		}
+	}
+	return resources, nil
+}
+
+// completeNames returns the names of resources of the given type that were
+// changed in the time range, most recently changed first
+func (o *HistoryOptions) completeNames(ctx context.Context, client *clientset.Clientset, resource string) ([]string, error) {
+	query := &activityv1alpha1.AuditLogQuery{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "history-completion-",
+		},
+		Spec: activityv1alpha1.AuditLogQuerySpec{
+			StartTime: o.TimeRange.StartTime,
+			EndTime:   o.TimeRange.EndTime,
+			Filter:    o.completionFilter(resource),
+			Limit:     completionLimit,
+		},
+	}
+
+	result, err := client.ActivityV1alpha1().AuditLogQueries().Create(ctx, query, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	var names []string
+	for _, event := range result.Status.Results {
+		if event.ObjectRef == nil || event.ObjectRef.Name == "" || seen[event.ObjectRef.Name] {
+			continue
+		}
+		seen[event.ObjectRef.Name] = true
+		names = append(names, event.ObjectRef.Name)
+	}
+	return names, nil
+}
+
+// completionFilter matches the changes a history would show, for any resource
+// or for one resource type
+func (o *HistoryOptions) completionFilter(resource string) string {
+	verbs := o.historyVerbs()
+	quoted := make([]string, len(verbs))
+	for i, verb := range verbs {
+		quoted[i] = fmt.Sprintf("'%s'", celutil.EscapeString(verb))
+	}
+
+	filters := []string{fmt.Sprintf("verb in [%s]", strings.Join(quoted, ", "))}
+	if resource != "" {
+		filters = append(filters, fmt.Sprintf("objectRef.resource == '%s'", celutil.EscapeString(resource)))
+	}
+	if o.Namespace != "" && !o.AllNamespaces {
+		filters = append(filters, fmt.Sprintf("objectRef.namespace == '%s'", celutil.EscapeString(o.Namespace)))
+	}
+	if !o.IncludeSubresources {
+		filters = append(filters, "!has(objectRef.subresource)")
+	}
+	return strings.Join(filters, " && ")
+}
+
+// filterPrefix returns the values that start with prefix
+func filterPrefix(values []string, prefix string) []string {
+	var matched []string
+	for _, v := range values {
+		if strings.HasPrefix(v, prefix) {
+			matched = append(matched, v)
+		}
+	}
+	return matched
+}
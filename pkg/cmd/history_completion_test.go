@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+	"k8s.io/client-go/rest"
+
+	activityv1alpha1 "go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+	clientset "go.miloapis.com/activity/pkg/client/clientset/versioned"
+	"go.miloapis.com/activity/pkg/cmd/common"
+)
+
+func TestHistoryOptions_completions(t *testing.T) {
+	var filters []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var body interface{}
+		switch {
+		case strings.HasSuffix(req.URL.Path, "/auditlogfacetsqueries"):
+			var query activityv1alpha1.AuditLogFacetsQuery
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&query))
+			filters = append(filters, query.Spec.Filter)
+			query.Status.Facets = []activityv1alpha1.FacetResult{{
+				Field: "objectRef.resource",
+				Values: []activityv1alpha1.FacetValue{
+					{Value: "configmaps", Count: 12},
+					{Value: "deployments", Count: 5},
+					{Value: "configurations", Count: 1},
+				},
+			}}
+			query.APIVersion, query.Kind = activityv1alpha1.SchemeGroupVersion.String(), "AuditLogFacetsQuery"
+			body = query
+		case strings.HasSuffix(req.URL.Path, "/auditlogqueries"):
+			var query activityv1alpha1.AuditLogQuery
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&query))
+			filters = append(filters, query.Spec.Filter)
+			for _, name := range []string{"app-config", "", "web-config", "app-config", "app-settings"} {
+				query.Status.Results = append(query.Status.Results, auditv1.Event{
+					ObjectRef: &auditv1.ObjectReference{Resource: "configmaps", Name: name},
+				})
+			}
+			query.APIVersion, query.Kind = activityv1alpha1.SchemeGroupVersion.String(), "AuditLogQuery"
+			body = query
+		default:
+			http.NotFound(w, req)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(body))
+	}))
+	defer server.Close()
+
+	client, err := clientset.NewForConfig(&rest.Config{Host: server.URL})
+	require.NoError(t, err)
+
+	o := &HistoryOptions{
+		Namespace: "default",
+		TimeRange: common.TimeRangeFlags{StartTime: "now-30d", EndTime: "now"},
+	}
+	noFiles := cobra.ShellCompDirectiveNoFileComp
+
+	tests := []struct {
+		name          string
+		args          []string
+		toComplete    string
+		want          []string
+		wantDirective cobra.ShellCompDirective
+		wantFilter    string
+	}{
+		{
+			name:          "resource types",
+			toComplete:    "con",
+			want:          []string{"configmaps", "configurations"},
+			wantDirective: noFiles,
+			wantFilter:    "verb in ['create', 'update', 'patch', 'delete', 'deletecollection'] && objectRef.namespace == 'default' && !has(objectRef.subresource)",
+		},
+		{
+			name:          "names of the chosen type, deduplicated",
+			args:          []string{"configmaps"},
+			toComplete:    "app",
+			want:          []string{"app-config", "app-settings"},
+			wantDirective: noFiles,
+			wantFilter:    "verb in ['create', 'update', 'patch', 'delete', 'deletecollection'] && objectRef.resource == 'configmaps' && objectRef.namespace == 'default' && !has(objectRef.subresource)",
+		},
+		{
+			name:          "name in a TYPE/NAME pair",
+			toComplete:    "configmaps/web",
+			want:          []string{"configmaps/web-config"},
+			wantDirective: noFiles,
+		},
+		{
+			name:          "type after a TYPE/NAME pair",
+			args:          []string{"configmaps/app-config"},
+			toComplete:    "dep",
+			want:          []string{"deployments/"},
+			wantDirective: noFiles | cobra.ShellCompDirectiveNoSpace,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filters = nil
+			got, directive := o.completions(t.Context(), client, tt.args, tt.toComplete)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantDirective, directive)
+			if tt.wantFilter != "" {
+				require.Len(t, filters, 1)
+				assert.Equal(t, tt.wantFilter, filters[0])
+			}
+		})
+	}
+
+	t.Run("server errors give no suggestions", func(t *testing.T) {
+		failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		}))
+		defer failing.Close()
+
+		broken, err := clientset.NewForConfig(&rest.Config{Host: failing.URL})
+		require.NoError(t, err)
+		got, directive := o.completions(t.Context(), broken, nil, "")
+		assert.Empty(t, got)
+		assert.Equal(t, noFiles, directive)
+	})
+}
@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// historyTarget is a resource whose history is shown, read from a manifest
+// with -f
+type historyTarget struct {
+	Resource  string
+	Name      string
+	Namespace string
+}
+
+// String returns the target as resource/name, with its namespace if it has one
+func (t historyTarget) String() string {
+	if t.Namespace == "" {
+		return t.Resource + "/" + t.Name
+	}
+	return fmt.Sprintf("%s/%s -n %s", t.Resource, t.Name, t.Namespace)
+}
+
+// readManifest reads a manifest file, or stdin for "-"
+func (o *HistoryOptions) readManifest(path string) ([]byte, error) {
+	if path == "-" {
+		data, err := io.ReadAll(o.In)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read manifest from stdin: %w", err)
+		}
+		return data, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	return data, nil
+}
+
+// loadTargets reads every object in the -f manifests. Objects without a
+// namespace take defaultNamespace if their type is namespaced.
+func (o *HistoryOptions) loadTargets(mapper meta.RESTMapper, defaultNamespace string) ([]historyTarget, error) {
+	var targets []historyTarget
+	for _, path := range o.Filenames {
+		data, err := o.readManifest(path)
+		if err != nil {
+			return nil, err
+		}
+		fileTargets, err := manifestTargets(data, mapper, defaultNamespace)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		targets = append(targets, fileTargets...)
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("no objects found in %v", o.Filenames)
+	}
+	return targets, nil
+}
+
+// manifestTargets converts each object in a YAML or JSON stream to a history
+// target. List objects (kind: List, or any *List kind) contribute their items.
+// Kinds are translated to their plural resource names with mapper, since audit
+// events record the resource rather than the kind.
+func manifestTargets(data []byte, mapper meta.RESTMapper, defaultNamespace string) ([]historyTarget, error) {
+	var targets []historyTarget
+
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+	for {
+		obj := &unstructured.Unstructured{}
+		if err := decoder.Decode(&obj.Object); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("failed to parse manifest: %w", err)
+		}
+		if len(obj.Object) == 0 {
+			// Empty document, e.g. a trailing "---"
+			continue
+		}
+
+		objects := []unstructured.Unstructured{*obj}
+		if obj.IsList() {
+			list, err := obj.ToList()
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s items: %w", obj.GetKind(), err)
+			}
+			objects = list.Items
+		}
+
+		for _, item := range objects {
+			target, err := objectTarget(item, mapper, defaultNamespace)
+			if err != nil {
+				return nil, err
+			}
+			targets = append(targets, target)
+		}
+	}
+
+	return targets, nil
+}
+
+// objectTarget resolves a single object's resource, name, and namespace
+func objectTarget(obj unstructured.Unstructured, mapper meta.RESTMapper, defaultNamespace string) (historyTarget, error) {
+	gvk := obj.GroupVersionKind()
+	if gvk.Kind == "" {
+		return historyTarget{}, fmt.Errorf("object %q has no kind", obj.GetName())
+	}
+	if obj.GetName() == "" {
+		return historyTarget{}, fmt.Errorf("%s object has no metadata.name", gvk.Kind)
+	}
+
+	mapping, err := mapper.RESTMapping(schema.GroupKind{Group: gvk.Group, Kind: gvk.Kind}, gvk.Version)
+	if err != nil {
+		return historyTarget{}, fmt.Errorf("cannot find the resource type for %s %s: %w", gvk.GroupVersion().String(), gvk.Kind, err)
+	}
+
+	target := historyTarget{
+		Resource: mapping.Resource.Resource,
+		Name:     obj.GetName(),
+	}
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		target.Namespace = obj.GetNamespace()
+		if target.Namespace == "" {
+			target.Namespace = defaultNamespace
+		}
+	}
+	return target, nil
+}
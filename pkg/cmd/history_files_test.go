@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func manifestTestMapper() meta.RESTMapper {
+	core := schema.GroupVersion{Version: "v1"}
+	apps := schema.GroupVersion{Group: "apps", Version: "v1"}
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{core, apps})
+	mapper.Add(core.WithKind("ConfigMap"), meta.RESTScopeNamespace)
+	mapper.Add(core.WithKind("Namespace"), meta.RESTScopeRoot)
+	mapper.Add(apps.WithKind("Deployment"), meta.RESTScopeNamespace)
+	return mapper
+}
+
+func TestManifestTargets(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		want     []historyTarget
+		wantErr  string
+	}{
+		{
+			name: "single object",
+			manifest: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: production
+`,
+			want: []historyTarget{{Resource: "deployments", Name: "web", Namespace: "production"}},
+		},
+		{
+			name: "defaults the namespace of namespaced objects only",
+			manifest: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app-config
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: production
+---
+`,
+			want: []historyTarget{
+				{Resource: "configmaps", Name: "app-config", Namespace: "default"},
+				{Resource: "namespaces", Name: "production"},
+			},
+		},
+		{
+			name: "list objects are expanded",
+			manifest: `{"apiVersion": "v1", "kind": "List", "items": [
+  {"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "a", "namespace": "team-a"}},
+  {"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "b", "namespace": "team-b"}}
+]}`,
+			want: []historyTarget{
+				{Resource: "configmaps", Name: "a", Namespace: "team-a"},
+				{Resource: "deployments", Name: "b", Namespace: "team-b"},
+			},
+		},
+		{
+			name: "unknown kind",
+			manifest: `apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: w
+`,
+			wantErr: "cannot find the resource type for example.com/v1 Widget",
+		},
+		{
+			name: "missing name",
+			manifest: `apiVersion: v1
+kind: ConfigMap
+metadata: {}
+`,
+			wantErr: "ConfigMap object has no metadata.name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := manifestTargets([]byte(tt.manifest), manifestTestMapper(), "default")
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestHistoryOptions_loadTargets(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "deployment.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: web\n"), 0o600))
+
+	streams, in, _, _ := genericclioptions.NewTestIOStreams()
+	in.WriteString("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: app-config\n  namespace: staging\n")
+
+	o := NewHistoryOptions(nil, streams)
+	o.Filenames = []string{path, "-"}
+
+	got, err := o.loadTargets(manifestTestMapper(), "production")
+	require.NoError(t, err)
+	assert.Equal(t, []historyTarget{
+		{Resource: "deployments", Name: "web", Namespace: "production"},
+		{Resource: "configmaps", Name: "app-config", Namespace: "staging"},
+	}, got)
+
+	o.Filenames = []string{"-"}
+	_, err = o.loadTargets(manifestTestMapper(), "production")
+	assert.ErrorContains(t, err, "no objects found")
+}
+
+func TestHistoryOptions_Complete_Filenames(t *testing.T) {
+	streams, _, _, _ := genericclioptions.NewTestIOStreams()
+
+	o := NewHistoryOptions(nil, streams)
+	o.Filenames = []string{"-"}
+	err := o.Complete(nil, []string{"configmaps", "app-config"})
+	assert.ErrorContains(t, err, "cannot be used with -f/--filename")
+
+	o = NewHistoryOptions(nil, streams)
+	o.Filenames = []string{"-"}
+	err = o.Complete(nil, nil)
+	assert.ErrorContains(t, err, "without a connection to the cluster")
+}
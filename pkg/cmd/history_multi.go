@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+
+	"go.miloapis.com/activity/pkg/celutil"
+)
+
+// maxHistoryResources bounds how many resources one merged timeline covers, so
+// the OR filter stays a reasonable size for the server
+const maxHistoryResources = 20
+
+// parseResourcePairs parses TYPE/NAME pairs, such as deployments/app, into
+// history targets in namespace
+func parseResourcePairs(pairs []string, namespace string) ([]historyTarget, error) {
+	targets := make([]historyTarget, 0, len(pairs))
+	seen := make(map[historyTarget]bool, len(pairs))
+	for _, pair := range pairs {
+		resource, name, ok := strings.Cut(pair, "/")
+		if !ok || resource == "" || name == "" || strings.Contains(name, "/") {
+			return nil, fmt.Errorf("invalid resource %q: expected TYPE/NAME, e.g. deployments/app", pair)
+		}
+		target := historyTarget{Resource: resource, Name: name, Namespace: namespace}
+		if seen[target] {
+			continue
+		}
+		seen[target] = true
+		targets = append(targets, target)
+	}
+	if len(targets) > maxHistoryResources {
+		return nil, fmt.Errorf("at most %d resources can be shown in one timeline, got %d", maxHistoryResources, len(targets))
+	}
+	return targets, nil
+}
+
+// isResourcePairArgs reports whether the positional arguments are TYPE/NAME
+// pairs rather than the RESOURCE_TYPE NAME form
+func isResourcePairArgs(args []string) bool {
+	for _, arg := range args {
+		if !strings.Contains(arg, "/") {
+			return false
+		}
+	}
+	return len(args) > 0
+}
+
+// isMerged reports whether the history is a merged timeline of several
+// resources
+func (o *HistoryOptions) isMerged() bool {
+	return len(o.resources) > 1
+}
+
+// mergedResourcesFilter matches requests to any of the merged resources
+func (o *HistoryOptions) mergedResourcesFilter() string {
+	clauses := make([]string, len(o.resources))
+	for i, r := range o.resources {
+		clause := []string{
+			fmt.Sprintf("objectRef.resource == '%s'", celutil.EscapeString(r.Resource)),
+			o.nameFilter(r.Name),
+		}
+		if r.Namespace != "" && !o.AllNamespaces {
+			clause = append(clause, fmt.Sprintf("objectRef.namespace == '%s'", celutil.EscapeString(r.Namespace)))
+		}
+		clauses[i] = "(" + strings.Join(clause, " && ") + ")"
+	}
+	return "(" + strings.Join(clauses, " || ") + ")"
+}
+
+// printMergedDiff diffs each merged resource's versions separately, since
+// consecutive events in the merged timeline usually belong to different
+// resources. Events must be oldest first.
+func (o *HistoryOptions) printMergedDiff(events []auditv1.Event) error {
+	for i, r := range o.resources {
+		if i > 0 {
+			fmt.Fprintln(o.Out)
+		}
+		fmt.Fprintf(o.Out, "==> %s <==\n", r)
+
+		var resourceEvents []auditv1.Event
+		for _, event := range events {
+			if o.targetMatches(r, event) {
+				resourceEvents = append(resourceEvents, event)
+			}
+		}
+		if len(resourceEvents) == 0 {
+			fmt.Fprintf(o.Out, "No changes between %s and %s.\n", o.TimeRange.StartTime, o.TimeRange.EndTime)
+			continue
+		}
+		if err := o.printDiff(resourceEvents); err != nil {
+			return fmt.Errorf("%s: %w", r, err)
+		}
+	}
+	return nil
+}
+
+// targetMatches reports whether event is a request to r. Without a namespace
+// r matches its name in any namespace, as the filter does.
+func (o *HistoryOptions) targetMatches(r historyTarget, event auditv1.Event) bool {
+	ref := event.ObjectRef
+	if ref == nil || ref.Resource != r.Resource {
+		return false
+	}
+	// A collection delete names no object but may have removed this one
+	if ref.Name != r.Name && !(ref.Name == "" && event.Verb == "deletecollection") {
+		return false
+	}
+	return r.Namespace == "" || o.AllNamespaces || ref.Namespace == r.Namespace
+}
+
+// printNoMergedChanges explains an empty merged timeline
+func (o *HistoryOptions) printNoMergedChanges() {
+	names := make([]string, len(o.resources))
+	for i, r := range o.resources {
+		names[i] = r.Resource + "/" + r.Name
+	}
+
+	where := fmt.Sprintf(" in namespace %q", o.Namespace)
+	if o.AllNamespaces {
+		where = " in any namespace"
+	} else if o.Namespace == "" {
+		where = ""
+	}
+
+	fmt.Fprintf(o.Out, "No changes to %s%s between %s and %s.\n",
+		strings.Join(names, ", "), where, o.TimeRange.StartTime, o.TimeRange.EndTime)
+	fmt.Fprintf(o.ErrOut, "\nCheck the resource types and names, which audit logs record in plural form, or widen the time range with --start-time.\n")
+}
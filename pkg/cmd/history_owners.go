@@ -0,0 +1,282 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+
+	activityv1alpha1 "go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+	"go.miloapis.com/activity/pkg/celutil"
+	clientset "go.miloapis.com/activity/pkg/client/clientset/versioned"
+)
+
+const (
+	// defaultMaxOwnerDepth follows a Deployment down to its Pods
+	defaultMaxOwnerDepth = 2
+	maxOwnerDepthLimit   = 5
+
+	defaultMaxOwnedResources = 25
+	maxOwnedResourcesLimit   = 100
+
+	// ownerScanLimit bounds how many create events are read to find owned
+	// resources, and ownerScanPageSize is the page size used to read them.
+	ownerScanLimit    = 2000
+	ownerScanPageSize = 500
+)
+
+// ownedResource is a resource found by following owner references from the
+// resource whose history is shown.
+type ownedResource struct {
+	Resource  string
+	Namespace string
+	Name      string
+	Kind      string
+	UID       types.UID
+	Depth     int
+
+	ownerRefs []metav1.OwnerReference
+}
+
+// capturedObject is the part of a captured request or response body needed to
+// follow owner references.
+type capturedObject struct {
+	Kind     string `json:"kind"`
+	Metadata struct {
+		Name            string                  `json:"name"`
+		Namespace       string                  `json:"namespace"`
+		UID             types.UID               `json:"uid"`
+		OwnerReferences []metav1.OwnerReference `json:"ownerReferences"`
+	} `json:"metadata"`
+}
+
+// ownerScanFilter matches the create events whose captured bodies are searched
+// for owner references. Only RequestResponse events carry the response
+// object, which has the generated name and UID of the new resource.
+func (o *HistoryOptions) ownerScanFilter() string {
+	filters := []string{
+		"verb == 'create'",
+		"!has(objectRef.subresource)",
+		"level == 'RequestResponse'",
+	}
+	if o.Namespace != "" && !o.AllNamespaces {
+		filters = append(filters, fmt.Sprintf("objectRef.namespace == '%s'", celutil.EscapeString(o.Namespace)))
+	}
+	return strings.Join(filters, " && ")
+}
+
+// findOwnedResources reads recent create events and returns the resources
+// owned, directly or through intermediate owners, by the resource whose
+// history is shown. The boolean reports whether the scan or traversal hit a
+// limit, in which case some owned resources may be missing.
+func (o *HistoryOptions) findOwnedResources(ctx context.Context, client *clientset.Clientset) ([]ownedResource, bool, error) {
+	var events []auditv1.Event
+	continueAfter := ""
+	truncated := false
+	for {
+		query := &activityv1alpha1.AuditLogQuery{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "history-owners-",
+			},
+			Spec: activityv1alpha1.AuditLogQuerySpec{
+				StartTime: o.TimeRange.StartTime,
+				EndTime:   o.TimeRange.EndTime,
+				Filter:    o.ownerScanFilter(),
+				Limit:     ownerScanPageSize,
+				Continue:  continueAfter,
+			},
+		}
+
+		result, err := client.ActivityV1alpha1().AuditLogQueries().Create(ctx, query, metav1.CreateOptions{})
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to search for owned resources: %w", err)
+		}
+		events = append(events, result.Status.Results...)
+
+		if result.Status.Continue == "" {
+			break
+		}
+		if len(events) >= ownerScanLimit {
+			truncated = true
+			break
+		}
+		continueAfter = result.Status.Continue
+	}
+
+	owned, limited := o.resolveOwnedResources(events, o.rootKind())
+	return owned, truncated || limited, nil
+}
+
+// rootKind returns the kind of the resource whose history is shown, used to
+// match owner references when the resource's UID isn't known. Empty when the
+// resource type can't be resolved.
+func (o *HistoryOptions) rootKind() string {
+	if o.restMapper == nil {
+		return ""
+	}
+	gvk, err := o.restMapper.KindFor(schema.GroupVersionResource{Resource: o.Resource})
+	if err != nil {
+		return ""
+	}
+	return gvk.Kind
+}
+
+// resolveOwnedResources walks owner references breadth-first from the
+// resource whose history is shown, through at most MaxOwnerDepth levels and
+// MaxOwnedResources resources. The boolean reports whether the resource limit
+// cut the walk short.
+func (o *HistoryOptions) resolveOwnedResources(events []auditv1.Event, rootKind string) ([]ownedResource, bool) {
+	candidates := make([]ownedResource, 0, len(events))
+	seen := map[string]bool{}
+	root := ownedResource{Resource: o.Resource, Name: o.Name, Namespace: o.Namespace, Kind: rootKind}
+	if o.AllNamespaces {
+		root.Namespace = ""
+	}
+
+	for i := range events {
+		candidate, ok := capturedResource(&events[i])
+		if !ok {
+			continue
+		}
+		// A resource recreated under the same name is a different object, so
+		// candidates are told apart by UID where one was captured
+		key := candidate.Resource + "/" + candidate.Namespace + "/" + candidate.Name + "/" + string(candidate.UID)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		if candidate.Resource == root.Resource && candidate.Name == root.Name &&
+			(root.Namespace == "" || candidate.Namespace == root.Namespace) {
+			if root.UID == "" {
+				root.UID = candidate.UID
+			}
+			if root.Kind == "" {
+				root.Kind = candidate.Kind
+			}
+			continue
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	maxDepth := o.MaxOwnerDepth
+	if maxDepth <= 0 {
+		maxDepth = defaultMaxOwnerDepth
+	}
+	maxOwned := o.MaxOwnedResources
+	if maxOwned <= 0 {
+		maxOwned = defaultMaxOwnedResources
+	}
+
+	var owned []ownedResource
+	found := make([]bool, len(candidates))
+	parents := []ownedResource{root}
+	for depth := 1; depth <= maxDepth && len(parents) > 0; depth++ {
+		var next []ownedResource
+		for i, candidate := range candidates {
+			if found[i] || !ownedByAny(candidate, parents) {
+				continue
+			}
+			if len(owned) == maxOwned {
+				return owned, true
+			}
+			found[i] = true
+			candidate.Depth = depth
+			owned = append(owned, candidate)
+			next = append(next, candidate)
+		}
+		parents = next
+	}
+	return owned, false
+}
+
+// capturedResource reads the created resource from a create event's captured
+// body. Resources created with generateName only have their name in the
+// response, so the response is preferred over the request.
+func capturedResource(event *auditv1.Event) (ownedResource, bool) {
+	if event.ObjectRef == nil {
+		return ownedResource{}, false
+	}
+	body := event.ResponseObject
+	if body == nil || len(body.Raw) == 0 {
+		body = event.RequestObject
+	}
+	if body == nil || len(body.Raw) == 0 {
+		return ownedResource{}, false
+	}
+
+	var obj capturedObject
+	if err := json.Unmarshal(body.Raw, &obj); err != nil {
+		return ownedResource{}, false
+	}
+
+	resource := ownedResource{
+		Resource:  event.ObjectRef.Resource,
+		Namespace: event.ObjectRef.Namespace,
+		Name:      obj.Metadata.Name,
+		Kind:      obj.Kind,
+		UID:       obj.Metadata.UID,
+		ownerRefs: obj.Metadata.OwnerReferences,
+	}
+	if resource.Namespace == "" {
+		resource.Namespace = obj.Metadata.Namespace
+	}
+	if resource.Name == "" {
+		resource.Name = event.ObjectRef.Name
+	}
+	if resource.Name == "" {
+		return ownedResource{}, false
+	}
+	return resource, true
+}
+
+// ownedByAny reports whether child has an owner reference to one of parents.
+// References are matched by UID; when a parent's UID is unknown, by kind and
+// name within the parent's namespace.
+func ownedByAny(child ownedResource, parents []ownedResource) bool {
+	for _, ref := range child.ownerRefs {
+		for _, parent := range parents {
+			if parent.UID != "" {
+				if ref.UID == parent.UID {
+					return true
+				}
+				continue
+			}
+			if parent.Kind != "" && ref.Kind == parent.Kind && ref.Name == parent.Name &&
+				(parent.Namespace == "" || child.Namespace == parent.Namespace) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ownedResourcesFilter matches requests to the resource whose history is shown
+// or any of the owned resources.
+func (o *HistoryOptions) ownedResourcesFilter() string {
+	root := []string{
+		fmt.Sprintf("objectRef.resource == '%s'", celutil.EscapeString(o.Resource)),
+		o.nameFilter(o.Name),
+	}
+	if o.Namespace != "" && !o.AllNamespaces {
+		root = append(root, fmt.Sprintf("objectRef.namespace == '%s'", celutil.EscapeString(o.Namespace)))
+	}
+
+	clauses := []string{"(" + strings.Join(root, " && ") + ")"}
+	for _, owned := range o.owned {
+		clause := []string{
+			fmt.Sprintf("objectRef.resource == '%s'", celutil.EscapeString(owned.Resource)),
+			o.nameFilter(owned.Name),
+		}
+		if owned.Namespace != "" {
+			clause = append(clause, fmt.Sprintf("objectRef.namespace == '%s'", celutil.EscapeString(owned.Namespace)))
+		}
+		clauses = append(clauses, "("+strings.Join(clause, " && ")+")")
+	}
+	return "(" + strings.Join(clauses, " || ") + ")"
+}
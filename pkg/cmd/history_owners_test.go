@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authnv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// createEvent builds a RequestResponse create event whose response body has
+// the given identity and owner.
+func createEvent(t *testing.T, resource, kind, name string, uid types.UID, owner *metav1.OwnerReference) auditv1.Event {
+	t.Helper()
+	meta := map[string]interface{}{"name": name, "namespace": "default", "uid": uid}
+	if owner != nil {
+		meta["ownerReferences"] = []metav1.OwnerReference{*owner}
+	}
+	body, err := json.Marshal(map[string]interface{}{"kind": kind, "metadata": meta})
+	require.NoError(t, err)
+
+	return auditv1.Event{
+		Verb:           "create",
+		ObjectRef:      &auditv1.ObjectReference{Resource: resource, Namespace: "default"},
+		ResponseObject: &runtime.Unknown{Raw: body},
+	}
+}
+
+func ownerRef(kind, name string, uid types.UID) *metav1.OwnerReference {
+	return &metav1.OwnerReference{Kind: kind, Name: name, UID: uid}
+}
+
+func TestHistoryOptions_resolveOwnedResources(t *testing.T) {
+	events := []auditv1.Event{
+		createEvent(t, "deployments", "Deployment", "web", "dep-1", nil),
+		createEvent(t, "replicasets", "ReplicaSet", "web-7d4b", "rs-1", ownerRef("Deployment", "web", "dep-1")),
+		createEvent(t, "pods", "Pod", "web-7d4b-abcde", "pod-1", ownerRef("ReplicaSet", "web-7d4b", "rs-1")),
+		createEvent(t, "pods", "Pod", "web-7d4b-fghij", "pod-2", ownerRef("ReplicaSet", "web-7d4b", "rs-1")),
+		// Owned by a different Deployment that happens to share the name
+		createEvent(t, "replicasets", "ReplicaSet", "web-old", "rs-2", ownerRef("Deployment", "web", "dep-0")),
+		createEvent(t, "pods", "Pod", "api-0", "pod-3", ownerRef("StatefulSet", "api", "sts-1")),
+	}
+
+	t.Run("follows owners down to the depth limit", func(t *testing.T) {
+		o := &HistoryOptions{Resource: "deployments", Name: "web", Namespace: "default", MaxOwnerDepth: 2, MaxOwnedResources: 25}
+		owned, truncated := o.resolveOwnedResources(events, "")
+		assert.False(t, truncated)
+
+		var names []string
+		for _, r := range owned {
+			names = append(names, r.Name)
+		}
+		assert.Equal(t, []string{"web-7d4b", "web-7d4b-abcde", "web-7d4b-fghij"}, names)
+		assert.Equal(t, 1, owned[0].Depth)
+		assert.Equal(t, 2, owned[1].Depth)
+	})
+
+	t.Run("depth one stops at direct children", func(t *testing.T) {
+		o := &HistoryOptions{Resource: "deployments", Name: "web", Namespace: "default", MaxOwnerDepth: 1, MaxOwnedResources: 25}
+		owned, _ := o.resolveOwnedResources(events, "")
+		require.Len(t, owned, 1)
+		assert.Equal(t, "replicasets", owned[0].Resource)
+	})
+
+	t.Run("resource limit truncates the walk", func(t *testing.T) {
+		o := &HistoryOptions{Resource: "deployments", Name: "web", Namespace: "default", MaxOwnerDepth: 2, MaxOwnedResources: 2}
+		owned, truncated := o.resolveOwnedResources(events, "")
+		assert.True(t, truncated)
+		assert.Len(t, owned, 2)
+	})
+
+	t.Run("matches by kind and name when the root was created before the window", func(t *testing.T) {
+		o := &HistoryOptions{Resource: "deployments", Name: "web", Namespace: "default", MaxOwnerDepth: 1, MaxOwnedResources: 25}
+		owned, _ := o.resolveOwnedResources(events[1:], "Deployment")
+
+		var names []string
+		for _, r := range owned {
+			names = append(names, r.Name)
+		}
+		assert.ElementsMatch(t, []string{"web-7d4b", "web-old"}, names)
+	})
+
+	t.Run("unknown root kind and UID finds nothing", func(t *testing.T) {
+		o := &HistoryOptions{Resource: "deployments", Name: "web", Namespace: "default", MaxOwnerDepth: 2, MaxOwnedResources: 25}
+		owned, _ := o.resolveOwnedResources(events[1:], "")
+		assert.Empty(t, owned)
+	})
+}
+
+func TestHistoryOptions_buildFilter_FollowOwnerReferences(t *testing.T) {
+	o := &HistoryOptions{Resource: "deployments", Name: "web", Namespace: "default", FollowOwnerReferences: true}
+	o.owned = []ownedResource{
+		{Resource: "replicasets", Name: "web-7d4b", Namespace: "default"},
+		{Resource: "pods", Name: "web-7d4b-abcde", Namespace: "default"},
+	}
+
+	assert.Equal(t,
+		"((objectRef.resource == 'deployments' && (objectRef.name == 'web' || verb == 'deletecollection') && objectRef.namespace == 'default') || "+
+			"(objectRef.resource == 'replicasets' && (objectRef.name == 'web-7d4b' || verb == 'deletecollection') && objectRef.namespace == 'default') || "+
+			"(objectRef.resource == 'pods' && (objectRef.name == 'web-7d4b-abcde' || verb == 'deletecollection') && objectRef.namespace == 'default')) && "+
+			"verb in ['create', 'update', 'patch', 'delete', 'deletecollection'] && !has(objectRef.subresource)",
+		o.buildFilter())
+}
+
+func TestHistoryOptions_Validate_FollowOwnerReferences(t *testing.T) {
+	o := NewHistoryOptions(nil, genericclioptions.IOStreams{})
+	o.Resource = "deployments"
+	o.Name = "web"
+	o.FollowOwnerReferences = true
+	require.NoError(t, o.Validate())
+
+	o.ShowDiff = true
+	err := o.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--follow-owner-references")
+
+	o.ShowDiff = false
+	o.MaxOwnerDepth = 6
+	err = o.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--max-owner-depth")
+}
+
+func TestHistoryOptions_eventsToTable_FollowOwnerReferences(t *testing.T) {
+	ts := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	events := []auditv1.Event{
+		{
+			Verb:           "create",
+			User:           authnv1.UserInfo{Username: "system:serviceaccount:kube-system:replicaset-controller"},
+			ObjectRef:      &auditv1.ObjectReference{Resource: "pods", Namespace: "default", Name: "web-7d4b-abcde"},
+			StageTimestamp: metav1.NewMicroTime(ts),
+			ResponseStatus: &metav1.Status{Code: 201},
+		},
+	}
+
+	o := &HistoryOptions{FollowOwnerReferences: true}
+	table := o.eventsToTable(events)
+	require.Len(t, table.ColumnDefinitions, 5)
+	assert.Equal(t, "Resource", table.ColumnDefinitions[1].Name)
+	assert.Equal(t, []interface{}{"2026-10-01 09:00:00", "pods/web-7d4b-abcde", "create", "system:serviceaccount:kube-system:replicaset-controller", "201"}, table.Rows[0].Cells)
+}
@@ -0,0 +1,306 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authnv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"go.miloapis.com/activity/pkg/cmd/common"
+)
+
+func TestHistoryOptions_buildFilter_AllNamespaces(t *testing.T) {
+	o := &HistoryOptions{Resource: "configmaps", Name: "app-config", Namespace: "default", AllNamespaces: true}
+
+	assert.Equal(t,
+		"objectRef.resource == 'configmaps' && (objectRef.name == 'app-config' || verb == 'deletecollection') && verb in ['create', 'update', 'patch', 'delete', 'deletecollection'] && !has(objectRef.subresource)",
+		o.buildFilter())
+}
+
+func TestHistoryOptions_buildFilter_IncludeSubresources(t *testing.T) {
+	o := &HistoryOptions{Resource: "pods", Name: "web-0", Namespace: "default", IncludeSubresources: true}
+
+	assert.Equal(t,
+		"objectRef.resource == 'pods' && (objectRef.name == 'web-0' || verb == 'deletecollection') && verb in ['create', 'update', 'patch', 'delete', 'deletecollection'] && objectRef.namespace == 'default'",
+		o.buildFilter())
+}
+
+func TestHistoryOptions_buildFilter_Diff(t *testing.T) {
+	o := &HistoryOptions{Resource: "configmaps", Name: "app-config", Namespace: "default", ShowDiff: true}
+
+	assert.Equal(t,
+		"objectRef.resource == 'configmaps' && (objectRef.name == 'app-config' || verb == 'deletecollection') && verb in ['create', 'update', 'patch', 'delete', 'deletecollection'] && objectRef.namespace == 'default' && !has(objectRef.subresource) && level == 'RequestResponse'",
+		o.buildFilter())
+}
+
+func TestHistoryOptions_Run_Explain(t *testing.T) {
+	streams, _, out, _ := genericclioptions.NewTestIOStreams()
+	o := NewHistoryOptions(nil, streams)
+	o.Resource, o.Name, o.Namespace = "configmaps", "app-config", "default"
+	o.TimeRange = common.TimeRangeFlags{StartTime: "2026-01-01T00:00:00Z", EndTime: "2026-01-02T00:00:00Z"}
+	o.Explain = true
+
+	// A nil factory would fail if Run tried to reach the server
+	require.NoError(t, o.Run(t.Context()))
+	assert.Equal(t,
+		"Filter:     "+o.buildFilter()+"\n"+
+			"Start time: 2026-01-01T00:00:00Z\n"+
+			"End time:   2026-01-02T00:00:00Z\n",
+		out.String())
+}
+
+func TestHistoryOptions_Validate_AllNamespacesDiff(t *testing.T) {
+	o := &HistoryOptions{Resource: "configmaps", Name: "app-config", AllNamespaces: true, ShowDiff: true}
+
+	err := o.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--all-namespaces")
+}
+
+func TestHistoryOptions_eventsToTable_AllNamespaces(t *testing.T) {
+	ts := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	events := []auditv1.Event{
+		{
+			Verb:           "update",
+			User:           authnv1.UserInfo{Username: "alice@example.com"},
+			ObjectRef:      &auditv1.ObjectReference{Resource: "configmaps", Namespace: "staging", Name: "app-config"},
+			StageTimestamp: metav1.NewMicroTime(ts),
+			ResponseStatus: &metav1.Status{Code: 200},
+		},
+		{
+			Verb:           "create",
+			User:           authnv1.UserInfo{Username: "bob@example.com"},
+			ObjectRef:      &auditv1.ObjectReference{Resource: "domains", Name: "app-config"},
+			StageTimestamp: metav1.NewMicroTime(ts),
+			ResponseStatus: &metav1.Status{Code: 201},
+		},
+	}
+
+	o := &HistoryOptions{}
+	table := o.eventsToTable(events)
+	require.Len(t, table.ColumnDefinitions, 4)
+	assert.Equal(t, []interface{}{"2026-10-01 09:00:00", "update", "alice@example.com", "200"}, table.Rows[0].Cells)
+
+	o.AllNamespaces = true
+	table = o.eventsToTable(events)
+	require.Len(t, table.ColumnDefinitions, 5)
+	assert.Equal(t, "Namespace", table.ColumnDefinitions[0].Name)
+	assert.Equal(t, []interface{}{"staging", "2026-10-01 09:00:00", "update", "alice@example.com", "200"}, table.Rows[0].Cells)
+	assert.Equal(t, []interface{}{"<none>", "2026-10-01 09:00:00", "create", "bob@example.com", "201"}, table.Rows[1].Cells)
+}
+
+func TestHistoryOptions_eventsToTable_IncludeSubresources(t *testing.T) {
+	ts := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	events := []auditv1.Event{
+		{
+			Verb:           "patch",
+			User:           authnv1.UserInfo{Username: "system:node:node-1"},
+			ObjectRef:      &auditv1.ObjectReference{Resource: "pods", Subresource: "status", Namespace: "default", Name: "web-0"},
+			StageTimestamp: metav1.NewMicroTime(ts),
+			ResponseStatus: &metav1.Status{Code: 200},
+		},
+		{
+			Verb:           "update",
+			User:           authnv1.UserInfo{Username: "alice@example.com"},
+			ObjectRef:      &auditv1.ObjectReference{Resource: "pods", Namespace: "default", Name: "web-0"},
+			StageTimestamp: metav1.NewMicroTime(ts),
+			ResponseStatus: &metav1.Status{Code: 200},
+		},
+	}
+
+	o := &HistoryOptions{IncludeSubresources: true}
+	table := o.eventsToTable(events)
+	require.Len(t, table.ColumnDefinitions, 5)
+	assert.Equal(t, "Subresource", table.ColumnDefinitions[2].Name)
+	assert.Equal(t, []interface{}{"2026-10-01 09:00:00", "patch", "status", "system:node:node-1", "200"}, table.Rows[0].Cells)
+	assert.Equal(t, []interface{}{"2026-10-01 09:00:00", "update", "<none>", "alice@example.com", "200"}, table.Rows[1].Cells)
+}
+
+func TestHistoryOptions_printNoChanges(t *testing.T) {
+	gv := schema.GroupVersion{Group: "networking.datumapis.com", Version: "v1alpha"}
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{gv})
+	mapper.Add(gv.WithKind("Domain"), meta.RESTScopeNamespace)
+
+	tests := []struct {
+		name       string
+		resource   string
+		mapper     meta.RESTMapper
+		wantOut    string
+		wantErrOut string
+	}{
+		{
+			name:       "known type with no changes",
+			resource:   "domains",
+			mapper:     mapper,
+			wantOut:    `No changes to domains "example-com" in namespace "default" between now-30d and now.`,
+			wantErrOut: "Check the resource name and namespace",
+		},
+		{
+			name:       "unrecognized type",
+			resource:   "domainz",
+			mapper:     mapper,
+			wantOut:    `resource type "domainz" is not recognized by the server`,
+			wantErrOut: "kubectl api-resources",
+		},
+		{
+			name:       "singular type",
+			resource:   "domain",
+			mapper:     mapper,
+			wantOut:    `No changes to domain "example-com"`,
+			wantErrOut: "activity history domains example-com",
+		},
+		{
+			name:       "no mapper",
+			resource:   "domainz",
+			wantOut:    `No changes to domainz "example-com" in namespace "default"`,
+			wantErrOut: "Check the resource name and namespace",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams, _, out, errOut := genericclioptions.NewTestIOStreams()
+			o := NewHistoryOptions(nil, streams)
+			o.Resource = tt.resource
+			o.Name = "example-com"
+			o.Namespace = "default"
+			o.restMapper = tt.mapper
+
+			require.NoError(t, o.printTable(nil, ""))
+			assert.Contains(t, out.String(), tt.wantOut)
+			assert.Contains(t, errOut.String(), tt.wantErrOut)
+		})
+	}
+}
+
+func TestHistoryOptions_printDiff_NoChanges(t *testing.T) {
+	streams, _, out, _ := genericclioptions.NewTestIOStreams()
+	o := NewHistoryOptions(nil, streams)
+	o.Resource = "configmaps"
+	o.Name = "app-config"
+	o.AllNamespaces = true
+	o.Color.Color = common.ColorNever
+
+	require.NoError(t, o.printDiff(nil))
+	assert.Equal(t, "No changes to configmaps \"app-config\" in any namespace between now-30d and now.\n", out.String())
+}
+
+func TestHistoryOptions_Complete_ResourcePairs(t *testing.T) {
+	streams, _, _, _ := genericclioptions.NewTestIOStreams()
+
+	o := NewHistoryOptions(nil, streams)
+	o.Resources = []string{"services/web", "deployments/web"}
+	require.NoError(t, o.Complete(nil, []string{"deployments/web", "configmaps/web-config"}))
+	assert.Equal(t, []historyTarget{
+		{Resource: "deployments", Name: "web"},
+		{Resource: "configmaps", Name: "web-config"},
+		{Resource: "services", Name: "web"},
+	}, o.resources)
+	assert.Equal(t, "deployments", o.Resource)
+
+	// A single pair is the same as RESOURCE_TYPE NAME
+	o = NewHistoryOptions(nil, streams)
+	require.NoError(t, o.Complete(nil, []string{"configmaps/app-config"}))
+	assert.False(t, o.isMerged())
+	assert.Equal(t, "configmaps", o.Resource)
+	assert.Equal(t, "app-config", o.Name)
+
+	o = NewHistoryOptions(nil, streams)
+	assert.ErrorContains(t, o.Complete(nil, []string{"deployments/"}), "expected TYPE/NAME")
+
+	o = NewHistoryOptions(nil, streams)
+	o.Resources = []string{"services/web"}
+	assert.ErrorContains(t, o.Complete(nil, []string{"deployments", "web"}), "must be TYPE/NAME pairs")
+
+	pairs := make([]string, maxHistoryResources+1)
+	for i := range pairs {
+		pairs[i] = fmt.Sprintf("configmaps/cm-%d", i)
+	}
+	o = NewHistoryOptions(nil, streams)
+	assert.ErrorContains(t, o.Complete(nil, pairs), "at most 20 resources")
+}
+
+func TestHistoryOptions_buildFilter_Merged(t *testing.T) {
+	o := &HistoryOptions{
+		Resource: "deployments", Name: "web", Namespace: "default",
+		resources: []historyTarget{
+			{Resource: "deployments", Name: "web", Namespace: "default"},
+			{Resource: "services", Name: "web", Namespace: "default"},
+		},
+	}
+
+	assert.Equal(t,
+		"((objectRef.resource == 'deployments' && (objectRef.name == 'web' || verb == 'deletecollection') && objectRef.namespace == 'default') || "+
+			"(objectRef.resource == 'services' && (objectRef.name == 'web' || verb == 'deletecollection') && objectRef.namespace == 'default')) && "+
+			"verb in ['create', 'update', 'patch', 'delete', 'deletecollection'] && !has(objectRef.subresource)",
+		o.buildFilter())
+}
+
+func TestHistoryOptions_printMergedDiff(t *testing.T) {
+	streams, _, out, _ := genericclioptions.NewTestIOStreams()
+	o := NewHistoryOptions(nil, streams)
+	o.Namespace = "default"
+	o.resources = []historyTarget{
+		{Resource: "deployments", Name: "web", Namespace: "default"},
+		{Resource: "services", Name: "web", Namespace: "default"},
+	}
+
+	body := func(replicas int) *runtime.Unknown {
+		return &runtime.Unknown{Raw: []byte(fmt.Sprintf(`{"kind":"Deployment","spec":{"replicas":%d}}`, replicas))}
+	}
+	ref := &auditv1.ObjectReference{Resource: "deployments", Name: "web", Namespace: "default"}
+	events := []auditv1.Event{
+		{Verb: "create", ObjectRef: ref, ResponseObject: body(1)},
+		{Verb: "update", ObjectRef: &auditv1.ObjectReference{Resource: "configmaps", Name: "other", Namespace: "default"}, ResponseObject: body(9)},
+		{Verb: "update", ObjectRef: ref, ResponseObject: body(3)},
+	}
+
+	require.NoError(t, o.printMergedDiff(events))
+	got := out.String()
+	assert.Contains(t, got, "==> deployments/web -n default <==")
+	assert.Contains(t, got, "-    \"replicas\": 1")
+	assert.Contains(t, got, "+    \"replicas\": 3")
+	assert.NotContains(t, got, "9")
+	assert.Contains(t, got, "==> services/web -n default <==\nNo changes between now-30d and now.")
+}
+
+func TestHistoryOptions_CollectionDelete(t *testing.T) {
+	streams, _, out, _ := genericclioptions.NewTestIOStreams()
+	o := NewHistoryOptions(nil, streams)
+	o.Resource, o.Name, o.Namespace = "deployments", "web", "default"
+	o.resources = []historyTarget{
+		{Resource: "deployments", Name: "web", Namespace: "default"},
+		{Resource: "services", Name: "web", Namespace: "default"},
+	}
+
+	collection := auditv1.Event{
+		Verb:           "deletecollection",
+		ObjectRef:      &auditv1.ObjectReference{Resource: "deployments", Namespace: "default"},
+		ResponseObject: &runtime.Unknown{Raw: []byte(`{"kind":"DeploymentList","items":[]}`)},
+	}
+	assert.True(t, o.targetMatches(o.resources[0], collection))
+	assert.False(t, o.targetMatches(o.resources[1], collection))
+
+	rows := o.eventsToRows([]auditv1.Event{collection})
+	require.Len(t, rows, 1)
+	assert.Equal(t, "deployments/*", rows[0].Cells[1])
+
+	created := auditv1.Event{
+		Verb:           "create",
+		ObjectRef:      &auditv1.ObjectReference{Resource: "deployments", Name: "web", Namespace: "default"},
+		ResponseObject: &runtime.Unknown{Raw: []byte(`{"kind":"Deployment","spec":{"replicas":1}}`)},
+	}
+	require.NoError(t, o.printDiff([]auditv1.Event{created, collection}))
+	got := out.String()
+	assert.Contains(t, got, "Collection delete of all matching deployments")
+	assert.NotContains(t, got, "DeploymentList")
+}
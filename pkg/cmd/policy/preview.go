@@ -369,6 +369,7 @@ func NewPolicyCommand(f util.Factory, ioStreams genericclioptions.IOStreams) *co
 	}
 
 	cmd.AddCommand(NewPreviewCommand(f, ioStreams))
+	cmd.AddCommand(NewValidateCommand(ioStreams))
 
 	return cmd
 }
@@ -0,0 +1,379 @@
+package policy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	policyregistry "go.miloapis.com/activity/internal/registry/activity/policy"
+	"go.miloapis.com/activity/pkg/apis/activity"
+	activityv1alpha1 "go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+)
+
+// kindPattern matches a well-formed Kubernetes kind (e.g. Deployment, HTTPProxy).
+var kindPattern = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+
+// ValidateOptions contains the options for validating policy files
+type ValidateOptions struct {
+	// File paths ("-" reads from stdin)
+	PolicyFiles []string
+
+	genericclioptions.IOStreams
+}
+
+// NewValidateOptions creates a new ValidateOptions with default values
+func NewValidateOptions(ioStreams genericclioptions.IOStreams) *ValidateOptions {
+	return &ValidateOptions{
+		IOStreams: ioStreams,
+	}
+}
+
+// NewValidateCommand creates the policy validate command
+func NewValidateCommand(ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewValidateOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:   "validate -f <policy-file> [flags]",
+		Short: "Check ActivityPolicy files for errors without a cluster",
+		Long: `Check ActivityPolicy files for errors without connecting to a cluster.
+
+Every ActivityPolicy document in the given files is parsed and checked:
+  - unknown or misspelled fields
+  - the same validation the API server runs on create: every rule has a
+    unique, valid name, and every match and summary expression compiles
+    with the CEL environment the activity processor uses
+  - lint checks the API server doesn't run, reported with a "lint:" prefix:
+    apiVersion, metadata.name, and a well-formed spec.resource.apiGroup and
+    spec.resource.kind
+
+Problems are reported with the file and line they were found on. The command
+exits non-zero if any policy has errors or lint findings, so it can gate CI
+pipelines. Warnings the API server would return, such as a policy with no
+rules, are printed but don't fail validation.
+
+Examples:
+  # Validate a single policy
+  kubectl activity policy validate -f policy.yaml
+
+  # Validate several files, each of which may hold multiple documents
+  kubectl activity policy validate -f deployments.yaml -f services.yaml
+
+  # Validate policies from stdin
+  cat policies/*.yaml | kubectl activity policy validate -f -
+`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return o.Run()
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&o.PolicyFiles, "file", "f", nil, "Path to ActivityPolicy YAML file, or - for stdin (required, repeatable)")
+
+	cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+// Validate checks that required options are set correctly
+func (o *ValidateOptions) Validate() error {
+	if len(o.PolicyFiles) == 0 {
+		return fmt.Errorf("--file is required")
+	}
+	return nil
+}
+
+// Run validates every policy in the given files
+func (o *ValidateOptions) Run() error {
+	failed := 0
+	for _, path := range o.PolicyFiles {
+		data, err := o.readFile(path)
+		if err != nil {
+			return err
+		}
+		if !o.validateFile(path, data) {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d policy file(s) failed validation", failed, len(o.PolicyFiles))
+	}
+	return nil
+}
+
+// validateFile validates and reports on every policy in one file. It returns
+// false if the file can't be parsed, holds no policies, or any policy is
+// invalid.
+func (o *ValidateOptions) validateFile(path string, data []byte) bool {
+	results, err := validatePolicyDocuments(data)
+	if err != nil {
+		fmt.Fprintf(o.ErrOut, "%s: %v\n", path, err)
+		return false
+	}
+
+	valid := true
+	policies := 0
+	for _, result := range results {
+		if result.Kind != "ActivityPolicy" {
+			fmt.Fprintf(o.Out, "%s: skipping %s %q, not an ActivityPolicy\n", path, result.Kind, result.Name)
+			continue
+		}
+		policies++
+		errs := 0
+		for _, problem := range result.Problems {
+			if problem.Warning {
+				fmt.Fprintf(o.ErrOut, "%s:%d: warning: %s\n", path, problem.Line, problem.Message)
+				continue
+			}
+			errs++
+			fmt.Fprintf(o.ErrOut, "%s:%d: %s\n", path, problem.Line, problem.Message)
+		}
+		if errs == 0 {
+			fmt.Fprintf(o.Out, "%s: ActivityPolicy %q is valid\n", path, result.Name)
+			continue
+		}
+		valid = false
+	}
+	if policies == 0 {
+		fmt.Fprintf(o.ErrOut, "%s: no ActivityPolicy documents found\n", path)
+		return false
+	}
+	return valid
+}
+
+// readFile reads a policy file, or stdin for "-"
+func (o *ValidateOptions) readFile(path string) ([]byte, error) {
+	if path == "-" {
+		data, err := io.ReadAll(o.In)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read policies from stdin: %w", err)
+		}
+		return data, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %w", err)
+	}
+	return data, nil
+}
+
+// policyValidationResult holds the problems found in one YAML document. Only
+// documents of kind ActivityPolicy are validated.
+type policyValidationResult struct {
+	Kind     string
+	Name     string
+	Problems []policyProblem
+}
+
+// policyProblem is a single validation problem and the line it was found on.
+// Warnings are reported but don't make the policy invalid.
+type policyProblem struct {
+	Line    int
+	Message string
+	Warning bool
+}
+
+// validatePolicyDocuments validates every document in a YAML stream. It
+// returns an error only if the stream itself can't be parsed.
+func validatePolicyDocuments(data []byte) ([]policyValidationResult, error) {
+	var results []policyValidationResult
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var doc yaml.Node
+		if err := decoder.Decode(&doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("failed to parse YAML: %w", err)
+		}
+		if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+			// Empty document, e.g. a trailing "---"
+			continue
+		}
+		root := doc.Content[0]
+		if kind := mappingValue(root, "kind"); kind == nil || kind.Value != "ActivityPolicy" {
+			// Policy files often sit next to other manifests; only
+			// ActivityPolicy documents are validated.
+			result := policyValidationResult{}
+			if kind != nil {
+				result.Kind = kind.Value
+			}
+			if name := mappingValue(mappingValue(root, "metadata"), "name"); name != nil {
+				result.Name = name.Value
+			}
+			results = append(results, result)
+			continue
+		}
+		results = append(results, validatePolicyDocument(root))
+	}
+
+	return results, nil
+}
+
+// validatePolicyDocument validates a single ActivityPolicy YAML document
+func validatePolicyDocument(root *yaml.Node) policyValidationResult {
+	result := policyValidationResult{Kind: "ActivityPolicy"}
+	addProblem := func(line int, format string, args ...any) {
+		result.Problems = append(result.Problems, policyProblem{Line: line, Message: fmt.Sprintf(format, args...)})
+	}
+
+	var raw map[string]any
+	if err := root.Decode(&raw); err != nil {
+		addProblem(root.Line, "failed to decode document: %v", err)
+		return result
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		addProblem(root.Line, "failed to decode document: %v", err)
+		return result
+	}
+
+	// Decode strictly so misspelled fields (e.g. "auditRule") are reported
+	// instead of silently producing a policy without rules.
+	var policy activityv1alpha1.ActivityPolicy
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&policy); err != nil {
+		line := root.Line
+		if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+			if unquoted, err := strconv.Unquote(field); err == nil {
+				line = lineForKey(root, unquoted, line)
+			}
+		}
+		addProblem(line, "%s", strings.TrimPrefix(err.Error(), "json: "))
+		return result
+	}
+
+	result.Name = policy.Name
+	allErrs, warnings := validatePolicy(&policy)
+	for _, fieldErr := range allErrs {
+		addProblem(lineForField(root, fieldErr.Field), "%s: %s", fieldErr.Field, fieldErr.ErrorBody())
+	}
+	for _, fieldErr := range lintPolicy(&policy) {
+		addProblem(lineForField(root, fieldErr.Field), "lint: %s: %s", fieldErr.Field, fieldErr.ErrorBody())
+	}
+	for _, msg := range warnings {
+		result.Problems = append(result.Problems, policyProblem{Line: lineForKey(root, "spec", root.Line), Message: msg, Warning: true})
+	}
+	return result
+}
+
+// validatePolicy checks an ActivityPolicy with the same validation the API
+// server runs on create, returning its errors and warnings.
+func validatePolicy(policy *activityv1alpha1.ActivityPolicy) (field.ErrorList, []string) {
+	var internal activity.ActivityPolicy
+	if err := activityv1alpha1.Convert_v1alpha1_ActivityPolicy_To_activity_ActivityPolicy(policy, &internal, nil); err != nil {
+		return field.ErrorList{field.InternalError(field.NewPath("spec"), err)}, nil
+	}
+	return policyregistry.ValidateActivityPolicy(&internal), policyregistry.WarningsForPolicy(&internal)
+}
+
+// lintPolicy runs checks the API server doesn't. They catch policies that are
+// accepted but can't work, such as a plural resource name used as the kind.
+func lintPolicy(policy *activityv1alpha1.ActivityPolicy) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	wantAPIVersion := activityv1alpha1.SchemeGroupVersion.String()
+	if policy.APIVersion != wantAPIVersion {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("apiVersion"), policy.APIVersion,
+			fmt.Sprintf("must be %s", wantAPIVersion)))
+	}
+	if policy.Name == "" {
+		allErrs = append(allErrs, field.Required(field.NewPath("metadata", "name"), "provide a name for the policy"))
+	}
+
+	resourcePath := field.NewPath("spec", "resource")
+	// apiGroup is empty for core API resources (pods, services, ...)
+	if group := policy.Spec.Resource.APIGroup; group != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(group) {
+			allErrs = append(allErrs, field.Invalid(resourcePath.Child("apiGroup"), group, msg))
+		}
+	}
+	// An empty kind is already reported by validatePolicy
+	if kind := policy.Spec.Resource.Kind; kind != "" && !kindPattern.MatchString(kind) {
+		allErrs = append(allErrs, field.Invalid(resourcePath.Child("kind"), kind,
+			"must be the CamelCase kind of the resource (e.g., 'Deployment'), not its plural resource name"))
+	}
+
+	return allErrs
+}
+
+// lineForField returns the line of the YAML node at a field path such as
+// "spec.auditRules[1].match", falling back to the closest parent that exists.
+func lineForField(root *yaml.Node, fieldPath string) int {
+	node := root
+	line := root.Line
+
+	for _, segment := range strings.Split(fieldPath, ".") {
+		key, indexes, _ := strings.Cut(segment, "[")
+
+		node = mappingValue(node, key)
+		if node == nil {
+			return line
+		}
+		line = node.Line
+
+		for indexes != "" {
+			var index string
+			index, indexes, _ = strings.Cut(indexes, "]")
+			indexes = strings.TrimPrefix(indexes, "[")
+
+			i, err := strconv.Atoi(index)
+			if err != nil || node.Kind != yaml.SequenceNode || i >= len(node.Content) {
+				return line
+			}
+			node = node.Content[i]
+			line = node.Line
+		}
+	}
+
+	return line
+}
+
+// lineForKey returns the line of the first mapping key named key, searching
+// the document depth-first, or fallback if there is none.
+func lineForKey(node *yaml.Node, key string, fallback int) int {
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				return node.Content[i].Line
+			}
+		}
+	}
+	for _, child := range node.Content {
+		if line := lineForKey(child, key, 0); line != 0 {
+			return line
+		}
+	}
+	return fallback
+}
+
+// mappingValue returns the value node for key in a YAML mapping node
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
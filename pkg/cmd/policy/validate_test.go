@@ -0,0 +1,181 @@
+package policy
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+const validPolicyYAML = `apiVersion: activity.miloapis.com/v1alpha1
+kind: ActivityPolicy
+metadata:
+  name: deployments
+spec:
+  resource:
+    apiGroup: apps
+    kind: Deployment
+  auditRules:
+    - name: create
+      match: "audit.verb == 'create'"
+      summary: "{{ actor }} created deployment {{ audit.objectRef.name }}"
+  eventRules:
+    - name: scaled
+      match: "event.reason == 'ScalingReplicaSet'"
+      summary: "{{ event.note }}"
+`
+
+func TestValidatePolicyDocuments_Valid(t *testing.T) {
+	results, err := validatePolicyDocuments([]byte(validPolicyYAML))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "deployments", results[0].Name)
+	assert.Empty(t, results[0].Problems)
+}
+
+func TestValidatePolicyDocuments_Problems(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   string
+		wantLine int
+		wantMsg  string
+	}{
+		{
+			name:     "invalid match expression",
+			policy:   strings.Replace(validPolicyYAML, `"audit.verb == 'create'"`, `"audit.verb =="`, 1),
+			wantLine: 11,
+			wantMsg:  "spec.auditRules[0].match",
+		},
+		{
+			name:     "match expression is not boolean",
+			policy:   strings.Replace(validPolicyYAML, `"audit.verb == 'create'"`, `"audit.verb"`, 1),
+			wantLine: 11,
+			wantMsg:  "must return a boolean",
+		},
+		{
+			name:     "unknown variable in event summary",
+			policy:   strings.Replace(validPolicyYAML, `"{{ event.note }}"`, `"{{ audit.verb }}"`, 1),
+			wantLine: 16,
+			wantMsg:  "spec.eventRules[0].summary",
+		},
+		{
+			name:     "plural resource name used as kind",
+			policy:   strings.Replace(validPolicyYAML, "kind: Deployment", "kind: deployments", 1),
+			wantLine: 8,
+			wantMsg:  "lint: spec.resource.kind",
+		},
+		{
+			name:     "malformed API group",
+			policy:   strings.Replace(validPolicyYAML, "apiGroup: apps", "apiGroup: Apps_v1", 1),
+			wantLine: 7,
+			wantMsg:  "lint: spec.resource.apiGroup",
+		},
+		{
+			name: "duplicate rule name",
+			policy: validPolicyYAML[:strings.Index(validPolicyYAML, "  eventRules:")] + `    - name: create
+      match: "audit.verb == 'update'"
+      summary: "{{ actor }} updated deployment"
+`,
+			wantLine: 13,
+			wantMsg:  "spec.auditRules[1].name: Duplicate value",
+		},
+		{
+			name:     "unsupported severity",
+			policy:   strings.Replace(validPolicyYAML, "      summary: \"{{ event.note }}\"\n", "      summary: \"{{ event.note }}\"\n      severity: Urgent\n", 1),
+			wantLine: 17,
+			wantMsg:  `spec.eventRules[0].severity: Unsupported value: "Urgent"`,
+		},
+		{
+			name:     "misspelled field",
+			policy:   strings.Replace(validPolicyYAML, "auditRules:", "auditRule:", 1),
+			wantLine: 9,
+			wantMsg:  `unknown field "auditRule"`,
+		},
+		{
+			name:     "wrong API version",
+			policy:   strings.Replace(validPolicyYAML, "activity.miloapis.com/v1alpha1", "activity.miloapis.com/v1", 1),
+			wantLine: 1,
+			wantMsg:  "lint: apiVersion: Invalid value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := validatePolicyDocuments([]byte(tt.policy))
+			require.NoError(t, err)
+			require.Len(t, results, 1)
+			require.NotEmpty(t, results[0].Problems)
+
+			problem := results[0].Problems[0]
+			assert.Equal(t, tt.wantLine, problem.Line)
+			assert.Contains(t, problem.Message, tt.wantMsg)
+		})
+	}
+}
+
+func TestValidatePolicyDocuments_MultipleDocuments(t *testing.T) {
+	noRules := validPolicyYAML[:strings.Index(validPolicyYAML, "  auditRules:")]
+	stream := validPolicyYAML + "---\n" +
+		strings.Replace(noRules, "name: deployments", "name: broken", 1) +
+		"---\n"
+
+	results, err := validatePolicyDocuments([]byte(stream))
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Empty(t, results[0].Problems)
+	assert.Equal(t, "broken", results[1].Name)
+	require.Len(t, results[1].Problems, 1)
+	assert.Contains(t, results[1].Problems[0].Message, "no rules defined")
+	assert.True(t, results[1].Problems[0].Warning, "a policy without rules is accepted by the API server")
+}
+
+func TestValidateOptions_Run(t *testing.T) {
+	dir := t.TempDir()
+	validFile := filepath.Join(dir, "valid.yaml")
+	invalidFile := filepath.Join(dir, "invalid.yaml")
+	require.NoError(t, os.WriteFile(validFile, []byte(validPolicyYAML), 0o600))
+	require.NoError(t, os.WriteFile(invalidFile,
+		[]byte(strings.Replace(validPolicyYAML, `"audit.verb == 'create'"`, `"audit.verb =="`, 1)), 0o600))
+
+	streams, _, out, errOut := genericclioptions.NewTestIOStreams()
+	o := NewValidateOptions(streams)
+	o.PolicyFiles = []string{validFile}
+	require.NoError(t, o.Run())
+	assert.Contains(t, out.String(), `ActivityPolicy "deployments" is valid`)
+	assert.Empty(t, errOut.String())
+
+	o.PolicyFiles = []string{validFile, invalidFile}
+	err := o.Run()
+	require.Error(t, err)
+	assert.Contains(t, errOut.String(), invalidFile+":11: spec.auditRules[0].match")
+
+	// Other manifests are skipped, but a file needs at least one policy.
+	otherFile := filepath.Join(dir, "other.yaml")
+	require.NoError(t, os.WriteFile(otherFile, []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: settings\n"), 0o600))
+	streams, _, out, errOut = genericclioptions.NewTestIOStreams()
+	o = NewValidateOptions(streams)
+	o.PolicyFiles = []string{otherFile}
+	require.Error(t, o.Run())
+	assert.Contains(t, out.String(), `skipping ConfigMap "settings"`)
+	assert.Contains(t, errOut.String(), "no ActivityPolicy documents found")
+
+	streams.In = bytes.NewBufferString(validPolicyYAML)
+	o = NewValidateOptions(streams)
+	o.PolicyFiles = []string{"-"}
+	require.NoError(t, o.Run())
+
+	// Server warnings are printed but don't fail validation.
+	noRulesFile := filepath.Join(dir, "norules.yaml")
+	require.NoError(t, os.WriteFile(noRulesFile, []byte(validPolicyYAML[:strings.Index(validPolicyYAML, "  auditRules:")]), 0o600))
+	streams, _, out, errOut = genericclioptions.NewTestIOStreams()
+	o = NewValidateOptions(streams)
+	o.PolicyFiles = []string{noRulesFile}
+	require.NoError(t, o.Run())
+	assert.Contains(t, out.String(), `ActivityPolicy "deployments" is valid`)
+	assert.Contains(t, errOut.String(), noRulesFile+":5: warning: policy has no rules defined")
+}
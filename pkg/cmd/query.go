@@ -14,6 +14,7 @@ import (
 
 	activityv1alpha1 "go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
 	clientset "go.miloapis.com/activity/pkg/client/clientset/versioned"
+	"go.miloapis.com/activity/pkg/cmd/common"
 )
 
 // QueryOptions contains the options for querying audit logs
@@ -24,6 +25,7 @@ type QueryOptions struct {
 	Limit         int32
 	ContinueAfter string
 	AllPages      bool
+	MaxTotal      int
 
 	PrintFlags *genericclioptions.PrintFlags
 	genericclioptions.IOStreams
@@ -76,10 +78,11 @@ Examples:
   activity query -o yaml
 
   # Use JSONPath to extract specific fields
-  activity query -o jsonpath='{.items[*].verb}'
+  activity query -o jsonpath='{.verb}'
 
-  # Use Go templates for custom output
-  activity query -o go-template='{{range .items}}{{.verb}} {{.user.username}}{{"\n"}}{{end}}'
+  # Use Go templates for custom output. Templates are evaluated once per
+  # event, one line each, and use the event's JSON field names.
+  activity query --template '{{.user.username}} {{.verb}} {{.objectRef.name}}'
 
 Time Formats:
   Relative: "now-7d", "now-2h", "now-30m" (units: s, m, h, d, w)
@@ -112,6 +115,7 @@ Common Filters:
 	cmd.Flags().Int32Var(&o.Limit, "limit", 25, "Maximum number of results per page (1-1000)")
 	cmd.Flags().StringVar(&o.ContinueAfter, "continue-after", "", "Pagination cursor from previous query")
 	cmd.Flags().BoolVar(&o.AllPages, "all-pages", false, "Fetch all pages of results (ignores --continue-after)")
+	cmd.Flags().IntVar(&o.MaxTotal, "max-total", common.DefaultMaxTotal, "Maximum number of results to fetch with --all-pages (0 for no limit)")
 
 	// Add printer flags (handles -o json, -o yaml, -o wide, etc.)
 	o.PrintFlags.AddFlags(cmd)
@@ -149,6 +153,9 @@ func (o *QueryOptions) Validate() error {
 	if o.AllPages && o.ContinueAfter != "" {
 		return fmt.Errorf("--all-pages and --continue-after are mutually exclusive")
 	}
+	if o.MaxTotal < 0 {
+		return fmt.Errorf("--max-total must be 0 or greater")
+	}
 
 	return nil
 }
@@ -202,10 +209,12 @@ func (o *QueryOptions) runAllPages(ctx context.Context, client *clientset.Client
 	var allEvents []auditv1.Event
 	continueAfter := ""
 	pageNum := 1
+	totalCount := 0
+	truncatedAfter := ""
+	pagination := common.PaginationFlags{Limit: o.Limit, MaxTotal: o.MaxTotal}
 
 	// Check if using table output
-	outputFormat := o.PrintFlags.OutputFormat
-	isTableOutput := outputFormat == nil || *outputFormat == ""
+	isTableOutput := common.IsDefaultOutputFormat(o.PrintFlags)
 
 	// Create table printer for table output
 	var tablePrinter printers.ResourcePrinter
@@ -225,7 +234,7 @@ func (o *QueryOptions) runAllPages(ctx context.Context, client *clientset.Client
 				StartTime: o.StartTime,
 				EndTime:   o.EndTime,
 				Filter:    o.Filter,
-				Limit:     o.Limit,
+				Limit:     pagination.PageLimit(totalCount),
 				Continue:  continueAfter,
 			},
 		}
@@ -235,6 +244,8 @@ func (o *QueryOptions) runAllPages(ctx context.Context, client *clientset.Client
 			return fmt.Errorf("query failed on page %d: %w", pageNum, err)
 		}
 
+		totalCount += len(result.Status.Results)
+
 		// For table output, print each page as we get it
 		if isTableOutput {
 			if pageNum == 1 {
@@ -265,6 +276,10 @@ func (o *QueryOptions) runAllPages(ctx context.Context, client *clientset.Client
 		if result.Status.Continue == "" {
 			break
 		}
+		if pagination.ReachedMaxTotal(totalCount) {
+			truncatedAfter = result.Status.Continue
+			break
+		}
 
 		continueAfter = result.Status.Continue
 		pageNum++
@@ -272,13 +287,15 @@ func (o *QueryOptions) runAllPages(ctx context.Context, client *clientset.Client
 
 	// Print collected results for JSON/YAML
 	if !isTableOutput {
-		printer, err := o.PrintFlags.ToPrinter()
-		if err != nil {
-			return fmt.Errorf("failed to create printer: %w", err)
+		if err := printEvents(allEvents, o.PrintFlags, o.Out); err != nil {
+			return err
 		}
-		return o.printEvents(allEvents, printer)
 	}
 
+	if truncatedAfter != "" {
+		tp := common.NewTablePrinter(o.PrintFlags, o.IOStreams, false)
+		tp.PrintTruncatedInfo(o.MaxTotal, truncatedAfter)
+	}
 	return nil
 }
 
@@ -286,20 +303,13 @@ func (o *QueryOptions) runAllPages(ctx context.Context, client *clientset.Client
 func (o *QueryOptions) printResults(result *activityv1alpha1.AuditLogQuery) error {
 	// For default output (table), use our custom table printer
 	// For other formats (json, yaml, etc.), use the standard printer
-	outputFormat := o.PrintFlags.OutputFormat
-	if outputFormat == nil || *outputFormat == "" {
+	if common.IsDefaultOutputFormat(o.PrintFlags) {
 		// Use custom table printing
 		return o.printTable(result.Status.Results, result.Status.Continue)
 	}
 
-	// Create printer for other formats
-	printer, err := o.PrintFlags.ToPrinter()
-	if err != nil {
-		return fmt.Errorf("failed to create printer: %w", err)
-	}
-
 	// Print the events
-	return o.printEvents(result.Status.Results, printer)
+	return printEvents(result.Status.Results, o.PrintFlags, o.Out)
 }
 
 // printTable prints events as a formatted table
@@ -326,19 +336,6 @@ func (o *QueryOptions) printTable(events []auditv1.Event, continueToken string)
 	return nil
 }
 
-// printEvents prints audit events using the configured printer
-func (o *QueryOptions) printEvents(events []auditv1.Event, printer printers.ResourcePrinter) error {
-	// Create an event list for printing the actual audit objects
-	eventList := &auditv1.EventList{
-		TypeMeta: metav1.TypeMeta{
-			Kind:       "EventList",
-			APIVersion: "audit.k8s.io/v1",
-		},
-		Items: events,
-	}
-	return printer.PrintObj(eventList, o.Out)
-}
-
 // eventsToTable converts audit events to a Table object
 func (o *QueryOptions) eventsToTable(events []auditv1.Event) *metav1.Table {
 	return &metav1.Table{
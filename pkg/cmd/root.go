@@ -5,8 +5,10 @@ import (
 
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/rest"
 	"k8s.io/kubectl/pkg/cmd/util"
 
+	"go.miloapis.com/activity/pkg/cmd/common"
 	"go.miloapis.com/activity/pkg/cmd/policy"
 	"go.miloapis.com/activity/pkg/cmd/reindexjob"
 )
@@ -71,6 +73,11 @@ func NewActivityCommand(opts ActivityCommandOptions) *cobra.Command {
 		f = util.NewFactory(matchVersionKubeConfigFlags)
 	}
 
+	// Every subcommand builds its clients from the factory, so wrapping it
+	// applies --scope-kind/--scope-name everywhere
+	scopeFlags := &common.ScopeFlags{}
+	f = &scopedFactory{Factory: f, scope: scopeFlags}
+
 	longDesc := `The activity plugin provides commands to query and analyze audit logs, events,
 and human-readable activity summaries from your control plane.
 
@@ -80,6 +87,8 @@ activity, generate compliance reports, or develop ActivityPolicy rules.
 Available Commands:
   audit    - Query audit logs from the control plane
   events   - Query Kubernetes events with extended retention
+  export   - Stream audit logs, or counts grouped by field, in bulk
+  facets   - Show the most common values of audit log fields
   feed     - Query human-readable activity summaries
   history  - View resource change history with diffs`
 
@@ -102,7 +111,10 @@ Examples:
   kubectl activity feed --change-source human
 
   # Resource change history with diffs
-  kubectl activity history deployments my-app -n default --diff`
+  kubectl activity history deployments my-app -n default --diff
+
+  # Platform administrators: audit logs for a specific project
+  kubectl activity audit --scope-kind project --scope-name backend-api`
 
 	if opts.EnableAdminCommands {
 		longDesc += `
@@ -110,6 +122,9 @@ Examples:
   # Test a policy before deploying
   kubectl activity policy preview -f my-policy.yaml --input samples.yaml
 
+  # Check policy files in CI without a cluster
+  kubectl activity policy validate -f my-policy.yaml
+
   # Reindex the last 7 days
   kubectl activity reindex create --start-time now-7d`
 	}
@@ -128,11 +143,17 @@ Examples:
 		kubeConfigFlags.AddFlags(cmd.PersistentFlags())
 	}
 
+	common.AddScopeFlags(cmd, scopeFlags)
+
 	// Add core subcommands (always registered)
 	cmd.AddCommand(NewAuditCommand(f, ioStreams))
 	cmd.AddCommand(NewEventsCommand(f, ioStreams))
+	cmd.AddCommand(NewExportCommand(f, ioStreams))
+	cmd.AddCommand(NewFacetsCommand(f, ioStreams))
 	cmd.AddCommand(NewFeedCommand(f, ioStreams))
 	cmd.AddCommand(NewHistoryCommand(f, ioStreams))
+	cmd.AddCommand(NewSummaryCommand(f, ioStreams))
+	cmd.AddCommand(NewWhoDeletedCommand(f, ioStreams))
 
 	// Add administrative subcommands when opted-in
 	if opts.EnableAdminCommands {
@@ -142,3 +163,20 @@ Examples:
 
 	return cmd
 }
+
+// scopedFactory applies the scope override flags to every REST config it
+// hands out
+type scopedFactory struct {
+	util.Factory
+	scope *common.ScopeFlags
+}
+
+// ToRESTConfig returns the underlying REST config with the scope override
+// headers attached
+func (f *scopedFactory) ToRESTConfig() (*rest.Config, error) {
+	config, err := f.Factory.ToRESTConfig()
+	if err != nil {
+		return nil, err
+	}
+	return f.scope.WrapConfig(config)
+}
@@ -0,0 +1,293 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/kubectl/pkg/cmd/util"
+	"sigs.k8s.io/yaml"
+
+	"go.miloapis.com/activity/internal/timeutil"
+	"go.miloapis.com/activity/pkg/activitysummary"
+	activityv1alpha1 "go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+	"go.miloapis.com/activity/pkg/celutil"
+	clientset "go.miloapis.com/activity/pkg/client/clientset/versioned"
+	"go.miloapis.com/activity/pkg/cmd/common"
+)
+
+// summarySampleLimit is the number of activities, and separately of
+// deletions, the summary is computed from. It matches the MCP tool.
+const summarySampleLimit = 1000
+
+// SummaryOptions contains the options for summarizing activity
+type SummaryOptions struct {
+	ChangeSource string
+	TopN         int
+
+	// Since is shorthand for --start-time now-<Since>
+	Since string
+
+	// Output is "" for the report, or json or yaml
+	Output string
+
+	// Common flags
+	TimeRange common.TimeRangeFlags
+
+	genericclioptions.IOStreams
+	Factory util.Factory
+}
+
+// summaryReport is the -o json/yaml form of a summary
+type summaryReport struct {
+	TimeRange summaryTimeRange `json:"timeRange"`
+	*activitysummary.Summary
+	Highlights []activitysummary.Highlight `json:"highlights"`
+
+	// ActivitiesSampled and DeletionsSampled are set when the window held
+	// more than summarySampleLimit of them, so only the most recent were
+	// counted.
+	ActivitiesSampled bool `json:"activitiesSampled,omitempty"`
+	DeletionsSampled  bool `json:"deletionsSampled,omitempty"`
+}
+
+type summaryTimeRange struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// NewSummaryOptions creates a new SummaryOptions with default values
+func NewSummaryOptions(f util.Factory, ioStreams genericclioptions.IOStreams) *SummaryOptions {
+	return &SummaryOptions{
+		IOStreams: ioStreams,
+		Factory:   f,
+		TopN:      activitysummary.DefaultTopN,
+		TimeRange: common.TimeRangeFlags{
+			StartTime: "now-24h",
+			EndTime:   "now",
+		},
+	}
+}
+
+// NewSummaryCommand creates the summary command
+func NewSummaryCommand(f util.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewSummaryOptions(f, ioStreams)
+
+	cmd := &cobra.Command{
+		Use:   "summary [flags]",
+		Short: "Summarize who changed what over a time range",
+		Long: `Summarize activity over a time range: the most active actors, the most
+changed resource kinds, how many changes were made by humans versus the system,
+and which deletions happened and by whom.
+
+This is the same summary the summarize_recent_activity MCP tool returns. It is
+computed from the most recent 1000 activities and 1000 deletions in the range;
+a note is printed when the range holds more.
+
+Deletions come from audit logs, which don't record a change source, so
+--change-source narrows the activity counts but not the deletions.
+
+Examples:
+  # Summarize the last 24 hours
+  kubectl activity summary
+
+  # Summarize human changes over the last week
+  kubectl activity summary --since 7d --change-source human
+
+  # Show the top 10 in each ranking
+  kubectl activity summary --top-n 10
+
+  # Output the summary as JSON
+  kubectl activity summary --since 2h -o json
+`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Complete(cmd); err != nil {
+				return err
+			}
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return o.Run(cmd.Context())
+		},
+	}
+
+	cmd.Flags().StringVar(&o.ChangeSource, "change-source", "", "Only summarize activities from this change source: human, system")
+	cmd.Flags().IntVar(&o.TopN, "top-n", o.TopN, "Number of entries in each ranking")
+	cmd.Flags().StringVar(&o.Since, "since", "", "Summarize the period up to now, e.g. 2h, 7d (shorthand for --start-time now-<since>)")
+	cmd.Flags().StringVarP(&o.Output, "output", "o", "", "Output format: json or yaml. Defaults to a readable report.")
+
+	common.AddTimeRangeFlags(cmd, &o.TimeRange, "now-24h")
+
+	return cmd
+}
+
+// Complete fills in missing options
+func (o *SummaryOptions) Complete(cmd *cobra.Command) error {
+	if o.Out == nil {
+		o.Out = os.Stdout
+	}
+	if o.ErrOut == nil {
+		o.ErrOut = os.Stderr
+	}
+	if o.In == nil {
+		o.In = os.Stdin
+	}
+
+	if o.Since != "" {
+		if cmd != nil && (cmd.Flags().Changed("start-time") || cmd.Flags().Changed("end-time")) {
+			return fmt.Errorf("--since cannot be combined with --start-time or --end-time")
+		}
+		o.TimeRange.StartTime = "now-" + o.Since
+		o.TimeRange.EndTime = "now"
+	}
+	return nil
+}
+
+// Validate checks that required options are set correctly
+func (o *SummaryOptions) Validate() error {
+	if o.ChangeSource != "" && o.ChangeSource != "human" && o.ChangeSource != "system" {
+		return fmt.Errorf("invalid --change-source value %q: must be \"human\" or \"system\"", o.ChangeSource)
+	}
+	if o.TopN < 1 {
+		return fmt.Errorf("--top-n must be at least 1")
+	}
+	if o.Since != "" {
+		if _, err := timeutil.ParseRelativeTime("now-"+o.Since, time.Now()); err != nil {
+			return fmt.Errorf("invalid --since value %q: %w", o.Since, err)
+		}
+	}
+	if o.Output != "" && o.Output != "json" && o.Output != "yaml" {
+		return fmt.Errorf("invalid --output value %q: must be json or yaml", o.Output)
+	}
+	return o.TimeRange.Validate()
+}
+
+// Run executes the summary command
+func (o *SummaryOptions) Run(ctx context.Context) error {
+	config, err := o.Factory.ToRESTConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get kubeconfig: %w", err)
+	}
+
+	client, err := clientset.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("failed to create activity client: %w", err)
+	}
+
+	activities, err := client.ActivityV1alpha1().ActivityQueries().Create(ctx, &activityv1alpha1.ActivityQuery{
+		ObjectMeta: metav1.ObjectMeta{GenerateName: "summary-"},
+		Spec: activityv1alpha1.ActivityQuerySpec{
+			StartTime: o.TimeRange.StartTime,
+			EndTime:   o.TimeRange.EndTime,
+			Filter:    o.buildFilter(),
+			Limit:     summarySampleLimit,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("activity query failed: %w", err)
+	}
+
+	deletions, err := client.ActivityV1alpha1().AuditLogQueries().Create(ctx, &activityv1alpha1.AuditLogQuery{
+		ObjectMeta: metav1.ObjectMeta{GenerateName: "summary-deletions-"},
+		Spec: activityv1alpha1.AuditLogQuerySpec{
+			StartTime: o.TimeRange.StartTime,
+			EndTime:   o.TimeRange.EndTime,
+			Filter:    activitysummary.DeletionFilter,
+			Limit:     summarySampleLimit,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("deletion query failed: %w", err)
+	}
+
+	summary := activitysummary.Summarize(activities.Status.Results, deletions.Status.Results, o.TopN)
+	report := &summaryReport{
+		TimeRange:         summaryTimeRange{Start: activities.Status.EffectiveStartTime, End: activities.Status.EffectiveEndTime},
+		Summary:           summary,
+		Highlights:        summary.Highlights(),
+		ActivitiesSampled: activities.Status.Continue != "",
+		DeletionsSampled:  deletions.Status.Continue != "",
+	}
+
+	return o.printReport(report)
+}
+
+// buildFilter creates the activity filter from the shorthand flags
+func (o *SummaryOptions) buildFilter() string {
+	if o.ChangeSource == "" {
+		return ""
+	}
+	return fmt.Sprintf("spec.changeSource == '%s'", celutil.EscapeString(o.ChangeSource))
+}
+
+// printReport prints the summary in the requested format
+func (o *SummaryOptions) printReport(report *summaryReport) error {
+	switch o.Output {
+	case "json":
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode summary: %w", err)
+		}
+		_, err = fmt.Fprintf(o.Out, "%s\n", data)
+		return err
+	case "yaml":
+		data, err := yaml.Marshal(report)
+		if err != nil {
+			return fmt.Errorf("failed to encode summary: %w", err)
+		}
+		_, err = o.Out.Write(data)
+		return err
+	}
+
+	if err := writeSummaryReport(o.Out, report); err != nil {
+		return err
+	}
+	if report.ActivitiesSampled || report.DeletionsSampled {
+		fmt.Fprintf(o.ErrOut, "\nOnly the most recent %d activities and %d deletions were counted. Narrow the time range for exact counts.\n",
+			summarySampleLimit, summarySampleLimit)
+	}
+	return nil
+}
+
+// writeSummaryReport writes the readable form of a summary
+func writeSummaryReport(out io.Writer, report *summaryReport) error {
+	s := report.Summary
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(w, "Activity summary from %s to %s\n\n", report.TimeRange.Start, report.TimeRange.End)
+	fmt.Fprintf(w, "Changes:\t%d (%d human, %d system)\n", s.TotalActivities, s.HumanChanges, s.SystemChanges)
+	fmt.Fprintf(w, "Deletions:\t%d\n", s.Deletions.Total)
+
+	writeRanking(w, "Top actors", s.TopActors)
+	writeRanking(w, "Most changed resource kinds", s.TopResources)
+	writeRanking(w, "Deletions by actor", s.Deletions.TopActors)
+	writeRanking(w, "Deletions by resource type", s.Deletions.TopResources)
+
+	if len(s.RecentSummaries) > 0 {
+		fmt.Fprintf(w, "\nMost recent:\n")
+		for _, summary := range s.RecentSummaries {
+			fmt.Fprintf(w, "  %s\n", summary)
+		}
+	}
+
+	return w.Flush()
+}
+
+// writeRanking writes a titled list of counts, skipping empty rankings
+func writeRanking(w io.Writer, title string, counts []activitysummary.Count) {
+	if len(counts) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "\n%s:\n", title)
+	for _, c := range counts {
+		fmt.Fprintf(w, "  %s\t%d\n", c.Name, c.Count)
+	}
+}
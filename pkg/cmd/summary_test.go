@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"go.miloapis.com/activity/pkg/activitysummary"
+)
+
+func TestSummaryOptions_Complete_Since(t *testing.T) {
+	streams := genericclioptions.NewTestIOStreamsDiscard()
+
+	cmd := NewSummaryCommand(nil, streams)
+	o := NewSummaryOptions(nil, streams)
+	o.Since = "7d"
+	require.NoError(t, o.Complete(cmd))
+	assert.Equal(t, "now-7d", o.TimeRange.StartTime)
+	assert.Equal(t, "now", o.TimeRange.EndTime)
+
+	cmd = NewSummaryCommand(nil, streams)
+	require.NoError(t, cmd.Flags().Set("start-time", "now-2d"))
+	o = NewSummaryOptions(nil, streams)
+	o.Since = "7d"
+	assert.ErrorContains(t, o.Complete(cmd), "--since cannot be combined")
+}
+
+func TestSummaryOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(o *SummaryOptions)
+		wantErr string
+	}{
+		{name: "defaults", modify: func(o *SummaryOptions) {}},
+		{name: "human changes as JSON", modify: func(o *SummaryOptions) { o.ChangeSource = "human"; o.Output = "json" }},
+		{name: "since", modify: func(o *SummaryOptions) { o.Since = "2h" }},
+		{name: "invalid change source", modify: func(o *SummaryOptions) { o.ChangeSource = "robots" }, wantErr: "invalid --change-source"},
+		{name: "zero top-n", modify: func(o *SummaryOptions) { o.TopN = 0 }, wantErr: "--top-n must be at least 1"},
+		{name: "invalid since", modify: func(o *SummaryOptions) { o.Since = "yesterday" }, wantErr: "invalid --since"},
+		{name: "unsupported output", modify: func(o *SummaryOptions) { o.Output = "wide" }, wantErr: "invalid --output"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := NewSummaryOptions(nil, genericclioptions.NewTestIOStreamsDiscard())
+			tt.modify(o)
+			err := o.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSummaryOptions_buildFilter(t *testing.T) {
+	o := &SummaryOptions{}
+	assert.Empty(t, o.buildFilter())
+
+	o.ChangeSource = "system"
+	assert.Equal(t, "spec.changeSource == 'system'", o.buildFilter())
+}
+
+func testSummaryReport() *summaryReport {
+	summary := &activitysummary.Summary{
+		TotalActivities: 3,
+		HumanChanges:    2,
+		SystemChanges:   1,
+		TopActors:       []activitysummary.Count{{Name: "alice", Count: 2}, {Name: "controller", Count: 1}},
+		TopResources:    []activitysummary.Count{{Name: "Domain", Count: 3}},
+		RecentSummaries: []string{"alice created Domain example-com"},
+		Deletions: activitysummary.Deletions{
+			Total:        1,
+			TopActors:    []activitysummary.Count{{Name: "bob", Count: 1}},
+			TopResources: []activitysummary.Count{{Name: "configmaps", Count: 1}},
+		},
+	}
+	return &summaryReport{
+		TimeRange:  summaryTimeRange{Start: "2026-01-01T00:00:00Z", End: "2026-01-02T00:00:00Z"},
+		Summary:    summary,
+		Highlights: summary.Highlights(),
+	}
+}
+
+func TestWriteSummaryReport(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, writeSummaryReport(&out, testSummaryReport()))
+
+	report := out.String()
+	assert.Contains(t, report, "Activity summary from 2026-01-01T00:00:00Z to 2026-01-02T00:00:00Z")
+	assert.Contains(t, report, "Changes:    3 (2 human, 1 system)")
+	assert.Contains(t, report, "Top actors:\n  alice       2\n  controller  1\n")
+	assert.Contains(t, report, "Deletions by actor:\n  bob  1\n")
+	assert.Contains(t, report, "Deletions by resource type:\n  configmaps  1\n")
+	assert.Contains(t, report, "Most recent:\n  alice created Domain example-com\n")
+}
+
+func TestSummaryOptions_printReport_JSON(t *testing.T) {
+	streams, _, out, _ := genericclioptions.NewTestIOStreams()
+	o := NewSummaryOptions(nil, streams)
+	o.Output = "json"
+
+	require.NoError(t, o.printReport(testSummaryReport()))
+
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, float64(3), decoded["totalActivities"])
+	assert.Equal(t, float64(1), decoded["deletions"].(map[string]any)["total"])
+	assert.Len(t, decoded["highlights"], 4)
+	assert.NotContains(t, decoded, "activitiesSampled")
+}
@@ -0,0 +1,290 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/kubectl/pkg/cmd/util"
+
+	activityv1alpha1 "go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+	clientset "go.miloapis.com/activity/pkg/client/clientset/versioned"
+	"go.miloapis.com/activity/pkg/cmd/common"
+)
+
+// deletionVerbs are the audit verbs that remove a resource
+var deletionVerbs = []string{"delete", "deletecollection"}
+
+// whoDeletedPageLimit is the page size used while collecting deletions. A
+// resource is rarely deleted more than a handful of times, so every page is
+// fetched and the full list is shown.
+const whoDeletedPageLimit = 100
+
+// WhoDeletedOptions contains the options for finding who deleted a resource
+type WhoDeletedOptions struct {
+	Namespace string
+	Resource  string
+	Name      string
+
+	// Common flags
+	TimeRange common.TimeRangeFlags
+
+	PrintFlags *genericclioptions.PrintFlags
+	genericclioptions.IOStreams
+	Factory util.Factory
+}
+
+// NewWhoDeletedOptions creates a new WhoDeletedOptions with default values
+func NewWhoDeletedOptions(f util.Factory, ioStreams genericclioptions.IOStreams) *WhoDeletedOptions {
+	return &WhoDeletedOptions{
+		IOStreams:  ioStreams,
+		Factory:    f,
+		PrintFlags: genericclioptions.NewPrintFlags(""),
+		TimeRange: common.TimeRangeFlags{
+			StartTime: "now-30d",
+			EndTime:   "now",
+		},
+	}
+}
+
+// NewWhoDeletedCommand creates the who-deleted command
+func NewWhoDeletedCommand(f util.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewWhoDeletedOptions(f, ioStreams)
+
+	cmd := &cobra.Command{
+		Use:   "who-deleted RESOURCE_TYPE NAME",
+		Short: "Find out who deleted a specific resource",
+		Long: `Find out who deleted a specific resource by querying audit logs.
+
+This command searches for delete and deletecollection requests against the given
+resource and shows the actor, timestamp, and source IP of each one. If the resource
+was recreated and deleted again, every deletion in the time range is listed, oldest
+first.
+
+The command accepts the resource type and name as separate arguments:
+  - RESOURCE_TYPE: The type of resource (e.g., domains, dnsrecordsets, configmaps, secrets)
+  - NAME: The name of the specific resource instance
+
+Use the -n/--namespace flag for namespaced resources.
+
+Collection deletes (for example "kubectl delete configmaps --all") are recorded
+without an object name, so every collection delete of the resource type in the
+namespace is listed as a possible match.
+
+Examples:
+  # Find who deleted a config map
+  activity who-deleted configmaps app-config -n default
+
+  # Search further back than the default 30 days
+  activity who-deleted secrets api-credentials -n production --start-time "now-90d"
+
+  # Output the matching audit events as JSON
+  activity who-deleted domains example-com -n default -o json
+`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Complete(cmd, args); err != nil {
+				return err
+			}
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return o.Run(cmd.Context())
+		},
+	}
+
+	// Add flags
+	common.AddTimeRangeFlags(cmd, &o.TimeRange, "now-30d")
+
+	// Add printer flags
+	o.PrintFlags.AddFlags(cmd)
+
+	return cmd
+}
+
+// Complete fills in missing options
+func (o *WhoDeletedOptions) Complete(cmd *cobra.Command, args []string) error {
+	// Set up IO streams if not already set
+	if o.Out == nil {
+		o.Out = os.Stdout
+	}
+	if o.ErrOut == nil {
+		o.ErrOut = os.Stderr
+	}
+	if o.In == nil {
+		o.In = os.Stdin
+	}
+
+	// Parse resource type and name from arguments
+	if len(args) != 2 {
+		return fmt.Errorf("exactly two arguments are required: RESOURCE_TYPE NAME")
+	}
+
+	o.Resource = args[0]
+	o.Name = args[1]
+
+	// Get namespace from the factory's namespace flag if available
+	if o.Factory != nil {
+		namespace, enforceNamespace, err := o.Factory.ToRawKubeConfigLoader().Namespace()
+		if err != nil {
+			return fmt.Errorf("failed to get namespace: %w", err)
+		}
+		if enforceNamespace || namespace != "" {
+			o.Namespace = namespace
+		}
+	}
+
+	return nil
+}
+
+// Validate checks that required options are set correctly
+func (o *WhoDeletedOptions) Validate() error {
+	if o.Resource == "" {
+		return fmt.Errorf("resource type is required")
+	}
+	if o.Name == "" {
+		return fmt.Errorf("resource name is required")
+	}
+	return o.TimeRange.Validate()
+}
+
+// Run executes the who-deleted command
+func (o *WhoDeletedOptions) Run(ctx context.Context) error {
+	config, err := o.Factory.ToRESTConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get kubeconfig: %w", err)
+	}
+
+	client, err := clientset.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("failed to create activity client: %w", err)
+	}
+
+	events, err := o.fetchDeletions(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	if !common.IsDefaultOutputFormat(o.PrintFlags) {
+		return printEvents(events, o.PrintFlags, o.Out)
+	}
+
+	if len(events) == 0 {
+		fmt.Fprintf(o.Out, "No deletions of %s/%s found between %s and %s.\n",
+			o.Resource, o.Name, o.TimeRange.StartTime, o.TimeRange.EndTime)
+		return nil
+	}
+
+	if err := common.CreateTablePrinter(false).PrintObj(o.deletionsToTable(events), o.Out); err != nil {
+		return err
+	}
+	fmt.Fprintf(o.ErrOut, "\nFound %d deletion(s).\n", len(events))
+	return nil
+}
+
+// fetchDeletions collects every deletion in the time range, oldest first
+func (o *WhoDeletedOptions) fetchDeletions(ctx context.Context, client *clientset.Clientset) ([]auditv1.Event, error) {
+	var events []auditv1.Event
+	continueAfter := ""
+	filter := o.buildFilter()
+
+	for pageNum := 1; ; pageNum++ {
+		query := &activityv1alpha1.AuditLogQuery{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "who-deleted-",
+			},
+			Spec: activityv1alpha1.AuditLogQuerySpec{
+				StartTime: o.TimeRange.StartTime,
+				EndTime:   o.TimeRange.EndTime,
+				Filter:    filter,
+				Limit:     whoDeletedPageLimit,
+				Continue:  continueAfter,
+			},
+		}
+
+		result, err := client.ActivityV1alpha1().AuditLogQueries().Create(ctx, query, metav1.CreateOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("query failed on page %d: %w", pageNum, err)
+		}
+
+		events = append(events, result.Status.Results...)
+
+		if result.Status.Continue == "" {
+			break
+		}
+		continueAfter = result.Status.Continue
+	}
+
+	// Results come newest-first; show deletions in the order they happened
+	for i := 0; i < len(events)/2; i++ {
+		j := len(events) - i - 1
+		events[i], events[j] = events[j], events[i]
+	}
+
+	return events, nil
+}
+
+// buildFilter creates a CEL filter that matches deletions of the resource,
+// reusing the history filter with the deletion verbs. Deletions never target
+// a subresource, so there is nothing to exclude.
+func (o *WhoDeletedOptions) buildFilter() string {
+	history := &HistoryOptions{
+		Namespace:           o.Namespace,
+		Resource:            o.Resource,
+		Name:                o.Name,
+		Verbs:               deletionVerbs,
+		IncludeSubresources: true,
+	}
+	return history.buildFilter()
+}
+
+// deletionsToTable converts deletion audit events to a Table object
+func (o *WhoDeletedOptions) deletionsToTable(events []auditv1.Event) *metav1.Table {
+	return &metav1.Table{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Table",
+			APIVersion: "meta.k8s.io/v1",
+		},
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Timestamp", Type: "string", Description: "Time of the deletion"},
+			{Name: "Verb", Type: "string", Description: "delete or deletecollection"},
+			{Name: "User", Type: "string", Description: "User who deleted the resource"},
+			{Name: "Source IP", Type: "string", Description: "Client IP addresses of the request"},
+			{Name: "Status", Type: "string", Description: "HTTP status code"},
+		},
+		Rows: o.deletionsToRows(events),
+	}
+}
+
+// deletionsToRows converts deletion audit events to table rows
+func (o *WhoDeletedOptions) deletionsToRows(events []auditv1.Event) []metav1.TableRow {
+	rows := make([]metav1.TableRow, 0, len(events))
+	for i := range events {
+		timestamp := "<unknown>"
+		if !events[i].StageTimestamp.IsZero() {
+			timestamp = events[i].StageTimestamp.Format("2006-01-02 15:04:05")
+		} else if !events[i].RequestReceivedTimestamp.IsZero() {
+			timestamp = events[i].RequestReceivedTimestamp.Format("2006-01-02 15:04:05")
+		}
+
+		sourceIP := "<unknown>"
+		if len(events[i].SourceIPs) > 0 {
+			sourceIP = strings.Join(events[i].SourceIPs, ",")
+		}
+
+		status := ""
+		if events[i].ResponseStatus != nil {
+			status = fmt.Sprintf("%d", events[i].ResponseStatus.Code)
+		}
+
+		rows = append(rows, metav1.TableRow{
+			Cells: []interface{}{timestamp, events[i].Verb, events[i].User.Username, sourceIP, status},
+		})
+	}
+	return rows
+}
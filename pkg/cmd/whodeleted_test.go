@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authnv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"go.miloapis.com/activity/internal/cel"
+)
+
+func TestWhoDeletedOptions_buildFilter(t *testing.T) {
+	tests := []struct {
+		name      string
+		namespace string
+		resource  string
+		resName   string
+		want      string
+	}{
+		{
+			name:     "cluster-scoped resource",
+			resource: "domains",
+			resName:  "example-com",
+			want:     "objectRef.resource == 'domains' && (objectRef.name == 'example-com' || verb == 'deletecollection') && verb in ['delete', 'deletecollection']",
+		},
+		{
+			name:      "namespaced resource",
+			namespace: "production",
+			resource:  "configmaps",
+			resName:   "app-config",
+			want:      "objectRef.resource == 'configmaps' && (objectRef.name == 'app-config' || verb == 'deletecollection') && verb in ['delete', 'deletecollection'] && objectRef.namespace == 'production'",
+		},
+		{
+			name:     "name with quote is escaped",
+			resource: "secrets",
+			resName:  "it's",
+			want:     "objectRef.resource == 'secrets' && (objectRef.name == 'it\\'s' || verb == 'deletecollection') && verb in ['delete', 'deletecollection']",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &WhoDeletedOptions{
+				Namespace: tt.namespace,
+				Resource:  tt.resource,
+				Name:      tt.resName,
+			}
+			got := o.buildFilter()
+			assert.Equal(t, tt.want, got)
+
+			// The filter must be accepted by the server
+			_, err := cel.CompileFilter(got)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestHistoryOptions_buildFilter_DefaultVerbs(t *testing.T) {
+	o := &HistoryOptions{Resource: "configmaps", Name: "app-config"}
+
+	assert.Equal(t,
+		"objectRef.resource == 'configmaps' && (objectRef.name == 'app-config' || verb == 'deletecollection') && verb in ['create', 'update', 'patch', 'delete', 'deletecollection'] && !has(objectRef.subresource)",
+		o.buildFilter())
+}
+
+func TestWhoDeletedOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    *WhoDeletedOptions
+		wantErr string
+	}{
+		{
+			name:    "missing resource",
+			opts:    &WhoDeletedOptions{Name: "app-config"},
+			wantErr: "resource type is required",
+		},
+		{
+			name:    "missing name",
+			opts:    &WhoDeletedOptions{Resource: "configmaps"},
+			wantErr: "resource name is required",
+		},
+		{
+			name: "valid",
+			opts: NewWhoDeletedOptions(nil, genericclioptions.IOStreams{}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantErr == "" {
+				tt.opts.Resource = "configmaps"
+				tt.opts.Name = "app-config"
+			}
+			err := tt.opts.Validate()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestWhoDeletedOptions_Complete(t *testing.T) {
+	o := &WhoDeletedOptions{}
+
+	err := o.Complete(nil, []string{"configmaps"})
+	require.Error(t, err)
+
+	err = o.Complete(nil, []string{"configmaps", "app-config"})
+	require.NoError(t, err)
+	assert.Equal(t, "configmaps", o.Resource)
+	assert.Equal(t, "app-config", o.Name)
+}
+
+func TestDeletionsToRows(t *testing.T) {
+	first := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	second := time.Date(2026, 10, 8, 17, 30, 0, 0, time.UTC)
+
+	events := []auditv1.Event{
+		{
+			Verb:           "delete",
+			User:           authnv1.UserInfo{Username: "alice@example.com"},
+			SourceIPs:      []string{"203.0.113.7"},
+			StageTimestamp: metav1.NewMicroTime(first),
+			ResponseStatus: &metav1.Status{Code: 200},
+		},
+		{
+			Verb:           "deletecollection",
+			User:           authnv1.UserInfo{Username: "bob@example.com"},
+			SourceIPs:      []string{"198.51.100.2", "10.0.0.1"},
+			StageTimestamp: metav1.NewMicroTime(second),
+			ResponseStatus: &metav1.Status{Code: 200},
+		},
+		{
+			Verb: "delete",
+			User: authnv1.UserInfo{Username: "system:serviceaccount:kube-system:gc"},
+		},
+	}
+
+	o := &WhoDeletedOptions{}
+	rows := o.deletionsToRows(events)
+
+	require.Len(t, rows, 3)
+	assert.Equal(t, []interface{}{"2026-10-01 09:00:00", "delete", "alice@example.com", "203.0.113.7", "200"}, rows[0].Cells)
+	assert.Equal(t, []interface{}{"2026-10-08 17:30:00", "deletecollection", "bob@example.com", "198.51.100.2,10.0.0.1", "200"}, rows[1].Cells)
+	assert.Equal(t, []interface{}{"<unknown>", "delete", "system:serviceaccount:kube-system:gc", "<unknown>", ""}, rows[2].Cells)
+
+	table := o.deletionsToTable(events)
+	assert.Len(t, table.ColumnDefinitions, 5)
+	assert.Equal(t, "Source IP", table.ColumnDefinitions[3].Name)
+}
+
+func TestHistoryOptions_buildFilter_NoCollectionVerb(t *testing.T) {
+	o := &HistoryOptions{Resource: "configmaps", Name: "app-config", Verbs: []string{"update", "patch"}}
+
+	assert.Equal(t,
+		"objectRef.resource == 'configmaps' && objectRef.name == 'app-config' && verb in ['update', 'patch'] && !has(objectRef.subresource)",
+		o.buildFilter())
+}
@@ -21,386 +21,403 @@ import (
 
 func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
 	return map[string]common.OpenAPIDefinition{
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.Activity":                  schema_pkg_apis_activity_v1alpha1_Activity(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityActor":             schema_pkg_apis_activity_v1alpha1_ActivityActor(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityChange":            schema_pkg_apis_activity_v1alpha1_ActivityChange(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityFacetQuery":        schema_pkg_apis_activity_v1alpha1_ActivityFacetQuery(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityFacetQuerySpec":    schema_pkg_apis_activity_v1alpha1_ActivityFacetQuerySpec(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityFacetQueryStatus":  schema_pkg_apis_activity_v1alpha1_ActivityFacetQueryStatus(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityLink":              schema_pkg_apis_activity_v1alpha1_ActivityLink(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityList":              schema_pkg_apis_activity_v1alpha1_ActivityList(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityOrigin":            schema_pkg_apis_activity_v1alpha1_ActivityOrigin(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityPolicy":            schema_pkg_apis_activity_v1alpha1_ActivityPolicy(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityPolicyList":        schema_pkg_apis_activity_v1alpha1_ActivityPolicyList(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityPolicyResource":    schema_pkg_apis_activity_v1alpha1_ActivityPolicyResource(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityPolicyRule":        schema_pkg_apis_activity_v1alpha1_ActivityPolicyRule(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityPolicySpec":        schema_pkg_apis_activity_v1alpha1_ActivityPolicySpec(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityPolicyStatus":      schema_pkg_apis_activity_v1alpha1_ActivityPolicyStatus(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityQuery":             schema_pkg_apis_activity_v1alpha1_ActivityQuery(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityQuerySpec":         schema_pkg_apis_activity_v1alpha1_ActivityQuerySpec(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityQueryStatus":       schema_pkg_apis_activity_v1alpha1_ActivityQueryStatus(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityResource":          schema_pkg_apis_activity_v1alpha1_ActivityResource(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivitySpec":              schema_pkg_apis_activity_v1alpha1_ActivitySpec(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityTenant":            schema_pkg_apis_activity_v1alpha1_ActivityTenant(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.AuditLogFacetsQuery":       schema_pkg_apis_activity_v1alpha1_AuditLogFacetsQuery(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.AuditLogFacetsQuerySpec":   schema_pkg_apis_activity_v1alpha1_AuditLogFacetsQuerySpec(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.AuditLogFacetsQueryStatus": schema_pkg_apis_activity_v1alpha1_AuditLogFacetsQueryStatus(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.AuditLogQuery":             schema_pkg_apis_activity_v1alpha1_AuditLogQuery(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.AuditLogQuerySpec":         schema_pkg_apis_activity_v1alpha1_AuditLogQuerySpec(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.AuditLogQueryStatus":       schema_pkg_apis_activity_v1alpha1_AuditLogQueryStatus(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.AutoFetchSpec":             schema_pkg_apis_activity_v1alpha1_AutoFetchSpec(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.EventFacetQuery":           schema_pkg_apis_activity_v1alpha1_EventFacetQuery(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.EventFacetQuerySpec":       schema_pkg_apis_activity_v1alpha1_EventFacetQuerySpec(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.EventFacetQueryStatus":     schema_pkg_apis_activity_v1alpha1_EventFacetQueryStatus(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.EventQuery":                schema_pkg_apis_activity_v1alpha1_EventQuery(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.EventQueryList":            schema_pkg_apis_activity_v1alpha1_EventQueryList(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.EventQuerySpec":            schema_pkg_apis_activity_v1alpha1_EventQuerySpec(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.EventQueryStatus":          schema_pkg_apis_activity_v1alpha1_EventQueryStatus(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.EventRecord":               schema_pkg_apis_activity_v1alpha1_EventRecord(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.FacetResult":               schema_pkg_apis_activity_v1alpha1_FacetResult(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.FacetSpec":                 schema_pkg_apis_activity_v1alpha1_FacetSpec(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.FacetTimeRange":            schema_pkg_apis_activity_v1alpha1_FacetTimeRange(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.FacetValue":                schema_pkg_apis_activity_v1alpha1_FacetValue(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.PolicyPreview":             schema_pkg_apis_activity_v1alpha1_PolicyPreview(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.PolicyPreviewInput":        schema_pkg_apis_activity_v1alpha1_PolicyPreviewInput(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.PolicyPreviewInputResult":  schema_pkg_apis_activity_v1alpha1_PolicyPreviewInputResult(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.PolicyPreviewSpec":         schema_pkg_apis_activity_v1alpha1_PolicyPreviewSpec(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.PolicyPreviewStatus":       schema_pkg_apis_activity_v1alpha1_PolicyPreviewStatus(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ReindexConfig":             schema_pkg_apis_activity_v1alpha1_ReindexConfig(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ReindexJob":                schema_pkg_apis_activity_v1alpha1_ReindexJob(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ReindexJobList":            schema_pkg_apis_activity_v1alpha1_ReindexJobList(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ReindexJobSpec":            schema_pkg_apis_activity_v1alpha1_ReindexJobSpec(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ReindexJobStatus":          schema_pkg_apis_activity_v1alpha1_ReindexJobStatus(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ReindexPolicySelector":     schema_pkg_apis_activity_v1alpha1_ReindexPolicySelector(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ReindexProgress":           schema_pkg_apis_activity_v1alpha1_ReindexProgress(ref),
-		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ReindexTimeRange":          schema_pkg_apis_activity_v1alpha1_ReindexTimeRange(ref),
-		v1.BoundObjectReference{}.OpenAPIModelName():                                    schema_k8sio_api_authentication_v1_BoundObjectReference(ref),
-		v1.SelfSubjectReview{}.OpenAPIModelName():                                       schema_k8sio_api_authentication_v1_SelfSubjectReview(ref),
-		v1.SelfSubjectReviewStatus{}.OpenAPIModelName():                                 schema_k8sio_api_authentication_v1_SelfSubjectReviewStatus(ref),
-		v1.TokenRequest{}.OpenAPIModelName():                                            schema_k8sio_api_authentication_v1_TokenRequest(ref),
-		v1.TokenRequestSpec{}.OpenAPIModelName():                                        schema_k8sio_api_authentication_v1_TokenRequestSpec(ref),
-		v1.TokenRequestStatus{}.OpenAPIModelName():                                      schema_k8sio_api_authentication_v1_TokenRequestStatus(ref),
-		v1.TokenReview{}.OpenAPIModelName():                                             schema_k8sio_api_authentication_v1_TokenReview(ref),
-		v1.TokenReviewSpec{}.OpenAPIModelName():                                         schema_k8sio_api_authentication_v1_TokenReviewSpec(ref),
-		v1.TokenReviewStatus{}.OpenAPIModelName():                                       schema_k8sio_api_authentication_v1_TokenReviewStatus(ref),
-		v1.UserInfo{}.OpenAPIModelName():                                                schema_k8sio_api_authentication_v1_UserInfo(ref),
-		authorizationv1.FieldSelectorAttributes{}.OpenAPIModelName():                    schema_k8sio_api_authorization_v1_FieldSelectorAttributes(ref),
-		authorizationv1.LabelSelectorAttributes{}.OpenAPIModelName():                    schema_k8sio_api_authorization_v1_LabelSelectorAttributes(ref),
-		authorizationv1.LocalSubjectAccessReview{}.OpenAPIModelName():                   schema_k8sio_api_authorization_v1_LocalSubjectAccessReview(ref),
-		authorizationv1.NonResourceAttributes{}.OpenAPIModelName():                      schema_k8sio_api_authorization_v1_NonResourceAttributes(ref),
-		authorizationv1.NonResourceRule{}.OpenAPIModelName():                            schema_k8sio_api_authorization_v1_NonResourceRule(ref),
-		authorizationv1.ResourceAttributes{}.OpenAPIModelName():                         schema_k8sio_api_authorization_v1_ResourceAttributes(ref),
-		authorizationv1.ResourceRule{}.OpenAPIModelName():                               schema_k8sio_api_authorization_v1_ResourceRule(ref),
-		authorizationv1.SelfSubjectAccessReview{}.OpenAPIModelName():                    schema_k8sio_api_authorization_v1_SelfSubjectAccessReview(ref),
-		authorizationv1.SelfSubjectAccessReviewSpec{}.OpenAPIModelName():                schema_k8sio_api_authorization_v1_SelfSubjectAccessReviewSpec(ref),
-		authorizationv1.SelfSubjectRulesReview{}.OpenAPIModelName():                     schema_k8sio_api_authorization_v1_SelfSubjectRulesReview(ref),
-		authorizationv1.SelfSubjectRulesReviewSpec{}.OpenAPIModelName():                 schema_k8sio_api_authorization_v1_SelfSubjectRulesReviewSpec(ref),
-		authorizationv1.SubjectAccessReview{}.OpenAPIModelName():                        schema_k8sio_api_authorization_v1_SubjectAccessReview(ref),
-		authorizationv1.SubjectAccessReviewSpec{}.OpenAPIModelName():                    schema_k8sio_api_authorization_v1_SubjectAccessReviewSpec(ref),
-		authorizationv1.SubjectAccessReviewStatus{}.OpenAPIModelName():                  schema_k8sio_api_authorization_v1_SubjectAccessReviewStatus(ref),
-		authorizationv1.SubjectRulesReviewStatus{}.OpenAPIModelName():                   schema_k8sio_api_authorization_v1_SubjectRulesReviewStatus(ref),
-		corev1.AWSElasticBlockStoreVolumeSource{}.OpenAPIModelName():                    schema_k8sio_api_core_v1_AWSElasticBlockStoreVolumeSource(ref),
-		corev1.Affinity{}.OpenAPIModelName():                                            schema_k8sio_api_core_v1_Affinity(ref),
-		corev1.AppArmorProfile{}.OpenAPIModelName():                                     schema_k8sio_api_core_v1_AppArmorProfile(ref),
-		corev1.AttachedVolume{}.OpenAPIModelName():                                      schema_k8sio_api_core_v1_AttachedVolume(ref),
-		corev1.AvoidPods{}.OpenAPIModelName():                                           schema_k8sio_api_core_v1_AvoidPods(ref),
-		corev1.AzureDiskVolumeSource{}.OpenAPIModelName():                               schema_k8sio_api_core_v1_AzureDiskVolumeSource(ref),
-		corev1.AzureFilePersistentVolumeSource{}.OpenAPIModelName():                     schema_k8sio_api_core_v1_AzureFilePersistentVolumeSource(ref),
-		corev1.AzureFileVolumeSource{}.OpenAPIModelName():                               schema_k8sio_api_core_v1_AzureFileVolumeSource(ref),
-		corev1.Binding{}.OpenAPIModelName():                                             schema_k8sio_api_core_v1_Binding(ref),
-		corev1.CSIPersistentVolumeSource{}.OpenAPIModelName():                           schema_k8sio_api_core_v1_CSIPersistentVolumeSource(ref),
-		corev1.CSIVolumeSource{}.OpenAPIModelName():                                     schema_k8sio_api_core_v1_CSIVolumeSource(ref),
-		corev1.Capabilities{}.OpenAPIModelName():                                        schema_k8sio_api_core_v1_Capabilities(ref),
-		corev1.CephFSPersistentVolumeSource{}.OpenAPIModelName():                        schema_k8sio_api_core_v1_CephFSPersistentVolumeSource(ref),
-		corev1.CephFSVolumeSource{}.OpenAPIModelName():                                  schema_k8sio_api_core_v1_CephFSVolumeSource(ref),
-		corev1.CinderPersistentVolumeSource{}.OpenAPIModelName():                        schema_k8sio_api_core_v1_CinderPersistentVolumeSource(ref),
-		corev1.CinderVolumeSource{}.OpenAPIModelName():                                  schema_k8sio_api_core_v1_CinderVolumeSource(ref),
-		corev1.ClientIPConfig{}.OpenAPIModelName():                                      schema_k8sio_api_core_v1_ClientIPConfig(ref),
-		corev1.ClusterTrustBundleProjection{}.OpenAPIModelName():                        schema_k8sio_api_core_v1_ClusterTrustBundleProjection(ref),
-		corev1.ComponentCondition{}.OpenAPIModelName():                                  schema_k8sio_api_core_v1_ComponentCondition(ref),
-		corev1.ComponentStatus{}.OpenAPIModelName():                                     schema_k8sio_api_core_v1_ComponentStatus(ref),
-		corev1.ComponentStatusList{}.OpenAPIModelName():                                 schema_k8sio_api_core_v1_ComponentStatusList(ref),
-		corev1.ConfigMap{}.OpenAPIModelName():                                           schema_k8sio_api_core_v1_ConfigMap(ref),
-		corev1.ConfigMapEnvSource{}.OpenAPIModelName():                                  schema_k8sio_api_core_v1_ConfigMapEnvSource(ref),
-		corev1.ConfigMapKeySelector{}.OpenAPIModelName():                                schema_k8sio_api_core_v1_ConfigMapKeySelector(ref),
-		corev1.ConfigMapList{}.OpenAPIModelName():                                       schema_k8sio_api_core_v1_ConfigMapList(ref),
-		corev1.ConfigMapNodeConfigSource{}.OpenAPIModelName():                           schema_k8sio_api_core_v1_ConfigMapNodeConfigSource(ref),
-		corev1.ConfigMapProjection{}.OpenAPIModelName():                                 schema_k8sio_api_core_v1_ConfigMapProjection(ref),
-		corev1.ConfigMapVolumeSource{}.OpenAPIModelName():                               schema_k8sio_api_core_v1_ConfigMapVolumeSource(ref),
-		corev1.Container{}.OpenAPIModelName():                                           schema_k8sio_api_core_v1_Container(ref),
-		corev1.ContainerExtendedResourceRequest{}.OpenAPIModelName():                    schema_k8sio_api_core_v1_ContainerExtendedResourceRequest(ref),
-		corev1.ContainerImage{}.OpenAPIModelName():                                      schema_k8sio_api_core_v1_ContainerImage(ref),
-		corev1.ContainerPort{}.OpenAPIModelName():                                       schema_k8sio_api_core_v1_ContainerPort(ref),
-		corev1.ContainerResizePolicy{}.OpenAPIModelName():                               schema_k8sio_api_core_v1_ContainerResizePolicy(ref),
-		corev1.ContainerRestartRule{}.OpenAPIModelName():                                schema_k8sio_api_core_v1_ContainerRestartRule(ref),
-		corev1.ContainerRestartRuleOnExitCodes{}.OpenAPIModelName():                     schema_k8sio_api_core_v1_ContainerRestartRuleOnExitCodes(ref),
-		corev1.ContainerState{}.OpenAPIModelName():                                      schema_k8sio_api_core_v1_ContainerState(ref),
-		corev1.ContainerStateRunning{}.OpenAPIModelName():                               schema_k8sio_api_core_v1_ContainerStateRunning(ref),
-		corev1.ContainerStateTerminated{}.OpenAPIModelName():                            schema_k8sio_api_core_v1_ContainerStateTerminated(ref),
-		corev1.ContainerStateWaiting{}.OpenAPIModelName():                               schema_k8sio_api_core_v1_ContainerStateWaiting(ref),
-		corev1.ContainerStatus{}.OpenAPIModelName():                                     schema_k8sio_api_core_v1_ContainerStatus(ref),
-		corev1.ContainerUser{}.OpenAPIModelName():                                       schema_k8sio_api_core_v1_ContainerUser(ref),
-		corev1.DaemonEndpoint{}.OpenAPIModelName():                                      schema_k8sio_api_core_v1_DaemonEndpoint(ref),
-		corev1.DownwardAPIProjection{}.OpenAPIModelName():                               schema_k8sio_api_core_v1_DownwardAPIProjection(ref),
-		corev1.DownwardAPIVolumeFile{}.OpenAPIModelName():                               schema_k8sio_api_core_v1_DownwardAPIVolumeFile(ref),
-		corev1.DownwardAPIVolumeSource{}.OpenAPIModelName():                             schema_k8sio_api_core_v1_DownwardAPIVolumeSource(ref),
-		corev1.EmptyDirVolumeSource{}.OpenAPIModelName():                                schema_k8sio_api_core_v1_EmptyDirVolumeSource(ref),
-		corev1.EndpointAddress{}.OpenAPIModelName():                                     schema_k8sio_api_core_v1_EndpointAddress(ref),
-		corev1.EndpointPort{}.OpenAPIModelName():                                        schema_k8sio_api_core_v1_EndpointPort(ref),
-		corev1.EndpointSubset{}.OpenAPIModelName():                                      schema_k8sio_api_core_v1_EndpointSubset(ref),
-		corev1.Endpoints{}.OpenAPIModelName():                                           schema_k8sio_api_core_v1_Endpoints(ref),
-		corev1.EndpointsList{}.OpenAPIModelName():                                       schema_k8sio_api_core_v1_EndpointsList(ref),
-		corev1.EnvFromSource{}.OpenAPIModelName():                                       schema_k8sio_api_core_v1_EnvFromSource(ref),
-		corev1.EnvVar{}.OpenAPIModelName():                                              schema_k8sio_api_core_v1_EnvVar(ref),
-		corev1.EnvVarSource{}.OpenAPIModelName():                                        schema_k8sio_api_core_v1_EnvVarSource(ref),
-		corev1.EphemeralContainer{}.OpenAPIModelName():                                  schema_k8sio_api_core_v1_EphemeralContainer(ref),
-		corev1.EphemeralContainerCommon{}.OpenAPIModelName():                            schema_k8sio_api_core_v1_EphemeralContainerCommon(ref),
-		corev1.EphemeralVolumeSource{}.OpenAPIModelName():                               schema_k8sio_api_core_v1_EphemeralVolumeSource(ref),
-		corev1.Event{}.OpenAPIModelName():                                               schema_k8sio_api_core_v1_Event(ref),
-		corev1.EventList{}.OpenAPIModelName():                                           schema_k8sio_api_core_v1_EventList(ref),
-		corev1.EventSeries{}.OpenAPIModelName():                                         schema_k8sio_api_core_v1_EventSeries(ref),
-		corev1.EventSource{}.OpenAPIModelName():                                         schema_k8sio_api_core_v1_EventSource(ref),
-		corev1.ExecAction{}.OpenAPIModelName():                                          schema_k8sio_api_core_v1_ExecAction(ref),
-		corev1.FCVolumeSource{}.OpenAPIModelName():                                      schema_k8sio_api_core_v1_FCVolumeSource(ref),
-		corev1.FileKeySelector{}.OpenAPIModelName():                                     schema_k8sio_api_core_v1_FileKeySelector(ref),
-		corev1.FlexPersistentVolumeSource{}.OpenAPIModelName():                          schema_k8sio_api_core_v1_FlexPersistentVolumeSource(ref),
-		corev1.FlexVolumeSource{}.OpenAPIModelName():                                    schema_k8sio_api_core_v1_FlexVolumeSource(ref),
-		corev1.FlockerVolumeSource{}.OpenAPIModelName():                                 schema_k8sio_api_core_v1_FlockerVolumeSource(ref),
-		corev1.GCEPersistentDiskVolumeSource{}.OpenAPIModelName():                       schema_k8sio_api_core_v1_GCEPersistentDiskVolumeSource(ref),
-		corev1.GRPCAction{}.OpenAPIModelName():                                          schema_k8sio_api_core_v1_GRPCAction(ref),
-		corev1.GitRepoVolumeSource{}.OpenAPIModelName():                                 schema_k8sio_api_core_v1_GitRepoVolumeSource(ref),
-		corev1.GlusterfsPersistentVolumeSource{}.OpenAPIModelName():                     schema_k8sio_api_core_v1_GlusterfsPersistentVolumeSource(ref),
-		corev1.GlusterfsVolumeSource{}.OpenAPIModelName():                               schema_k8sio_api_core_v1_GlusterfsVolumeSource(ref),
-		corev1.HTTPGetAction{}.OpenAPIModelName():                                       schema_k8sio_api_core_v1_HTTPGetAction(ref),
-		corev1.HTTPHeader{}.OpenAPIModelName():                                          schema_k8sio_api_core_v1_HTTPHeader(ref),
-		corev1.HostAlias{}.OpenAPIModelName():                                           schema_k8sio_api_core_v1_HostAlias(ref),
-		corev1.HostIP{}.OpenAPIModelName():                                              schema_k8sio_api_core_v1_HostIP(ref),
-		corev1.HostPathVolumeSource{}.OpenAPIModelName():                                schema_k8sio_api_core_v1_HostPathVolumeSource(ref),
-		corev1.ISCSIPersistentVolumeSource{}.OpenAPIModelName():                         schema_k8sio_api_core_v1_ISCSIPersistentVolumeSource(ref),
-		corev1.ISCSIVolumeSource{}.OpenAPIModelName():                                   schema_k8sio_api_core_v1_ISCSIVolumeSource(ref),
-		corev1.ImageVolumeSource{}.OpenAPIModelName():                                   schema_k8sio_api_core_v1_ImageVolumeSource(ref),
-		corev1.KeyToPath{}.OpenAPIModelName():                                           schema_k8sio_api_core_v1_KeyToPath(ref),
-		corev1.Lifecycle{}.OpenAPIModelName():                                           schema_k8sio_api_core_v1_Lifecycle(ref),
-		corev1.LifecycleHandler{}.OpenAPIModelName():                                    schema_k8sio_api_core_v1_LifecycleHandler(ref),
-		corev1.LimitRange{}.OpenAPIModelName():                                          schema_k8sio_api_core_v1_LimitRange(ref),
-		corev1.LimitRangeItem{}.OpenAPIModelName():                                      schema_k8sio_api_core_v1_LimitRangeItem(ref),
-		corev1.LimitRangeList{}.OpenAPIModelName():                                      schema_k8sio_api_core_v1_LimitRangeList(ref),
-		corev1.LimitRangeSpec{}.OpenAPIModelName():                                      schema_k8sio_api_core_v1_LimitRangeSpec(ref),
-		corev1.LinuxContainerUser{}.OpenAPIModelName():                                  schema_k8sio_api_core_v1_LinuxContainerUser(ref),
-		corev1.List{}.OpenAPIModelName():                                                schema_k8sio_api_core_v1_List(ref),
-		corev1.LoadBalancerIngress{}.OpenAPIModelName():                                 schema_k8sio_api_core_v1_LoadBalancerIngress(ref),
-		corev1.LoadBalancerStatus{}.OpenAPIModelName():                                  schema_k8sio_api_core_v1_LoadBalancerStatus(ref),
-		corev1.LocalObjectReference{}.OpenAPIModelName():                                schema_k8sio_api_core_v1_LocalObjectReference(ref),
-		corev1.LocalVolumeSource{}.OpenAPIModelName():                                   schema_k8sio_api_core_v1_LocalVolumeSource(ref),
-		corev1.ModifyVolumeStatus{}.OpenAPIModelName():                                  schema_k8sio_api_core_v1_ModifyVolumeStatus(ref),
-		corev1.NFSVolumeSource{}.OpenAPIModelName():                                     schema_k8sio_api_core_v1_NFSVolumeSource(ref),
-		corev1.Namespace{}.OpenAPIModelName():                                           schema_k8sio_api_core_v1_Namespace(ref),
-		corev1.NamespaceCondition{}.OpenAPIModelName():                                  schema_k8sio_api_core_v1_NamespaceCondition(ref),
-		corev1.NamespaceList{}.OpenAPIModelName():                                       schema_k8sio_api_core_v1_NamespaceList(ref),
-		corev1.NamespaceSpec{}.OpenAPIModelName():                                       schema_k8sio_api_core_v1_NamespaceSpec(ref),
-		corev1.NamespaceStatus{}.OpenAPIModelName():                                     schema_k8sio_api_core_v1_NamespaceStatus(ref),
-		corev1.Node{}.OpenAPIModelName():                                                schema_k8sio_api_core_v1_Node(ref),
-		corev1.NodeAddress{}.OpenAPIModelName():                                         schema_k8sio_api_core_v1_NodeAddress(ref),
-		corev1.NodeAffinity{}.OpenAPIModelName():                                        schema_k8sio_api_core_v1_NodeAffinity(ref),
-		corev1.NodeCondition{}.OpenAPIModelName():                                       schema_k8sio_api_core_v1_NodeCondition(ref),
-		corev1.NodeConfigSource{}.OpenAPIModelName():                                    schema_k8sio_api_core_v1_NodeConfigSource(ref),
-		corev1.NodeConfigStatus{}.OpenAPIModelName():                                    schema_k8sio_api_core_v1_NodeConfigStatus(ref),
-		corev1.NodeDaemonEndpoints{}.OpenAPIModelName():                                 schema_k8sio_api_core_v1_NodeDaemonEndpoints(ref),
-		corev1.NodeFeatures{}.OpenAPIModelName():                                        schema_k8sio_api_core_v1_NodeFeatures(ref),
-		corev1.NodeList{}.OpenAPIModelName():                                            schema_k8sio_api_core_v1_NodeList(ref),
-		corev1.NodeProxyOptions{}.OpenAPIModelName():                                    schema_k8sio_api_core_v1_NodeProxyOptions(ref),
-		corev1.NodeRuntimeHandler{}.OpenAPIModelName():                                  schema_k8sio_api_core_v1_NodeRuntimeHandler(ref),
-		corev1.NodeRuntimeHandlerFeatures{}.OpenAPIModelName():                          schema_k8sio_api_core_v1_NodeRuntimeHandlerFeatures(ref),
-		corev1.NodeSelector{}.OpenAPIModelName():                                        schema_k8sio_api_core_v1_NodeSelector(ref),
-		corev1.NodeSelectorRequirement{}.OpenAPIModelName():                             schema_k8sio_api_core_v1_NodeSelectorRequirement(ref),
-		corev1.NodeSelectorTerm{}.OpenAPIModelName():                                    schema_k8sio_api_core_v1_NodeSelectorTerm(ref),
-		corev1.NodeSpec{}.OpenAPIModelName():                                            schema_k8sio_api_core_v1_NodeSpec(ref),
-		corev1.NodeStatus{}.OpenAPIModelName():                                          schema_k8sio_api_core_v1_NodeStatus(ref),
-		corev1.NodeSwapStatus{}.OpenAPIModelName():                                      schema_k8sio_api_core_v1_NodeSwapStatus(ref),
-		corev1.NodeSystemInfo{}.OpenAPIModelName():                                      schema_k8sio_api_core_v1_NodeSystemInfo(ref),
-		corev1.ObjectFieldSelector{}.OpenAPIModelName():                                 schema_k8sio_api_core_v1_ObjectFieldSelector(ref),
-		corev1.ObjectReference{}.OpenAPIModelName():                                     schema_k8sio_api_core_v1_ObjectReference(ref),
-		corev1.PersistentVolume{}.OpenAPIModelName():                                    schema_k8sio_api_core_v1_PersistentVolume(ref),
-		corev1.PersistentVolumeClaim{}.OpenAPIModelName():                               schema_k8sio_api_core_v1_PersistentVolumeClaim(ref),
-		corev1.PersistentVolumeClaimCondition{}.OpenAPIModelName():                      schema_k8sio_api_core_v1_PersistentVolumeClaimCondition(ref),
-		corev1.PersistentVolumeClaimList{}.OpenAPIModelName():                           schema_k8sio_api_core_v1_PersistentVolumeClaimList(ref),
-		corev1.PersistentVolumeClaimSpec{}.OpenAPIModelName():                           schema_k8sio_api_core_v1_PersistentVolumeClaimSpec(ref),
-		corev1.PersistentVolumeClaimStatus{}.OpenAPIModelName():                         schema_k8sio_api_core_v1_PersistentVolumeClaimStatus(ref),
-		corev1.PersistentVolumeClaimTemplate{}.OpenAPIModelName():                       schema_k8sio_api_core_v1_PersistentVolumeClaimTemplate(ref),
-		corev1.PersistentVolumeClaimVolumeSource{}.OpenAPIModelName():                   schema_k8sio_api_core_v1_PersistentVolumeClaimVolumeSource(ref),
-		corev1.PersistentVolumeList{}.OpenAPIModelName():                                schema_k8sio_api_core_v1_PersistentVolumeList(ref),
-		corev1.PersistentVolumeSource{}.OpenAPIModelName():                              schema_k8sio_api_core_v1_PersistentVolumeSource(ref),
-		corev1.PersistentVolumeSpec{}.OpenAPIModelName():                                schema_k8sio_api_core_v1_PersistentVolumeSpec(ref),
-		corev1.PersistentVolumeStatus{}.OpenAPIModelName():                              schema_k8sio_api_core_v1_PersistentVolumeStatus(ref),
-		corev1.PhotonPersistentDiskVolumeSource{}.OpenAPIModelName():                    schema_k8sio_api_core_v1_PhotonPersistentDiskVolumeSource(ref),
-		corev1.Pod{}.OpenAPIModelName():                                                 schema_k8sio_api_core_v1_Pod(ref),
-		corev1.PodAffinity{}.OpenAPIModelName():                                         schema_k8sio_api_core_v1_PodAffinity(ref),
-		corev1.PodAffinityTerm{}.OpenAPIModelName():                                     schema_k8sio_api_core_v1_PodAffinityTerm(ref),
-		corev1.PodAntiAffinity{}.OpenAPIModelName():                                     schema_k8sio_api_core_v1_PodAntiAffinity(ref),
-		corev1.PodAttachOptions{}.OpenAPIModelName():                                    schema_k8sio_api_core_v1_PodAttachOptions(ref),
-		corev1.PodCertificateProjection{}.OpenAPIModelName():                            schema_k8sio_api_core_v1_PodCertificateProjection(ref),
-		corev1.PodCondition{}.OpenAPIModelName():                                        schema_k8sio_api_core_v1_PodCondition(ref),
-		corev1.PodDNSConfig{}.OpenAPIModelName():                                        schema_k8sio_api_core_v1_PodDNSConfig(ref),
-		corev1.PodDNSConfigOption{}.OpenAPIModelName():                                  schema_k8sio_api_core_v1_PodDNSConfigOption(ref),
-		corev1.PodExecOptions{}.OpenAPIModelName():                                      schema_k8sio_api_core_v1_PodExecOptions(ref),
-		corev1.PodExtendedResourceClaimStatus{}.OpenAPIModelName():                      schema_k8sio_api_core_v1_PodExtendedResourceClaimStatus(ref),
-		corev1.PodIP{}.OpenAPIModelName():                                               schema_k8sio_api_core_v1_PodIP(ref),
-		corev1.PodList{}.OpenAPIModelName():                                             schema_k8sio_api_core_v1_PodList(ref),
-		corev1.PodLogOptions{}.OpenAPIModelName():                                       schema_k8sio_api_core_v1_PodLogOptions(ref),
-		corev1.PodOS{}.OpenAPIModelName():                                               schema_k8sio_api_core_v1_PodOS(ref),
-		corev1.PodPortForwardOptions{}.OpenAPIModelName():                               schema_k8sio_api_core_v1_PodPortForwardOptions(ref),
-		corev1.PodProxyOptions{}.OpenAPIModelName():                                     schema_k8sio_api_core_v1_PodProxyOptions(ref),
-		corev1.PodReadinessGate{}.OpenAPIModelName():                                    schema_k8sio_api_core_v1_PodReadinessGate(ref),
-		corev1.PodResourceClaim{}.OpenAPIModelName():                                    schema_k8sio_api_core_v1_PodResourceClaim(ref),
-		corev1.PodResourceClaimStatus{}.OpenAPIModelName():                              schema_k8sio_api_core_v1_PodResourceClaimStatus(ref),
-		corev1.PodSchedulingGate{}.OpenAPIModelName():                                   schema_k8sio_api_core_v1_PodSchedulingGate(ref),
-		corev1.PodSecurityContext{}.OpenAPIModelName():                                  schema_k8sio_api_core_v1_PodSecurityContext(ref),
-		corev1.PodSignature{}.OpenAPIModelName():                                        schema_k8sio_api_core_v1_PodSignature(ref),
-		corev1.PodSpec{}.OpenAPIModelName():                                             schema_k8sio_api_core_v1_PodSpec(ref),
-		corev1.PodStatus{}.OpenAPIModelName():                                           schema_k8sio_api_core_v1_PodStatus(ref),
-		corev1.PodStatusResult{}.OpenAPIModelName():                                     schema_k8sio_api_core_v1_PodStatusResult(ref),
-		corev1.PodTemplate{}.OpenAPIModelName():                                         schema_k8sio_api_core_v1_PodTemplate(ref),
-		corev1.PodTemplateList{}.OpenAPIModelName():                                     schema_k8sio_api_core_v1_PodTemplateList(ref),
-		corev1.PodTemplateSpec{}.OpenAPIModelName():                                     schema_k8sio_api_core_v1_PodTemplateSpec(ref),
-		corev1.PortStatus{}.OpenAPIModelName():                                          schema_k8sio_api_core_v1_PortStatus(ref),
-		corev1.PortworxVolumeSource{}.OpenAPIModelName():                                schema_k8sio_api_core_v1_PortworxVolumeSource(ref),
-		corev1.PreferAvoidPodsEntry{}.OpenAPIModelName():                                schema_k8sio_api_core_v1_PreferAvoidPodsEntry(ref),
-		corev1.PreferredSchedulingTerm{}.OpenAPIModelName():                             schema_k8sio_api_core_v1_PreferredSchedulingTerm(ref),
-		corev1.Probe{}.OpenAPIModelName():                                               schema_k8sio_api_core_v1_Probe(ref),
-		corev1.ProbeHandler{}.OpenAPIModelName():                                        schema_k8sio_api_core_v1_ProbeHandler(ref),
-		corev1.ProjectedVolumeSource{}.OpenAPIModelName():                               schema_k8sio_api_core_v1_ProjectedVolumeSource(ref),
-		corev1.QuobyteVolumeSource{}.OpenAPIModelName():                                 schema_k8sio_api_core_v1_QuobyteVolumeSource(ref),
-		corev1.RBDPersistentVolumeSource{}.OpenAPIModelName():                           schema_k8sio_api_core_v1_RBDPersistentVolumeSource(ref),
-		corev1.RBDVolumeSource{}.OpenAPIModelName():                                     schema_k8sio_api_core_v1_RBDVolumeSource(ref),
-		corev1.RangeAllocation{}.OpenAPIModelName():                                     schema_k8sio_api_core_v1_RangeAllocation(ref),
-		corev1.ReplicationController{}.OpenAPIModelName():                               schema_k8sio_api_core_v1_ReplicationController(ref),
-		corev1.ReplicationControllerCondition{}.OpenAPIModelName():                      schema_k8sio_api_core_v1_ReplicationControllerCondition(ref),
-		corev1.ReplicationControllerList{}.OpenAPIModelName():                           schema_k8sio_api_core_v1_ReplicationControllerList(ref),
-		corev1.ReplicationControllerSpec{}.OpenAPIModelName():                           schema_k8sio_api_core_v1_ReplicationControllerSpec(ref),
-		corev1.ReplicationControllerStatus{}.OpenAPIModelName():                         schema_k8sio_api_core_v1_ReplicationControllerStatus(ref),
-		corev1.ResourceClaim{}.OpenAPIModelName():                                       schema_k8sio_api_core_v1_ResourceClaim(ref),
-		corev1.ResourceFieldSelector{}.OpenAPIModelName():                               schema_k8sio_api_core_v1_ResourceFieldSelector(ref),
-		corev1.ResourceHealth{}.OpenAPIModelName():                                      schema_k8sio_api_core_v1_ResourceHealth(ref),
-		corev1.ResourceQuota{}.OpenAPIModelName():                                       schema_k8sio_api_core_v1_ResourceQuota(ref),
-		corev1.ResourceQuotaList{}.OpenAPIModelName():                                   schema_k8sio_api_core_v1_ResourceQuotaList(ref),
-		corev1.ResourceQuotaSpec{}.OpenAPIModelName():                                   schema_k8sio_api_core_v1_ResourceQuotaSpec(ref),
-		corev1.ResourceQuotaStatus{}.OpenAPIModelName():                                 schema_k8sio_api_core_v1_ResourceQuotaStatus(ref),
-		corev1.ResourceRequirements{}.OpenAPIModelName():                                schema_k8sio_api_core_v1_ResourceRequirements(ref),
-		corev1.ResourceStatus{}.OpenAPIModelName():                                      schema_k8sio_api_core_v1_ResourceStatus(ref),
-		corev1.SELinuxOptions{}.OpenAPIModelName():                                      schema_k8sio_api_core_v1_SELinuxOptions(ref),
-		corev1.ScaleIOPersistentVolumeSource{}.OpenAPIModelName():                       schema_k8sio_api_core_v1_ScaleIOPersistentVolumeSource(ref),
-		corev1.ScaleIOVolumeSource{}.OpenAPIModelName():                                 schema_k8sio_api_core_v1_ScaleIOVolumeSource(ref),
-		corev1.ScopeSelector{}.OpenAPIModelName():                                       schema_k8sio_api_core_v1_ScopeSelector(ref),
-		corev1.ScopedResourceSelectorRequirement{}.OpenAPIModelName():                   schema_k8sio_api_core_v1_ScopedResourceSelectorRequirement(ref),
-		corev1.SeccompProfile{}.OpenAPIModelName():                                      schema_k8sio_api_core_v1_SeccompProfile(ref),
-		corev1.Secret{}.OpenAPIModelName():                                              schema_k8sio_api_core_v1_Secret(ref),
-		corev1.SecretEnvSource{}.OpenAPIModelName():                                     schema_k8sio_api_core_v1_SecretEnvSource(ref),
-		corev1.SecretKeySelector{}.OpenAPIModelName():                                   schema_k8sio_api_core_v1_SecretKeySelector(ref),
-		corev1.SecretList{}.OpenAPIModelName():                                          schema_k8sio_api_core_v1_SecretList(ref),
-		corev1.SecretProjection{}.OpenAPIModelName():                                    schema_k8sio_api_core_v1_SecretProjection(ref),
-		corev1.SecretReference{}.OpenAPIModelName():                                     schema_k8sio_api_core_v1_SecretReference(ref),
-		corev1.SecretVolumeSource{}.OpenAPIModelName():                                  schema_k8sio_api_core_v1_SecretVolumeSource(ref),
-		corev1.SecurityContext{}.OpenAPIModelName():                                     schema_k8sio_api_core_v1_SecurityContext(ref),
-		corev1.SerializedReference{}.OpenAPIModelName():                                 schema_k8sio_api_core_v1_SerializedReference(ref),
-		corev1.Service{}.OpenAPIModelName():                                             schema_k8sio_api_core_v1_Service(ref),
-		corev1.ServiceAccount{}.OpenAPIModelName():                                      schema_k8sio_api_core_v1_ServiceAccount(ref),
-		corev1.ServiceAccountList{}.OpenAPIModelName():                                  schema_k8sio_api_core_v1_ServiceAccountList(ref),
-		corev1.ServiceAccountTokenProjection{}.OpenAPIModelName():                       schema_k8sio_api_core_v1_ServiceAccountTokenProjection(ref),
-		corev1.ServiceList{}.OpenAPIModelName():                                         schema_k8sio_api_core_v1_ServiceList(ref),
-		corev1.ServicePort{}.OpenAPIModelName():                                         schema_k8sio_api_core_v1_ServicePort(ref),
-		corev1.ServiceProxyOptions{}.OpenAPIModelName():                                 schema_k8sio_api_core_v1_ServiceProxyOptions(ref),
-		corev1.ServiceSpec{}.OpenAPIModelName():                                         schema_k8sio_api_core_v1_ServiceSpec(ref),
-		corev1.ServiceStatus{}.OpenAPIModelName():                                       schema_k8sio_api_core_v1_ServiceStatus(ref),
-		corev1.SessionAffinityConfig{}.OpenAPIModelName():                               schema_k8sio_api_core_v1_SessionAffinityConfig(ref),
-		corev1.SleepAction{}.OpenAPIModelName():                                         schema_k8sio_api_core_v1_SleepAction(ref),
-		corev1.StorageOSPersistentVolumeSource{}.OpenAPIModelName():                     schema_k8sio_api_core_v1_StorageOSPersistentVolumeSource(ref),
-		corev1.StorageOSVolumeSource{}.OpenAPIModelName():                               schema_k8sio_api_core_v1_StorageOSVolumeSource(ref),
-		corev1.Sysctl{}.OpenAPIModelName():                                              schema_k8sio_api_core_v1_Sysctl(ref),
-		corev1.TCPSocketAction{}.OpenAPIModelName():                                     schema_k8sio_api_core_v1_TCPSocketAction(ref),
-		corev1.Taint{}.OpenAPIModelName():                                               schema_k8sio_api_core_v1_Taint(ref),
-		corev1.Toleration{}.OpenAPIModelName():                                          schema_k8sio_api_core_v1_Toleration(ref),
-		corev1.TopologySelectorLabelRequirement{}.OpenAPIModelName():                    schema_k8sio_api_core_v1_TopologySelectorLabelRequirement(ref),
-		corev1.TopologySelectorTerm{}.OpenAPIModelName():                                schema_k8sio_api_core_v1_TopologySelectorTerm(ref),
-		corev1.TopologySpreadConstraint{}.OpenAPIModelName():                            schema_k8sio_api_core_v1_TopologySpreadConstraint(ref),
-		corev1.TypedLocalObjectReference{}.OpenAPIModelName():                           schema_k8sio_api_core_v1_TypedLocalObjectReference(ref),
-		corev1.TypedObjectReference{}.OpenAPIModelName():                                schema_k8sio_api_core_v1_TypedObjectReference(ref),
-		corev1.Volume{}.OpenAPIModelName():                                              schema_k8sio_api_core_v1_Volume(ref),
-		corev1.VolumeDevice{}.OpenAPIModelName():                                        schema_k8sio_api_core_v1_VolumeDevice(ref),
-		corev1.VolumeMount{}.OpenAPIModelName():                                         schema_k8sio_api_core_v1_VolumeMount(ref),
-		corev1.VolumeMountStatus{}.OpenAPIModelName():                                   schema_k8sio_api_core_v1_VolumeMountStatus(ref),
-		corev1.VolumeNodeAffinity{}.OpenAPIModelName():                                  schema_k8sio_api_core_v1_VolumeNodeAffinity(ref),
-		corev1.VolumeProjection{}.OpenAPIModelName():                                    schema_k8sio_api_core_v1_VolumeProjection(ref),
-		corev1.VolumeResourceRequirements{}.OpenAPIModelName():                          schema_k8sio_api_core_v1_VolumeResourceRequirements(ref),
-		corev1.VolumeSource{}.OpenAPIModelName():                                        schema_k8sio_api_core_v1_VolumeSource(ref),
-		corev1.VsphereVirtualDiskVolumeSource{}.OpenAPIModelName():                      schema_k8sio_api_core_v1_VsphereVirtualDiskVolumeSource(ref),
-		corev1.WeightedPodAffinityTerm{}.OpenAPIModelName():                             schema_k8sio_api_core_v1_WeightedPodAffinityTerm(ref),
-		corev1.WindowsSecurityContextOptions{}.OpenAPIModelName():                       schema_k8sio_api_core_v1_WindowsSecurityContextOptions(ref),
-		corev1.WorkloadReference{}.OpenAPIModelName():                                   schema_k8sio_api_core_v1_WorkloadReference(ref),
-		eventsv1.Event{}.OpenAPIModelName():                                             schema_k8sio_api_events_v1_Event(ref),
-		eventsv1.EventList{}.OpenAPIModelName():                                         schema_k8sio_api_events_v1_EventList(ref),
-		eventsv1.EventSeries{}.OpenAPIModelName():                                       schema_k8sio_api_events_v1_EventSeries(ref),
-		resource.Quantity{}.OpenAPIModelName():                                          schema_apimachinery_pkg_api_resource_Quantity(ref),
-		metav1.APIGroup{}.OpenAPIModelName():                                            schema_pkg_apis_meta_v1_APIGroup(ref),
-		metav1.APIGroupList{}.OpenAPIModelName():                                        schema_pkg_apis_meta_v1_APIGroupList(ref),
-		metav1.APIResource{}.OpenAPIModelName():                                         schema_pkg_apis_meta_v1_APIResource(ref),
-		metav1.APIResourceList{}.OpenAPIModelName():                                     schema_pkg_apis_meta_v1_APIResourceList(ref),
-		metav1.APIVersions{}.OpenAPIModelName():                                         schema_pkg_apis_meta_v1_APIVersions(ref),
-		metav1.ApplyOptions{}.OpenAPIModelName():                                        schema_pkg_apis_meta_v1_ApplyOptions(ref),
-		metav1.Condition{}.OpenAPIModelName():                                           schema_pkg_apis_meta_v1_Condition(ref),
-		metav1.CreateOptions{}.OpenAPIModelName():                                       schema_pkg_apis_meta_v1_CreateOptions(ref),
-		metav1.DeleteOptions{}.OpenAPIModelName():                                       schema_pkg_apis_meta_v1_DeleteOptions(ref),
-		metav1.Duration{}.OpenAPIModelName():                                            schema_pkg_apis_meta_v1_Duration(ref),
-		metav1.FieldSelectorRequirement{}.OpenAPIModelName():                            schema_pkg_apis_meta_v1_FieldSelectorRequirement(ref),
-		metav1.FieldsV1{}.OpenAPIModelName():                                            schema_pkg_apis_meta_v1_FieldsV1(ref),
-		metav1.GetOptions{}.OpenAPIModelName():                                          schema_pkg_apis_meta_v1_GetOptions(ref),
-		metav1.GroupKind{}.OpenAPIModelName():                                           schema_pkg_apis_meta_v1_GroupKind(ref),
-		metav1.GroupResource{}.OpenAPIModelName():                                       schema_pkg_apis_meta_v1_GroupResource(ref),
-		metav1.GroupVersion{}.OpenAPIModelName():                                        schema_pkg_apis_meta_v1_GroupVersion(ref),
-		metav1.GroupVersionForDiscovery{}.OpenAPIModelName():                            schema_pkg_apis_meta_v1_GroupVersionForDiscovery(ref),
-		metav1.GroupVersionKind{}.OpenAPIModelName():                                    schema_pkg_apis_meta_v1_GroupVersionKind(ref),
-		metav1.GroupVersionResource{}.OpenAPIModelName():                                schema_pkg_apis_meta_v1_GroupVersionResource(ref),
-		metav1.InternalEvent{}.OpenAPIModelName():                                       schema_pkg_apis_meta_v1_InternalEvent(ref),
-		metav1.LabelSelector{}.OpenAPIModelName():                                       schema_pkg_apis_meta_v1_LabelSelector(ref),
-		metav1.LabelSelectorRequirement{}.OpenAPIModelName():                            schema_pkg_apis_meta_v1_LabelSelectorRequirement(ref),
-		metav1.List{}.OpenAPIModelName():                                                schema_pkg_apis_meta_v1_List(ref),
-		metav1.ListMeta{}.OpenAPIModelName():                                            schema_pkg_apis_meta_v1_ListMeta(ref),
-		metav1.ListOptions{}.OpenAPIModelName():                                         schema_pkg_apis_meta_v1_ListOptions(ref),
-		metav1.ManagedFieldsEntry{}.OpenAPIModelName():                                  schema_pkg_apis_meta_v1_ManagedFieldsEntry(ref),
-		metav1.MicroTime{}.OpenAPIModelName():                                           schema_pkg_apis_meta_v1_MicroTime(ref),
-		metav1.ObjectMeta{}.OpenAPIModelName():                                          schema_pkg_apis_meta_v1_ObjectMeta(ref),
-		metav1.OwnerReference{}.OpenAPIModelName():                                      schema_pkg_apis_meta_v1_OwnerReference(ref),
-		metav1.PartialObjectMetadata{}.OpenAPIModelName():                               schema_pkg_apis_meta_v1_PartialObjectMetadata(ref),
-		metav1.PartialObjectMetadataList{}.OpenAPIModelName():                           schema_pkg_apis_meta_v1_PartialObjectMetadataList(ref),
-		metav1.Patch{}.OpenAPIModelName():                                               schema_pkg_apis_meta_v1_Patch(ref),
-		metav1.PatchOptions{}.OpenAPIModelName():                                        schema_pkg_apis_meta_v1_PatchOptions(ref),
-		metav1.Preconditions{}.OpenAPIModelName():                                       schema_pkg_apis_meta_v1_Preconditions(ref),
-		metav1.RootPaths{}.OpenAPIModelName():                                           schema_pkg_apis_meta_v1_RootPaths(ref),
-		metav1.ServerAddressByClientCIDR{}.OpenAPIModelName():                           schema_pkg_apis_meta_v1_ServerAddressByClientCIDR(ref),
-		metav1.Status{}.OpenAPIModelName():                                              schema_pkg_apis_meta_v1_Status(ref),
-		metav1.StatusCause{}.OpenAPIModelName():                                         schema_pkg_apis_meta_v1_StatusCause(ref),
-		metav1.StatusDetails{}.OpenAPIModelName():                                       schema_pkg_apis_meta_v1_StatusDetails(ref),
-		metav1.Table{}.OpenAPIModelName():                                               schema_pkg_apis_meta_v1_Table(ref),
-		metav1.TableColumnDefinition{}.OpenAPIModelName():                               schema_pkg_apis_meta_v1_TableColumnDefinition(ref),
-		metav1.TableOptions{}.OpenAPIModelName():                                        schema_pkg_apis_meta_v1_TableOptions(ref),
-		metav1.TableRow{}.OpenAPIModelName():                                            schema_pkg_apis_meta_v1_TableRow(ref),
-		metav1.TableRowCondition{}.OpenAPIModelName():                                   schema_pkg_apis_meta_v1_TableRowCondition(ref),
-		metav1.Time{}.OpenAPIModelName():                                                schema_pkg_apis_meta_v1_Time(ref),
-		metav1.Timestamp{}.OpenAPIModelName():                                           schema_pkg_apis_meta_v1_Timestamp(ref),
-		metav1.TypeMeta{}.OpenAPIModelName():                                            schema_pkg_apis_meta_v1_TypeMeta(ref),
-		metav1.UpdateOptions{}.OpenAPIModelName():                                       schema_pkg_apis_meta_v1_UpdateOptions(ref),
-		metav1.WatchEvent{}.OpenAPIModelName():                                          schema_pkg_apis_meta_v1_WatchEvent(ref),
-		runtime.RawExtension{}.OpenAPIModelName():                                       schema_k8sio_apimachinery_pkg_runtime_RawExtension(ref),
-		runtime.TypeMeta{}.OpenAPIModelName():                                           schema_k8sio_apimachinery_pkg_runtime_TypeMeta(ref),
-		runtime.Unknown{}.OpenAPIModelName():                                            schema_k8sio_apimachinery_pkg_runtime_Unknown(ref),
-		version.Info{}.OpenAPIModelName():                                               schema_k8sio_apimachinery_pkg_version_Info(ref),
-		auditv1.AuthenticationMetadata{}.OpenAPIModelName():                             schema_pkg_apis_audit_v1_AuthenticationMetadata(ref),
-		auditv1.Event{}.OpenAPIModelName():                                              schema_pkg_apis_audit_v1_Event(ref),
-		auditv1.EventList{}.OpenAPIModelName():                                          schema_pkg_apis_audit_v1_EventList(ref),
-		auditv1.GroupResources{}.OpenAPIModelName():                                     schema_pkg_apis_audit_v1_GroupResources(ref),
-		auditv1.ObjectReference{}.OpenAPIModelName():                                    schema_pkg_apis_audit_v1_ObjectReference(ref),
-		auditv1.Policy{}.OpenAPIModelName():                                             schema_pkg_apis_audit_v1_Policy(ref),
-		auditv1.PolicyList{}.OpenAPIModelName():                                         schema_pkg_apis_audit_v1_PolicyList(ref),
-		auditv1.PolicyRule{}.OpenAPIModelName():                                         schema_pkg_apis_audit_v1_PolicyRule(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.Activity":                   schema_pkg_apis_activity_v1alpha1_Activity(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityActor":              schema_pkg_apis_activity_v1alpha1_ActivityActor(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityChange":             schema_pkg_apis_activity_v1alpha1_ActivityChange(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityFacetQuery":         schema_pkg_apis_activity_v1alpha1_ActivityFacetQuery(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityFacetQuerySpec":     schema_pkg_apis_activity_v1alpha1_ActivityFacetQuerySpec(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityFacetQueryStatus":   schema_pkg_apis_activity_v1alpha1_ActivityFacetQueryStatus(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityLink":               schema_pkg_apis_activity_v1alpha1_ActivityLink(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityList":               schema_pkg_apis_activity_v1alpha1_ActivityList(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityMetricsPoint":       schema_pkg_apis_activity_v1alpha1_ActivityMetricsPoint(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityMetricsQuery":       schema_pkg_apis_activity_v1alpha1_ActivityMetricsQuery(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityMetricsQuerySpec":   schema_pkg_apis_activity_v1alpha1_ActivityMetricsQuerySpec(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityMetricsQueryStatus": schema_pkg_apis_activity_v1alpha1_ActivityMetricsQueryStatus(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityOrigin":             schema_pkg_apis_activity_v1alpha1_ActivityOrigin(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityPolicy":             schema_pkg_apis_activity_v1alpha1_ActivityPolicy(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityPolicyList":         schema_pkg_apis_activity_v1alpha1_ActivityPolicyList(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityPolicyResource":     schema_pkg_apis_activity_v1alpha1_ActivityPolicyResource(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityPolicyRule":         schema_pkg_apis_activity_v1alpha1_ActivityPolicyRule(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityPolicySpec":         schema_pkg_apis_activity_v1alpha1_ActivityPolicySpec(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityPolicyStatus":       schema_pkg_apis_activity_v1alpha1_ActivityPolicyStatus(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityQuery":              schema_pkg_apis_activity_v1alpha1_ActivityQuery(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityQuerySpec":          schema_pkg_apis_activity_v1alpha1_ActivityQuerySpec(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityQueryStatus":        schema_pkg_apis_activity_v1alpha1_ActivityQueryStatus(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityQueryTenant":        schema_pkg_apis_activity_v1alpha1_ActivityQueryTenant(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityResource":           schema_pkg_apis_activity_v1alpha1_ActivityResource(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivitySpec":               schema_pkg_apis_activity_v1alpha1_ActivitySpec(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityTenant":             schema_pkg_apis_activity_v1alpha1_ActivityTenant(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.AuditLogFacetsQuery":        schema_pkg_apis_activity_v1alpha1_AuditLogFacetsQuery(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.AuditLogFacetsQuerySpec":    schema_pkg_apis_activity_v1alpha1_AuditLogFacetsQuerySpec(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.AuditLogFacetsQueryStatus":  schema_pkg_apis_activity_v1alpha1_AuditLogFacetsQueryStatus(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.AuditLogGroup":              schema_pkg_apis_activity_v1alpha1_AuditLogGroup(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.AuditLogQuery":              schema_pkg_apis_activity_v1alpha1_AuditLogQuery(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.AuditLogQuerySpec":          schema_pkg_apis_activity_v1alpha1_AuditLogQuerySpec(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.AuditLogQueryStatus":        schema_pkg_apis_activity_v1alpha1_AuditLogQueryStatus(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.AutoFetchSpec":              schema_pkg_apis_activity_v1alpha1_AutoFetchSpec(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.EventFacetQuery":            schema_pkg_apis_activity_v1alpha1_EventFacetQuery(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.EventFacetQuerySpec":        schema_pkg_apis_activity_v1alpha1_EventFacetQuerySpec(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.EventFacetQueryStatus":      schema_pkg_apis_activity_v1alpha1_EventFacetQueryStatus(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.EventQuery":                 schema_pkg_apis_activity_v1alpha1_EventQuery(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.EventQueryList":             schema_pkg_apis_activity_v1alpha1_EventQueryList(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.EventQuerySpec":             schema_pkg_apis_activity_v1alpha1_EventQuerySpec(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.EventQueryStatus":           schema_pkg_apis_activity_v1alpha1_EventQueryStatus(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.EventRecord":                schema_pkg_apis_activity_v1alpha1_EventRecord(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.FacetError":                 schema_pkg_apis_activity_v1alpha1_FacetError(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.FacetResult":                schema_pkg_apis_activity_v1alpha1_FacetResult(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.FacetSpec":                  schema_pkg_apis_activity_v1alpha1_FacetSpec(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.FacetTimeRange":             schema_pkg_apis_activity_v1alpha1_FacetTimeRange(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.FacetTrendPoint":            schema_pkg_apis_activity_v1alpha1_FacetTrendPoint(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.FacetTrendQuery":            schema_pkg_apis_activity_v1alpha1_FacetTrendQuery(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.FacetTrendQuerySpec":        schema_pkg_apis_activity_v1alpha1_FacetTrendQuerySpec(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.FacetTrendQueryStatus":      schema_pkg_apis_activity_v1alpha1_FacetTrendQueryStatus(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.FacetTrendSeries":           schema_pkg_apis_activity_v1alpha1_FacetTrendSeries(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.FacetValue":                 schema_pkg_apis_activity_v1alpha1_FacetValue(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.PolicyPreview":              schema_pkg_apis_activity_v1alpha1_PolicyPreview(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.PolicyPreviewInput":         schema_pkg_apis_activity_v1alpha1_PolicyPreviewInput(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.PolicyPreviewInputResult":   schema_pkg_apis_activity_v1alpha1_PolicyPreviewInputResult(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.PolicyPreviewSpec":          schema_pkg_apis_activity_v1alpha1_PolicyPreviewSpec(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.PolicyPreviewStatus":        schema_pkg_apis_activity_v1alpha1_PolicyPreviewStatus(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ReindexConfig":              schema_pkg_apis_activity_v1alpha1_ReindexConfig(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ReindexJob":                 schema_pkg_apis_activity_v1alpha1_ReindexJob(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ReindexJobList":             schema_pkg_apis_activity_v1alpha1_ReindexJobList(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ReindexJobSpec":             schema_pkg_apis_activity_v1alpha1_ReindexJobSpec(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ReindexJobStatus":           schema_pkg_apis_activity_v1alpha1_ReindexJobStatus(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ReindexPolicySelector":      schema_pkg_apis_activity_v1alpha1_ReindexPolicySelector(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ReindexProgress":            schema_pkg_apis_activity_v1alpha1_ReindexProgress(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ReindexTimeRange":           schema_pkg_apis_activity_v1alpha1_ReindexTimeRange(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ScopeStats":                 schema_pkg_apis_activity_v1alpha1_ScopeStats(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ScopeStatsResource":         schema_pkg_apis_activity_v1alpha1_ScopeStatsResource(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ScopeStatsStatus":           schema_pkg_apis_activity_v1alpha1_ScopeStatsStatus(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.SelfScope":                  schema_pkg_apis_activity_v1alpha1_SelfScope(ref),
+		"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.SelfScopeStatus":            schema_pkg_apis_activity_v1alpha1_SelfScopeStatus(ref),
+		v1.BoundObjectReference{}.OpenAPIModelName():                                     schema_k8sio_api_authentication_v1_BoundObjectReference(ref),
+		v1.SelfSubjectReview{}.OpenAPIModelName():                                        schema_k8sio_api_authentication_v1_SelfSubjectReview(ref),
+		v1.SelfSubjectReviewStatus{}.OpenAPIModelName():                                  schema_k8sio_api_authentication_v1_SelfSubjectReviewStatus(ref),
+		v1.TokenRequest{}.OpenAPIModelName():                                             schema_k8sio_api_authentication_v1_TokenRequest(ref),
+		v1.TokenRequestSpec{}.OpenAPIModelName():                                         schema_k8sio_api_authentication_v1_TokenRequestSpec(ref),
+		v1.TokenRequestStatus{}.OpenAPIModelName():                                       schema_k8sio_api_authentication_v1_TokenRequestStatus(ref),
+		v1.TokenReview{}.OpenAPIModelName():                                              schema_k8sio_api_authentication_v1_TokenReview(ref),
+		v1.TokenReviewSpec{}.OpenAPIModelName():                                          schema_k8sio_api_authentication_v1_TokenReviewSpec(ref),
+		v1.TokenReviewStatus{}.OpenAPIModelName():                                        schema_k8sio_api_authentication_v1_TokenReviewStatus(ref),
+		v1.UserInfo{}.OpenAPIModelName():                                                 schema_k8sio_api_authentication_v1_UserInfo(ref),
+		authorizationv1.FieldSelectorAttributes{}.OpenAPIModelName():                     schema_k8sio_api_authorization_v1_FieldSelectorAttributes(ref),
+		authorizationv1.LabelSelectorAttributes{}.OpenAPIModelName():                     schema_k8sio_api_authorization_v1_LabelSelectorAttributes(ref),
+		authorizationv1.LocalSubjectAccessReview{}.OpenAPIModelName():                    schema_k8sio_api_authorization_v1_LocalSubjectAccessReview(ref),
+		authorizationv1.NonResourceAttributes{}.OpenAPIModelName():                       schema_k8sio_api_authorization_v1_NonResourceAttributes(ref),
+		authorizationv1.NonResourceRule{}.OpenAPIModelName():                             schema_k8sio_api_authorization_v1_NonResourceRule(ref),
+		authorizationv1.ResourceAttributes{}.OpenAPIModelName():                          schema_k8sio_api_authorization_v1_ResourceAttributes(ref),
+		authorizationv1.ResourceRule{}.OpenAPIModelName():                                schema_k8sio_api_authorization_v1_ResourceRule(ref),
+		authorizationv1.SelfSubjectAccessReview{}.OpenAPIModelName():                     schema_k8sio_api_authorization_v1_SelfSubjectAccessReview(ref),
+		authorizationv1.SelfSubjectAccessReviewSpec{}.OpenAPIModelName():                 schema_k8sio_api_authorization_v1_SelfSubjectAccessReviewSpec(ref),
+		authorizationv1.SelfSubjectRulesReview{}.OpenAPIModelName():                      schema_k8sio_api_authorization_v1_SelfSubjectRulesReview(ref),
+		authorizationv1.SelfSubjectRulesReviewSpec{}.OpenAPIModelName():                  schema_k8sio_api_authorization_v1_SelfSubjectRulesReviewSpec(ref),
+		authorizationv1.SubjectAccessReview{}.OpenAPIModelName():                         schema_k8sio_api_authorization_v1_SubjectAccessReview(ref),
+		authorizationv1.SubjectAccessReviewSpec{}.OpenAPIModelName():                     schema_k8sio_api_authorization_v1_SubjectAccessReviewSpec(ref),
+		authorizationv1.SubjectAccessReviewStatus{}.OpenAPIModelName():                   schema_k8sio_api_authorization_v1_SubjectAccessReviewStatus(ref),
+		authorizationv1.SubjectRulesReviewStatus{}.OpenAPIModelName():                    schema_k8sio_api_authorization_v1_SubjectRulesReviewStatus(ref),
+		corev1.AWSElasticBlockStoreVolumeSource{}.OpenAPIModelName():                     schema_k8sio_api_core_v1_AWSElasticBlockStoreVolumeSource(ref),
+		corev1.Affinity{}.OpenAPIModelName():                                             schema_k8sio_api_core_v1_Affinity(ref),
+		corev1.AppArmorProfile{}.OpenAPIModelName():                                      schema_k8sio_api_core_v1_AppArmorProfile(ref),
+		corev1.AttachedVolume{}.OpenAPIModelName():                                       schema_k8sio_api_core_v1_AttachedVolume(ref),
+		corev1.AvoidPods{}.OpenAPIModelName():                                            schema_k8sio_api_core_v1_AvoidPods(ref),
+		corev1.AzureDiskVolumeSource{}.OpenAPIModelName():                                schema_k8sio_api_core_v1_AzureDiskVolumeSource(ref),
+		corev1.AzureFilePersistentVolumeSource{}.OpenAPIModelName():                      schema_k8sio_api_core_v1_AzureFilePersistentVolumeSource(ref),
+		corev1.AzureFileVolumeSource{}.OpenAPIModelName():                                schema_k8sio_api_core_v1_AzureFileVolumeSource(ref),
+		corev1.Binding{}.OpenAPIModelName():                                              schema_k8sio_api_core_v1_Binding(ref),
+		corev1.CSIPersistentVolumeSource{}.OpenAPIModelName():                            schema_k8sio_api_core_v1_CSIPersistentVolumeSource(ref),
+		corev1.CSIVolumeSource{}.OpenAPIModelName():                                      schema_k8sio_api_core_v1_CSIVolumeSource(ref),
+		corev1.Capabilities{}.OpenAPIModelName():                                         schema_k8sio_api_core_v1_Capabilities(ref),
+		corev1.CephFSPersistentVolumeSource{}.OpenAPIModelName():                         schema_k8sio_api_core_v1_CephFSPersistentVolumeSource(ref),
+		corev1.CephFSVolumeSource{}.OpenAPIModelName():                                   schema_k8sio_api_core_v1_CephFSVolumeSource(ref),
+		corev1.CinderPersistentVolumeSource{}.OpenAPIModelName():                         schema_k8sio_api_core_v1_CinderPersistentVolumeSource(ref),
+		corev1.CinderVolumeSource{}.OpenAPIModelName():                                   schema_k8sio_api_core_v1_CinderVolumeSource(ref),
+		corev1.ClientIPConfig{}.OpenAPIModelName():                                       schema_k8sio_api_core_v1_ClientIPConfig(ref),
+		corev1.ClusterTrustBundleProjection{}.OpenAPIModelName():                         schema_k8sio_api_core_v1_ClusterTrustBundleProjection(ref),
+		corev1.ComponentCondition{}.OpenAPIModelName():                                   schema_k8sio_api_core_v1_ComponentCondition(ref),
+		corev1.ComponentStatus{}.OpenAPIModelName():                                      schema_k8sio_api_core_v1_ComponentStatus(ref),
+		corev1.ComponentStatusList{}.OpenAPIModelName():                                  schema_k8sio_api_core_v1_ComponentStatusList(ref),
+		corev1.ConfigMap{}.OpenAPIModelName():                                            schema_k8sio_api_core_v1_ConfigMap(ref),
+		corev1.ConfigMapEnvSource{}.OpenAPIModelName():                                   schema_k8sio_api_core_v1_ConfigMapEnvSource(ref),
+		corev1.ConfigMapKeySelector{}.OpenAPIModelName():                                 schema_k8sio_api_core_v1_ConfigMapKeySelector(ref),
+		corev1.ConfigMapList{}.OpenAPIModelName():                                        schema_k8sio_api_core_v1_ConfigMapList(ref),
+		corev1.ConfigMapNodeConfigSource{}.OpenAPIModelName():                            schema_k8sio_api_core_v1_ConfigMapNodeConfigSource(ref),
+		corev1.ConfigMapProjection{}.OpenAPIModelName():                                  schema_k8sio_api_core_v1_ConfigMapProjection(ref),
+		corev1.ConfigMapVolumeSource{}.OpenAPIModelName():                                schema_k8sio_api_core_v1_ConfigMapVolumeSource(ref),
+		corev1.Container{}.OpenAPIModelName():                                            schema_k8sio_api_core_v1_Container(ref),
+		corev1.ContainerExtendedResourceRequest{}.OpenAPIModelName():                     schema_k8sio_api_core_v1_ContainerExtendedResourceRequest(ref),
+		corev1.ContainerImage{}.OpenAPIModelName():                                       schema_k8sio_api_core_v1_ContainerImage(ref),
+		corev1.ContainerPort{}.OpenAPIModelName():                                        schema_k8sio_api_core_v1_ContainerPort(ref),
+		corev1.ContainerResizePolicy{}.OpenAPIModelName():                                schema_k8sio_api_core_v1_ContainerResizePolicy(ref),
+		corev1.ContainerRestartRule{}.OpenAPIModelName():                                 schema_k8sio_api_core_v1_ContainerRestartRule(ref),
+		corev1.ContainerRestartRuleOnExitCodes{}.OpenAPIModelName():                      schema_k8sio_api_core_v1_ContainerRestartRuleOnExitCodes(ref),
+		corev1.ContainerState{}.OpenAPIModelName():                                       schema_k8sio_api_core_v1_ContainerState(ref),
+		corev1.ContainerStateRunning{}.OpenAPIModelName():                                schema_k8sio_api_core_v1_ContainerStateRunning(ref),
+		corev1.ContainerStateTerminated{}.OpenAPIModelName():                             schema_k8sio_api_core_v1_ContainerStateTerminated(ref),
+		corev1.ContainerStateWaiting{}.OpenAPIModelName():                                schema_k8sio_api_core_v1_ContainerStateWaiting(ref),
+		corev1.ContainerStatus{}.OpenAPIModelName():                                      schema_k8sio_api_core_v1_ContainerStatus(ref),
+		corev1.ContainerUser{}.OpenAPIModelName():                                        schema_k8sio_api_core_v1_ContainerUser(ref),
+		corev1.DaemonEndpoint{}.OpenAPIModelName():                                       schema_k8sio_api_core_v1_DaemonEndpoint(ref),
+		corev1.DownwardAPIProjection{}.OpenAPIModelName():                                schema_k8sio_api_core_v1_DownwardAPIProjection(ref),
+		corev1.DownwardAPIVolumeFile{}.OpenAPIModelName():                                schema_k8sio_api_core_v1_DownwardAPIVolumeFile(ref),
+		corev1.DownwardAPIVolumeSource{}.OpenAPIModelName():                              schema_k8sio_api_core_v1_DownwardAPIVolumeSource(ref),
+		corev1.EmptyDirVolumeSource{}.OpenAPIModelName():                                 schema_k8sio_api_core_v1_EmptyDirVolumeSource(ref),
+		corev1.EndpointAddress{}.OpenAPIModelName():                                      schema_k8sio_api_core_v1_EndpointAddress(ref),
+		corev1.EndpointPort{}.OpenAPIModelName():                                         schema_k8sio_api_core_v1_EndpointPort(ref),
+		corev1.EndpointSubset{}.OpenAPIModelName():                                       schema_k8sio_api_core_v1_EndpointSubset(ref),
+		corev1.Endpoints{}.OpenAPIModelName():                                            schema_k8sio_api_core_v1_Endpoints(ref),
+		corev1.EndpointsList{}.OpenAPIModelName():                                        schema_k8sio_api_core_v1_EndpointsList(ref),
+		corev1.EnvFromSource{}.OpenAPIModelName():                                        schema_k8sio_api_core_v1_EnvFromSource(ref),
+		corev1.EnvVar{}.OpenAPIModelName():                                               schema_k8sio_api_core_v1_EnvVar(ref),
+		corev1.EnvVarSource{}.OpenAPIModelName():                                         schema_k8sio_api_core_v1_EnvVarSource(ref),
+		corev1.EphemeralContainer{}.OpenAPIModelName():                                   schema_k8sio_api_core_v1_EphemeralContainer(ref),
+		corev1.EphemeralContainerCommon{}.OpenAPIModelName():                             schema_k8sio_api_core_v1_EphemeralContainerCommon(ref),
+		corev1.EphemeralVolumeSource{}.OpenAPIModelName():                                schema_k8sio_api_core_v1_EphemeralVolumeSource(ref),
+		corev1.Event{}.OpenAPIModelName():                                                schema_k8sio_api_core_v1_Event(ref),
+		corev1.EventList{}.OpenAPIModelName():                                            schema_k8sio_api_core_v1_EventList(ref),
+		corev1.EventSeries{}.OpenAPIModelName():                                          schema_k8sio_api_core_v1_EventSeries(ref),
+		corev1.EventSource{}.OpenAPIModelName():                                          schema_k8sio_api_core_v1_EventSource(ref),
+		corev1.ExecAction{}.OpenAPIModelName():                                           schema_k8sio_api_core_v1_ExecAction(ref),
+		corev1.FCVolumeSource{}.OpenAPIModelName():                                       schema_k8sio_api_core_v1_FCVolumeSource(ref),
+		corev1.FileKeySelector{}.OpenAPIModelName():                                      schema_k8sio_api_core_v1_FileKeySelector(ref),
+		corev1.FlexPersistentVolumeSource{}.OpenAPIModelName():                           schema_k8sio_api_core_v1_FlexPersistentVolumeSource(ref),
+		corev1.FlexVolumeSource{}.OpenAPIModelName():                                     schema_k8sio_api_core_v1_FlexVolumeSource(ref),
+		corev1.FlockerVolumeSource{}.OpenAPIModelName():                                  schema_k8sio_api_core_v1_FlockerVolumeSource(ref),
+		corev1.GCEPersistentDiskVolumeSource{}.OpenAPIModelName():                        schema_k8sio_api_core_v1_GCEPersistentDiskVolumeSource(ref),
+		corev1.GRPCAction{}.OpenAPIModelName():                                           schema_k8sio_api_core_v1_GRPCAction(ref),
+		corev1.GitRepoVolumeSource{}.OpenAPIModelName():                                  schema_k8sio_api_core_v1_GitRepoVolumeSource(ref),
+		corev1.GlusterfsPersistentVolumeSource{}.OpenAPIModelName():                      schema_k8sio_api_core_v1_GlusterfsPersistentVolumeSource(ref),
+		corev1.GlusterfsVolumeSource{}.OpenAPIModelName():                                schema_k8sio_api_core_v1_GlusterfsVolumeSource(ref),
+		corev1.HTTPGetAction{}.OpenAPIModelName():                                        schema_k8sio_api_core_v1_HTTPGetAction(ref),
+		corev1.HTTPHeader{}.OpenAPIModelName():                                           schema_k8sio_api_core_v1_HTTPHeader(ref),
+		corev1.HostAlias{}.OpenAPIModelName():                                            schema_k8sio_api_core_v1_HostAlias(ref),
+		corev1.HostIP{}.OpenAPIModelName():                                               schema_k8sio_api_core_v1_HostIP(ref),
+		corev1.HostPathVolumeSource{}.OpenAPIModelName():                                 schema_k8sio_api_core_v1_HostPathVolumeSource(ref),
+		corev1.ISCSIPersistentVolumeSource{}.OpenAPIModelName():                          schema_k8sio_api_core_v1_ISCSIPersistentVolumeSource(ref),
+		corev1.ISCSIVolumeSource{}.OpenAPIModelName():                                    schema_k8sio_api_core_v1_ISCSIVolumeSource(ref),
+		corev1.ImageVolumeSource{}.OpenAPIModelName():                                    schema_k8sio_api_core_v1_ImageVolumeSource(ref),
+		corev1.KeyToPath{}.OpenAPIModelName():                                            schema_k8sio_api_core_v1_KeyToPath(ref),
+		corev1.Lifecycle{}.OpenAPIModelName():                                            schema_k8sio_api_core_v1_Lifecycle(ref),
+		corev1.LifecycleHandler{}.OpenAPIModelName():                                     schema_k8sio_api_core_v1_LifecycleHandler(ref),
+		corev1.LimitRange{}.OpenAPIModelName():                                           schema_k8sio_api_core_v1_LimitRange(ref),
+		corev1.LimitRangeItem{}.OpenAPIModelName():                                       schema_k8sio_api_core_v1_LimitRangeItem(ref),
+		corev1.LimitRangeList{}.OpenAPIModelName():                                       schema_k8sio_api_core_v1_LimitRangeList(ref),
+		corev1.LimitRangeSpec{}.OpenAPIModelName():                                       schema_k8sio_api_core_v1_LimitRangeSpec(ref),
+		corev1.LinuxContainerUser{}.OpenAPIModelName():                                   schema_k8sio_api_core_v1_LinuxContainerUser(ref),
+		corev1.List{}.OpenAPIModelName():                                                 schema_k8sio_api_core_v1_List(ref),
+		corev1.LoadBalancerIngress{}.OpenAPIModelName():                                  schema_k8sio_api_core_v1_LoadBalancerIngress(ref),
+		corev1.LoadBalancerStatus{}.OpenAPIModelName():                                   schema_k8sio_api_core_v1_LoadBalancerStatus(ref),
+		corev1.LocalObjectReference{}.OpenAPIModelName():                                 schema_k8sio_api_core_v1_LocalObjectReference(ref),
+		corev1.LocalVolumeSource{}.OpenAPIModelName():                                    schema_k8sio_api_core_v1_LocalVolumeSource(ref),
+		corev1.ModifyVolumeStatus{}.OpenAPIModelName():                                   schema_k8sio_api_core_v1_ModifyVolumeStatus(ref),
+		corev1.NFSVolumeSource{}.OpenAPIModelName():                                      schema_k8sio_api_core_v1_NFSVolumeSource(ref),
+		corev1.Namespace{}.OpenAPIModelName():                                            schema_k8sio_api_core_v1_Namespace(ref),
+		corev1.NamespaceCondition{}.OpenAPIModelName():                                   schema_k8sio_api_core_v1_NamespaceCondition(ref),
+		corev1.NamespaceList{}.OpenAPIModelName():                                        schema_k8sio_api_core_v1_NamespaceList(ref),
+		corev1.NamespaceSpec{}.OpenAPIModelName():                                        schema_k8sio_api_core_v1_NamespaceSpec(ref),
+		corev1.NamespaceStatus{}.OpenAPIModelName():                                      schema_k8sio_api_core_v1_NamespaceStatus(ref),
+		corev1.Node{}.OpenAPIModelName():                                                 schema_k8sio_api_core_v1_Node(ref),
+		corev1.NodeAddress{}.OpenAPIModelName():                                          schema_k8sio_api_core_v1_NodeAddress(ref),
+		corev1.NodeAffinity{}.OpenAPIModelName():                                         schema_k8sio_api_core_v1_NodeAffinity(ref),
+		corev1.NodeCondition{}.OpenAPIModelName():                                        schema_k8sio_api_core_v1_NodeCondition(ref),
+		corev1.NodeConfigSource{}.OpenAPIModelName():                                     schema_k8sio_api_core_v1_NodeConfigSource(ref),
+		corev1.NodeConfigStatus{}.OpenAPIModelName():                                     schema_k8sio_api_core_v1_NodeConfigStatus(ref),
+		corev1.NodeDaemonEndpoints{}.OpenAPIModelName():                                  schema_k8sio_api_core_v1_NodeDaemonEndpoints(ref),
+		corev1.NodeFeatures{}.OpenAPIModelName():                                         schema_k8sio_api_core_v1_NodeFeatures(ref),
+		corev1.NodeList{}.OpenAPIModelName():                                             schema_k8sio_api_core_v1_NodeList(ref),
+		corev1.NodeProxyOptions{}.OpenAPIModelName():                                     schema_k8sio_api_core_v1_NodeProxyOptions(ref),
+		corev1.NodeRuntimeHandler{}.OpenAPIModelName():                                   schema_k8sio_api_core_v1_NodeRuntimeHandler(ref),
+		corev1.NodeRuntimeHandlerFeatures{}.OpenAPIModelName():                           schema_k8sio_api_core_v1_NodeRuntimeHandlerFeatures(ref),
+		corev1.NodeSelector{}.OpenAPIModelName():                                         schema_k8sio_api_core_v1_NodeSelector(ref),
+		corev1.NodeSelectorRequirement{}.OpenAPIModelName():                              schema_k8sio_api_core_v1_NodeSelectorRequirement(ref),
+		corev1.NodeSelectorTerm{}.OpenAPIModelName():                                     schema_k8sio_api_core_v1_NodeSelectorTerm(ref),
+		corev1.NodeSpec{}.OpenAPIModelName():                                             schema_k8sio_api_core_v1_NodeSpec(ref),
+		corev1.NodeStatus{}.OpenAPIModelName():                                           schema_k8sio_api_core_v1_NodeStatus(ref),
+		corev1.NodeSwapStatus{}.OpenAPIModelName():                                       schema_k8sio_api_core_v1_NodeSwapStatus(ref),
+		corev1.NodeSystemInfo{}.OpenAPIModelName():                                       schema_k8sio_api_core_v1_NodeSystemInfo(ref),
+		corev1.ObjectFieldSelector{}.OpenAPIModelName():                                  schema_k8sio_api_core_v1_ObjectFieldSelector(ref),
+		corev1.ObjectReference{}.OpenAPIModelName():                                      schema_k8sio_api_core_v1_ObjectReference(ref),
+		corev1.PersistentVolume{}.OpenAPIModelName():                                     schema_k8sio_api_core_v1_PersistentVolume(ref),
+		corev1.PersistentVolumeClaim{}.OpenAPIModelName():                                schema_k8sio_api_core_v1_PersistentVolumeClaim(ref),
+		corev1.PersistentVolumeClaimCondition{}.OpenAPIModelName():                       schema_k8sio_api_core_v1_PersistentVolumeClaimCondition(ref),
+		corev1.PersistentVolumeClaimList{}.OpenAPIModelName():                            schema_k8sio_api_core_v1_PersistentVolumeClaimList(ref),
+		corev1.PersistentVolumeClaimSpec{}.OpenAPIModelName():                            schema_k8sio_api_core_v1_PersistentVolumeClaimSpec(ref),
+		corev1.PersistentVolumeClaimStatus{}.OpenAPIModelName():                          schema_k8sio_api_core_v1_PersistentVolumeClaimStatus(ref),
+		corev1.PersistentVolumeClaimTemplate{}.OpenAPIModelName():                        schema_k8sio_api_core_v1_PersistentVolumeClaimTemplate(ref),
+		corev1.PersistentVolumeClaimVolumeSource{}.OpenAPIModelName():                    schema_k8sio_api_core_v1_PersistentVolumeClaimVolumeSource(ref),
+		corev1.PersistentVolumeList{}.OpenAPIModelName():                                 schema_k8sio_api_core_v1_PersistentVolumeList(ref),
+		corev1.PersistentVolumeSource{}.OpenAPIModelName():                               schema_k8sio_api_core_v1_PersistentVolumeSource(ref),
+		corev1.PersistentVolumeSpec{}.OpenAPIModelName():                                 schema_k8sio_api_core_v1_PersistentVolumeSpec(ref),
+		corev1.PersistentVolumeStatus{}.OpenAPIModelName():                               schema_k8sio_api_core_v1_PersistentVolumeStatus(ref),
+		corev1.PhotonPersistentDiskVolumeSource{}.OpenAPIModelName():                     schema_k8sio_api_core_v1_PhotonPersistentDiskVolumeSource(ref),
+		corev1.Pod{}.OpenAPIModelName():                                                  schema_k8sio_api_core_v1_Pod(ref),
+		corev1.PodAffinity{}.OpenAPIModelName():                                          schema_k8sio_api_core_v1_PodAffinity(ref),
+		corev1.PodAffinityTerm{}.OpenAPIModelName():                                      schema_k8sio_api_core_v1_PodAffinityTerm(ref),
+		corev1.PodAntiAffinity{}.OpenAPIModelName():                                      schema_k8sio_api_core_v1_PodAntiAffinity(ref),
+		corev1.PodAttachOptions{}.OpenAPIModelName():                                     schema_k8sio_api_core_v1_PodAttachOptions(ref),
+		corev1.PodCertificateProjection{}.OpenAPIModelName():                             schema_k8sio_api_core_v1_PodCertificateProjection(ref),
+		corev1.PodCondition{}.OpenAPIModelName():                                         schema_k8sio_api_core_v1_PodCondition(ref),
+		corev1.PodDNSConfig{}.OpenAPIModelName():                                         schema_k8sio_api_core_v1_PodDNSConfig(ref),
+		corev1.PodDNSConfigOption{}.OpenAPIModelName():                                   schema_k8sio_api_core_v1_PodDNSConfigOption(ref),
+		corev1.PodExecOptions{}.OpenAPIModelName():                                       schema_k8sio_api_core_v1_PodExecOptions(ref),
+		corev1.PodExtendedResourceClaimStatus{}.OpenAPIModelName():                       schema_k8sio_api_core_v1_PodExtendedResourceClaimStatus(ref),
+		corev1.PodIP{}.OpenAPIModelName():                                                schema_k8sio_api_core_v1_PodIP(ref),
+		corev1.PodList{}.OpenAPIModelName():                                              schema_k8sio_api_core_v1_PodList(ref),
+		corev1.PodLogOptions{}.OpenAPIModelName():                                        schema_k8sio_api_core_v1_PodLogOptions(ref),
+		corev1.PodOS{}.OpenAPIModelName():                                                schema_k8sio_api_core_v1_PodOS(ref),
+		corev1.PodPortForwardOptions{}.OpenAPIModelName():                                schema_k8sio_api_core_v1_PodPortForwardOptions(ref),
+		corev1.PodProxyOptions{}.OpenAPIModelName():                                      schema_k8sio_api_core_v1_PodProxyOptions(ref),
+		corev1.PodReadinessGate{}.OpenAPIModelName():                                     schema_k8sio_api_core_v1_PodReadinessGate(ref),
+		corev1.PodResourceClaim{}.OpenAPIModelName():                                     schema_k8sio_api_core_v1_PodResourceClaim(ref),
+		corev1.PodResourceClaimStatus{}.OpenAPIModelName():                               schema_k8sio_api_core_v1_PodResourceClaimStatus(ref),
+		corev1.PodSchedulingGate{}.OpenAPIModelName():                                    schema_k8sio_api_core_v1_PodSchedulingGate(ref),
+		corev1.PodSecurityContext{}.OpenAPIModelName():                                   schema_k8sio_api_core_v1_PodSecurityContext(ref),
+		corev1.PodSignature{}.OpenAPIModelName():                                         schema_k8sio_api_core_v1_PodSignature(ref),
+		corev1.PodSpec{}.OpenAPIModelName():                                              schema_k8sio_api_core_v1_PodSpec(ref),
+		corev1.PodStatus{}.OpenAPIModelName():                                            schema_k8sio_api_core_v1_PodStatus(ref),
+		corev1.PodStatusResult{}.OpenAPIModelName():                                      schema_k8sio_api_core_v1_PodStatusResult(ref),
+		corev1.PodTemplate{}.OpenAPIModelName():                                          schema_k8sio_api_core_v1_PodTemplate(ref),
+		corev1.PodTemplateList{}.OpenAPIModelName():                                      schema_k8sio_api_core_v1_PodTemplateList(ref),
+		corev1.PodTemplateSpec{}.OpenAPIModelName():                                      schema_k8sio_api_core_v1_PodTemplateSpec(ref),
+		corev1.PortStatus{}.OpenAPIModelName():                                           schema_k8sio_api_core_v1_PortStatus(ref),
+		corev1.PortworxVolumeSource{}.OpenAPIModelName():                                 schema_k8sio_api_core_v1_PortworxVolumeSource(ref),
+		corev1.PreferAvoidPodsEntry{}.OpenAPIModelName():                                 schema_k8sio_api_core_v1_PreferAvoidPodsEntry(ref),
+		corev1.PreferredSchedulingTerm{}.OpenAPIModelName():                              schema_k8sio_api_core_v1_PreferredSchedulingTerm(ref),
+		corev1.Probe{}.OpenAPIModelName():                                                schema_k8sio_api_core_v1_Probe(ref),
+		corev1.ProbeHandler{}.OpenAPIModelName():                                         schema_k8sio_api_core_v1_ProbeHandler(ref),
+		corev1.ProjectedVolumeSource{}.OpenAPIModelName():                                schema_k8sio_api_core_v1_ProjectedVolumeSource(ref),
+		corev1.QuobyteVolumeSource{}.OpenAPIModelName():                                  schema_k8sio_api_core_v1_QuobyteVolumeSource(ref),
+		corev1.RBDPersistentVolumeSource{}.OpenAPIModelName():                            schema_k8sio_api_core_v1_RBDPersistentVolumeSource(ref),
+		corev1.RBDVolumeSource{}.OpenAPIModelName():                                      schema_k8sio_api_core_v1_RBDVolumeSource(ref),
+		corev1.RangeAllocation{}.OpenAPIModelName():                                      schema_k8sio_api_core_v1_RangeAllocation(ref),
+		corev1.ReplicationController{}.OpenAPIModelName():                                schema_k8sio_api_core_v1_ReplicationController(ref),
+		corev1.ReplicationControllerCondition{}.OpenAPIModelName():                       schema_k8sio_api_core_v1_ReplicationControllerCondition(ref),
+		corev1.ReplicationControllerList{}.OpenAPIModelName():                            schema_k8sio_api_core_v1_ReplicationControllerList(ref),
+		corev1.ReplicationControllerSpec{}.OpenAPIModelName():                            schema_k8sio_api_core_v1_ReplicationControllerSpec(ref),
+		corev1.ReplicationControllerStatus{}.OpenAPIModelName():                          schema_k8sio_api_core_v1_ReplicationControllerStatus(ref),
+		corev1.ResourceClaim{}.OpenAPIModelName():                                        schema_k8sio_api_core_v1_ResourceClaim(ref),
+		corev1.ResourceFieldSelector{}.OpenAPIModelName():                                schema_k8sio_api_core_v1_ResourceFieldSelector(ref),
+		corev1.ResourceHealth{}.OpenAPIModelName():                                       schema_k8sio_api_core_v1_ResourceHealth(ref),
+		corev1.ResourceQuota{}.OpenAPIModelName():                                        schema_k8sio_api_core_v1_ResourceQuota(ref),
+		corev1.ResourceQuotaList{}.OpenAPIModelName():                                    schema_k8sio_api_core_v1_ResourceQuotaList(ref),
+		corev1.ResourceQuotaSpec{}.OpenAPIModelName():                                    schema_k8sio_api_core_v1_ResourceQuotaSpec(ref),
+		corev1.ResourceQuotaStatus{}.OpenAPIModelName():                                  schema_k8sio_api_core_v1_ResourceQuotaStatus(ref),
+		corev1.ResourceRequirements{}.OpenAPIModelName():                                 schema_k8sio_api_core_v1_ResourceRequirements(ref),
+		corev1.ResourceStatus{}.OpenAPIModelName():                                       schema_k8sio_api_core_v1_ResourceStatus(ref),
+		corev1.SELinuxOptions{}.OpenAPIModelName():                                       schema_k8sio_api_core_v1_SELinuxOptions(ref),
+		corev1.ScaleIOPersistentVolumeSource{}.OpenAPIModelName():                        schema_k8sio_api_core_v1_ScaleIOPersistentVolumeSource(ref),
+		corev1.ScaleIOVolumeSource{}.OpenAPIModelName():                                  schema_k8sio_api_core_v1_ScaleIOVolumeSource(ref),
+		corev1.ScopeSelector{}.OpenAPIModelName():                                        schema_k8sio_api_core_v1_ScopeSelector(ref),
+		corev1.ScopedResourceSelectorRequirement{}.OpenAPIModelName():                    schema_k8sio_api_core_v1_ScopedResourceSelectorRequirement(ref),
+		corev1.SeccompProfile{}.OpenAPIModelName():                                       schema_k8sio_api_core_v1_SeccompProfile(ref),
+		corev1.Secret{}.OpenAPIModelName():                                               schema_k8sio_api_core_v1_Secret(ref),
+		corev1.SecretEnvSource{}.OpenAPIModelName():                                      schema_k8sio_api_core_v1_SecretEnvSource(ref),
+		corev1.SecretKeySelector{}.OpenAPIModelName():                                    schema_k8sio_api_core_v1_SecretKeySelector(ref),
+		corev1.SecretList{}.OpenAPIModelName():                                           schema_k8sio_api_core_v1_SecretList(ref),
+		corev1.SecretProjection{}.OpenAPIModelName():                                     schema_k8sio_api_core_v1_SecretProjection(ref),
+		corev1.SecretReference{}.OpenAPIModelName():                                      schema_k8sio_api_core_v1_SecretReference(ref),
+		corev1.SecretVolumeSource{}.OpenAPIModelName():                                   schema_k8sio_api_core_v1_SecretVolumeSource(ref),
+		corev1.SecurityContext{}.OpenAPIModelName():                                      schema_k8sio_api_core_v1_SecurityContext(ref),
+		corev1.SerializedReference{}.OpenAPIModelName():                                  schema_k8sio_api_core_v1_SerializedReference(ref),
+		corev1.Service{}.OpenAPIModelName():                                              schema_k8sio_api_core_v1_Service(ref),
+		corev1.ServiceAccount{}.OpenAPIModelName():                                       schema_k8sio_api_core_v1_ServiceAccount(ref),
+		corev1.ServiceAccountList{}.OpenAPIModelName():                                   schema_k8sio_api_core_v1_ServiceAccountList(ref),
+		corev1.ServiceAccountTokenProjection{}.OpenAPIModelName():                        schema_k8sio_api_core_v1_ServiceAccountTokenProjection(ref),
+		corev1.ServiceList{}.OpenAPIModelName():                                          schema_k8sio_api_core_v1_ServiceList(ref),
+		corev1.ServicePort{}.OpenAPIModelName():                                          schema_k8sio_api_core_v1_ServicePort(ref),
+		corev1.ServiceProxyOptions{}.OpenAPIModelName():                                  schema_k8sio_api_core_v1_ServiceProxyOptions(ref),
+		corev1.ServiceSpec{}.OpenAPIModelName():                                          schema_k8sio_api_core_v1_ServiceSpec(ref),
+		corev1.ServiceStatus{}.OpenAPIModelName():                                        schema_k8sio_api_core_v1_ServiceStatus(ref),
+		corev1.SessionAffinityConfig{}.OpenAPIModelName():                                schema_k8sio_api_core_v1_SessionAffinityConfig(ref),
+		corev1.SleepAction{}.OpenAPIModelName():                                          schema_k8sio_api_core_v1_SleepAction(ref),
+		corev1.StorageOSPersistentVolumeSource{}.OpenAPIModelName():                      schema_k8sio_api_core_v1_StorageOSPersistentVolumeSource(ref),
+		corev1.StorageOSVolumeSource{}.OpenAPIModelName():                                schema_k8sio_api_core_v1_StorageOSVolumeSource(ref),
+		corev1.Sysctl{}.OpenAPIModelName():                                               schema_k8sio_api_core_v1_Sysctl(ref),
+		corev1.TCPSocketAction{}.OpenAPIModelName():                                      schema_k8sio_api_core_v1_TCPSocketAction(ref),
+		corev1.Taint{}.OpenAPIModelName():                                                schema_k8sio_api_core_v1_Taint(ref),
+		corev1.Toleration{}.OpenAPIModelName():                                           schema_k8sio_api_core_v1_Toleration(ref),
+		corev1.TopologySelectorLabelRequirement{}.OpenAPIModelName():                     schema_k8sio_api_core_v1_TopologySelectorLabelRequirement(ref),
+		corev1.TopologySelectorTerm{}.OpenAPIModelName():                                 schema_k8sio_api_core_v1_TopologySelectorTerm(ref),
+		corev1.TopologySpreadConstraint{}.OpenAPIModelName():                             schema_k8sio_api_core_v1_TopologySpreadConstraint(ref),
+		corev1.TypedLocalObjectReference{}.OpenAPIModelName():                            schema_k8sio_api_core_v1_TypedLocalObjectReference(ref),
+		corev1.TypedObjectReference{}.OpenAPIModelName():                                 schema_k8sio_api_core_v1_TypedObjectReference(ref),
+		corev1.Volume{}.OpenAPIModelName():                                               schema_k8sio_api_core_v1_Volume(ref),
+		corev1.VolumeDevice{}.OpenAPIModelName():                                         schema_k8sio_api_core_v1_VolumeDevice(ref),
+		corev1.VolumeMount{}.OpenAPIModelName():                                          schema_k8sio_api_core_v1_VolumeMount(ref),
+		corev1.VolumeMountStatus{}.OpenAPIModelName():                                    schema_k8sio_api_core_v1_VolumeMountStatus(ref),
+		corev1.VolumeNodeAffinity{}.OpenAPIModelName():                                   schema_k8sio_api_core_v1_VolumeNodeAffinity(ref),
+		corev1.VolumeProjection{}.OpenAPIModelName():                                     schema_k8sio_api_core_v1_VolumeProjection(ref),
+		corev1.VolumeResourceRequirements{}.OpenAPIModelName():                           schema_k8sio_api_core_v1_VolumeResourceRequirements(ref),
+		corev1.VolumeSource{}.OpenAPIModelName():                                         schema_k8sio_api_core_v1_VolumeSource(ref),
+		corev1.VsphereVirtualDiskVolumeSource{}.OpenAPIModelName():                       schema_k8sio_api_core_v1_VsphereVirtualDiskVolumeSource(ref),
+		corev1.WeightedPodAffinityTerm{}.OpenAPIModelName():                              schema_k8sio_api_core_v1_WeightedPodAffinityTerm(ref),
+		corev1.WindowsSecurityContextOptions{}.OpenAPIModelName():                        schema_k8sio_api_core_v1_WindowsSecurityContextOptions(ref),
+		corev1.WorkloadReference{}.OpenAPIModelName():                                    schema_k8sio_api_core_v1_WorkloadReference(ref),
+		eventsv1.Event{}.OpenAPIModelName():                                              schema_k8sio_api_events_v1_Event(ref),
+		eventsv1.EventList{}.OpenAPIModelName():                                          schema_k8sio_api_events_v1_EventList(ref),
+		eventsv1.EventSeries{}.OpenAPIModelName():                                        schema_k8sio_api_events_v1_EventSeries(ref),
+		resource.Quantity{}.OpenAPIModelName():                                           schema_apimachinery_pkg_api_resource_Quantity(ref),
+		metav1.APIGroup{}.OpenAPIModelName():                                             schema_pkg_apis_meta_v1_APIGroup(ref),
+		metav1.APIGroupList{}.OpenAPIModelName():                                         schema_pkg_apis_meta_v1_APIGroupList(ref),
+		metav1.APIResource{}.OpenAPIModelName():                                          schema_pkg_apis_meta_v1_APIResource(ref),
+		metav1.APIResourceList{}.OpenAPIModelName():                                      schema_pkg_apis_meta_v1_APIResourceList(ref),
+		metav1.APIVersions{}.OpenAPIModelName():                                          schema_pkg_apis_meta_v1_APIVersions(ref),
+		metav1.ApplyOptions{}.OpenAPIModelName():                                         schema_pkg_apis_meta_v1_ApplyOptions(ref),
+		metav1.Condition{}.OpenAPIModelName():                                            schema_pkg_apis_meta_v1_Condition(ref),
+		metav1.CreateOptions{}.OpenAPIModelName():                                        schema_pkg_apis_meta_v1_CreateOptions(ref),
+		metav1.DeleteOptions{}.OpenAPIModelName():                                        schema_pkg_apis_meta_v1_DeleteOptions(ref),
+		metav1.Duration{}.OpenAPIModelName():                                             schema_pkg_apis_meta_v1_Duration(ref),
+		metav1.FieldSelectorRequirement{}.OpenAPIModelName():                             schema_pkg_apis_meta_v1_FieldSelectorRequirement(ref),
+		metav1.FieldsV1{}.OpenAPIModelName():                                             schema_pkg_apis_meta_v1_FieldsV1(ref),
+		metav1.GetOptions{}.OpenAPIModelName():                                           schema_pkg_apis_meta_v1_GetOptions(ref),
+		metav1.GroupKind{}.OpenAPIModelName():                                            schema_pkg_apis_meta_v1_GroupKind(ref),
+		metav1.GroupResource{}.OpenAPIModelName():                                        schema_pkg_apis_meta_v1_GroupResource(ref),
+		metav1.GroupVersion{}.OpenAPIModelName():                                         schema_pkg_apis_meta_v1_GroupVersion(ref),
+		metav1.GroupVersionForDiscovery{}.OpenAPIModelName():                             schema_pkg_apis_meta_v1_GroupVersionForDiscovery(ref),
+		metav1.GroupVersionKind{}.OpenAPIModelName():                                     schema_pkg_apis_meta_v1_GroupVersionKind(ref),
+		metav1.GroupVersionResource{}.OpenAPIModelName():                                 schema_pkg_apis_meta_v1_GroupVersionResource(ref),
+		metav1.InternalEvent{}.OpenAPIModelName():                                        schema_pkg_apis_meta_v1_InternalEvent(ref),
+		metav1.LabelSelector{}.OpenAPIModelName():                                        schema_pkg_apis_meta_v1_LabelSelector(ref),
+		metav1.LabelSelectorRequirement{}.OpenAPIModelName():                             schema_pkg_apis_meta_v1_LabelSelectorRequirement(ref),
+		metav1.List{}.OpenAPIModelName():                                                 schema_pkg_apis_meta_v1_List(ref),
+		metav1.ListMeta{}.OpenAPIModelName():                                             schema_pkg_apis_meta_v1_ListMeta(ref),
+		metav1.ListOptions{}.OpenAPIModelName():                                          schema_pkg_apis_meta_v1_ListOptions(ref),
+		metav1.ManagedFieldsEntry{}.OpenAPIModelName():                                   schema_pkg_apis_meta_v1_ManagedFieldsEntry(ref),
+		metav1.MicroTime{}.OpenAPIModelName():                                            schema_pkg_apis_meta_v1_MicroTime(ref),
+		metav1.ObjectMeta{}.OpenAPIModelName():                                           schema_pkg_apis_meta_v1_ObjectMeta(ref),
+		metav1.OwnerReference{}.OpenAPIModelName():                                       schema_pkg_apis_meta_v1_OwnerReference(ref),
+		metav1.PartialObjectMetadata{}.OpenAPIModelName():                                schema_pkg_apis_meta_v1_PartialObjectMetadata(ref),
+		metav1.PartialObjectMetadataList{}.OpenAPIModelName():                            schema_pkg_apis_meta_v1_PartialObjectMetadataList(ref),
+		metav1.Patch{}.OpenAPIModelName():                                                schema_pkg_apis_meta_v1_Patch(ref),
+		metav1.PatchOptions{}.OpenAPIModelName():                                         schema_pkg_apis_meta_v1_PatchOptions(ref),
+		metav1.Preconditions{}.OpenAPIModelName():                                        schema_pkg_apis_meta_v1_Preconditions(ref),
+		metav1.RootPaths{}.OpenAPIModelName():                                            schema_pkg_apis_meta_v1_RootPaths(ref),
+		metav1.ServerAddressByClientCIDR{}.OpenAPIModelName():                            schema_pkg_apis_meta_v1_ServerAddressByClientCIDR(ref),
+		metav1.Status{}.OpenAPIModelName():                                               schema_pkg_apis_meta_v1_Status(ref),
+		metav1.StatusCause{}.OpenAPIModelName():                                          schema_pkg_apis_meta_v1_StatusCause(ref),
+		metav1.StatusDetails{}.OpenAPIModelName():                                        schema_pkg_apis_meta_v1_StatusDetails(ref),
+		metav1.Table{}.OpenAPIModelName():                                                schema_pkg_apis_meta_v1_Table(ref),
+		metav1.TableColumnDefinition{}.OpenAPIModelName():                                schema_pkg_apis_meta_v1_TableColumnDefinition(ref),
+		metav1.TableOptions{}.OpenAPIModelName():                                         schema_pkg_apis_meta_v1_TableOptions(ref),
+		metav1.TableRow{}.OpenAPIModelName():                                             schema_pkg_apis_meta_v1_TableRow(ref),
+		metav1.TableRowCondition{}.OpenAPIModelName():                                    schema_pkg_apis_meta_v1_TableRowCondition(ref),
+		metav1.Time{}.OpenAPIModelName():                                                 schema_pkg_apis_meta_v1_Time(ref),
+		metav1.Timestamp{}.OpenAPIModelName():                                            schema_pkg_apis_meta_v1_Timestamp(ref),
+		metav1.TypeMeta{}.OpenAPIModelName():                                             schema_pkg_apis_meta_v1_TypeMeta(ref),
+		metav1.UpdateOptions{}.OpenAPIModelName():                                        schema_pkg_apis_meta_v1_UpdateOptions(ref),
+		metav1.WatchEvent{}.OpenAPIModelName():                                           schema_pkg_apis_meta_v1_WatchEvent(ref),
+		runtime.RawExtension{}.OpenAPIModelName():                                        schema_k8sio_apimachinery_pkg_runtime_RawExtension(ref),
+		runtime.TypeMeta{}.OpenAPIModelName():                                            schema_k8sio_apimachinery_pkg_runtime_TypeMeta(ref),
+		runtime.Unknown{}.OpenAPIModelName():                                             schema_k8sio_apimachinery_pkg_runtime_Unknown(ref),
+		version.Info{}.OpenAPIModelName():                                                schema_k8sio_apimachinery_pkg_version_Info(ref),
+		auditv1.AuthenticationMetadata{}.OpenAPIModelName():                              schema_pkg_apis_audit_v1_AuthenticationMetadata(ref),
+		auditv1.Event{}.OpenAPIModelName():                                               schema_pkg_apis_audit_v1_Event(ref),
+		auditv1.EventList{}.OpenAPIModelName():                                           schema_pkg_apis_audit_v1_EventList(ref),
+		auditv1.GroupResources{}.OpenAPIModelName():                                      schema_pkg_apis_audit_v1_GroupResources(ref),
+		auditv1.ObjectReference{}.OpenAPIModelName():                                     schema_pkg_apis_audit_v1_ObjectReference(ref),
+		auditv1.Policy{}.OpenAPIModelName():                                              schema_pkg_apis_audit_v1_Policy(ref),
+		auditv1.PolicyList{}.OpenAPIModelName():                                          schema_pkg_apis_audit_v1_PolicyList(ref),
+		auditv1.PolicyRule{}.OpenAPIModelName():                                          schema_pkg_apis_audit_v1_PolicyRule(ref),
 	}
 }
 
@@ -408,7 +425,7 @@ func schema_pkg_apis_activity_v1alpha1_Activity(ref common.ReferenceCallback) co
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "Activity is a human-readable summary of something that happened in your cluster. Think of it as the \"what changed and who did it\" record that powers activity feeds, audit trails, and change history views.\n\nActivities are created automatically from audit logs and Kubernetes events based on your ActivityPolicy rules. They're read-only - you query them, not create them.\n\n# Accessing Activities\n\nThere are three ways to get activity data, depending on what you need:\n\n| What you need | API to use | Notes | | --- | --- | --- | | Live feed | GET /activities?watch=true | Streams new activities as they happen. List only returns the last hour. | | Search history | POST /activityqueries | Query any time range with filters, search, and pagination. | | Filter options | POST /activityfacetqueries | Get values for dropdowns (e.g., \"which actors have activities?\"). |\n\n# Quick Examples\n\nWatch for new activities:\n\n\tkubectl get activities --watch\n\nList recent human-initiated changes:\n\n\tkubectl get activities --field-selector spec.changeSource=human\n\nFor historical queries or advanced filtering, use ActivityQuery instead.",
+				Description: "Activity is a human-readable summary of something that happened in your cluster. Think of it as the \"what changed and who did it\" record that powers activity feeds, audit trails, and change history views.\n\nActivities are created automatically from audit logs and Kubernetes events based on your ActivityPolicy rules. They're read-only - you query them, not create them.\n\n# Accessing Activities\n\nThere are four ways to get activity data, depending on what you need:\n\n| What you need | API to use | Notes | | --- | --- | --- | | Live feed | GET /activities?watch=true | Streams new activities as they happen. List only returns the last hour. | | One activity | GET /namespaces/{namespace}/activities/{name} | Look up an activity you already know the name of, e.g. from an alert. Searches the full retention period. | | Search history | POST /activityqueries | Query any time range with filters, search, and pagination. | | Filter options | POST /activityfacetqueries | Get values for dropdowns (e.g., \"which actors have activities?\"). |\n\n# Quick Examples\n\nWatch for new activities:\n\n\tkubectl get activities --watch\n\nList recent human-initiated changes:\n\n\tkubectl get activities --field-selector spec.changeSource=human\n\nGet a single activity by name:\n\n\tkubectl get activity act-1a2b3c4d5e6f -n production -o yaml\n\nFor historical queries or advanced filtering, use ActivityQuery instead.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"kind": {
@@ -614,6 +631,13 @@ func schema_pkg_apis_activity_v1alpha1_ActivityFacetQuerySpec(ref common.Referen
 							},
 						},
 					},
+					"timeoutSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TimeoutSeconds overrides how long this query may run before it is cancelled. Raise it for facets over long time ranges, or lower it to fail fast in interactive filter pickers. Must be positive and no more than the server maximum (60 seconds unless the operator raised it). Defaults to 60 seconds.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
 				},
 				Required: []string{"facets"},
 			},
@@ -737,6 +761,205 @@ func schema_pkg_apis_activity_v1alpha1_ActivityList(ref common.ReferenceCallback
 	}
 }
 
+func schema_pkg_apis_activity_v1alpha1_ActivityMetricsPoint(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ActivityMetricsPoint is a single count for one time bucket and series.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"timestamp": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Timestamp is the start of the bucket (RFC3339).",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"series": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Series is the group-by value for this count, or \"total\" when no group-by dimension was requested.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"count": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Count is the number of activities in the bucket for this series.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+				Required: []string{"timestamp", "series", "count"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_activity_v1alpha1_ActivityMetricsQuery(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ActivityMetricsQuery returns activity counts aggregated into time buckets.\n\nUse this to drive time-series panels (Grafana, dashboards) without paging through individual activities. Each result point is a (bucket, series, count) tuple, where the series is the value of the optional group-by dimension.\n\n# Example: Hourly human changes per resource kind over the last day\n\n\tapiVersion: activity.miloapis.com/v1alpha1\n\tkind: ActivityMetricsQuery\n\tspec:\n\t  startTime: \"now-24h\"\n\t  endTime: \"now\"\n\t  bucketSize: \"1h\"\n\t  groupBy: resource\n\t  filter: \"spec.changeSource == 'human'\"\n\nThis returns something like:\n\n\tstatus:\n\t  points:\n\t    - timestamp: \"2026-10-15T10:00:00Z\"\n\t      series: Deployment\n\t      count: 12\n\t    - timestamp: \"2026-10-15T10:00:00Z\"\n\t      series: HTTPProxy\n\t      count: 3",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref(metav1.ObjectMeta{}.OpenAPIModelName()),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityMetricsQuerySpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityMetricsQueryStatus"),
+						},
+					},
+				},
+				Required: []string{"spec"},
+			},
+		},
+		Dependencies: []string{
+			"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityMetricsQuerySpec", "go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityMetricsQueryStatus", metav1.ObjectMeta{}.OpenAPIModelName()},
+	}
+}
+
+func schema_pkg_apis_activity_v1alpha1_ActivityMetricsQuerySpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ActivityMetricsQuerySpec defines the time window, bucketing, and grouping for the counts.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"startTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StartTime is the beginning of the window. Accepts relative (\"now-7d\") or absolute RFC3339 timestamps.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"endTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EndTime is the end of the window (exclusive). Accepts relative (\"now\") or absolute RFC3339 timestamps.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"bucketSize": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BucketSize is the width of each time bucket, as a Go duration (\"5m\", \"1h\") or a whole number of days (\"1d\"). Defaults to \"1h\". The window may contain at most 1000 buckets.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"groupBy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GroupBy splits counts into one series per distinct value of a dimension. Leave empty to return a single \"total\" series.\n\nSupported dimensions:\n  resource     - spec.resource.kind\n  actor        - spec.actor.name\n  changeSource - spec.changeSource",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"filter": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Filter narrows which activities are counted. Uses the same CEL fields as ActivityQuery (for example \"spec.resource.namespace == 'production'\").",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"timeoutSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TimeoutSeconds overrides how long this query may run before it is cancelled. Raise it for long windows with fine buckets, or lower it so dashboards fail fast. Must be positive and no more than the server maximum (60 seconds unless the operator raised it). Defaults to 60 seconds.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"startTime", "endTime"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_activity_v1alpha1_ActivityMetricsQueryStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ActivityMetricsQueryStatus contains the aggregated counts.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"points": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Points are the (bucket, series, count) tuples, ordered by timestamp and then by series. Buckets with no matching activities are omitted.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityMetricsPoint"),
+									},
+								},
+							},
+						},
+					},
+					"effectiveStartTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EffectiveStartTime is the resolved start of the window, aligned down to a bucket boundary (RFC3339).",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"effectiveEndTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EffectiveEndTime is the resolved end of the window (RFC3339).",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"bucketSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BucketSeconds is the bucket width that was applied, in seconds.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityMetricsPoint"},
+	}
+}
+
 func schema_pkg_apis_activity_v1alpha1_ActivityOrigin(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -760,6 +983,20 @@ func schema_pkg_apis_activity_v1alpha1_ActivityOrigin(ref common.ReferenceCallba
 							Format:      "",
 						},
 					},
+					"policyName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PolicyName is the name of the ActivityPolicy that generated this activity.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"ruleIndex": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RuleIndex is the index of the matching rule within the policy's auditRules (for audit origins) or eventRules (for event origins).",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
 				},
 				Required: []string{"type", "id"},
 			},
@@ -771,7 +1008,7 @@ func schema_pkg_apis_activity_v1alpha1_ActivityPolicy(ref common.ReferenceCallba
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "ActivityPolicy defines translation rules for a specific resource type. Service providers create one ActivityPolicy per resource kind to customize activity descriptions without modifying the Activity Processor.\n\nExample:\n\n\tapiVersion: activity.miloapis.com/v1alpha1\n\tkind: ActivityPolicy\n\tmetadata:\n\t  name: networking-httpproxy\n\tspec:\n\t  resource:\n\t    apiGroup: networking.datumapis.com\n\t    kind: HTTPProxy\n\t  auditRules:\n\t    - match: \"audit.verb == 'create'\"\n\t      summary: \"{{ actor }} created {{ link(kind + ' ' + audit.objectRef.name, audit.responseObject) }}\"\n\t  eventRules:\n\t    - match: \"event.reason == 'Programmed'\"\n\t      summary: \"{{ link(kind + ' ' + event.regarding.name, event.regarding) }} is now programmed\"",
+				Description: "ActivityPolicy defines translation rules for a specific resource type. Service providers create one ActivityPolicy per resource kind to customize activity descriptions without modifying the Activity Processor.\n\nExample:\n\n\tapiVersion: activity.miloapis.com/v1alpha1\n\tkind: ActivityPolicy\n\tmetadata:\n\t  name: networking-httpproxy\n\tspec:\n\t  resource:\n\t    apiGroup: networking.datumapis.com\n\t    kind: HTTPProxy\n\t  auditRules:\n\t    - match: \"audit.verb == 'create'\"\n\t      summary: \"{{ actor }} created {{ link(kind + ' ' + audit.objectRef.name, audit.objectRef) }}\"\n\t  eventRules:\n\t    - match: \"event.reason == 'Programmed'\"\n\t      summary: \"{{ link(kind + ' ' + event.regarding.name, event.regarding) }} is now programmed\"",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"kind": {
@@ -926,12 +1163,19 @@ func schema_pkg_apis_activity_v1alpha1_ActivityPolicyRule(ref common.ReferenceCa
 					},
 					"summary": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Summary is a CEL template for generating the activity summary. Use {{ }} delimiters to embed CEL expressions within strings.\n\nAvailable variables:\n  - For audit rules: audit (map), actor, actorRef, kind\n    Access audit fields via: audit.verb, audit.objectRef, audit.user, audit.responseStatus, audit.responseObject\n  - For event rules: event, actor, actorRef\n\nAvailable functions:\n  - link(displayText, resourceRef): Creates a clickable reference\n\nExamples:\n  \"{{ actor }} created {{ link(kind + ' ' + audit.objectRef.name, audit.responseObject) }}\"\n  \"{{ link(kind + ' ' + event.regarding.name, event.regarding) }} is now programmed\"",
+							Description: "Summary is a CEL template for generating the activity summary. Use {{ }} delimiters to embed CEL expressions within strings.\n\nAvailable variables:\n  - For audit rules: audit (map), actor, actorRef, kind, objectNamespace, verb, responseCode, timestamp\n    Access audit fields via: audit.verb, audit.objectRef, audit.user, audit.responseStatus, audit.responseObject\n  - For event rules: event, actor, actorRef\n\nAvailable functions:\n  - link(displayText, resourceRef): Creates a clickable reference\n\nExamples:\n  \"{{ actor }} created {{ link(kind + ' ' + audit.objectRef.name, audit.objectRef) }}\"\n  \"{{ link(kind + ' ' + event.regarding.name, event.regarding) }} is now programmed\"",
 							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
+					"severity": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Severity marks activities produced by this rule for alert routing. High-severity activities are also published to the activity processor's alert subject, so security-relevant changes such as deletes or RBAC updates can feed real-time alerting. Defaults to Normal.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"name", "match", "summary"},
 			},
@@ -963,7 +1207,7 @@ func schema_pkg_apis_activity_v1alpha1_ActivityPolicySpec(ref common.ReferenceCa
 							},
 						},
 						SchemaProps: spec.SchemaProps{
-							Description: "AuditRules define how to translate audit log entries into activity summaries. Rules are evaluated in order; the first matching rule wins. Available variables: audit (map with verb, objectRef, user, responseStatus,\n  responseObject, requestObject), actor, actorRef, kind",
+							Description: "AuditRules define how to translate audit log entries into activity summaries. Rules are evaluated in order; the first matching rule wins. Available variables: audit (map with verb, objectRef, user, responseStatus,\n  responseObject, requestObject), actor, actorRef, kind, objectNamespace, verb,\n  responseCode, timestamp",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
@@ -1023,7 +1267,7 @@ func schema_pkg_apis_activity_v1alpha1_ActivityPolicyStatus(ref common.Reference
 							},
 						},
 						SchemaProps: spec.SchemaProps{
-							Description: "Conditions represent the current state of the policy. The \"Ready\" condition indicates whether all rules compile successfully.",
+							Description: "Conditions represent the current state of the policy. The \"Ready\" condition indicates whether all rules compile successfully. The \"ResourceResolved\" condition indicates whether the activity processor could map the policy's Kind to an API resource.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
@@ -1102,7 +1346,7 @@ func schema_pkg_apis_activity_v1alpha1_ActivityQuerySpec(ref common.ReferenceCal
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "ActivityQuerySpec defines the search parameters for activities.\n\nRequired: startTime and endTime define your search window. Optional: filter (CEL expression), search, limit, continue.\n\nCEL is the primary filtering mechanism. All dedicated filter fields have been removed in favor of the expressive filter field.\n\nAvailable CEL Fields:\n\n\tspec.changeSource      - \"human\" or \"system\"\n\tspec.actor.name        - who performed the action\n\tspec.actor.type        - \"user\", \"serviceaccount\", \"controller\"\n\tspec.actor.uid         - actor's unique identifier\n\tspec.resource.apiGroup - resource API group (empty for core)\n\tspec.resource.kind     - resource kind (Deployment, Pod, etc.)\n\tspec.resource.name     - resource name\n\tspec.resource.namespace - resource namespace\n\tspec.resource.uid      - resource UID\n\tspec.summary           - activity summary text\n\tspec.origin.type       - \"audit\" or \"event\"\n\tmetadata.namespace     - activity namespace\n\nCEL Filter Examples:\n\n\t\"spec.changeSource == 'human'\"\n\t\"spec.resource.kind == 'Deployment'\"\n\t\"spec.actor.name.contains('admin')\"\n\t\"spec.resource.kind in ['Deployment', 'StatefulSet']\"\n\t\"spec.resource.apiGroup == 'networking.datumapis.com'\"\n\t\"spec.actor.uid == 'abc123'\"",
+				Description: "ActivityQuerySpec defines the search parameters for activities.\n\nRequired: startTime and endTime define your search window. Optional: filter (CEL expression), search, tenant, limit, orderBy, continue.\n\nCEL is the primary filtering mechanism. All dedicated filter fields have been removed in favor of the expressive filter field.\n\nAvailable CEL Fields:\n\n\tspec.changeSource      - \"human\" or \"system\"\n\tspec.actor.name        - who performed the action\n\tspec.actor.type        - \"user\", \"serviceaccount\", \"controller\"\n\tspec.actor.uid         - actor's unique identifier\n\tspec.resource.apiGroup - resource API group (empty for core)\n\tspec.resource.kind     - resource kind (Deployment, Pod, etc.)\n\tspec.resource.name     - resource name\n\tspec.resource.namespace - resource namespace\n\tspec.resource.uid      - resource UID\n\tspec.summary           - activity summary text\n\tspec.origin.type       - \"audit\" or \"event\"\n\tspec.origin.policyName - policy that generated the activity\n\tspec.origin.ruleIndex  - index of the matching rule in that policy\n\tspec.tenant.type       - \"organization\", \"project\", or \"user\"\n\tspec.tenant.name       - tenant name\n\tmetadata.namespace     - activity namespace\n\nCEL Filter Examples:\n\n\t\"spec.changeSource == 'human'\"\n\t\"spec.resource.kind == 'Deployment'\"\n\t\"spec.actor.name.contains('admin')\"\n\t\"spec.resource.kind in ['Deployment', 'StatefulSet']\"\n\t\"spec.resource.apiGroup == 'networking.datumapis.com'\"\n\t\"spec.actor.uid == 'abc123'\"\n\t\"spec.summary == '' || size(spec.summary) < 20\"  (empty or suspiciously short summaries)",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"startTime": {
@@ -1123,11 +1367,17 @@ func schema_pkg_apis_activity_v1alpha1_ActivityQuerySpec(ref common.ReferenceCal
 					},
 					"filter": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Filter narrows results using CEL (Common Expression Language).\n\nThis is the primary filtering mechanism. See the ActivityQuerySpec godoc for available fields and examples.\n\nOperators: ==, !=, &&, ||, !, in String Functions: startsWith(), endsWith(), contains()",
+							Description: "Filter narrows results using CEL (Common Expression Language).\n\nThis is the primary filtering mechanism. See the ActivityQuerySpec godoc for available fields and examples.\n\nOperators: ==, !=, <, >, <=, >=, &&, ||, !, in String Functions: startsWith(), endsWith(), contains(), size()",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
+					"tenant": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Tenant narrows results to a single organization or project.\n\nOnly platform-scoped callers may set it. Tenant-scoped callers already see only their own tenant, so the request is rejected for them.",
+							Ref:         ref("go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityQueryTenant"),
+						},
+					},
 					"search": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Search performs full-text search on activity summaries.\n\nExample: \"created deployment\" matches activities with those words in the summary.",
@@ -1142,17 +1392,40 @@ func schema_pkg_apis_activity_v1alpha1_ActivityQuerySpec(ref common.ReferenceCal
 							Format:      "int32",
 						},
 					},
+					"orderBy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OrderBy sorts results by a field other than time.\n\nSupported values: - \"timestamp\" (default): newest first - \"spec.actor.name\": actor name A-Z, newest first within each actor - \"spec.resource.apiGroup\": API group A-Z, newest first within each group\n\nOrdering by anything other than timestamp sorts the whole time window before returning a page, so those queries are limited to a 24 hour window.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"continue": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Continue is the pagination cursor for fetching additional pages.\n\nLeave empty for the first page. Copy status.continue here to get the next page. Keep all other parameters identical across paginated requests.",
+							Description: "Continue is the pagination cursor for fetching additional pages.\n\nLeave empty for the first page. Copy status.continue here to get the next page. Keep all other parameters except limit identical across paginated requests.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
+					"timeoutSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TimeoutSeconds overrides how long this query may run before it is cancelled. Lower it to fail fast, or raise it for broad searches over long time ranges. Must be positive and no more than the server maximum (60 seconds unless the operator raised it). Defaults to 60 seconds.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"includeTotal": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IncludeTotal also counts every activity matching the query across all pages and returns it in status.total, e.g. for \"showing 100 of 4,523\".\n\nThe count is a second ClickHouse query with the same filters, run alongside the list. It reads every matching row in the time window, so it is the more expensive of the two for broad queries; it stops at 10,000 and sets status.totalCapped beyond that. Request it on the first page only, since the total doesn't change between pages.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"startTime", "endTime"},
 			},
 		},
+		Dependencies: []string{
+			"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityQueryTenant"},
 	}
 }
 
@@ -1170,7 +1443,7 @@ func schema_pkg_apis_activity_v1alpha1_ActivityQueryStatus(ref common.ReferenceC
 							},
 						},
 						SchemaProps: spec.SchemaProps{
-							Description: "Results contains matching activities, sorted newest-first.",
+							Description: "Results contains matching activities, sorted newest-first unless spec.orderBy selects another order.",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
@@ -1203,11 +1476,68 @@ func schema_pkg_apis_activity_v1alpha1_ActivityQueryStatus(ref common.ReferenceC
 							Format:      "",
 						},
 					},
+					"retentionClamped": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RetentionClamped is true when startTime reached further back than the server's retention window. EffectiveStartTime shows the oldest retained time.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"total": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Total is the number of activities matching the query across all pages. Only set when spec.includeTotal is true.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"totalCapped": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TotalCapped is true when more activities match than were counted; total is then the 10,000 cap and a lower bound.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"replaySpec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ReplaySpec is this query's spec with every relative value resolved, so it can be saved and re-run later to return the same results. startTime and endTime hold the absolute times that values like \"now-7d\" and \"now\" resolved to when the query ran, the filter is in normalized form, and continue is cleared so a replay starts from the first page. Results can still differ if data in the window has since passed the retention window.",
+							Ref:         ref("go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityQuerySpec"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.Activity"},
+			"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.Activity", "go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ActivityQuerySpec"},
+	}
+}
+
+func schema_pkg_apis_activity_v1alpha1_ActivityQueryTenant(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ActivityQueryTenant identifies the tenant an ActivityQuery is narrowed to.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type is the tenant type: \"Organization\" or \"Project\".",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the organization or project name.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"type", "name"},
+			},
+		},
 	}
 }
 
@@ -1462,19 +1792,26 @@ func schema_pkg_apis_activity_v1alpha1_AuditLogFacetsQuerySpec(ref common.Refere
 					},
 					"filter": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Filter narrows the audit logs before computing facets using CEL. This allows you to get facet values for a subset of audit logs.\n\nAvailable Fields:\n  verb               - API action: get, list, create, update, patch, delete, watch\n  user.username      - who made the request (user or service account)\n  user.uid           - unique user identifier\n  responseStatus.code - HTTP response code (200, 201, 404, 500, etc.)\n  objectRef.namespace - target resource namespace\n  objectRef.resource  - resource type (pods, deployments, secrets, configmaps, etc.)\n  objectRef.apiGroup  - API group of the resource\n  objectRef.name     - specific resource name\n\nOperators: ==, !=, <, >, <=, >=, &&, ||, !, in String Functions: startsWith(), endsWith(), contains()\n\nExamples:\n  \"verb in ['create', 'update', 'delete']\"        - Facets for write operations only\n  \"!(verb in ['get', 'list', 'watch'])\"           - Exclude read-only operations\n  \"!user.username.startsWith('system:')\"          - Exclude system users\n  \"objectRef.namespace == 'production'\"           - Facets for production namespace",
+							Description: "Filter narrows the audit logs before computing facets using CEL. This allows you to get facet values for a subset of audit logs.\n\nAvailable Fields:\n  verb               - API action: get, list, create, update, patch, delete, watch\n  user.username      - who made the request (user or service account)\n  user.uid           - unique user identifier\n  user.groups        - groups the user belongs to (list; use 'group' in user.groups)\n  responseStatus.code - HTTP response code (200, 201, 404, 500, etc.)\n  responseStatus.message - error detail returned with the response\n  durationMs         - request latency in milliseconds (integer)\n  level              - audit level: Metadata, Request, RequestResponse\n  requestURI         - request path and query string (/healthz, /apis/apps/v1/...)\n  authzDecision      - authorizer decision: allow, forbid (empty if not recorded)\n  admissionWebhook   - admission webhook that rejected the request (empty if none)\n  objectRef.namespace - target resource namespace\n  objectRef.resource  - resource type (pods, deployments, secrets, configmaps, etc.)\n  objectRef.subresource - subresource the request targeted (status, scale, exec, etc.)\n  objectRef.apiGroup  - API group of the resource\n  objectRef.name     - specific resource name\n\nOperators: ==, !=, <, >, <=, >=, &&, ||, !, in String Functions: startsWith(), endsWith(), contains()\n\nExamples:\n  \"verb in ['create', 'update', 'delete']\"        - Facets for write operations only\n  \"!(verb in ['get', 'list', 'watch'])\"           - Exclude read-only operations\n  \"!user.username.startsWith('system:')\"          - Exclude system users\n  \"objectRef.namespace == 'production'\"           - Facets for production namespace\n  \"!has(objectRef.resource)\"                      - Non-resource requests only",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"facets": {
-						VendorExtensible: spec.VendorExtensible{
-							Extensions: spec.Extensions{
+					"mutationsOnly": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MutationsOnly counts only requests that change resources: the create, update, patch, delete and deletecollection verbs. It is ANDed with filter, as in AuditLogQuery. Defaults to false.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"facets": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
 								"x-kubernetes-list-type": "atomic",
 							},
 						},
 						SchemaProps: spec.SchemaProps{
-							Description: "Facets specifies which fields to get distinct values for. Each facet returns the top N values with counts.\n\nSupported fields:\n  - verb: API action (get, list, create, update, patch, delete, watch)\n  - user.username: Actor display names\n  - user.uid: Unique user identifiers\n  - responseStatus.code: HTTP response codes\n  - objectRef.namespace: Namespaces\n  - objectRef.resource: Resource types\n  - objectRef.apiGroup: API groups",
+							Description: "Facets specifies which fields to get distinct values for. Each facet returns the top N values with counts.\n\nSupported fields:\n  - verb: API action (get, list, create, update, patch, delete, watch)\n  - user.username: Actor display names\n  - user.uid: Unique user identifiers\n  - user.groups: Groups of the requesting users (each membership counted)\n  - responseStatus.code: HTTP response codes\n  - durationMs: Request latency histogram (<100ms, 100ms-1s, 1s-5s, 5s-30s, >=30s)\n  - level: Audit levels (Metadata, Request, RequestResponse)\n  - objectRef.namespace: Namespaces\n  - objectRef.resource: Resource types\n  - objectRef.apiGroup: API groups\n  - requestURI: Request path prefixes (/apis/apps, /healthz, /metrics)\n  - authzDecision: Authorization decisions (allow, forbid)\n  - admissionWebhook: Admission webhooks that rejected requests",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
@@ -1486,6 +1823,20 @@ func schema_pkg_apis_activity_v1alpha1_AuditLogFacetsQuerySpec(ref common.Refere
 							},
 						},
 					},
+					"partialResults": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PartialResults returns the facets that succeeded even if others fail. Failed facets are listed in status.facetErrors instead of failing the whole request. The request still fails if every facet fails.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"timeoutSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TimeoutSeconds overrides how long this query may run before it is cancelled. Raise it for facets over long time ranges, or lower it to fail fast in interactive filter pickers. Must be positive and no more than the server maximum (60 seconds unless the operator raised it). Defaults to 60 seconds.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
 				},
 				Required: []string{"facets"},
 			},
@@ -1521,11 +1872,68 @@ func schema_pkg_apis_activity_v1alpha1_AuditLogFacetsQueryStatus(ref common.Refe
 							},
 						},
 					},
+					"facetErrors": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "FacetErrors lists the facets that could not be computed when spec.partialResults is set. Empty when every facet succeeded.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("go.miloapis.com/activity/pkg/apis/activity/v1alpha1.FacetError"),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.FacetResult"},
+			"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.FacetError", "go.miloapis.com/activity/pkg/apis/activity/v1alpha1.FacetResult"},
+	}
+}
+
+func schema_pkg_apis_activity_v1alpha1_AuditLogGroup(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "AuditLogGroup is one line of a grouped audit log export: the values of the groupBy fields and how many matching audit events share them.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"values": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Values maps each groupBy field to its value in this group, e.g. {\"user\": \"alice@example.com\", \"day\": \"2026-10-16\"}. Empty for events without the field, such as the namespace of a cluster-scoped resource.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"count": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Count is the number of audit events in the group.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+				Required: []string{"values", "count"},
+			},
+		},
 	}
 }
 
@@ -1600,13 +2008,27 @@ func schema_pkg_apis_activity_v1alpha1_AuditLogQuerySpec(ref common.ReferenceCal
 							Format:      "",
 						},
 					},
+					"relativeTo": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RelativeTo is the moment relative times are resolved against, in place of the current time. Set it to run a query \"as of\" a fixed point: with relativeTo \"2024-06-01T00:00:00Z\", startTime \"now-7d\" means 2024-05-25T00:00:00Z and endTime \"now\" means 2024-06-01T00:00:00Z, whenever the query runs.\n\nMust be an RFC3339 timestamp that is not in the future. Times after it are rejected, as times after the current moment are without it.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"filter": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Filter narrows results using CEL (Common Expression Language). Leave empty to get all events.\n\nAvailable Fields:\n  verb               - API action: get, list, create, update, patch, delete, watch\n  auditID            - unique event identifier\n  requestReceivedTimestamp - when the API server received the request (RFC3339 timestamp)\n  user.username      - who made the request (user or service account)\n  user.uid           - unique user identifier (stable across username changes)\n  responseStatus.code - HTTP response code (200, 201, 404, 500, etc.)\n  objectRef.namespace - target resource namespace\n  objectRef.resource  - resource type (pods, deployments, secrets, configmaps, etc.)\n  objectRef.name     - specific resource name\n\nOperators: ==, !=, <, >, <=, >=, &&, ||, !, in String Functions: startsWith(), endsWith(), contains()\n\nCommon Patterns:\n  \"verb == 'delete'\"                                    - All deletions\n  \"objectRef.namespace == 'production'\"                 - Activity in production namespace\n  \"verb in ['create', 'update', 'delete', 'patch']\"     - All write operations\n  \"!(verb in ['get', 'list', 'watch'])\"                 - Exclude read-only operations\n  \"responseStatus.code >= 400\"                          - Failed requests\n  \"user.username.startsWith('system:serviceaccount:')\"  - Service account activity\n  \"!user.username.startsWith('system:')\"                - Exclude system users\n  \"user.uid == '550e8400-e29b-41d4-a716-446655440000'\"  - Specific user by UID\n  \"objectRef.resource == 'secrets'\"                     - Secret access\n  \"verb == 'delete' && objectRef.namespace == 'production'\" - Production deletions\n\nNote: Use single quotes for strings. Field names are case-sensitive. CEL reference: https://cel.dev",
+							Description: "Filter narrows results using CEL (Common Expression Language). Leave empty to get all events.\n\nAvailable Fields:\n  verb               - API action: get, list, create, update, patch, delete, watch\n  auditID            - unique event identifier\n  requestReceivedTimestamp - when the API server received the request (RFC3339 timestamp)\n  durationMs         - request latency in milliseconds (integer)\n  level              - audit level: Metadata, Request, RequestResponse\n  requestURI         - request path and query string (/healthz, /apis/apps/v1/...)\n  authzDecision      - authorizer decision: allow, forbid (empty if not recorded)\n  admissionWebhook   - admission webhook that rejected the request (empty if none)\n  user.username      - who made the request (user or service account)\n  user.uid           - unique user identifier (stable across username changes)\n  user.groups        - groups the user belongs to (list; membership tests only)\n  responseStatus.code - HTTP response code (200, 201, 404, 500, etc.)\n  responseStatus.message - error detail returned with the response\n  objectRef.namespace - target resource namespace\n  objectRef.resource  - resource type (pods, deployments, secrets, configmaps, etc.)\n  objectRef.subresource - subresource the request targeted (status, scale, exec, etc.)\n  objectRef.name     - specific resource name\n\nOperators: ==, !=, <, >, <=, >=, &&, ||, !, in String Functions: startsWith(), endsWith(), contains() Presence: has() on optional fields (objectRef.*, responseStatus.*, user.groups) Raw JSON: jsonExtract('path.to.field') reads any other field as a string, if the server enables it. It can't use indexes, so such queries are slower.\n\nCommon Patterns:\n  \"verb == 'delete'\"                                    - All deletions\n  \"objectRef.namespace == 'production'\"                 - Activity in production namespace\n  \"verb in ['create', 'update', 'delete', 'patch']\"     - All write operations\n  \"!(verb in ['get', 'list', 'watch'])\"                 - Exclude read-only operations\n  \"responseStatus.code >= 400\"                          - Failed requests\n  \"responseStatus.message.contains('admission webhook')\" - Rejected by a webhook\n  \"authzDecision == 'forbid'\"                           - Denied by RBAC or another authorizer\n  \"admissionWebhook != ''\"                              - Rejected by any admission webhook\n  \"durationMs > 1000\"                                   - Requests slower than one second\n  \"level == 'RequestResponse'\"                          - Events that captured object bodies\n  \"!has(objectRef.resource)\"                            - Non-resource requests (e.g. /healthz)\n  \"requestURI.startsWith('/metrics')\"                   - Requests to the metrics endpoint\n  \"requestURI.contains('?watch=true')\"                  - Watch requests\n  \"objectRef.subresource == 'status'\"                   - Status updates\n  \"user.username.startsWith('system:serviceaccount:')\"  - Service account activity\n  \"!user.username.startsWith('system:')\"                - Exclude system users\n  \"user.uid == '550e8400-e29b-41d4-a716-446655440000'\"  - Specific user by UID\n  \"'system:masters' in user.groups\"                     - Requests by cluster admins\n  \"objectRef.resource == 'secrets'\"                     - Secret access\n  \"verb == 'delete' && objectRef.namespace == 'production'\" - Production deletions\n\nNote: Use single quotes for strings. Field names are case-sensitive. CEL reference: https://cel.dev",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
+					"mutationsOnly": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MutationsOnly limits results to requests that change resources: the create, update, patch, delete and deletecollection verbs. It is ANDed with filter, and saves repeating a verb clause in every query when reads (get, list, watch) are noise. Defaults to false.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 					"limit": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Limit sets the maximum number of results per page. Default: 100, Maximum: 1000.\n\nUse smaller values (10-50) for exploration, larger (500-1000) for data collection. Use continue to fetch additional pages.",
@@ -1616,11 +2038,32 @@ func schema_pkg_apis_activity_v1alpha1_AuditLogQuerySpec(ref common.ReferenceCal
 					},
 					"continue": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Continue is the pagination cursor for fetching additional pages.\n\nLeave empty for the first page. If status.continue is non-empty after a query, copy that value here in a new query with identical parameters to get the next page. Repeat until status.continue is empty.\n\nImportant: Keep all other parameters (startTime, endTime, filter, limit) identical across paginated requests. The cursor is opaque - copy it exactly without modification.",
+							Description: "Continue is the pagination cursor for fetching additional pages.\n\nLeave empty for the first page. If status.continue is non-empty after a query, copy that value here in a new query with identical parameters to get the next page. Repeat until status.continue is empty. To go back a page, copy status.previous here instead.\n\nImportant: Keep startTime, endTime, relativeTo, filter and mutationsOnly identical across paginated requests. Limit may change between pages. The cursor is opaque - copy it exactly without modification.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
+					"timeoutSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TimeoutSeconds overrides how long this query may run before it is cancelled. Lower it to fail fast, or raise it for broad filters over long time ranges. Must be positive and no more than the server maximum (60 seconds unless the operator raised it). Defaults to 60 seconds.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"includeRaw": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IncludeRaw also returns each result's audit event JSON exactly as it was stored, in status.rawResults. Use it for forensics: results are decoded into the audit.k8s.io/v1 Event type, which drops any fields it doesn't know about.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"bestEffort": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BestEffort returns the results read so far, instead of an error, when the query times out while results are being read. The response then has status.partial set, and status.continue resumes after the last returned event. A timeout before any results arrive still fails the query. Defaults to false.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"startTime", "endTime"},
 			},
@@ -1642,7 +2085,7 @@ func schema_pkg_apis_activity_v1alpha1_AuditLogQueryStatus(ref common.ReferenceC
 							},
 						},
 						SchemaProps: spec.SchemaProps{
-							Description: "Results contains matching audit events, sorted newest-first.\n\nEach event follows the Kubernetes audit.Event format with fields like:\n  verb, user.username, objectRef.{namespace,resource,name}, requestReceivedTimestamp,\n  stageTimestamp, responseStatus.code, requestObject, responseObject\n\nEmpty results? Try broadening your filter or time range. Full documentation: https://kubernetes.io/docs/reference/config-api/apiserver-audit.v1/",
+							Description: "Results contains matching audit events, sorted newest-first.\n\nEach event follows the Kubernetes audit.Event format with fields like:\n  verb, user.username, objectRef.{namespace,resource,name}, requestReceivedTimestamp,\n  stageTimestamp, responseStatus.code, requestObject, responseObject\n\nThe request latency is added as the \"activity.miloapis.com/duration-ms\" annotation. Bodies of sensitive resources such as Secrets are reduced to their metadata and marked with the \"activity.miloapis.com/redacted\" annotation.\n\nEmpty results? Try broadening your filter or time range. Full documentation: https://kubernetes.io/docs/reference/config-api/apiserver-audit.v1/",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
@@ -1654,6 +2097,26 @@ func schema_pkg_apis_activity_v1alpha1_AuditLogQueryStatus(ref common.ReferenceC
 							},
 						},
 					},
+					"rawResults": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "RawResults holds the stored JSON of each result when spec.includeRaw is set, byte for byte and in the same order as results. It has no duration-ms annotation. Events whose bodies were redacted are returned as the redacted event instead of the stored bytes.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
 					"continue": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Continue is the pagination cursor. Non-empty means more results are available - copy this to spec.continue for the next page. Empty means you have all results.",
@@ -1661,6 +2124,13 @@ func schema_pkg_apis_activity_v1alpha1_AuditLogQueryStatus(ref common.ReferenceC
 							Format:      "",
 						},
 					},
+					"previous": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Previous is the cursor for the page before this one, holding the newer events just above its first result. Copy it to spec.continue, with the other parameters unchanged, to step back a page. Empty on the first page.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"effectiveStartTime": {
 						SchemaProps: spec.SchemaProps{
 							Description: "EffectiveStartTime is the actual start time used for this query (RFC3339 format).\n\nWhen you use relative times like \"now-7d\", this shows the exact timestamp that was calculated. Useful for understanding exactly what time range was queried, especially for auditing, debugging, or recreating queries with absolute timestamps.\n\nExample: If you query with startTime=\"now-7d\" at 2025-12-17T12:00:00Z, this will be \"2025-12-10T12:00:00Z\".",
@@ -1675,11 +2145,45 @@ func schema_pkg_apis_activity_v1alpha1_AuditLogQueryStatus(ref common.ReferenceC
 							Format:      "",
 						},
 					},
+					"retentionClamped": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RetentionClamped is true when startTime reached further back than the server's retention window. EffectiveStartTime is moved forward to the oldest retained time and a warning explains that older data has aged out.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"partial": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Partial is true when spec.bestEffort was set and the query timed out part-way through reading results. Results holds the events read before the timeout, newest-first with none skipped, and continue picks up after the last of them.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"traceID": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TraceID identifies the server-side trace for this query.\n\nInclude it when reporting a slow or unexpected query to support so the matching server logs can be found. Failed queries include the same ID in the error message. Empty when request tracing is disabled on the server.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"queryID": {
+						SchemaProps: spec.SchemaProps{
+							Description: "QueryID is the query_id this query ran with in ClickHouse. It is the trace ID and a span ID joined by a dash, and finds the exact query in ClickHouse's own system.query_log:\n\n  SELECT * FROM system.query_log WHERE query_id = '<queryID>'\n\nEmpty when request tracing is disabled on the server.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"replaySpec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ReplaySpec is this query's spec with every relative value resolved, so it can be saved and re-run later to return the same results. startTime and endTime hold the absolute times that values like \"now-7d\" and \"now\" resolved to when the query ran, the filter is in normalized form, and continue is cleared so a replay starts from the first page. Results can still differ if data in the window has since passed the retention window.",
+							Ref:         ref("go.miloapis.com/activity/pkg/apis/activity/v1alpha1.AuditLogQuerySpec"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			auditv1.Event{}.OpenAPIModelName()},
+			"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.AuditLogQuerySpec", auditv1.Event{}.OpenAPIModelName()},
 	}
 }
 
@@ -1786,7 +2290,7 @@ func schema_pkg_apis_activity_v1alpha1_EventFacetQuerySpec(ref common.ReferenceC
 							},
 						},
 						SchemaProps: spec.SchemaProps{
-							Description: "Facets specifies which fields to get distinct values for. Each facet returns the top N values with counts.\n\nSupported fields:\n  - regarding.kind: Resource kinds (Pod, Deployment, etc.)\n  - regarding.namespace: Namespaces of regarding objects\n  - reason: Event reasons (Scheduled, Pulled, Created, etc.)\n  - type: Event types (Normal, Warning)\n  - source.component: Source components (kubelet, scheduler, etc.)\n  - namespace: Event namespace",
+							Description: "Facets specifies which fields to get distinct values for. Each facet returns the top N values with counts.\n\nSupported fields:\n  - regarding.kind: Resource kinds (Pod, Deployment, etc.)\n  - regarding.namespace: Namespaces of regarding objects\n  - reason: Event reasons (Scheduled, Pulled, Created, etc.)\n  - type: Event types (Normal, Warning)\n  - source.component: Source components (kubelet, scheduler, etc.)\n  - namespace: Event namespace\n  - related.kind: Related resource kinds (Node, ConfigMap, etc.)\n  - related.namespace: Namespaces of related objects",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
@@ -1798,6 +2302,13 @@ func schema_pkg_apis_activity_v1alpha1_EventFacetQuerySpec(ref common.ReferenceC
 							},
 						},
 					},
+					"timeoutSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TimeoutSeconds overrides how long this query may run before it is cancelled. Raise it for facets over long time ranges, or lower it to fail fast in interactive filter pickers. Must be positive and no more than the server maximum (60 seconds unless the operator raised it). Defaults to 60 seconds.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
 				},
 				Required: []string{"facets"},
 			},
@@ -1942,7 +2453,7 @@ func schema_pkg_apis_activity_v1alpha1_EventQuerySpec(ref common.ReferenceCallba
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "EventQuerySpec defines the search parameters.\n\nRequired: startTime and endTime define your search window (max 60 days). Optional: namespace (limit to namespace), fieldSelector (standard K8s syntax), limit (page size, default 100), continue (pagination).",
+				Description: "EventQuerySpec defines the search parameters.\n\nRequired: startTime and endTime define your search window (max 60 days). Optional: namespace (limit to namespace), fieldSelector (standard K8s syntax), reasons, types and sourceComponents (match any listed value), limit (page size, default 100), continue (pagination).",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"startTime": {
@@ -1970,11 +2481,71 @@ func schema_pkg_apis_activity_v1alpha1_EventQuerySpec(ref common.ReferenceCallba
 					},
 					"fieldSelector": {
 						SchemaProps: spec.SchemaProps{
-							Description: "FieldSelector filters events using standard Kubernetes field selector syntax.\n\nSupported Fields:\n  metadata.name               - event name\n  metadata.namespace          - event namespace\n  metadata.uid                - event UID\n  regarding.apiVersion        - regarding resource API version\n  regarding.kind              - regarding resource kind (e.g., Pod, Deployment)\n  regarding.namespace         - regarding resource namespace\n  regarding.name              - regarding resource name\n  regarding.uid               - regarding resource UID\n  regarding.fieldPath         - regarding resource field path\n  reason                      - event reason (e.g., FailedMount, Pulled)\n  type                        - event type (Normal or Warning)\n  source.component            - reporting component\n  source.host                 - reporting host\n  reportingComponent          - reporting component (alias for source.component)\n  reportingInstance           - reporting instance (alias for source.host)\n\nOperators: = (or ==), != Multiple conditions: comma-separated (all must match)\n\nCommon Patterns:\n  \"type=Warning\"                                  - Warning events only\n  \"regarding.kind=Pod\"                            - Events for pods\n  \"reason=FailedMount\"                            - Mount failure events\n  \"regarding.name=my-pod,type=Warning\"            - Warnings for a specific pod",
+							Description: "FieldSelector filters events using standard Kubernetes field selector syntax.\n\nSupported Fields:\n  metadata.name               - event name\n  metadata.namespace          - event namespace\n  metadata.uid                - event UID\n  regarding.apiVersion        - regarding resource API version\n  regarding.kind              - regarding resource kind (e.g., Pod, Deployment)\n  regarding.namespace         - regarding resource namespace\n  regarding.name              - regarding resource name\n  regarding.uid               - regarding resource UID\n  regarding.fieldPath         - regarding resource field path\n  related.apiVersion          - related resource API version\n  related.kind                - related resource kind (e.g., Node)\n  related.namespace           - related resource namespace\n  related.name                - related resource name\n  reason                      - event reason (e.g., FailedMount, Pulled)\n  type                        - event type (Normal or Warning)\n  source.component            - reporting component\n  source.host                 - reporting host\n  reportingComponent          - reporting component (alias for source.component)\n  reportingInstance           - reporting instance (alias for source.host)\n\nOperators: = (or ==), != Multiple conditions: comma-separated (all must match)\n\nCommon Patterns:\n  \"type=Warning\"                                  - Warning events only\n  \"regarding.kind=Pod\"                            - Events for pods\n  \"reason=FailedMount\"                            - Mount failure events\n  \"regarding.name=my-pod,type=Warning\"            - Warnings for a specific pod\n  \"related.kind=Node\"                              - Events related to nodes",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
+					"reasons": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Reasons limits results to events with any of the listed reasons. Field selectors can only require every condition, so use this to match several reasons at once. Combines with fieldSelector, which must also match.\n\nExample: [\"FailedScheduling\", \"FailedMount\", \"BackOff\"]",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"types": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Types limits results to events of any of the listed types (Normal or Warning).",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"sourceComponents": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "SourceComponents limits results to events reported by any of the listed components (source.component in field selectors).\n\nExample: [\"kubelet\", \"default-scheduler\"]",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
 					"limit": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Limit sets the maximum number of results per page. Default: 100, Maximum: 1000.\n\nUse smaller values (10-50) for exploration, larger (500-1000) for data collection. Use continue to fetch additional pages.",
@@ -1984,11 +2555,38 @@ func schema_pkg_apis_activity_v1alpha1_EventQuerySpec(ref common.ReferenceCallba
 					},
 					"continue": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Continue is the pagination cursor for fetching additional pages.\n\nLeave empty for the first page. If status.continue is non-empty after a query, copy that value here in a new query with identical parameters to get the next page. Repeat until status.continue is empty.\n\nImportant: Keep all other parameters (startTime, endTime, namespace, fieldSelector, limit) identical across paginated requests. The cursor is opaque - copy it exactly without modification.",
+							Description: "Continue is the pagination cursor for fetching additional pages.\n\nLeave empty for the first page. If status.continue is non-empty after a query, copy that value here in a new query with identical parameters to get the next page. Repeat until status.continue is empty.\n\nImportant: Keep startTime, endTime, namespace, fieldSelector, reasons, types and sourceComponents identical across paginated requests. Limit may change between pages. The cursor is opaque - copy it exactly without modification.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
+					"timeoutSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TimeoutSeconds overrides how long this query may run before it is cancelled. Lower it to fail fast, or raise it for broad field selectors over long time ranges. Must be positive and no more than the server maximum (60 seconds unless the operator raised it). Defaults to 60 seconds.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"fields": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Fields trims each result to the listed event fields, shrinking the response for bulk pulls. Events always keep their name, namespace, uid, resourceVersion and creationTimestamp; managed fields, labels, annotations and any unlisted fields are dropped. Leave empty to return full events.\n\nSupported Fields:\n  reason, note, type, action, eventTime, series, regarding, related,\n  reportingController, reportingInstance\n\nThe core/v1 names are accepted as aliases: message (note), involvedObject (regarding), and source (reportingController and reportingInstance).\n\nExample: [\"reason\", \"message\", \"type\", \"involvedObject\", \"source\"]",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
 				},
 				Required: []string{"startTime", "endTime"},
 			},
@@ -2043,11 +2641,24 @@ func schema_pkg_apis_activity_v1alpha1_EventQueryStatus(ref common.ReferenceCall
 							Format:      "",
 						},
 					},
+					"retentionClamped": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RetentionClamped is true when startTime reached further back than the server's retention window. EffectiveStartTime is moved forward to the oldest retained time and a warning explains that older data has aged out.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"replaySpec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ReplaySpec is this query's spec with every relative value resolved, so it can be saved and re-run later to return the same results. startTime and endTime hold the absolute times that values like \"now-7d\" and \"now\" resolved to when the query ran, and continue is cleared so a replay starts from the first page. Results can still differ if events in the window have since passed the retention window.",
+							Ref:         ref("go.miloapis.com/activity/pkg/apis/activity/v1alpha1.EventQuerySpec"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.EventRecord"},
+			"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.EventQuerySpec", "go.miloapis.com/activity/pkg/apis/activity/v1alpha1.EventRecord"},
 	}
 }
 
@@ -2094,6 +2705,36 @@ func schema_pkg_apis_activity_v1alpha1_EventRecord(ref common.ReferenceCallback)
 	}
 }
 
+func schema_pkg_apis_activity_v1alpha1_FacetError(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "FacetError describes a facet that could not be computed.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"field": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Field is the field path of the failed facet.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message explains why the facet failed.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"field", "message"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_activity_v1alpha1_FacetResult(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -2128,68 +2769,318 @@ func schema_pkg_apis_activity_v1alpha1_FacetResult(ref common.ReferenceCallback)
 							},
 						},
 					},
-				},
-				Required: []string{"field"},
-			},
-		},
-		Dependencies: []string{
-			"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.FacetValue"},
-	}
-}
-
-func schema_pkg_apis_activity_v1alpha1_FacetSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "FacetSpec defines a single facet to retrieve.",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"field": {
+				},
+				Required: []string{"field"},
+			},
+		},
+		Dependencies: []string{
+			"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.FacetValue"},
+	}
+}
+
+func schema_pkg_apis_activity_v1alpha1_FacetSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "FacetSpec defines a single facet to retrieve.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"field": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Field is the activity field path to get distinct values for.\n\nSupported fields:\n  - spec.actor.name: Actor display names\n  - spec.actor.type: Actor types (user, serviceaccount, controller)\n  - spec.resource.apiGroup: API groups\n  - spec.resource.kind: Resource kinds\n  - spec.resource.namespace: Namespaces\n  - spec.changeSource: Change sources (human, system)\n  - spec.origin.policyName: Policies that generated activities\n  - spec.tenant.type: Tenant types (Organization, Project)\n  - spec.tenant.name: Tenant names, most useful from platform scope",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"limit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Limit is the maximum number of distinct values to return. Default: 20, Maximum: 100.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"order": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Order sets how values are sorted.\n  - count: Most frequent first, ties broken by value (default)\n  - value_asc: By value, ascending\n  - value_desc: By value, descending\n\nNumeric fields such as responseStatus.code sort numerically, so 429 comes after 100. Value ordering is useful for stable dropdowns; combine it with a limit high enough to include every value.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"field"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_activity_v1alpha1_FacetTimeRange(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "FacetTimeRange specifies the time window for facet queries.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"start": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Start is the beginning of the time window (inclusive). Supports RFC3339 timestamps and relative times (e.g., \"now-7d\").",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"end": {
+						SchemaProps: spec.SchemaProps{
+							Description: "End is the end of the time window (exclusive). Supports RFC3339 timestamps and relative times. Defaults to \"now\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_activity_v1alpha1_FacetTrendPoint(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "FacetTrendPoint is the count of one value in one time bucket.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"timestamp": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Timestamp is the start of the bucket (RFC3339).",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"count": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Count is the number of audit logs with the value in the bucket.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+				Required: []string{"timestamp", "count"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_activity_v1alpha1_FacetTrendQuery(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "FacetTrendQuery returns audit log counts over time for the most frequent values of one field.\n\nAn AuditLogFacetsQuery gives a single total per value. A FacetTrendQuery first picks the top values of the field over the whole window, then counts each of them per time bucket, returning one series per value. Use it to drive stacked-area charts such as \"top verbs per day over the last week\".\n\n# Example: Daily counts of the five most common verbs over the last week\n\n\tapiVersion: activity.miloapis.com/v1alpha1\n\tkind: FacetTrendQuery\n\tspec:\n\t  timeRange:\n\t    start: \"now-7d\"\n\t  field: verb\n\t  limit: 5\n\t  bucketSize: \"1d\"\n\nThis returns something like:\n\n\tstatus:\n\t  series:\n\t    - value: get\n\t      total: 5120\n\t      points:\n\t        - timestamp: \"2026-10-09T00:00:00Z\"\n\t          count: 701\n\t        - timestamp: \"2026-10-10T00:00:00Z\"\n\t          count: 688\n\t    - value: update\n\t      total: 940\n\t      points:\n\t        - timestamp: \"2026-10-09T00:00:00Z\"\n\t          count: 130",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref(metav1.ObjectMeta{}.OpenAPIModelName()),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("go.miloapis.com/activity/pkg/apis/activity/v1alpha1.FacetTrendQuerySpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("go.miloapis.com/activity/pkg/apis/activity/v1alpha1.FacetTrendQueryStatus"),
+						},
+					},
+				},
+				Required: []string{"spec"},
+			},
+		},
+		Dependencies: []string{
+			"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.FacetTrendQuerySpec", "go.miloapis.com/activity/pkg/apis/activity/v1alpha1.FacetTrendQueryStatus", metav1.ObjectMeta{}.OpenAPIModelName()},
+	}
+}
+
+func schema_pkg_apis_activity_v1alpha1_FacetTrendQuerySpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "FacetTrendQuerySpec defines the field, time window and bucketing for the trend.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"timeRange": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TimeRange is the window to count over. Start defaults to \"now-7d\" and End to \"now\".",
+							Default:     map[string]interface{}{},
+							Ref:         ref("go.miloapis.com/activity/pkg/apis/activity/v1alpha1.FacetTimeRange"),
+						},
+					},
+					"filter": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Filter narrows the audit logs before counting, using the same CEL fields as AuditLogFacetsQuery (for example \"!user.username.startsWith('system:')\").",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"field": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Field is the audit log field to split series on. Accepts the same fields as AuditLogFacetsQuery facets, such as verb, user.username, objectRef.resource or responseStatus.code.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"limit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Limit is how many of the field's most frequent values get a series. Default: 5, Maximum: 20.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"bucketSize": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BucketSize is the width of each time bucket, as a Go duration (\"1h\") or a whole number of days (\"1d\"). Defaults to \"1d\". The window may contain at most 1000 buckets.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"timeoutSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TimeoutSeconds overrides how long this query may run before it is cancelled. Must be positive and no more than the server maximum (60 seconds unless the operator raised it). Defaults to 60 seconds.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"field"},
+			},
+		},
+		Dependencies: []string{
+			"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.FacetTimeRange"},
+	}
+}
+
+func schema_pkg_apis_activity_v1alpha1_FacetTrendQueryStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "FacetTrendQueryStatus contains one count series per top value.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"series": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Series holds one entry per top value, most frequent first.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("go.miloapis.com/activity/pkg/apis/activity/v1alpha1.FacetTrendSeries"),
+									},
+								},
+							},
+						},
+					},
+					"effectiveStartTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EffectiveStartTime is the resolved start of the window, aligned down to a bucket boundary (RFC3339).",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"effectiveEndTime": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Field is the activity field path to get distinct values for.\n\nSupported fields:\n  - spec.actor.name: Actor display names\n  - spec.actor.type: Actor types (user, serviceaccount, controller)\n  - spec.resource.apiGroup: API groups\n  - spec.resource.kind: Resource kinds\n  - spec.resource.namespace: Namespaces\n  - spec.changeSource: Change sources (human, system)",
-							Default:     "",
+							Description: "EffectiveEndTime is the resolved end of the window (RFC3339).",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"limit": {
+					"bucketSeconds": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Limit is the maximum number of distinct values to return. Default: 20, Maximum: 100.",
+							Description: "BucketSeconds is the bucket width that was applied, in seconds.",
 							Type:        []string{"integer"},
-							Format:      "int32",
+							Format:      "int64",
 						},
 					},
 				},
-				Required: []string{"field"},
 			},
 		},
+		Dependencies: []string{
+			"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.FacetTrendSeries"},
 	}
 }
 
-func schema_pkg_apis_activity_v1alpha1_FacetTimeRange(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_activity_v1alpha1_FacetTrendSeries(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "FacetTimeRange specifies the time window for facet queries.",
+				Description: "FacetTrendSeries is the count over time for one value of the field.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"start": {
+					"value": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Start is the beginning of the time window (inclusive). Supports RFC3339 timestamps and relative times (e.g., \"now-7d\").",
+							Description: "Value is the field value this series counts.",
+							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"end": {
+					"total": {
 						SchemaProps: spec.SchemaProps{
-							Description: "End is the end of the time window (exclusive). Supports RFC3339 timestamps and relative times. Defaults to \"now\".",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Total is the value's count over the whole window.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"points": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Points are the per-bucket counts, ordered by timestamp. Buckets in which the value did not occur are omitted.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("go.miloapis.com/activity/pkg/apis/activity/v1alpha1.FacetTrendPoint"),
+									},
+								},
+							},
 						},
 					},
 				},
+				Required: []string{"value", "total"},
 			},
 		},
+		Dependencies: []string{
+			"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.FacetTrendPoint"},
 	}
 }
 
@@ -2877,6 +3768,244 @@ func schema_pkg_apis_activity_v1alpha1_ReindexTimeRange(ref common.ReferenceCall
 	}
 }
 
+func schema_pkg_apis_activity_v1alpha1_ScopeStats(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ScopeStats returns high-level stats for the caller's scope over the last 24 hours.\n\nUse this to populate a landing dashboard with a single call instead of issuing separate queries for each number. The window is fixed so the aggregates stay cheap.\n\nCreate an empty ScopeStats to get the current numbers:\n\n\tapiVersion: activity.miloapis.com/v1alpha1\n\tkind: ScopeStats\n\nThis returns something like:\n\n\tstatus:\n\t  windowStart: \"2026-10-15T12:00:00Z\"\n\t  windowEnd: \"2026-10-16T12:00:00Z\"\n\t  auditEventCount: 48210\n\t  activityCount: 1312\n\t  uniqueActors: 27\n\t  topResources:\n\t    - kind: Deployment\n\t      apiGroup: apps\n\t      count: 402\n\t  newestAuditEventTime: \"2026-10-16T11:59:58Z\"",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref(metav1.ObjectMeta{}.OpenAPIModelName()),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ScopeStatsStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ScopeStatsStatus", metav1.ObjectMeta{}.OpenAPIModelName()},
+	}
+}
+
+func schema_pkg_apis_activity_v1alpha1_ScopeStatsResource(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ScopeStatsResource is the activity count for one resource kind.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"apiGroup": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIGroup is the resource's API group (empty for the core group).",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is the resource kind, e.g. \"Deployment\".",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"count": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Count is the number of activities for this kind in the window.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+				Required: []string{"kind", "count"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_activity_v1alpha1_ScopeStatsStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ScopeStatsStatus contains the computed stats.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"windowStart": {
+						SchemaProps: spec.SchemaProps{
+							Description: "WindowStart is the start of the 24 hour window (RFC3339).",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"windowEnd": {
+						SchemaProps: spec.SchemaProps{
+							Description: "WindowEnd is the end of the window, the time the stats were computed (RFC3339).",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"auditEventCount": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AuditEventCount is the number of audit events in the window.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"activityCount": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ActivityCount is the number of activities in the window.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"uniqueActors": {
+						SchemaProps: spec.SchemaProps{
+							Description: "UniqueActors is the approximate number of distinct actors across the window's activities.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"topResources": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "TopResources are the 5 resource kinds with the most activities in the window, most active first.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ScopeStatsResource"),
+									},
+								},
+							},
+						},
+					},
+					"newestAuditEventTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NewestAuditEventTime is the timestamp of the most recent audit event in the window (RFC3339). Empty when there were none.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"windowStart", "windowEnd", "auditEventCount", "activityCount", "uniqueActors"},
+			},
+		},
+		Dependencies: []string{
+			"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.ScopeStatsResource"},
+	}
+}
+
+func schema_pkg_apis_activity_v1alpha1_SelfScope(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SelfScope reports the scope the server resolves for the caller.\n\nEvery query is limited to the caller's scope, which comes from the parent resource in their credentials. Use this to see why queries return platform-wide results or nothing at all, without access to the server logs.\n\nCreate an empty SelfScope to see your scope:\n\n\tapiVersion: activity.miloapis.com/v1alpha1\n\tkind: SelfScope\n\nThis returns something like:\n\n\tstatus:\n\t  username: alice@example.com\n\t  scopeType: Project\n\t  scopeName: backend-api",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref(metav1.ObjectMeta{}.OpenAPIModelName()),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("go.miloapis.com/activity/pkg/apis/activity/v1alpha1.SelfScopeStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"go.miloapis.com/activity/pkg/apis/activity/v1alpha1.SelfScopeStatus", metav1.ObjectMeta{}.OpenAPIModelName()},
+	}
+}
+
+func schema_pkg_apis_activity_v1alpha1_SelfScopeStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SelfScopeStatus contains the resolved scope.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"username": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Username is the authenticated user the scope was resolved for.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"scopeType": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ScopeType is the kind of scope queries are limited to: \"platform\", \"Organization\", \"Project\", \"User\", or \"none\". Platform scope covers every tenant and is used when the credentials name no parent resource, unless the server runs with --default-scope=deny, which gives them \"none\" scope and no results.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"scopeName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ScopeName identifies the organization, project, or user (by UID) that queries are limited to. Empty for platform scope.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"username", "scopeType"},
+			},
+		},
+	}
+}
+
 func schema_k8sio_api_authentication_v1_BoundObjectReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
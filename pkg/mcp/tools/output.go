@@ -0,0 +1,105 @@
+package tools
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+)
+
+// encodeOutput encodes a tool result as JSON. Within each object, fields with
+// plain values come before nested objects and lists, each group sorted by key,
+// so headline values such as counts, cursors and names lead and bulky lists
+// trail. Compact output drops the indentation, which takes up a large share
+// of the tokens in big results.
+func encodeOutput(output any, compact bool) ([]byte, error) {
+	raw, err := json.Marshal(output)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decode generically, keeping numbers exactly as encoded
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	var ordered bytes.Buffer
+	if err := writeOrdered(&ordered, value); err != nil {
+		return nil, err
+	}
+	if compact {
+		return ordered.Bytes(), nil
+	}
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, ordered.Bytes(), "", "  "); err != nil {
+		return nil, err
+	}
+	return indented.Bytes(), nil
+}
+
+// writeOrdered writes value as compact JSON with the field order described on
+// encodeOutput.
+func writeOrdered(buf *bytes.Buffer, value any) error {
+	switch v := value.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			iNested, jNested := isNestedValue(v[keys[i]]), isNestedValue(v[keys[j]])
+			if iNested != jNested {
+				return !iNested
+			}
+			return keys[i] < keys[j]
+		})
+
+		buf.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			encodedKey, err := json.Marshal(key)
+			if err != nil {
+				return err
+			}
+			buf.Write(encodedKey)
+			buf.WriteByte(':')
+			if err := writeOrdered(buf, v[key]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case []any:
+		buf.WriteByte('[')
+		for i, element := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeOrdered(buf, element); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		buf.Write(encoded)
+	}
+	return nil
+}
+
+// isNestedValue reports whether a decoded JSON value is an object or list.
+func isNestedValue(value any) bool {
+	switch value.(type) {
+	case map[string]any, []any:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,64 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestEncodeOutput(t *testing.T) {
+	output := map[string]any{
+		"events": []map[string]any{
+			{"regarding": map[string]any{"kind": "Pod"}, "reason": "BackOff"},
+		},
+		"count":    1,
+		"continue": "",
+		"bytes":    int64(9007199254740993),
+		"note":     "ok",
+	}
+
+	compact, err := encodeOutput(output, true)
+	if err != nil {
+		t.Fatalf("encodeOutput() error = %v", err)
+	}
+	want := `{"bytes":9007199254740993,"continue":"","count":1,"note":"ok",` +
+		`"events":[{"reason":"BackOff","regarding":{"kind":"Pod"}}]}`
+	if string(compact) != want {
+		t.Errorf("compact output = %s, want %s", compact, want)
+	}
+
+	indented, err := encodeOutput(output, false)
+	if err != nil {
+		t.Fatalf("encodeOutput() error = %v", err)
+	}
+	wantIndented := `{
+  "bytes": 9007199254740993,
+  "continue": "",
+  "count": 1,
+  "note": "ok",
+  "events": [
+    {
+      "reason": "BackOff",
+      "regarding": {
+        "kind": "Pod"
+      }
+    }
+  ]
+}`
+	if string(indented) != wantIndented {
+		t.Errorf("indented output = %s, want %s", indented, wantIndented)
+	}
+}
+
+func TestJSONResult_Compact(t *testing.T) {
+	provider := createTestProvider(newMockClient())
+	provider.SetCompact(true)
+
+	result, _, err := provider.jsonResult(map[string]any{"count": 2})
+	if err != nil {
+		t.Fatalf("jsonResult() error = %v", err)
+	}
+	if text := result.Content[0].(*mcp.TextContent).Text; text != `{"count":2}` {
+		t.Errorf("expected unindented JSON, got %s", text)
+	}
+}
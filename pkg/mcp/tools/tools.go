@@ -7,14 +7,28 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 
+	"go.miloapis.com/activity/internal/changesource"
+	"go.miloapis.com/activity/internal/timeutil"
+	"go.miloapis.com/activity/pkg/activitysummary"
 	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
+	"go.miloapis.com/activity/pkg/celutil"
 	activityclient "go.miloapis.com/activity/pkg/client/clientset/versioned/typed/activity/v1alpha1"
 )
 
@@ -23,6 +37,11 @@ import (
 type ToolProvider struct {
 	client    activityclient.ActivityV1alpha1Interface
 	namespace string
+	compact   bool
+
+	// maxTimelineBuckets caps get_activity_timeline buckets; 0 means
+	// defaultMaxTimelineBuckets.
+	maxTimelineBuckets int
 }
 
 // Config contains configuration for the ToolProvider.
@@ -38,6 +57,16 @@ type Config struct {
 	// Namespace for namespaced resources (e.g., Activities).
 	// If empty, uses "default".
 	Namespace string
+
+	// Compact returns tool results as unindented JSON. Indentation makes
+	// large results much bigger, so compact output saves context window
+	// tokens at the cost of readability.
+	Compact bool
+
+	// MaxTimelineBuckets caps how many buckets get_activity_timeline may
+	// return. Requests that would exceed it are rejected, and auto bucket
+	// sizing picks the finest size that fits. Zero uses the default of 500.
+	MaxTimelineBuckets int
 }
 
 // NewToolProvider creates a new ToolProvider with the given configuration.
@@ -85,6 +114,9 @@ func NewToolProvider(cfg Config) (*ToolProvider, error) {
 	return &ToolProvider{
 		client:    client,
 		namespace: namespace,
+		compact:   cfg.Compact,
+
+		maxTimelineBuckets: cfg.MaxTimelineBuckets,
 	}, nil
 }
 
@@ -100,6 +132,18 @@ func NewToolProviderWithClient(client activityclient.ActivityV1alpha1Interface,
 	}
 }
 
+// SetCompact switches tool results between indented and unindented JSON, for
+// providers created with NewToolProviderWithClient. See Config.Compact.
+func (p *ToolProvider) SetCompact(compact bool) {
+	p.compact = compact
+}
+
+// SetMaxTimelineBuckets caps get_activity_timeline buckets, for providers
+// created with NewToolProviderWithClient. See Config.MaxTimelineBuckets.
+func (p *ToolProvider) SetMaxTimelineBuckets(max int) {
+	p.maxTimelineBuckets = max
+}
+
 // Close releases resources held by the ToolProvider.
 func (p *ToolProvider) Close() error {
 	// Kubernetes client doesn't need explicit cleanup
@@ -111,12 +155,12 @@ func (p *ToolProvider) RegisterTools(server *mcp.Server) {
 	// Audit log tools
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "query_audit_logs",
-		Description: "Search audit logs from the Kubernetes control plane. Use this to investigate incidents, track resource changes, or analyze user activity. Results are returned newest-first.",
+		Description: "Search audit logs from the Kubernetes control plane. Use this to investigate incidents, track resource changes, or analyze user activity. Results are returned newest-first. Filter with durationMs (e.g. durationMs > 1000) to find slow requests; each event carries its latency in the activity.miloapis.com/duration-ms annotation. Requests to non-resource endpoints such as /healthz or /metrics have no objectRef; find them with !has(objectRef.resource) and requestURI (e.g. requestURI.startsWith('/metrics')). authzDecision == 'forbid' finds requests the authorizer (RBAC) denied, as opposed to 403s from admission webhooks or policies, whose authzDecision is 'allow'. admissionWebhook names the webhook that rejected a request (e.g. admissionWebhook == 'validate.example.com'). Set mutationsOnly to skip reads and return only requests that changed something.",
 	}, p.handleQueryAuditLogs)
 
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "get_audit_log_facets",
-		Description: "Get distinct values and counts for audit log fields. Use this to discover what verbs, users, resources, and namespaces appear in the audit logs. Useful for building filters or understanding activity patterns.",
+		Description: "Get distinct values and counts for audit log fields. Use this to discover what verbs, users, resources, and namespaces appear in the audit logs, or use the durationMs field for a request latency histogram, the level field to see which audit levels are recorded, and the requestURI field for request path prefixes (e.g. /healthz, /apis/apps) to spot unusual endpoint access, the authzDecision field (allow, forbid) to count authorization denials, and the admissionWebhook field with the filter admissionWebhook != '' to rank admission webhooks by how many requests they reject. Useful for building filters or understanding activity patterns. If some fields fail, the rest are still returned and the failures are listed under facetErrors.",
 	}, p.handleGetAuditLogFacets)
 
 	// Activity tools (human-readable summaries)
@@ -125,15 +169,30 @@ func (p *ToolProvider) RegisterTools(server *mcp.Server) {
 		Description: "Search human-readable activity summaries. Activities are translated from audit logs into friendly descriptions like 'alice created HTTP proxy api-gateway'. Use this to understand what changed in plain language.",
 	}, p.handleQueryActivities)
 
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_activity_by_id",
+		Description: "Get a single activity by name and namespace, for example one referenced by an alert. Returns the full activity including summary, actor, resource, tenant, origin, and links. Cheaper than searching with query_activities when you already know the name.",
+	}, p.handleGetActivityByID)
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_activity_source_events",
+		Description: "Get activities together with the raw audit events that produced them, for deep debugging. Each activity is paired with its source audit event by audit ID. At most 50 activities per call. Activities whose audit event has aged out of retention are marked notFound; activities generated from Kubernetes events are marked notAudit.",
+	}, p.handleGetActivitySourceEvents)
+
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "get_activity_facets",
-		Description: "Get distinct values and counts for activity fields. Discover who's active, what resources are changing, and whether changes are human or automated. Valid fields: spec.changeSource, spec.actor.name, spec.actor.type, spec.resource.apiGroup, spec.resource.kind, spec.resource.namespace.",
+		Description: "Get distinct values and counts for activity fields. Discover who's active, what resources are changing, and whether changes are human or automated. Valid fields: spec.changeSource, spec.actor.name, spec.actor.type, spec.resource.apiGroup, spec.resource.kind, spec.resource.namespace, spec.origin.policyName, spec.tenant.type, spec.tenant.name.",
 	}, p.handleGetActivityFacets)
 
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_resource_types_with_activity",
+		Description: "List the resource kinds and API groups that have recorded activity in a time range, most active first, with activity counts. Use this first when exploring an unfamiliar cluster to learn which resource types are worth drilling into. Narrow it to a namespace, or to a tenant with tenantType (organization, project, user) and tenantName. Defaults to the last 7 days.",
+	}, p.handleGetResourceTypesWithActivity)
+
 	// Investigation tools
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "find_failed_operations",
-		Description: "Find operations that failed (HTTP 4xx/5xx responses). Use this to debug permission issues, find failed deployments, or investigate security events.",
+		Description: "Find operations that failed (HTTP 4xx/5xx responses). Use this to debug permission issues, find failed deployments, or investigate security events. Set messageContains to match the error message text, e.g. the name of an admission webhook or validation rule. To see which webhook rejects the most requests, use get_audit_log_facets on the admissionWebhook field.",
 	}, p.handleFindFailedOperations)
 
 	mcp.AddTool(server, &mcp.Tool{
@@ -141,27 +200,52 @@ func (p *ToolProvider) RegisterTools(server *mcp.Server) {
 		Description: "Get the change history for a specific resource. See who changed what, when, with field-level diffs where available. Use this to understand how a resource evolved over time.",
 	}, p.handleGetResourceHistory)
 
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_resource_histories_batch",
+		Description: "Get the change history (create, update, patch, delete, deletecollection) for up to 25 resources in one call. Collection deletes name no object, so they are matched on resource type and namespace and marked collectionWide; they may not have removed that exact resource. Use this to map the blast radius of an incident across many resources. Each resource is looked up independently; failures are reported inline under that resource instead of failing the batch.",
+	}, p.handleGetResourceHistoriesBatch)
+
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "get_user_activity_summary",
-		Description: "Get a summary of a specific user's recent actions. See what resources they modified, when, and how often. Useful for security reviews and understanding user behavior.",
+		Description: "Get a summary of a specific user's recent actions. See what resources they modified, when, and how often, with a per-verb success/failure breakdown and a list of forbidden or unauthorized attempts. The breakdown is omitted, with a note, when audit logs can't be read; when the response has sampled=true the counts are lower bounds. Set group instead of username to summarize everyone in a group (e.g. system:masters), with an extra per-member breakdown. Useful for security reviews and understanding user behavior.",
 	}, p.handleGetUserActivitySummary)
 
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_actor_blast_radius",
+		Description: "List every resource an actor touched in a time window, for incident response such as a credential compromise. Audit events are grouped by (namespace, resource, name) with mutation counts, per-verb counts, failed requests, and first/last seen timestamps, sorted by namespace and then by count. Set includeReads to count every request, including get, list, watch, impersonate, and subresource verbs, rather than mutations only. Defaults to the last 24 hours.",
+	}, p.handleGetActorBlastRadius)
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_failed_auth_attempts",
+		Description: "Find authentication (401) and authorization (403) failures for security review. Failures are grouped by source IP and by username, with user agents and first/last seen timestamps. Source IPs whose failures span minDistinctUsernames or more usernames (default 3), a credential stuffing signal, are ranked under suspiciousSources. 403s from admission webhooks or policies are not authorization failures and are only counted. Defaults to the last 24 hours.",
+	}, p.handleGetFailedAuthAttempts)
+
 	// Analytics tools
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "get_activity_timeline",
-		Description: "Get activity counts grouped by time buckets (hourly/daily). Use this to visualize activity patterns, identify peak periods, and correlate with incidents.",
+		Description: "Get activity counts grouped by time buckets (hour, day or week). Use this to visualize activity patterns, identify peak periods, and correlate with incidents. bucketSize defaults to auto, which picks the finest size that keeps the timeline within the bucket limit (500 by default); the response's bucketSize says which was used. An explicit bucketSize that would produce more buckets than the limit is rejected. Counts are computed from at most 1000 activities; when the response has sampled=true the counts are lower bounds.",
 	}, p.handleGetActivityTimeline)
 
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "summarize_recent_activity",
-		Description: "Generate a summary of recent activity including top actors, most changed resources, and key highlights. Perfect for status updates and handoffs.",
+		Description: "Generate a summary of recent activity including top actors, most changed resources, a breakdown of deletions by actor and resource type, and key highlights. changeSource narrows the activities to human or system changes; deletions come from audit logs and always cover every actor. Highlights are returned both as rendered sentences and as structuredHighlights objects with type, label, name, count, and metric. Perfect for status updates and handoffs.",
 	}, p.handleSummarizeRecentActivity)
 
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "compare_activity_periods",
-		Description: "Compare activity between two time periods. Identify what changed, new actors, increased/decreased activity. Use this for incident investigation and trend analysis.",
+		Description: "Compare activity between two time periods. Identify what changed, new actors, increased/decreased activity. Counts are computed server-side over every activity in each period, covering the top 100 actors and resource kinds. Use this for incident investigation and trend analysis.",
 	}, p.handleCompareActivityPeriods)
 
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_suspicious_activity",
+		Description: "Flag anomalies for first-pass security triage by comparing a recent window against the equal-length window before it. Reports actors whose change volume grew by volumeMultiplier, first-time actors, deletion spikes, and bursts of 403 forbidden responses as a ranked list of findings with high/medium/low severity. Thresholds are simple and explainable; findings are leads to investigate, not verdicts.",
+	}, p.handleGetSuspiciousActivity)
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_change_velocity",
+		Description: "Answer \"is change velocity unusual right now\". Counts mutating requests (create, update, patch, delete) in the current window, ending now, and in each of the baselineWindows equal-length windows before it, then reports how the current count compares to the baseline average: a ratio (\"3.0x normal\"), a deviation in standard deviations, a level (spike, elevated, normal or quiet) and a one-line verdict. Counts are exact, computed server-side. Use filter to narrow to a namespace, resource or actor.",
+	}, p.handleGetChangeVelocity)
+
 	// Policy tools
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "list_activity_policies",
@@ -176,7 +260,7 @@ func (p *ToolProvider) RegisterTools(server *mcp.Server) {
 	// Event tools
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "query_events",
-		Description: "Search control plane events stored in the Activity service. Events capture resource lifecycle changes, provisioning status, warnings, and errors. Use this to investigate issues, debug deployments, or monitor system health. Results are returned newest-first.",
+		Description: "Search control plane events stored in the Activity service. Events capture resource lifecycle changes, provisioning status, warnings, and errors. Use this to investigate issues, debug deployments, or monitor system health. Results are returned newest-first. Use reasons, types or sourceComponents to match any of several values at once. When the response has a non-empty continue value, pass it as continueToken with the same arguments to fetch the next page.",
 	}, p.handleQueryEvents)
 
 	mcp.AddTool(server, &mcp.Tool{
@@ -202,6 +286,10 @@ type QueryAuditLogsArgs struct {
 
 	// Limit is the maximum number of results to return.
 	Limit int `json:"limit,omitempty"`
+
+	// MutationsOnly skips reads (get, list, watch), returning only requests
+	// that change resources.
+	MutationsOnly bool `json:"mutationsOnly,omitempty"`
 }
 
 func (p *ToolProvider) handleQueryAuditLogs(ctx context.Context, req *mcp.CallToolRequest, args QueryAuditLogsArgs) (*mcp.CallToolResult, any, error) {
@@ -215,10 +303,11 @@ func (p *ToolProvider) handleQueryAuditLogs(ctx context.Context, req *mcp.CallTo
 			GenerateName: "mcp-query-",
 		},
 		Spec: v1alpha1.AuditLogQuerySpec{
-			StartTime: args.StartTime,
-			EndTime:   args.EndTime,
-			Filter:    args.Filter,
-			Limit:     limit,
+			StartTime:     args.StartTime,
+			EndTime:       args.EndTime,
+			Filter:        args.Filter,
+			Limit:         limit,
+			MutationsOnly: args.MutationsOnly,
 		},
 	}
 
@@ -232,10 +321,11 @@ func (p *ToolProvider) handleQueryAuditLogs(ctx context.Context, req *mcp.CallTo
 		"continue":           result.Status.Continue,
 		"effectiveStartTime": result.Status.EffectiveStartTime,
 		"effectiveEndTime":   result.Status.EffectiveEndTime,
+		"retentionClamped":   result.Status.RetentionClamped,
 		"events":             result.Status.Results,
 	}
 
-	return jsonResult(output)
+	return p.jsonResult(output)
 }
 
 // =============================================================================
@@ -295,6 +385,9 @@ func (p *ToolProvider) handleGetAuditLogFacets(ctx context.Context, req *mcp.Cal
 			},
 			Filter: args.Filter,
 			Facets: facetSpecs,
+			// Return whatever facets succeed so the agent can work with them and
+			// mention the failures, rather than getting nothing.
+			PartialResults: true,
 		},
 	}
 
@@ -315,7 +408,18 @@ func (p *ToolProvider) handleGetAuditLogFacets(ctx context.Context, req *mcp.Cal
 		output[facet.Field] = values
 	}
 
-	return jsonResult(output)
+	if len(result.Status.FacetErrors) > 0 {
+		facetErrors := make([]map[string]any, 0, len(result.Status.FacetErrors))
+		for _, e := range result.Status.FacetErrors {
+			facetErrors = append(facetErrors, map[string]any{
+				"field":   e.Field,
+				"message": e.Message,
+			})
+		}
+		output["facetErrors"] = facetErrors
+	}
+
+	return p.jsonResult(output)
 }
 
 // =============================================================================
@@ -399,10 +503,227 @@ func (p *ToolProvider) handleQueryActivities(ctx context.Context, req *mcp.CallT
 		"continue":           result.Status.Continue,
 		"effectiveStartTime": result.Status.EffectiveStartTime,
 		"effectiveEndTime":   result.Status.EffectiveEndTime,
+		"retentionClamped":   result.Status.RetentionClamped,
 		"activities":         activities,
 	}
 
-	return jsonResult(output)
+	return p.jsonResult(output)
+}
+
+// =============================================================================
+// Get Activity By ID
+// =============================================================================
+
+// GetActivityByIDArgs contains the arguments for the get_activity_by_id tool.
+type GetActivityByIDArgs struct {
+	// Name is the activity name (e.g., "act-1a2b3c4d5e6f").
+	Name string `json:"name"`
+
+	// Namespace is the activity's namespace, which is the namespace of the
+	// resource it describes.
+	Namespace string `json:"namespace"`
+}
+
+func (p *ToolProvider) handleGetActivityByID(ctx context.Context, req *mcp.CallToolRequest, args GetActivityByIDArgs) (*mcp.CallToolResult, any, error) {
+	if args.Name == "" {
+		return errorResult("name is required"), nil, nil
+	}
+	if args.Namespace == "" {
+		return errorResult("namespace is required. Activities are stored in the namespace of the resource they describe"), nil, nil
+	}
+
+	activity, err := p.client.Activities(args.Namespace).Get(ctx, args.Name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return errorResult(fmt.Sprintf("Activity %q not found in namespace %q. It may have aged out of retention, or belong to a different namespace or tenant", args.Name, args.Namespace)), nil, nil
+		}
+		return errorResult(fmt.Sprintf("Get failed: %v", err)), nil, nil
+	}
+
+	output := map[string]any{
+		"name":      activity.Name,
+		"namespace": activity.Namespace,
+		"timestamp": activity.CreationTimestamp.Format("2006-01-02T15:04:05Z"),
+		"spec":      activity.Spec,
+	}
+
+	return p.jsonResult(output)
+}
+
+// =============================================================================
+// Get Activity Source Events
+// =============================================================================
+
+const (
+	// maxSourceEventActivities caps how many activities one call may join with
+	// their source audit events. Raw events are large, so the batch is kept small.
+	maxSourceEventActivities = 50
+	// sourceEventLookback widens the audit log window before the earliest
+	// activity. An activity is stamped with its audit event's receive time, so
+	// this only guards against clock skew between the two records.
+	sourceEventLookback = time.Hour
+)
+
+// GetActivitySourceEventsArgs contains the arguments for the get_activity_source_events tool.
+type GetActivitySourceEventsArgs struct {
+	// StartTime is the beginning of the activity search window.
+	StartTime string `json:"startTime"`
+
+	// EndTime is the end of the activity search window.
+	EndTime string `json:"endTime"`
+
+	// Filter is a CEL expression over activity fields (e.g. spec.actor.name == 'alice').
+	Filter string `json:"filter,omitempty"`
+
+	// Search performs full-text search on summary.
+	Search string `json:"search,omitempty"`
+
+	// Limit is the maximum number of activities to return (at most 50).
+	Limit int `json:"limit,omitempty"`
+}
+
+func (p *ToolProvider) handleGetActivitySourceEvents(ctx context.Context, req *mcp.CallToolRequest, args GetActivitySourceEventsArgs) (*mcp.CallToolResult, any, error) {
+	limit := args.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > maxSourceEventActivities {
+		return errorResult(fmt.Sprintf("limit is %d; at most %d activities can be joined with their source events per call", limit, maxSourceEventActivities)), nil, nil
+	}
+
+	query := &v1alpha1.ActivityQuery{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "mcp-activity-sources-",
+		},
+		Spec: v1alpha1.ActivityQuerySpec{
+			StartTime: args.StartTime,
+			EndTime:   args.EndTime,
+			Filter:    args.Filter,
+			Search:    args.Search,
+			Limit:     int32(limit),
+		},
+	}
+
+	result, err := p.client.ActivityQueries().Create(ctx, query, metav1.CreateOptions{})
+	if err != nil {
+		return errorResult(fmt.Sprintf("Query failed: %v", err)), nil, nil
+	}
+
+	// Join on the audit ID carried in each activity's origin
+	var auditIDs []string
+	var earliest, latest time.Time
+	for _, activity := range result.Status.Results {
+		if activity.Spec.Origin.Type != "audit" || activity.Spec.Origin.ID == "" {
+			continue
+		}
+		auditIDs = append(auditIDs, activity.Spec.Origin.ID)
+		ts := activity.CreationTimestamp.Time
+		if earliest.IsZero() || ts.Before(earliest) {
+			earliest = ts
+		}
+		if ts.After(latest) {
+			latest = ts
+		}
+	}
+
+	events := map[string]auditv1.Event{}
+	if len(auditIDs) > 0 {
+		events, err = p.lookupAuditEvents(ctx, auditIDs, earliest.Add(-sourceEventLookback), latest.Add(time.Minute))
+		if err != nil {
+			return errorResult(fmt.Sprintf("Source event query failed: %v", err)), nil, nil
+		}
+	}
+
+	pairs := make([]map[string]any, 0, len(result.Status.Results))
+	foundCount := 0
+	for _, activity := range result.Status.Results {
+		pair := map[string]any{
+			"activity": map[string]any{
+				"name":         activity.Name,
+				"summary":      activity.Spec.Summary,
+				"changeSource": activity.Spec.ChangeSource,
+				"actor": map[string]any{
+					"type": activity.Spec.Actor.Type,
+					"name": activity.Spec.Actor.Name,
+				},
+				"resource": map[string]any{
+					"apiGroup":  activity.Spec.Resource.APIGroup,
+					"kind":      activity.Spec.Resource.Kind,
+					"name":      activity.Spec.Resource.Name,
+					"namespace": activity.Spec.Resource.Namespace,
+				},
+				"origin": map[string]any{
+					"type":       activity.Spec.Origin.Type,
+					"id":         activity.Spec.Origin.ID,
+					"policyName": activity.Spec.Origin.PolicyName,
+				},
+				"timestamp": activity.CreationTimestamp.Format("2006-01-02T15:04:05Z"),
+			},
+		}
+
+		switch {
+		case activity.Spec.Origin.Type != "audit":
+			pair["sourceStatus"] = "notAudit"
+			pair["note"] = "This activity came from a Kubernetes event, not an audit log; use query_events to look it up."
+		case activity.Spec.Origin.ID == "":
+			pair["sourceStatus"] = "missingAuditID"
+			pair["note"] = "This activity does not record the audit ID of its source event."
+		default:
+			if event, ok := events[activity.Spec.Origin.ID]; ok {
+				pair["sourceStatus"] = "found"
+				pair["auditEvent"] = event
+				foundCount++
+			} else {
+				pair["sourceStatus"] = "notFound"
+				pair["note"] = "No audit event with this ID was found; it has most likely aged out of audit log retention."
+			}
+		}
+
+		pairs = append(pairs, pair)
+	}
+
+	output := map[string]any{
+		"count":              len(pairs),
+		"sourceEventsFound":  foundCount,
+		"continue":           result.Status.Continue,
+		"effectiveStartTime": result.Status.EffectiveStartTime,
+		"effectiveEndTime":   result.Status.EffectiveEndTime,
+		"pairs":              pairs,
+	}
+
+	return p.jsonResult(output)
+}
+
+// lookupAuditEvents fetches the audit events with the given IDs from one query
+// and indexes them by audit ID. IDs with no matching event are simply absent.
+func (p *ToolProvider) lookupAuditEvents(ctx context.Context, auditIDs []string, start, end time.Time) (map[string]auditv1.Event, error) {
+	quoted := make([]string, len(auditIDs))
+	for i, id := range auditIDs {
+		quoted[i] = "'" + celutil.EscapeString(id) + "'"
+	}
+
+	query := &v1alpha1.AuditLogQuery{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "mcp-activity-sources-audit-",
+		},
+		Spec: v1alpha1.AuditLogQuerySpec{
+			StartTime: start.UTC().Format(time.RFC3339),
+			EndTime:   end.UTC().Format(time.RFC3339),
+			Filter:    fmt.Sprintf("auditID in [%s]", strings.Join(quoted, ", ")),
+			Limit:     int32(len(auditIDs)),
+		},
+	}
+
+	result, err := p.client.AuditLogQueries().Create(ctx, query, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(map[string]auditv1.Event, len(result.Status.Results))
+	for _, event := range result.Status.Results {
+		events[string(event.AuditID)] = event
+	}
+	return events, nil
 }
 
 // =============================================================================
@@ -413,7 +734,8 @@ func (p *ToolProvider) handleQueryActivities(ctx context.Context, req *mcp.CallT
 type GetActivityFacetsArgs struct {
 	// Fields to get facets for.
 	// Valid values: spec.changeSource, spec.actor.name, spec.actor.type,
-	// spec.resource.apiGroup, spec.resource.kind, spec.resource.namespace
+	// spec.resource.apiGroup, spec.resource.kind, spec.resource.namespace,
+	// spec.origin.policyName, spec.tenant.type, spec.tenant.name
 	Fields []string `json:"fields"`
 
 	// StartTime is the beginning of the time window.
@@ -484,7 +806,122 @@ func (p *ToolProvider) handleGetActivityFacets(ctx context.Context, req *mcp.Cal
 		output[facet.Field] = values
 	}
 
-	return jsonResult(output)
+	return p.jsonResult(output)
+}
+
+// =============================================================================
+// Get Resource Types With Activity
+// =============================================================================
+
+const (
+	// defaultResourceTypesLimit is the number of resource kinds returned when
+	// no limit is given.
+	defaultResourceTypesLimit = 50
+	// maxResourceTypesLimit is the facet query's own cap on distinct values.
+	maxResourceTypesLimit = 100
+)
+
+// GetResourceTypesWithActivityArgs contains the arguments for the
+// get_resource_types_with_activity tool.
+type GetResourceTypesWithActivityArgs struct {
+	// StartTime is the beginning of the time window.
+	StartTime string `json:"startTime,omitempty"`
+
+	// EndTime is the end of the time window.
+	EndTime string `json:"endTime,omitempty"`
+
+	// Namespace limits the count to resources in this namespace.
+	Namespace string `json:"namespace,omitempty"`
+
+	// TenantType limits the count to one tenant type (organization, project, user).
+	TenantType string `json:"tenantType,omitempty"`
+
+	// TenantName limits the count to one tenant.
+	TenantName string `json:"tenantName,omitempty"`
+
+	// Limit is the maximum number of resource kinds and API groups to return.
+	Limit int `json:"limit,omitempty"`
+}
+
+func (p *ToolProvider) handleGetResourceTypesWithActivity(ctx context.Context, req *mcp.CallToolRequest, args GetResourceTypesWithActivityArgs) (*mcp.CallToolResult, any, error) {
+	limit := args.Limit
+	if limit == 0 {
+		limit = defaultResourceTypesLimit
+	}
+	if limit < 0 || limit > maxResourceTypesLimit {
+		return errorResult(fmt.Sprintf("limit must be between 1 and %d", maxResourceTypesLimit)), nil, nil
+	}
+
+	startTime := args.StartTime
+	if startTime == "" {
+		startTime = "now-7d"
+	}
+
+	endTime := args.EndTime
+	if endTime == "" {
+		endTime = "now"
+	}
+
+	var filters []string
+	if args.Namespace != "" {
+		filters = append(filters, fmt.Sprintf("spec.resource.namespace == '%s'", celutil.EscapeString(args.Namespace)))
+	}
+	if args.TenantType != "" {
+		filters = append(filters, fmt.Sprintf("spec.tenant.type == '%s'", celutil.EscapeString(args.TenantType)))
+	}
+	if args.TenantName != "" {
+		filters = append(filters, fmt.Sprintf("spec.tenant.name == '%s'", celutil.EscapeString(args.TenantName)))
+	}
+
+	query := &v1alpha1.ActivityFacetQuery{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "mcp-resource-types-",
+		},
+		Spec: v1alpha1.ActivityFacetQuerySpec{
+			TimeRange: v1alpha1.FacetTimeRange{
+				Start: startTime,
+				End:   endTime,
+			},
+			Filter: strings.Join(filters, " && "),
+			Facets: []v1alpha1.FacetSpec{
+				{Field: "spec.resource.kind", Limit: int32(limit)},
+				{Field: "spec.resource.apiGroup", Limit: int32(limit)},
+			},
+		},
+	}
+
+	result, err := p.client.ActivityFacetQueries().Create(ctx, query, metav1.CreateOptions{})
+	if err != nil {
+		return errorResult(fmt.Sprintf("Query failed: %v", err)), nil, nil
+	}
+
+	resourceTypes := make([]map[string]any, 0)
+	apiGroups := make([]map[string]any, 0)
+	for _, facet := range result.Status.Facets {
+		for _, v := range facet.Values {
+			switch facet.Field {
+			case "spec.resource.kind":
+				resourceTypes = append(resourceTypes, map[string]any{"kind": v.Value, "count": v.Count})
+			case "spec.resource.apiGroup":
+				apiGroups = append(apiGroups, map[string]any{"apiGroup": v.Value, "count": v.Count})
+			}
+		}
+	}
+
+	output := map[string]any{
+		"timeRange": map[string]any{
+			"start": startTime,
+			"end":   endTime,
+		},
+		"count":         len(resourceTypes),
+		"resourceTypes": resourceTypes,
+		"apiGroups":     apiGroups,
+	}
+	if len(resourceTypes) == limit {
+		output["note"] = fmt.Sprintf("Only the %d most active resource kinds are listed. Raise limit (at most %d) or narrow the scope to see the rest.", limit, maxResourceTypesLimit)
+	}
+
+	return p.jsonResult(output)
 }
 
 // =============================================================================
@@ -515,6 +952,10 @@ type FindFailedOperationsArgs struct {
 	// Verb filters by verb.
 	Verb string `json:"verb,omitempty"`
 
+	// MessageContains matches failures whose response message contains this
+	// text (case-sensitive), e.g. "denied the request" or the name of an admission webhook.
+	MessageContains string `json:"messageContains,omitempty"`
+
 	// Limit is the maximum number of results.
 	Limit int `json:"limit,omitempty"`
 }
@@ -540,23 +981,7 @@ func (p *ToolProvider) handleFindFailedOperations(ctx context.Context, req *mcp.
 		statusCodeMax = 599
 	}
 
-	// Build CEL filter for failed operations
-	filters := []string{
-		fmt.Sprintf("responseStatus.code >= %d", statusCodeMin),
-		fmt.Sprintf("responseStatus.code <= %d", statusCodeMax),
-	}
-
-	if args.Username != "" {
-		filters = append(filters, fmt.Sprintf("user.username == '%s'", args.Username))
-	}
-	if args.Resource != "" {
-		filters = append(filters, fmt.Sprintf("objectRef.resource == '%s'", args.Resource))
-	}
-	if args.Verb != "" {
-		filters = append(filters, fmt.Sprintf("verb == '%s'", args.Verb))
-	}
-
-	filter := strings.Join(filters, " && ")
+	filter := buildFailedOperationsFilter(statusCodeMin, statusCodeMax, args.Username, args.Resource, args.Verb, args.MessageContains)
 
 	// Note: Failed operations are queried from audit logs since Activities
 	// are only created for successful operations that match ActivityPolicies.
@@ -608,7 +1033,32 @@ func (p *ToolProvider) handleFindFailedOperations(ctx context.Context, req *mcp.
 		"failures":     failures,
 	}
 
-	return jsonResult(output)
+	return p.jsonResult(output)
+}
+
+// buildFailedOperationsFilter builds the CEL filter for audit events whose
+// response status falls within [statusCodeMin, statusCodeMax]. Empty username,
+// resource, verb, and message values are not filtered on.
+func buildFailedOperationsFilter(statusCodeMin, statusCodeMax int, username, resource, verb, message string) string {
+	filters := []string{
+		fmt.Sprintf("responseStatus.code >= %d", statusCodeMin),
+		fmt.Sprintf("responseStatus.code <= %d", statusCodeMax),
+	}
+
+	if username != "" {
+		filters = append(filters, fmt.Sprintf("user.username == '%s'", celutil.EscapeString(username)))
+	}
+	if resource != "" {
+		filters = append(filters, fmt.Sprintf("objectRef.resource == '%s'", celutil.EscapeString(resource)))
+	}
+	if verb != "" {
+		filters = append(filters, fmt.Sprintf("verb == '%s'", celutil.EscapeString(verb)))
+	}
+	if message != "" {
+		filters = append(filters, fmt.Sprintf("responseStatus.message.contains('%s')", celutil.EscapeString(message)))
+	}
+
+	return strings.Join(filters, " && ")
 }
 
 // =============================================================================
@@ -719,34 +1169,282 @@ func (p *ToolProvider) handleGetResourceHistory(ctx context.Context, req *mcp.Ca
 		"history":   history,
 	}
 
-	return jsonResult(output)
+	return p.jsonResult(output)
 }
 
 // =============================================================================
-// Get User Activity Summary
+// Get Resource Histories (Batch)
 // =============================================================================
 
-// GetUserActivitySummaryArgs contains the arguments for the get_user_activity_summary tool.
-type GetUserActivitySummaryArgs struct {
-	// Username is the username or email to get activity for.
-	Username string `json:"username,omitempty"`
+const (
+	// maxBatchResources caps how many resources one batch call may look up.
+	maxBatchResources = 25
+	// maxBatchEvents caps the events returned across the whole batch. The
+	// per-resource limit is reduced so the batch never exceeds it.
+	maxBatchEvents = 1000
+	// batchConcurrency bounds the audit log queries in flight for one batch.
+	batchConcurrency = 5
+)
 
-	// StartTime is the beginning of the time window.
-	StartTime string `json:"startTime,omitempty"`
+// ResourceRef identifies a resource for a batch history lookup.
+type ResourceRef struct {
+	// APIGroup of the resource (empty for the core group).
+	APIGroup string `json:"apiGroup,omitempty"`
 
-	// EndTime is the end of the time window.
-	EndTime string `json:"endTime,omitempty"`
+	// Kind of the resource (e.g. Deployment).
+	Kind string `json:"kind,omitempty"`
 
-	// IncludeDetails includes individual activities in the response.
-	IncludeDetails bool `json:"includeDetails,omitempty"`
-}
+	// Resource is the plural resource name (e.g. deployments). Defaults to
+	// the lowercase plural of Kind; set it for irregular plurals.
+	Resource string `json:"resource,omitempty"`
 
-func (p *ToolProvider) handleGetUserActivitySummary(ctx context.Context, req *mcp.CallToolRequest, args GetUserActivitySummaryArgs) (*mcp.CallToolResult, any, error) {
-	if args.Username == "" {
-		return errorResult("Username is required"), nil, nil
-	}
+	// Name of the resource.
+	Name string `json:"name"`
 
-	startTime := args.StartTime
+	// Namespace of the resource (empty for cluster-scoped resources).
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// GetResourceHistoriesBatchArgs contains the arguments for the get_resource_histories_batch tool.
+type GetResourceHistoriesBatchArgs struct {
+	// Resources to look up (at most 25).
+	Resources []ResourceRef `json:"resources"`
+
+	// StartTime limits history to after this time.
+	StartTime string `json:"startTime,omitempty"`
+
+	// EndTime limits history to before this time.
+	EndTime string `json:"endTime,omitempty"`
+
+	// LimitPerResource is the maximum number of events per resource.
+	LimitPerResource int `json:"limitPerResource,omitempty"`
+}
+
+func (p *ToolProvider) handleGetResourceHistoriesBatch(ctx context.Context, req *mcp.CallToolRequest, args GetResourceHistoriesBatchArgs) (*mcp.CallToolResult, any, error) {
+	if len(args.Resources) == 0 {
+		return errorResult("resources must contain at least one resource"), nil, nil
+	}
+	if len(args.Resources) > maxBatchResources {
+		return errorResult(fmt.Sprintf("resources contains %d entries; at most %d are allowed per call", len(args.Resources), maxBatchResources)), nil, nil
+	}
+
+	startTime := args.StartTime
+	if startTime == "" {
+		startTime = "now-30d"
+	}
+
+	endTime := args.EndTime
+	if endTime == "" {
+		endTime = "now"
+	}
+
+	limit := args.LimitPerResource
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	if budget := maxBatchEvents / len(args.Resources); limit > budget {
+		limit = budget
+	}
+
+	// Look up each distinct resource once, keeping the caller's order
+	keys := make([]string, 0, len(args.Resources))
+	refs := make(map[string]ResourceRef, len(args.Resources))
+	for _, ref := range args.Resources {
+		ref.Resource = resourceForRef(ref)
+		key := resourceRefKey(ref)
+		if _, ok := refs[key]; ok {
+			continue
+		}
+		keys = append(keys, key)
+		refs[key] = ref
+	}
+
+	results := make([]map[string]any, len(keys))
+	sem := make(chan struct{}, batchConcurrency)
+	var wg sync.WaitGroup
+	for i, key := range keys {
+		wg.Add(1)
+		go func(i int, ref ResourceRef) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i] = p.lookupResourceHistory(ctx, ref, startTime, endTime, int32(limit))
+		}(i, refs[key])
+	}
+	wg.Wait()
+
+	histories := make(map[string]any, len(keys))
+	totalEvents := 0
+	errorCount := 0
+	for i, key := range keys {
+		histories[key] = results[i]
+		if _, failed := results[i]["error"]; failed {
+			errorCount++
+			continue
+		}
+		totalEvents += results[i]["count"].(int)
+	}
+
+	output := map[string]any{
+		"resourceCount":    len(keys),
+		"totalEvents":      totalEvents,
+		"errorCount":       errorCount,
+		"limitPerResource": limit,
+		"timeRange":        map[string]any{"start": startTime, "end": endTime},
+		"histories":        histories,
+	}
+
+	return p.jsonResult(output)
+}
+
+// lookupResourceHistory queries the changes to one resource. Failures are
+// returned inline so one bad resource doesn't fail the whole batch.
+func (p *ToolProvider) lookupResourceHistory(ctx context.Context, ref ResourceRef, startTime, endTime string, limit int32) map[string]any {
+	entry := map[string]any{
+		"resource": map[string]any{
+			"apiGroup":  ref.APIGroup,
+			"kind":      ref.Kind,
+			"resource":  ref.Resource,
+			"name":      ref.Name,
+			"namespace": ref.Namespace,
+		},
+	}
+
+	if ref.Name == "" {
+		entry["error"] = "name is required"
+		return entry
+	}
+	if ref.Resource == "" {
+		entry["error"] = "kind or resource is required"
+		return entry
+	}
+
+	query := &v1alpha1.AuditLogQuery{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "mcp-resource-histories-",
+		},
+		Spec: v1alpha1.AuditLogQuerySpec{
+			StartTime:     startTime,
+			EndTime:       endTime,
+			Filter:        buildResourceHistoryFilter(ref),
+			MutationsOnly: true,
+			Limit:         limit,
+		},
+	}
+
+	result, err := p.client.AuditLogQueries().Create(ctx, query, metav1.CreateOptions{})
+	if err != nil {
+		entry["error"] = fmt.Sprintf("Query failed: %v", err)
+		return entry
+	}
+
+	history := make([]map[string]any, 0, len(result.Status.Results))
+	for _, event := range result.Status.Results {
+		change := map[string]any{
+			"timestamp": event.StageTimestamp.Format("2006-01-02T15:04:05Z"),
+			"verb":      event.Verb,
+			"actor":     event.User.Username,
+			"auditID":   string(event.AuditID),
+		}
+		if event.ResponseStatus != nil {
+			change["statusCode"] = event.ResponseStatus.Code
+		}
+		if event.Verb == "deletecollection" {
+			change["collectionWide"] = true
+		}
+		history = append(history, change)
+	}
+
+	entry["count"] = len(history)
+	entry["truncated"] = result.Status.Continue != ""
+	entry["history"] = history
+	return entry
+}
+
+// buildResourceHistoryFilter builds the CEL filter for requests to one
+// resource. The query sets mutationsOnly to keep only the changes.
+func buildResourceHistoryFilter(ref ResourceRef) string {
+	filters := []string{
+		fmt.Sprintf("objectRef.apiGroup == '%s'", celutil.EscapeString(ref.APIGroup)),
+		fmt.Sprintf("objectRef.resource == '%s'", celutil.EscapeString(ref.Resource)),
+		// Collection deletes name no object but may have removed this one
+		fmt.Sprintf("(objectRef.name == '%s' || verb == 'deletecollection')", celutil.EscapeString(ref.Name)),
+	}
+	if ref.Namespace != "" {
+		filters = append(filters, fmt.Sprintf("objectRef.namespace == '%s'", celutil.EscapeString(ref.Namespace)))
+	}
+
+	return strings.Join(filters, " && ")
+}
+
+// resourceForRef returns the plural resource name for a reference, guessing it
+// from the Kind the same way Kubernetes does when none is given.
+func resourceForRef(ref ResourceRef) string {
+	if ref.Resource != "" || ref.Kind == "" {
+		return ref.Resource
+	}
+	plural, _ := meta.UnsafeGuessKindToResource(schema.GroupVersionKind{Group: ref.APIGroup, Kind: ref.Kind})
+	return plural.Resource
+}
+
+// resourceRefKey identifies a resource in the batch output as
+// group/resource/namespace/name, using "core" for the core group and
+// omitting the namespace for cluster-scoped resources.
+func resourceRefKey(ref ResourceRef) string {
+	group := ref.APIGroup
+	if group == "" {
+		group = "core"
+	}
+	if ref.Namespace == "" {
+		return fmt.Sprintf("%s/%s/%s", group, ref.Resource, ref.Name)
+	}
+	return fmt.Sprintf("%s/%s/%s/%s", group, ref.Resource, ref.Namespace, ref.Name)
+}
+
+// =============================================================================
+// Get User Activity Summary
+// =============================================================================
+
+const (
+	// userSummaryPageSize is the audit log page size used to collect requests.
+	userSummaryPageSize = 1000
+	// userSummaryMaxEvents caps the audit events aggregated in one call.
+	userSummaryMaxEvents = 10000
+	// userSummaryActivityLimit caps the activities counted in one call.
+	userSummaryActivityLimit = 1000
+)
+
+// GetUserActivitySummaryArgs contains the arguments for the get_user_activity_summary tool.
+type GetUserActivitySummaryArgs struct {
+	// Username is the username or email to get activity for.
+	Username string `json:"username,omitempty"`
+
+	// Group summarizes the activity of every member of a group instead of a
+	// single user. Mutually exclusive with Username.
+	Group string `json:"group,omitempty"`
+
+	// StartTime is the beginning of the time window.
+	StartTime string `json:"startTime,omitempty"`
+
+	// EndTime is the end of the time window.
+	EndTime string `json:"endTime,omitempty"`
+
+	// IncludeDetails includes individual activities in the response.
+	IncludeDetails bool `json:"includeDetails,omitempty"`
+}
+
+func (p *ToolProvider) handleGetUserActivitySummary(ctx context.Context, req *mcp.CallToolRequest, args GetUserActivitySummaryArgs) (*mcp.CallToolResult, any, error) {
+	if args.Username == "" && args.Group == "" {
+		return errorResult("Username or group is required"), nil, nil
+	}
+	if args.Username != "" && args.Group != "" {
+		return errorResult("Specify either username or group, not both"), nil, nil
+	}
+
+	startTime := args.StartTime
 	if startTime == "" {
 		startTime = "now-7d"
 	}
@@ -764,13 +1462,37 @@ func (p *ToolProvider) handleGetUserActivitySummary(ctx context.Context, req *mc
 		Spec: v1alpha1.ActivityQuerySpec{
 			StartTime: startTime,
 			EndTime:   endTime,
-			Limit:     1000, // Get more activities for summary
+			Limit:     userSummaryActivityLimit,
 		},
 	}
 
-	result, err := p.client.ActivityQueries().Create(ctx, query, metav1.CreateOptions{})
-	if err != nil {
-		return errorResult(fmt.Sprintf("Query failed: %v", err)), nil, nil
+	// Activities are only generated for successful changes, so the per-verb
+	// success/failure breakdown comes from the user's audit logs.
+	auditFilter := fmt.Sprintf("user.username == '%s'", celutil.EscapeString(args.Username))
+	if args.Group != "" {
+		auditFilter = fmt.Sprintf("'%s' in user.groups", celutil.EscapeString(args.Group))
+	}
+	audit, auditErr := p.collectUserSummaryAuditLogs(ctx, startTime, endTime, auditFilter)
+	if auditErr != nil && args.Group != "" {
+		// Group members are only known from the group's audit logs
+		return errorResult(fmt.Sprintf("Audit log query failed: %v", auditErr)), nil, nil
+	}
+
+	// Activities don't record the actor's groups, so a group's are matched on
+	// the members seen in its audit logs.
+	members := []string{args.Username}
+	if args.Group != "" {
+		members = auditLogUsernames(audit.events)
+	}
+	query.Spec.Filter = buildActorNameFilter(members)
+
+	result := &v1alpha1.ActivityQuery{}
+	if args.Group == "" || len(members) > 0 {
+		var err error
+		result, err = p.client.ActivityQueries().Create(ctx, query, metav1.CreateOptions{})
+		if err != nil {
+			return errorResult(fmt.Sprintf("Query failed: %v", err)), nil, nil
+		}
 	}
 
 	// Build summary
@@ -809,333 +1531,1685 @@ func (p *ToolProvider) handleGetUserActivitySummary(ctx context.Context, req *mc
 		})
 	}
 
+	timeRange := map[string]any{
+		"start": result.Status.EffectiveStartTime,
+		"end":   result.Status.EffectiveEndTime,
+	}
+	if timeRange["start"] == "" {
+		timeRange["start"] = audit.effectiveStart
+		timeRange["end"] = audit.effectiveEnd
+	}
+
+	breakdown := map[string]any{
+		"byChangeSource": changeSourceCounts,
+		"byResourceKind": resourceKindCounts,
+		"byDay":          dayList,
+	}
 	output := map[string]any{
 		"user": map[string]any{
 			"username": args.Username,
 		},
-		"timeRange": map[string]any{
-			"start": result.Status.EffectiveStartTime,
-			"end":   result.Status.EffectiveEndTime,
-		},
+		"timeRange":       timeRange,
 		"totalActivities": len(result.Status.Results),
-		"breakdown": map[string]any{
-			"byChangeSource": changeSourceCounts,
-			"byResourceKind": resourceKindCounts,
-			"byDay":          dayList,
-		},
+		"breakdown":       breakdown,
+	}
+
+	// Counts built from a capped read are lower bounds, and are flagged so
+	// they aren't taken as authoritative
+	var notes []string
+	sampled := false
+	if auditErr != nil {
+		notes = append(notes, fmt.Sprintf("Audit logs could not be read (%v), so the per-verb breakdown and failures are omitted.", auditErr))
+	} else {
+		verbBreakdown, failures := buildVerbBreakdown(audit.events)
+		breakdown["byVerb"] = verbBreakdown
+		output["failures"] = failures
+		if audit.truncated {
+			sampled = true
+			notes = append(notes, fmt.Sprintf("The window holds more than %d matching audit events, so byVerb and failures cover only the most recent. "+
+				"Use a shorter window for complete counts.", userSummaryMaxEvents))
+			if args.Group != "" {
+				notes = append(notes, "Group members were found in those events, so members only seen in older events are missing from memberCount, byMember and the activity counts.")
+			}
+		}
+	}
+	if result.Status.Continue != "" {
+		sampled = true
+		notes = append(notes, fmt.Sprintf("Only the %d most recent activities were counted, so totalActivities and the activity breakdowns are lower bounds. "+
+			"Use a shorter window for complete counts.", userSummaryActivityLimit))
+	}
+	output["sampled"] = sampled
+	if len(notes) > 0 {
+		output["note"] = strings.Join(notes, " ")
+	}
+
+	if args.Group != "" {
+		delete(output, "user")
+		output["group"] = map[string]any{
+			"name":            args.Group,
+			"memberCount":     len(members),
+			"membersComplete": !audit.truncated,
+		}
+		output["byMember"] = buildMemberBreakdown(audit.events, result.Status.Results)
 	}
 
 	if args.IncludeDetails && len(recentActivities) > 0 {
 		output["recentActivities"] = recentActivities
 	}
 
-	return jsonResult(output)
+	return p.jsonResult(output)
+}
+
+// userSummaryAuditLogs holds the audit events read for a user activity summary.
+type userSummaryAuditLogs struct {
+	events                       []auditv1.Event
+	effectiveStart, effectiveEnd string
+	// truncated is set when more matching events were left unread
+	truncated bool
+}
+
+// collectUserSummaryAuditLogs pages through the audit logs matching filter in
+// the window, newest first, up to userSummaryMaxEvents.
+func (p *ToolProvider) collectUserSummaryAuditLogs(ctx context.Context, startTime, endTime, filter string) (*userSummaryAuditLogs, error) {
+	audit := &userSummaryAuditLogs{}
+	cont := ""
+	for {
+		result, err := p.client.AuditLogQueries().Create(ctx, &v1alpha1.AuditLogQuery{
+			ObjectMeta: metav1.ObjectMeta{GenerateName: "mcp-user-summary-audit-"},
+			Spec: v1alpha1.AuditLogQuerySpec{
+				StartTime: startTime,
+				EndTime:   endTime,
+				Filter:    filter,
+				Limit:     userSummaryPageSize,
+				Continue:  cont,
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return audit, err
+		}
+
+		if audit.effectiveStart == "" {
+			audit.effectiveStart = result.Status.EffectiveStartTime
+			audit.effectiveEnd = result.Status.EffectiveEndTime
+		}
+		audit.events = append(audit.events, result.Status.Results...)
+		cont = result.Status.Continue
+		if cont == "" || len(audit.events) >= userSummaryMaxEvents {
+			audit.truncated = cont != ""
+			return audit, nil
+		}
+	}
+}
+
+// auditLogUsernames returns the distinct usernames in events, sorted.
+func auditLogUsernames(events []auditv1.Event) []string {
+	seen := make(map[string]bool)
+	var usernames []string
+	for _, event := range events {
+		if event.User.Username != "" && !seen[event.User.Username] {
+			seen[event.User.Username] = true
+			usernames = append(usernames, event.User.Username)
+		}
+	}
+	sort.Strings(usernames)
+	return usernames
+}
+
+// buildActorNameFilter builds a CEL filter matching activities by any of the
+// given actor names.
+func buildActorNameFilter(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = "'" + celutil.EscapeString(name) + "'"
+	}
+	return fmt.Sprintf("spec.actor.name in [%s]", strings.Join(quoted, ", "))
+}
+
+// memberStats summarizes one group member's requests and activities.
+type memberStats struct {
+	Username   string `json:"username"`
+	Requests   int    `json:"requests"`
+	Failed     int    `json:"failed"`
+	Activities int    `json:"activities"`
+}
+
+// buildMemberBreakdown counts requests, failed requests, and activities per
+// group member, busiest members first.
+func buildMemberBreakdown(events []auditv1.Event, activities []v1alpha1.Activity) []*memberStats {
+	byUser := make(map[string]*memberStats)
+	member := func(username string) *memberStats {
+		stats, ok := byUser[username]
+		if !ok {
+			stats = &memberStats{Username: username}
+			byUser[username] = stats
+		}
+		return stats
+	}
+
+	for _, event := range events {
+		stats := member(event.User.Username)
+		stats.Requests++
+		if event.ResponseStatus != nil && event.ResponseStatus.Code >= 400 {
+			stats.Failed++
+		}
+	}
+	for _, activity := range activities {
+		member(activity.Spec.Actor.Name).Activities++
+	}
+
+	members := make([]*memberStats, 0, len(byUser))
+	for _, stats := range byUser {
+		members = append(members, stats)
+	}
+	sort.Slice(members, func(i, j int) bool {
+		if members[i].Requests != members[j].Requests {
+			return members[i].Requests > members[j].Requests
+		}
+		return members[i].Username < members[j].Username
+	})
+	return members
 }
 
 // =============================================================================
-// Get Activity Timeline
+// Get Actor Blast Radius
 // =============================================================================
 
-// GetActivityTimelineArgs contains the arguments for the get_activity_timeline tool.
-type GetActivityTimelineArgs struct {
-	// StartTime is the beginning of the timeline.
-	StartTime string `json:"startTime"`
+const (
+	// blastRadiusPageSize is the audit log page size used to collect events.
+	blastRadiusPageSize = 1000
+	// blastRadiusMaxEvents caps the audit events aggregated in one call.
+	blastRadiusMaxEvents = 10000
+)
 
-	// EndTime is the end of the timeline.
+// GetActorBlastRadiusArgs contains the arguments for the get_actor_blast_radius tool.
+type GetActorBlastRadiusArgs struct {
+	// Username is the username or email of the actor.
+	Username string `json:"username"`
+
+	// StartTime is the beginning of the time window.
+	StartTime string `json:"startTime,omitempty"`
+
+	// EndTime is the end of the time window.
 	EndTime string `json:"endTime,omitempty"`
 
-	// BucketSize is the time bucket size (hour, day).
-	BucketSize string `json:"bucketSize,omitempty"`
+	// IncludeReads counts every request, including get, list, and watch,
+	// rather than mutations only.
+	IncludeReads bool `json:"includeReads,omitempty"`
+}
 
-	// ChangeSource filters by change source (human, system).
-	ChangeSource string `json:"changeSource,omitempty"`
+// blastRadiusEntry aggregates an actor's requests against one resource.
+type blastRadiusEntry struct {
+	Namespace string         `json:"namespace,omitempty"`
+	APIGroup  string         `json:"apiGroup,omitempty"`
+	Resource  string         `json:"resource"`
+	Name      string         `json:"name,omitempty"`
+	Mutations int            `json:"mutations"`
+	Reads     int            `json:"reads,omitempty"`
+	Failed    int            `json:"failed,omitempty"`
+	Verbs     map[string]int `json:"verbs"`
+	FirstSeen time.Time      `json:"firstSeen"`
+	LastSeen  time.Time      `json:"lastSeen"`
 }
 
-func (p *ToolProvider) handleGetActivityTimeline(ctx context.Context, req *mcp.CallToolRequest, args GetActivityTimelineArgs) (*mcp.CallToolResult, any, error) {
+// count returns the number of requests aggregated into the entry.
+func (e *blastRadiusEntry) count() int {
+	return e.Mutations + e.Reads
+}
+
+func (p *ToolProvider) handleGetActorBlastRadius(ctx context.Context, req *mcp.CallToolRequest, args GetActorBlastRadiusArgs) (*mcp.CallToolResult, any, error) {
+	if args.Username == "" {
+		return errorResult("username is required"), nil, nil
+	}
+
+	startTime := args.StartTime
+	if startTime == "" {
+		startTime = "now-24h"
+	}
+
 	endTime := args.EndTime
 	if endTime == "" {
 		endTime = "now"
 	}
 
-	query := &v1alpha1.ActivityQuery{
-		ObjectMeta: metav1.ObjectMeta{
-			GenerateName: "mcp-timeline-",
-		},
-		Spec: v1alpha1.ActivityQuerySpec{
-			StartTime: args.StartTime,
-			EndTime:   endTime,
-			Limit:     1000,
-		},
+	filter := fmt.Sprintf("user.username == '%s'", celutil.EscapeString(args.Username))
+
+	// Page through the window so the aggregate covers every event, up to a cap
+	var events []auditv1.Event
+	var effectiveStart, effectiveEnd, cont string
+	for {
+		result, err := p.client.AuditLogQueries().Create(ctx, &v1alpha1.AuditLogQuery{
+			ObjectMeta: metav1.ObjectMeta{GenerateName: "mcp-blast-radius-"},
+			Spec: v1alpha1.AuditLogQuerySpec{
+				StartTime:     startTime,
+				EndTime:       endTime,
+				Filter:        filter,
+				MutationsOnly: !args.IncludeReads,
+				Limit:         blastRadiusPageSize,
+				Continue:      cont,
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return errorResult(fmt.Sprintf("Audit log query failed: %v", err)), nil, nil
+		}
+
+		if effectiveStart == "" {
+			effectiveStart = result.Status.EffectiveStartTime
+			effectiveEnd = result.Status.EffectiveEndTime
+		}
+		events = append(events, result.Status.Results...)
+		cont = result.Status.Continue
+		if cont == "" || len(events) >= blastRadiusMaxEvents {
+			break
+		}
+	}
+
+	entries := buildBlastRadius(events)
+
+	namespaces := make(map[string]bool)
+	resourceTypes := make(map[string]bool)
+	totalMutations := 0
+	for _, e := range entries {
+		namespaces[e.Namespace] = true
+		resourceTypes[e.APIGroup+"/"+e.Resource] = true
+		totalMutations += e.Mutations
+	}
+
+	truncated := cont != ""
+	output := map[string]any{
+		"user": map[string]any{
+			"username": args.Username,
+		},
+		"timeRange": map[string]any{
+			"start": effectiveStart,
+			"end":   effectiveEnd,
+		},
+		"totalEvents":    len(events),
+		"totalMutations": totalMutations,
+		"namespaces":     len(namespaces),
+		"resourceTypes":  len(resourceTypes),
+		"resources":      entries,
+		"truncated":      truncated,
+	}
+
+	if truncated {
+		output["note"] = fmt.Sprintf("The window holds more than %d matching audit events, so only the most recent were aggregated. "+
+			"Use a shorter window for a complete picture.", blastRadiusMaxEvents)
+	}
+
+	return p.jsonResult(output)
+}
+
+// buildBlastRadius aggregates audit events by the (namespace, resource, name)
+// they touched, sorted by namespace and then by request count, busiest first.
+// Events without an object reference (non-resource URLs) are skipped.
+func buildBlastRadius(events []auditv1.Event) []*blastRadiusEntry {
+	type key struct{ namespace, apiGroup, resource, name string }
+	byKey := make(map[key]*blastRadiusEntry)
+
+	for _, event := range events {
+		ref := event.ObjectRef
+		if ref == nil {
+			continue
+		}
+
+		k := key{ref.Namespace, ref.APIGroup, ref.Resource, ref.Name}
+		entry, ok := byKey[k]
+		if !ok {
+			entry = &blastRadiusEntry{
+				Namespace: ref.Namespace,
+				APIGroup:  ref.APIGroup,
+				Resource:  ref.Resource,
+				Name:      ref.Name,
+				Verbs:     make(map[string]int),
+			}
+			byKey[k] = entry
+		}
+
+		if slices.Contains(celutil.MutatingVerbs, event.Verb) {
+			entry.Mutations++
+		} else {
+			entry.Reads++
+		}
+		entry.Verbs[event.Verb]++
+		if event.ResponseStatus != nil && event.ResponseStatus.Code >= 400 {
+			entry.Failed++
+		}
+
+		ts := event.RequestReceivedTimestamp.Time.UTC()
+		if entry.FirstSeen.IsZero() || ts.Before(entry.FirstSeen) {
+			entry.FirstSeen = ts
+		}
+		if ts.After(entry.LastSeen) {
+			entry.LastSeen = ts
+		}
+	}
+
+	entries := make([]*blastRadiusEntry, 0, len(byKey))
+	for _, entry := range byKey {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.count() != b.count() {
+			return a.count() > b.count()
+		}
+		if a.Resource != b.Resource {
+			return a.Resource < b.Resource
+		}
+		return a.Name < b.Name
+	})
+
+	return entries
+}
+
+// =============================================================================
+// Get Failed Auth Attempts
+// =============================================================================
+
+const (
+	// failedAuthPageSize is the audit log page size used to collect failures.
+	failedAuthPageSize = 1000
+	// failedAuthMaxEvents caps the failures aggregated in one call.
+	failedAuthMaxEvents = 10000
+	// failedAuthMaxUserAgents caps the user agents listed per source IP.
+	failedAuthMaxUserAgents = 5
+)
+
+// GetFailedAuthAttemptsArgs contains the arguments for the get_failed_auth_attempts tool.
+type GetFailedAuthAttemptsArgs struct {
+	// StartTime is the beginning of the time window.
+	StartTime string `json:"startTime,omitempty"`
+
+	// EndTime is the end of the time window.
+	EndTime string `json:"endTime,omitempty"`
+
+	// Username limits the search to failures for one username.
+	Username string `json:"username,omitempty"`
+
+	// MinDistinctUsernames is how many distinct usernames a source IP must fail
+	// as before it is listed as suspicious. Defaults to 3.
+	MinDistinctUsernames int `json:"minDistinctUsernames,omitempty"`
+}
+
+// failedAuthSource aggregates the auth failures from one source IP.
+type failedAuthSource struct {
+	SourceIP               string         `json:"sourceIP"`
+	Failures               int            `json:"failures"`
+	AuthenticationFailures int            `json:"authenticationFailures"`
+	AuthorizationFailures  int            `json:"authorizationFailures"`
+	DistinctUsernames      int            `json:"distinctUsernames"`
+	Usernames              map[string]int `json:"usernames"`
+	UserAgents             []string       `json:"userAgents,omitempty"`
+	FirstSeen              time.Time      `json:"firstSeen"`
+	LastSeen               time.Time      `json:"lastSeen"`
+
+	userAgents map[string]int
+}
+
+// failedAuthUser aggregates the auth failures for one username.
+type failedAuthUser struct {
+	Username               string   `json:"username"`
+	Failures               int      `json:"failures"`
+	AuthenticationFailures int      `json:"authenticationFailures"`
+	AuthorizationFailures  int      `json:"authorizationFailures"`
+	SourceIPs              []string `json:"sourceIPs"`
+
+	sourceIPs map[string]bool
+}
+
+// failedAuthReport is the aggregate of a window's auth failures.
+type failedAuthReport struct {
+	authentication   int
+	authorization    int
+	admissionDenials int
+	sources          []*failedAuthSource
+	users            []*failedAuthUser
+}
+
+func (p *ToolProvider) handleGetFailedAuthAttempts(ctx context.Context, req *mcp.CallToolRequest, args GetFailedAuthAttemptsArgs) (*mcp.CallToolResult, any, error) {
+	startTime := args.StartTime
+	if startTime == "" {
+		startTime = "now-24h"
+	}
+
+	endTime := args.EndTime
+	if endTime == "" {
+		endTime = "now"
+	}
+
+	minDistinct := args.MinDistinctUsernames
+	if minDistinct <= 0 {
+		minDistinct = 3
+	}
+
+	filter := "responseStatus.code in [401, 403]"
+	if args.Username != "" {
+		filter += fmt.Sprintf(" && user.username == '%s'", celutil.EscapeString(args.Username))
+	}
+
+	// Page through the window so the aggregate covers every failure, up to a cap
+	var events []auditv1.Event
+	var effectiveStart, effectiveEnd, cont string
+	for {
+		result, err := p.client.AuditLogQueries().Create(ctx, &v1alpha1.AuditLogQuery{
+			ObjectMeta: metav1.ObjectMeta{GenerateName: "mcp-failed-auth-"},
+			Spec: v1alpha1.AuditLogQuerySpec{
+				StartTime: startTime,
+				EndTime:   endTime,
+				Filter:    filter,
+				Limit:     failedAuthPageSize,
+				Continue:  cont,
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return errorResult(fmt.Sprintf("Audit log query failed: %v", err)), nil, nil
+		}
+
+		if effectiveStart == "" {
+			effectiveStart = result.Status.EffectiveStartTime
+			effectiveEnd = result.Status.EffectiveEndTime
+		}
+		events = append(events, result.Status.Results...)
+		cont = result.Status.Continue
+		if cont == "" || len(events) >= failedAuthMaxEvents {
+			break
+		}
+	}
+
+	report := buildFailedAuthReport(events)
+
+	// Many usernames failing from one address is the credential stuffing
+	// signal, so rank by distinct usernames before raw volume
+	suspicious := make([]map[string]any, 0)
+	for _, source := range report.sources {
+		if source.DistinctUsernames < minDistinct {
+			continue
+		}
+		suspicious = append(suspicious, map[string]any{
+			"sourceIP":          source.SourceIP,
+			"distinctUsernames": source.DistinctUsernames,
+			"failures":          source.Failures,
+			"reason": fmt.Sprintf("%d failed requests as %d different usernames",
+				source.Failures, source.DistinctUsernames),
+		})
+	}
+	sort.SliceStable(suspicious, func(i, j int) bool {
+		a, b := suspicious[i], suspicious[j]
+		if a["distinctUsernames"] != b["distinctUsernames"] {
+			return a["distinctUsernames"].(int) > b["distinctUsernames"].(int)
+		}
+		return a["failures"].(int) > b["failures"].(int)
+	})
+
+	truncated := cont != ""
+	output := map[string]any{
+		"timeRange": map[string]any{
+			"start": effectiveStart,
+			"end":   effectiveEnd,
+		},
+		"authenticationFailures":   report.authentication,
+		"authorizationFailures":    report.authorization,
+		"excludedAdmissionDenials": report.admissionDenials,
+		"bySourceIP":               report.sources,
+		"byUsername":               report.users,
+		"suspiciousSources":        suspicious,
+		"truncated":                truncated,
+	}
+
+	if truncated {
+		output["note"] = fmt.Sprintf("The window holds more than %d failed requests, so only the most recent were aggregated. "+
+			"Use a shorter window for a complete picture.", failedAuthMaxEvents)
+	}
+
+	return p.jsonResult(output)
+}
+
+// buildFailedAuthReport aggregates 401 and 403 audit events by source IP and
+// username. A 403 from an admission webhook or policy means the request was
+// authorized but rejected, so it is counted apart and left out of the
+// aggregates. Sources and users are sorted by failures, most first.
+func buildFailedAuthReport(events []auditv1.Event) failedAuthReport {
+	var report failedAuthReport
+	sources := make(map[string]*failedAuthSource)
+	users := make(map[string]*failedAuthUser)
+
+	for _, event := range events {
+		if event.ResponseStatus == nil {
+			continue
+		}
+
+		code := event.ResponseStatus.Code
+		switch {
+		case code == 401:
+			report.authentication++
+		case code == 403 && isAdmissionDenial(event.ResponseStatus.Message):
+			report.admissionDenials++
+			continue
+		case code == 403:
+			report.authorization++
+		default:
+			continue
+		}
+
+		// The first source IP is the client; any others are proxies in between
+		sourceIP := "<unknown>"
+		if len(event.SourceIPs) > 0 {
+			sourceIP = event.SourceIPs[0]
+		}
+		// Authentication failures usually carry no user
+		username := event.User.Username
+		if username == "" {
+			username = "<unauthenticated>"
+		}
+
+		source, ok := sources[sourceIP]
+		if !ok {
+			source = &failedAuthSource{
+				SourceIP:   sourceIP,
+				Usernames:  make(map[string]int),
+				userAgents: make(map[string]int),
+			}
+			sources[sourceIP] = source
+		}
+		user, ok := users[username]
+		if !ok {
+			user = &failedAuthUser{Username: username, sourceIPs: make(map[string]bool)}
+			users[username] = user
+		}
+
+		source.Failures++
+		user.Failures++
+		if code == 401 {
+			source.AuthenticationFailures++
+			user.AuthenticationFailures++
+		} else {
+			source.AuthorizationFailures++
+			user.AuthorizationFailures++
+		}
+		source.Usernames[username]++
+		if event.UserAgent != "" {
+			source.userAgents[event.UserAgent]++
+		}
+		user.sourceIPs[sourceIP] = true
+
+		ts := event.RequestReceivedTimestamp.Time.UTC()
+		if source.FirstSeen.IsZero() || ts.Before(source.FirstSeen) {
+			source.FirstSeen = ts
+		}
+		if ts.After(source.LastSeen) {
+			source.LastSeen = ts
+		}
+	}
+
+	report.sources = make([]*failedAuthSource, 0, len(sources))
+	for _, source := range sources {
+		source.DistinctUsernames = len(source.Usernames)
+		source.UserAgents = topKeys(source.userAgents, failedAuthMaxUserAgents)
+		report.sources = append(report.sources, source)
+	}
+	sort.Slice(report.sources, func(i, j int) bool {
+		a, b := report.sources[i], report.sources[j]
+		if a.Failures != b.Failures {
+			return a.Failures > b.Failures
+		}
+		return a.SourceIP < b.SourceIP
+	})
+
+	report.users = make([]*failedAuthUser, 0, len(users))
+	for _, user := range users {
+		for ip := range user.sourceIPs {
+			user.SourceIPs = append(user.SourceIPs, ip)
+		}
+		sort.Strings(user.SourceIPs)
+		report.users = append(report.users, user)
+	}
+	sort.Slice(report.users, func(i, j int) bool {
+		a, b := report.users[i], report.users[j]
+		if a.Failures != b.Failures {
+			return a.Failures > b.Failures
+		}
+		return a.Username < b.Username
+	})
+
+	return report
+}
+
+// isAdmissionDenial reports whether a 403 message comes from admission control
+// rather than authorization.
+func isAdmissionDenial(message string) bool {
+	return strings.Contains(message, "admission webhook") ||
+		strings.Contains(message, "ValidatingAdmissionPolicy")
+}
+
+// topKeys returns up to n keys of counts, highest count first.
+func topKeys(counts map[string]int, n int) []string {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	if len(keys) > n {
+		keys = keys[:n]
+	}
+	return keys
+}
+
+// =============================================================================
+// Get Activity Timeline
+// =============================================================================
+
+// GetActivityTimelineArgs contains the arguments for the get_activity_timeline tool.
+type GetActivityTimelineArgs struct {
+	// StartTime is the beginning of the timeline.
+	StartTime string `json:"startTime"`
+
+	// EndTime is the end of the timeline.
+	EndTime string `json:"endTime,omitempty"`
+
+	// BucketSize is the time bucket size (hour, day, week or auto).
+	// Defaults to auto, which picks the finest size that fits the bucket
+	// limit.
+	BucketSize string `json:"bucketSize,omitempty"`
+
+	// ChangeSource filters by change source (human, system).
+	ChangeSource string `json:"changeSource,omitempty"`
+}
+
+// timelineSampleLimit is the maximum number of activities the timeline tool
+// fetches. Bucket counts are computed client-side from at most this many
+// activities.
+const timelineSampleLimit = 1000
+
+// defaultMaxTimelineBuckets is the bucket cap used when none is configured.
+const defaultMaxTimelineBuckets = 500
+
+// timelineBucketSizes are the supported bucket sizes, finest first, which is
+// the order auto sizing tries them in.
+var timelineBucketSizes = []struct {
+	name  string
+	width time.Duration
+}{
+	{"hour", time.Hour},
+	{"day", 24 * time.Hour},
+	{"week", 7 * 24 * time.Hour},
+}
+
+// timelineBucketCount returns how many buckets of width cover [start, end).
+func timelineBucketCount(start, end time.Time, width time.Duration) int {
+	span := end.Sub(start)
+	if span <= 0 {
+		return 1
+	}
+	return int((span + width - 1) / width)
+}
+
+// chooseTimelineBucketSize resolves bucketSize ("" and "auto" included) to a
+// concrete size whose bucket count over [start, end) is at most maxBuckets.
+func chooseTimelineBucketSize(bucketSize string, start, end time.Time, maxBuckets int) (string, error) {
+	if bucketSize == "" || bucketSize == "auto" {
+		for _, size := range timelineBucketSizes {
+			if timelineBucketCount(start, end, size.width) <= maxBuckets {
+				return size.name, nil
+			}
+		}
+		return "", fmt.Errorf("the time range is too wide for %d weekly buckets; narrow the time range", maxBuckets)
+	}
+
+	for _, size := range timelineBucketSizes {
+		if size.name != bucketSize {
+			continue
+		}
+		if count := timelineBucketCount(start, end, size.width); count > maxBuckets {
+			return "", fmt.Errorf("bucketSize %s would produce %d buckets, more than the limit of %d; use a larger bucketSize or auto, or narrow the time range",
+				bucketSize, count, maxBuckets)
+		}
+		return bucketSize, nil
+	}
+	return "", fmt.Errorf("bucketSize must be hour, day, week or auto, got %q", bucketSize)
+}
+
+// timelineBucketStart returns the start of the UTC bucket containing t.
+// Weekly buckets start on Monday.
+func timelineBucketStart(t time.Time, bucketSize string) time.Time {
+	t = t.UTC()
+	switch bucketSize {
+	case "day":
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	case "week":
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	default:
+		return t.Truncate(time.Hour)
+	}
+}
+
+func (p *ToolProvider) handleGetActivityTimeline(ctx context.Context, req *mcp.CallToolRequest, args GetActivityTimelineArgs) (*mcp.CallToolResult, any, error) {
+	endTime := args.EndTime
+	if endTime == "" {
+		endTime = "now"
+	}
+
+	// Resolve relative times once so the bucket count is checked against
+	// exactly the range that is queried.
+	now := time.Now()
+	start, err := timeutil.ParseFlexibleTime(args.StartTime, now)
+	if err != nil {
+		return errorResult(fmt.Sprintf("Invalid startTime: %v", err)), nil, nil
+	}
+	end, err := timeutil.ParseFlexibleTime(endTime, now)
+	if err != nil {
+		return errorResult(fmt.Sprintf("Invalid endTime: %v", err)), nil, nil
+	}
+
+	maxBuckets := p.maxTimelineBuckets
+	if maxBuckets <= 0 {
+		maxBuckets = defaultMaxTimelineBuckets
+	}
+	bucketSize, err := chooseTimelineBucketSize(args.BucketSize, start, end, maxBuckets)
+	if err != nil {
+		return errorResult(err.Error()), nil, nil
+	}
+
+	query := &v1alpha1.ActivityQuery{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "mcp-timeline-",
+		},
+		Spec: v1alpha1.ActivityQuerySpec{
+			StartTime: start.Format(time.RFC3339),
+			EndTime:   end.Format(time.RFC3339),
+			Limit:     timelineSampleLimit,
+		},
+	}
+
+	result, err := p.client.ActivityQueries().Create(ctx, query, metav1.CreateOptions{})
+	if err != nil {
+		return errorResult(fmt.Sprintf("Query failed: %v", err)), nil, nil
+	}
+
+	// Count by bucket
+	bucketCounts := make(map[string]int)
+	var peakBucket string
+	var peakCount int
+
+	for _, activity := range result.Status.Results {
+		bucket := timelineBucketStart(activity.CreationTimestamp.Time, bucketSize).Format(time.RFC3339)
+		bucketCounts[bucket]++
+
+		if bucketCounts[bucket] > peakCount {
+			peakCount = bucketCounts[bucket]
+			peakBucket = bucket
+		}
+	}
+
+	// Convert to sorted list
+	buckets := make([]map[string]any, 0, len(bucketCounts))
+	for bucket, count := range bucketCounts {
+		entry := map[string]any{
+			"timestamp": bucket,
+			"count":     count,
+		}
+		if bucket == peakBucket {
+			entry["note"] = "peak"
+		}
+		buckets = append(buckets, entry)
+	}
+
+	// Calculate average
+	var avg float64
+	if len(buckets) > 0 {
+		avg = float64(len(result.Status.Results)) / float64(len(buckets))
+	}
+
+	// A full page (or a continue token) means more activities exist in the
+	// window than were counted, so every count below is only a lower bound.
+	sampled := result.Status.Continue != "" || len(result.Status.Results) >= timelineSampleLimit
+
+	output := map[string]any{
+		"timeRange": map[string]any{
+			"start": result.Status.EffectiveStartTime,
+			"end":   result.Status.EffectiveEndTime,
+		},
+		"bucketSize":       bucketSize,
+		"totalCount":       len(result.Status.Results),
+		"buckets":          buckets,
+		"peakBucket":       map[string]any{"timestamp": peakBucket, "count": peakCount},
+		"averagePerBucket": avg,
+		"sampled":          sampled,
+	}
+
+	if sampled {
+		output["note"] = fmt.Sprintf("Only the %d most recent activities in the time range were counted. "+
+			"totalCount and bucket counts are lower bounds, and older buckets may be missing or undercounted. "+
+			"Narrow the time range for exact counts.", len(result.Status.Results))
+	}
+
+	return p.jsonResult(output)
+}
+
+// =============================================================================
+// Summarize Recent Activity
+// =============================================================================
+
+// SummarizeRecentActivityArgs contains the arguments for the summarize_recent_activity tool.
+type SummarizeRecentActivityArgs struct {
+	// StartTime is the beginning of the summary window.
+	StartTime string `json:"startTime"`
+
+	// EndTime is the end of the summary window.
+	EndTime string `json:"endTime,omitempty"`
+
+	// ChangeSource filters by change source (human, system).
+	ChangeSource string `json:"changeSource,omitempty"`
+
+	// TopN is the number of top items per category.
+	TopN int `json:"topN,omitempty"`
+}
+
+func (p *ToolProvider) handleSummarizeRecentActivity(ctx context.Context, req *mcp.CallToolRequest, args SummarizeRecentActivityArgs) (*mcp.CallToolResult, any, error) {
+	endTime := args.EndTime
+	if endTime == "" {
+		endTime = "now"
+	}
+
+	filter := ""
+	switch args.ChangeSource {
+	case "":
+	case "human", "system":
+		filter = fmt.Sprintf("spec.changeSource == '%s'", args.ChangeSource)
+	default:
+		return errorResult(fmt.Sprintf("changeSource must be human or system, got %q", args.ChangeSource)), nil, nil
+	}
+
+	query := &v1alpha1.ActivityQuery{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "mcp-summary-",
+		},
+		Spec: v1alpha1.ActivityQuerySpec{
+			StartTime: args.StartTime,
+			EndTime:   endTime,
+			Filter:    filter,
+			Limit:     1000,
+		},
+	}
+
+	result, err := p.client.ActivityQueries().Create(ctx, query, metav1.CreateOptions{})
+	if err != nil {
+		return errorResult(fmt.Sprintf("Query failed: %v", err)), nil, nil
+	}
+
+	deletions, err := p.client.AuditLogQueries().Create(ctx, &v1alpha1.AuditLogQuery{
+		ObjectMeta: metav1.ObjectMeta{GenerateName: "mcp-summary-deletions-"},
+		Spec: v1alpha1.AuditLogQuerySpec{
+			StartTime: args.StartTime,
+			EndTime:   endTime,
+			Filter:    activitysummary.DeletionFilter,
+			Limit:     1000,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return errorResult(fmt.Sprintf("Deletion query failed: %v", err)), nil, nil
+	}
+
+	summary := activitysummary.Summarize(result.Status.Results, deletions.Status.Results, args.TopN)
+	structured := summary.Highlights()
+
+	// highlights keeps the rendered sentences for existing consumers.
+	highlights := make([]string, len(structured))
+	for i, h := range structured {
+		highlights[i] = h.Text
+	}
+
+	output := map[string]any{
+		"timeRange": map[string]any{
+			"start": result.Status.EffectiveStartTime,
+			"end":   result.Status.EffectiveEndTime,
+		},
+		"totalActivities":      summary.TotalActivities,
+		"humanChanges":         summary.HumanChanges,
+		"systemChanges":        summary.SystemChanges,
+		"highlights":           highlights,
+		"structuredHighlights": structured,
+		"topActors":            summary.TopActors,
+		"topResources":         summary.TopResources,
+		"recentSummaries":      summary.RecentSummaries,
+		"deletions":            summary.Deletions,
+	}
+
+	return p.jsonResult(output)
+}
+
+// =============================================================================
+// Compare Activity Periods
+// =============================================================================
+
+// CompareActivityPeriodsArgs contains the arguments for the compare_activity_periods tool.
+type CompareActivityPeriodsArgs struct {
+	// BaselineStart is the start of the baseline period.
+	BaselineStart string `json:"baselineStart"`
+
+	// BaselineEnd is the end of the baseline period.
+	BaselineEnd string `json:"baselineEnd"`
+
+	// ComparisonStart is the start of the comparison period.
+	ComparisonStart string `json:"comparisonStart"`
+
+	// ComparisonEnd is the end of the comparison period.
+	ComparisonEnd string `json:"comparisonEnd"`
+}
+
+func (p *ToolProvider) handleCompareActivityPeriods(ctx context.Context, req *mcp.CallToolRequest, args CompareActivityPeriodsArgs) (*mcp.CallToolResult, any, error) {
+	// Resolve relative times once so the reported periods are exactly the
+	// ones that were counted.
+	now := time.Now()
+	baselineStart, err := timeutil.ParseFlexibleTime(args.BaselineStart, now)
+	if err != nil {
+		return errorResult(fmt.Sprintf("Invalid baselineStart: %v", err)), nil, nil
+	}
+	baselineEnd, err := timeutil.ParseFlexibleTime(args.BaselineEnd, now)
+	if err != nil {
+		return errorResult(fmt.Sprintf("Invalid baselineEnd: %v", err)), nil, nil
+	}
+	comparisonStart, err := timeutil.ParseFlexibleTime(args.ComparisonStart, now)
+	if err != nil {
+		return errorResult(fmt.Sprintf("Invalid comparisonStart: %v", err)), nil, nil
+	}
+	comparisonEnd, err := timeutil.ParseFlexibleTime(args.ComparisonEnd, now)
+	if err != nil {
+		return errorResult(fmt.Sprintf("Invalid comparisonEnd: %v", err)), nil, nil
+	}
+
+	baselineCounts, err := p.aggregateActivityPeriod(ctx, "mcp-compare-baseline-", baselineStart, baselineEnd)
+	if err != nil {
+		return errorResult(fmt.Sprintf("Baseline query failed: %v", err)), nil, nil
+	}
+
+	comparisonCounts, err := p.aggregateActivityPeriod(ctx, "mcp-compare-comparison-", comparisonStart, comparisonEnd)
+	if err != nil {
+		return errorResult(fmt.Sprintf("Comparison query failed: %v", err)), nil, nil
+	}
+
+	// Find differences
+	newInComparison := findNew(baselineCounts.actors, comparisonCounts.actors)
+	increasedActivity := findIncreased(baselineCounts.resourceKinds, comparisonCounts.resourceKinds)
+	decreasedActivity := findDecreased(baselineCounts.resourceKinds, comparisonCounts.resourceKinds)
+
+	// Calculate change percentage
+	var changePercent float64
+	if baselineCounts.total > 0 {
+		changePercent = float64(comparisonCounts.total-baselineCounts.total) / float64(baselineCounts.total) * 100
+	}
+
+	output := map[string]any{
+		"baseline": map[string]any{
+			"start": baselineStart.UTC().Format(time.RFC3339),
+			"end":   baselineEnd.UTC().Format(time.RFC3339),
+			"count": baselineCounts.total,
+		},
+		"comparison": map[string]any{
+			"start": comparisonStart.UTC().Format(time.RFC3339),
+			"end":   comparisonEnd.UTC().Format(time.RFC3339),
+			"count": comparisonCounts.total,
+		},
+		"changePercent":     changePercent,
+		"newInComparison":   newInComparison,
+		"increasedActivity": increasedActivity,
+		"decreasedActivity": decreasedActivity,
+	}
+
+	// Add analysis summary
+	direction := "more"
+	if changePercent < 0 {
+		direction = "less"
+	}
+	analysis := fmt.Sprintf("Comparison period shows %.0f%% %s activity.", absFloat(changePercent), direction)
+
+	if len(newInComparison) > 0 {
+		analysis += fmt.Sprintf(" %d new actors appeared.", len(newInComparison))
+	}
+
+	output["analysis"] = analysis
+
+	return p.jsonResult(output)
+}
+
+// comparisonFacetLimit is the most actors or resource kinds counted per period.
+// Totals come from the change source facet, which only has a handful of
+// values, so they are exact regardless of this limit.
+const comparisonFacetLimit = 100
+
+// aggregateActivityPeriod counts a period's activities by actor, resource kind,
+// and change source with a single facet query, so the counts are computed in
+// ClickHouse over every activity rather than a sample.
+func (p *ToolProvider) aggregateActivityPeriod(ctx context.Context, generateName string, start, end time.Time) (activityCounts, error) {
+	query := &v1alpha1.ActivityFacetQuery{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: generateName,
+		},
+		Spec: v1alpha1.ActivityFacetQuerySpec{
+			TimeRange: v1alpha1.FacetTimeRange{
+				Start: start.UTC().Format(time.RFC3339),
+				End:   end.UTC().Format(time.RFC3339),
+			},
+			Facets: []v1alpha1.FacetSpec{
+				{Field: "spec.changeSource", Limit: comparisonFacetLimit},
+				{Field: "spec.actor.name", Limit: comparisonFacetLimit},
+				{Field: "spec.resource.kind", Limit: comparisonFacetLimit},
+			},
+		},
+	}
+
+	result, err := p.client.ActivityFacetQueries().Create(ctx, query, metav1.CreateOptions{})
+	if err != nil {
+		return activityCounts{}, err
+	}
+
+	counts := activityCounts{
+		actors:        make(map[string]int),
+		resourceKinds: make(map[string]int),
+		changeSources: make(map[string]int),
+	}
+	for _, facet := range result.Status.Facets {
+		var target map[string]int
+		switch facet.Field {
+		case "spec.changeSource":
+			target = counts.changeSources
+		case "spec.actor.name":
+			target = counts.actors
+		case "spec.resource.kind":
+			target = counts.resourceKinds
+		default:
+			continue
+		}
+		for _, v := range facet.Values {
+			target[v.Value] = int(v.Count)
+		}
+	}
+
+	// Every activity has exactly one change source, so its counts sum to the total.
+	for _, count := range counts.changeSources {
+		counts.total += count
+	}
+
+	return counts, nil
+}
+
+// =============================================================================
+// Get Suspicious Activity
+// =============================================================================
+
+// GetSuspiciousActivityArgs contains the arguments for the get_suspicious_activity tool.
+type GetSuspiciousActivityArgs struct {
+	// Window is the length of the period to inspect, ending now (e.g. "24h", "7d").
+	// The baseline is the equal-length window immediately before it.
+	Window string `json:"window,omitempty"`
+
+	// VolumeMultiplier is how many times the baseline count must be exceeded
+	// for a volume, deletion, or forbidden finding.
+	VolumeMultiplier float64 `json:"volumeMultiplier,omitempty"`
+
+	// MinEvents is the smallest count in the inspected window that can be
+	// flagged, so low-volume noise is ignored.
+	MinEvents int `json:"minEvents,omitempty"`
+
+	// IncludeSystemActors includes controllers and service accounts in
+	// per-actor findings.
+	IncludeSystemActors bool `json:"includeSystemActors,omitempty"`
+}
+
+const (
+	// suspiciousSampleLimit is the maximum number of records fetched per
+	// window and data source.
+	suspiciousSampleLimit = 1000
+
+	defaultSuspiciousWindow     = 24 * time.Hour
+	defaultSuspiciousMultiplier = 3.0
+	defaultSuspiciousMinEvents  = 10
+
+	severityHigh   = "high"
+	severityMedium = "medium"
+	severityLow    = "low"
+)
+
+// suspiciousFinding is a single anomaly reported by get_suspicious_activity.
+type suspiciousFinding struct {
+	Type        string         `json:"type"`
+	Severity    string         `json:"severity"`
+	Actor       string         `json:"actor,omitempty"`
+	Description string         `json:"description"`
+	Baseline    int            `json:"baseline"`
+	Comparison  int            `json:"comparison"`
+	Ratio       float64        `json:"ratio,omitempty"`
+	Details     map[string]any `json:"details,omitempty"`
+}
+
+// auditAnomalyCounts holds the audit log counts used for anomaly detection.
+type auditAnomalyCounts struct {
+	deletions     int
+	deletesByUser map[string]int
+	forbidden     map[string]int
+}
+
+func (p *ToolProvider) handleGetSuspiciousActivity(ctx context.Context, req *mcp.CallToolRequest, args GetSuspiciousActivityArgs) (*mcp.CallToolResult, any, error) {
+	window := defaultSuspiciousWindow
+	if args.Window != "" {
+		parsed, err := parseSuspiciousWindow(args.Window)
+		if err != nil {
+			return errorResult(err.Error()), nil, nil
+		}
+		window = parsed
+	}
+
+	multiplier := args.VolumeMultiplier
+	if multiplier == 0 {
+		multiplier = defaultSuspiciousMultiplier
+	}
+	if multiplier <= 1 {
+		return errorResult("volumeMultiplier must be greater than 1"), nil, nil
+	}
+
+	minEvents := args.MinEvents
+	if minEvents == 0 {
+		minEvents = defaultSuspiciousMinEvents
 	}
 
-	result, err := p.client.ActivityQueries().Create(ctx, query, metav1.CreateOptions{})
+	now := time.Now().UTC()
+	comparisonEnd := now.Format(time.RFC3339)
+	comparisonStart := now.Add(-window).Format(time.RFC3339)
+	baselineStart := now.Add(-2 * window).Format(time.RFC3339)
+
+	// Activity volume per actor, as in compare_activity_periods
+	baselineActivities, err := p.client.ActivityQueries().Create(ctx, &v1alpha1.ActivityQuery{
+		ObjectMeta: metav1.ObjectMeta{GenerateName: "mcp-suspicious-baseline-"},
+		Spec:       v1alpha1.ActivityQuerySpec{StartTime: baselineStart, EndTime: comparisonStart, Limit: suspiciousSampleLimit},
+	}, metav1.CreateOptions{})
 	if err != nil {
-		return errorResult(fmt.Sprintf("Query failed: %v", err)), nil, nil
+		return errorResult(fmt.Sprintf("Baseline activity query failed: %v", err)), nil, nil
 	}
 
-	// Determine bucket size
-	bucketFormat := "2006-01-02T15:00:00Z" // hourly default
-	bucketSize := args.BucketSize
-	if bucketSize == "" {
-		bucketSize = "hour"
+	comparisonActivities, err := p.client.ActivityQueries().Create(ctx, &v1alpha1.ActivityQuery{
+		ObjectMeta: metav1.ObjectMeta{GenerateName: "mcp-suspicious-comparison-"},
+		Spec:       v1alpha1.ActivityQuerySpec{StartTime: comparisonStart, EndTime: comparisonEnd, Limit: suspiciousSampleLimit},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return errorResult(fmt.Sprintf("Comparison activity query failed: %v", err)), nil, nil
 	}
 
-	if bucketSize == "day" {
-		bucketFormat = "2006-01-02T00:00:00Z"
+	// Deletions and forbidden requests come from audit logs, since activities
+	// only cover successful changes that match a policy
+	auditFilter := fmt.Sprintf("verb in ['delete', 'deletecollection'] || (%s)",
+		buildFailedOperationsFilter(403, 403, "", "", "", ""))
+
+	baselineAudit, err := p.client.AuditLogQueries().Create(ctx, &v1alpha1.AuditLogQuery{
+		ObjectMeta: metav1.ObjectMeta{GenerateName: "mcp-suspicious-audit-baseline-"},
+		Spec:       v1alpha1.AuditLogQuerySpec{StartTime: baselineStart, EndTime: comparisonStart, Filter: auditFilter, Limit: suspiciousSampleLimit},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return errorResult(fmt.Sprintf("Baseline audit log query failed: %v", err)), nil, nil
 	}
 
-	// Count by bucket
-	bucketCounts := make(map[string]int)
-	var peakBucket string
-	var peakCount int
+	comparisonAudit, err := p.client.AuditLogQueries().Create(ctx, &v1alpha1.AuditLogQuery{
+		ObjectMeta: metav1.ObjectMeta{GenerateName: "mcp-suspicious-audit-comparison-"},
+		Spec:       v1alpha1.AuditLogQuerySpec{StartTime: comparisonStart, EndTime: comparisonEnd, Filter: auditFilter, Limit: suspiciousSampleLimit},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return errorResult(fmt.Sprintf("Comparison audit log query failed: %v", err)), nil, nil
+	}
 
-	for _, activity := range result.Status.Results {
-		bucket := activity.CreationTimestamp.Format(bucketFormat)
-		bucketCounts[bucket]++
+	baselineCounts := buildActivityCounts(baselineActivities.Status.Results)
+	comparisonCounts := buildActivityCounts(comparisonActivities.Status.Results)
+	baselineAuditCounts := buildAuditAnomalyCounts(baselineAudit.Status.Results)
+	comparisonAuditCounts := buildAuditAnomalyCounts(comparisonAudit.Status.Results)
 
-		if bucketCounts[bucket] > peakCount {
-			peakCount = bucketCounts[bucket]
-			peakBucket = bucket
-		}
+	includeActor := func(name string) bool {
+		return args.IncludeSystemActors || !isSystemUser(name)
 	}
 
-	// Convert to sorted list
-	buckets := make([]map[string]any, 0, len(bucketCounts))
-	for bucket, count := range bucketCounts {
-		entry := map[string]any{
-			"timestamp": bucket,
-			"count":     count,
+	findings := make([]suspiciousFinding, 0)
+
+	// Actors whose volume grew by the multiplier over their own baseline
+	for actor, count := range comparisonCounts.actors {
+		baseline := baselineCounts.actors[actor]
+		if baseline == 0 || count < minEvents || !includeActor(actor) {
+			continue
 		}
-		if bucket == peakBucket {
-			entry["note"] = "peak"
+		ratio := float64(count) / float64(baseline)
+		if ratio < multiplier {
+			continue
 		}
-		buckets = append(buckets, entry)
+		severity := severityMedium
+		if ratio >= 2*multiplier {
+			severity = severityHigh
+		}
+		findings = append(findings, suspiciousFinding{
+			Type:        "volumeSpike",
+			Severity:    severity,
+			Actor:       actor,
+			Description: fmt.Sprintf("%s made %d changes, %.1fx the %d in the previous window.", actor, count, ratio, baseline),
+			Baseline:    baseline,
+			Comparison:  count,
+			Ratio:       ratio,
+		})
 	}
 
-	// Calculate average
-	var avg float64
-	if len(buckets) > 0 {
-		avg = float64(len(result.Status.Results)) / float64(len(buckets))
+	// Actors with no activity in the baseline window
+	for _, entry := range findNew(baselineCounts.actors, comparisonCounts.actors) {
+		actor := entry["name"].(string)
+		count := entry["count"].(int)
+		if !includeActor(actor) {
+			continue
+		}
+		severity := severityLow
+		if count >= minEvents {
+			severity = severityMedium
+		}
+		findings = append(findings, suspiciousFinding{
+			Type:        "firstTimeActor",
+			Severity:    severity,
+			Actor:       actor,
+			Description: fmt.Sprintf("%s made %d changes and had no activity in the previous window.", actor, count),
+			Comparison:  count,
+		})
 	}
 
-	output := map[string]any{
-		"timeRange": map[string]any{
-			"start": result.Status.EffectiveStartTime,
-			"end":   result.Status.EffectiveEndTime,
-		},
-		"bucketSize":       bucketSize,
-		"totalCount":       len(result.Status.Results),
-		"buckets":          buckets,
-		"peakBucket":       map[string]any{"timestamp": peakBucket, "count": peakCount},
-		"averagePerBucket": avg,
+	// Deletions across all actors
+	if deletions := comparisonAuditCounts.deletions; deletions >= minEvents {
+		baseline := baselineAuditCounts.deletions
+		ratio := 0.0
+		if baseline > 0 {
+			ratio = float64(deletions) / float64(baseline)
+		}
+		if baseline == 0 || ratio >= multiplier {
+			severity := severityMedium
+			if baseline == 0 || ratio >= 2*multiplier {
+				severity = severityHigh
+			}
+			findings = append(findings, suspiciousFinding{
+				Type:        "deletionSpike",
+				Severity:    severity,
+				Description: fmt.Sprintf("%d delete requests, compared to %d in the previous window.", deletions, baseline),
+				Baseline:    baseline,
+				Comparison:  deletions,
+				Ratio:       ratio,
+				Details: map[string]any{
+					"topActors": activitysummary.TopN(comparisonAuditCounts.deletesByUser, 5),
+				},
+			})
+		}
 	}
 
-	return jsonResult(output)
-}
-
-// =============================================================================
-// Summarize Recent Activity
-// =============================================================================
-
-// SummarizeRecentActivityArgs contains the arguments for the summarize_recent_activity tool.
-type SummarizeRecentActivityArgs struct {
-	// StartTime is the beginning of the summary window.
-	StartTime string `json:"startTime"`
-
-	// EndTime is the end of the summary window.
-	EndTime string `json:"endTime,omitempty"`
-
-	// ChangeSource filters by change source (human, system).
-	ChangeSource string `json:"changeSource,omitempty"`
+	// Bursts of forbidden requests per actor
+	for actor, count := range comparisonAuditCounts.forbidden {
+		if count < minEvents || !includeActor(actor) {
+			continue
+		}
+		baseline := baselineAuditCounts.forbidden[actor]
+		ratio := 0.0
+		if baseline > 0 {
+			ratio = float64(count) / float64(baseline)
+			if ratio < multiplier {
+				continue
+			}
+		}
+		severity := severityMedium
+		if count >= 5*minEvents {
+			severity = severityHigh
+		}
+		findings = append(findings, suspiciousFinding{
+			Type:        "forbiddenBurst",
+			Severity:    severity,
+			Actor:       actor,
+			Description: fmt.Sprintf("%s was denied (403) %d times, compared to %d in the previous window.", actor, count, baseline),
+			Baseline:    baseline,
+			Comparison:  count,
+			Ratio:       ratio,
+		})
+	}
 
-	// TopN is the number of top items per category.
-	TopN int `json:"topN,omitempty"`
-}
+	rankSuspiciousFindings(findings)
 
-func (p *ToolProvider) handleSummarizeRecentActivity(ctx context.Context, req *mcp.CallToolRequest, args SummarizeRecentActivityArgs) (*mcp.CallToolResult, any, error) {
-	endTime := args.EndTime
-	if endTime == "" {
-		endTime = "now"
+	bySeverity := map[string]int{severityHigh: 0, severityMedium: 0, severityLow: 0}
+	for _, f := range findings {
+		bySeverity[f.Severity]++
 	}
 
-	topN := args.TopN
-	if topN == 0 {
-		topN = 5
+	// Any full page means the window holds more records than were counted
+	sampled := false
+	for _, n := range []int{
+		len(baselineActivities.Status.Results),
+		len(comparisonActivities.Status.Results),
+		len(baselineAudit.Status.Results),
+		len(comparisonAudit.Status.Results),
+	} {
+		if n >= suspiciousSampleLimit {
+			sampled = true
+		}
 	}
 
-	query := &v1alpha1.ActivityQuery{
-		ObjectMeta: metav1.ObjectMeta{
-			GenerateName: "mcp-summary-",
+	output := map[string]any{
+		"baseline": map[string]any{
+			"start": baselineStart,
+			"end":   comparisonStart,
 		},
-		Spec: v1alpha1.ActivityQuerySpec{
-			StartTime: args.StartTime,
-			EndTime:   endTime,
-			Limit:     1000,
+		"comparison": map[string]any{
+			"start": comparisonStart,
+			"end":   comparisonEnd,
+		},
+		"thresholds": map[string]any{
+			"volumeMultiplier": multiplier,
+			"minEvents":        minEvents,
 		},
+		"findingCount": len(findings),
+		"bySeverity":   bySeverity,
+		"findings":     findings,
+		"sampled":      sampled,
 	}
 
-	result, err := p.client.ActivityQueries().Create(ctx, query, metav1.CreateOptions{})
-	if err != nil {
-		return errorResult(fmt.Sprintf("Query failed: %v", err)), nil, nil
+	if len(findings) == 0 {
+		output["analysis"] = "No anomalies found. Activity, deletions, and forbidden requests are in line with the previous window."
+	} else {
+		output["analysis"] = fmt.Sprintf("%d findings: %d high, %d medium, %d low severity.",
+			len(findings), bySeverity[severityHigh], bySeverity[severityMedium], bySeverity[severityLow])
 	}
 
-	// Build summary statistics
-	actorCounts := make(map[string]int)
-	resourceKindCounts := make(map[string]int)
-	var humanChanges, systemChanges int
-	var recentSummaries []string
+	if sampled {
+		output["note"] = fmt.Sprintf("At least one window held more than %d records, so only the most recent were counted. "+
+			"Counts are lower bounds; use a shorter window for exact results.", suspiciousSampleLimit)
+	}
 
-	for i, activity := range result.Status.Results {
-		actorCounts[activity.Spec.Actor.Name]++
-		resourceKindCounts[activity.Spec.Resource.Kind]++
+	return p.jsonResult(output)
+}
 
-		// Classify as human or system
-		if activity.Spec.ChangeSource == "human" {
-			humanChanges++
-		} else {
-			systemChanges++
+// parseSuspiciousWindow parses a Go duration ("12h") or a whole number of days ("7d").
+func parseSuspiciousWindow(s string) (time.Duration, error) {
+	var d time.Duration
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid window %q: use a duration like \"24h\" or \"7d\"", s)
 		}
-
-		// Collect recent summaries
-		if i < topN {
-			recentSummaries = append(recentSummaries, activity.Spec.Summary)
+		d = time.Duration(n) * 24 * time.Hour
+	} else {
+		parsed, err := time.ParseDuration(s)
+		if err != nil {
+			return 0, fmt.Errorf("invalid window %q: use a duration like \"24h\" or \"7d\"", s)
 		}
+		d = parsed
 	}
-
-	// Get top actors and resources
-	topActors := getTopN(actorCounts, topN)
-	topResources := getTopN(resourceKindCounts, topN)
-
-	// Build highlights
-	highlights := []string{
-		fmt.Sprintf("%d total activities (%d human, %d system)", len(result.Status.Results), humanChanges, systemChanges),
+	if d <= 0 {
+		return 0, fmt.Errorf("window must be positive")
 	}
+	return d, nil
+}
 
-	if len(topActors) > 0 {
-		highlights = append(highlights, fmt.Sprintf("Most active: %s (%d activities)", topActors[0]["name"], topActors[0]["count"]))
+// buildAuditAnomalyCounts counts deletions and forbidden requests in audit events.
+func buildAuditAnomalyCounts(events []auditv1.Event) auditAnomalyCounts {
+	counts := auditAnomalyCounts{
+		deletesByUser: make(map[string]int),
+		forbidden:     make(map[string]int),
 	}
 
-	if len(topResources) > 0 {
-		highlights = append(highlights, fmt.Sprintf("Most changed resource type: %s (%d activities)", topResources[0]["name"], topResources[0]["count"]))
+	for _, event := range events {
+		if event.ResponseStatus != nil && event.ResponseStatus.Code == 403 {
+			counts.forbidden[event.User.Username]++
+			continue
+		}
+		if event.Verb == "delete" || event.Verb == "deletecollection" {
+			counts.deletions++
+			counts.deletesByUser[event.User.Username]++
+		}
 	}
 
-	output := map[string]any{
-		"timeRange": map[string]any{
-			"start": result.Status.EffectiveStartTime,
-			"end":   result.Status.EffectiveEndTime,
-		},
-		"totalActivities": len(result.Status.Results),
-		"humanChanges":    humanChanges,
-		"systemChanges":   systemChanges,
-		"highlights":      highlights,
-		"topActors":       topActors,
-		"topResources":    topResources,
-		"recentSummaries": recentSummaries,
-	}
+	return counts
+}
 
-	return jsonResult(output)
+// rankSuspiciousFindings orders findings by severity, then by count in the
+// inspected window, so the most pressing items come first.
+func rankSuspiciousFindings(findings []suspiciousFinding) {
+	rank := map[string]int{severityHigh: 0, severityMedium: 1, severityLow: 2}
+	sort.SliceStable(findings, func(i, j int) bool {
+		if rank[findings[i].Severity] != rank[findings[j].Severity] {
+			return rank[findings[i].Severity] < rank[findings[j].Severity]
+		}
+		if findings[i].Comparison != findings[j].Comparison {
+			return findings[i].Comparison > findings[j].Comparison
+		}
+		if findings[i].Type != findings[j].Type {
+			return findings[i].Type < findings[j].Type
+		}
+		return findings[i].Actor < findings[j].Actor
+	})
 }
 
 // =============================================================================
-// Compare Activity Periods
+// Get Change Velocity
 // =============================================================================
 
-// CompareActivityPeriodsArgs contains the arguments for the compare_activity_periods tool.
-type CompareActivityPeriodsArgs struct {
-	// BaselineStart is the start of the baseline period.
-	BaselineStart string `json:"baselineStart"`
+// GetChangeVelocityArgs contains the arguments for the get_change_velocity tool.
+type GetChangeVelocityArgs struct {
+	// Window is the length of the current window, ending now (e.g. "1h", "1d").
+	Window string `json:"window,omitempty"`
 
-	// BaselineEnd is the end of the baseline period.
-	BaselineEnd string `json:"baselineEnd"`
+	// BaselineWindows is how many equal-length windows before the current one
+	// make up the baseline.
+	BaselineWindows int `json:"baselineWindows,omitempty"`
 
-	// ComparisonStart is the start of the comparison period.
-	ComparisonStart string `json:"comparisonStart"`
+	// Filter is a CEL filter expression to narrow the counted requests.
+	Filter string `json:"filter,omitempty"`
 
-	// ComparisonEnd is the end of the comparison period.
-	ComparisonEnd string `json:"comparisonEnd"`
+	// MinEvents is the smallest current count that can be called a spike or
+	// elevated, so a jump from 1 to 4 changes isn't reported as unusual.
+	MinEvents int `json:"minEvents,omitempty"`
 }
 
-func (p *ToolProvider) handleCompareActivityPeriods(ctx context.Context, req *mcp.CallToolRequest, args CompareActivityPeriodsArgs) (*mcp.CallToolResult, any, error) {
-	// Query baseline period
-	baselineQuery := &v1alpha1.ActivityQuery{
-		ObjectMeta: metav1.ObjectMeta{
-			GenerateName: "mcp-compare-baseline-",
-		},
-		Spec: v1alpha1.ActivityQuerySpec{
-			StartTime: args.BaselineStart,
-			EndTime:   args.BaselineEnd,
-			Limit:     1000,
-		},
-	}
+const (
+	defaultVelocityWindow          = time.Hour
+	defaultVelocityBaselineWindows = 6
+	maxVelocityBaselineWindows     = 24
+	defaultVelocityMinEvents       = 10
 
-	baselineResult, err := p.client.ActivityQueries().Create(ctx, baselineQuery, metav1.CreateOptions{})
-	if err != nil {
-		return errorResult(fmt.Sprintf("Baseline query failed: %v", err)), nil, nil
+	velocitySpike    = "spike"
+	velocityElevated = "elevated"
+	velocityNormal   = "normal"
+	velocityQuiet    = "quiet"
+)
+
+// velocityWindow is one window counted by get_change_velocity.
+type velocityWindow struct {
+	Start  string         `json:"start"`
+	End    string         `json:"end"`
+	Count  int64          `json:"count"`
+	ByVerb map[string]int `json:"byVerb,omitempty"`
+}
+
+func (p *ToolProvider) handleGetChangeVelocity(ctx context.Context, req *mcp.CallToolRequest, args GetChangeVelocityArgs) (*mcp.CallToolResult, any, error) {
+	window := defaultVelocityWindow
+	if args.Window != "" {
+		parsed, err := parseSuspiciousWindow(args.Window)
+		if err != nil {
+			return errorResult(err.Error()), nil, nil
+		}
+		window = parsed
 	}
 
-	// Query comparison period
-	comparisonQuery := &v1alpha1.ActivityQuery{
-		ObjectMeta: metav1.ObjectMeta{
-			GenerateName: "mcp-compare-comparison-",
-		},
-		Spec: v1alpha1.ActivityQuerySpec{
-			StartTime: args.ComparisonStart,
-			EndTime:   args.ComparisonEnd,
-			Limit:     1000,
-		},
+	k := args.BaselineWindows
+	if k == 0 {
+		k = defaultVelocityBaselineWindows
+	}
+	if k < 2 || k > maxVelocityBaselineWindows {
+		return errorResult(fmt.Sprintf("baselineWindows must be between 2 and %d", maxVelocityBaselineWindows)), nil, nil
 	}
 
-	comparisonResult, err := p.client.ActivityQueries().Create(ctx, comparisonQuery, metav1.CreateOptions{})
-	if err != nil {
-		return errorResult(fmt.Sprintf("Comparison query failed: %v", err)), nil, nil
+	minEvents := args.MinEvents
+	if minEvents == 0 {
+		minEvents = defaultVelocityMinEvents
 	}
 
-	// Build counts for both periods
-	baselineCounts := buildActivityCounts(baselineResult.Status.Results)
-	comparisonCounts := buildActivityCounts(comparisonResult.Status.Results)
+	// Window 0 is the current one; the rest run back in time from it
+	now := time.Now().UTC().Truncate(time.Second)
+	windows := make([]velocityWindow, k+1)
+	errs := make([]error, k+1)
+	sem := make(chan struct{}, batchConcurrency)
+	var wg sync.WaitGroup
+	for i := range windows {
+		end := now.Add(-time.Duration(i) * window)
+		windows[i].Start = end.Add(-window).Format(time.RFC3339)
+		windows[i].End = end.Format(time.RFC3339)
 
-	// Find differences
-	newInComparison := findNew(baselineCounts.actors, comparisonCounts.actors)
-	increasedActivity := findIncreased(baselineCounts.resourceKinds, comparisonCounts.resourceKinds)
-	decreasedActivity := findDecreased(baselineCounts.resourceKinds, comparisonCounts.resourceKinds)
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			errs[i] = p.countVelocityWindow(ctx, &windows[i], args.Filter)
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return errorResult(fmt.Sprintf("Query failed: %v", err)), nil, nil
+		}
+	}
 
-	// Calculate change percentage
-	var changePercent float64
-	if baselineCounts.total > 0 {
-		changePercent = float64(comparisonCounts.total-baselineCounts.total) / float64(baselineCounts.total) * 100
+	current := windows[0]
+	baseline := windows[1:]
+	slices.Reverse(baseline)
+
+	counts := make([]int64, len(baseline))
+	for i, w := range baseline {
+		counts[i] = w.Count
 	}
+	mean, stddev := meanStddev(counts)
+	level, ratio, deviation, verdict := assessVelocity(current.Count, mean, stddev, minEvents)
 
 	output := map[string]any{
+		"current": current,
 		"baseline": map[string]any{
-			"start": baselineResult.Status.EffectiveStartTime,
-			"end":   baselineResult.Status.EffectiveEndTime,
-			"count": baselineCounts.total,
+			"windows": baseline,
+			"mean":    mean,
+			"stddev":  stddev,
 		},
-		"comparison": map[string]any{
-			"start": comparisonResult.Status.EffectiveStartTime,
-			"end":   comparisonResult.Status.EffectiveEndTime,
-			"count": comparisonCounts.total,
+		"level":   level,
+		"verdict": verdict,
+		"method": fmt.Sprintf("ratio is the current count over the baseline mean; deviation is how many standard deviations the current count is from the mean. "+
+			"spike: ratio >= 2 and deviation >= 3. elevated: deviation >= 2. quiet: deviation <= -2. Counts below %d are never spike or elevated.", minEvents),
+	}
+	if ratio != nil {
+		output["ratio"] = *ratio
+	}
+	if deviation != nil {
+		output["deviation"] = *deviation
+	}
+
+	return p.jsonResult(output)
+}
+
+// countVelocityWindow fills in a window's count of changes matching filter
+// with a verb facet query, which is exact since there are only a few verbs.
+func (p *ToolProvider) countVelocityWindow(ctx context.Context, w *velocityWindow, filter string) error {
+	result, err := p.client.AuditLogFacetsQueries().Create(ctx, &v1alpha1.AuditLogFacetsQuery{
+		ObjectMeta: metav1.ObjectMeta{GenerateName: "mcp-change-velocity-"},
+		Spec: v1alpha1.AuditLogFacetsQuerySpec{
+			TimeRange:     v1alpha1.FacetTimeRange{Start: w.Start, End: w.End},
+			Filter:        filter,
+			MutationsOnly: true,
+			Facets:        []v1alpha1.FacetSpec{{Field: "verb", Limit: int32(len(celutil.MutatingVerbs))}},
 		},
-		"changePercent":     changePercent,
-		"newInComparison":   newInComparison,
-		"increasedActivity": increasedActivity,
-		"decreasedActivity": decreasedActivity,
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return err
 	}
 
-	// Add analysis summary
-	direction := "more"
-	if changePercent < 0 {
-		direction = "less"
+	w.ByVerb = make(map[string]int)
+	for _, facet := range result.Status.Facets {
+		for _, v := range facet.Values {
+			w.ByVerb[v.Value] = int(v.Count)
+			w.Count += v.Count
+		}
 	}
-	analysis := fmt.Sprintf("Comparison period shows %.0f%% %s activity.", absFloat(changePercent), direction)
+	return nil
+}
 
-	if len(newInComparison) > 0 {
-		analysis += fmt.Sprintf(" %d new actors appeared.", len(newInComparison))
+// meanStddev returns the mean and population standard deviation of counts.
+func meanStddev(counts []int64) (float64, float64) {
+	if len(counts) == 0 {
+		return 0, 0
+	}
+	var sum float64
+	for _, c := range counts {
+		sum += float64(c)
 	}
+	mean := sum / float64(len(counts))
 
-	output["analysis"] = analysis
+	var squares float64
+	for _, c := range counts {
+		squares += (float64(c) - mean) * (float64(c) - mean)
+	}
+	return mean, math.Sqrt(squares / float64(len(counts)))
+}
 
-	return jsonResult(output)
+// assessVelocity compares a current count to the baseline mean and standard
+// deviation. ratio is nil when the baseline is empty, and deviation is nil
+// when the baseline is flat, as neither is defined then. A flat baseline
+// falls back to the ratio alone: 2x or more is a spike, half or less is quiet.
+func assessVelocity(current int64, mean, stddev float64, minEvents int) (level string, ratio, deviation *float64, verdict string) {
+	if mean == 0 {
+		if current == 0 {
+			return velocityNormal, nil, nil, "No changes in the current window or the baseline."
+		}
+		level = velocityNormal
+		if current >= int64(minEvents) {
+			level = velocitySpike
+		}
+		return level, nil, nil, fmt.Sprintf("%d changes, with none in the baseline windows. This activity is new rather than a rise in existing activity.", current)
+	}
+
+	r := float64(current) / mean
+	ratio = &r
+	if stddev > 0 {
+		d := (float64(current) - mean) / stddev
+		deviation = &d
+	}
+
+	aboveMin := current >= int64(minEvents)
+	switch {
+	case deviation != nil && *deviation >= 3 && r >= 2 && aboveMin,
+		deviation == nil && r >= 2 && aboveMin:
+		level = velocitySpike
+	case deviation != nil && *deviation >= 2 && aboveMin:
+		level = velocityElevated
+	case deviation != nil && *deviation <= -2,
+		deviation == nil && r <= 0.5:
+		level = velocityQuiet
+	default:
+		level = velocityNormal
+	}
+
+	summary := fmt.Sprintf("%.1fx normal: %d changes against a baseline average of %.1f", r, current, mean)
+	if deviation != nil {
+		summary += fmt.Sprintf(" (%+.1f standard deviations)", *deviation)
+	}
+	switch level {
+	case velocitySpike:
+		verdict = summary + ". This is a real spike."
+	case velocityElevated:
+		verdict = summary + ". Change velocity is elevated but not a clear spike."
+	case velocityQuiet:
+		verdict = summary + ". Change velocity is unusually low."
+	default:
+		verdict = summary + ". Change velocity is within the normal range."
+		if r >= 2 && !aboveMin {
+			verdict = summary + fmt.Sprintf(". Too few changes (under %d) to call a spike.", minEvents)
+		}
+	}
+	return level, ratio, deviation, verdict
 }
 
 // =============================================================================
@@ -1192,6 +3266,18 @@ func (p *ToolProvider) handleListActivityPolicies(ctx context.Context, req *mcp.
 				break
 			}
 		}
+
+		// A Ready policy is still inactive when the processor can't resolve its
+		// resource, e.g. while waiting for the CRD to be installed
+		if status == "Ready" {
+			for _, cond := range policy.Status.Conditions {
+				if cond.Type == v1alpha1.ActivityPolicyConditionResourceResolved && cond.Status == "False" {
+					status = cond.Reason
+					policyMap["statusMessage"] = cond.Message
+					break
+				}
+			}
+		}
 		policyMap["status"] = status
 
 		if args.IncludeRules {
@@ -1207,7 +3293,7 @@ func (p *ToolProvider) handleListActivityPolicies(ctx context.Context, req *mcp.
 		"summary":  fmt.Sprintf("%d policies covering %d resource types", len(policies), len(policies)),
 	}
 
-	return jsonResult(output)
+	return p.jsonResult(output)
 }
 
 // =============================================================================
@@ -1302,7 +3388,7 @@ func (p *ToolProvider) handlePreviewActivityPolicy(ctx context.Context, req *mcp
 		"activities": activities,
 	}
 
-	return jsonResult(output)
+	return p.jsonResult(output)
 }
 
 // =============================================================================
@@ -1329,14 +3415,49 @@ type QueryEventsArgs struct {
 	// Reason filters by event reason.
 	Reason string `json:"reason,omitempty"`
 
+	// Reasons matches events with any of these reasons, e.g. FailedScheduling,
+	// FailedMount and BackOff. Reason, when also set, is added to the list.
+	Reasons []string `json:"reasons,omitempty"`
+
 	// Type filters by event type (Normal or Warning).
 	Type string `json:"type,omitempty"`
 
+	// Types matches events of any of these types. Type, when also set, is
+	// added to the list.
+	Types []string `json:"types,omitempty"`
+
 	// SourceComponent filters by source component.
 	SourceComponent string `json:"sourceComponent,omitempty"`
 
+	// SourceComponents matches events from any of these source components.
+	// SourceComponent, when also set, is added to the list.
+	SourceComponents []string `json:"sourceComponents,omitempty"`
+
 	// Limit is the maximum number of results to return.
 	Limit int `json:"limit,omitempty"`
+
+	// ContinueToken fetches the next page. Copy it from the continue field of
+	// the previous response and keep every other argument unchanged except limit.
+	ContinueToken string `json:"continueToken,omitempty"`
+}
+
+// queryEventsFields are the event fields query_events reports, requested from
+// the server so the rest of each event isn't sent.
+var queryEventsFields = []string{"reason", "note", "type", "regarding", "source", "series", "eventTime"}
+
+// eventFilterValues merges the single and list forms of a query_events filter,
+// dropping empty and repeated values.
+func eventFilterValues(single string, values []string) []string {
+	var merged []string
+	seen := make(map[string]bool, len(values)+1)
+	for _, value := range append([]string{single}, values...) {
+		if value == "" || seen[value] {
+			continue
+		}
+		seen[value] = true
+		merged = append(merged, value)
+	}
+	return merged
 }
 
 func (p *ToolProvider) handleQueryEvents(ctx context.Context, req *mcp.CallToolRequest, args QueryEventsArgs) (*mcp.CallToolResult, any, error) {
@@ -1353,14 +3474,22 @@ func (p *ToolProvider) handleQueryEvents(ctx context.Context, req *mcp.CallToolR
 	if args.RegardingName != "" {
 		fieldSelectors = append(fieldSelectors, fmt.Sprintf("regarding.name=%s", args.RegardingName))
 	}
-	if args.Reason != "" {
-		fieldSelectors = append(fieldSelectors, fmt.Sprintf("reason=%s", args.Reason))
+	// A single value stays a field selector; several are sent as a list the
+	// server matches with OR
+	reasons := eventFilterValues(args.Reason, args.Reasons)
+	if len(reasons) == 1 {
+		fieldSelectors = append(fieldSelectors, fmt.Sprintf("reason=%s", reasons[0]))
+		reasons = nil
 	}
-	if args.Type != "" {
-		fieldSelectors = append(fieldSelectors, fmt.Sprintf("type=%s", args.Type))
+	types := eventFilterValues(args.Type, args.Types)
+	if len(types) == 1 {
+		fieldSelectors = append(fieldSelectors, fmt.Sprintf("type=%s", types[0]))
+		types = nil
 	}
-	if args.SourceComponent != "" {
-		fieldSelectors = append(fieldSelectors, fmt.Sprintf("source.component=%s", args.SourceComponent))
+	components := eventFilterValues(args.SourceComponent, args.SourceComponents)
+	if len(components) == 1 {
+		fieldSelectors = append(fieldSelectors, fmt.Sprintf("source.component=%s", components[0]))
+		components = nil
 	}
 
 	fieldSelector := ""
@@ -1373,11 +3502,17 @@ func (p *ToolProvider) handleQueryEvents(ctx context.Context, req *mcp.CallToolR
 			GenerateName: "mcp-event-query-",
 		},
 		Spec: v1alpha1.EventQuerySpec{
-			StartTime:     args.StartTime,
-			EndTime:       args.EndTime,
-			Namespace:     args.Namespace,
-			FieldSelector: fieldSelector,
-			Limit:         limit,
+			StartTime:        args.StartTime,
+			EndTime:          args.EndTime,
+			Namespace:        args.Namespace,
+			FieldSelector:    fieldSelector,
+			Reasons:          reasons,
+			Types:            types,
+			SourceComponents: components,
+			Limit:            limit,
+			Continue:         args.ContinueToken,
+			// Only fetch the fields formatted below
+			Fields: queryEventsFields,
 		},
 	}
 
@@ -1439,7 +3574,7 @@ func (p *ToolProvider) handleQueryEvents(ctx context.Context, req *mcp.CallToolR
 		"events":             events,
 	}
 
-	return jsonResult(output)
+	return p.jsonResult(output)
 }
 
 // =============================================================================
@@ -1515,7 +3650,7 @@ func (p *ToolProvider) handleGetEventFacets(ctx context.Context, req *mcp.CallTo
 		output[facet.Field] = values
 	}
 
-	return jsonResult(output)
+	return p.jsonResult(output)
 }
 
 // =============================================================================
@@ -1539,49 +3674,104 @@ func errorResult(message string) *mcp.CallToolResult {
 	}
 }
 
-func jsonResult(output any) (*mcp.CallToolResult, any, error) {
-	jsonBytes, err := json.MarshalIndent(output, "", "  ")
+// jsonResult returns output as the JSON text of a tool result, indented unless
+// the provider is set to compact output.
+func (p *ToolProvider) jsonResult(output any) (*mcp.CallToolResult, any, error) {
+	jsonBytes, err := encodeOutput(output, p.compact)
 	if err != nil {
 		return errorResult(fmt.Sprintf("Failed to format results: %v", err)), nil, nil
 	}
 	return textResult(string(jsonBytes)), nil, nil
 }
 
+// isSystemUser reports whether username belongs to a controller, service
+// account or other system actor, using the same rules the processor applies
+// to changeSource.
 func isSystemUser(username string) bool {
-	return strings.HasPrefix(username, "system:") ||
-		strings.Contains(username, "serviceaccount") ||
-		strings.Contains(username, "controller")
+	return changesource.Default().IsSystem(username)
 }
 
-func getTopN(counts map[string]int, n int) []map[string]any {
-	// Convert to slice and sort
-	type kv struct {
-		Key   string
-		Value int
-	}
-	var sorted []kv
-	for k, v := range counts {
-		sorted = append(sorted, kv{k, v})
-	}
+// maxUserSummaryFailures caps the number of failed attempts listed in the
+// user activity summary.
+const maxUserSummaryFailures = 20
 
-	// Sort by count descending
-	for i := 0; i < len(sorted)-1; i++ {
-		for j := i + 1; j < len(sorted); j++ {
-			if sorted[j].Value > sorted[i].Value {
-				sorted[i], sorted[j] = sorted[j], sorted[i]
+// verbStats tracks how many operations for a single verb succeeded or failed.
+type verbStats struct {
+	Total     int            `json:"total"`
+	Succeeded int            `json:"succeeded"`
+	Failed    int            `json:"failed"`
+	ByStatus  map[string]int `json:"failedByStatus,omitempty"`
+}
+
+// buildVerbBreakdown groups audit events by verb, splitting each verb into
+// successful (status < 400) and failed operations. It also returns the
+// forbidden and unauthorized attempts, which matter most for security review.
+func buildVerbBreakdown(events []auditv1.Event) (map[string]*verbStats, []map[string]any) {
+	breakdown := make(map[string]*verbStats)
+	failures := make([]map[string]any, 0)
+
+	for _, event := range events {
+		stats, ok := breakdown[event.Verb]
+		if !ok {
+			stats = &verbStats{}
+			breakdown[event.Verb] = stats
+		}
+		stats.Total++
+
+		code := 0
+		if event.ResponseStatus != nil {
+			code = int(event.ResponseStatus.Code)
+		}
+		if code < 400 {
+			stats.Succeeded++
+			continue
+		}
+
+		stats.Failed++
+		if stats.ByStatus == nil {
+			stats.ByStatus = make(map[string]int)
+		}
+		stats.ByStatus[statusCodeLabel(code)]++
+
+		if (code == 401 || code == 403) && len(failures) < maxUserSummaryFailures {
+			failure := map[string]any{
+				"timestamp":  event.RequestReceivedTimestamp.Format("2006-01-02T15:04:05Z"),
+				"verb":       event.Verb,
+				"statusCode": code,
+				"reason":     statusCodeLabel(code),
+			}
+			if event.ObjectRef != nil {
+				failure["resource"] = event.ObjectRef.Resource
+				failure["name"] = event.ObjectRef.Name
+				failure["namespace"] = event.ObjectRef.Namespace
 			}
+			if event.ResponseStatus.Message != "" {
+				failure["message"] = event.ResponseStatus.Message
+			}
+			failures = append(failures, failure)
 		}
 	}
 
-	// Take top N
-	result := make([]map[string]any, 0, n)
-	for i := 0; i < len(sorted) && i < n; i++ {
-		result = append(result, map[string]any{
-			"name":  sorted[i].Key,
-			"count": sorted[i].Value,
-		})
-	}
-	return result
+	return breakdown, failures
+}
+
+// statusCodeLabel returns a short human-readable label for an HTTP status code.
+func statusCodeLabel(code int) string {
+	switch code {
+	case 401:
+		return "unauthorized"
+	case 403:
+		return "forbidden"
+	case 404:
+		return "notFound"
+	case 409:
+		return "conflict"
+	case 422:
+		return "invalid"
+	case 429:
+		return "tooManyRequests"
+	}
+	return fmt.Sprintf("%d", code)
 }
 
 type activityCounts struct {
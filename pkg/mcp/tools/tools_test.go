@@ -3,17 +3,22 @@ package tools
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	authnv1 "k8s.io/api/authentication/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/watch"
 	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
 	"k8s.io/client-go/rest"
 
+	"go.miloapis.com/activity/internal/cel"
 	"go.miloapis.com/activity/pkg/apis/activity/v1alpha1"
 	activityclient "go.miloapis.com/activity/pkg/client/clientset/versioned/typed/activity/v1alpha1"
 )
@@ -27,12 +32,16 @@ type mockActivityV1alpha1Client struct {
 	auditLogFacetsQueries *mockAuditLogFacetsQueryInterface
 	activityQueries       *mockActivityQueryInterface
 	activityFacetQueries  *mockActivityFacetQueryInterface
+	activityMetrics       *mockActivityMetricsQueryInterface
 	activityPolicies      *mockActivityPolicyInterface
 	policyPreviews        *mockPolicyPreviewInterface
 	activities            *mockActivityInterface
 	eventFacetQueries     *mockEventFacetQueryInterface
 	eventQueries          *mockEventQueryInterface
+	facetTrendQueries     *mockFacetTrendQueryInterface
 	reindexJobs           *mockReindexJobInterface
+	scopeStats            *mockScopeStatsInterface
+	selfScopes            *mockSelfScopeInterface
 }
 
 func newMockClient() *mockActivityV1alpha1Client {
@@ -41,12 +50,16 @@ func newMockClient() *mockActivityV1alpha1Client {
 		auditLogFacetsQueries: &mockAuditLogFacetsQueryInterface{},
 		activityQueries:       &mockActivityQueryInterface{},
 		activityFacetQueries:  &mockActivityFacetQueryInterface{},
+		activityMetrics:       &mockActivityMetricsQueryInterface{},
 		activityPolicies:      &mockActivityPolicyInterface{},
 		policyPreviews:        &mockPolicyPreviewInterface{},
 		activities:            &mockActivityInterface{},
 		eventFacetQueries:     &mockEventFacetQueryInterface{},
 		eventQueries:          &mockEventQueryInterface{},
+		facetTrendQueries:     &mockFacetTrendQueryInterface{},
 		reindexJobs:           &mockReindexJobInterface{},
+		scopeStats:            &mockScopeStatsInterface{},
+		selfScopes:            &mockSelfScopeInterface{},
 	}
 }
 
@@ -66,6 +79,10 @@ func (m *mockActivityV1alpha1Client) ActivityFacetQueries() activityclient.Activ
 	return m.activityFacetQueries
 }
 
+func (m *mockActivityV1alpha1Client) ActivityMetricsQueries() activityclient.ActivityMetricsQueryInterface {
+	return m.activityMetrics
+}
+
 func (m *mockActivityV1alpha1Client) ActivityPolicies() activityclient.ActivityPolicyInterface {
 	return m.activityPolicies
 }
@@ -75,6 +92,7 @@ func (m *mockActivityV1alpha1Client) PolicyPreviews() activityclient.PolicyPrevi
 }
 
 func (m *mockActivityV1alpha1Client) Activities(namespace string) activityclient.ActivityInterface {
+	m.activities.namespace = namespace
 	return m.activities
 }
 
@@ -86,10 +104,22 @@ func (m *mockActivityV1alpha1Client) EventQueries() activityclient.EventQueryInt
 	return m.eventQueries
 }
 
+func (m *mockActivityV1alpha1Client) FacetTrendQueries() activityclient.FacetTrendQueryInterface {
+	return m.facetTrendQueries
+}
+
 func (m *mockActivityV1alpha1Client) ReindexJobs() activityclient.ReindexJobInterface {
 	return m.reindexJobs
 }
 
+func (m *mockActivityV1alpha1Client) ScopeStats() activityclient.ScopeStatsInterface {
+	return m.scopeStats
+}
+
+func (m *mockActivityV1alpha1Client) SelfScopes() activityclient.SelfScopeInterface {
+	return m.selfScopes
+}
+
 func (m *mockActivityV1alpha1Client) RESTClient() rest.Interface {
 	return nil
 }
@@ -234,6 +264,36 @@ func (m *mockActivityFacetQueryInterface) Create(ctx context.Context, query *v1a
 	}, nil
 }
 
+// =============================================================================
+// Mock ActivityMetricsQuery Interface
+// =============================================================================
+
+type mockActivityMetricsQueryInterface struct {
+	createFunc func(ctx context.Context, query *v1alpha1.ActivityMetricsQuery, opts metav1.CreateOptions) (*v1alpha1.ActivityMetricsQuery, error)
+}
+
+func (m *mockActivityMetricsQueryInterface) Create(ctx context.Context, query *v1alpha1.ActivityMetricsQuery, opts metav1.CreateOptions) (*v1alpha1.ActivityMetricsQuery, error) {
+	if m.createFunc != nil {
+		return m.createFunc(ctx, query, opts)
+	}
+	return query, nil
+}
+
+// =============================================================================
+// Mock FacetTrendQuery Interface
+// =============================================================================
+
+type mockFacetTrendQueryInterface struct {
+	createFunc func(ctx context.Context, query *v1alpha1.FacetTrendQuery, opts metav1.CreateOptions) (*v1alpha1.FacetTrendQuery, error)
+}
+
+func (m *mockFacetTrendQueryInterface) Create(ctx context.Context, query *v1alpha1.FacetTrendQuery, opts metav1.CreateOptions) (*v1alpha1.FacetTrendQuery, error) {
+	if m.createFunc != nil {
+		return m.createFunc(ctx, query, opts)
+	}
+	return query, nil
+}
+
 // =============================================================================
 // Mock ActivityPolicy Interface
 // =============================================================================
@@ -337,7 +397,10 @@ func (m *mockPolicyPreviewInterface) Create(ctx context.Context, preview *v1alph
 // Mock Activity Interface (for namespaced activities)
 // =============================================================================
 
-type mockActivityInterface struct{}
+type mockActivityInterface struct {
+	namespace string
+	getFunc   func(ctx context.Context, name string, opts metav1.GetOptions) (*v1alpha1.Activity, error)
+}
 
 func (m *mockActivityInterface) Create(ctx context.Context, activity *v1alpha1.Activity, opts metav1.CreateOptions) (*v1alpha1.Activity, error) {
 	return activity, nil
@@ -356,6 +419,9 @@ func (m *mockActivityInterface) DeleteCollection(ctx context.Context, opts metav
 }
 
 func (m *mockActivityInterface) Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1alpha1.Activity, error) {
+	if m.getFunc != nil {
+		return m.getFunc(ctx, name, opts)
+	}
 	return &v1alpha1.Activity{ObjectMeta: metav1.ObjectMeta{Name: name}}, nil
 }
 
@@ -410,12 +476,29 @@ func (m *mockEventFacetQueryInterface) Patch(ctx context.Context, name string, p
 // Mock EventQuery Interface
 // =============================================================================
 
-type mockEventQueryInterface struct{}
+type mockEventQueryInterface struct {
+	createFunc func(ctx context.Context, query *v1alpha1.EventQuery, opts metav1.CreateOptions) (*v1alpha1.EventQuery, error)
+}
 
 func (m *mockEventQueryInterface) Create(ctx context.Context, query *v1alpha1.EventQuery, opts metav1.CreateOptions) (*v1alpha1.EventQuery, error) {
+	if m.createFunc != nil {
+		return m.createFunc(ctx, query, opts)
+	}
 	return query, nil
 }
 
+type mockScopeStatsInterface struct{}
+
+func (m *mockScopeStatsInterface) Create(ctx context.Context, stats *v1alpha1.ScopeStats, opts metav1.CreateOptions) (*v1alpha1.ScopeStats, error) {
+	return stats, nil
+}
+
+type mockSelfScopeInterface struct{}
+
+func (m *mockSelfScopeInterface) Create(ctx context.Context, selfScope *v1alpha1.SelfScope, opts metav1.CreateOptions) (*v1alpha1.SelfScope, error) {
+	return selfScope, nil
+}
+
 type mockReindexJobInterface struct{}
 
 func (m *mockReindexJobInterface) Create(ctx context.Context, job *v1alpha1.ReindexJob, opts metav1.CreateOptions) (*v1alpha1.ReindexJob, error) {
@@ -547,6 +630,36 @@ func TestQueryAuditLogs(t *testing.T) {
 	t.Log("✓ query_audit_logs works correctly")
 }
 
+func TestQueryAuditLogsMutationsOnly(t *testing.T) {
+	client := newMockClient()
+	provider := createTestProvider(client)
+
+	var got v1alpha1.AuditLogQuerySpec
+	client.auditLogQueries.createFunc = func(ctx context.Context, query *v1alpha1.AuditLogQuery, opts metav1.CreateOptions) (*v1alpha1.AuditLogQuery, error) {
+		got = query.Spec
+		return query, nil
+	}
+
+	args := QueryAuditLogsArgs{
+		StartTime:     "now-1h",
+		EndTime:       "now",
+		Filter:        "objectRef.namespace == 'production'",
+		MutationsOnly: true,
+	}
+	if _, _, err := provider.handleQueryAuditLogs(context.Background(), nil, args); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !got.MutationsOnly {
+		t.Error("Expected mutationsOnly to be passed to the query")
+	}
+	if got.Filter != args.Filter {
+		t.Errorf("Expected the filter to be passed unchanged, got %q", got.Filter)
+	}
+
+	t.Log("✓ query_audit_logs passes mutationsOnly to the server")
+}
+
 func TestGetAuditLogFacets(t *testing.T) {
 	client := newMockClient()
 	provider := createTestProvider(client)
@@ -578,6 +691,49 @@ func TestGetAuditLogFacets(t *testing.T) {
 	t.Log("✓ get_audit_log_facets works correctly")
 }
 
+func TestGetAuditLogFacetsPartialResults(t *testing.T) {
+	client := newMockClient()
+	client.auditLogFacetsQueries.createFunc = func(ctx context.Context, query *v1alpha1.AuditLogFacetsQuery, opts metav1.CreateOptions) (*v1alpha1.AuditLogFacetsQuery, error) {
+		if !query.Spec.PartialResults {
+			t.Error("Expected partialResults to be requested")
+		}
+		return &v1alpha1.AuditLogFacetsQuery{
+			Status: v1alpha1.AuditLogFacetsQueryStatus{
+				Facets: []v1alpha1.FacetResult{
+					{Field: "verb", Values: []v1alpha1.FacetValue{{Value: "create", Count: 10}}},
+				},
+				FacetErrors: []v1alpha1.FacetError{
+					{Field: "user.username", Message: "unable to retrieve facet data for field 'user.username'"},
+				},
+			},
+		}, nil
+	}
+	provider := createTestProvider(client)
+
+	args := GetAuditLogFacetsArgs{Fields: []string{"verb", "user.username"}}
+
+	result, _, err := provider.handleGetAuditLogFacets(context.Background(), nil, args)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	output := parseJSONResult(t, result)
+
+	if verbFacets := output["verb"].([]any); len(verbFacets) != 1 {
+		t.Errorf("Expected 1 verb facet value, got %d", len(verbFacets))
+	}
+
+	facetErrors, ok := output["facetErrors"].([]any)
+	if !ok || len(facetErrors) != 1 {
+		t.Fatalf("Expected 1 facet error, got %v", output["facetErrors"])
+	}
+	if field := facetErrors[0].(map[string]any)["field"]; field != "user.username" {
+		t.Errorf("Expected failed field user.username, got %v", field)
+	}
+
+	t.Log("✓ get_audit_log_facets returns partial results")
+}
+
 func TestQueryActivities(t *testing.T) {
 	client := newMockClient()
 	provider := createTestProvider(client)
@@ -613,6 +769,158 @@ func TestQueryActivities(t *testing.T) {
 	t.Log("✓ query_activities works correctly")
 }
 
+func TestGetActivitySourceEvents(t *testing.T) {
+	client := newMockClient()
+	now := time.Now()
+	client.activityQueries.createFunc = func(ctx context.Context, query *v1alpha1.ActivityQuery, opts metav1.CreateOptions) (*v1alpha1.ActivityQuery, error) {
+		newActivity := func(name string, origin v1alpha1.ActivityOrigin) v1alpha1.Activity {
+			return v1alpha1.Activity{
+				ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(now)},
+				Spec:       v1alpha1.ActivitySpec{Summary: name, Origin: origin},
+			}
+		}
+		return &v1alpha1.ActivityQuery{
+			Status: v1alpha1.ActivityQueryStatus{
+				Results: []v1alpha1.Activity{
+					newActivity("found", v1alpha1.ActivityOrigin{Type: "audit", ID: "audit-1"}),
+					newActivity("aged-out", v1alpha1.ActivityOrigin{Type: "audit", ID: "audit-2"}),
+					newActivity("from-event", v1alpha1.ActivityOrigin{Type: "event", ID: "event-uid"}),
+				},
+			},
+		}, nil
+	}
+
+	var auditQuery *v1alpha1.AuditLogQuery
+	client.auditLogQueries.createFunc = func(ctx context.Context, query *v1alpha1.AuditLogQuery, opts metav1.CreateOptions) (*v1alpha1.AuditLogQuery, error) {
+		auditQuery = query
+		return &v1alpha1.AuditLogQuery{
+			Status: v1alpha1.AuditLogQueryStatus{
+				Results: []auditv1.Event{{AuditID: types.UID("audit-1"), Verb: "create"}},
+			},
+		}, nil
+	}
+
+	provider := createTestProvider(client)
+
+	result, _, err := provider.handleGetActivitySourceEvents(context.Background(), nil, GetActivitySourceEventsArgs{StartTime: "now-1d", EndTime: "now"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if auditQuery == nil {
+		t.Fatal("Expected an audit log query for the source events")
+	}
+	if want := "auditID in ['audit-1', 'audit-2']"; auditQuery.Spec.Filter != want {
+		t.Errorf("Expected filter %q, got %q", want, auditQuery.Spec.Filter)
+	}
+
+	output := parseJSONResult(t, result)
+
+	if output["sourceEventsFound"].(float64) != 1 {
+		t.Errorf("Expected sourceEventsFound=1, got %v", output["sourceEventsFound"])
+	}
+
+	pairs := output["pairs"].([]any)
+	if len(pairs) != 3 {
+		t.Fatalf("Expected 3 pairs, got %d", len(pairs))
+	}
+
+	wantStatus := []string{"found", "notFound", "notAudit"}
+	for i, want := range wantStatus {
+		pair := pairs[i].(map[string]any)
+		if pair["sourceStatus"] != want {
+			t.Errorf("Pair %d: expected sourceStatus %s, got %v", i, want, pair["sourceStatus"])
+		}
+	}
+
+	event, ok := pairs[0].(map[string]any)["auditEvent"].(map[string]any)
+	if !ok || event["auditID"] != "audit-1" {
+		t.Errorf("Expected source event audit-1, got %v", pairs[0].(map[string]any)["auditEvent"])
+	}
+
+	t.Log("✓ get_activity_source_events pairs activities with their audit events")
+}
+
+func TestGetActivitySourceEvents_LimitTooLarge(t *testing.T) {
+	client := newMockClient()
+	provider := createTestProvider(client)
+
+	result, _, err := provider.handleGetActivitySourceEvents(context.Background(), nil, GetActivitySourceEventsArgs{Limit: maxSourceEventActivities + 1})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !result.IsError {
+		t.Error("Expected an error result for a limit over the batch cap")
+	}
+
+	t.Log("✓ get_activity_source_events rejects oversized batches")
+}
+
+func TestGetActivityByID(t *testing.T) {
+	client := newMockClient()
+	client.activities.getFunc = func(ctx context.Context, name string, opts metav1.GetOptions) (*v1alpha1.Activity, error) {
+		return &v1alpha1.Activity{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: client.activities.namespace},
+			Spec: v1alpha1.ActivitySpec{
+				Summary:  "alice deleted secret db-creds",
+				Actor:    v1alpha1.ActivityActor{Type: "user", Name: "alice"},
+				Resource: v1alpha1.ActivityResource{Kind: "Secret", Name: "db-creds", Namespace: "production"},
+				Tenant:   v1alpha1.ActivityTenant{Type: "project", Name: "backend"},
+				Origin:   v1alpha1.ActivityOrigin{Type: "audit", ID: "audit-1"},
+			},
+		}, nil
+	}
+	provider := createTestProvider(client)
+
+	result, _, err := provider.handleGetActivityByID(context.Background(), nil, GetActivityByIDArgs{Name: "act-0123456789ab", Namespace: "production"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if client.activities.namespace != "production" {
+		t.Errorf("Expected lookup in namespace production, got %q", client.activities.namespace)
+	}
+
+	output := parseJSONResult(t, result)
+	if output["name"] != "act-0123456789ab" || output["namespace"] != "production" {
+		t.Errorf("Expected act-0123456789ab in production, got %v/%v", output["namespace"], output["name"])
+	}
+
+	spec := output["spec"].(map[string]any)
+	if spec["summary"] != "alice deleted secret db-creds" {
+		t.Errorf("Expected summary, got %v", spec["summary"])
+	}
+	if origin := spec["origin"].(map[string]any); origin["id"] != "audit-1" {
+		t.Errorf("Expected origin id audit-1, got %v", origin["id"])
+	}
+	if tenant := spec["tenant"].(map[string]any); tenant["name"] != "backend" {
+		t.Errorf("Expected tenant backend, got %v", tenant["name"])
+	}
+
+	t.Log("✓ get_activity_by_id returns the full activity")
+}
+
+func TestGetActivityByID_NotFound(t *testing.T) {
+	client := newMockClient()
+	client.activities.getFunc = func(ctx context.Context, name string, opts metav1.GetOptions) (*v1alpha1.Activity, error) {
+		return nil, apierrors.NewNotFound(v1alpha1.Resource("activities"), name)
+	}
+	provider := createTestProvider(client)
+
+	result, _, err := provider.handleGetActivityByID(context.Background(), nil, GetActivityByIDArgs{Name: "act-missing", Namespace: "production"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !result.IsError {
+		t.Fatal("Expected an error result for a missing activity")
+	}
+	if text := result.Content[0].(*mcp.TextContent).Text; !strings.Contains(text, "not found") {
+		t.Errorf("Expected a not-found message, got %q", text)
+	}
+
+	t.Log("✓ get_activity_by_id reports missing activities clearly")
+}
+
 func TestGetActivityFacets(t *testing.T) {
 	client := newMockClient()
 	provider := createTestProvider(client)
@@ -639,6 +947,65 @@ func TestGetActivityFacets(t *testing.T) {
 	t.Log("✓ get_activity_facets works correctly")
 }
 
+func TestGetResourceTypesWithActivity(t *testing.T) {
+	client := newMockClient()
+	provider := createTestProvider(client)
+
+	var captured *v1alpha1.ActivityFacetQuery
+	client.activityFacetQueries.createFunc = func(ctx context.Context, query *v1alpha1.ActivityFacetQuery, opts metav1.CreateOptions) (*v1alpha1.ActivityFacetQuery, error) {
+		captured = query
+		return &v1alpha1.ActivityFacetQuery{
+			Status: v1alpha1.ActivityFacetQueryStatus{
+				Facets: []v1alpha1.FacetResult{
+					{Field: "spec.resource.kind", Values: []v1alpha1.FacetValue{{Value: "Deployment", Count: 12}, {Value: "ConfigMap", Count: 3}}},
+					{Field: "spec.resource.apiGroup", Values: []v1alpha1.FacetValue{{Value: "apps", Count: 12}, {Value: "", Count: 3}}},
+				},
+			},
+		}, nil
+	}
+
+	result, _, err := provider.handleGetResourceTypesWithActivity(context.Background(), nil, GetResourceTypesWithActivityArgs{
+		Namespace:  "default",
+		TenantType: "project",
+		TenantName: "web-app",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Unexpected error result: %v", result.Content)
+	}
+
+	wantFilter := "spec.resource.namespace == 'default' && spec.tenant.type == 'project' && spec.tenant.name == 'web-app'"
+	if captured.Spec.Filter != wantFilter {
+		t.Errorf("Expected filter %q, got %q", wantFilter, captured.Spec.Filter)
+	}
+	if captured.Spec.TimeRange.Start != "now-7d" || captured.Spec.TimeRange.End != "now" {
+		t.Errorf("Expected default time range now-7d..now, got %s..%s", captured.Spec.TimeRange.Start, captured.Spec.TimeRange.End)
+	}
+	if len(captured.Spec.Facets) != 2 || captured.Spec.Facets[0].Limit != defaultResourceTypesLimit {
+		t.Errorf("Expected kind and apiGroup facets with the default limit, got %+v", captured.Spec.Facets)
+	}
+
+	output := parseJSONResult(t, result)
+	resourceTypes := output["resourceTypes"].([]any)
+	if len(resourceTypes) != 2 {
+		t.Fatalf("Expected 2 resource types, got %d", len(resourceTypes))
+	}
+	first := resourceTypes[0].(map[string]any)
+	if first["kind"] != "Deployment" || first["count"] != float64(12) {
+		t.Errorf("Expected Deployment with 12 activities first, got %v", first)
+	}
+	if apiGroups := output["apiGroups"].([]any); len(apiGroups) != 2 {
+		t.Errorf("Expected 2 API groups, got %d", len(apiGroups))
+	}
+
+	result, _, _ = provider.handleGetResourceTypesWithActivity(context.Background(), nil, GetResourceTypesWithActivityArgs{Limit: 500})
+	if !result.IsError {
+		t.Error("Expected an error for a limit above the facet maximum")
+	}
+}
+
 func TestFindFailedOperations(t *testing.T) {
 	client := newMockClient()
 
@@ -689,6 +1056,64 @@ func TestFindFailedOperations(t *testing.T) {
 	t.Log("✓ find_failed_operations works correctly")
 }
 
+func TestFindFailedOperationsMessageContains(t *testing.T) {
+	client := newMockClient()
+
+	var gotFilter string
+	client.auditLogQueries.createFunc = func(ctx context.Context, query *v1alpha1.AuditLogQuery, opts metav1.CreateOptions) (*v1alpha1.AuditLogQuery, error) {
+		gotFilter = query.Spec.Filter
+		return query, nil
+	}
+
+	provider := createTestProvider(client)
+
+	args := FindFailedOperationsArgs{
+		StartTime:       "now-1d",
+		MessageContains: `admission webhook "policy.example.com" denied the request: can't`,
+	}
+	if _, _, err := provider.handleFindFailedOperations(context.Background(), nil, args); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !strings.Contains(gotFilter, `responseStatus.message.contains('admission webhook "policy.example.com" denied the request: can\'t')`) {
+		t.Errorf("Expected escaped message filter, got %q", gotFilter)
+	}
+	if _, err := cel.CompileFilter(gotFilter); err != nil {
+		t.Errorf("Filter %q does not compile: %v", gotFilter, err)
+	}
+}
+
+func TestFindFailedOperationsEscapesArgs(t *testing.T) {
+	client := newMockClient()
+
+	var gotFilter string
+	client.auditLogQueries.createFunc = func(ctx context.Context, query *v1alpha1.AuditLogQuery, opts metav1.CreateOptions) (*v1alpha1.AuditLogQuery, error) {
+		gotFilter = query.Spec.Filter
+		return query, nil
+	}
+
+	provider := createTestProvider(client)
+
+	args := FindFailedOperationsArgs{
+		StartTime: "now-1d",
+		Username:  "o'brien' || true || 'x",
+		Resource:  "pods'",
+		Verb:      "get'",
+	}
+	if _, _, err := provider.handleFindFailedOperations(context.Background(), nil, args); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !strings.Contains(gotFilter, `user.username == 'o\'brien\' || true || \'x'`) {
+		t.Errorf("Expected escaped username filter, got %q", gotFilter)
+	}
+	if _, err := cel.CompileFilter(gotFilter); err != nil {
+		t.Errorf("Filter %q does not compile: %v", gotFilter, err)
+	}
+
+	t.Log("✓ find_failed_operations escapes quotes in its arguments")
+}
+
 func TestGetResourceHistory(t *testing.T) {
 	client := newMockClient()
 
@@ -772,31 +1197,189 @@ func TestGetResourceHistoryRequiresName(t *testing.T) {
 	t.Log("✓ get_resource_history validates required fields")
 }
 
-func TestGetUserActivitySummary(t *testing.T) {
+func TestGetResourceHistoriesBatch(t *testing.T) {
 	client := newMockClient()
 
-	// Setup mock with user activities
-	client.activityQueries.createFunc = func(ctx context.Context, query *v1alpha1.ActivityQuery, opts metav1.CreateOptions) (*v1alpha1.ActivityQuery, error) {
-		now := metav1.NewTime(time.Now())
-		return &v1alpha1.ActivityQuery{
-			ObjectMeta: metav1.ObjectMeta{Name: "test-user-summary"},
-			Status: v1alpha1.ActivityQueryStatus{
-				Results: []v1alpha1.Activity{
+	// Fail the lookup for one resource, return a change for the others
+	var mu sync.Mutex
+	var filters []string
+	client.auditLogQueries.createFunc = func(ctx context.Context, query *v1alpha1.AuditLogQuery, opts metav1.CreateOptions) (*v1alpha1.AuditLogQuery, error) {
+		if !query.Spec.MutationsOnly {
+			t.Errorf("Expected history lookups to request only mutations, filter %q", query.Spec.Filter)
+		}
+		mu.Lock()
+		filters = append(filters, query.Spec.Filter)
+		mu.Unlock()
+
+		if strings.Contains(query.Spec.Filter, "objectRef.name == 'broken'") {
+			return nil, fmt.Errorf("query timed out")
+		}
+		return &v1alpha1.AuditLogQuery{
+			Spec: query.Spec,
+			Status: v1alpha1.AuditLogQueryStatus{
+				Results: []auditv1.Event{
 					{
-						ObjectMeta: metav1.ObjectMeta{Name: "activity-1", CreationTimestamp: now},
-						Spec: v1alpha1.ActivitySpec{
-							Summary:      "alice created Pod pod-1",
-							ChangeSource: "human",
-							Actor:        v1alpha1.ActivityActor{Type: "user", Name: "alice@example.com"},
-							Resource:     v1alpha1.ActivityResource{APIVersion: "v1", Kind: "Pod", Name: "pod-1"},
-							Tenant:       v1alpha1.ActivityTenant{Type: "organization", Name: "acme"},
-							Origin:       v1alpha1.ActivityOrigin{Type: "audit", ID: "audit-1"},
-						},
+						AuditID:        "audit-1",
+						Verb:           "patch",
+						User:           authnv1.UserInfo{Username: "alice@example.com"},
+						StageTimestamp: metav1.NewMicroTime(time.Now()),
+						ResponseStatus: &metav1.Status{Code: 200},
 					},
-					{
-						ObjectMeta: metav1.ObjectMeta{Name: "activity-2", CreationTimestamp: now},
-						Spec: v1alpha1.ActivitySpec{
-							Summary:      "alice updated Deployment deploy-1",
+				},
+				Continue: "more",
+			},
+		}, nil
+	}
+
+	provider := createTestProvider(client)
+
+	args := GetResourceHistoriesBatchArgs{
+		Resources: []ResourceRef{
+			{APIGroup: "apps", Kind: "Deployment", Name: "my-app", Namespace: "default"},
+			{Kind: "ConfigMap", Name: "broken", Namespace: "default"},
+			{APIGroup: "networking.datumapis.com", Kind: "HTTPProxy", Name: "gateway", Namespace: "default"},
+			// Duplicate of the first resource
+			{APIGroup: "apps", Kind: "Deployment", Name: "my-app", Namespace: "default"},
+		},
+	}
+
+	result, _, err := provider.handleGetResourceHistoriesBatch(context.Background(), nil, args)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	output := parseJSONResult(t, result)
+
+	if output["resourceCount"].(float64) != 3 {
+		t.Errorf("Expected 3 distinct resources, got %v", output["resourceCount"])
+	}
+	if output["errorCount"].(float64) != 1 {
+		t.Errorf("Expected 1 error, got %v", output["errorCount"])
+	}
+	if output["totalEvents"].(float64) != 2 {
+		t.Errorf("Expected 2 events, got %v", output["totalEvents"])
+	}
+	if len(filters) != 3 {
+		t.Errorf("Expected 3 queries, got %d", len(filters))
+	}
+
+	histories := output["histories"].(map[string]any)
+
+	deployment, ok := histories["apps/deployments/default/my-app"].(map[string]any)
+	if !ok {
+		t.Fatalf("Expected history for apps/deployments/default/my-app, got keys %v", histories)
+	}
+	if deployment["truncated"] != true {
+		t.Error("Expected deployment history to be marked truncated")
+	}
+	history := deployment["history"].([]any)
+	if len(history) != 1 || history[0].(map[string]any)["actor"] != "alice@example.com" {
+		t.Errorf("Unexpected deployment history: %v", history)
+	}
+
+	broken, ok := histories["core/configmaps/default/broken"].(map[string]any)
+	if !ok {
+		t.Fatalf("Expected entry for core/configmaps/default/broken, got keys %v", histories)
+	}
+	if !strings.Contains(broken["error"].(string), "query timed out") {
+		t.Errorf("Expected inline error, got %v", broken["error"])
+	}
+
+	for _, filter := range filters {
+		if _, err := cel.CompileFilter(filter); err != nil {
+			t.Errorf("Generated filter %q does not compile: %v", filter, err)
+		}
+	}
+
+	t.Log("✓ get_resource_histories_batch returns per-resource histories and inline errors")
+}
+
+func TestGetResourceHistoriesBatchLimits(t *testing.T) {
+	client := newMockClient()
+
+	var mu sync.Mutex
+	var limits []int32
+	client.auditLogQueries.createFunc = func(ctx context.Context, query *v1alpha1.AuditLogQuery, opts metav1.CreateOptions) (*v1alpha1.AuditLogQuery, error) {
+		mu.Lock()
+		limits = append(limits, query.Spec.Limit)
+		mu.Unlock()
+		return &v1alpha1.AuditLogQuery{Spec: query.Spec}, nil
+	}
+
+	provider := createTestProvider(client)
+
+	// Too many resources is rejected outright
+	tooMany := make([]ResourceRef, maxBatchResources+1)
+	for i := range tooMany {
+		tooMany[i] = ResourceRef{Kind: "ConfigMap", Name: fmt.Sprintf("cm-%d", i)}
+	}
+	result, _, _ := provider.handleGetResourceHistoriesBatch(context.Background(), nil, GetResourceHistoriesBatchArgs{Resources: tooMany})
+	if !result.IsError {
+		t.Error("Expected error when the batch exceeds the resource cap")
+	}
+
+	// A full batch shares the total event budget
+	full := tooMany[:maxBatchResources]
+	result, _, _ = provider.handleGetResourceHistoriesBatch(context.Background(), nil, GetResourceHistoriesBatchArgs{Resources: full, LimitPerResource: 100})
+	output := parseJSONResult(t, result)
+
+	want := maxBatchEvents / maxBatchResources
+	if int(output["limitPerResource"].(float64)) != want {
+		t.Errorf("Expected limitPerResource %d, got %v", want, output["limitPerResource"])
+	}
+	for _, limit := range limits {
+		if int(limit) != want {
+			t.Errorf("Expected query limit %d, got %d", want, limit)
+		}
+	}
+
+	// Resources missing a name are reported inline
+	result, _, _ = provider.handleGetResourceHistoriesBatch(context.Background(), nil, GetResourceHistoriesBatchArgs{
+		Resources: []ResourceRef{{Kind: "ConfigMap"}},
+	})
+	output = parseJSONResult(t, result)
+	if output["errorCount"].(float64) != 1 {
+		t.Errorf("Expected missing name to be reported inline, got %v", output)
+	}
+
+	t.Log("✓ get_resource_histories_batch enforces batch caps")
+}
+
+func TestBuildResourceHistoryFilter(t *testing.T) {
+	got := buildResourceHistoryFilter(ResourceRef{APIGroup: "apps", Resource: "deployments", Name: "it's", Namespace: "default"})
+	want := "objectRef.apiGroup == 'apps' && objectRef.resource == 'deployments' && (objectRef.name == 'it\\'s' || verb == 'deletecollection') && objectRef.namespace == 'default'"
+	if got != want {
+		t.Errorf("buildResourceHistoryFilter() = %q, want %q", got, want)
+	}
+}
+
+func TestGetUserActivitySummary(t *testing.T) {
+	client := newMockClient()
+
+	// Setup mock with user activities
+	var activityFilter string
+	client.activityQueries.createFunc = func(ctx context.Context, query *v1alpha1.ActivityQuery, opts metav1.CreateOptions) (*v1alpha1.ActivityQuery, error) {
+		activityFilter = query.Spec.Filter
+		now := metav1.NewTime(time.Now())
+		return &v1alpha1.ActivityQuery{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-user-summary"},
+			Status: v1alpha1.ActivityQueryStatus{
+				Results: []v1alpha1.Activity{
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "activity-1", CreationTimestamp: now},
+						Spec: v1alpha1.ActivitySpec{
+							Summary:      "alice created Pod pod-1",
+							ChangeSource: "human",
+							Actor:        v1alpha1.ActivityActor{Type: "user", Name: "alice@example.com"},
+							Resource:     v1alpha1.ActivityResource{APIVersion: "v1", Kind: "Pod", Name: "pod-1"},
+							Tenant:       v1alpha1.ActivityTenant{Type: "organization", Name: "acme"},
+							Origin:       v1alpha1.ActivityOrigin{Type: "audit", ID: "audit-1"},
+						},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "activity-2", CreationTimestamp: now},
+						Spec: v1alpha1.ActivitySpec{
+							Summary:      "alice updated Deployment deploy-1",
 							ChangeSource: "human",
 							Actor:        v1alpha1.ActivityActor{Type: "user", Name: "alice@example.com"},
 							Resource:     v1alpha1.ActivityResource{APIGroup: "apps", APIVersion: "v1", Kind: "Deployment", Name: "deploy-1"},
@@ -824,6 +1407,10 @@ func TestGetUserActivitySummary(t *testing.T) {
 
 	output := parseJSONResult(t, result)
 
+	// Activities are counted for the user only, like the audit log breakdown
+	if activityFilter != "spec.actor.name in ['alice@example.com']" {
+		t.Errorf("Expected activity filter on the user, got %q", activityFilter)
+	}
 	if output["totalActivities"].(float64) != 2 {
 		t.Errorf("Expected totalActivities=2, got %v", output["totalActivities"])
 	}
@@ -836,6 +1423,352 @@ func TestGetUserActivitySummary(t *testing.T) {
 	t.Log("✓ get_user_activity_summary works correctly")
 }
 
+func TestGetUserActivitySummaryVerbBreakdown(t *testing.T) {
+	client := newMockClient()
+
+	var capturedFilter string
+	client.auditLogQueries.createFunc = func(ctx context.Context, query *v1alpha1.AuditLogQuery, opts metav1.CreateOptions) (*v1alpha1.AuditLogQuery, error) {
+		capturedFilter = query.Spec.Filter
+		now := metav1.NewMicroTime(time.Now())
+		event := func(verb string, code int32) auditv1.Event {
+			return auditv1.Event{
+				Verb:                     verb,
+				User:                     authnv1.UserInfo{Username: "o'brien@example.com"},
+				ObjectRef:                &auditv1.ObjectReference{Resource: "secrets", Namespace: "default", Name: "db-creds"},
+				ResponseStatus:           &metav1.Status{Code: code, Message: "denied"},
+				RequestReceivedTimestamp: now,
+			}
+		}
+		return &v1alpha1.AuditLogQuery{
+			Status: v1alpha1.AuditLogQueryStatus{
+				Results: []auditv1.Event{
+					event("create", 201),
+					event("create", 201),
+					event("create", 403),
+					event("delete", 200),
+					event("get", 401),
+					event("update", 409),
+				},
+			},
+		}, nil
+	}
+
+	provider := createTestProvider(client)
+
+	result, _, err := provider.handleGetUserActivitySummary(context.Background(), nil, GetUserActivitySummaryArgs{
+		Username: "o'brien@example.com",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	output := parseJSONResult(t, result)
+
+	if capturedFilter != `user.username == 'o\'brien@example.com'` {
+		t.Errorf("Expected audit filter on the escaped username, got %q", capturedFilter)
+	}
+	if _, err := cel.CompileFilter(capturedFilter); err != nil {
+		t.Errorf("Filter %q does not compile: %v", capturedFilter, err)
+	}
+
+	byVerb := output["breakdown"].(map[string]any)["byVerb"].(map[string]any)
+
+	create := byVerb["create"].(map[string]any)
+	if create["total"].(float64) != 3 || create["succeeded"].(float64) != 2 || create["failed"].(float64) != 1 {
+		t.Errorf("Unexpected create stats: %v", create)
+	}
+	if create["failedByStatus"].(map[string]any)["forbidden"].(float64) != 1 {
+		t.Errorf("Expected 1 forbidden create, got %v", create["failedByStatus"])
+	}
+
+	deleteStats := byVerb["delete"].(map[string]any)
+	if deleteStats["failed"].(float64) != 0 {
+		t.Errorf("Expected no failed deletes, got %v", deleteStats)
+	}
+	if _, ok := deleteStats["failedByStatus"]; ok {
+		t.Errorf("Expected failedByStatus to be omitted for all-ok verb, got %v", deleteStats)
+	}
+
+	// Only forbidden/unauthorized attempts are listed; the 409 conflict is not.
+	failures := output["failures"].([]any)
+	if len(failures) != 2 {
+		t.Fatalf("Expected 2 failures, got %d: %v", len(failures), failures)
+	}
+	first := failures[0].(map[string]any)
+	if first["reason"] != "forbidden" || first["resource"] != "secrets" {
+		t.Errorf("Unexpected first failure: %v", first)
+	}
+
+	t.Log("✓ get_user_activity_summary breaks down verbs by success/failure")
+}
+
+func TestGetUserActivitySummaryPagesAuditLogs(t *testing.T) {
+	client := newMockClient()
+
+	var continues []string
+	client.auditLogQueries.createFunc = func(ctx context.Context, query *v1alpha1.AuditLogQuery, opts metav1.CreateOptions) (*v1alpha1.AuditLogQuery, error) {
+		continues = append(continues, query.Spec.Continue)
+		events := make([]auditv1.Event, userSummaryPageSize)
+		for i := range events {
+			events[i] = auditv1.Event{Verb: "update", ResponseStatus: &metav1.Status{Code: 200}}
+		}
+		result := &v1alpha1.AuditLogQuery{Status: v1alpha1.AuditLogQueryStatus{Results: events}}
+		if query.Spec.Continue == "" {
+			result.Status.Continue = "page-2"
+		}
+		return result, nil
+	}
+
+	provider := createTestProvider(client)
+
+	result, _, err := provider.handleGetUserActivitySummary(context.Background(), nil, GetUserActivitySummaryArgs{
+		Username: "alice@example.com",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	output := parseJSONResult(t, result)
+
+	if fmt.Sprint(continues) != "[ page-2]" {
+		t.Errorf("Expected two audit log pages, got continues %q", continues)
+	}
+	update := output["breakdown"].(map[string]any)["byVerb"].(map[string]any)["update"].(map[string]any)
+	if update["total"].(float64) != 2*userSummaryPageSize {
+		t.Errorf("Expected updates from both pages counted, got %v", update)
+	}
+	if output["sampled"] != false {
+		t.Errorf("Expected sampled=false for a fully read window, got %v", output["sampled"])
+	}
+
+	// A window with more events than the cap is flagged as sampled
+	client.auditLogQueries.createFunc = func(ctx context.Context, query *v1alpha1.AuditLogQuery, opts metav1.CreateOptions) (*v1alpha1.AuditLogQuery, error) {
+		return &v1alpha1.AuditLogQuery{Status: v1alpha1.AuditLogQueryStatus{
+			Results:  make([]auditv1.Event, userSummaryPageSize),
+			Continue: "more",
+		}}, nil
+	}
+	result, _, err = provider.handleGetUserActivitySummary(context.Background(), nil, GetUserActivitySummaryArgs{
+		Username: "alice@example.com",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	output = parseJSONResult(t, result)
+	if output["sampled"] != true {
+		t.Errorf("Expected sampled=true for a truncated window, got %v", output["sampled"])
+	}
+	if note, _ := output["note"].(string); !strings.Contains(note, "byVerb and failures cover only the most recent") {
+		t.Errorf("Expected a note on the truncated audit logs, got %q", note)
+	}
+}
+
+func TestGetUserActivitySummaryWithoutAuditLogs(t *testing.T) {
+	client := newMockClient()
+
+	client.auditLogQueries.createFunc = func(ctx context.Context, query *v1alpha1.AuditLogQuery, opts metav1.CreateOptions) (*v1alpha1.AuditLogQuery, error) {
+		return nil, fmt.Errorf("auditlogqueries is forbidden")
+	}
+	client.activityQueries.createFunc = func(ctx context.Context, query *v1alpha1.ActivityQuery, opts metav1.CreateOptions) (*v1alpha1.ActivityQuery, error) {
+		return &v1alpha1.ActivityQuery{
+			Status: v1alpha1.ActivityQueryStatus{
+				Results: []v1alpha1.Activity{{
+					ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(time.Now())},
+					Spec:       v1alpha1.ActivitySpec{ChangeSource: "human", Resource: v1alpha1.ActivityResource{Kind: "Pod"}},
+				}},
+			},
+		}, nil
+	}
+
+	provider := createTestProvider(client)
+
+	result, _, err := provider.handleGetUserActivitySummary(context.Background(), nil, GetUserActivitySummaryArgs{
+		Username: "alice@example.com",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Expected a summary without audit logs, got error: %v", result.Content)
+	}
+
+	output := parseJSONResult(t, result)
+
+	if output["totalActivities"].(float64) != 1 {
+		t.Errorf("Expected totalActivities=1, got %v", output["totalActivities"])
+	}
+	if _, ok := output["breakdown"].(map[string]any)["byVerb"]; ok {
+		t.Errorf("Expected byVerb to be omitted, got %v", output["breakdown"])
+	}
+	if _, ok := output["failures"]; ok {
+		t.Errorf("Expected failures to be omitted, got %v", output["failures"])
+	}
+	if note, _ := output["note"].(string); !strings.Contains(note, "Audit logs could not be read") {
+		t.Errorf("Expected a note on the missing audit logs, got %q", note)
+	}
+}
+
+func TestGetUserActivitySummaryGroup(t *testing.T) {
+	client := newMockClient()
+
+	var auditFilter, activityFilter string
+	client.auditLogQueries.createFunc = func(ctx context.Context, query *v1alpha1.AuditLogQuery, opts metav1.CreateOptions) (*v1alpha1.AuditLogQuery, error) {
+		auditFilter = query.Spec.Filter
+		event := func(username, verb string, code int32) auditv1.Event {
+			return auditv1.Event{
+				Verb:                     verb,
+				User:                     authnv1.UserInfo{Username: username, Groups: []string{"team-payments"}},
+				ObjectRef:                &auditv1.ObjectReference{Resource: "deployments", Namespace: "payments", Name: "api"},
+				ResponseStatus:           &metav1.Status{Code: code},
+				RequestReceivedTimestamp: metav1.NewMicroTime(time.Now()),
+			}
+		}
+		return &v1alpha1.AuditLogQuery{
+			Status: v1alpha1.AuditLogQueryStatus{
+				Results: []auditv1.Event{
+					event("bob@example.com", "update", 200),
+					event("alice@example.com", "patch", 200),
+					event("bob@example.com", "delete", 403),
+					event("bob@example.com", "create", 201),
+				},
+			},
+		}, nil
+	}
+	client.activityQueries.createFunc = func(ctx context.Context, query *v1alpha1.ActivityQuery, opts metav1.CreateOptions) (*v1alpha1.ActivityQuery, error) {
+		activityFilter = query.Spec.Filter
+		activity := func(actor string) v1alpha1.Activity {
+			return v1alpha1.Activity{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(time.Now())},
+				Spec: v1alpha1.ActivitySpec{
+					ChangeSource: "human",
+					Actor:        v1alpha1.ActivityActor{Type: "user", Name: actor},
+					Resource:     v1alpha1.ActivityResource{APIGroup: "apps", Kind: "Deployment", Name: "api"},
+				},
+			}
+		}
+		return &v1alpha1.ActivityQuery{
+			Status: v1alpha1.ActivityQueryStatus{
+				Results: []v1alpha1.Activity{
+					activity("bob@example.com"),
+					activity("bob@example.com"),
+					activity("alice@example.com"),
+				},
+			},
+		}, nil
+	}
+
+	provider := createTestProvider(client)
+
+	result, _, err := provider.handleGetUserActivitySummary(context.Background(), nil, GetUserActivitySummaryArgs{
+		Group: "team-payments",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Unexpected error result: %v", result.Content)
+	}
+
+	if auditFilter != "'team-payments' in user.groups" {
+		t.Errorf("Expected audit filter on group membership, got %q", auditFilter)
+	}
+	if activityFilter != "spec.actor.name in ['alice@example.com', 'bob@example.com']" {
+		t.Errorf("Expected activity filter on group members, got %q", activityFilter)
+	}
+
+	output := parseJSONResult(t, result)
+
+	if _, ok := output["user"]; ok {
+		t.Errorf("Expected no user in a group summary, got %v", output["user"])
+	}
+	group := output["group"].(map[string]any)
+	if group["name"] != "team-payments" || group["memberCount"].(float64) != 2 || group["membersComplete"] != true {
+		t.Errorf("Unexpected group: %v", group)
+	}
+	if output["totalActivities"].(float64) != 3 {
+		t.Errorf("Expected totalActivities=3, got %v", output["totalActivities"])
+	}
+
+	byMember := output["byMember"].([]any)
+	if len(byMember) != 2 {
+		t.Fatalf("Expected 2 members, got %d: %v", len(byMember), byMember)
+	}
+	bob := byMember[0].(map[string]any)
+	if bob["username"] != "bob@example.com" || bob["requests"].(float64) != 3 || bob["failed"].(float64) != 1 || bob["activities"].(float64) != 2 {
+		t.Errorf("Unexpected first member: %v", bob)
+	}
+	alice := byMember[1].(map[string]any)
+	if alice["username"] != "alice@example.com" || alice["requests"].(float64) != 1 || alice["activities"].(float64) != 1 {
+		t.Errorf("Unexpected second member: %v", alice)
+	}
+
+	t.Log("✓ get_user_activity_summary summarizes a group by member")
+}
+
+func TestGetUserActivitySummaryGroupPagesMembers(t *testing.T) {
+	client := newMockClient()
+
+	// bob only appears on the second page of the group's audit logs
+	client.auditLogQueries.createFunc = func(ctx context.Context, query *v1alpha1.AuditLogQuery, opts metav1.CreateOptions) (*v1alpha1.AuditLogQuery, error) {
+		username, cont := "alice@example.com", "page-2"
+		if query.Spec.Continue != "" {
+			username, cont = "bob@example.com", ""
+		}
+		events := make([]auditv1.Event, userSummaryPageSize)
+		for i := range events {
+			events[i] = auditv1.Event{Verb: "update", User: authnv1.UserInfo{Username: username}}
+		}
+		return &v1alpha1.AuditLogQuery{Status: v1alpha1.AuditLogQueryStatus{Results: events, Continue: cont}}, nil
+	}
+	var activityFilter string
+	client.activityQueries.createFunc = func(ctx context.Context, query *v1alpha1.ActivityQuery, opts metav1.CreateOptions) (*v1alpha1.ActivityQuery, error) {
+		activityFilter = query.Spec.Filter
+		return &v1alpha1.ActivityQuery{}, nil
+	}
+
+	provider := createTestProvider(client)
+
+	result, _, err := provider.handleGetUserActivitySummary(context.Background(), nil, GetUserActivitySummaryArgs{
+		Group: "team-payments",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	output := parseJSONResult(t, result)
+
+	if activityFilter != "spec.actor.name in ['alice@example.com', 'bob@example.com']" {
+		t.Errorf("Expected activity filter on members from both pages, got %q", activityFilter)
+	}
+	group := output["group"].(map[string]any)
+	if group["memberCount"].(float64) != 2 || group["membersComplete"] != true {
+		t.Errorf("Unexpected group: %v", group)
+	}
+
+	// Members past the event cap can't be seen, which the summary says
+	client.auditLogQueries.createFunc = func(ctx context.Context, query *v1alpha1.AuditLogQuery, opts metav1.CreateOptions) (*v1alpha1.AuditLogQuery, error) {
+		events := make([]auditv1.Event, userSummaryPageSize)
+		for i := range events {
+			events[i] = auditv1.Event{Verb: "update", User: authnv1.UserInfo{Username: "alice@example.com"}}
+		}
+		return &v1alpha1.AuditLogQuery{Status: v1alpha1.AuditLogQueryStatus{Results: events, Continue: "more"}}, nil
+	}
+	result, _, err = provider.handleGetUserActivitySummary(context.Background(), nil, GetUserActivitySummaryArgs{
+		Group: "team-payments",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	output = parseJSONResult(t, result)
+	group = output["group"].(map[string]any)
+	if group["membersComplete"] != false {
+		t.Errorf("Expected membersComplete=false for a truncated window, got %v", group)
+	}
+	if note, _ := output["note"].(string); !strings.Contains(note, "missing from memberCount") {
+		t.Errorf("Expected a note on incomplete membership, got %q", note)
+	}
+}
+
 func TestGetUserActivitySummaryRequiresUser(t *testing.T) {
 	client := newMockClient()
 	provider := createTestProvider(client)
@@ -850,9 +1783,301 @@ func TestGetUserActivitySummaryRequiresUser(t *testing.T) {
 		t.Error("Expected error when neither username nor userUID provided")
 	}
 
+	result, _, _ = provider.handleGetUserActivitySummary(context.Background(), nil, GetUserActivitySummaryArgs{
+		Username: "alice@example.com",
+		Group:    "team-payments",
+	})
+	if !result.IsError {
+		t.Error("Expected error when both username and group provided")
+	}
+
 	t.Log("✓ get_user_activity_summary validates required fields")
 }
 
+func TestGetActorBlastRadius(t *testing.T) {
+	client := newMockClient()
+
+	base := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	event := func(verb, namespace, resource, name string, code int32, offset time.Duration) auditv1.Event {
+		return auditv1.Event{
+			Verb:                     verb,
+			User:                     authnv1.UserInfo{Username: "bob@example.com"},
+			ObjectRef:                &auditv1.ObjectReference{Resource: resource, Namespace: namespace, Name: name},
+			ResponseStatus:           &metav1.Status{Code: code},
+			RequestReceivedTimestamp: metav1.NewMicroTime(base.Add(offset)),
+		}
+	}
+
+	pages := map[string]v1alpha1.AuditLogQueryStatus{
+		"": {
+			Results: []auditv1.Event{
+				event("delete", "prod", "secrets", "db-creds", 200, 2*time.Hour),
+				event("patch", "prod", "configmaps", "settings", 200, time.Hour),
+				event("update", "dev", "deployments", "api", 200, 0),
+			},
+			Continue:           "page-2",
+			EffectiveStartTime: "2026-10-15T12:00:00Z",
+			EffectiveEndTime:   "2026-10-16T12:00:00Z",
+		},
+		"page-2": {
+			Results: []auditv1.Event{
+				event("create", "prod", "secrets", "db-creds", 201, 0),
+				event("delete", "prod", "secrets", "db-creds", 403, 30*time.Minute),
+				{Verb: "create", RequestReceivedTimestamp: metav1.NewMicroTime(base)},
+			},
+		},
+	}
+
+	var filters, continues []string
+	var mutationsOnly []bool
+	client.auditLogQueries.createFunc = func(ctx context.Context, query *v1alpha1.AuditLogQuery, opts metav1.CreateOptions) (*v1alpha1.AuditLogQuery, error) {
+		filters = append(filters, query.Spec.Filter)
+		continues = append(continues, query.Spec.Continue)
+		mutationsOnly = append(mutationsOnly, query.Spec.MutationsOnly)
+		return &v1alpha1.AuditLogQuery{Status: pages[query.Spec.Continue]}, nil
+	}
+
+	provider := createTestProvider(client)
+
+	result, _, err := provider.handleGetActorBlastRadius(context.Background(), nil, GetActorBlastRadiusArgs{
+		Username: "bob@example.com",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(continues) != 2 || continues[1] != "page-2" {
+		t.Fatalf("Expected two pages to be fetched, got continue tokens %q", continues)
+	}
+	if filters[0] != "user.username == 'bob@example.com'" {
+		t.Errorf("Expected filter on the username, got %q", filters[0])
+	}
+	if !mutationsOnly[0] || !mutationsOnly[1] {
+		t.Errorf("Expected every page to request only mutations, got %v", mutationsOnly)
+	}
+
+	output := parseJSONResult(t, result)
+
+	if output["totalEvents"].(float64) != 6 {
+		t.Errorf("Expected totalEvents=6, got %v", output["totalEvents"])
+	}
+	if output["totalMutations"].(float64) != 5 {
+		t.Errorf("Expected totalMutations=5, got %v", output["totalMutations"])
+	}
+	if output["namespaces"].(float64) != 2 {
+		t.Errorf("Expected namespaces=2, got %v", output["namespaces"])
+	}
+	if output["truncated"] != false {
+		t.Errorf("Expected truncated=false, got %v", output["truncated"])
+	}
+
+	resources := output["resources"].([]any)
+	var order []string
+	for _, r := range resources {
+		entry := r.(map[string]any)
+		order = append(order, fmt.Sprintf("%s/%s/%s", entry["namespace"], entry["resource"], entry["name"]))
+	}
+	wantOrder := []string{"dev/deployments/api", "prod/secrets/db-creds", "prod/configmaps/settings"}
+	if strings.Join(order, ",") != strings.Join(wantOrder, ",") {
+		t.Fatalf("Expected resources %v, got %v", wantOrder, order)
+	}
+
+	secret := resources[1].(map[string]any)
+	if secret["mutations"].(float64) != 3 {
+		t.Errorf("Expected 3 mutations on the secret, got %v", secret["mutations"])
+	}
+	if secret["failed"].(float64) != 1 {
+		t.Errorf("Expected 1 failed request on the secret, got %v", secret["failed"])
+	}
+	verbs := secret["verbs"].(map[string]any)
+	if verbs["delete"].(float64) != 2 || verbs["create"].(float64) != 1 {
+		t.Errorf("Expected delete=2 and create=1, got %v", verbs)
+	}
+	if secret["firstSeen"] != "2026-10-16T09:00:00Z" || secret["lastSeen"] != "2026-10-16T11:00:00Z" {
+		t.Errorf("Expected secret seen from 09:00 to 11:00, got %v to %v", secret["firstSeen"], secret["lastSeen"])
+	}
+
+	t.Log("✓ get_actor_blast_radius works correctly")
+}
+
+func TestGetActorBlastRadiusIncludeReads(t *testing.T) {
+	client := newMockClient()
+
+	var gotFilter string
+	var gotMutationsOnly bool
+	client.auditLogQueries.createFunc = func(ctx context.Context, query *v1alpha1.AuditLogQuery, opts metav1.CreateOptions) (*v1alpha1.AuditLogQuery, error) {
+		gotFilter = query.Spec.Filter
+		gotMutationsOnly = query.Spec.MutationsOnly
+		return &v1alpha1.AuditLogQuery{}, nil
+	}
+
+	provider := createTestProvider(client)
+
+	_, _, err := provider.handleGetActorBlastRadius(context.Background(), nil, GetActorBlastRadiusArgs{
+		Username:     "o'brien@example.com",
+		IncludeReads: true,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Every verb is counted, including impersonate, escalate, bind and proxy
+	if gotFilter != `user.username == 'o\'brien@example.com'` {
+		t.Errorf("Expected only the escaped username in filter, got %q", gotFilter)
+	}
+	if gotMutationsOnly {
+		t.Error("Expected reads not to be excluded by mutationsOnly")
+	}
+}
+
+func TestGetActorBlastRadiusRequiresUser(t *testing.T) {
+	client := newMockClient()
+	provider := createTestProvider(client)
+
+	result, _, _ := provider.handleGetActorBlastRadius(context.Background(), nil, GetActorBlastRadiusArgs{})
+
+	if !result.IsError {
+		t.Error("Expected error when username is not provided")
+	}
+
+	t.Log("✓ get_actor_blast_radius validates required fields")
+}
+
+func TestGetFailedAuthAttempts(t *testing.T) {
+	client := newMockClient()
+
+	base := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	event := func(code int32, username, sourceIP, userAgent, message string, offset time.Duration) auditv1.Event {
+		return auditv1.Event{
+			Verb:                     "get",
+			User:                     authnv1.UserInfo{Username: username},
+			SourceIPs:                []string{sourceIP, "10.0.0.1"},
+			UserAgent:                userAgent,
+			ResponseStatus:           &metav1.Status{Code: code, Message: message},
+			RequestReceivedTimestamp: metav1.NewMicroTime(base.Add(offset)),
+		}
+	}
+
+	pages := map[string]v1alpha1.AuditLogQueryStatus{
+		"": {
+			Results: []auditv1.Event{
+				event(401, "", "203.0.113.7", "curl/8.0", "", 3*time.Minute),
+				event(403, "alice@example.com", "203.0.113.7", "curl/8.0", "secrets is forbidden", 2*time.Minute),
+				event(403, "bob@example.com", "203.0.113.7", "python-requests", "secrets is forbidden", time.Minute),
+				event(403, "carol@example.com", "198.51.100.2", "kubectl", "secrets is forbidden", 0),
+			},
+			Continue:           "page-2",
+			EffectiveStartTime: "2026-10-15T12:00:00Z",
+			EffectiveEndTime:   "2026-10-16T12:00:00Z",
+		},
+		"page-2": {
+			Results: []auditv1.Event{
+				event(403, "carol@example.com", "198.51.100.2", "kubectl", "secrets is forbidden", -time.Minute),
+				event(403, "carol@example.com", "198.51.100.2", "kubectl", "secrets is forbidden", -2*time.Minute),
+				event(403, "dave@example.com", "198.51.100.9", "kubectl",
+					`admission webhook "policy.example.com" denied the request: missing label`, 0),
+			},
+		},
+	}
+
+	var filters []string
+	client.auditLogQueries.createFunc = func(ctx context.Context, query *v1alpha1.AuditLogQuery, opts metav1.CreateOptions) (*v1alpha1.AuditLogQuery, error) {
+		filters = append(filters, query.Spec.Filter)
+		return &v1alpha1.AuditLogQuery{Status: pages[query.Spec.Continue]}, nil
+	}
+
+	provider := createTestProvider(client)
+
+	result, _, err := provider.handleGetFailedAuthAttempts(context.Background(), nil, GetFailedAuthAttemptsArgs{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(filters) != 2 {
+		t.Fatalf("Expected two pages to be fetched, got %d", len(filters))
+	}
+	if filters[0] != "responseStatus.code in [401, 403]" {
+		t.Errorf("Unexpected filter %q", filters[0])
+	}
+
+	output := parseJSONResult(t, result)
+
+	if output["authenticationFailures"].(float64) != 1 {
+		t.Errorf("Expected authenticationFailures=1, got %v", output["authenticationFailures"])
+	}
+	if output["authorizationFailures"].(float64) != 5 {
+		t.Errorf("Expected authorizationFailures=5, got %v", output["authorizationFailures"])
+	}
+	if output["excludedAdmissionDenials"].(float64) != 1 {
+		t.Errorf("Expected excludedAdmissionDenials=1, got %v", output["excludedAdmissionDenials"])
+	}
+
+	sources := output["bySourceIP"].([]any)
+	if len(sources) != 2 {
+		t.Fatalf("Expected 2 source IPs, got %d", len(sources))
+	}
+	// Equal failure counts fall back to source IP order
+	source := sources[1].(map[string]any)
+	if source["sourceIP"] != "203.0.113.7" || source["failures"].(float64) != 3 || source["distinctUsernames"].(float64) != 3 {
+		t.Errorf("Unexpected source: %v", source)
+	}
+	if sources[0].(map[string]any)["sourceIP"] != "198.51.100.2" {
+		t.Errorf("Expected 198.51.100.2 first, got %v", sources[0])
+	}
+	if source["usernames"].(map[string]any)["<unauthenticated>"].(float64) != 1 {
+		t.Errorf("Expected the 401 to be attributed to <unauthenticated>, got %v", source["usernames"])
+	}
+	if agents := source["userAgents"].([]any); len(agents) != 2 || agents[0] != "curl/8.0" {
+		t.Errorf("Expected curl/8.0 as the top user agent, got %v", agents)
+	}
+	if source["firstSeen"] != "2026-10-16T09:01:00Z" || source["lastSeen"] != "2026-10-16T09:03:00Z" {
+		t.Errorf("Unexpected first/last seen: %v / %v", source["firstSeen"], source["lastSeen"])
+	}
+
+	users := output["byUsername"].([]any)
+	carol := users[0].(map[string]any)
+	if carol["username"] != "carol@example.com" || carol["failures"].(float64) != 3 {
+		t.Errorf("Expected carol@example.com first with 3 failures, got %v", carol)
+	}
+
+	suspicious := output["suspiciousSources"].([]any)
+	if len(suspicious) != 1 || suspicious[0].(map[string]any)["sourceIP"] != "203.0.113.7" {
+		t.Errorf("Expected only 203.0.113.7 to be suspicious, got %v", suspicious)
+	}
+
+	t.Log("✓ get_failed_auth_attempts groups failures and flags credential stuffing")
+}
+
+func TestGetFailedAuthAttemptsUsername(t *testing.T) {
+	client := newMockClient()
+
+	var filter string
+	client.auditLogQueries.createFunc = func(ctx context.Context, query *v1alpha1.AuditLogQuery, opts metav1.CreateOptions) (*v1alpha1.AuditLogQuery, error) {
+		filter = query.Spec.Filter
+		return &v1alpha1.AuditLogQuery{}, nil
+	}
+
+	provider := createTestProvider(client)
+
+	result, _, err := provider.handleGetFailedAuthAttempts(context.Background(), nil, GetFailedAuthAttemptsArgs{
+		Username:             "o'brien@example.com",
+		MinDistinctUsernames: 1,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := `responseStatus.code in [401, 403] && user.username == 'o\'brien@example.com'`
+	if filter != want {
+		t.Errorf("Expected filter %q, got %q", want, filter)
+	}
+
+	output := parseJSONResult(t, result)
+	if len(output["suspiciousSources"].([]any)) != 0 {
+		t.Errorf("Expected no suspicious sources, got %v", output["suspiciousSources"])
+	}
+}
+
 func TestGetActivityTimeline(t *testing.T) {
 	client := newMockClient()
 	provider := createTestProvider(client)
@@ -882,6 +2107,133 @@ func TestGetActivityTimeline(t *testing.T) {
 	t.Log("✓ get_activity_timeline works correctly")
 }
 
+func TestGetActivityTimelineSampled(t *testing.T) {
+	client := newMockClient()
+	provider := createTestProvider(client)
+
+	// Default mock returns a complete result set
+	result, _, err := provider.handleGetActivityTimeline(context.Background(), nil, GetActivityTimelineArgs{StartTime: "now-7d"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	output := parseJSONResult(t, result)
+	if output["sampled"] != false {
+		t.Errorf("Expected sampled=false for complete results, got %v", output["sampled"])
+	}
+	if _, ok := output["note"]; ok {
+		t.Errorf("Expected no note for complete results, got %v", output["note"])
+	}
+
+	// A continue token indicates the results were truncated
+	client.activityQueries.createFunc = func(ctx context.Context, query *v1alpha1.ActivityQuery, opts metav1.CreateOptions) (*v1alpha1.ActivityQuery, error) {
+		return &v1alpha1.ActivityQuery{
+			Status: v1alpha1.ActivityQueryStatus{
+				Results: []v1alpha1.Activity{
+					{ObjectMeta: metav1.ObjectMeta{Name: "activity-1", CreationTimestamp: metav1.NewTime(time.Now())}},
+				},
+				Continue: "next-page",
+			},
+		}, nil
+	}
+
+	result, _, err = provider.handleGetActivityTimeline(context.Background(), nil, GetActivityTimelineArgs{StartTime: "now-7d"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	output = parseJSONResult(t, result)
+	if output["sampled"] != true {
+		t.Errorf("Expected sampled=true for truncated results, got %v", output["sampled"])
+	}
+	if output["note"] == nil {
+		t.Error("Expected a lower-bound note for truncated results")
+	}
+
+	t.Log("✓ get_activity_timeline reports truncated counts as sampled")
+}
+
+func TestGetActivityTimelineBucketLimit(t *testing.T) {
+	client := newMockClient()
+	provider := createTestProvider(client)
+
+	// Auto sizing picks the finest bucket that fits the default limit of 500.
+	autoCases := []struct {
+		startTime string
+		want      string
+	}{
+		{"now-7d", "hour"},
+		{"now-90d", "day"},
+		{"now-730d", "week"},
+	}
+	for _, tc := range autoCases {
+		result, _, err := provider.handleGetActivityTimeline(context.Background(), nil, GetActivityTimelineArgs{StartTime: tc.startTime, BucketSize: "auto"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		output := parseJSONResult(t, result)
+		if output["bucketSize"] != tc.want {
+			t.Errorf("startTime=%s: expected bucketSize=%s, got %v", tc.startTime, tc.want, output["bucketSize"])
+		}
+	}
+
+	// An explicit size that exceeds the limit is rejected before querying.
+	queried := false
+	client.activityQueries.createFunc = func(ctx context.Context, query *v1alpha1.ActivityQuery, opts metav1.CreateOptions) (*v1alpha1.ActivityQuery, error) {
+		queried = true
+		return query, nil
+	}
+	result, _, err := provider.handleGetActivityTimeline(context.Background(), nil, GetActivityTimelineArgs{StartTime: "now-90d", BucketSize: "hour"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !result.IsError {
+		t.Error("Expected hourly buckets over 90 days to be rejected")
+	}
+	if queried {
+		t.Error("Expected no query for a rejected bucket size")
+	}
+
+	result, _, err = provider.handleGetActivityTimeline(context.Background(), nil, GetActivityTimelineArgs{StartTime: "now-7d", BucketSize: "minute"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !result.IsError {
+		t.Error("Expected an unknown bucket size to be rejected")
+	}
+
+	// A configured limit applies to auto sizing too.
+	provider.SetMaxTimelineBuckets(24)
+	result, _, err = provider.handleGetActivityTimeline(context.Background(), nil, GetActivityTimelineArgs{StartTime: "now-7d"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	output := parseJSONResult(t, result)
+	if output["bucketSize"] != "day" {
+		t.Errorf("Expected bucketSize=day with a limit of 24, got %v", output["bucketSize"])
+	}
+}
+
+func TestTimelineBucketStart(t *testing.T) {
+	// Wednesday afternoon
+	ts := time.Date(2026, 3, 18, 14, 35, 0, 0, time.UTC)
+
+	cases := map[string]string{
+		"hour": "2026-03-18T14:00:00Z",
+		"day":  "2026-03-18T00:00:00Z",
+		"week": "2026-03-16T00:00:00Z",
+	}
+	for size, want := range cases {
+		if got := timelineBucketStart(ts, size).Format(time.RFC3339); got != want {
+			t.Errorf("%s: expected %s, got %s", size, want, got)
+		}
+	}
+
+	// A Sunday belongs to the week that started the previous Monday.
+	sunday := time.Date(2026, 3, 22, 23, 0, 0, 0, time.UTC)
+	if got := timelineBucketStart(sunday, "week").Format(time.RFC3339); got != "2026-03-16T00:00:00Z" {
+		t.Errorf("Expected Sunday to fall in the week of 2026-03-16, got %s", got)
+	}
+}
+
 func TestSummarizeRecentActivity(t *testing.T) {
 	client := newMockClient()
 
@@ -915,139 +2267,167 @@ func TestSummarizeRecentActivity(t *testing.T) {
 						},
 					},
 				},
-				EffectiveStartTime: "2024-01-01T00:00:00Z",
-				EffectiveEndTime:   "2024-01-07T00:00:00Z",
+				EffectiveStartTime: "2024-01-01T00:00:00Z",
+				EffectiveEndTime:   "2024-01-07T00:00:00Z",
+			},
+		}, nil
+	}
+
+	provider := createTestProvider(client)
+
+	args := SummarizeRecentActivityArgs{
+		StartTime: "now-24h",
+		TopN:      5,
+	}
+
+	result, _, err := provider.handleSummarizeRecentActivity(context.Background(), nil, args)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	output := parseJSONResult(t, result)
+
+	if output["totalActivities"].(float64) != 2 {
+		t.Errorf("Expected totalActivities=2, got %v", output["totalActivities"])
+	}
+
+	if output["humanChanges"].(float64) != 1 {
+		t.Errorf("Expected humanChanges=1, got %v", output["humanChanges"])
+	}
+
+	if output["systemChanges"].(float64) != 1 {
+		t.Errorf("Expected systemChanges=1, got %v", output["systemChanges"])
+	}
+
+	highlights := output["highlights"].([]any)
+	if len(highlights) == 0 {
+		t.Error("Expected highlights")
+	}
+
+	structured := output["structuredHighlights"].([]any)
+	if len(structured) != len(highlights) {
+		t.Fatalf("Expected %d structured highlights, got %d", len(highlights), len(structured))
+	}
+	for i, h := range structured {
+		if h.(map[string]any)["text"] != highlights[i] {
+			t.Errorf("Structured highlight %d text = %v, want %v", i, h.(map[string]any)["text"], highlights[i])
+		}
+	}
+
+	total := structured[0].(map[string]any)
+	if total["type"] != "total_activities" || total["count"].(float64) != 2 {
+		t.Errorf("Expected total_activities highlight with count 2, got %v", total)
+	}
+	if details := total["details"].(map[string]any); details["human"].(float64) != 1 || details["system"].(float64) != 1 {
+		t.Errorf("Expected human=1 system=1 details, got %v", details)
+	}
+
+	topActor := structured[1].(map[string]any)
+	if topActor["type"] != "top_actor" || topActor["count"].(float64) != 1 || topActor["metric"] != "activities" {
+		t.Errorf("Expected top_actor highlight, got %v", topActor)
+	}
+
+	topKind := structured[2].(map[string]any)
+	if topKind["type"] != "top_resource_kind" || topKind["name"] != "Pod" || topKind["count"].(float64) != 2 {
+		t.Errorf("Expected top_resource_kind highlight for Pod with count 2, got %v", topKind)
+	}
+
+	t.Log("✓ summarize_recent_activity works correctly")
+}
+
+func TestSummarizeRecentActivityDeletionsAndChangeSource(t *testing.T) {
+	client := newMockClient()
+
+	var activityFilter string
+	client.activityQueries.createFunc = func(ctx context.Context, query *v1alpha1.ActivityQuery, opts metav1.CreateOptions) (*v1alpha1.ActivityQuery, error) {
+		activityFilter = query.Spec.Filter
+		return &v1alpha1.ActivityQuery{}, nil
+	}
+	var auditFilter string
+	client.auditLogQueries.createFunc = func(ctx context.Context, query *v1alpha1.AuditLogQuery, opts metav1.CreateOptions) (*v1alpha1.AuditLogQuery, error) {
+		auditFilter = query.Spec.Filter
+		return &v1alpha1.AuditLogQuery{
+			Status: v1alpha1.AuditLogQueryStatus{
+				Results: []auditv1.Event{
+					{Verb: "delete", User: authnv1.UserInfo{Username: "alice"}, ObjectRef: &auditv1.ObjectReference{Resource: "configmaps"}},
+					{Verb: "deletecollection", User: authnv1.UserInfo{Username: "alice"}, ObjectRef: &auditv1.ObjectReference{Resource: "domains", APIGroup: "networking.datumapis.com"}},
+				},
 			},
 		}, nil
 	}
 
 	provider := createTestProvider(client)
 
-	args := SummarizeRecentActivityArgs{
-		StartTime: "now-24h",
-		TopN:      5,
-	}
-
-	result, _, err := provider.handleSummarizeRecentActivity(context.Background(), nil, args)
+	result, _, err := provider.handleSummarizeRecentActivity(context.Background(), nil, SummarizeRecentActivityArgs{
+		StartTime:    "now-24h",
+		ChangeSource: "human",
+	})
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
 
-	output := parseJSONResult(t, result)
-
-	if output["totalActivities"].(float64) != 2 {
-		t.Errorf("Expected totalActivities=2, got %v", output["totalActivities"])
+	if activityFilter != "spec.changeSource == 'human'" {
+		t.Errorf("Expected the change source filter, got %q", activityFilter)
 	}
-
-	if output["humanChanges"].(float64) != 1 {
-		t.Errorf("Expected humanChanges=1, got %v", output["humanChanges"])
+	if !strings.Contains(auditFilter, "verb in ['delete', 'deletecollection']") {
+		t.Errorf("Expected a deletion filter, got %q", auditFilter)
 	}
 
-	if output["systemChanges"].(float64) != 1 {
-		t.Errorf("Expected systemChanges=1, got %v", output["systemChanges"])
+	output := parseJSONResult(t, result)
+	deletions := output["deletions"].(map[string]any)
+	if deletions["total"].(float64) != 2 {
+		t.Errorf("Expected 2 deletions, got %v", deletions["total"])
+	}
+	topActors := deletions["topActors"].([]any)
+	if len(topActors) != 1 || topActors[0].(map[string]any)["name"] != "alice" {
+		t.Errorf("Expected alice as the only deleter, got %v", topActors)
 	}
 
-	highlights := output["highlights"].([]any)
-	if len(highlights) == 0 {
-		t.Error("Expected highlights")
+	structured := output["structuredHighlights"].([]any)
+	last := structured[len(structured)-1].(map[string]any)
+	if last["type"] != "deletions" || last["count"].(float64) != 2 {
+		t.Errorf("Expected a deletions highlight, got %v", last)
 	}
 
-	t.Log("✓ summarize_recent_activity works correctly")
+	result, _, err = provider.handleSummarizeRecentActivity(context.Background(), nil, SummarizeRecentActivityArgs{
+		StartTime:    "now-24h",
+		ChangeSource: "robots",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !result.IsError {
+		t.Error("Expected an invalid change source to be rejected")
+	}
 }
 
 func TestCompareActivityPeriods(t *testing.T) {
 	client := newMockClient()
 
-	callCount := 0
-	client.activityQueries.createFunc = func(ctx context.Context, query *v1alpha1.ActivityQuery, opts metav1.CreateOptions) (*v1alpha1.ActivityQuery, error) {
-		callCount++
-		now := metav1.NewTime(time.Now())
+	// Counts come from facet queries, so totals past the old 1000 activity
+	// sample are exact.
+	var queries []*v1alpha1.ActivityFacetQuery
+	client.activityFacetQueries.createFunc = func(ctx context.Context, query *v1alpha1.ActivityFacetQuery, opts metav1.CreateOptions) (*v1alpha1.ActivityFacetQuery, error) {
+		queries = append(queries, query)
 
-		var results []v1alpha1.Activity
-		if callCount == 1 {
-			// Baseline: 2 activities
-			results = []v1alpha1.Activity{
-				{
-					ObjectMeta: metav1.ObjectMeta{Name: "baseline-1", CreationTimestamp: now},
-					Spec: v1alpha1.ActivitySpec{
-						Summary:      "alice created pod test-pod",
-						ChangeSource: "human",
-						Actor:        v1alpha1.ActivityActor{Name: "alice", Type: "user"},
-						Resource:     v1alpha1.ActivityResource{Kind: "Pod", APIVersion: "v1"},
-						Tenant:       v1alpha1.ActivityTenant{Type: "global", Name: "default"},
-						Origin:       v1alpha1.ActivityOrigin{Type: "audit", ID: "test-1"},
-					},
-				},
-				{
-					ObjectMeta: metav1.ObjectMeta{Name: "baseline-2", CreationTimestamp: now},
-					Spec: v1alpha1.ActivitySpec{
-						Summary:      "alice updated pod test-pod",
-						ChangeSource: "human",
-						Actor:        v1alpha1.ActivityActor{Name: "alice", Type: "user"},
-						Resource:     v1alpha1.ActivityResource{Kind: "Pod", APIVersion: "v1"},
-						Tenant:       v1alpha1.ActivityTenant{Type: "global", Name: "default"},
-						Origin:       v1alpha1.ActivityOrigin{Type: "audit", ID: "test-2"},
-					},
-				},
-			}
-		} else {
-			// Comparison: 4 activities (100% increase)
-			results = []v1alpha1.Activity{
-				{
-					ObjectMeta: metav1.ObjectMeta{Name: "compare-1", CreationTimestamp: now},
-					Spec: v1alpha1.ActivitySpec{
-						Summary:      "alice created pod test-pod",
-						ChangeSource: "human",
-						Actor:        v1alpha1.ActivityActor{Name: "alice", Type: "user"},
-						Resource:     v1alpha1.ActivityResource{Kind: "Pod", APIVersion: "v1"},
-						Tenant:       v1alpha1.ActivityTenant{Type: "global", Name: "default"},
-						Origin:       v1alpha1.ActivityOrigin{Type: "audit", ID: "test-3"},
-					},
-				},
-				{
-					ObjectMeta: metav1.ObjectMeta{Name: "compare-2", CreationTimestamp: now},
-					Spec: v1alpha1.ActivitySpec{
-						Summary:      "alice updated pod test-pod",
-						ChangeSource: "human",
-						Actor:        v1alpha1.ActivityActor{Name: "alice", Type: "user"},
-						Resource:     v1alpha1.ActivityResource{Kind: "Pod", APIVersion: "v1"},
-						Tenant:       v1alpha1.ActivityTenant{Type: "global", Name: "default"},
-						Origin:       v1alpha1.ActivityOrigin{Type: "audit", ID: "test-4"},
-					},
-				},
-				{
-					ObjectMeta: metav1.ObjectMeta{Name: "compare-3", CreationTimestamp: now},
-					Spec: v1alpha1.ActivitySpec{
-						Summary:      "bob created deployment test-deploy",
-						ChangeSource: "human",
-						Actor:        v1alpha1.ActivityActor{Name: "bob", Type: "user"},
-						Resource:     v1alpha1.ActivityResource{Kind: "Deployment", APIVersion: "apps/v1"},
-						Tenant:       v1alpha1.ActivityTenant{Type: "global", Name: "default"},
-						Origin:       v1alpha1.ActivityOrigin{Type: "audit", ID: "test-5"},
-					},
-				},
-				{
-					ObjectMeta: metav1.ObjectMeta{Name: "compare-4", CreationTimestamp: now},
-					Spec: v1alpha1.ActivitySpec{
-						Summary:      "bob updated deployment test-deploy",
-						ChangeSource: "human",
-						Actor:        v1alpha1.ActivityActor{Name: "bob", Type: "user"},
-						Resource:     v1alpha1.ActivityResource{Kind: "Deployment", APIVersion: "apps/v1"},
-						Tenant:       v1alpha1.ActivityTenant{Type: "global", Name: "default"},
-						Origin:       v1alpha1.ActivityOrigin{Type: "audit", ID: "test-6"},
-					},
-				},
+		values := map[string][]v1alpha1.FacetValue{
+			"spec.changeSource":  {{Value: "human", Count: 1500}},
+			"spec.actor.name":    {{Value: "alice", Count: 1500}},
+			"spec.resource.kind": {{Value: "Pod", Count: 1000}, {Value: "Deployment", Count: 500}},
+		}
+		if len(queries) == 2 {
+			values = map[string][]v1alpha1.FacetValue{
+				"spec.changeSource":  {{Value: "human", Count: 2000}, {Value: "system", Count: 1000}},
+				"spec.actor.name":    {{Value: "bob", Count: 1900}, {Value: "alice", Count: 1100}},
+				"spec.resource.kind": {{Value: "Pod", Count: 400}, {Value: "Deployment", Count: 2600}},
 			}
 		}
 
-		return &v1alpha1.ActivityQuery{
-			ObjectMeta: metav1.ObjectMeta{Name: "test-compare"},
-			Status: v1alpha1.ActivityQueryStatus{
-				Results:            results,
-				EffectiveStartTime: query.Spec.StartTime,
-				EffectiveEndTime:   query.Spec.EndTime,
-			},
-		}, nil
+		facets := make([]v1alpha1.FacetResult, 0, len(query.Spec.Facets))
+		for _, spec := range query.Spec.Facets {
+			facets = append(facets, v1alpha1.FacetResult{Field: spec.Field, Values: values[spec.Field]})
+		}
+		return &v1alpha1.ActivityFacetQuery{Status: v1alpha1.ActivityFacetQueryStatus{Facets: facets}}, nil
 	}
 
 	provider := createTestProvider(client)
@@ -1066,14 +2446,38 @@ func TestCompareActivityPeriods(t *testing.T) {
 
 	output := parseJSONResult(t, result)
 
+	if len(queries) != 2 {
+		t.Fatalf("Expected one facet query per period, got %d", len(queries))
+	}
+	if _, err := time.Parse(time.RFC3339, queries[0].Spec.TimeRange.Start); err != nil {
+		t.Errorf("Expected relative times resolved before querying, got %q", queries[0].Spec.TimeRange.Start)
+	}
+
 	baseline := output["baseline"].(map[string]any)
-	if baseline["count"].(float64) != 2 {
-		t.Errorf("Expected baseline count=2, got %v", baseline["count"])
+	if baseline["count"].(float64) != 1500 {
+		t.Errorf("Expected baseline count=1500, got %v", baseline["count"])
+	}
+	if baseline["start"] != queries[0].Spec.TimeRange.Start {
+		t.Errorf("Expected baseline start %q, got %v", queries[0].Spec.TimeRange.Start, baseline["start"])
 	}
 
 	comparison := output["comparison"].(map[string]any)
-	if comparison["count"].(float64) != 4 {
-		t.Errorf("Expected comparison count=4, got %v", comparison["count"])
+	if comparison["count"].(float64) != 3000 {
+		t.Errorf("Expected comparison count=3000, got %v", comparison["count"])
+	}
+
+	newActors := output["newInComparison"].([]any)
+	if len(newActors) != 1 || newActors[0].(map[string]any)["name"] != "bob" {
+		t.Errorf("Expected bob as the only new actor, got %v", newActors)
+	}
+
+	increased := output["increasedActivity"].([]any)
+	if len(increased) != 1 || increased[0].(map[string]any)["name"] != "Deployment" {
+		t.Errorf("Expected Deployment as increased, got %v", increased)
+	}
+	decreased := output["decreasedActivity"].([]any)
+	if len(decreased) != 1 || decreased[0].(map[string]any)["name"] != "Pod" {
+		t.Errorf("Expected Pod as decreased, got %v", decreased)
 	}
 
 	changePercent := output["changePercent"].(float64)
@@ -1089,6 +2493,237 @@ func TestCompareActivityPeriods(t *testing.T) {
 	t.Log("✓ compare_activity_periods works correctly")
 }
 
+func TestGetSuspiciousActivity(t *testing.T) {
+	client := newMockClient()
+
+	activitiesFor := func(actor string, n int) []v1alpha1.Activity {
+		results := make([]v1alpha1.Activity, n)
+		for i := range results {
+			results[i] = v1alpha1.Activity{
+				Spec: v1alpha1.ActivitySpec{
+					ChangeSource: "human",
+					Actor:        v1alpha1.ActivityActor{Name: actor, Type: "user"},
+					Resource:     v1alpha1.ActivityResource{Kind: "Deployment"},
+				},
+			}
+		}
+		return results
+	}
+	eventsFor := func(user, verb string, code int32, n int) []auditv1.Event {
+		events := make([]auditv1.Event, n)
+		for i := range events {
+			events[i] = auditv1.Event{
+				Verb:           verb,
+				User:           authnv1.UserInfo{Username: user},
+				ResponseStatus: &metav1.Status{Code: code},
+			}
+		}
+		return events
+	}
+
+	client.activityQueries.createFunc = func(ctx context.Context, query *v1alpha1.ActivityQuery, opts metav1.CreateOptions) (*v1alpha1.ActivityQuery, error) {
+		var results []v1alpha1.Activity
+		if query.GenerateName == "mcp-suspicious-baseline-" {
+			results = activitiesFor("alice", 2)
+		} else {
+			// alice grows 6x; mallory and a controller are new
+			results = append(activitiesFor("alice", 12), activitiesFor("mallory", 3)...)
+			results = append(results, activitiesFor("system:serviceaccount:kube-system:gc", 20)...)
+		}
+		return &v1alpha1.ActivityQuery{Status: v1alpha1.ActivityQueryStatus{Results: results}}, nil
+	}
+
+	var auditFilter string
+	client.auditLogQueries.createFunc = func(ctx context.Context, query *v1alpha1.AuditLogQuery, opts metav1.CreateOptions) (*v1alpha1.AuditLogQuery, error) {
+		auditFilter = query.Spec.Filter
+		var results []auditv1.Event
+		if query.GenerateName == "mcp-suspicious-audit-baseline-" {
+			results = eventsFor("bob", "delete", 200, 1)
+		} else {
+			results = append(eventsFor("bob", "delete", 200, 12), eventsFor("carol", "get", 403, 10)...)
+		}
+		return &v1alpha1.AuditLogQuery{Status: v1alpha1.AuditLogQueryStatus{Results: results}}, nil
+	}
+
+	provider := createTestProvider(client)
+
+	result, _, err := provider.handleGetSuspiciousActivity(context.Background(), nil, GetSuspiciousActivityArgs{Window: "1d"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	output := parseJSONResult(t, result)
+
+	if auditFilter != "verb in ['delete', 'deletecollection'] || (responseStatus.code >= 403 && responseStatus.code <= 403)" {
+		t.Errorf("Unexpected audit filter: %s", auditFilter)
+	}
+
+	findings := output["findings"].([]any)
+	if len(findings) != 4 {
+		t.Fatalf("Expected 4 findings, got %d: %v", len(findings), findings)
+	}
+
+	// Highest severity and largest count first; system actors are excluded
+	want := []struct{ findingType, severity, actor string }{
+		{"deletionSpike", "high", ""},
+		{"volumeSpike", "high", "alice"},
+		{"forbiddenBurst", "medium", "carol"},
+		{"firstTimeActor", "low", "mallory"},
+	}
+	for i, w := range want {
+		f := findings[i].(map[string]any)
+		actor, _ := f["actor"].(string)
+		if f["type"] != w.findingType || f["severity"] != w.severity || actor != w.actor {
+			t.Errorf("findings[%d] = %v %v %q, want %s %s %q", i, f["type"], f["severity"], actor, w.findingType, w.severity, w.actor)
+		}
+	}
+
+	deletion := findings[0].(map[string]any)
+	topActors := deletion["details"].(map[string]any)["topActors"].([]any)
+	if top := topActors[0].(map[string]any); top["name"] != "bob" || top["count"].(float64) != 12 {
+		t.Errorf("Expected bob as top deleter, got %v", top)
+	}
+
+	bySeverity := output["bySeverity"].(map[string]any)
+	if bySeverity["high"].(float64) != 2 || bySeverity["medium"].(float64) != 1 || bySeverity["low"].(float64) != 1 {
+		t.Errorf("Unexpected severity counts: %v", bySeverity)
+	}
+
+	t.Log("✓ get_suspicious_activity ranks findings by severity")
+}
+
+func TestGetSuspiciousActivityInvalidWindow(t *testing.T) {
+	provider := createTestProvider(newMockClient())
+
+	result, _, err := provider.handleGetSuspiciousActivity(context.Background(), nil, GetSuspiciousActivityArgs{Window: "fortnight"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !result.IsError {
+		t.Error("Expected error result for an invalid window")
+	}
+
+	t.Log("✓ get_suspicious_activity rejects invalid windows")
+}
+
+func TestGetChangeVelocity(t *testing.T) {
+	client := newMockClient()
+
+	// Counts by window, newest first: the current hour, then the baseline
+	counts := []int64{40, 10, 12, 8, 10, 11, 9}
+	var mu sync.Mutex
+	var filters []string
+	mutationsOnly := true
+	client.auditLogFacetsQueries.createFunc = func(ctx context.Context, query *v1alpha1.AuditLogFacetsQuery, opts metav1.CreateOptions) (*v1alpha1.AuditLogFacetsQuery, error) {
+		mu.Lock()
+		filters = append(filters, query.Spec.Filter)
+		mutationsOnly = mutationsOnly && query.Spec.MutationsOnly
+		mu.Unlock()
+
+		end, err := time.Parse(time.RFC3339, query.Spec.TimeRange.End)
+		if err != nil {
+			return nil, err
+		}
+		i := int(time.Since(end).Round(time.Hour) / time.Hour)
+		return &v1alpha1.AuditLogFacetsQuery{Status: v1alpha1.AuditLogFacetsQueryStatus{
+			Facets: []v1alpha1.FacetResult{{Field: "verb", Values: []v1alpha1.FacetValue{
+				{Value: "update", Count: counts[i] - 1},
+				{Value: "delete", Count: 1},
+			}}},
+		}}, nil
+	}
+
+	provider := createTestProvider(client)
+	result, _, err := provider.handleGetChangeVelocity(context.Background(), nil, GetChangeVelocityArgs{
+		Filter: "objectRef.namespace == 'production'",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	output := parseJSONResult(t, result)
+
+	if len(filters) != 7 {
+		t.Fatalf("Expected one facet query per window, got %d", len(filters))
+	}
+	if filters[0] != "objectRef.namespace == 'production'" {
+		t.Errorf("Expected the filter to be passed unchanged, got %q", filters[0])
+	}
+	if !mutationsOnly {
+		t.Error("Expected every window to count only mutations")
+	}
+
+	current := output["current"].(map[string]any)
+	if current["count"].(float64) != 40 {
+		t.Errorf("Expected current count=40, got %v", current["count"])
+	}
+	baseline := output["baseline"].(map[string]any)
+	windows := baseline["windows"].([]any)
+	if len(windows) != 6 || windows[0].(map[string]any)["count"].(float64) != 9 {
+		t.Errorf("Expected 6 baseline windows, oldest first, got %v", windows)
+	}
+	if baseline["mean"].(float64) != 10 {
+		t.Errorf("Expected baseline mean=10, got %v", baseline["mean"])
+	}
+	if output["ratio"].(float64) != 4 {
+		t.Errorf("Expected ratio=4, got %v", output["ratio"])
+	}
+	if output["level"] != "spike" {
+		t.Errorf("Expected level=spike, got %v", output["level"])
+	}
+	if verdict := output["verdict"].(string); !strings.HasPrefix(verdict, "4.0x normal") {
+		t.Errorf("Expected verdict to start with the ratio, got %q", verdict)
+	}
+
+	t.Log("✓ get_change_velocity works correctly")
+}
+
+func TestAssessVelocity(t *testing.T) {
+	tests := []struct {
+		name      string
+		current   int64
+		mean      float64
+		stddev    float64
+		wantLevel string
+	}{
+		{name: "spike", current: 40, mean: 10, stddev: 2, wantLevel: "spike"},
+		{name: "doubled but within noisy baseline", current: 20, mean: 10, stddev: 4, wantLevel: "elevated"},
+		{name: "ordinary variation", current: 12, mean: 10, stddev: 2, wantLevel: "normal"},
+		{name: "quiet", current: 2, mean: 10, stddev: 2, wantLevel: "quiet"},
+		{name: "too few changes", current: 6, mean: 1, stddev: 0.5, wantLevel: "normal"},
+		{name: "flat baseline doubled", current: 20, mean: 10, stddev: 0, wantLevel: "spike"},
+		{name: "flat baseline unchanged", current: 10, mean: 10, stddev: 0, wantLevel: "normal"},
+		{name: "empty baseline", current: 50, mean: 0, stddev: 0, wantLevel: "spike"},
+		{name: "no changes at all", current: 0, mean: 0, stddev: 0, wantLevel: "normal"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			level, _, _, verdict := assessVelocity(tt.current, tt.mean, tt.stddev, defaultVelocityMinEvents)
+			if level != tt.wantLevel {
+				t.Errorf("level = %q, want %q (verdict %q)", level, tt.wantLevel, verdict)
+			}
+		})
+	}
+}
+
+func TestGetChangeVelocityInvalidArgs(t *testing.T) {
+	provider := createTestProvider(newMockClient())
+
+	for _, args := range []GetChangeVelocityArgs{
+		{Window: "fortnight"},
+		{BaselineWindows: 1},
+		{BaselineWindows: maxVelocityBaselineWindows + 1},
+	} {
+		result, _, err := provider.handleGetChangeVelocity(context.Background(), nil, args)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !result.IsError {
+			t.Errorf("Expected error result for %+v", args)
+		}
+	}
+}
+
 func TestListActivityPolicies(t *testing.T) {
 	client := newMockClient()
 	provider := createTestProvider(client)
@@ -1118,6 +2753,51 @@ func TestListActivityPolicies(t *testing.T) {
 	t.Log("✓ list_activity_policies works correctly")
 }
 
+func TestListActivityPoliciesUnresolvedResource(t *testing.T) {
+	client := newMockClient()
+	client.activityPolicies.listFunc = func(ctx context.Context, opts metav1.ListOptions) (*v1alpha1.ActivityPolicyList, error) {
+		return &v1alpha1.ActivityPolicyList{
+			Items: []v1alpha1.ActivityPolicy{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "compute-workload"},
+					Spec: v1alpha1.ActivityPolicySpec{
+						Resource: v1alpha1.ActivityPolicyResource{APIGroup: "compute.datumapis.com", Kind: "Workload"},
+					},
+					Status: v1alpha1.ActivityPolicyStatus{
+						Conditions: []metav1.Condition{
+							{Type: "Ready", Status: metav1.ConditionTrue},
+							{
+								Type:    v1alpha1.ActivityPolicyConditionResourceResolved,
+								Status:  metav1.ConditionFalse,
+								Reason:  v1alpha1.ActivityPolicyReasonResourceNotFound,
+								Message: "Waiting for Workload in API group \"compute.datumapis.com\" to be registered",
+							},
+						},
+					},
+				},
+			},
+		}, nil
+	}
+	provider := createTestProvider(client)
+
+	result, _, err := provider.handleListActivityPolicies(context.Background(), nil, ListActivityPoliciesArgs{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	output := parseJSONResult(t, result)
+
+	policy := output["policies"].([]any)[0].(map[string]any)
+	if policy["status"] != v1alpha1.ActivityPolicyReasonResourceNotFound {
+		t.Errorf("Expected status=%s, got %v", v1alpha1.ActivityPolicyReasonResourceNotFound, policy["status"])
+	}
+	if policy["statusMessage"] == nil {
+		t.Error("Expected statusMessage explaining why the policy is inactive")
+	}
+
+	t.Log("✓ list_activity_policies surfaces unresolved resources")
+}
+
 func TestListActivityPoliciesWithFilter(t *testing.T) {
 	client := newMockClient()
 	provider := createTestProvider(client)
@@ -1189,6 +2869,96 @@ func TestPreviewActivityPolicy(t *testing.T) {
 // Test Tool Registration
 // =============================================================================
 
+func TestQueryEventsPagination(t *testing.T) {
+	client := newMockClient()
+
+	var specs []v1alpha1.EventQuerySpec
+	client.eventQueries.createFunc = func(ctx context.Context, query *v1alpha1.EventQuery, opts metav1.CreateOptions) (*v1alpha1.EventQuery, error) {
+		specs = append(specs, query.Spec)
+		query.Status.Results = []v1alpha1.EventRecord{{}}
+		if query.Spec.Continue == "" {
+			query.Status.Continue = "cursor-2"
+		}
+		return query, nil
+	}
+
+	provider := createTestProvider(client)
+	args := QueryEventsArgs{
+		StartTime: "now-1h",
+		EndTime:   "now",
+		Type:      "Warning",
+		Limit:     1,
+	}
+
+	result, _, err := provider.handleQueryEvents(context.Background(), nil, args)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	output := parseJSONResult(t, result)
+	if output["continue"] != "cursor-2" {
+		t.Fatalf("Expected continue=cursor-2, got %v", output["continue"])
+	}
+
+	args.ContinueToken = output["continue"].(string)
+	result, _, err = provider.handleQueryEvents(context.Background(), nil, args)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	output = parseJSONResult(t, result)
+	if output["continue"] != "" {
+		t.Errorf("Expected the last page to have no continue token, got %v", output["continue"])
+	}
+
+	if len(specs) != 2 || specs[1].Continue != "cursor-2" {
+		t.Fatalf("Expected the second query to carry the continue token, got %+v", specs)
+	}
+	if specs[0].FieldSelector != specs[1].FieldSelector || specs[1].FieldSelector != "type=Warning" {
+		t.Errorf("Expected identical field selectors across pages, got %q and %q", specs[0].FieldSelector, specs[1].FieldSelector)
+	}
+	if len(specs[0].Fields) == 0 {
+		t.Errorf("Expected query_events to request only the fields it reports")
+	}
+
+	t.Log("✓ query_events pages with continueToken")
+}
+
+func TestQueryEventsValueLists(t *testing.T) {
+	client := newMockClient()
+
+	var spec v1alpha1.EventQuerySpec
+	client.eventQueries.createFunc = func(ctx context.Context, query *v1alpha1.EventQuery, opts metav1.CreateOptions) (*v1alpha1.EventQuery, error) {
+		spec = query.Spec
+		return query, nil
+	}
+
+	provider := createTestProvider(client)
+	args := QueryEventsArgs{
+		StartTime:        "now-1h",
+		EndTime:          "now",
+		Reason:           "BackOff",
+		Reasons:          []string{"FailedScheduling", "FailedMount", "BackOff"},
+		Types:            []string{"Warning"},
+		SourceComponents: []string{"kubelet", "default-scheduler"},
+	}
+
+	if _, _, err := provider.handleQueryEvents(context.Background(), nil, args); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if got := strings.Join(spec.Reasons, ","); got != "BackOff,FailedScheduling,FailedMount" {
+		t.Errorf("Expected reason and reasons to be merged without repeats, got %q", got)
+	}
+	if got := strings.Join(spec.SourceComponents, ","); got != "kubelet,default-scheduler" {
+		t.Errorf("Expected source components to be sent as a list, got %q", got)
+	}
+	// A single value stays in the field selector
+	if spec.FieldSelector != "type=Warning" || spec.Types != nil {
+		t.Errorf("Expected a single type to use the field selector, got %q and %v", spec.FieldSelector, spec.Types)
+	}
+
+	t.Log("✓ query_events sends several values as lists")
+}
+
 func TestRegisterTools(t *testing.T) {
 	client := newMockClient()
 	provider := createTestProvider(client)
@@ -1225,31 +2995,6 @@ func TestIsSystemUser(t *testing.T) {
 	t.Log("✓ isSystemUser works correctly")
 }
 
-func TestGetTopN(t *testing.T) {
-	counts := map[string]int{
-		"a": 10,
-		"b": 50,
-		"c": 30,
-		"d": 5,
-	}
-
-	result := getTopN(counts, 2)
-
-	if len(result) != 2 {
-		t.Errorf("Expected 2 items, got %d", len(result))
-	}
-
-	if result[0]["name"] != "b" {
-		t.Errorf("Expected first item to be 'b', got %v", result[0]["name"])
-	}
-
-	if result[1]["name"] != "c" {
-		t.Errorf("Expected second item to be 'c', got %v", result[1]["name"])
-	}
-
-	t.Log("✓ getTopN works correctly")
-}
-
 func TestAbsFloat(t *testing.T) {
 	if absFloat(-5.0) != 5.0 {
 		t.Error("absFloat(-5.0) should be 5.0")